@@ -0,0 +1,14 @@
+CREATE TABLE audit_log (
+    id bigint NOT NULL AUTO_INCREMENT,
+    created_at timestamp NOT NULL,
+    actor varchar(100) NOT NULL,
+    request_id varchar(64),
+    entity varchar(20) NOT NULL,
+    entity_id int NOT NULL,
+    action varchar(20) NOT NULL,
+    before_data JSON,
+    after_data JSON,
+    PRIMARY KEY (id),
+    INDEX IDX_AUDIT_LOG_ENTITY (entity, entity_id, created_at),
+    INDEX IDX_AUDIT_LOG_CREATED_AT (created_at)
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE planets_history;
\ No newline at end of file
@@ -0,0 +1,18 @@
+CREATE TABLE planets_history (
+    id bigint NOT NULL AUTO_INCREMENT,
+    planet_id int NOT NULL,
+    valid_from timestamp NOT NULL,
+    valid_to timestamp NULL,
+    created_at timestamp NOT NULL,
+    updated_at timestamp NOT NULL,
+    deleted_at timestamp NULL,
+    name varchar(100) NOT NULL,
+    climates JSON NOT NULL,
+    terrains JSON NOT NULL,
+    PRIMARY KEY (id),
+    INDEX IDX_PLANETS_HISTORY_VALID (planet_id, valid_from, valid_to),
+    CONSTRAINT FK_PLANETS_HISTORY_PLANET_ID FOREIGN KEY (planet_id) REFERENCES planets(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+INSERT INTO planets_history (planet_id, valid_from, created_at, updated_at, deleted_at, name, climates, terrains)
+SELECT id, COALESCE(deleted_at, updated_at), created_at, updated_at, deleted_at, name, climates, terrains FROM planets;
\ No newline at end of file
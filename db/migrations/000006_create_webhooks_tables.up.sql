@@ -0,0 +1,28 @@
+CREATE TABLE webhooks (
+    id int NOT NULL AUTO_INCREMENT,
+    created_at timestamp NOT NULL,
+    updated_at timestamp NOT NULL,
+    url varchar(2048) NOT NULL,
+    secret varchar(255) NOT NULL,
+    events JSON NOT NULL,
+    active tinyint(1) NOT NULL DEFAULT 1,
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE webhook_deliveries (
+    id bigint NOT NULL AUTO_INCREMENT,
+    created_at timestamp NOT NULL,
+    updated_at timestamp NOT NULL,
+    webhook_id int NOT NULL,
+    event_id varchar(64) NOT NULL,
+    event varchar(50) NOT NULL,
+    payload JSON NOT NULL,
+    status varchar(20) NOT NULL,
+    attempts int NOT NULL DEFAULT 0,
+    next_attempt_at timestamp NOT NULL,
+    last_error varchar(1000),
+    delivered_at timestamp NULL,
+    PRIMARY KEY (id),
+    INDEX IDX_WEBHOOK_DELIVERIES_STATUS (status, next_attempt_at),
+    CONSTRAINT FK_WEBHOOK_DELIVERIES_WEBHOOK_ID FOREIGN KEY (webhook_id) REFERENCES webhooks(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
\ No newline at end of file
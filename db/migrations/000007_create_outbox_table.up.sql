@@ -0,0 +1,12 @@
+CREATE TABLE outbox (
+    id bigint NOT NULL AUTO_INCREMENT,
+    created_at timestamp NOT NULL,
+    event_id varchar(64) NOT NULL,
+    event varchar(50) NOT NULL,
+    entity varchar(20) NOT NULL,
+    entity_id int NOT NULL,
+    data JSON NOT NULL,
+    published_at timestamp NULL,
+    PRIMARY KEY (id),
+    INDEX IDX_OUTBOX_PUBLISHED_AT (published_at, id)
+);
\ No newline at end of file
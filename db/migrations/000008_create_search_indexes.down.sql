@@ -0,0 +1,7 @@
+ALTER TABLE films DROP INDEX FT_FILMS_SEARCH;
+
+ALTER TABLE planets DROP INDEX FT_PLANETS_ATTRIBUTES;
+
+ALTER TABLE planets DROP INDEX FT_PLANETS_NAME;
+
+ALTER TABLE planets DROP COLUMN search_attributes;
\ No newline at end of file
@@ -0,0 +1,9 @@
+ALTER TABLE planets ADD COLUMN search_attributes varchar(1000) GENERATED ALWAYS AS (
+    REPLACE(REPLACE(REPLACE(REPLACE(CONCAT(climates, ' ', terrains), '[', ''), ']', ''), '"', ''), ',', ' ')
+) STORED;
+
+ALTER TABLE planets ADD FULLTEXT INDEX FT_PLANETS_NAME (name) WITH PARSER ngram;
+
+ALTER TABLE planets ADD FULLTEXT INDEX FT_PLANETS_ATTRIBUTES (search_attributes) WITH PARSER ngram;
+
+ALTER TABLE films ADD FULLTEXT INDEX FT_FILMS_SEARCH (title, director) WITH PARSER ngram;
\ No newline at end of file
@@ -0,0 +1,19 @@
+ALTER TABLE films
+    DROP COLUMN producer,
+    DROP COLUMN opening_crawl;
+
+ALTER TABLE planets_history
+    DROP COLUMN population,
+    DROP COLUMN surface_water,
+    DROP COLUMN gravity,
+    DROP COLUMN diameter,
+    DROP COLUMN orbital_period,
+    DROP COLUMN rotation_period;
+
+ALTER TABLE planets
+    DROP COLUMN population,
+    DROP COLUMN surface_water,
+    DROP COLUMN gravity,
+    DROP COLUMN diameter,
+    DROP COLUMN orbital_period,
+    DROP COLUMN rotation_period;
\ No newline at end of file
@@ -0,0 +1,19 @@
+ALTER TABLE planets
+    ADD COLUMN rotation_period int NULL,
+    ADD COLUMN orbital_period int NULL,
+    ADD COLUMN diameter int NULL,
+    ADD COLUMN gravity varchar(100) NULL,
+    ADD COLUMN surface_water double NULL,
+    ADD COLUMN population bigint NULL;
+
+ALTER TABLE planets_history
+    ADD COLUMN rotation_period int NULL,
+    ADD COLUMN orbital_period int NULL,
+    ADD COLUMN diameter int NULL,
+    ADD COLUMN gravity varchar(100) NULL,
+    ADD COLUMN surface_water double NULL,
+    ADD COLUMN population bigint NULL;
+
+ALTER TABLE films
+    ADD COLUMN opening_crawl text NULL,
+    ADD COLUMN producer varchar(255) NULL;
\ No newline at end of file
@@ -0,0 +1,4 @@
+DROP TABLE planets_terrains;
+DROP TABLE planets_climates;
+DROP TABLE terrains;
+DROP TABLE climates;
\ No newline at end of file
@@ -0,0 +1,51 @@
+CREATE TABLE climates (
+    id int NOT NULL AUTO_INCREMENT,
+    name varchar(100) NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE KEY UN_CLIMATES_NAME (name)
+);
+
+CREATE TABLE terrains (
+    id int NOT NULL AUTO_INCREMENT,
+    name varchar(100) NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE KEY UN_TERRAINS_NAME (name)
+);
+
+CREATE TABLE planets_climates (
+    planet_id int NOT NULL,
+    climate_id int NOT NULL,
+    PRIMARY KEY (planet_id, climate_id),
+    INDEX IDX_PLANETS_CLIMATES_CLIMATE_ID (climate_id),
+    CONSTRAINT FK_PLANETS_CLIMATES_PLANET_ID FOREIGN KEY (planet_id) REFERENCES planets(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT FK_PLANETS_CLIMATES_CLIMATE_ID FOREIGN KEY (climate_id) REFERENCES climates(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE planets_terrains (
+    planet_id int NOT NULL,
+    terrain_id int NOT NULL,
+    PRIMARY KEY (planet_id, terrain_id),
+    INDEX IDX_PLANETS_TERRAINS_TERRAIN_ID (terrain_id),
+    CONSTRAINT FK_PLANETS_TERRAINS_PLANET_ID FOREIGN KEY (planet_id) REFERENCES planets(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT FK_PLANETS_TERRAINS_TERRAIN_ID FOREIGN KEY (terrain_id) REFERENCES terrains(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+INSERT IGNORE INTO climates (name)
+SELECT DISTINCT jt.name FROM planets
+INNER JOIN JSON_TABLE(planets.climates, '$[*]' COLUMNS (name varchar(100) PATH '$')) AS jt
+WHERE jt.name <> '';
+
+INSERT IGNORE INTO terrains (name)
+SELECT DISTINCT jt.name FROM planets
+INNER JOIN JSON_TABLE(planets.terrains, '$[*]' COLUMNS (name varchar(100) PATH '$')) AS jt
+WHERE jt.name <> '';
+
+INSERT IGNORE INTO planets_climates (planet_id, climate_id)
+SELECT planets.id, climates.id FROM planets
+INNER JOIN JSON_TABLE(planets.climates, '$[*]' COLUMNS (name varchar(100) PATH '$')) AS jt
+INNER JOIN climates ON climates.name = jt.name;
+
+INSERT IGNORE INTO planets_terrains (planet_id, terrain_id)
+SELECT planets.id, terrains.id FROM planets
+INNER JOIN JSON_TABLE(planets.terrains, '$[*]' COLUMNS (name varchar(100) PATH '$')) AS jt
+INNER JOIN terrains ON terrains.name = jt.name;
\ No newline at end of file
@@ -0,0 +1,2 @@
+DROP TABLE films_translations;
+DROP TABLE planets_translations;
\ No newline at end of file
@@ -0,0 +1,19 @@
+CREATE TABLE planets_translations (
+    planet_id int NOT NULL,
+    locale varchar(10) NOT NULL,
+    created_at timestamp NOT NULL,
+    updated_at timestamp NOT NULL,
+    name varchar(100) NOT NULL,
+    PRIMARY KEY (planet_id, locale),
+    CONSTRAINT FK_PLANETS_TRANSLATIONS_PLANET_ID FOREIGN KEY (planet_id) REFERENCES planets(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE films_translations (
+    film_id int NOT NULL,
+    locale varchar(10) NOT NULL,
+    created_at timestamp NOT NULL,
+    updated_at timestamp NOT NULL,
+    title varchar(255) NOT NULL,
+    PRIMARY KEY (film_id, locale),
+    CONSTRAINT FK_FILMS_TRANSLATIONS_FILM_ID FOREIGN KEY (film_id) REFERENCES films(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE feed_checkpoints;
\ No newline at end of file
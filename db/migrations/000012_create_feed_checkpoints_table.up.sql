@@ -0,0 +1,7 @@
+CREATE TABLE feed_checkpoints (
+    name varchar(100) NOT NULL,
+    updated_at timestamp NOT NULL,
+    resource varchar(20) NOT NULL,
+    page int NOT NULL,
+    PRIMARY KEY (name)
+);
\ No newline at end of file
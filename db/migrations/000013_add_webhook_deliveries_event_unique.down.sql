@@ -0,0 +1 @@
+ALTER TABLE webhook_deliveries DROP INDEX UN_WEBHOOK_DELIVERIES_EVENT;
\ No newline at end of file
@@ -0,0 +1,6 @@
+DELETE duplicate FROM webhook_deliveries duplicate
+    INNER JOIN webhook_deliveries first ON first.webhook_id = duplicate.webhook_id
+        AND first.event_id = duplicate.event_id
+        AND first.id < duplicate.id;
+
+ALTER TABLE webhook_deliveries ADD UNIQUE KEY UN_WEBHOOK_DELIVERIES_EVENT (webhook_id, event_id);
\ No newline at end of file
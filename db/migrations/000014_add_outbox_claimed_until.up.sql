@@ -0,0 +1 @@
+ALTER TABLE outbox ADD COLUMN claimed_until timestamp NULL AFTER data;
\ No newline at end of file
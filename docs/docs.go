@@ -16,8 +16,70 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/healthcheck": {
+        "/api/admin/sync/run": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "run the SWAPI sync job now, in background",
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SyncStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/sync/status": {
             "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -25,27 +87,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "health"
+                    "admin"
                 ],
-                "summary": "healthcheck",
+                "summary": "find the status of the SWAPI sync job",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.HealthResponse"
+                            "$ref": "#/definitions/dto.SyncStatus"
                         }
                     },
-                    "503": {
-                        "description": "Service Unavailable",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/dto.HealthResponse"
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
                         }
                     }
                 }
             }
         },
-        "/api/planets": {
+        "/api/audit": {
             "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -53,9 +135,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "planet"
+                    "audit"
                 ],
-                "summary": "find planets",
+                "summary": "find audit logs",
                 "parameters": [
                     {
                         "type": "integer",
@@ -70,15 +152,27 @@ const docTemplate = `{
                         "in": "query"
                     },
                     {
-                        "type": "boolean",
-                        "description": "loadFilms",
-                        "name": "loadFilms",
+                        "type": "string",
+                        "description": "planet or film",
+                        "name": "entity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "entity id",
+                        "name": "entityId",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "name",
-                        "name": "name",
+                        "description": "RFC 3339 start time, e.g. 2022-10-01T00:00:00Z",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 end time, e.g. 2022-10-31T23:59:59Z",
+                        "name": "to",
                         "in": "query"
                     }
                 ],
@@ -86,7 +180,31 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.PlanetsResponse"
+                            "$ref": "#/definitions/dto.AuditLogsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
                         }
                     },
                     "500": {
@@ -98,7 +216,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/planets/{planetID}": {
+        "/api/climates": {
             "get": {
                 "consumes": [
                     "application/json"
@@ -107,29 +225,126 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "planet"
+                    "lookup"
                 ],
-                "summary": "find planet by id",
+                "summary": "find climates with their planet counts",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LookupsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/events": {
+            "get": {
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "event"
+                ],
+                "summary": "stream planet and film change events",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "comma separated event types, e.g. planet.deleted,film.linked",
+                        "name": "types",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "Planet ID",
-                        "name": "planetID",
-                        "in": "path",
-                        "required": true
+                        "description": "id of the last event received, to resume the stream",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Event"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
                     },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/films/{filmID}": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "planet film"
+                ],
+                "summary": "find film by id",
+                "parameters": [
                     {
-                        "type": "boolean",
-                        "description": "loadFilms",
-                        "name": "loadFilms",
-                        "in": "query"
+                        "type": "integer",
+                        "description": "Film ID",
+                        "name": "filmID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.PlanetResponse"
+                            "$ref": "#/definitions/dto.FilmResponse"
                         }
                     },
                     "400": {
@@ -138,12 +353,30 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ApiError"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/dto.ApiError"
                         }
                     },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -151,8 +384,18 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/films/{filmID}/translations/{locale}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -160,16 +403,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "planet"
+                    "translation"
                 ],
-                "summary": "delete planet",
+                "summary": "create or replace the title of a film in a locale",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Planet ID",
-                        "name": "planetID",
+                        "description": "Film ID",
+                        "name": "filmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "locale, e.g. pt-BR",
+                        "name": "locale",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "translation",
+                        "name": "translation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.FilmTranslationRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -182,6 +441,30 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ApiError"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -189,78 +472,3805 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "dto.ApiError": {
-            "type": "object",
-            "properties": {
-                "error": {
-                    "type": "string",
-                    "example": "error"
-                }
-            }
-        },
-        "dto.FilmDto": {
-            "type": "object",
-            "properties": {
-                "created_at": {
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "translation"
+                ],
+                "summary": "delete the title of a film in a locale",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Film ID",
+                        "name": "filmID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "locale, e.g. pt-BR",
+                        "name": "locale",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": ""
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/graphql": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "graphql"
+                ],
+                "summary": "graphql query over planets and films",
+                "parameters": [
+                    {
+                        "description": "GraphQL request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.GraphQLRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GraphQLResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/healthcheck": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "healthcheck",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HealthResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/planets": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "planet"
+                ],
+                "summary": "find planets",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "size",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "loadFilms",
+                        "name": "loadFilms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "climate",
+                        "name": "climate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "terrain",
+                        "name": "terrain",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "minimum rotationPeriod",
+                        "name": "rotationPeriodMin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "maximum rotationPeriod",
+                        "name": "rotationPeriodMax",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "minimum orbitalPeriod",
+                        "name": "orbitalPeriodMin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "maximum orbitalPeriod",
+                        "name": "orbitalPeriodMax",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "minimum diameter",
+                        "name": "diameterMin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "maximum diameter",
+                        "name": "diameterMax",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "minimum surfaceWater",
+                        "name": "surfaceWaterMin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "maximum surfaceWater",
+                        "name": "surfaceWaterMax",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "minimum population",
+                        "name": "populationMin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "maximum population",
+                        "name": "populationMax",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated planet fields, e.g. name,climates",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "embedded relationship, e.g. films(title,episode)",
+                        "name": "embed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated planet ids, answered as dto.PlanetsBatchResponse, e.g. 1,2,3",
+                        "name": "ids",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PlanetsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/planets/batch-get": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "planet"
+                ],
+                "summary": "find planets by ids",
+                "parameters": [
+                    {
+                        "description": "planet ids",
+                        "name": "planets",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PlanetsBatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PlanetsBatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/planets/bulk": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "planet"
+                ],
+                "summary": "delete, restore or patch planets in a single transaction",
+                "parameters": [
+                    {
+                        "description": "operations, run in order; mode defaults to atomic",
+                        "name": "planets",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PlanetsBulkRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PlanetsBulkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PlanetsBulkResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/planets/export": {
+            "get": {
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "planet"
+                ],
+                "summary": "export planets",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "csv or ndjson",
+                        "name": "format",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "name",
+                        "name": "name",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": ""
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/planets/import": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "validates every row of a csv or ndjson body, in the same layout of the export, and inserts the planets in a single transaction only when all rows are valid, so a failure imports none of them",
+                "consumes": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "planet"
+                ],
+                "summary": "import planets",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "csv or ndjson",
+                        "name": "format",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "only validate the rows",
+                        "name": "dryRun",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "insert (default) ignores existing planets, upsert updates them",
+                        "name": "mode",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ImportResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ImportResult"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/planets/{planetID}": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "planet"
+                ],
+                "summary": "find planet by id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Planet ID",
+                        "name": "planetID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "loadFilms",
+                        "name": "loadFilms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated planet fields, e.g. name,climates",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "embedded relationship, e.g. films(title,episode)",
+                        "name": "embed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 time to read the planet as it was, e.g. 2022-10-01T00:00:00Z",
+                        "name": "asOf",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PlanetResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "planet"
+                ],
+                "summary": "delete planet",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Planet ID",
+                        "name": "planetID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": ""
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/planets/{planetID}/films": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "planet film"
+                ],
+                "summary": "find the films of a planet",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Planet ID",
+                        "name": "planetID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "size",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FilmsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/planets/{planetID}/films/{filmID}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "planet film"
+                ],
+                "summary": "link a film to a planet",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Planet ID",
+                        "name": "planetID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Film ID",
+                        "name": "filmID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": ""
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "planet film"
+                ],
+                "summary": "unlink a film from a planet",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Planet ID",
+                        "name": "planetID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Film ID",
+                        "name": "filmID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": ""
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/planets/{planetID}/history": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "planet"
+                ],
+                "summary": "find planet history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Planet ID",
+                        "name": "planetID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PlanetHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/planets/{planetID}/translations/{locale}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "translation"
+                ],
+                "summary": "create or replace the name of a planet in a locale",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Planet ID",
+                        "name": "planetID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "locale, e.g. pt-BR",
+                        "name": "locale",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "translation",
+                        "name": "translation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PlanetTranslationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": ""
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "translation"
+                ],
+                "summary": "delete the name of a planet in a locale",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Planet ID",
+                        "name": "planetID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "locale, e.g. pt-BR",
+                        "name": "locale",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": ""
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/search": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "search planets and films",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "search terms, typos are tolerated",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated result types, e.g. planet,film",
+                        "name": "types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/stats/films/planets": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "count planets by film",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "climate",
+                        "name": "climate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "terrain",
+                        "name": "terrain",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "minimum rotationPeriod",
+                        "name": "rotationPeriodMin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "maximum rotationPeriod",
+                        "name": "rotationPeriodMax",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "minimum orbitalPeriod",
+                        "name": "orbitalPeriodMin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "maximum orbitalPeriod",
+                        "name": "orbitalPeriodMax",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "minimum diameter",
+                        "name": "diameterMin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "maximum diameter",
+                        "name": "diameterMax",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "minimum surfaceWater",
+                        "name": "surfaceWaterMin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "maximum surfaceWater",
+                        "name": "surfaceWaterMax",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "minimum population",
+                        "name": "populationMin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "maximum population",
+                        "name": "populationMax",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.StatsEntityCountsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/stats/planets/climates": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "count planets by climate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "climate",
+                        "name": "climate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "terrain",
+                        "name": "terrain",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "minimum rotationPeriod",
+                        "name": "rotationPeriodMin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "maximum rotationPeriod",
+                        "name": "rotationPeriodMax",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "minimum orbitalPeriod",
+                        "name": "orbitalPeriodMin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "maximum orbitalPeriod",
+                        "name": "orbitalPeriodMax",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "minimum diameter",
+                        "name": "diameterMin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "maximum diameter",
+                        "name": "diameterMax",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "minimum surfaceWater",
+                        "name": "surfaceWaterMin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "maximum surfaceWater",
+                        "name": "surfaceWaterMax",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "minimum population",
+                        "name": "populationMin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "maximum population",
+                        "name": "populationMax",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.StatsCountsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/stats/planets/films": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "count planets by number of films they appear in",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "climate",
+                        "name": "climate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "terrain",
+                        "name": "terrain",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "minimum rotationPeriod",
+                        "name": "rotationPeriodMin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "maximum rotationPeriod",
+                        "name": "rotationPeriodMax",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "minimum orbitalPeriod",
+                        "name": "orbitalPeriodMin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "maximum orbitalPeriod",
+                        "name": "orbitalPeriodMax",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "minimum diameter",
+                        "name": "diameterMin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "maximum diameter",
+                        "name": "diameterMax",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "minimum surfaceWater",
+                        "name": "surfaceWaterMin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "maximum surfaceWater",
+                        "name": "surfaceWaterMax",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "minimum population",
+                        "name": "populationMin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "maximum population",
+                        "name": "populationMax",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.StatsDistributionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/stats/planets/terrains": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "count planets by terrain",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "climate",
+                        "name": "climate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "terrain",
+                        "name": "terrain",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "minimum rotationPeriod",
+                        "name": "rotationPeriodMin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "maximum rotationPeriod",
+                        "name": "rotationPeriodMax",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "minimum orbitalPeriod",
+                        "name": "orbitalPeriodMin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "maximum orbitalPeriod",
+                        "name": "orbitalPeriodMax",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "minimum diameter",
+                        "name": "diameterMin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "maximum diameter",
+                        "name": "diameterMax",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "minimum surfaceWater",
+                        "name": "surfaceWaterMin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "maximum surfaceWater",
+                        "name": "surfaceWaterMax",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "minimum population",
+                        "name": "populationMin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "maximum population",
+                        "name": "populationMax",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.StatsCountsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/stats/planets/top": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "find the planets appearing in the most films",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "climate",
+                        "name": "climate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "terrain",
+                        "name": "terrain",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "minimum rotationPeriod",
+                        "name": "rotationPeriodMin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "maximum rotationPeriod",
+                        "name": "rotationPeriodMax",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "minimum orbitalPeriod",
+                        "name": "orbitalPeriodMin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "maximum orbitalPeriod",
+                        "name": "orbitalPeriodMax",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "minimum diameter",
+                        "name": "diameterMin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "maximum diameter",
+                        "name": "diameterMax",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "minimum surfaceWater",
+                        "name": "surfaceWaterMin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "maximum surfaceWater",
+                        "name": "surfaceWaterMax",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "minimum population",
+                        "name": "populationMin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "maximum population",
+                        "name": "populationMax",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.StatsEntityCountsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/terrains": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "lookup"
+                ],
+                "summary": "find terrains with their planet counts",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LookupsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/planets": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "planet v2"
+                ],
+                "summary": "find planets",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "size",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "loadFilms",
+                        "name": "loadFilms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "climate",
+                        "name": "climate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "terrain",
+                        "name": "terrain",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "minimum rotationPeriod",
+                        "name": "rotationPeriodMin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "maximum rotationPeriod",
+                        "name": "rotationPeriodMax",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "minimum orbitalPeriod",
+                        "name": "orbitalPeriodMin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "maximum orbitalPeriod",
+                        "name": "orbitalPeriodMax",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "minimum diameter",
+                        "name": "diameterMin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "maximum diameter",
+                        "name": "diameterMax",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "minimum surfaceWater",
+                        "name": "surfaceWaterMin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "maximum surfaceWater",
+                        "name": "surfaceWaterMax",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "minimum population",
+                        "name": "populationMin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "maximum population",
+                        "name": "populationMax",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PlanetsV2Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/planets/{planetID}": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "planet v2"
+                ],
+                "summary": "find planet by id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Planet ID",
+                        "name": "planetID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "loadFilms",
+                        "name": "loadFilms",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PlanetV2Dto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "find webhooks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhooksResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "create webhook",
+                "parameters": [
+                    {
+                        "description": "webhook",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhooks/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "find webhook deliveries",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "size",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "pending, delivered or dead",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "webhook id",
+                        "name": "webhookId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookDeliveriesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhooks/deliveries/{deliveryID}/retry": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "retry dead webhook delivery",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Delivery ID",
+                        "name": "deliveryID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": ""
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhooks/{webhookID}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "find webhook by id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhookID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "update webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhookID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "webhook, keeping the secret when empty",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "delete webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhookID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": ""
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiError"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.ApiError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "dto.AuditLogDto": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "delete"
+                },
+                "actor": {
+                    "type": "string",
+                    "example": "ops"
+                },
+                "after": {
+                    "type": "object"
+                },
+                "before": {
+                    "type": "object"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2022-10-01 12:00:00"
+                },
+                "entity": {
+                    "type": "string",
+                    "example": "planet"
+                },
+                "entity_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "8f14e45f-ceea-467a-9575-7e5b2f3c1a2b"
+                }
+            }
+        },
+        "dto.AuditLogsResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AuditLogDto"
+                    }
+                },
+                "next": {
+                    "type": "string",
+                    "example": "http://localhost:8080/api/planets?page=3\u0026size=10"
+                },
+                "previous": {
+                    "type": "string",
+                    "example": "http://localhost:8080/api/planets?size=10"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 60
+                }
+            }
+        },
+        "dto.Event": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "object"
+                },
+                "entity": {
+                    "type": "string",
+                    "example": "planet"
+                },
+                "entity_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "string",
+                    "example": "8f14e45f-ceea-467a-9575-7e5b2f3c1a2b"
+                },
+                "occurred_at": {
+                    "type": "string",
+                    "example": "2022-10-01T12:00:00Z"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "planet.deleted"
+                }
+            }
+        },
+        "dto.FilmDto": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "$ref": "#/definitions/dto.FilmLinks"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2014-12-09 13:50:49"
+                },
+                "director": {
+                    "type": "string",
+                    "example": "George Lucas"
+                },
+                "episode": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "opening_crawl": {
+                    "type": "string",
+                    "example": "It is a period of civil war."
+                },
+                "producer": {
+                    "type": "string",
+                    "example": "Gary Kurtz, Rick McCallum"
+                },
+                "release_date": {
+                    "type": "string",
+                    "example": "1977-05-25"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "A New Hope"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2014-12-20 20:58:18"
+                }
+            }
+        },
+        "dto.FilmLinks": {
+            "type": "object",
+            "properties": {
+                "planet": {
+                    "$ref": "#/definitions/dto.Link"
+                },
+                "self": {
+                    "$ref": "#/definitions/dto.Link"
+                }
+            }
+        },
+        "dto.FilmResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/dto.FilmDto"
+                }
+            }
+        },
+        "dto.FilmTranslationRequest": {
+            "type": "object",
+            "properties": {
+                "title": {
+                    "type": "string",
+                    "example": "Uma Nova Esperança"
+                }
+            }
+        },
+        "dto.FilmV2Dto": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2014-12-10T14:23:31Z"
+                },
+                "director": {
+                    "type": "string",
+                    "example": "George Lucas"
+                },
+                "episode": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "opening_crawl": {
+                    "type": "string",
+                    "example": "It is a period of civil war."
+                },
+                "producer": {
+                    "type": "string",
+                    "example": "Gary Kurtz, Rick McCallum"
+                },
+                "release_date": {
+                    "type": "string",
+                    "example": "1977-05-25"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "A New Hope"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2014-12-20T19:49:45Z"
+                }
+            }
+        },
+        "dto.FilmsResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.FilmDto"
+                    }
+                },
+                "next": {
+                    "type": "string",
+                    "example": "http://localhost:8080/api/planets?page=3\u0026size=10"
+                },
+                "previous": {
+                    "type": "string",
+                    "example": "http://localhost:8080/api/planets?size=10"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 60
+                }
+            }
+        },
+        "dto.GraphQLRequest": {
+            "type": "object",
+            "properties": {
+                "operationName": {
+                    "type": "string"
+                },
+                "query": {
+                    "type": "string",
+                    "example": "{ planets(size: 2) { data { name films { title } } } }"
+                },
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
+        },
+        "dto.GraphQLResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "errors": {
+                    "type": "array",
+                    "items": {}
+                }
+            }
+        },
+        "dto.HealthResponse": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "example": "up"
+                }
+            }
+        },
+        "dto.ImportResult": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ImportRowError"
+                    }
+                },
+                "imported": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "upsert": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "valid": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dto.ImportRowError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "name is required"
+                },
+                "line": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "dto.Link": {
+            "type": "object",
+            "properties": {
+                "href": {
+                    "type": "string",
+                    "example": "http://localhost:8080/api/planets/1"
+                }
+            }
+        },
+        "dto.LinkV2": {
+            "type": "object",
+            "properties": {
+                "href": {
+                    "type": "string",
+                    "example": "http://localhost:8080/api/v2/planets/1"
+                }
+            }
+        },
+        "dto.LinksV2": {
+            "type": "object",
+            "properties": {
+                "first": {
+                    "$ref": "#/definitions/dto.LinkV2"
+                },
+                "last": {
+                    "$ref": "#/definitions/dto.LinkV2"
+                },
+                "next": {
+                    "$ref": "#/definitions/dto.LinkV2"
+                },
+                "prev": {
+                    "$ref": "#/definitions/dto.LinkV2"
+                },
+                "self": {
+                    "$ref": "#/definitions/dto.LinkV2"
+                }
+            }
+        },
+        "dto.LookupDto": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "arid"
+                },
+                "planets": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "dto.LookupsResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LookupDto"
+                    }
+                }
+            }
+        },
+        "dto.PageV2": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "number": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "total_elements": {
+                    "type": "integer",
+                    "example": 60
+                },
+                "total_pages": {
+                    "type": "integer",
+                    "example": 6
+                }
+            }
+        },
+        "dto.PlanetBulkOperation": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "op": {
+                    "type": "string",
+                    "enum": [
+                        "delete",
+                        "restore",
+                        "patch"
+                    ],
+                    "example": "patch"
+                },
+                "patch": {
+                    "$ref": "#/definitions/dto.PlanetPatch"
+                }
+            }
+        },
+        "dto.PlanetBulkResult": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/dto.PlanetDto"
+                },
+                "error": {
+                    "type": "string",
+                    "example": "planet 1 not found"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "index": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "op": {
+                    "type": "string",
+                    "example": "patch"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "dto.PlanetDto": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "$ref": "#/definitions/dto.PlanetLinks"
+                },
+                "climates": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "arid"
+                    ]
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2014-12-09 13:50:49"
+                },
+                "diameter": {
+                    "type": "integer",
+                    "example": 10465
+                },
+                "films": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.FilmDto"
+                    }
+                },
+                "gravity": {
+                    "type": "string",
+                    "example": "1 standard"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Tatooine"
+                },
+                "orbital_period": {
+                    "type": "integer",
+                    "example": 304
+                },
+                "population": {
+                    "type": "integer",
+                    "example": 200000
+                },
+                "rotation_period": {
+                    "type": "integer",
+                    "example": 23
+                },
+                "surface_water": {
+                    "type": "number",
+                    "example": 1
+                },
+                "terrains": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "desert"
+                    ]
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2014-12-20 20:58:18"
+                }
+            }
+        },
+        "dto.PlanetHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PlanetVersionDto"
+                    }
+                }
+            }
+        },
+        "dto.PlanetLinks": {
+            "type": "object",
+            "properties": {
+                "films": {
+                    "$ref": "#/definitions/dto.Link"
+                },
+                "self": {
+                    "$ref": "#/definitions/dto.Link"
+                }
+            }
+        },
+        "dto.PlanetPatch": {
+            "type": "object",
+            "properties": {
+                "climates": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "arid"
+                    ]
+                },
+                "diameter": {
+                    "type": "integer",
+                    "example": 10465
+                },
+                "gravity": {
+                    "type": "string",
+                    "example": "1 standard"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Tatooine"
+                },
+                "orbital_period": {
+                    "type": "integer",
+                    "example": 304
+                },
+                "population": {
+                    "type": "integer",
+                    "example": 200000
+                },
+                "rotation_period": {
+                    "type": "integer",
+                    "example": 23
+                },
+                "surface_water": {
+                    "type": "number",
+                    "example": 1
+                },
+                "terrains": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "desert"
+                    ]
+                }
+            }
+        },
+        "dto.PlanetResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/dto.PlanetDto"
+                }
+            }
+        },
+        "dto.PlanetTranslationRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Tatuíne"
+                }
+            }
+        },
+        "dto.PlanetV2Dto": {
+            "type": "object",
+            "properties": {
+                "_embedded": {
+                    "$ref": "#/definitions/dto.PlanetV2Embedded"
+                },
+                "_links": {
+                    "$ref": "#/definitions/dto.LinksV2"
+                },
+                "climates": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "arid"
+                    ]
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2014-12-09T13:50:49Z"
+                },
+                "diameter": {
+                    "type": "integer",
+                    "example": 10465
+                },
+                "gravity": {
+                    "type": "string",
+                    "example": "1 standard"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Tatooine"
+                },
+                "orbital_period": {
+                    "type": "integer",
+                    "example": 304
+                },
+                "population": {
+                    "type": "integer",
+                    "example": 200000
+                },
+                "rotation_period": {
+                    "type": "integer",
+                    "example": 23
+                },
+                "surface_water": {
+                    "type": "number",
+                    "example": 1
+                },
+                "terrains": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "desert"
+                    ]
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2014-12-20T20:58:18Z"
+                }
+            }
+        },
+        "dto.PlanetV2Embedded": {
+            "type": "object",
+            "properties": {
+                "films": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.FilmV2Dto"
+                    }
+                }
+            }
+        },
+        "dto.PlanetVersionDto": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "$ref": "#/definitions/dto.PlanetLinks"
+                },
+                "climates": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "arid"
+                    ]
+                },
+                "created_at": {
                     "type": "string",
                     "example": "2014-12-09 13:50:49"
                 },
-                "director": {
+                "deleted_at": {
+                    "type": "string",
+                    "example": "2022-10-02 12:00:00"
+                },
+                "diameter": {
+                    "type": "integer",
+                    "example": 10465
+                },
+                "films": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.FilmDto"
+                    }
+                },
+                "gravity": {
+                    "type": "string",
+                    "example": "1 standard"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Tatooine"
+                },
+                "orbital_period": {
+                    "type": "integer",
+                    "example": 304
+                },
+                "population": {
+                    "type": "integer",
+                    "example": 200000
+                },
+                "rotation_period": {
+                    "type": "integer",
+                    "example": 23
+                },
+                "surface_water": {
+                    "type": "number",
+                    "example": 1
+                },
+                "terrains": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "desert"
+                    ]
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2014-12-20 20:58:18"
+                },
+                "valid_from": {
+                    "type": "string",
+                    "example": "2022-10-01 12:00:00"
+                },
+                "valid_to": {
+                    "type": "string",
+                    "example": "2022-10-02 12:00:00"
+                }
+            }
+        },
+        "dto.PlanetsBatchRequest": {
+            "type": "object",
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2,
+                        3
+                    ]
+                },
+                "loadFilms": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "dto.PlanetsBatchResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PlanetDto"
+                    }
+                },
+                "missing": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        3
+                    ]
+                }
+            }
+        },
+        "dto.PlanetsBulkRequest": {
+            "type": "object",
+            "properties": {
+                "mode": {
+                    "type": "string",
+                    "enum": [
+                        "atomic",
+                        "best_effort"
+                    ],
+                    "example": "atomic"
+                },
+                "operations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PlanetBulkOperation"
+                    }
+                }
+            }
+        },
+        "dto.PlanetsBulkResponse": {
+            "type": "object",
+            "properties": {
+                "committed": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "failed": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "mode": {
                     "type": "string",
-                    "example": "George Lucas"
+                    "example": "atomic"
                 },
-                "id": {
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PlanetBulkResult"
+                    }
+                },
+                "succeeded": {
                     "type": "integer",
-                    "example": 1
+                    "example": 2
+                }
+            }
+        },
+        "dto.PlanetsResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 10
                 },
-                "release_date": {
-                    "type": "string",
-                    "example": "1977-05-25"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PlanetDto"
+                    }
                 },
-                "title": {
+                "next": {
                     "type": "string",
-                    "example": "A New Hope, Episode 4"
+                    "example": "http://localhost:8080/api/planets?page=3\u0026size=10"
                 },
-                "updated_at": {
+                "previous": {
                     "type": "string",
-                    "example": "2014-12-20 20:58:18"
+                    "example": "http://localhost:8080/api/planets?size=10"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 60
                 }
             }
         },
-        "dto.HealthResponse": {
+        "dto.PlanetsV2Embedded": {
             "type": "object",
             "properties": {
-                "status": {
+                "planets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PlanetV2Dto"
+                    }
+                }
+            }
+        },
+        "dto.PlanetsV2Response": {
+            "type": "object",
+            "properties": {
+                "_embedded": {
+                    "$ref": "#/definitions/dto.PlanetsV2Embedded"
+                },
+                "_links": {
+                    "$ref": "#/definitions/dto.LinksV2"
+                },
+                "page": {
+                    "$ref": "#/definitions/dto.PageV2"
+                }
+            }
+        },
+        "dto.SearchHitDto": {
+            "type": "object",
+            "properties": {
+                "film": {
+                    "$ref": "#/definitions/dto.FilmDto"
+                },
+                "highlights": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "planet": {
+                    "$ref": "#/definitions/dto.PlanetDto"
+                },
+                "score": {
+                    "type": "number",
+                    "example": 1.5
+                },
+                "type": {
                     "type": "string",
-                    "example": "up"
+                    "example": "planet"
                 }
             }
         },
-        "dto.PlanetDto": {
+        "dto.SearchResponse": {
             "type": "object",
             "properties": {
-                "climates": {
+                "count": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "data": {
                     "type": "array",
                     "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "arid"
-                    ]
+                        "$ref": "#/definitions/dto.SearchHitDto"
+                    }
+                }
+            }
+        },
+        "dto.StatsCountDto": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 3
                 },
-                "created_at": {
+                "value": {
                     "type": "string",
-                    "example": "2014-12-09 13:50:49"
+                    "example": "arid"
+                }
+            }
+        },
+        "dto.StatsCountsResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 1
                 },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.StatsCountDto"
+                    }
+                }
+            }
+        },
+        "dto.StatsDistributionDto": {
+            "type": "object",
+            "properties": {
                 "films": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "planets": {
+                    "type": "integer",
+                    "example": 7
+                }
+            }
+        },
+        "dto.StatsDistributionResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.FilmDto"
+                        "$ref": "#/definitions/dto.StatsDistributionDto"
                     }
+                }
+            }
+        },
+        "dto.StatsEntityCountDto": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 5
                 },
                 "id": {
                     "type": "integer",
@@ -269,31 +4279,83 @@ const docTemplate = `{
                 "name": {
                     "type": "string",
                     "example": "Tatooine"
+                }
+            }
+        },
+        "dto.StatsEntityCountsResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "terrains": {
+                "data": {
                     "type": "array",
                     "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "desert"
-                    ]
+                        "$ref": "#/definitions/dto.StatsEntityCountDto"
+                    }
+                }
+            }
+        },
+        "dto.SyncRun": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "sync is running in another instance"
                 },
-                "updated_at": {
+                "finished_at": {
                     "type": "string",
-                    "example": "2014-12-20 20:58:18"
+                    "example": "2022-10-02T03:00:12Z"
+                },
+                "started_at": {
+                    "type": "string",
+                    "example": "2022-10-02T03:00:00Z"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "succeeded",
+                        "failed",
+                        "skipped"
+                    ],
+                    "example": "succeeded"
+                },
+                "trigger": {
+                    "type": "string",
+                    "enum": [
+                        "schedule",
+                        "manual"
+                    ],
+                    "example": "schedule"
                 }
             }
         },
-        "dto.PlanetResponse": {
+        "dto.SyncStatus": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/dto.PlanetDto"
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "last_run": {
+                    "$ref": "#/definitions/dto.SyncRun"
+                },
+                "next_run_at": {
+                    "type": "string",
+                    "example": "2022-10-03T03:00:00Z"
+                },
+                "running": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "schedule": {
+                    "type": "string",
+                    "example": "0 3 * * *"
                 }
             }
         },
-        "dto.PlanetsResponse": {
+        "dto.WebhookDeliveriesResponse": {
             "type": "object",
             "properties": {
                 "count": {
@@ -303,7 +4365,7 @@ const docTemplate = `{
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.PlanetDto"
+                        "$ref": "#/definitions/dto.WebhookDeliveryDto"
                     }
                 },
                 "next": {
@@ -319,6 +4381,133 @@ const docTemplate = `{
                     "example": 60
                 }
             }
+        },
+        "dto.WebhookDeliveryDto": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2022-10-01 12:00:00"
+                },
+                "delivered_at": {
+                    "type": "string",
+                    "example": "2022-10-01 12:00:00"
+                },
+                "event": {
+                    "type": "string",
+                    "example": "planet.deleted"
+                },
+                "event_id": {
+                    "type": "string",
+                    "example": "8f14e45f-ceea-467a-9575-7e5b2f3c1a2b"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "last_error": {
+                    "type": "string",
+                    "example": "unexpected status 500"
+                },
+                "next_attempt_at": {
+                    "type": "string",
+                    "example": "2022-10-01 12:00:00"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "dead"
+                },
+                "webhook_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dto.WebhookDto": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2022-10-01 12:00:00"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "planet.deleted"
+                    ]
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2022-10-01 12:00:00"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/starwars"
+                }
+            }
+        },
+        "dto.WebhookRequest": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "planet.deleted"
+                    ]
+                },
+                "secret": {
+                    "type": "string",
+                    "example": "a-long-random-secret"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/starwars"
+                }
+            }
+        },
+        "dto.WebhookResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/dto.WebhookDto"
+                }
+            }
+        },
+        "dto.WebhooksResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.WebhookDto"
+                    }
+                }
+            }
         }
     }
 }`
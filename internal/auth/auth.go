@@ -0,0 +1,54 @@
+package auth
+
+import "context"
+
+type Role string
+
+const (
+	RoleReader Role = "reader"
+	RoleEditor Role = "editor"
+	RoleAdmin  Role = "admin"
+)
+
+const PRINCIPAL_KEY = "principal"
+
+var roleLevels = map[Role]int{
+	RoleReader: 1,
+	RoleEditor: 2,
+	RoleAdmin:  3,
+}
+
+// Allows reports whether the role grants at least the required role
+func (r Role) Allows(required Role) bool {
+	return roleLevels[required] > 0 && roleLevels[r] >= roleLevels[required]
+}
+
+func ParseRole(value string) (Role, bool) {
+	role := Role(value)
+	_, ok := roleLevels[role]
+	return role, ok
+}
+
+type Principal struct {
+	Subject string
+	Role    Role
+}
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the authenticated principal of a gin or gRPC
+// request context, or nil when there is none
+func PrincipalFromContext(ctx context.Context) *Principal {
+	if p, ok := ctx.Value(principalKey{}).(*Principal); ok {
+		return p
+	}
+	if p, ok := ctx.Value(PRINCIPAL_KEY).(*Principal); ok {
+		return p
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/viniosilva/starwars-api/internal/config"
+)
+
+//go:generate mockgen -destination=../../mock/authenticator_mock.go -package=mock . Authenticator
+type Authenticator interface {
+	Authenticate(apiKey, bearerToken string) (*Principal, error)
+}
+
+type IAuthenticator struct {
+	ApiKeys    map[string]*Principal
+	HMACSecret []byte
+	RSAKeys    map[string]*rsa.PublicKey
+	Issuer     string
+	Audience   string
+}
+
+type Claims struct {
+	Role string `json:"role"`
+	jwt.RegisteredClaims
+}
+
+var ErrInvalidCredentials = fmt.Errorf("invalid credentials")
+
+// NewAuthenticator builds an IAuthenticator from the api keys, the HS256
+// secret and the RS256 keys of the local JWKS file configured
+func NewAuthenticator(c config.AuthConfig) (*IAuthenticator, error) {
+	apiKeys := map[string]*Principal{}
+	for _, k := range c.ApiKeys {
+		role, ok := ParseRole(k.Role)
+		if !ok || k.Key == "" {
+			return nil, fmt.Errorf("invalid api key %s", k.Name)
+		}
+		apiKeys[k.Key] = &Principal{Subject: k.Name, Role: role}
+	}
+
+	rsaKeys := map[string]*rsa.PublicKey{}
+	if c.JWT.JwksFile != "" {
+		b, err := os.ReadFile(c.JWT.JwksFile)
+		if err != nil {
+			return nil, err
+		}
+		if rsaKeys, err = ParseJWKS(b); err != nil {
+			return nil, err
+		}
+	}
+
+	return &IAuthenticator{
+		ApiKeys:    apiKeys,
+		HMACSecret: []byte(c.JWT.Secret),
+		RSAKeys:    rsaKeys,
+		Issuer:     c.JWT.Issuer,
+		Audience:   c.JWT.Audience,
+	}, nil
+}
+
+// Authenticate returns the principal of the api key or bearer token, nil when
+// neither is given and ErrInvalidCredentials when they are not valid
+func (impl *IAuthenticator) Authenticate(apiKey, bearerToken string) (*Principal, error) {
+	if apiKey != "" {
+		principal, ok := impl.ApiKeys[apiKey]
+		if !ok {
+			return nil, ErrInvalidCredentials
+		}
+		return principal, nil
+	}
+
+	if bearerToken != "" {
+		return impl.parseToken(bearerToken)
+	}
+
+	return nil, nil
+}
+
+func (impl *IAuthenticator) parseToken(bearerToken string) (*Principal, error) {
+	methods := []string{}
+	if len(impl.HMACSecret) > 0 {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	if len(impl.RSAKeys) > 0 {
+		methods = append(methods, jwt.SigningMethodRS256.Alg())
+	}
+	if len(methods) == 0 {
+		return nil, ErrInvalidCredentials
+	}
+
+	opts := []jwt.ParserOption{jwt.WithValidMethods(methods), jwt.WithExpirationRequired()}
+	if impl.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(impl.Issuer))
+	}
+	if impl.Audience != "" {
+		opts = append(opts, jwt.WithAudience(impl.Audience))
+	}
+
+	claims := &Claims{}
+	if _, err := jwt.ParseWithClaims(bearerToken, claims, impl.keyFunc, opts...); err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	role, ok := ParseRole(claims.Role)
+	if !ok {
+		return nil, ErrInvalidCredentials
+	}
+
+	return &Principal{Subject: claims.Subject, Role: role}, nil
+}
+
+func (impl *IAuthenticator) keyFunc(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+		return impl.HMACSecret, nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	if key, ok := impl.RSAKeys[kid]; ok {
+		return key, nil
+	}
+	if kid == "" && len(impl.RSAKeys) == 1 {
+		for _, key := range impl.RSAKeys {
+			return key, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown key %s", kid)
+}
+
+// ParseJWKS reads the RSA public keys of a JWKS document indexed by kid
+func ParseJWKS(b []byte) (map[string]*rsa.PublicKey, error) {
+	var jwks struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := json.Unmarshal(b, &jwks); err != nil {
+		return nil, err
+	}
+
+	keys := map[string]*rsa.PublicKey{}
+	for _, k := range jwks.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid jwks key %s", k.Kid)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid jwks key %s", k.Kid)
+		}
+
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+
+	return keys, nil
+}
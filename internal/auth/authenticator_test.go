@@ -0,0 +1,186 @@
+package auth_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/auth"
+	"github.com/viniosilva/starwars-api/internal/config"
+)
+
+func Test_Authenticator_Authenticate(t *testing.T) {
+	secret := []byte("secret")
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	otherRsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	sign := func(method jwt.SigningMethod, key interface{}, kid string, claims jwt.Claims) string {
+		token := jwt.NewWithClaims(method, claims)
+		if kid != "" {
+			token.Header["kid"] = kid
+		}
+		s, _ := token.SignedString(key)
+		return s
+	}
+	claims := func(role string, expiresAt time.Time) *auth.Claims {
+		return &auth.Claims{
+			Role: role,
+			RegisteredClaims: jwt.RegisteredClaims{
+				Subject:   "luke",
+				Issuer:    "rebellion",
+				ExpiresAt: jwt.NewNumericDate(expiresAt),
+			},
+		}
+	}
+	future := time.Now().Add(time.Hour)
+
+	var cases = map[string]struct {
+		inputApiKey       string
+		inputBearerToken  string
+		expectedPrincipal *auth.Principal
+		expectedErr       error
+	}{
+		"should return principal of api key": {
+			inputApiKey:       "admin-key",
+			expectedPrincipal: &auth.Principal{Subject: "ops", Role: auth.RoleAdmin},
+		},
+		"should return principal of HS256 token": {
+			inputBearerToken:  sign(jwt.SigningMethodHS256, secret, "", claims("editor", future)),
+			expectedPrincipal: &auth.Principal{Subject: "luke", Role: auth.RoleEditor},
+		},
+		"should return principal of RS256 token": {
+			inputBearerToken:  sign(jwt.SigningMethodRS256, rsaKey, "key-1", claims("reader", future)),
+			expectedPrincipal: &auth.Principal{Subject: "luke", Role: auth.RoleReader},
+		},
+		"should return nil when there are no credentials": {},
+		"should throw invalid credentials when api key is unknown": {
+			inputApiKey: "unknown",
+			expectedErr: auth.ErrInvalidCredentials,
+		},
+		"should throw invalid credentials when token is expired": {
+			inputBearerToken: sign(jwt.SigningMethodHS256, secret, "", claims("editor", time.Now().Add(-time.Hour))),
+			expectedErr:      auth.ErrInvalidCredentials,
+		},
+		"should throw invalid credentials when token signature is invalid": {
+			inputBearerToken: sign(jwt.SigningMethodRS256, otherRsaKey, "key-1", claims("admin", future)),
+			expectedErr:      auth.ErrInvalidCredentials,
+		},
+		"should throw invalid credentials when role is unknown": {
+			inputBearerToken: sign(jwt.SigningMethodHS256, secret, "", claims("jedi", future)),
+			expectedErr:      auth.ErrInvalidCredentials,
+		},
+		"should throw invalid credentials when issuer is not expected": {
+			inputBearerToken: sign(jwt.SigningMethodHS256, secret, "", &auth.Claims{
+				Role:             "admin",
+				RegisteredClaims: jwt.RegisteredClaims{Issuer: "empire", ExpiresAt: jwt.NewNumericDate(future)},
+			}),
+			expectedErr: auth.ErrInvalidCredentials,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			authenticator := &auth.IAuthenticator{
+				ApiKeys:    map[string]*auth.Principal{"admin-key": {Subject: "ops", Role: auth.RoleAdmin}},
+				HMACSecret: secret,
+				RSAKeys:    map[string]*rsa.PublicKey{"key-1": &rsaKey.PublicKey},
+				Issuer:     "rebellion",
+			}
+
+			// when
+			principal, err := authenticator.Authenticate(cs.inputApiKey, cs.inputBearerToken)
+
+			// then
+			assert.Equal(t, cs.expectedPrincipal, principal)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func Test_Authenticator_NewAuthenticator(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	jwks := fmt.Sprintf(`{"keys":[{"kty":"RSA","kid":"key-1","n":"%s","e":"%s"},{"kty":"EC","kid":"key-2"}]}`,
+		base64.RawURLEncoding.EncodeToString(rsaKey.N.Bytes()),
+		base64.RawURLEncoding.EncodeToString(big.NewInt(int64(rsaKey.E)).Bytes()))
+
+	dir := t.TempDir()
+	jwksFile := filepath.Join(dir, "jwks.json")
+	os.WriteFile(jwksFile, []byte(jwks), 0644)
+
+	var cases = map[string]struct {
+		inputConfig           config.AuthConfig
+		expectedAuthenticator *auth.IAuthenticator
+		expectedErrorMsg      string
+	}{
+		"should build authenticator": {
+			inputConfig: config.AuthConfig{
+				ApiKeys: []config.ApiKeyConfig{{Name: "ops", Key: "admin-key", Role: "admin"}},
+				JWT:     config.JWTConfig{Secret: "secret", JwksFile: jwksFile, Issuer: "rebellion"},
+			},
+			expectedAuthenticator: &auth.IAuthenticator{
+				ApiKeys:    map[string]*auth.Principal{"admin-key": {Subject: "ops", Role: auth.RoleAdmin}},
+				HMACSecret: []byte("secret"),
+				RSAKeys:    map[string]*rsa.PublicKey{"key-1": &rsaKey.PublicKey},
+				Issuer:     "rebellion",
+			},
+		},
+		"should throw error when api key role is invalid": {
+			inputConfig: config.AuthConfig{
+				ApiKeys: []config.ApiKeyConfig{{Name: "ops", Key: "admin-key", Role: "jedi"}},
+			},
+			expectedErrorMsg: "invalid api key ops",
+		},
+		"should throw error when jwks file does not exist": {
+			inputConfig: config.AuthConfig{
+				JWT: config.JWTConfig{JwksFile: filepath.Join(dir, "missing.json")},
+			},
+			expectedErrorMsg: "no such file or directory",
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// when
+			authenticator, err := auth.NewAuthenticator(cs.inputConfig)
+
+			// then
+			if cs.expectedErrorMsg != "" {
+				assert.ErrorContains(t, err, cs.expectedErrorMsg)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, cs.expectedAuthenticator, authenticator)
+		})
+	}
+}
+
+func Test_Auth_RoleAllows(t *testing.T) {
+	var cases = map[string]struct {
+		inputRole     auth.Role
+		inputRequired auth.Role
+		expected      bool
+	}{
+		"admin should allow editor":    {inputRole: auth.RoleAdmin, inputRequired: auth.RoleEditor, expected: true},
+		"editor should allow reader":   {inputRole: auth.RoleEditor, inputRequired: auth.RoleReader, expected: true},
+		"reader should deny editor":    {inputRole: auth.RoleReader, inputRequired: auth.RoleEditor, expected: false},
+		"unknown role should deny":     {inputRole: auth.Role("jedi"), inputRequired: auth.RoleReader, expected: false},
+		"unknown required should deny": {inputRole: auth.RoleAdmin, inputRequired: auth.Role(""), expected: false},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// when
+			res := cs.inputRole.Allows(cs.inputRequired)
+
+			// then
+			assert.Equal(t, cs.expected, res)
+		})
+	}
+}
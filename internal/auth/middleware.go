@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/i18n"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// GinAuth authenticates the X-Api-Key or Authorization: Bearer header, falling
+// back to anonymousRole when no credentials are given
+func GinAuth(authenticator Authenticator, anonymousRole Role) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		principal, err := authenticator.Authenticate(ctx.GetHeader("X-Api-Key"), ParseBearer(ctx.GetHeader("Authorization")))
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, dto.ApiError{Error: i18n.T(ctx, "invalid credentials")})
+			return
+		}
+
+		if principal == nil && anonymousRole != "" {
+			principal = &Principal{Subject: "anonymous", Role: anonymousRole}
+		}
+		if principal != nil {
+			ctx.Set(PRINCIPAL_KEY, principal)
+		}
+
+		ctx.Next()
+	}
+}
+
+// RequireRole rejects requests without a principal with 401 and those whose
+// role does not allow the required one with 403
+func RequireRole(role Role) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		principal := PrincipalFromContext(ctx)
+		if principal == nil {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, dto.ApiError{Error: i18n.T(ctx, "unauthorized")})
+			return
+		}
+		if !principal.Role.Allows(role) {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, dto.ApiError{Error: i18n.T(ctx, "forbidden")})
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// GrpcAuth is the gRPC counterpart of GinAuth and RequireRole, reading the
+// x-api-key and authorization metadata and requiring methodRoles[info.FullMethod],
+// or RoleReader for methods not listed
+func GrpcAuth(authenticator Authenticator, anonymousRole Role, methodRoles map[string]Role) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		principal, err := authenticator.Authenticate(firstMetadata(md, "x-api-key"), ParseBearer(firstMetadata(md, "authorization")))
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, "invalid credentials")
+		}
+		if principal == nil && anonymousRole != "" {
+			principal = &Principal{Subject: "anonymous", Role: anonymousRole}
+		}
+		if principal == nil {
+			return nil, status.Error(codes.Unauthenticated, "unauthorized")
+		}
+
+		role, ok := methodRoles[info.FullMethod]
+		if !ok {
+			role = RoleReader
+		}
+		if !principal.Role.Allows(role) {
+			return nil, status.Error(codes.PermissionDenied, "forbidden")
+		}
+
+		return handler(WithPrincipal(ctx, principal), req)
+	}
+}
+
+func ParseBearer(authorization string) string {
+	if len(authorization) > 7 && strings.EqualFold(authorization[:7], "bearer ") {
+		return strings.TrimSpace(authorization[7:])
+	}
+
+	return ""
+}
+
+func firstMetadata(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
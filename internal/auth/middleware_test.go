@@ -0,0 +1,193 @@
+package auth_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/auth"
+	"github.com/viniosilva/starwars-api/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func Test_Auth_GinAuth(t *testing.T) {
+	var cases = map[string]struct {
+		mocking            func(authenticator *mock.MockAuthenticator)
+		inputAuthorization string
+		inputAnonymousRole auth.Role
+		inputRequiredRole  auth.Role
+		expectedStatusCode int
+		expectedSubject    string
+	}{
+		"should allow bearer token with required role": {
+			mocking: func(authenticator *mock.MockAuthenticator) {
+				authenticator.EXPECT().Authenticate("", "token").Return(&auth.Principal{Subject: "luke", Role: auth.RoleEditor}, nil)
+			},
+			inputAuthorization: "Bearer token",
+			inputRequiredRole:  auth.RoleEditor,
+			expectedStatusCode: http.StatusOK,
+			expectedSubject:    "luke",
+		},
+		"should allow anonymous reader": {
+			mocking: func(authenticator *mock.MockAuthenticator) {
+				authenticator.EXPECT().Authenticate("", "").Return(nil, nil)
+			},
+			inputAnonymousRole: auth.RoleReader,
+			inputRequiredRole:  auth.RoleReader,
+			expectedStatusCode: http.StatusOK,
+			expectedSubject:    "anonymous",
+		},
+		"should throw forbidden when role is not enough": {
+			mocking: func(authenticator *mock.MockAuthenticator) {
+				authenticator.EXPECT().Authenticate("", "token").Return(&auth.Principal{Subject: "luke", Role: auth.RoleReader}, nil)
+			},
+			inputAuthorization: "Bearer token",
+			inputRequiredRole:  auth.RoleAdmin,
+			expectedStatusCode: http.StatusForbidden,
+		},
+		"should throw unauthorized when there are no credentials": {
+			mocking: func(authenticator *mock.MockAuthenticator) {
+				authenticator.EXPECT().Authenticate("", "").Return(nil, nil)
+			},
+			inputRequiredRole:  auth.RoleReader,
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			_, r := gin.CreateTestContext(res)
+
+			mockAuthenticator := mock.NewMockAuthenticator(ctrl)
+			cs.mocking(mockAuthenticator)
+
+			subject := ""
+			r.Use(auth.GinAuth(mockAuthenticator, cs.inputAnonymousRole))
+			r.GET("/", auth.RequireRole(cs.inputRequiredRole), func(ctx *gin.Context) {
+				subject = auth.PrincipalFromContext(ctx).Subject
+				ctx.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest("GET", "/", nil)
+			if cs.inputAuthorization != "" {
+				req.Header.Set("Authorization", cs.inputAuthorization)
+			}
+
+			// when
+			r.ServeHTTP(res, req)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Code)
+			assert.Equal(t, cs.expectedSubject, subject)
+		})
+	}
+}
+
+func Test_Auth_GrpcAuth(t *testing.T) {
+	var cases = map[string]struct {
+		mocking            func(authenticator *mock.MockAuthenticator)
+		inputMetadata      metadata.MD
+		inputAnonymousRole auth.Role
+		inputMethod        string
+		expectedErr        error
+	}{
+		"should allow api key with required role": {
+			mocking: func(authenticator *mock.MockAuthenticator) {
+				authenticator.EXPECT().Authenticate("admin-key", "").Return(&auth.Principal{Subject: "ops", Role: auth.RoleAdmin}, nil)
+			},
+			inputMetadata: metadata.Pairs("x-api-key", "admin-key"),
+			inputMethod:   "/starwars.PlanetService/DeletePlanet",
+		},
+		"should allow anonymous reader on methods not listed": {
+			mocking: func(authenticator *mock.MockAuthenticator) {
+				authenticator.EXPECT().Authenticate("", "").Return(nil, nil)
+			},
+			inputMetadata:      metadata.MD{},
+			inputAnonymousRole: auth.RoleReader,
+			inputMethod:        "/starwars.PlanetService/ListPlanets",
+		},
+		"should throw permission denied when role is not enough": {
+			mocking: func(authenticator *mock.MockAuthenticator) {
+				authenticator.EXPECT().Authenticate("", "").Return(nil, nil)
+			},
+			inputMetadata:      metadata.MD{},
+			inputAnonymousRole: auth.RoleReader,
+			inputMethod:        "/starwars.PlanetService/DeletePlanet",
+			expectedErr:        status.Error(codes.PermissionDenied, "forbidden"),
+		},
+		"should throw unauthenticated when credentials are invalid": {
+			mocking: func(authenticator *mock.MockAuthenticator) {
+				authenticator.EXPECT().Authenticate("", "token").Return(nil, auth.ErrInvalidCredentials)
+			},
+			inputMetadata: metadata.Pairs("authorization", "Bearer token"),
+			inputMethod:   "/starwars.PlanetService/ListPlanets",
+			expectedErr:   status.Error(codes.Unauthenticated, "invalid credentials"),
+		},
+		"should throw unauthenticated when anonymous access is disabled": {
+			mocking: func(authenticator *mock.MockAuthenticator) {
+				authenticator.EXPECT().Authenticate("", "").Return(nil, nil)
+			},
+			inputMetadata: metadata.MD{},
+			inputMethod:   "/starwars.PlanetService/ListPlanets",
+			expectedErr:   status.Error(codes.Unauthenticated, "unauthorized"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAuthenticator := mock.NewMockAuthenticator(ctrl)
+			cs.mocking(mockAuthenticator)
+
+			interceptor := auth.GrpcAuth(mockAuthenticator, cs.inputAnonymousRole, map[string]auth.Role{
+				"/starwars.PlanetService/DeletePlanet": auth.RoleAdmin,
+			})
+			ctx := metadata.NewIncomingContext(context.Background(), cs.inputMetadata)
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				assert.NotNil(t, auth.PrincipalFromContext(ctx))
+				return "ok", nil
+			}
+
+			// when
+			_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: cs.inputMethod}, handler)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func Test_Auth_ParseBearer(t *testing.T) {
+	var cases = map[string]struct {
+		inputAuthorization string
+		expectedToken      string
+	}{
+		"should return bearer token":         {inputAuthorization: "Bearer abc.def", expectedToken: "abc.def"},
+		"should ignore case of the scheme":   {inputAuthorization: "bearer abc.def", expectedToken: "abc.def"},
+		"should ignore other schemes":        {inputAuthorization: "Basic dXNlcg==", expectedToken: ""},
+		"should return empty when not given": {inputAuthorization: "", expectedToken: ""},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// when
+			token := auth.ParseBearer(cs.inputAuthorization)
+
+			// then
+			assert.Equal(t, cs.expectedToken, token)
+		})
+	}
+}
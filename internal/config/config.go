@@ -8,6 +8,12 @@ import (
 )
 
 type ServerConfig struct {
+	Host           string   `mapstructure:"host"`
+	Port           string   `mapstructure:"port"`
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
+}
+
+type GRPCConfig struct {
 	Host string `mapstructure:"host"`
 	Port string `mapstructure:"port"`
 }
@@ -20,9 +26,108 @@ type MySQLConfig struct {
 	Database string `mapstructure:"database"`
 }
 
+type ApiKeyConfig struct {
+	Name string `mapstructure:"name"`
+	Key  string `mapstructure:"key"`
+	Role string `mapstructure:"role"`
+}
+
+type JWTConfig struct {
+	Secret   string
+	JwksFile string `mapstructure:"jwks_file"`
+	Issuer   string `mapstructure:"issuer"`
+	Audience string `mapstructure:"audience"`
+}
+
+type AuthConfig struct {
+	AnonymousRole string         `mapstructure:"anonymous_role"`
+	ApiKeys       []ApiKeyConfig `mapstructure:"api_keys"`
+	JWT           JWTConfig      `mapstructure:"jwt"`
+}
+
+type RateLimitRouteConfig struct {
+	Method            string `mapstructure:"method"`
+	Path              string `mapstructure:"path"`
+	RequestsPerMinute int    `mapstructure:"requests_per_minute"`
+	Burst             int    `mapstructure:"burst"`
+}
+
+type RedisConfig struct {
+	Address  string `mapstructure:"address"`
+	Password string
+	DB       int `mapstructure:"db"`
+}
+
+type RateLimitConfig struct {
+	Enabled           bool                   `mapstructure:"enabled"`
+	Store             string                 `mapstructure:"store"`
+	RequestsPerMinute int                    `mapstructure:"requests_per_minute"`
+	Burst             int                    `mapstructure:"burst"`
+	Routes            []RateLimitRouteConfig `mapstructure:"routes"`
+	Redis             RedisConfig            `mapstructure:"redis"`
+}
+
+type WebhooksConfig struct {
+	Enabled             bool `mapstructure:"enabled"`
+	IntervalSeconds     int  `mapstructure:"interval_seconds"`
+	BatchSize           int  `mapstructure:"batch_size"`
+	MaxAttempts         int  `mapstructure:"max_attempts"`
+	BackoffSeconds      int  `mapstructure:"backoff_seconds"`
+	MaxBackoffSeconds   int  `mapstructure:"max_backoff_seconds"`
+	TimeoutSeconds      int  `mapstructure:"timeout_seconds"`
+	AllowPrivateTargets bool `mapstructure:"allow_private_targets"`
+}
+
+type OutboxConfig struct {
+	Enabled         bool   `mapstructure:"enabled"`
+	IntervalSeconds int    `mapstructure:"interval_seconds"`
+	BatchSize       int    `mapstructure:"batch_size"`
+	RetentionHours  int    `mapstructure:"retention_hours"`
+	Sink            string `mapstructure:"sink"`
+	File            string `mapstructure:"file"`
+	Topic           string `mapstructure:"topic"`
+}
+
+type StreamConfig struct {
+	BufferSize       int `mapstructure:"buffer_size"`
+	KeepAliveSeconds int `mapstructure:"keep_alive_seconds"`
+	PollIntervalMs   int `mapstructure:"poll_interval_ms"`
+	BatchSize        int `mapstructure:"batch_size"`
+	SettleSeconds    int `mapstructure:"settle_seconds"`
+}
+
+type StatsConfig struct {
+	CacheSeconds int `mapstructure:"cache_seconds"`
+}
+
+type SyncConfig struct {
+	Enabled  bool   `mapstructure:"enabled"`
+	Cron     string `mapstructure:"cron"`
+	LockName string `mapstructure:"lock_name"`
+}
+
+type FeedConfig struct {
+	OnInvalid  string `mapstructure:"on_invalid"`
+	Checkpoint bool   `mapstructure:"checkpoint"`
+}
+
+type ApiConfig struct {
+	V1Sunset string `mapstructure:"v1_sunset"`
+}
+
 type Config struct {
-	Server ServerConfig `mapstructure:"server"`
-	MySQL  MySQLConfig  `mapstructure:"mysql"`
+	Server    ServerConfig    `mapstructure:"server"`
+	Api       ApiConfig       `mapstructure:"api"`
+	GRPC      GRPCConfig      `mapstructure:"grpc"`
+	MySQL     MySQLConfig     `mapstructure:"mysql"`
+	Auth      AuthConfig      `mapstructure:"auth"`
+	RateLimit RateLimitConfig `mapstructure:"rate_limit"`
+	Webhooks  WebhooksConfig  `mapstructure:"webhooks"`
+	Outbox    OutboxConfig    `mapstructure:"outbox"`
+	Stream    StreamConfig    `mapstructure:"stream"`
+	Stats     StatsConfig     `mapstructure:"stats"`
+	Sync      SyncConfig      `mapstructure:"sync"`
+	Feed      FeedConfig      `mapstructure:"feed"`
 }
 
 func LoadConfig() Config {
@@ -42,6 +147,8 @@ func LoadConfig() Config {
 	}
 
 	configuration.MySQL.Password = os.Getenv("MYSQL_PASSWORD")
+	configuration.Auth.JWT.Secret = os.Getenv("JWT_SECRET")
+	configuration.RateLimit.Redis.Password = os.Getenv("REDIS_PASSWORD")
 
 	return configuration
 }
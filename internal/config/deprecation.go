@@ -0,0 +1,23 @@
+package config
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GinDeprecation marks the responses of a deprecated API version with the
+// Deprecation header, a Link to its successor and, when given, the Sunset date
+func GinDeprecation(successor string, sunset time.Time) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Deprecation", "true")
+		c.Header("Link", fmt.Sprintf("<%s>; rel=\"successor-version\"", successor))
+		if !sunset.IsZero() {
+			c.Header("Sunset", sunset.UTC().Format(http.TimeFormat))
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func GrpcLogger() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		res, err := handler(ctx, req)
+		duration := time.Now().UnixMilli() - start.UnixMilli()
+
+		code := status.Code(err)
+		entry := logrus.WithFields(logrus.Fields{
+			"duration_ms": duration,
+			"method":      info.FullMethod,
+			"code":        code.String(),
+		})
+
+		if code == codes.Internal || code == codes.Unknown {
+			entry.Error(err)
+		} else {
+			entry.Info("request")
+		}
+
+		return res, err
+	}
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	REQUEST_ID_KEY        = "request_id"
+	REQUEST_ID_MAX_LENGTH = 64
+)
+
+type requestIDKey struct{}
+
+// GinRequestID keeps the X-Request-Id header of the request, or a new uuid,
+// in the context and in the Request-Id response header read by GinLogger
+func GinRequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := ParseRequestID(c.GetHeader("X-Request-Id"))
+		c.Set(REQUEST_ID_KEY, requestID)
+		c.Header("Request-Id", requestID)
+
+		c.Next()
+	}
+}
+
+func GrpcRequestID() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		incoming := ""
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get("x-request-id"); len(values) > 0 {
+				incoming = values[0]
+			}
+		}
+
+		requestID := ParseRequestID(incoming)
+		grpc.SetHeader(ctx, metadata.Pairs("x-request-id", requestID))
+
+		return handler(WithRequestID(ctx, requestID), req)
+	}
+}
+
+func ParseRequestID(requestID string) string {
+	if requestID == "" || len(requestID) > REQUEST_ID_MAX_LENGTH {
+		return uuid.NewString()
+	}
+
+	return requestID
+}
+
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request id of a gin or gRPC request
+// context, or an empty string when there is none
+func RequestIDFromContext(ctx context.Context) string {
+	if requestID, ok := ctx.Value(requestIDKey{}).(string); ok {
+		return requestID
+	}
+	if requestID, ok := ctx.Value(REQUEST_ID_KEY).(string); ok {
+		return requestID
+	}
+
+	return ""
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const TRUSTED_PROXY_KEY = "trusted_proxy"
+
+// GinTrustedProxies marks the requests sent by one of the proxies, given as
+// ips or cidrs, so that their X-Forwarded-* headers can be honored
+func GinTrustedProxies(proxies []string) (gin.HandlerFunc, error) {
+	networks, err := ParseTrustedProxies(proxies)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(c *gin.Context) {
+		c.Set(TRUSTED_PROXY_KEY, isTrustedProxy(networks, net.ParseIP(c.RemoteIP())))
+
+		c.Next()
+	}, nil
+}
+
+func ParseTrustedProxies(proxies []string) ([]*net.IPNet, error) {
+	networks := []*net.IPNet{}
+	for _, proxy := range proxies {
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy %s", proxy)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %s", proxy)
+		}
+		networks = append(networks, network)
+	}
+
+	return networks, nil
+}
+
+func isTrustedProxy(networks []*net.IPNet, ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
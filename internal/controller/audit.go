@@ -0,0 +1,138 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/starwars-api/internal/auth"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/i18n"
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/viniosilva/starwars-api/internal/service"
+)
+
+type IAuditController struct {
+	AuditService service.AuditService
+}
+
+func (impl *IAuditController) Configure(router *gin.RouterGroup) {
+	router.GET("/audit", auth.RequireRole(auth.RoleAdmin), impl.FindAuditLogsAndTotal)
+}
+
+// @Summary find audit logs
+// @Schemes
+// @Tags audit
+// @Accept json
+// @Produce json
+// @Param page query int false "page"
+// @Param size query int false "size"
+// @Param entity query string false "planet or film"
+// @Param entityId query int false "entity id"
+// @Param from query string false "RFC 3339 start time, e.g. 2022-10-01T00:00:00Z"
+// @Param to query string false "RFC 3339 end time, e.g. 2022-10-31T23:59:59Z"
+// @Success 200 {object} dto.AuditLogsResponse
+// @Failure 400 {object} dto.ApiError
+// @Failure 401 {object} dto.ApiError
+// @Failure 403 {object} dto.ApiError
+// @Failure 429 {object} dto.ApiError
+// @Failure 500 {object} dto.ApiError
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/audit [get]
+func (impl *IAuditController) FindAuditLogsAndTotal(ctx *gin.Context) {
+	page, err := strconv.Atoi(ctx.Query("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	size, err := strconv.Atoi(ctx.Query("size"))
+	if err != nil || size < 1 {
+		size = 10
+	}
+
+	opts := []service.Option{}
+	if entity := ctx.Query("entity"); entity != "" {
+		if entity != service.AUDIT_ENTITY_PLANET && entity != service.AUDIT_ENTITY_FILM {
+			ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, "invalid entity")})
+			return
+		}
+		opts = append(opts, service.OptionWhere(fmt.Sprintf("%s = ?", model.AuditLogColumns.Entity), entity))
+	}
+	if raw := ctx.Query("entityId"); raw != "" {
+		entityID, err := strconv.Atoi(raw)
+		if err != nil || entityID < 1 {
+			ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, "invalid entity id")})
+			return
+		}
+		opts = append(opts, service.OptionWhere(fmt.Sprintf("%s = ?", model.AuditLogColumns.EntityID), entityID))
+	}
+	if raw := ctx.Query("from"); raw != "" {
+		from, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, "invalid from")})
+			return
+		}
+		opts = append(opts, service.OptionWhere(fmt.Sprintf("%s >= ?", model.AuditLogColumns.CreatedAt), from.UTC()))
+	}
+	if raw := ctx.Query("to"); raw != "" {
+		to, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, "invalid to")})
+			return
+		}
+		opts = append(opts, service.OptionWhere(fmt.Sprintf("%s <= ?", model.AuditLogColumns.CreatedAt), to.UTC()))
+	}
+
+	res, err := impl.AuditService.FindAuditLogsAndTotal(ctx, page, size, opts...)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ApiError{Error: i18n.T(ctx, "internal server error")})
+		return
+	}
+
+	data := make([]dto.AuditLogDto, res.Count)
+	for i := 0; i < len(data); i += 1 {
+		data[i] = impl.ParseAuditLogDto(res.Data[i])
+	}
+
+	previous := ""
+	if page > 1 {
+		previous = RequestPageURL(ctx, page-1)
+	}
+
+	next := ""
+	if res.Next {
+		next = RequestPageURL(ctx, page+1)
+	}
+
+	ctx.JSON(http.StatusOK, dto.AuditLogsResponse{
+		Pagination: dto.Pagination{
+			Count:    len(data),
+			Total:    res.Total,
+			Previous: previous,
+			Next:     next,
+		},
+		Data: data,
+	})
+}
+
+func (impl *IAuditController) ParseAuditLogDto(log *model.AuditLog) dto.AuditLogDto {
+	res := dto.AuditLogDto{
+		ID:        log.ID,
+		CreatedAt: log.CreatedAt.Format("2006-01-02 15:04:05"),
+		Actor:     log.Actor,
+		RequestID: log.RequestID.String,
+		Entity:    log.Entity,
+		EntityID:  log.EntityID,
+		Action:    log.Action,
+	}
+	if log.BeforeData.Valid {
+		res.Before = log.BeforeData.JSON
+	}
+	if log.AfterData.Valid {
+		res.After = log.AfterData.JSON
+	}
+
+	return res
+}
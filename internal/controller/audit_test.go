@@ -0,0 +1,124 @@
+package controller_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/auth"
+	"github.com/viniosilva/starwars-api/internal/controller"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/viniosilva/starwars-api/internal/service"
+	"github.com/viniosilva/starwars-api/mock"
+	"github.com/volatiletech/null/v8"
+)
+
+func Test_AuditController_FindAuditLogsAndTotal(t *testing.T) {
+	var cases = map[string]struct {
+		mocking            func(auditService *mock.MockAuditService)
+		inputQuery         string
+		inputRole          auth.Role
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		"should return audit logs": {
+			mocking: func(auditService *mock.MockAuditService) {
+				auditService.EXPECT().FindAuditLogsAndTotal(gomock.Any(), 1, 1,
+					service.OptionWhere("entity = ?", "planet"),
+					service.OptionWhere("entity_id = ?", 1),
+					service.OptionWhere("created_at >= ?", time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)),
+					service.OptionWhere("created_at <= ?", time.Date(2022, 10, 31, 0, 0, 0, 0, time.UTC)),
+				).Return(dto.FindAuditLogsAndTotalResult{
+					Count: 1,
+					Total: 2,
+					Next:  true,
+					Data: []*model.AuditLog{{
+						ID:         2,
+						CreatedAt:  time.Date(2022, 10, 2, 12, 0, 0, 0, time.UTC),
+						Actor:      "ops",
+						RequestID:  null.StringFrom("request-id"),
+						Entity:     "planet",
+						EntityID:   1,
+						Action:     "delete",
+						BeforeData: null.JSONFrom([]byte(`{"id":1}`)),
+						AfterData:  null.JSONFrom([]byte(`{"id":1,"deleted_at":"2022-10-02T12:00:00Z"}`)),
+					}},
+				}, nil)
+			},
+			inputQuery:         "?size=1&entity=planet&entityId=1&from=2022-10-01T00:00:00Z&to=2022-10-31T00:00:00Z",
+			inputRole:          auth.RoleAdmin,
+			expectedStatusCode: http.StatusOK,
+			expectedBody: `{"count":1,"total":2,"previous":"","next":"http://example.com/api/audit?entity=planet\u0026entityId=1\u0026from=2022-10-01T00%3A00%3A00Z\u0026page=2\u0026size=1\u0026to=2022-10-31T00%3A00%3A00Z",` +
+				`"data":[{"id":2,"created_at":"2022-10-02 12:00:00","actor":"ops","request_id":"request-id","entity":"planet","entity_id":1,"action":"delete","before":{"id":1},"after":{"id":1,"deleted_at":"2022-10-02T12:00:00Z"}}]}`,
+		},
+		"should throw bad request when entity is invalid": {
+			mocking:            func(auditService *mock.MockAuditService) {},
+			inputQuery:         "?entity=starship",
+			inputRole:          auth.RoleAdmin,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid entity"}`,
+		},
+		"should throw bad request when entity id is invalid": {
+			mocking:            func(auditService *mock.MockAuditService) {},
+			inputQuery:         "?entityId=abc",
+			inputRole:          auth.RoleAdmin,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid entity id"}`,
+		},
+		"should throw bad request when from is invalid": {
+			mocking:            func(auditService *mock.MockAuditService) {},
+			inputQuery:         "?from=yesterday",
+			inputRole:          auth.RoleAdmin,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid from"}`,
+		},
+		"should throw forbidden when role is not admin": {
+			mocking:            func(auditService *mock.MockAuditService) {},
+			inputRole:          auth.RoleEditor,
+			expectedStatusCode: http.StatusForbidden,
+			expectedBody:       `{"error":"forbidden"}`,
+		},
+		"should throw internal server error when find audit logs": {
+			mocking: func(auditService *mock.MockAuditService) {
+				auditService.EXPECT().FindAuditLogsAndTotal(gomock.Any(), 1, 10).Return(dto.FindAuditLogsAndTotalResult{}, fmt.Errorf("error"))
+			},
+			inputRole:          auth.RoleAdmin,
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       `{"error":"internal server error"}`,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			_, r := gin.CreateTestContext(res)
+			r.Use(auth.GinAuth(&auth.IAuthenticator{}, cs.inputRole))
+
+			mockAuditService := mock.NewMockAuditService(ctrl)
+
+			auditController := &controller.IAuditController{
+				AuditService: mockAuditService,
+			}
+			auditController.Configure(r.Group("/api"))
+
+			cs.mocking(mockAuditService)
+
+			// when
+			r.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/api/audit"+cs.inputQuery, nil))
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Code)
+			assert.Equal(t, cs.expectedBody, res.Body.String())
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/starwars-api/internal/auth"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/i18n"
+	"github.com/viniosilva/starwars-api/internal/pubsub"
+	"github.com/viniosilva/starwars-api/internal/service"
+)
+
+const (
+	EVENTS_SUBSCRIBER_BUFFER  = 100
+	EVENTS_RETRY_MILLISECONDS = 3000
+	EVENTS_KEEP_ALIVE         = 15 * time.Second
+)
+
+type IEventController struct {
+	Hub       pubsub.Hub
+	KeepAlive time.Duration
+}
+
+func (impl *IEventController) Configure(router *gin.RouterGroup) {
+	router.GET("/events", auth.RequireRole(auth.RoleReader), impl.StreamEvents)
+}
+
+// @Summary stream planet and film change events
+// @Schemes
+// @Tags event
+// @Produce text/event-stream
+// @Param types query string false "comma separated event types, e.g. planet.deleted,film.linked"
+// @Param Last-Event-ID header int false "id of the last event received, to resume the stream"
+// @Success 200 {object} dto.Event
+// @Failure 400 {object} dto.ApiError
+// @Failure 401 {object} dto.ApiError
+// @Failure 403 {object} dto.ApiError
+// @Failure 429 {object} dto.ApiError
+// @Router /api/events [get]
+func (impl *IEventController) StreamEvents(ctx *gin.Context) {
+	types := map[string]bool{}
+	if raw := ctx.Query("types"); raw != "" {
+		for _, t := range strings.Split(raw, ",") {
+			t = strings.TrimSpace(t)
+			if !service.IsEventType(t) {
+				ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, fmt.Sprintf("invalid event type %s", t))})
+				return
+			}
+			types[t] = true
+		}
+	}
+
+	lastID := uint64(0)
+	if raw := ctx.GetHeader("Last-Event-ID"); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, "invalid last event id")})
+			return
+		}
+		lastID = id
+	}
+
+	replay, entries, unsubscribe := impl.Hub.Subscribe(lastID, EVENTS_SUBSCRIBER_BUFFER)
+	defer unsubscribe()
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+
+	fmt.Fprintf(ctx.Writer, "retry: %d\n\n", EVENTS_RETRY_MILLISECONDS)
+	for _, entry := range replay {
+		if err := impl.writeEntry(ctx.Writer, entry, types); err != nil {
+			return
+		}
+	}
+	ctx.Writer.Flush()
+
+	interval := impl.KeepAlive
+	if interval <= 0 {
+		interval = EVENTS_KEEP_ALIVE
+	}
+	keepAlive := time.NewTicker(interval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-ctx.Request.Context().Done():
+			return
+		case entry, ok := <-entries:
+			if !ok {
+				return
+			}
+			if err := impl.writeEntry(ctx.Writer, entry, types); err != nil {
+				return
+			}
+		case <-keepAlive.C:
+			if _, err := io.WriteString(ctx.Writer, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		}
+		ctx.Writer.Flush()
+	}
+}
+
+func (impl *IEventController) writeEntry(w io.Writer, entry pubsub.Entry, types map[string]bool) error {
+	if len(types) > 0 && !types[entry.Event.Type] {
+		return nil
+	}
+
+	data, err := json.Marshal(entry.Event)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", entry.ID, entry.Event.Type, data)
+	return err
+}
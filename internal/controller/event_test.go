@@ -0,0 +1,104 @@
+package controller_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/auth"
+	"github.com/viniosilva/starwars-api/internal/controller"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/pubsub"
+)
+
+func Test_EventController_StreamEvents(t *testing.T) {
+	occurredAt := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	deleted := dto.Event{ID: "event-1", Type: "planet.deleted", Entity: "planet", EntityID: 1, OccurredAt: occurredAt, Data: []byte(`{}`)}
+	linked := dto.Event{ID: "event-2", Type: "film.linked", Entity: "film", EntityID: 1, OccurredAt: occurredAt, Data: []byte(`{}`)}
+
+	var cases = map[string]struct {
+		inputQuery         string
+		inputLastEventID   string
+		inputLive          []pubsub.Entry
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		"should stream live events": {
+			inputLive:          []pubsub.Entry{{ID: 3, Event: deleted}},
+			expectedStatusCode: http.StatusOK,
+			expectedBody: "retry: 3000\n\n" +
+				"id: 3\nevent: planet.deleted\ndata: {\"id\":\"event-1\",\"type\":\"planet.deleted\",\"entity\":\"planet\",\"entity_id\":1,\"occurred_at\":\"2022-10-01T12:00:00Z\",\"data\":{}}\n\n",
+		},
+		"should resume after last event id": {
+			inputLastEventID:   "1",
+			expectedStatusCode: http.StatusOK,
+			expectedBody: "retry: 3000\n\n" +
+				"id: 2\nevent: film.linked\ndata: {\"id\":\"event-2\",\"type\":\"film.linked\",\"entity\":\"film\",\"entity_id\":1,\"occurred_at\":\"2022-10-01T12:00:00Z\",\"data\":{}}\n\n",
+		},
+		"should filter event types": {
+			inputQuery:         "?types=film.linked",
+			inputLastEventID:   "0",
+			inputLive:          []pubsub.Entry{{ID: 3, Event: deleted}, {ID: 4, Event: linked}},
+			expectedStatusCode: http.StatusOK,
+			expectedBody: "retry: 3000\n\n" +
+				"id: 4\nevent: film.linked\ndata: {\"id\":\"event-2\",\"type\":\"film.linked\",\"entity\":\"film\",\"entity_id\":1,\"occurred_at\":\"2022-10-01T12:00:00Z\",\"data\":{}}\n\n",
+		},
+		"should throw bad request when event type is invalid": {
+			inputQuery:         "?types=starship.created",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid event type starship.created"}`,
+		},
+		"should throw bad request when last event id is invalid": {
+			inputLastEventID:   "abc",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid last event id"}`,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			hub := pubsub.NewHub(10)
+			hub.Publish(pubsub.Entry{ID: 1, Event: deleted}, pubsub.Entry{ID: 2, Event: linked})
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.Use(auth.GinAuth(&auth.IAuthenticator{}, auth.RoleReader))
+
+			eventController := &controller.IEventController{Hub: hub, KeepAlive: time.Minute}
+			eventController.Configure(r.Group("/api"))
+
+			server := httptest.NewServer(r)
+			defer server.Close()
+
+			reqCtx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			req, _ := http.NewRequestWithContext(reqCtx, http.MethodGet, server.URL+"/api/events"+cs.inputQuery, nil)
+			if cs.inputLastEventID != "" {
+				req.Header.Set("Last-Event-ID", cs.inputLastEventID)
+			}
+
+			// when
+			res, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+
+			hub.Publish(cs.inputLive...)
+
+			body := make([]byte, len(cs.expectedBody))
+			_, err = io.ReadFull(res.Body, body)
+
+			// then
+			assert.Nil(t, err)
+			assert.Equal(t, cs.expectedStatusCode, res.StatusCode)
+			assert.Equal(t, cs.expectedBody, string(body))
+		})
+	}
+}
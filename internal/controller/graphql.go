@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/viniosilva/starwars-api/internal/auth"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/graph"
+	"github.com/viniosilva/starwars-api/internal/i18n"
+	"github.com/viniosilva/starwars-api/internal/service"
+)
+
+type IGraphQLController struct {
+	PlanetService      service.PlanetService
+	FilmService        service.FilmService
+	LookupService      service.LookupService
+	TranslationService service.TranslationService
+	schema             *graphql.Schema
+}
+
+func (impl *IGraphQLController) Configure(router *gin.RouterGroup) {
+	impl.schema = graph.NewSchema(impl.PlanetService, impl.FilmService, impl.LookupService, impl.TranslationService)
+
+	router.POST("/graphql", auth.RequireRole(auth.RoleReader), impl.Query)
+	router.GET("/graphql", auth.RequireRole(auth.RoleReader), impl.Query)
+}
+
+// @Summary graphql query over planets and films
+// @Schemes
+// @Tags graphql
+// @Accept json
+// @Produce json
+// @Param request body dto.GraphQLRequest true "GraphQL request"
+// @Success 200 {object} dto.GraphQLResponse
+// @Failure 400 {object} dto.ApiError
+// @Failure 401 {object} dto.ApiError
+// @Failure 403 {object} dto.ApiError
+// @Failure 429 {object} dto.ApiError
+// @Router /api/graphql [post]
+func (impl *IGraphQLController) Query(ctx *gin.Context) {
+	var req dto.GraphQLRequest
+	if ctx.Request.Method == http.MethodGet {
+		req.Query = ctx.Query("query")
+		req.OperationName = ctx.Query("operationName")
+		if v := ctx.Query("variables"); v != "" {
+			if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+				ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, "invalid graphql request")})
+				return
+			}
+		}
+	} else if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, "invalid graphql request")})
+		return
+	}
+
+	if req.Query == "" {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, "invalid graphql request")})
+		return
+	}
+
+	loaders := graph.NewLoaders(impl.PlanetService, impl.FilmService, impl.TranslationService)
+	res := impl.schema.Exec(graph.WithLoaders(ctx, loaders), req.Query, req.OperationName, req.Variables)
+
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,148 @@
+package controller_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/controller"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/exception"
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/viniosilva/starwars-api/internal/service"
+	"github.com/viniosilva/starwars-api/mock"
+)
+
+func Test_GraphQLController_Query(t *testing.T) {
+	var cases = map[string]struct {
+		mocking            func(planetService *mock.MockPlanetService, filmService *mock.MockFilmService)
+		inputBody          string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		"should return planets with films loaded in one batch": {
+			mocking: func(planetService *mock.MockPlanetService, filmService *mock.MockFilmService) {
+				planetService.EXPECT().FindPlanetsAndTotal(gomock.Any(), 1, 2, false).
+					Return(dto.FindPlanetsAndTotalResult{Count: 2, Total: 3, Next: true, Data: []*model.Planet{
+						{ID: 1, Name: "Tatooine"},
+						{ID: 2, Name: "Alderaan"},
+					}}, nil)
+				filmService.EXPECT().FindFilmsByPlanetIDs(gomock.Any(), gomock.Any()).
+					Return(map[int][]*model.Film{1: {{ID: 1, Title: "A New Hope"}}}, nil).Times(1)
+			},
+			inputBody:          `{"query":"{ planets(size: 2) { pagination { count total hasNext } data { name films { title } } } }"}`,
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"data":{"planets":{"pagination":{"count":2,"total":3,"hasNext":true},"data":[{"name":"Tatooine","films":[{"title":"A New Hope"}]},{"name":"Alderaan","films":[]}]}}}`,
+		},
+		"should filter planets by climate": {
+			mocking: func(planetService *mock.MockPlanetService, filmService *mock.MockFilmService) {
+				planetService.EXPECT().FindPlanetsAndTotal(gomock.Any(), 1, 10, false, service.OptionWhereClimate("arid")).
+					Return(dto.FindPlanetsAndTotalResult{Count: 1, Total: 1, Data: []*model.Planet{{ID: 1, Name: "Tatooine"}}}, nil)
+			},
+			inputBody:          `{"query":"{ planets(climate: \"arid\") { data { name } } }"}`,
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"data":{"planets":{"data":[{"name":"Tatooine"}]}}}`,
+		},
+		"should return film with planets": {
+			mocking: func(planetService *mock.MockPlanetService, filmService *mock.MockFilmService) {
+				filmService.EXPECT().FindFilmByID(gomock.Any(), 1).
+					Return(&model.Film{ID: 1, Title: "A New Hope", Episode: 4}, nil)
+				planetService.EXPECT().FindPlanetsByFilmIDs(gomock.Any(), []int{1}).
+					Return(map[int][]*model.Planet{1: {{ID: 1, Name: "Tatooine"}}}, nil)
+			},
+			inputBody:          `{"query":"query($id: Int!) { film(id: $id) { title episode planets { name } } }","variables":{"id":1}}`,
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"data":{"film":{"title":"A New Hope","episode":4,"planets":[{"name":"Tatooine"}]}}}`,
+		},
+		"should return null when planet not found": {
+			mocking: func(planetService *mock.MockPlanetService, filmService *mock.MockFilmService) {
+				planetService.EXPECT().FindPlanetByID(gomock.Any(), 1, false).
+					Return(nil, &exception.NotFoundException{Message: "planet 1 not found"})
+			},
+			inputBody:          `{"query":"{ planet(id: 1) { name } }"}`,
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"data":{"planet":null}}`,
+		},
+		"should return graphql error when service fails": {
+			mocking: func(planetService *mock.MockPlanetService, filmService *mock.MockFilmService) {
+				filmService.EXPECT().FindFilmsAndTotal(gomock.Any(), 1, 10).
+					Return(dto.FindFilmsAndTotalResult{}, fmt.Errorf("error"))
+			},
+			inputBody:          `{"query":"{ films { data { title } } }"}`,
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"errors":[{"message":"internal server error","path":["films"]}],"data":null}`,
+		},
+		"should throw bad request when query is empty": {
+			mocking:            func(planetService *mock.MockPlanetService, filmService *mock.MockFilmService) {},
+			inputBody:          `{}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid graphql request"}`,
+		},
+		"should throw bad request when body is invalid": {
+			mocking:            func(planetService *mock.MockPlanetService, filmService *mock.MockFilmService) {},
+			inputBody:          `{`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid graphql request"}`,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(res)
+			ctx.Request = httptest.NewRequest("POST", "/api/graphql", strings.NewReader(cs.inputBody))
+
+			mockPlanetService := mock.NewMockPlanetService(ctrl)
+			mockFilmService := mock.NewMockFilmService(ctrl)
+
+			graphqlController := &controller.IGraphQLController{PlanetService: mockPlanetService, FilmService: mockFilmService, LookupService: mock.NewMockLookupService(ctrl), TranslationService: mock.NewMockTranslationService(ctrl)}
+			graphqlController.Configure(r.Group("/api"))
+
+			cs.mocking(mockPlanetService, mockFilmService)
+
+			// when
+			graphqlController.Query(ctx)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.JSONEq(t, cs.expectedBody, res.Body.String())
+		})
+	}
+}
+
+func Test_GraphQLController_QueryWithGet(t *testing.T) {
+	// given
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gin.SetMode(gin.TestMode)
+	res := httptest.NewRecorder()
+	ctx, r := gin.CreateTestContext(res)
+	ctx.Request = httptest.NewRequest("GET", `/api/graphql?query={planet(id:1){name}}`, nil)
+
+	mockPlanetService := mock.NewMockPlanetService(ctrl)
+	mockPlanetService.EXPECT().FindPlanetByID(gomock.Any(), 1, false).Return(&model.Planet{ID: 1, Name: "Tatooine"}, nil)
+
+	graphqlController := &controller.IGraphQLController{PlanetService: mockPlanetService, FilmService: mock.NewMockFilmService(ctrl), LookupService: mock.NewMockLookupService(ctrl), TranslationService: mock.NewMockTranslationService(ctrl)}
+	graphqlController.Configure(r.Group("/api"))
+
+	// when
+	graphqlController.Query(ctx)
+
+	var body map[string]interface{}
+	json.Unmarshal(res.Body.Bytes(), &body)
+
+	// then
+	assert.Equal(t, http.StatusOK, res.Result().StatusCode)
+	assert.Equal(t, map[string]interface{}{"data": map[string]interface{}{"planet": map[string]interface{}{"name": "Tatooine"}}}, body)
+}
@@ -0,0 +1,45 @@
+package controller
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/starwars-api/internal/config"
+)
+
+// RequestBaseURL returns the scheme and host the client used to reach the
+// API. The X-Forwarded-Proto and X-Forwarded-Host headers are honored only
+// when the request comes from a trusted proxy, see config.GinTrustedProxies
+func RequestBaseURL(ctx *gin.Context) string {
+	scheme := "http"
+	if ctx.Request.TLS != nil {
+		scheme = "https"
+	}
+	host := ctx.Request.Host
+
+	if ctx.GetBool(config.TRUSTED_PROXY_KEY) {
+		if proto := firstForwarded(ctx.GetHeader("X-Forwarded-Proto")); proto == "http" || proto == "https" {
+			scheme = proto
+		}
+		if forwarded := firstForwarded(ctx.GetHeader("X-Forwarded-Host")); forwarded != "" {
+			host = forwarded
+		}
+	}
+
+	return fmt.Sprintf("%s://%s", scheme, host)
+}
+
+// RequestPageURL links to another page of the request, keeping every query
+// parameter but page
+func RequestPageURL(ctx *gin.Context, page int) string {
+	query := ctx.Request.URL.Query()
+	query.Set("page", strconv.Itoa(page))
+
+	return fmt.Sprintf("%s%s?%s", RequestBaseURL(ctx), ctx.Request.URL.Path, query.Encode())
+}
+
+func firstForwarded(header string) string {
+	return strings.TrimSpace(strings.Split(header, ",")[0])
+}
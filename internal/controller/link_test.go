@@ -0,0 +1,85 @@
+package controller_test
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/config"
+	"github.com/viniosilva/starwars-api/internal/controller"
+)
+
+func Test_RequestBaseURL(t *testing.T) {
+	var cases = map[string]struct {
+		inputHeaders    map[string]string
+		inputTLS        bool
+		inputTrusted    bool
+		expectedBaseURL string
+	}{
+		"should use request host": {
+			expectedBaseURL: "http://example.com",
+		},
+		"should use https when request is tls": {
+			inputTLS:        true,
+			expectedBaseURL: "https://example.com",
+		},
+		"should use forwarded proto and host of trusted proxy": {
+			inputHeaders:    map[string]string{"X-Forwarded-Proto": "https", "X-Forwarded-Host": "api.starwars.dev"},
+			inputTrusted:    true,
+			expectedBaseURL: "https://api.starwars.dev",
+		},
+		"should use first forwarded values": {
+			inputHeaders:    map[string]string{"X-Forwarded-Proto": "https, http", "X-Forwarded-Host": "api.starwars.dev, proxy.local"},
+			inputTrusted:    true,
+			expectedBaseURL: "https://api.starwars.dev",
+		},
+		"should ignore invalid forwarded proto": {
+			inputHeaders:    map[string]string{"X-Forwarded-Proto": "ftp"},
+			inputTrusted:    true,
+			expectedBaseURL: "http://example.com",
+		},
+		"should ignore forwarded headers of untrusted client": {
+			inputHeaders:    map[string]string{"X-Forwarded-Proto": "https", "X-Forwarded-Host": "evil.example"},
+			expectedBaseURL: "http://example.com",
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			gin.SetMode(gin.TestMode)
+			ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/api/planets", nil)
+			if cs.inputTLS {
+				ctx.Request.TLS = &tls.ConnectionState{}
+			}
+			for k, v := range cs.inputHeaders {
+				ctx.Request.Header.Set(k, v)
+			}
+			ctx.Set(config.TRUSTED_PROXY_KEY, cs.inputTrusted)
+
+			// when
+			baseURL := controller.RequestBaseURL(ctx)
+
+			// then
+			assert.Equal(t, cs.expectedBaseURL, baseURL)
+		})
+	}
+}
+
+func Test_RequestPageURL(t *testing.T) {
+	// given
+	gin.SetMode(gin.TestMode)
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/api/planets?name=tatooine&loadFilms=true&page=2&size=5&climate=arid", nil)
+	ctx.Request.Header.Set("X-Forwarded-Host", "api.starwars.dev")
+	ctx.Set(config.TRUSTED_PROXY_KEY, true)
+
+	// when
+	pageURL := controller.RequestPageURL(ctx, 3)
+
+	// then
+	assert.Equal(t, "http://api.starwars.dev/api/planets?climate=arid&loadFilms=true&name=tatooine&page=3&size=5", pageURL)
+}
@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/starwars-api/internal/auth"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/i18n"
+	"github.com/viniosilva/starwars-api/internal/service"
+)
+
+type ILookupController struct {
+	LookupService service.LookupService
+}
+
+func (impl *ILookupController) Configure(router *gin.RouterGroup) {
+	router.GET("/climates", auth.RequireRole(auth.RoleReader), impl.FindClimates)
+	router.GET("/terrains", auth.RequireRole(auth.RoleReader), impl.FindTerrains)
+}
+
+// @Summary find climates with their planet counts
+// @Schemes
+// @Tags lookup
+// @Accept json
+// @Produce json
+// @Success 200 {object} dto.LookupsResponse
+// @Failure 401 {object} dto.ApiError
+// @Failure 403 {object} dto.ApiError
+// @Failure 429 {object} dto.ApiError
+// @Failure 500 {object} dto.ApiError
+// @Router /api/climates [get]
+func (impl *ILookupController) FindClimates(ctx *gin.Context) {
+	res, err := impl.LookupService.FindClimates(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ApiError{Error: i18n.T(ctx, "internal server error")})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.LookupsResponse{Count: len(res), Data: res})
+}
+
+// @Summary find terrains with their planet counts
+// @Schemes
+// @Tags lookup
+// @Accept json
+// @Produce json
+// @Success 200 {object} dto.LookupsResponse
+// @Failure 401 {object} dto.ApiError
+// @Failure 403 {object} dto.ApiError
+// @Failure 429 {object} dto.ApiError
+// @Failure 500 {object} dto.ApiError
+// @Router /api/terrains [get]
+func (impl *ILookupController) FindTerrains(ctx *gin.Context) {
+	res, err := impl.LookupService.FindTerrains(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ApiError{Error: i18n.T(ctx, "internal server error")})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.LookupsResponse{Count: len(res), Data: res})
+}
@@ -0,0 +1,78 @@
+package controller_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/auth"
+	"github.com/viniosilva/starwars-api/internal/controller"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/mock"
+)
+
+func Test_LookupController(t *testing.T) {
+	var cases = map[string]struct {
+		mocking            func(lookupService *mock.MockLookupService)
+		inputPath          string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		"should find climates": {
+			mocking: func(lookupService *mock.MockLookupService) {
+				lookupService.EXPECT().FindClimates(gomock.Any()).
+					Return([]dto.LookupDto{{ID: 1, Name: "arid", Planets: 3}, {ID: 2, Name: "frozen", Planets: 0}}, nil)
+			},
+			inputPath:          "/api/climates",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"count":2,"data":[{"id":1,"name":"arid","planets":3},{"id":2,"name":"frozen","planets":0}]}`,
+		},
+		"should find terrains": {
+			mocking: func(lookupService *mock.MockLookupService) {
+				lookupService.EXPECT().FindTerrains(gomock.Any()).
+					Return([]dto.LookupDto{{ID: 1, Name: "desert", Planets: 2}}, nil)
+			},
+			inputPath:          "/api/terrains",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"count":1,"data":[{"id":1,"name":"desert","planets":2}]}`,
+		},
+		"should throw internal server error when find climates": {
+			mocking: func(lookupService *mock.MockLookupService) {
+				lookupService.EXPECT().FindClimates(gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			inputPath:          "/api/climates",
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       `{"error":"internal server error"}`,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			_, r := gin.CreateTestContext(res)
+			r.Use(auth.GinAuth(&auth.IAuthenticator{}, auth.RoleReader))
+
+			mockLookupService := mock.NewMockLookupService(ctrl)
+
+			lookupController := &controller.ILookupController{LookupService: mockLookupService}
+			lookupController.Configure(r.Group("/api"))
+
+			cs.mocking(mockLookupService)
+
+			// when
+			r.ServeHTTP(res, httptest.NewRequest(http.MethodGet, cs.inputPath, nil))
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Code)
+			assert.Equal(t, cs.expectedBody, res.Body.String())
+		})
+	}
+}
@@ -4,24 +4,58 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/viniosilva/starwars-api/internal/auth"
 	"github.com/viniosilva/starwars-api/internal/dto"
 	"github.com/viniosilva/starwars-api/internal/exception"
+	"github.com/viniosilva/starwars-api/internal/export"
+	"github.com/viniosilva/starwars-api/internal/i18n"
 	"github.com/viniosilva/starwars-api/internal/model"
 	"github.com/viniosilva/starwars-api/internal/service"
 )
 
+const (
+	EXPORT_FLUSH_SIZE             = 100
+	PLANETS_BATCH_MAX_IDS         = 1000
+	PLANETS_BULK_MAX_OPERATIONS   = 100
+	PLANETS_BULK_MODE_ATOMIC      = "atomic"
+	PLANETS_BULK_MODE_BEST_EFFORT = "best_effort"
+)
+
+// IPlanetController runs Deprecation, when set, before the routes that
+// have an /api/v2 equivalent. LinkFilms adds the links to the routes of
+// IPlanetFilmController, so set it only when that controller is configured
 type IPlanetController struct {
-	PlanetService service.PlanetService
-	Host          string
+	PlanetService      service.PlanetService
+	ImportService      service.ImportService
+	TranslationService service.TranslationService
+	Deprecation        gin.HandlerFunc
+	LinkFilms          bool
 }
 
 func (impl *IPlanetController) Configure(router *gin.RouterGroup) {
-	router.GET("/planets", impl.FindPlanetsAndTotal)
-	router.GET("/planets/:planetID", impl.FindPlanetByID)
-	router.DELETE("/planets/:planetID", impl.DeletePlanet)
+	router.GET("/planets", impl.deprecated(auth.RequireRole(auth.RoleReader), impl.FindPlanetsAndTotal)...)
+	router.GET("/planets/export", auth.RequireRole(auth.RoleReader), impl.ExportPlanets)
+	router.POST("/planets/import", auth.RequireRole(auth.RoleEditor), impl.ImportPlanets)
+	router.POST("/planets/batch-get", auth.RequireRole(auth.RoleReader), impl.BatchGetPlanets)
+	router.POST("/planets/bulk", auth.RequireRole(auth.RoleAdmin), impl.BulkPlanets)
+	router.GET("/planets/:planetID", impl.deprecated(auth.RequireRole(auth.RoleReader), impl.FindPlanetByID)...)
+	router.GET("/planets/:planetID/history", auth.RequireRole(auth.RoleReader), impl.FindPlanetHistory)
+	router.DELETE("/planets/:planetID", auth.RequireRole(auth.RoleAdmin), impl.DeletePlanet)
+}
+
+func (impl *IPlanetController) deprecated(handlers ...gin.HandlerFunc) []gin.HandlerFunc {
+	if impl.Deprecation == nil {
+		return handlers
+	}
+
+	return append([]gin.HandlerFunc{impl.Deprecation}, handlers...)
 }
 
 // @Summary find planets
@@ -33,10 +67,40 @@ func (impl *IPlanetController) Configure(router *gin.RouterGroup) {
 // @Param size query int false "size"
 // @Param loadFilms query bool false "loadFilms"
 // @Param name query string false "name"
+// @Param climate query string false "climate"
+// @Param terrain query string false "terrain"
+// @Param rotationPeriodMin query number false "minimum rotationPeriod"
+// @Param rotationPeriodMax query number false "maximum rotationPeriod"
+// @Param orbitalPeriodMin query number false "minimum orbitalPeriod"
+// @Param orbitalPeriodMax query number false "maximum orbitalPeriod"
+// @Param diameterMin query number false "minimum diameter"
+// @Param diameterMax query number false "maximum diameter"
+// @Param surfaceWaterMin query number false "minimum surfaceWater"
+// @Param surfaceWaterMax query number false "maximum surfaceWater"
+// @Param populationMin query number false "minimum population"
+// @Param populationMax query number false "maximum population"
+// @Param fields query string false "comma separated planet fields, e.g. name,climates"
+// @Param embed query string false "embedded relationship, e.g. films(title,episode)"
+// @Param ids query string false "comma separated planet ids, answered as dto.PlanetsBatchResponse, e.g. 1,2,3"
 // @Success 200 {object} dto.PlanetsResponse
+// @Failure 400 {object} dto.ApiError
+// @Failure 401 {object} dto.ApiError
+// @Failure 403 {object} dto.ApiError
+// @Failure 429 {object} dto.ApiError
 // @Failure 500 {object} dto.ApiError
 // @Router /api/planets [get]
 func (impl *IPlanetController) FindPlanetsAndTotal(ctx *gin.Context) {
+	if raw, ok := ctx.GetQuery("ids"); ok {
+		ids, err := impl.ParseIDs(raw)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, err.Error())})
+			return
+		}
+
+		impl.findPlanetsByIDs(ctx, ids, ctx.Query("loadFilms") == "true")
+		return
+	}
+
 	page, err := strconv.Atoi(ctx.Query("page"))
 	if err != nil || page < 1 {
 		page = 1
@@ -50,14 +114,39 @@ func (impl *IPlanetController) FindPlanetsAndTotal(ctx *gin.Context) {
 		loadFilms = true
 	}
 
-	opts := make([]service.Option, 1)
-	if n := ctx.Query("name"); n != "" {
-		opts[0] = service.OptionWhere("name like ?", n)
+	fields, columns, err := impl.ParseFields(ctx.Query("fields"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, err.Error())})
+		return
+	}
+	filmFields, filmColumns, embed, err := impl.ParseEmbed(ctx.Query("embed"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, err.Error())})
+		return
+	}
+	if embed {
+		loadFilms = true
+	}
+
+	opts, err := ParsePlanetFilters(ctx, model.PlanetColumns.Name)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, err.Error())})
+		return
+	}
+	if len(columns) > 0 {
+		opts = append(opts, service.OptionSelect(columns...))
+	}
+	if len(filmColumns) > 0 {
+		opts = append(opts, service.OptionFilmsSelect(filmColumns...))
 	}
 
 	res, err := impl.PlanetService.FindPlanetsAndTotal(ctx, page, size, loadFilms, opts...)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, dto.ApiError{Error: "internal server error"})
+		ctx.JSON(http.StatusInternalServerError, dto.ApiError{Error: i18n.T(ctx, "internal server error")})
+		return
+	}
+	if err := translatePlanets(ctx, impl.TranslationService, res.Data); err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ApiError{Error: i18n.T(ctx, "internal server error")})
 		return
 	}
 
@@ -75,34 +164,182 @@ func (impl *IPlanetController) FindPlanetsAndTotal(ctx *gin.Context) {
 
 		data[i] = impl.ParsePlanetDto(res.Data[i])
 		data[i].Films = films
-	}
-
-	paramSize := ""
-	if size != 10 {
-		paramSize = fmt.Sprintf("&size=%d", size)
+		impl.LinkPlanetDto(ctx, &data[i])
 	}
 
 	previous := ""
 	if page > 1 {
-		previous = fmt.Sprintf("%s?page=%d%s", impl.Host, page-1, paramSize)
+		previous = RequestPageURL(ctx, page-1)
 	}
 
 	next := ""
 	if res.Next {
-		next = fmt.Sprintf("%s?page=%d%s", impl.Host, page+1, paramSize)
+		next = RequestPageURL(ctx, page+1)
+	}
+
+	pagination := dto.Pagination{
+		Count:    len(data),
+		Total:    res.Total,
+		Previous: previous,
+		Next:     next,
+	}
+
+	if len(fields) > 0 || embed {
+		sparse := make([]map[string]interface{}, len(data))
+		for i := 0; i < len(data); i += 1 {
+			sparse[i] = impl.SparsePlanetDto(data[i], fields, filmFields)
+		}
+
+		ctx.JSON(http.StatusOK, dto.SparsePlanetsResponse{Pagination: pagination, Data: sparse})
+		return
 	}
 
 	ctx.JSON(http.StatusOK, dto.PlanetsResponse{
-		Pagination: dto.Pagination{
-			Count:    len(data),
-			Total:    res.Total,
-			Previous: previous,
-			Next:     next,
-		},
-		Data: data,
+		Pagination: pagination,
+		Data:       data,
 	})
 }
 
+// @Summary find planets by ids
+// @Schemes
+// @Tags planet
+// @Accept json
+// @Produce json
+// @Param planets body dto.PlanetsBatchRequest true "planet ids"
+// @Success 200 {object} dto.PlanetsBatchResponse
+// @Failure 400 {object} dto.ApiError
+// @Failure 401 {object} dto.ApiError
+// @Failure 403 {object} dto.ApiError
+// @Failure 429 {object} dto.ApiError
+// @Failure 500 {object} dto.ApiError
+// @Router /api/planets/batch-get [post]
+func (impl *IPlanetController) BatchGetPlanets(ctx *gin.Context) {
+	var req dto.PlanetsBatchRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, "invalid body")})
+		return
+	}
+	if err := impl.ValidateIDs(req.IDs); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, err.Error())})
+		return
+	}
+
+	impl.findPlanetsByIDs(ctx, req.IDs, req.LoadFilms)
+}
+
+// @Summary delete, restore or patch planets in a single transaction
+// @Schemes
+// @Tags planet
+// @Accept json
+// @Produce json
+// @Param planets body dto.PlanetsBulkRequest true "operations, run in order; mode defaults to atomic"
+// @Success 200 {object} dto.PlanetsBulkResponse
+// @Failure 400 {object} dto.ApiError
+// @Failure 401 {object} dto.ApiError
+// @Failure 403 {object} dto.ApiError
+// @Failure 409 {object} dto.PlanetsBulkResponse
+// @Failure 429 {object} dto.ApiError
+// @Failure 500 {object} dto.ApiError
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/planets/bulk [post]
+func (impl *IPlanetController) BulkPlanets(ctx *gin.Context) {
+	var req dto.PlanetsBulkRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, "invalid body")})
+		return
+	}
+	if req.Mode == "" {
+		req.Mode = PLANETS_BULK_MODE_ATOMIC
+	}
+	if err := impl.ValidateBulkRequest(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, err.Error())})
+		return
+	}
+
+	res, err := impl.PlanetService.BulkPlanets(ctx, req.Operations, req.Mode == PLANETS_BULK_MODE_ATOMIC)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ApiError{Error: i18n.T(ctx, "internal server error")})
+		return
+	}
+
+	response := dto.PlanetsBulkResponse{
+		Mode:      req.Mode,
+		Committed: res.Committed,
+		Results:   make([]dto.PlanetBulkResult, len(req.Operations)),
+	}
+	for i := 0; i < len(req.Operations); i += 1 {
+		result := impl.parseBulkResult(ctx, res.Results[i], res.Committed)
+		result.Index = i
+		result.Op = req.Operations[i].Op
+		result.ID = req.Operations[i].ID
+		if result.Status < http.StatusBadRequest {
+			response.Succeeded += 1
+		} else {
+			response.Failed += 1
+		}
+		response.Results[i] = result
+	}
+
+	status := http.StatusOK
+	if !res.Committed {
+		status = http.StatusConflict
+	}
+	ctx.JSON(status, response)
+}
+
+// parseBulkResult maps the outcome of an operation to an HTTP like status.
+// Operations without errors in a rolled back transaction fail as dependencies
+func (impl *IPlanetController) parseBulkResult(ctx *gin.Context, res dto.BulkPlanetResult, committed bool) dto.PlanetBulkResult {
+	switch e := res.Err.(type) {
+	case nil:
+		if !committed {
+			return dto.PlanetBulkResult{Status: http.StatusFailedDependency, Error: i18n.T(ctx, "operation rolled back")}
+		}
+		if res.Planet == nil {
+			return dto.PlanetBulkResult{Status: http.StatusNoContent}
+		}
+		planet := impl.ParsePlanetDto(res.Planet)
+		impl.LinkPlanetDto(ctx, &planet)
+		return dto.PlanetBulkResult{Status: http.StatusOK, Data: &planet}
+	case *exception.NotFoundException:
+		return dto.PlanetBulkResult{Status: http.StatusNotFound, Error: i18n.T(ctx, e.Message)}
+	case *exception.ValidationException:
+		return dto.PlanetBulkResult{Status: http.StatusBadRequest, Error: i18n.T(ctx, e.Message)}
+	}
+
+	return dto.PlanetBulkResult{Status: http.StatusInternalServerError, Error: i18n.T(ctx, "internal server error")}
+}
+
+// findPlanetsByIDs answers planets in the requested order, reporting the
+// ids not found apart
+func (impl *IPlanetController) findPlanetsByIDs(ctx *gin.Context, ids []int, loadFilms bool) {
+	res, err := impl.PlanetService.FindPlanetsByIDs(ctx, ids, loadFilms)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ApiError{Error: i18n.T(ctx, "internal server error")})
+		return
+	}
+	if err := translatePlanets(ctx, impl.TranslationService, res.Data); err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ApiError{Error: i18n.T(ctx, "internal server error")})
+		return
+	}
+
+	data := make([]dto.PlanetDto, len(res.Data))
+	for i := 0; i < len(data); i += 1 {
+		p := res.Data[i]
+		data[i] = impl.ParsePlanetDto(p)
+		if p.R != nil && len(p.R.Films) > 0 {
+			data[i].Films = make([]dto.FilmDto, len(p.R.Films))
+			for j := 0; j < len(p.R.Films); j += 1 {
+				data[i].Films[j] = impl.ParseFilmDto(p.R.Films[j])
+			}
+		}
+		impl.LinkPlanetDto(ctx, &data[i])
+	}
+
+	ctx.JSON(http.StatusOK, dto.PlanetsBatchResponse{Count: len(data), Data: data, Missing: res.Missing})
+}
+
 // @Summary find planet by id
 // @Schemes
 // @Tags planet
@@ -110,15 +347,21 @@ func (impl *IPlanetController) FindPlanetsAndTotal(ctx *gin.Context) {
 // @Produce json
 // @Param planetID path int true "Planet ID"
 // @Param loadFilms query bool false "loadFilms"
+// @Param fields query string false "comma separated planet fields, e.g. name,climates"
+// @Param embed query string false "embedded relationship, e.g. films(title,episode)"
+// @Param asOf query string false "RFC 3339 time to read the planet as it was, e.g. 2022-10-01T00:00:00Z"
 // @Success 200 {object} dto.PlanetResponse
 // @Failure 400 {object} dto.ApiError
+// @Failure 401 {object} dto.ApiError
+// @Failure 403 {object} dto.ApiError
 // @Failure 404 {object} dto.ApiError
+// @Failure 429 {object} dto.ApiError
 // @Failure 500 {object} dto.ApiError
 // @Router /api/planets/{planetID} [get]
 func (impl *IPlanetController) FindPlanetByID(ctx *gin.Context) {
 	planetID, err := strconv.Atoi(ctx.Param("planetID"))
 	if err != nil || planetID < 1 {
-		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: "invalid planet id"})
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, "invalid planet id")})
 		return
 	}
 	loadFilms := false
@@ -126,13 +369,47 @@ func (impl *IPlanetController) FindPlanetByID(ctx *gin.Context) {
 		loadFilms = true
 	}
 
-	planet, err := impl.PlanetService.FindPlanetByID(ctx, planetID, loadFilms)
+	fields, columns, err := impl.ParseFields(ctx.Query("fields"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, err.Error())})
+		return
+	}
+	filmFields, filmColumns, embed, err := impl.ParseEmbed(ctx.Query("embed"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, err.Error())})
+		return
+	}
+	if embed {
+		loadFilms = true
+	}
+
+	opts := []service.Option{}
+	if len(columns) > 0 {
+		opts = append(opts, service.OptionSelect(columns...))
+	}
+	if len(filmColumns) > 0 {
+		opts = append(opts, service.OptionFilmsSelect(filmColumns...))
+	}
+	if raw := ctx.Query("asOf"); raw != "" {
+		asOf, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, "invalid asOf")})
+			return
+		}
+		opts = append(opts, service.OptionAsOf(asOf.UTC()))
+	}
+
+	planet, err := impl.PlanetService.FindPlanetByID(ctx, planetID, loadFilms, opts...)
 	if err != nil {
 		if _, ok := err.(*exception.NotFoundException); ok {
-			ctx.JSON(http.StatusNotFound, dto.ApiError{Error: fmt.Sprintf("planet %d not found", planetID)})
+			ctx.JSON(http.StatusNotFound, dto.ApiError{Error: i18n.T(ctx, fmt.Sprintf("planet %d not found", planetID))})
 			return
 		}
-		ctx.JSON(http.StatusInternalServerError, dto.ApiError{Error: "internal server error"})
+		ctx.JSON(http.StatusInternalServerError, dto.ApiError{Error: i18n.T(ctx, "internal server error")})
+		return
+	}
+	if err := translatePlanets(ctx, impl.TranslationService, []*model.Planet{planet}); err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ApiError{Error: i18n.T(ctx, "internal server error")})
 		return
 	}
 
@@ -146,10 +423,200 @@ func (impl *IPlanetController) FindPlanetByID(ctx *gin.Context) {
 		}
 		data.Films = films
 	}
+	impl.LinkPlanetDto(ctx, &data)
+
+	if len(fields) > 0 || embed {
+		ctx.JSON(http.StatusOK, dto.SparsePlanetResponse{Data: impl.SparsePlanetDto(data, fields, filmFields)})
+		return
+	}
 
 	ctx.JSON(http.StatusOK, dto.PlanetResponse{Data: data})
 }
 
+// @Summary find planet history
+// @Schemes
+// @Tags planet
+// @Accept json
+// @Produce json
+// @Param planetID path int true "Planet ID"
+// @Success 200 {object} dto.PlanetHistoryResponse
+// @Failure 400 {object} dto.ApiError
+// @Failure 401 {object} dto.ApiError
+// @Failure 403 {object} dto.ApiError
+// @Failure 404 {object} dto.ApiError
+// @Failure 429 {object} dto.ApiError
+// @Failure 500 {object} dto.ApiError
+// @Router /api/planets/{planetID}/history [get]
+func (impl *IPlanetController) FindPlanetHistory(ctx *gin.Context) {
+	planetID, err := strconv.Atoi(ctx.Param("planetID"))
+	if err != nil || planetID < 1 {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, "invalid planet id")})
+		return
+	}
+
+	versions, err := impl.PlanetService.FindPlanetHistory(ctx, planetID)
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); ok {
+			ctx.JSON(http.StatusNotFound, dto.ApiError{Error: i18n.T(ctx, fmt.Sprintf("planet %d not found", planetID))})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, dto.ApiError{Error: i18n.T(ctx, "internal server error")})
+		return
+	}
+
+	data := make([]dto.PlanetVersionDto, len(versions))
+	for i := 0; i < len(data); i += 1 {
+		data[i] = impl.ParsePlanetVersionDto(versions[i])
+	}
+
+	ctx.JSON(http.StatusOK, dto.PlanetHistoryResponse{Data: data})
+}
+
+// @Summary export planets
+// @Schemes
+// @Tags planet
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Param format query string true "csv or ndjson"
+// @Param name query string false "name"
+// @Success 200 ""
+// @Failure 400 {object} dto.ApiError
+// @Failure 401 {object} dto.ApiError
+// @Failure 403 {object} dto.ApiError
+// @Failure 429 {object} dto.ApiError
+// @Failure 500 {object} dto.ApiError
+// @Router /api/planets/export [get]
+func (impl *IPlanetController) ExportPlanets(ctx *gin.Context) {
+	contentTypes := map[export.Format]string{
+		export.FormatCSV:    "text/csv",
+		export.FormatNDJSON: "application/x-ndjson",
+	}
+
+	format := export.Format(ctx.Query("format"))
+	contentType, ok := contentTypes[format]
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, "invalid format")})
+		return
+	}
+
+	opts := []service.Option{}
+	if n := ctx.Query("name"); n != "" {
+		opts = append(opts, service.OptionWhere("name like ?", n))
+	}
+
+	var encoder export.Encoder
+	count := 0
+	err := impl.PlanetService.StreamPlanets(ctx, func(planet *model.Planet) error {
+		if encoder == nil {
+			e, err := impl.startExport(ctx, format, contentType)
+			if err != nil {
+				return err
+			}
+			encoder = e
+		}
+
+		if err := encoder.Encode(export.ParsePlanetRecord(planet)); err != nil {
+			return err
+		}
+
+		count += 1
+		if count%EXPORT_FLUSH_SIZE == 0 {
+			if err := encoder.Flush(); err != nil {
+				return err
+			}
+			ctx.Writer.Flush()
+		}
+
+		return nil
+	}, opts...)
+
+	if err == nil && encoder == nil {
+		encoder, err = impl.startExport(ctx, format, contentType)
+	}
+	if err == nil {
+		err = encoder.Close()
+	}
+
+	if err != nil {
+		if !ctx.Writer.Written() {
+			ctx.JSON(http.StatusInternalServerError, dto.ApiError{Error: i18n.T(ctx, "internal server error")})
+			return
+		}
+
+		logrus.WithFields(logrus.Fields{"trace": "internal.controller.planet.export_planets:stream_planets"}).Error(err)
+		return
+	}
+
+	ctx.Writer.Flush()
+}
+
+func (impl *IPlanetController) startExport(ctx *gin.Context, format export.Format, contentType string) (export.Encoder, error) {
+	ctx.Header("Content-Type", contentType)
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=planets.%s", format))
+	ctx.Status(http.StatusOK)
+
+	return export.NewEncoder(format, ctx.Writer, &export.PlanetRecord{})
+}
+
+// @Summary import planets
+// @Description validates every row of a csv or ndjson body, in the same layout of the export, and inserts the planets in a single transaction only when all rows are valid, so a failure imports none of them
+// @Schemes
+// @Tags planet
+// @Accept text/csv
+// @Accept application/x-ndjson
+// @Produce json
+// @Param format query string true "csv or ndjson"
+// @Param dryRun query bool false "only validate the rows"
+// @Param mode query string false "insert (default) ignores existing planets, upsert updates them"
+// @Success 200 {object} dto.ImportResult
+// @Failure 400 {object} dto.ApiError
+// @Failure 401 {object} dto.ApiError
+// @Failure 403 {object} dto.ApiError
+// @Failure 422 {object} dto.ImportResult
+// @Failure 429 {object} dto.ApiError
+// @Failure 500 {object} dto.ApiError
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/planets/import [post]
+func (impl *IPlanetController) ImportPlanets(ctx *gin.Context) {
+	format := export.Format(ctx.Query("format"))
+	if format != export.FormatCSV && format != export.FormatNDJSON {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, "invalid format")})
+		return
+	}
+
+	opts := []service.Option{}
+	switch ctx.Query("mode") {
+	case "", "insert":
+	case "upsert":
+		opts = append(opts, service.OptionUpsert())
+	default:
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, "invalid mode")})
+		return
+	}
+	dryRun := ctx.Query("dryRun") == "true"
+
+	res, err := impl.ImportService.ImportPlanets(ctx, format, ctx.Request.Body, dryRun, opts...)
+	if err != nil {
+		if e, ok := err.(*exception.ValidationException); ok {
+			ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, e.Message)})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, dto.ApiError{Error: i18n.T(ctx, "internal server error")})
+		return
+	}
+
+	if len(res.Errors) > 0 {
+		for i := 0; i < len(res.Errors); i += 1 {
+			res.Errors[i].Error = i18n.T(ctx, res.Errors[i].Error)
+		}
+		ctx.JSON(http.StatusUnprocessableEntity, res)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
 // @Summary delete planet
 // @Schemes
 // @Tags planet
@@ -158,24 +625,66 @@ func (impl *IPlanetController) FindPlanetByID(ctx *gin.Context) {
 // @Param planetID path int true "Planet ID"
 // @Success 204 ""
 // @Failure 400 {object} dto.ApiError
+// @Failure 401 {object} dto.ApiError
+// @Failure 403 {object} dto.ApiError
+// @Failure 429 {object} dto.ApiError
 // @Failure 500 {object} dto.ApiError
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /api/planets/{planetID} [delete]
 func (impl *IPlanetController) DeletePlanet(ctx *gin.Context) {
 	planetID, err := strconv.Atoi(ctx.Param("planetID"))
 	if err != nil || planetID < 1 {
-		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: "invalid planet id"})
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, "invalid planet id")})
 		return
 	}
 
 	err = impl.PlanetService.DeletePlanet(ctx, planetID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, dto.ApiError{Error: "internal server error"})
+		ctx.JSON(http.StatusInternalServerError, dto.ApiError{Error: i18n.T(ctx, "internal server error")})
 		return
 	}
 
 	ctx.JSON(http.StatusNoContent, gin.H{})
 }
 
+// translatePlanets replaces planet names and film titles by their
+// translations when the negotiated locale is not the default one
+func translatePlanets(ctx *gin.Context, translationService service.TranslationService, planets []*model.Planet) error {
+	locale := i18n.LocaleFromContext(ctx)
+	if locale == i18n.DEFAULT_LOCALE {
+		return nil
+	}
+
+	return translationService.TranslatePlanets(ctx, locale, planets)
+}
+
+// translateFilms replaces film titles by their translations when the
+// negotiated locale is not the default one
+func translateFilms(ctx *gin.Context, translationService service.TranslationService, films []*model.Film) error {
+	locale := i18n.LocaleFromContext(ctx)
+	if locale == i18n.DEFAULT_LOCALE {
+		return nil
+	}
+
+	return translationService.TranslateFilms(ctx, locale, films)
+}
+
+func (impl *IPlanetController) ParsePlanetVersionDto(version *model.PlanetsHistory) dto.PlanetVersionDto {
+	res := dto.PlanetVersionDto{
+		ValidFrom: version.ValidFrom.Format("2006-01-02 15:04:05"),
+		PlanetDto: impl.ParsePlanetDto(service.ParsePlanetVersion(version)),
+	}
+	if version.ValidTo.Valid {
+		res.ValidTo = version.ValidTo.Time.Format("2006-01-02 15:04:05")
+	}
+	if version.DeletedAt.Valid {
+		res.DeletedAt = version.DeletedAt.Time.Format("2006-01-02 15:04:05")
+	}
+
+	return res
+}
+
 func (impl *IPlanetController) ParsePlanetDto(planet *model.Planet) dto.PlanetDto {
 	var climates []string
 	json.Unmarshal(planet.Climates, &climates)
@@ -184,23 +693,259 @@ func (impl *IPlanetController) ParsePlanetDto(planet *model.Planet) dto.PlanetDt
 	json.Unmarshal(planet.Terrains, &terrains)
 
 	return dto.PlanetDto{
-		ID:        planet.ID,
-		CreatedAt: planet.CreatedAt.Format("2006-01-02 15:04:05"),
-		UpdatedAt: planet.UpdatedAt.Format("2006-01-02 15:04:05"),
-		Name:      planet.Name,
-		Climates:  climates,
-		Terrains:  terrains,
+		ID:             planet.ID,
+		CreatedAt:      planet.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:      planet.UpdatedAt.Format("2006-01-02 15:04:05"),
+		Name:           planet.Name,
+		Climates:       climates,
+		Terrains:       terrains,
+		RotationPeriod: planet.RotationPeriod.Ptr(),
+		OrbitalPeriod:  planet.OrbitalPeriod.Ptr(),
+		Diameter:       planet.Diameter.Ptr(),
+		Gravity:        planet.Gravity.String,
+		SurfaceWater:   planet.SurfaceWater.Ptr(),
+		Population:     planet.Population.Ptr(),
 	}
 }
 
 func (impl *IPlanetController) ParseFilmDto(film *model.Film) dto.FilmDto {
 	return dto.FilmDto{
-		ID:          film.ID,
-		CreatedAt:   film.CreatedAt.Format("2006-01-02 15:04:05"),
-		UpdatedAt:   film.UpdatedAt.Format("2006-01-02 15:04:05"),
-		Title:       film.Title,
-		Episode:     int(film.Episode),
-		Director:    film.Director,
-		ReleaseDate: film.ReleaseDate.Format("2006-01-02"),
+		ID:           film.ID,
+		CreatedAt:    film.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:    film.UpdatedAt.Format("2006-01-02 15:04:05"),
+		Title:        film.Title,
+		Episode:      int(film.Episode),
+		Director:     film.Director,
+		Producer:     film.Producer.String,
+		ReleaseDate:  film.ReleaseDate.Format("2006-01-02"),
+		OpeningCrawl: film.OpeningCrawl.String,
+	}
+}
+
+// LinkPlanetDto adds the links of a planet, and of its films, derived from
+// the request. Links to the film routes are added only when LinkFilms is set
+func (impl *IPlanetController) LinkPlanetDto(ctx *gin.Context, planet *dto.PlanetDto) {
+	base := RequestBaseURL(ctx)
+	self := fmt.Sprintf("%s/api/planets/%d", base, planet.ID)
+	planet.Links = &dto.PlanetLinks{Self: dto.Link{Href: self}}
+	if impl.LinkFilms {
+		planet.Links.Films = &dto.Link{Href: self + "/films"}
+	}
+
+	for i := 0; i < len(planet.Films); i += 1 {
+		planet.Films[i].Links = &dto.FilmLinks{Planet: &dto.Link{Href: self}}
+		if impl.LinkFilms {
+			planet.Films[i].Links.Self = &dto.Link{Href: FilmURL(base, planet.Films[i].ID)}
+		}
+	}
+}
+
+// ParseIDs reads a comma separated list of planet ids
+func (impl *IPlanetController) ParseIDs(raw string) ([]int, error) {
+	parts := strings.Split(raw, ",")
+	ids := make([]int, len(parts))
+	for i := 0; i < len(parts); i += 1 {
+		id, err := strconv.Atoi(strings.TrimSpace(parts[i]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid ids")
+		}
+		ids[i] = id
+	}
+
+	if err := impl.ValidateIDs(ids); err != nil {
+		return nil, err
 	}
+
+	return ids, nil
+}
+
+// ValidateIDs requires from 1 to PLANETS_BATCH_MAX_IDS positive planet ids
+func (impl *IPlanetController) ValidateIDs(ids []int) error {
+	if len(ids) == 0 {
+		return fmt.Errorf("ids is required")
+	}
+	if len(ids) > PLANETS_BATCH_MAX_IDS {
+		return fmt.Errorf("ids must have at most %d items", PLANETS_BATCH_MAX_IDS)
+	}
+	for _, id := range ids {
+		if id < 1 {
+			return fmt.Errorf("invalid ids")
+		}
+	}
+
+	return nil
+}
+
+// ValidateBulkRequest rejects the whole request before any operation runs
+func (impl *IPlanetController) ValidateBulkRequest(req dto.PlanetsBulkRequest) error {
+	if req.Mode != PLANETS_BULK_MODE_ATOMIC && req.Mode != PLANETS_BULK_MODE_BEST_EFFORT {
+		return fmt.Errorf("invalid mode")
+	}
+	if len(req.Operations) == 0 {
+		return fmt.Errorf("operations is required")
+	}
+	if len(req.Operations) > PLANETS_BULK_MAX_OPERATIONS {
+		return fmt.Errorf("operations must have at most %d items", PLANETS_BULK_MAX_OPERATIONS)
+	}
+
+	for _, operation := range req.Operations {
+		if !service.IsBulkOp(operation.Op) {
+			return fmt.Errorf("invalid op %s", operation.Op)
+		}
+		if operation.ID < 1 {
+			return fmt.Errorf("invalid planet id")
+		}
+		if operation.Op != service.BULK_OP_PATCH {
+			continue
+		}
+		if operation.Patch == nil {
+			return fmt.Errorf("patch is required")
+		}
+		if err := service.ValidatePlanetPatch(*operation.Patch); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ParseFields validates a comma separated list of planet fields against
+// dto.PlanetFields, returning the fields and their database columns
+func (impl *IPlanetController) ParseFields(raw string) ([]string, []string, error) {
+	if raw == "" {
+		return nil, nil, nil
+	}
+
+	fields, columns, ok := parseFieldList(raw, dto.PlanetFields)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid fields")
+	}
+
+	return fields, columns, nil
+}
+
+// ParsePlanetFilters reads the filters of the planet list: name, climate,
+// terrain and the attribute ranges. The name is matched against nameColumn,
+// so queries joining other tables with a name can qualify it
+func ParsePlanetFilters(ctx *gin.Context, nameColumn string) ([]service.Option, error) {
+	opts := make([]service.Option, 1)
+	if n := ctx.Query("name"); n != "" {
+		opts[0] = service.OptionWhere(fmt.Sprintf("%s like ?", nameColumn), n)
+	}
+	if c := ctx.Query("climate"); c != "" {
+		opts = append(opts, service.OptionWhereClimate(c))
+	}
+	if t := ctx.Query("terrain"); t != "" {
+		opts = append(opts, service.OptionWhereTerrain(t))
+	}
+
+	rangeOpts, err := ParseRangeFilters(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(opts, rangeOpts...), nil
+}
+
+// ParseRangeFilters reads the <attribute>Min and <attribute>Max parameters of
+// the attributes in dto.PlanetRangeFilters, both inclusive
+func ParseRangeFilters(ctx *gin.Context) ([]service.Option, error) {
+	params := make([]string, 0, len(dto.PlanetRangeFilters))
+	for param := range dto.PlanetRangeFilters {
+		params = append(params, param)
+	}
+	sort.Strings(params)
+
+	bounds := map[string]float64{}
+	for _, param := range params {
+		for _, suffix := range []string{"Min", "Max"} {
+			raw := ctx.Query(param + suffix)
+			if raw == "" {
+				continue
+			}
+
+			value, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s%s", param, suffix)
+			}
+			bounds[param+suffix] = value
+		}
+	}
+
+	return service.OptionsWhereRange(bounds), nil
+}
+
+// ParseEmbed validates the embed parameter, which accepts "films" or
+// "films(title,episode)" with fields whitelisted by dto.FilmFields
+func (impl *IPlanetController) ParseEmbed(raw string) ([]string, []string, bool, error) {
+	if raw == "" {
+		return nil, nil, false, nil
+	}
+	if raw == "films" {
+		return nil, nil, true, nil
+	}
+
+	if !strings.HasPrefix(raw, "films(") || !strings.HasSuffix(raw, ")") {
+		return nil, nil, false, fmt.Errorf("invalid embed")
+	}
+
+	fields, columns, ok := parseFieldList(strings.TrimSuffix(strings.TrimPrefix(raw, "films("), ")"), dto.FilmFields)
+	if !ok {
+		return nil, nil, false, fmt.Errorf("invalid embed")
+	}
+
+	return fields, columns, true, nil
+}
+
+// SparsePlanetDto keeps only the requested planet and film fields,
+// an empty list keeps every field
+func (impl *IPlanetController) SparsePlanetDto(planet dto.PlanetDto, fields, filmFields []string) map[string]interface{} {
+	res := sparseFields(planet, fields)
+	if len(planet.Films) > 0 {
+		films := make([]map[string]interface{}, len(planet.Films))
+		for i := 0; i < len(films); i += 1 {
+			films[i] = sparseFields(planet.Films[i], filmFields)
+		}
+		res["films"] = films
+	}
+
+	return res
+}
+
+func parseFieldList(raw string, whitelist map[string]string) ([]string, []string, bool) {
+	fields := strings.Split(raw, ",")
+	columns := make([]string, len(fields))
+	for i := 0; i < len(fields); i += 1 {
+		fields[i] = strings.TrimSpace(fields[i])
+		column, ok := whitelist[fields[i]]
+		if !ok {
+			return nil, nil, false
+		}
+		columns[i] = column
+	}
+
+	return fields, columns, true
+}
+
+func sparseFields(value interface{}, fields []string) map[string]interface{} {
+	all := map[string]interface{}{}
+	b, _ := json.Marshal(value)
+	json.Unmarshal(b, &all)
+	delete(all, "films")
+
+	if len(fields) == 0 {
+		return all
+	}
+
+	res := map[string]interface{}{}
+	if links, ok := all["_links"]; ok {
+		res["_links"] = links
+	}
+	for _, f := range fields {
+		if v, ok := all[f]; ok {
+			res[f] = v
+		}
+	}
+
+	return res
 }
@@ -0,0 +1,244 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/starwars-api/internal/auth"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/exception"
+	"github.com/viniosilva/starwars-api/internal/i18n"
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/viniosilva/starwars-api/internal/service"
+)
+
+type IPlanetFilmController struct {
+	PlanetService      service.PlanetService
+	FilmService        service.FilmService
+	TranslationService service.TranslationService
+}
+
+func (impl *IPlanetFilmController) Configure(router *gin.RouterGroup) {
+	router.GET("/films/:filmID", auth.RequireRole(auth.RoleReader), impl.FindFilmByID)
+	router.GET("/planets/:planetID/films", auth.RequireRole(auth.RoleReader), impl.FindPlanetFilms)
+	router.PUT("/planets/:planetID/films/:filmID", auth.RequireRole(auth.RoleEditor), impl.LinkFilmToPlanet)
+	router.DELETE("/planets/:planetID/films/:filmID", auth.RequireRole(auth.RoleEditor), impl.UnlinkFilmFromPlanet)
+}
+
+// @Summary find film by id
+// @Schemes
+// @Tags planet film
+// @Accept json
+// @Produce json
+// @Param filmID path int true "Film ID"
+// @Success 200 {object} dto.FilmResponse
+// @Failure 400 {object} dto.ApiError
+// @Failure 401 {object} dto.ApiError
+// @Failure 403 {object} dto.ApiError
+// @Failure 404 {object} dto.ApiError
+// @Failure 429 {object} dto.ApiError
+// @Failure 500 {object} dto.ApiError
+// @Router /api/films/{filmID} [get]
+func (impl *IPlanetFilmController) FindFilmByID(ctx *gin.Context) {
+	filmID, err := strconv.Atoi(ctx.Param("filmID"))
+	if err != nil || filmID < 1 {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, "invalid film id")})
+		return
+	}
+
+	film, err := impl.FilmService.FindFilmByID(ctx, filmID)
+	if err != nil {
+		impl.handleError(ctx, err)
+		return
+	}
+	if err := translateFilms(ctx, impl.TranslationService, []*model.Film{film}); err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ApiError{Error: i18n.T(ctx, "internal server error")})
+		return
+	}
+
+	v1 := &IPlanetController{}
+	data := v1.ParseFilmDto(film)
+	data.Links = &dto.FilmLinks{Self: &dto.Link{Href: FilmURL(RequestBaseURL(ctx), film.ID)}}
+
+	ctx.JSON(http.StatusOK, dto.FilmResponse{Data: data})
+}
+
+// @Summary find the films of a planet
+// @Schemes
+// @Tags planet film
+// @Accept json
+// @Produce json
+// @Param planetID path int true "Planet ID"
+// @Param page query int false "page"
+// @Param size query int false "size"
+// @Success 200 {object} dto.FilmsResponse
+// @Failure 400 {object} dto.ApiError
+// @Failure 401 {object} dto.ApiError
+// @Failure 403 {object} dto.ApiError
+// @Failure 404 {object} dto.ApiError
+// @Failure 429 {object} dto.ApiError
+// @Failure 500 {object} dto.ApiError
+// @Router /api/planets/{planetID}/films [get]
+func (impl *IPlanetFilmController) FindPlanetFilms(ctx *gin.Context) {
+	planetID, err := strconv.Atoi(ctx.Param("planetID"))
+	if err != nil || planetID < 1 {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, "invalid planet id")})
+		return
+	}
+	page, err := strconv.Atoi(ctx.Query("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	size, err := strconv.Atoi(ctx.Query("size"))
+	if err != nil || size < 1 {
+		size = 10
+	}
+
+	_, err = impl.PlanetService.FindPlanetByID(ctx, planetID, false, service.OptionSelect(model.PlanetColumns.ID))
+	if err != nil {
+		impl.handleError(ctx, err)
+		return
+	}
+
+	res, err := impl.FilmService.FindFilmsAndTotal(ctx, page, size, service.OptionWhere(
+		fmt.Sprintf("%s IN (SELECT film_id FROM %s WHERE planet_id = ?)", model.FilmColumns.ID, model.TableNames.PlanetsFilms), planetID))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ApiError{Error: i18n.T(ctx, "internal server error")})
+		return
+	}
+	if err := translateFilms(ctx, impl.TranslationService, res.Data); err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ApiError{Error: i18n.T(ctx, "internal server error")})
+		return
+	}
+
+	v1 := &IPlanetController{}
+	base := RequestBaseURL(ctx)
+	planet := fmt.Sprintf("%s/api/planets/%d", base, planetID)
+	data := make([]dto.FilmDto, res.Count)
+	for i := 0; i < len(data); i += 1 {
+		data[i] = v1.ParseFilmDto(res.Data[i])
+		data[i].Links = &dto.FilmLinks{
+			Self:   &dto.Link{Href: FilmURL(base, data[i].ID)},
+			Planet: &dto.Link{Href: planet},
+		}
+	}
+
+	previous := ""
+	if page > 1 {
+		previous = RequestPageURL(ctx, page-1)
+	}
+
+	next := ""
+	if res.Next {
+		next = RequestPageURL(ctx, page+1)
+	}
+
+	ctx.JSON(http.StatusOK, dto.FilmsResponse{
+		Pagination: dto.Pagination{
+			Count:    len(data),
+			Total:    res.Total,
+			Previous: previous,
+			Next:     next,
+		},
+		Data: data,
+	})
+}
+
+// @Summary link a film to a planet
+// @Schemes
+// @Tags planet film
+// @Accept json
+// @Produce json
+// @Param planetID path int true "Planet ID"
+// @Param filmID path int true "Film ID"
+// @Success 204 ""
+// @Failure 400 {object} dto.ApiError
+// @Failure 401 {object} dto.ApiError
+// @Failure 403 {object} dto.ApiError
+// @Failure 404 {object} dto.ApiError
+// @Failure 409 {object} dto.ApiError
+// @Failure 429 {object} dto.ApiError
+// @Failure 500 {object} dto.ApiError
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/planets/{planetID}/films/{filmID} [put]
+func (impl *IPlanetFilmController) LinkFilmToPlanet(ctx *gin.Context) {
+	planetID, filmID, ok := impl.parseIDs(ctx)
+	if !ok {
+		return
+	}
+
+	if err := impl.PlanetService.LinkFilmToPlanet(ctx, planetID, filmID); err != nil {
+		impl.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, gin.H{})
+}
+
+// @Summary unlink a film from a planet
+// @Schemes
+// @Tags planet film
+// @Accept json
+// @Produce json
+// @Param planetID path int true "Planet ID"
+// @Param filmID path int true "Film ID"
+// @Success 204 ""
+// @Failure 400 {object} dto.ApiError
+// @Failure 401 {object} dto.ApiError
+// @Failure 403 {object} dto.ApiError
+// @Failure 404 {object} dto.ApiError
+// @Failure 409 {object} dto.ApiError
+// @Failure 429 {object} dto.ApiError
+// @Failure 500 {object} dto.ApiError
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/planets/{planetID}/films/{filmID} [delete]
+func (impl *IPlanetFilmController) UnlinkFilmFromPlanet(ctx *gin.Context) {
+	planetID, filmID, ok := impl.parseIDs(ctx)
+	if !ok {
+		return
+	}
+
+	if err := impl.PlanetService.UnlinkFilmFromPlanet(ctx, planetID, filmID); err != nil {
+		impl.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, gin.H{})
+}
+
+func (impl *IPlanetFilmController) parseIDs(ctx *gin.Context) (int, int, bool) {
+	planetID, err := strconv.Atoi(ctx.Param("planetID"))
+	if err != nil || planetID < 1 {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, "invalid planet id")})
+		return 0, 0, false
+	}
+	filmID, err := strconv.Atoi(ctx.Param("filmID"))
+	if err != nil || filmID < 1 {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, "invalid film id")})
+		return 0, 0, false
+	}
+
+	return planetID, filmID, true
+}
+
+// FilmURL is the address of the film route registered by IPlanetFilmController
+func FilmURL(base string, filmID int) string {
+	return fmt.Sprintf("%s/api/films/%d", base, filmID)
+}
+
+func (impl *IPlanetFilmController) handleError(ctx *gin.Context, err error) {
+	if e, ok := err.(*exception.NotFoundException); ok {
+		ctx.JSON(http.StatusNotFound, dto.ApiError{Error: i18n.T(ctx, e.Message)})
+		return
+	}
+	if e, ok := err.(*exception.ConflictException); ok {
+		ctx.JSON(http.StatusConflict, dto.ApiError{Error: i18n.T(ctx, e.Message)})
+		return
+	}
+
+	ctx.JSON(http.StatusInternalServerError, dto.ApiError{Error: i18n.T(ctx, "internal server error")})
+}
@@ -0,0 +1,238 @@
+package controller_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/auth"
+	"github.com/viniosilva/starwars-api/internal/controller"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/exception"
+	"github.com/viniosilva/starwars-api/internal/i18n"
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/viniosilva/starwars-api/mock"
+)
+
+func Test_PlanetFilmController(t *testing.T) {
+	releaseDate := time.Date(1977, 5, 25, 0, 0, 0, 0, time.UTC)
+
+	var cases = map[string]struct {
+		mocking            func(planetService *mock.MockPlanetService, filmService *mock.MockFilmService, translationService *mock.MockTranslationService)
+		inputRole          auth.Role
+		inputMethod        string
+		inputPath          string
+		inputLanguage      string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		"should find planet films": {
+			mocking: func(planetService *mock.MockPlanetService, filmService *mock.MockFilmService, translationService *mock.MockTranslationService) {
+				planetService.EXPECT().FindPlanetByID(gomock.Any(), 1, false, gomock.Any()).Return(&model.Planet{ID: 1}, nil)
+				filmService.EXPECT().FindFilmsAndTotal(gomock.Any(), 2, 1, gomock.Any()).Return(dto.FindFilmsAndTotalResult{
+					Count: 1,
+					Total: 3,
+					Next:  true,
+					Data:  []*model.Film{{ID: 2, Title: "The Empire Strikes Back", Episode: 5, ReleaseDate: releaseDate}},
+				}, nil)
+			},
+			inputRole:          auth.RoleReader,
+			inputMethod:        http.MethodGet,
+			inputPath:          "/api/planets/1/films?page=2&size=1",
+			expectedStatusCode: http.StatusOK,
+			expectedBody: `{"count":1,"total":3,"previous":"http://example.com/api/planets/1/films?page=1\u0026size=1","next":"http://example.com/api/planets/1/films?page=3\u0026size=1",` +
+				`"data":[{"id":2,"created_at":"0001-01-01 00:00:00","updated_at":"0001-01-01 00:00:00","title":"The Empire Strikes Back","episode":5,"release_date":"1977-05-25","_links":{"self":{"href":"http://example.com/api/films/2"},"planet":{"href":"http://example.com/api/planets/1"}}}]}`,
+		},
+		"should find planet films translated": {
+			mocking: func(planetService *mock.MockPlanetService, filmService *mock.MockFilmService, translationService *mock.MockTranslationService) {
+				planetService.EXPECT().FindPlanetByID(gomock.Any(), 1, false, gomock.Any()).Return(&model.Planet{ID: 1}, nil)
+				filmService.EXPECT().FindFilmsAndTotal(gomock.Any(), 1, 10, gomock.Any()).Return(dto.FindFilmsAndTotalResult{
+					Count: 1,
+					Total: 1,
+					Data:  []*model.Film{{ID: 1, Title: "A New Hope", ReleaseDate: releaseDate}},
+				}, nil)
+				translationService.EXPECT().TranslateFilms(gomock.Any(), i18n.LOCALE_PT_BR, gomock.Any()).
+					DoAndReturn(func(_ interface{}, _ string, films []*model.Film) error {
+						films[0].Title = "Uma Nova Esperança"
+						return nil
+					})
+			},
+			inputRole:          auth.RoleReader,
+			inputMethod:        http.MethodGet,
+			inputPath:          "/api/planets/1/films",
+			inputLanguage:      "pt-BR",
+			expectedStatusCode: http.StatusOK,
+			expectedBody: `{"count":1,"total":1,"previous":"","next":"",` +
+				`"data":[{"id":1,"created_at":"0001-01-01 00:00:00","updated_at":"0001-01-01 00:00:00","title":"Uma Nova Esperança","release_date":"1977-05-25","_links":{"self":{"href":"http://example.com/api/films/1"},"planet":{"href":"http://example.com/api/planets/1"}}}]}`,
+		},
+		"should throw not found when finding films of a missing planet": {
+			mocking: func(planetService *mock.MockPlanetService, filmService *mock.MockFilmService, translationService *mock.MockTranslationService) {
+				planetService.EXPECT().FindPlanetByID(gomock.Any(), 1, false, gomock.Any()).
+					Return(nil, &exception.NotFoundException{Message: "planet 1 not found"})
+			},
+			inputRole:          auth.RoleReader,
+			inputMethod:        http.MethodGet,
+			inputPath:          "/api/planets/1/films",
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       `{"error":"planet 1 not found"}`,
+		},
+		"should throw internal server error when finding films": {
+			mocking: func(planetService *mock.MockPlanetService, filmService *mock.MockFilmService, translationService *mock.MockTranslationService) {
+				planetService.EXPECT().FindPlanetByID(gomock.Any(), 1, false, gomock.Any()).Return(&model.Planet{ID: 1}, nil)
+				filmService.EXPECT().FindFilmsAndTotal(gomock.Any(), 1, 10, gomock.Any()).Return(dto.FindFilmsAndTotalResult{}, fmt.Errorf("error"))
+			},
+			inputRole:          auth.RoleReader,
+			inputMethod:        http.MethodGet,
+			inputPath:          "/api/planets/1/films",
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       `{"error":"internal server error"}`,
+		},
+		"should find film by id": {
+			mocking: func(planetService *mock.MockPlanetService, filmService *mock.MockFilmService, translationService *mock.MockTranslationService) {
+				filmService.EXPECT().FindFilmByID(gomock.Any(), 2).Return(&model.Film{ID: 2, Title: "The Empire Strikes Back", Episode: 5, ReleaseDate: releaseDate}, nil)
+			},
+			inputRole:          auth.RoleReader,
+			inputMethod:        http.MethodGet,
+			inputPath:          "/api/films/2",
+			expectedStatusCode: http.StatusOK,
+			expectedBody: `{"data":{"id":2,"created_at":"0001-01-01 00:00:00","updated_at":"0001-01-01 00:00:00","title":"The Empire Strikes Back","episode":5,"release_date":"1977-05-25",` +
+				`"_links":{"self":{"href":"http://example.com/api/films/2"}}}}`,
+		},
+		"should throw bad request when finding film with invalid id": {
+			mocking: func(planetService *mock.MockPlanetService, filmService *mock.MockFilmService, translationService *mock.MockTranslationService) {
+			},
+			inputRole:          auth.RoleReader,
+			inputMethod:        http.MethodGet,
+			inputPath:          "/api/films/x",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid film id"}`,
+		},
+		"should throw not found when finding film": {
+			mocking: func(planetService *mock.MockPlanetService, filmService *mock.MockFilmService, translationService *mock.MockTranslationService) {
+				filmService.EXPECT().FindFilmByID(gomock.Any(), 9).Return(nil, &exception.NotFoundException{Message: "film 9 not found"})
+			},
+			inputRole:          auth.RoleReader,
+			inputMethod:        http.MethodGet,
+			inputPath:          "/api/films/9",
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       `{"error":"film 9 not found"}`,
+		},
+		"should link film to planet": {
+			mocking: func(planetService *mock.MockPlanetService, filmService *mock.MockFilmService, translationService *mock.MockTranslationService) {
+				planetService.EXPECT().LinkFilmToPlanet(gomock.Any(), 1, 2).Return(nil)
+			},
+			inputRole:          auth.RoleEditor,
+			inputMethod:        http.MethodPut,
+			inputPath:          "/api/planets/1/films/2",
+			expectedStatusCode: http.StatusNoContent,
+		},
+		"should unlink film from planet": {
+			mocking: func(planetService *mock.MockPlanetService, filmService *mock.MockFilmService, translationService *mock.MockTranslationService) {
+				planetService.EXPECT().UnlinkFilmFromPlanet(gomock.Any(), 1, 2).Return(nil)
+			},
+			inputRole:          auth.RoleEditor,
+			inputMethod:        http.MethodDelete,
+			inputPath:          "/api/planets/1/films/2",
+			expectedStatusCode: http.StatusNoContent,
+		},
+		"should throw not found when film does not exist": {
+			mocking: func(planetService *mock.MockPlanetService, filmService *mock.MockFilmService, translationService *mock.MockTranslationService) {
+				planetService.EXPECT().LinkFilmToPlanet(gomock.Any(), 1, 2).Return(&exception.NotFoundException{Message: "film 2 not found"})
+			},
+			inputRole:          auth.RoleEditor,
+			inputMethod:        http.MethodPut,
+			inputPath:          "/api/planets/1/films/2",
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       `{"error":"film 2 not found"}`,
+		},
+		"should throw conflict when planet is deleted": {
+			mocking: func(planetService *mock.MockPlanetService, filmService *mock.MockFilmService, translationService *mock.MockTranslationService) {
+				planetService.EXPECT().UnlinkFilmFromPlanet(gomock.Any(), 1, 2).Return(&exception.ConflictException{Message: "planet 1 is deleted"})
+			},
+			inputRole:          auth.RoleEditor,
+			inputMethod:        http.MethodDelete,
+			inputPath:          "/api/planets/1/films/2",
+			expectedStatusCode: http.StatusConflict,
+			expectedBody:       `{"error":"planet 1 is deleted"}`,
+		},
+		"should throw internal server error when linking film": {
+			mocking: func(planetService *mock.MockPlanetService, filmService *mock.MockFilmService, translationService *mock.MockTranslationService) {
+				planetService.EXPECT().LinkFilmToPlanet(gomock.Any(), 1, 2).Return(fmt.Errorf("error"))
+			},
+			inputRole:          auth.RoleEditor,
+			inputMethod:        http.MethodPut,
+			inputPath:          "/api/planets/1/films/2",
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       `{"error":"internal server error"}`,
+		},
+		"should throw bad request when planet id is invalid": {
+			mocking: func(planetService *mock.MockPlanetService, filmService *mock.MockFilmService, translationService *mock.MockTranslationService) {
+			},
+			inputRole:          auth.RoleEditor,
+			inputMethod:        http.MethodPut,
+			inputPath:          "/api/planets/abc/films/2",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid planet id"}`,
+		},
+		"should throw bad request when film id is invalid": {
+			mocking: func(planetService *mock.MockPlanetService, filmService *mock.MockFilmService, translationService *mock.MockTranslationService) {
+			},
+			inputRole:          auth.RoleEditor,
+			inputMethod:        http.MethodDelete,
+			inputPath:          "/api/planets/1/films/0",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid film id"}`,
+		},
+		"should throw forbidden when reader links film": {
+			mocking: func(planetService *mock.MockPlanetService, filmService *mock.MockFilmService, translationService *mock.MockTranslationService) {
+			},
+			inputRole:          auth.RoleReader,
+			inputMethod:        http.MethodPut,
+			inputPath:          "/api/planets/1/films/2",
+			expectedStatusCode: http.StatusForbidden,
+			expectedBody:       `{"error":"forbidden"}`,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			_, r := gin.CreateTestContext(res)
+			r.Use(auth.GinAuth(&auth.IAuthenticator{}, cs.inputRole))
+			r.Use(i18n.GinLocale())
+
+			mockPlanetService := mock.NewMockPlanetService(ctrl)
+			mockFilmService := mock.NewMockFilmService(ctrl)
+			mockTranslationService := mock.NewMockTranslationService(ctrl)
+
+			planetFilmController := &controller.IPlanetFilmController{
+				PlanetService:      mockPlanetService,
+				FilmService:        mockFilmService,
+				TranslationService: mockTranslationService,
+			}
+			planetFilmController.Configure(r.Group("/api"))
+
+			cs.mocking(mockPlanetService, mockFilmService, mockTranslationService)
+
+			// when
+			req := httptest.NewRequest(cs.inputMethod, cs.inputPath, nil)
+			if cs.inputLanguage != "" {
+				req.Header.Set("Accept-Language", cs.inputLanguage)
+			}
+			r.ServeHTTP(res, req)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Code)
+			assert.Equal(t, cs.expectedBody, res.Body.String())
+		})
+	}
+}
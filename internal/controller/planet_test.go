@@ -1,21 +1,29 @@
 package controller_test
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/auth"
+	"github.com/viniosilva/starwars-api/internal/config"
 	"github.com/viniosilva/starwars-api/internal/controller"
 	"github.com/viniosilva/starwars-api/internal/dto"
 	"github.com/viniosilva/starwars-api/internal/exception"
+	"github.com/viniosilva/starwars-api/internal/export"
+	"github.com/viniosilva/starwars-api/internal/i18n"
 	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/viniosilva/starwars-api/internal/service"
 	"github.com/viniosilva/starwars-api/mock"
+	"github.com/volatiletech/null/v8"
 )
 
 func Test_PlanetController_FindPlanetsAndTotal(t *testing.T) {
@@ -45,6 +53,7 @@ func Test_PlanetController_FindPlanetsAndTotal(t *testing.T) {
 					ID:        1,
 					CreatedAt: "0001-01-01 00:00:00",
 					UpdatedAt: "0001-01-01 00:00:00",
+					Links:     planetLinks(1),
 				}},
 			},
 		},
@@ -61,13 +70,14 @@ func Test_PlanetController_FindPlanetsAndTotal(t *testing.T) {
 				Pagination: dto.Pagination{
 					Count:    1,
 					Total:    3,
-					Previous: "localhost?page=1&size=1",
-					Next:     "localhost?page=3&size=1",
+					Previous: "http://example.com/api/planets?loadFilms=true&page=1&size=1",
+					Next:     "http://example.com/api/planets?loadFilms=true&page=3&size=1",
 				},
 				Data: []dto.PlanetDto{{
 					ID:        1,
 					CreatedAt: "0001-01-01 00:00:00",
 					UpdatedAt: "0001-01-01 00:00:00",
+					Links:     planetLinks(1),
 				}},
 			},
 		},
@@ -88,6 +98,7 @@ func Test_PlanetController_FindPlanetsAndTotal(t *testing.T) {
 					Name:      "Tatooine",
 					CreatedAt: "0001-01-01 00:00:00",
 					UpdatedAt: "0001-01-01 00:00:00",
+					Links:     planetLinks(1),
 				}},
 			},
 		},
@@ -137,7 +148,7 @@ func Test_PlanetController_FindPlanetsAndTotal(t *testing.T) {
 			ctx.Request = httptest.NewRequest("GET", "/api/planets"+q, nil)
 			mockPlanetService := mock.NewMockPlanetService(ctrl)
 
-			planetController := &controller.IPlanetController{Host: "localhost", PlanetService: mockPlanetService}
+			planetController := &controller.IPlanetController{PlanetService: mockPlanetService, LinkFilms: true}
 			planetController.Configure(r.Group("/api"))
 
 			cs.mocking(mockPlanetService)
@@ -181,6 +192,7 @@ func Test_PlanetController_FindPlanetByID(t *testing.T) {
 					ID:        1,
 					CreatedAt: "0001-01-01 00:00:00",
 					UpdatedAt: "0001-01-01 00:00:00",
+					Links:     planetLinks(1),
 				},
 			},
 		},
@@ -234,7 +246,7 @@ func Test_PlanetController_FindPlanetByID(t *testing.T) {
 
 			mockPlanetService := mock.NewMockPlanetService(ctrl)
 
-			planetController := &controller.IPlanetController{PlanetService: mockPlanetService}
+			planetController := &controller.IPlanetController{PlanetService: mockPlanetService, LinkFilms: true}
 			planetController.Configure(r.Group("/api"))
 
 			cs.mocking(mockPlanetService)
@@ -256,6 +268,438 @@ func Test_PlanetController_FindPlanetByID(t *testing.T) {
 	}
 }
 
+func Test_PlanetController_FindPlanetHistory(t *testing.T) {
+	climates, _ := json.Marshal([]string{"arid"})
+
+	var cases = map[string]struct {
+		mocking            func(planetService *mock.MockPlanetService)
+		inputURL           string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		"should return planet history": {
+			mocking: func(planetService *mock.MockPlanetService) {
+				planetService.EXPECT().FindPlanetHistory(gomock.Any(), 1).Return([]*model.PlanetsHistory{
+					{
+						ID:        1,
+						PlanetID:  1,
+						ValidFrom: time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC),
+						ValidTo:   null.TimeFrom(time.Date(2022, 10, 2, 0, 0, 0, 0, time.UTC)),
+						Name:      "Tatooine",
+						Climates:  climates,
+					},
+					{
+						ID:        2,
+						PlanetID:  1,
+						ValidFrom: time.Date(2022, 10, 2, 0, 0, 0, 0, time.UTC),
+						DeletedAt: null.TimeFrom(time.Date(2022, 10, 2, 0, 0, 0, 0, time.UTC)),
+						Name:      "Tatooine",
+						Climates:  climates,
+					},
+				}, nil)
+			},
+			inputURL:           "/api/planets/1/history",
+			expectedStatusCode: http.StatusOK,
+			expectedBody: `{"data":[` +
+				`{"valid_from":"2022-10-01 00:00:00","valid_to":"2022-10-02 00:00:00","id":1,"created_at":"0001-01-01 00:00:00","updated_at":"0001-01-01 00:00:00","name":"Tatooine","climates":["arid"]},` +
+				`{"valid_from":"2022-10-02 00:00:00","deleted_at":"2022-10-02 00:00:00","id":1,"created_at":"0001-01-01 00:00:00","updated_at":"0001-01-01 00:00:00","name":"Tatooine","climates":["arid"]}]}`,
+		},
+		"should throw not found when planet has no history": {
+			mocking: func(planetService *mock.MockPlanetService) {
+				planetService.EXPECT().FindPlanetHistory(gomock.Any(), 1).Return(nil, &exception.NotFoundException{Message: "planet 1 not found"})
+			},
+			inputURL:           "/api/planets/1/history",
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       `{"error":"planet 1 not found"}`,
+		},
+		"should return planet as of time": {
+			mocking: func(planetService *mock.MockPlanetService) {
+				planetService.EXPECT().FindPlanetByID(gomock.Any(), 1, false, service.OptionAsOf(time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC))).
+					Return(&model.Planet{ID: 1, Name: "Tatooine"}, nil)
+			},
+			inputURL:           "/api/planets/1?asOf=2022-10-01T00:00:00Z",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"data":{"id":1,"created_at":"0001-01-01 00:00:00","updated_at":"0001-01-01 00:00:00","name":"Tatooine","_links":{"self":{"href":"http://example.com/api/planets/1"},"films":{"href":"http://example.com/api/planets/1/films"}}}}`,
+		},
+		"should throw bad request when asOf is invalid": {
+			mocking:            func(planetService *mock.MockPlanetService) {},
+			inputURL:           "/api/planets/1?asOf=yesterday",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid asOf"}`,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			_, r := gin.CreateTestContext(res)
+			r.Use(auth.GinAuth(&auth.IAuthenticator{}, auth.RoleReader))
+
+			mockPlanetService := mock.NewMockPlanetService(ctrl)
+
+			planetController := &controller.IPlanetController{PlanetService: mockPlanetService, LinkFilms: true}
+			planetController.Configure(r.Group("/api"))
+
+			cs.mocking(mockPlanetService)
+
+			// when
+			r.ServeHTTP(res, httptest.NewRequest(http.MethodGet, cs.inputURL, nil))
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Code)
+			assert.Equal(t, cs.expectedBody, res.Body.String())
+		})
+	}
+}
+
+func Test_PlanetController_Filters(t *testing.T) {
+	var cases = map[string]struct {
+		mocking            func(planetService *mock.MockPlanetService)
+		inputQuery         string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		"should filter planets by attribute ranges": {
+			mocking: func(planetService *mock.MockPlanetService) {
+				planetService.EXPECT().FindPlanetsAndTotal(gomock.Any(), 1, 10, false,
+					nil,
+					service.OptionWhere("diameter >= ?", float64(10000)),
+					service.OptionWhere("diameter <= ?", float64(13000)),
+					service.OptionWhere("population >= ?", float64(1e9)),
+					gomock.Any(),
+				).Return(dto.FindPlanetsAndTotalResult{Count: 1, Total: 1, Data: []*model.Planet{{
+					ID:           2,
+					Name:         "Alderaan",
+					Diameter:     null.IntFrom(12500),
+					Gravity:      null.StringFrom("1 standard"),
+					SurfaceWater: null.Float64From(40),
+					Population:   null.Int64From(2000000000),
+				}}}, nil)
+			},
+			inputQuery:         "?populationMin=1e9&diameterMin=10000&diameterMax=13000&fields=name,diameter,gravity,surface_water,population,rotation_period",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"count":1,"total":1,"previous":"","next":"","data":[{"_links":{"films":{"href":"http://example.com/api/planets/2/films"},"self":{"href":"http://example.com/api/planets/2"}},"diameter":12500,"gravity":"1 standard","name":"Alderaan","population":2000000000,"surface_water":40}]}`,
+		},
+		"should filter planets by climate and terrain": {
+			mocking: func(planetService *mock.MockPlanetService) {
+				planetService.EXPECT().FindPlanetsAndTotal(gomock.Any(), 1, 10, false,
+					nil,
+					service.OptionWhereClimate("arid"),
+					service.OptionWhereTerrain("desert"),
+				).Return(dto.FindPlanetsAndTotalResult{}, nil)
+			},
+			inputQuery:         "?climate=arid&terrain=desert",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"count":0,"total":0,"previous":"","next":"","data":[]}`,
+		},
+		"should throw bad request when range is invalid": {
+			mocking:            func(planetService *mock.MockPlanetService) {},
+			inputQuery:         "?populationMin=many",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid populationMin"}`,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			_, r := gin.CreateTestContext(res)
+			r.Use(auth.GinAuth(&auth.IAuthenticator{}, auth.RoleReader))
+
+			mockPlanetService := mock.NewMockPlanetService(ctrl)
+
+			planetController := &controller.IPlanetController{PlanetService: mockPlanetService, LinkFilms: true}
+			planetController.Configure(r.Group("/api"))
+
+			cs.mocking(mockPlanetService)
+
+			// when
+			r.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/api/planets"+cs.inputQuery, nil))
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Code)
+			assert.Equal(t, cs.expectedBody, res.Body.String())
+		})
+	}
+}
+
+func Test_PlanetController_SparseFieldsets(t *testing.T) {
+	var cases = map[string]struct {
+		mocking            func(planetService *mock.MockPlanetService)
+		inputPath          string
+		inputQuery         string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		"should return only selected planet fields": {
+			mocking: func(planetService *mock.MockPlanetService) {
+				planetService.EXPECT().FindPlanetsAndTotal(gomock.Any(), 1, 10, false, gomock.Any()).
+					Return(dto.FindPlanetsAndTotalResult{Count: 1, Total: 1, Data: []*model.Planet{{ID: 1, Name: "Tatooine"}}}, nil)
+			},
+			inputPath:          "/api/planets",
+			inputQuery:         "?fields=name",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"count":1,"total":1,"previous":"","next":"","data":[{"_links":{"films":{"href":"http://example.com/api/planets/1/films"},"self":{"href":"http://example.com/api/planets/1"}},"name":"Tatooine"}]}`,
+		},
+		"should return planet with embedded film fields": {
+			mocking: func(planetService *mock.MockPlanetService) {
+				planetService.EXPECT().FindPlanetByID(gomock.Any(), 1, true, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, planetID int, loadFilms bool, opts ...service.Option) (*model.Planet, error) {
+						assert.Equal(t, []string{model.PlanetColumns.ID, model.PlanetColumns.Name}, service.GetOptionSelect(opts))
+						assert.Equal(t, []string{model.FilmColumns.Title, model.FilmColumns.Episode}, service.GetOptionFilmsSelect(opts))
+						planet := &model.Planet{ID: 1, Name: "Tatooine"}
+						planet.R = planet.R.NewStruct()
+						planet.R.Films = model.FilmSlice{{ID: 1, Title: "A New Hope", Episode: 4}}
+						return planet, nil
+					})
+			},
+			inputPath:          "/api/planets/1",
+			inputQuery:         "?fields=id,name&embed=films(title,episode)",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"data":{"_links":{"films":{"href":"http://example.com/api/planets/1/films"},"self":{"href":"http://example.com/api/planets/1"}},"id":1,"name":"Tatooine","films":[{"_links":{"planet":{"href":"http://example.com/api/planets/1"},"self":{"href":"http://example.com/api/films/1"}},"title":"A New Hope","episode":4}]}}`,
+		},
+		"should throw bad request when field is not allowed": {
+			mocking:            func(planetService *mock.MockPlanetService) {},
+			inputPath:          "/api/planets",
+			inputQuery:         "?fields=name,deleted_at",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid fields"}`,
+		},
+		"should throw bad request when embed is not allowed": {
+			mocking:            func(planetService *mock.MockPlanetService) {},
+			inputPath:          "/api/planets/1",
+			inputQuery:         "?embed=residents",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid embed"}`,
+		},
+		"should throw bad request when embed field is not allowed": {
+			mocking:            func(planetService *mock.MockPlanetService) {},
+			inputPath:          "/api/planets",
+			inputQuery:         "?embed=films(title,planets)",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid embed"}`,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			_, r := gin.CreateTestContext(res)
+
+			mockPlanetService := mock.NewMockPlanetService(ctrl)
+
+			planetController := &controller.IPlanetController{PlanetService: mockPlanetService, LinkFilms: true}
+			r.Use(auth.GinAuth(&auth.IAuthenticator{}, auth.RoleReader))
+			planetController.Configure(r.Group("/api"))
+
+			cs.mocking(mockPlanetService)
+
+			// when
+			r.ServeHTTP(res, httptest.NewRequest("GET", cs.inputPath+cs.inputQuery, nil))
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.JSONEq(t, cs.expectedBody, res.Body.String())
+		})
+	}
+}
+
+func Test_PlanetController_ExportPlanets(t *testing.T) {
+	var cases = map[string]struct {
+		mocking             func(planetService *mock.MockPlanetService)
+		inputQuery          string
+		expectedStatusCode  int
+		expectedContentType string
+		expectedBody        string
+	}{
+		"should export planets as csv": {
+			mocking: func(planetService *mock.MockPlanetService) {
+				planetService.EXPECT().StreamPlanets(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(planet *model.Planet) error, opts ...service.Option) error {
+						query, arg := service.GetOptionWhere(opts)
+						assert.Equal(t, "name like ?", query)
+						assert.Equal(t, "Tatooine", arg)
+						return fn(&model.Planet{ID: 1, Name: "Tatooine", Climates: []byte(`["arid"]`), Terrains: []byte(`["desert"]`)})
+					})
+			},
+			inputQuery:          "?format=csv&name=Tatooine",
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "text/csv",
+			expectedBody: "id,created_at,updated_at,name,climates,terrains,rotation_period,orbital_period,diameter,gravity,surface_water,population\n" +
+				"1,0001-01-01 00:00:00,0001-01-01 00:00:00,Tatooine,arid,desert,,,,,,\n",
+		},
+		"should export planets as ndjson": {
+			mocking: func(planetService *mock.MockPlanetService) {
+				planetService.EXPECT().StreamPlanets(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(planet *model.Planet) error, opts ...service.Option) error {
+						return fn(&model.Planet{ID: 1, Name: "Tatooine"})
+					})
+			},
+			inputQuery:          "?format=ndjson",
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "application/x-ndjson",
+			expectedBody:        `{"id":1,"created_at":"0001-01-01 00:00:00","updated_at":"0001-01-01 00:00:00","name":"Tatooine","climates":null,"terrains":null}` + "\n",
+		},
+		"should export only csv header when there are no planets": {
+			mocking: func(planetService *mock.MockPlanetService) {
+				planetService.EXPECT().StreamPlanets(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			inputQuery:          "?format=csv",
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "text/csv",
+			expectedBody:        "id,created_at,updated_at,name,climates,terrains,rotation_period,orbital_period,diameter,gravity,surface_water,population\n",
+		},
+		"should throw bad request when format is invalid": {
+			mocking:             func(planetService *mock.MockPlanetService) {},
+			inputQuery:          "?format=parquet",
+			expectedStatusCode:  http.StatusBadRequest,
+			expectedContentType: "application/json; charset=utf-8",
+			expectedBody:        `{"error":"invalid format"}`,
+		},
+		"should throw internal server error": {
+			mocking: func(planetService *mock.MockPlanetService) {
+				planetService.EXPECT().StreamPlanets(gomock.Any(), gomock.Any()).Return(fmt.Errorf("error"))
+			},
+			inputQuery:          "?format=ndjson",
+			expectedStatusCode:  http.StatusInternalServerError,
+			expectedContentType: "application/json; charset=utf-8",
+			expectedBody:        `{"error":"internal server error"}`,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			_, r := gin.CreateTestContext(res)
+
+			mockPlanetService := mock.NewMockPlanetService(ctrl)
+
+			planetController := &controller.IPlanetController{PlanetService: mockPlanetService, LinkFilms: true}
+			r.Use(auth.GinAuth(&auth.IAuthenticator{}, auth.RoleReader))
+			planetController.Configure(r.Group("/api"))
+
+			cs.mocking(mockPlanetService)
+
+			// when
+			r.ServeHTTP(res, httptest.NewRequest("GET", "/api/planets/export"+cs.inputQuery, nil))
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.Equal(t, cs.expectedContentType, res.Header().Get("Content-Type"))
+			assert.Equal(t, cs.expectedBody, res.Body.String())
+		})
+	}
+}
+
+func Test_PlanetController_ImportPlanets(t *testing.T) {
+	var cases = map[string]struct {
+		mocking            func(importService *mock.MockImportService)
+		inputQuery         string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		"should import planets": {
+			mocking: func(importService *mock.MockImportService) {
+				importService.EXPECT().ImportPlanets(gomock.Any(), export.FormatCSV, gomock.Any(), false).
+					Return(dto.ImportResult{Total: 1, Valid: 1, Imported: 1, Errors: []dto.ImportRowError{}}, nil)
+			},
+			inputQuery:         "?format=csv",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"total":1,"valid":1,"imported":1,"dry_run":false,"upsert":false,"errors":[]}`,
+		},
+		"should upsert planets on dry run": {
+			mocking: func(importService *mock.MockImportService) {
+				importService.EXPECT().ImportPlanets(gomock.Any(), export.FormatNDJSON, gomock.Any(), true, gomock.Any()).
+					Return(dto.ImportResult{Total: 1, Valid: 1, DryRun: true, Upsert: true, Errors: []dto.ImportRowError{}}, nil)
+			},
+			inputQuery:         "?format=ndjson&dryRun=true&mode=upsert",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"total":1,"valid":1,"imported":0,"dry_run":true,"upsert":true,"errors":[]}`,
+		},
+		"should return unprocessable entity when there are invalid rows": {
+			mocking: func(importService *mock.MockImportService) {
+				importService.EXPECT().ImportPlanets(gomock.Any(), export.FormatCSV, gomock.Any(), false).
+					Return(dto.ImportResult{Total: 1, Errors: []dto.ImportRowError{{Line: 2, Error: "name is required"}}}, nil)
+			},
+			inputQuery:         "?format=csv",
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedBody:       `{"total":1,"valid":0,"imported":0,"dry_run":false,"upsert":false,"errors":[{"line":2,"error":"name is required"}]}`,
+		},
+		"should throw bad request when format is invalid": {
+			mocking:            func(importService *mock.MockImportService) {},
+			inputQuery:         "?format=xml",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid format"}`,
+		},
+		"should throw bad request when mode is invalid": {
+			mocking:            func(importService *mock.MockImportService) {},
+			inputQuery:         "?format=csv&mode=replace",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid mode"}`,
+		},
+		"should throw bad request when file is invalid": {
+			mocking: func(importService *mock.MockImportService) {
+				importService.EXPECT().ImportPlanets(gomock.Any(), export.FormatCSV, gomock.Any(), false).
+					Return(dto.ImportResult{}, &exception.ValidationException{Message: "invalid csv header"})
+			},
+			inputQuery:         "?format=csv",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid csv header"}`,
+		},
+		"should throw internal server error": {
+			mocking: func(importService *mock.MockImportService) {
+				importService.EXPECT().ImportPlanets(gomock.Any(), export.FormatCSV, gomock.Any(), false).
+					Return(dto.ImportResult{}, fmt.Errorf("error"))
+			},
+			inputQuery:         "?format=csv",
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       `{"error":"internal server error"}`,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			_, r := gin.CreateTestContext(res)
+
+			mockImportService := mock.NewMockImportService(ctrl)
+
+			planetController := &controller.IPlanetController{ImportService: mockImportService}
+			r.Use(auth.GinAuth(&auth.IAuthenticator{}, auth.RoleEditor))
+			planetController.Configure(r.Group("/api"))
+
+			cs.mocking(mockImportService)
+
+			// when
+			r.ServeHTTP(res, httptest.NewRequest("POST", "/api/planets/import"+cs.inputQuery, strings.NewReader("")))
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Result().StatusCode)
+			assert.JSONEq(t, cs.expectedBody, res.Body.String())
+		})
+	}
+}
+
 func Test_PlanetController_DeletePlanet(t *testing.T) {
 	var cases = map[string]struct {
 		mocking            func(planetService *mock.MockPlanetService)
@@ -296,7 +740,7 @@ func Test_PlanetController_DeletePlanet(t *testing.T) {
 
 			mockPlanetService := mock.NewMockPlanetService(ctrl)
 
-			planetController := &controller.IPlanetController{PlanetService: mockPlanetService}
+			planetController := &controller.IPlanetController{PlanetService: mockPlanetService, LinkFilms: true}
 			planetController.Configure(r.Group("/api"))
 
 			cs.mocking(mockPlanetService)
@@ -313,6 +757,206 @@ func Test_PlanetController_DeletePlanet(t *testing.T) {
 	}
 }
 
+func Test_PlanetController_Authorization(t *testing.T) {
+	var cases = map[string]struct {
+		mocking            func(authenticator *mock.MockAuthenticator, planetService *mock.MockPlanetService)
+		inputApiKey        string
+		inputAnonymousRole auth.Role
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		"should delete planet when role is admin": {
+			mocking: func(authenticator *mock.MockAuthenticator, planetService *mock.MockPlanetService) {
+				authenticator.EXPECT().Authenticate("admin-key", "").Return(&auth.Principal{Subject: "ops", Role: auth.RoleAdmin}, nil)
+				planetService.EXPECT().DeletePlanet(gomock.Any(), 1).Return(nil)
+			},
+			inputApiKey:        "admin-key",
+			inputAnonymousRole: auth.RoleReader,
+			expectedStatusCode: http.StatusNoContent,
+		},
+		"should throw forbidden when role is editor": {
+			mocking: func(authenticator *mock.MockAuthenticator, planetService *mock.MockPlanetService) {
+				authenticator.EXPECT().Authenticate("editor-key", "").Return(&auth.Principal{Subject: "team", Role: auth.RoleEditor}, nil)
+			},
+			inputApiKey:        "editor-key",
+			inputAnonymousRole: auth.RoleReader,
+			expectedStatusCode: http.StatusForbidden,
+			expectedBody:       `{"error":"forbidden"}`,
+		},
+		"should throw forbidden when anonymous": {
+			mocking: func(authenticator *mock.MockAuthenticator, planetService *mock.MockPlanetService) {
+				authenticator.EXPECT().Authenticate("", "").Return(nil, nil)
+			},
+			inputAnonymousRole: auth.RoleReader,
+			expectedStatusCode: http.StatusForbidden,
+			expectedBody:       `{"error":"forbidden"}`,
+		},
+		"should throw unauthorized when anonymous access is disabled": {
+			mocking: func(authenticator *mock.MockAuthenticator, planetService *mock.MockPlanetService) {
+				authenticator.EXPECT().Authenticate("", "").Return(nil, nil)
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedBody:       `{"error":"unauthorized"}`,
+		},
+		"should throw unauthorized when credentials are invalid": {
+			mocking: func(authenticator *mock.MockAuthenticator, planetService *mock.MockPlanetService) {
+				authenticator.EXPECT().Authenticate("wrong-key", "").Return(nil, auth.ErrInvalidCredentials)
+			},
+			inputApiKey:        "wrong-key",
+			inputAnonymousRole: auth.RoleReader,
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedBody:       `{"error":"invalid credentials"}`,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			_, r := gin.CreateTestContext(res)
+
+			mockAuthenticator := mock.NewMockAuthenticator(ctrl)
+			mockPlanetService := mock.NewMockPlanetService(ctrl)
+
+			r.Use(auth.GinAuth(mockAuthenticator, cs.inputAnonymousRole))
+			planetController := &controller.IPlanetController{PlanetService: mockPlanetService, LinkFilms: true}
+			planetController.Configure(r.Group("/api"))
+
+			cs.mocking(mockAuthenticator, mockPlanetService)
+
+			req := httptest.NewRequest("DELETE", "/api/planets/1", nil)
+			if cs.inputApiKey != "" {
+				req.Header.Set("X-Api-Key", cs.inputApiKey)
+			}
+
+			// when
+			r.ServeHTTP(res, req)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Code)
+			if cs.expectedBody != "" {
+				assert.JSONEq(t, cs.expectedBody, res.Body.String())
+			}
+		})
+	}
+}
+
+func Test_PlanetController_Deprecation(t *testing.T) {
+	var cases = map[string]struct {
+		mocking            func(planetService *mock.MockPlanetService)
+		inputURL           string
+		expectedDeprecated bool
+	}{
+		"should deprecate the planet list": {
+			mocking: func(planetService *mock.MockPlanetService) {
+				planetService.EXPECT().FindPlanetsAndTotal(gomock.Any(), 1, 10, false, nil).Return(dto.FindPlanetsAndTotalResult{}, nil)
+			},
+			inputURL:           "/api/planets",
+			expectedDeprecated: true,
+		},
+		"should deprecate the planet by id": {
+			mocking: func(planetService *mock.MockPlanetService) {
+				planetService.EXPECT().FindPlanetByID(gomock.Any(), 1, false).Return(&model.Planet{ID: 1}, nil)
+			},
+			inputURL:           "/api/planets/1",
+			expectedDeprecated: true,
+		},
+		"should not deprecate the planet history": {
+			mocking: func(planetService *mock.MockPlanetService) {
+				planetService.EXPECT().FindPlanetHistory(gomock.Any(), 1).Return([]*model.PlanetsHistory{}, nil)
+			},
+			inputURL: "/api/planets/1/history",
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			_, r := gin.CreateTestContext(res)
+			r.Use(auth.GinAuth(&auth.IAuthenticator{}, auth.RoleReader))
+
+			mockPlanetService := mock.NewMockPlanetService(ctrl)
+
+			planetController := &controller.IPlanetController{
+				PlanetService:      mockPlanetService,
+				TranslationService: mock.NewMockTranslationService(ctrl),
+				Deprecation:        config.GinDeprecation("/api/v2/planets", time.Time{}),
+			}
+			planetController.Configure(r.Group("/api"))
+
+			cs.mocking(mockPlanetService)
+
+			// when
+			r.ServeHTTP(res, httptest.NewRequest(http.MethodGet, cs.inputURL, nil))
+
+			// then
+			assert.Equal(t, http.StatusOK, res.Code)
+			if cs.expectedDeprecated {
+				assert.Equal(t, "true", res.Header().Get("Deprecation"))
+				assert.Equal(t, `</api/v2/planets>; rel="successor-version"`, res.Header().Get("Link"))
+			} else {
+				assert.Empty(t, res.Header().Get("Deprecation"))
+			}
+		})
+	}
+}
+
+func Test_ParsePlanetFilters(t *testing.T) {
+	var cases = map[string]struct {
+		inputQuery       string
+		inputNameColumn  string
+		expectedOpts     []service.Option
+		expectedErrorMsg string
+	}{
+		"should parse every filter": {
+			inputQuery:      "?name=Tatooine&climate=arid&terrain=desert&surfaceWaterMax=1&diameterMin=10000",
+			inputNameColumn: model.PlanetTableColumns.Name,
+			expectedOpts: []service.Option{
+				service.OptionWhere("planets.name like ?", "Tatooine"),
+				service.OptionWhereClimate("arid"),
+				service.OptionWhereTerrain("desert"),
+				service.OptionWhere("diameter >= ?", float64(10000)),
+				service.OptionWhere("surface_water <= ?", float64(1)),
+			},
+		},
+		"should keep the name placeholder": {
+			inputNameColumn: model.PlanetColumns.Name,
+			expectedOpts:    []service.Option{nil},
+		},
+		"should throw error when range is invalid": {
+			inputQuery:       "?orbitalPeriodMax=long",
+			inputNameColumn:  model.PlanetColumns.Name,
+			expectedErrorMsg: "invalid orbitalPeriodMax",
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			gin.SetMode(gin.TestMode)
+			ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/api/planets"+cs.inputQuery, nil)
+
+			// when
+			opts, err := controller.ParsePlanetFilters(ctx, cs.inputNameColumn)
+
+			// then
+			assert.Equal(t, cs.expectedOpts, opts)
+			if cs.expectedErrorMsg != "" {
+				assert.EqualError(t, err, cs.expectedErrorMsg)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
 func Test_PlanetController_ParsePlanetDto(t *testing.T) {
 	climates, _ := json.Marshal([]string{"arid"})
 	terrains, _ := json.Marshal([]string{"desert"})
@@ -393,3 +1037,418 @@ func Test_PlanetController_ParseFilmDto(t *testing.T) {
 		})
 	}
 }
+
+func Test_PlanetController_LinkPlanetDto(t *testing.T) {
+	var cases = map[string]struct {
+		inputLinkFilms bool
+		expectedLinks  *dto.PlanetLinks
+		expectedFilm   *dto.FilmLinks
+	}{
+		"should link the film routes": {
+			inputLinkFilms: true,
+			expectedLinks:  planetLinks(1),
+			expectedFilm: &dto.FilmLinks{
+				Self:   &dto.Link{Href: "http://example.com/api/films/4"},
+				Planet: &dto.Link{Href: "http://example.com/api/planets/1"},
+			},
+		},
+		"should not link the film routes when they are not configured": {
+			expectedLinks: &dto.PlanetLinks{Self: dto.Link{Href: "http://example.com/api/planets/1"}},
+			expectedFilm:  &dto.FilmLinks{Planet: &dto.Link{Href: "http://example.com/api/planets/1"}},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			gin.SetMode(gin.TestMode)
+			ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/api/planets/1", nil)
+
+			planetController := &controller.IPlanetController{LinkFilms: cs.inputLinkFilms}
+			planet := dto.PlanetDto{ID: 1, Films: []dto.FilmDto{{ID: 4}}}
+
+			// when
+			planetController.LinkPlanetDto(ctx, &planet)
+
+			// then
+			assert.Equal(t, cs.expectedLinks, planet.Links)
+			assert.Equal(t, cs.expectedFilm, planet.Films[0].Links)
+		})
+	}
+}
+
+func Test_PlanetController_Locale(t *testing.T) {
+	var cases = map[string]struct {
+		mocking                 func(planetService *mock.MockPlanetService, translationService *mock.MockTranslationService)
+		inputURL                string
+		inputAcceptLanguage     string
+		expectedStatusCode      int
+		expectedContentLanguage string
+		expectedBody            string
+	}{
+		"should find planet translated to negotiated locale": {
+			mocking: func(planetService *mock.MockPlanetService, translationService *mock.MockTranslationService) {
+				planet := &model.Planet{ID: 1, Name: "Tatooine"}
+				planetService.EXPECT().FindPlanetByID(gomock.Any(), 1, false).Return(planet, nil)
+				translationService.EXPECT().TranslatePlanets(gomock.Any(), i18n.LOCALE_PT_BR, []*model.Planet{planet}).
+					DoAndReturn(func(ctx context.Context, locale string, planets []*model.Planet) error {
+						planets[0].Name = "Tatuíne"
+						return nil
+					})
+			},
+			inputURL:                "/api/planets/1",
+			inputAcceptLanguage:     "pt-BR,pt;q=0.9",
+			expectedStatusCode:      http.StatusOK,
+			expectedContentLanguage: i18n.LOCALE_PT_BR,
+			expectedBody:            `{"data":{"id":1,"created_at":"0001-01-01 00:00:00","updated_at":"0001-01-01 00:00:00","name":"Tatuíne","_links":{"self":{"href":"http://example.com/api/planets/1"},"films":{"href":"http://example.com/api/planets/1/films"}}}}`,
+		},
+		"should find planets translated to negotiated locale": {
+			mocking: func(planetService *mock.MockPlanetService, translationService *mock.MockTranslationService) {
+				planets := []*model.Planet{{ID: 1, Name: "Tatooine"}}
+				planetService.EXPECT().FindPlanetsAndTotal(gomock.Any(), 1, 10, false, nil).
+					Return(dto.FindPlanetsAndTotalResult{Count: 1, Total: 1, Data: planets}, nil)
+				translationService.EXPECT().TranslatePlanets(gomock.Any(), i18n.LOCALE_PT_BR, planets).
+					DoAndReturn(func(ctx context.Context, locale string, planets []*model.Planet) error {
+						planets[0].Name = "Tatuíne"
+						return nil
+					})
+			},
+			inputURL:                "/api/planets",
+			inputAcceptLanguage:     "pt-BR",
+			expectedStatusCode:      http.StatusOK,
+			expectedContentLanguage: i18n.LOCALE_PT_BR,
+			expectedBody:            `{"count":1,"total":1,"previous":"","next":"","data":[{"id":1,"created_at":"0001-01-01 00:00:00","updated_at":"0001-01-01 00:00:00","name":"Tatuíne","_links":{"self":{"href":"http://example.com/api/planets/1"},"films":{"href":"http://example.com/api/planets/1/films"}}}]}`,
+		},
+		"should not translate planet to default locale": {
+			mocking: func(planetService *mock.MockPlanetService, translationService *mock.MockTranslationService) {
+				planetService.EXPECT().FindPlanetByID(gomock.Any(), 1, false).Return(&model.Planet{ID: 1, Name: "Tatooine"}, nil)
+			},
+			inputURL:                "/api/planets/1",
+			inputAcceptLanguage:     "ja",
+			expectedStatusCode:      http.StatusOK,
+			expectedContentLanguage: i18n.LOCALE_EN,
+			expectedBody:            `{"data":{"id":1,"created_at":"0001-01-01 00:00:00","updated_at":"0001-01-01 00:00:00","name":"Tatooine","_links":{"self":{"href":"http://example.com/api/planets/1"},"films":{"href":"http://example.com/api/planets/1/films"}}}}`,
+		},
+		"should throw not found translated to negotiated locale": {
+			mocking: func(planetService *mock.MockPlanetService, translationService *mock.MockTranslationService) {
+				planetService.EXPECT().FindPlanetByID(gomock.Any(), 1, false).Return(nil, &exception.NotFoundException{Message: "planet 1 not found"})
+			},
+			inputURL:                "/api/planets/1",
+			inputAcceptLanguage:     "pt-BR",
+			expectedStatusCode:      http.StatusNotFound,
+			expectedContentLanguage: i18n.LOCALE_PT_BR,
+			expectedBody:            `{"error":"planeta 1 não encontrado"}`,
+		},
+		"should throw bad request translated to negotiated locale": {
+			mocking:                 func(planetService *mock.MockPlanetService, translationService *mock.MockTranslationService) {},
+			inputURL:                "/api/planets/0",
+			inputAcceptLanguage:     "pt-BR",
+			expectedStatusCode:      http.StatusBadRequest,
+			expectedContentLanguage: i18n.LOCALE_PT_BR,
+			expectedBody:            `{"error":"id do planeta inválido"}`,
+		},
+		"should throw internal server error when translate planets": {
+			mocking: func(planetService *mock.MockPlanetService, translationService *mock.MockTranslationService) {
+				planetService.EXPECT().FindPlanetByID(gomock.Any(), 1, false).Return(&model.Planet{ID: 1, Name: "Tatooine"}, nil)
+				translationService.EXPECT().TranslatePlanets(gomock.Any(), i18n.LOCALE_PT_BR, gomock.Any()).Return(fmt.Errorf("error"))
+			},
+			inputURL:                "/api/planets/1",
+			inputAcceptLanguage:     "pt-BR",
+			expectedStatusCode:      http.StatusInternalServerError,
+			expectedContentLanguage: i18n.LOCALE_PT_BR,
+			expectedBody:            `{"error":"erro interno do servidor"}`,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			_, r := gin.CreateTestContext(res)
+			r.Use(i18n.GinLocale())
+			r.Use(auth.GinAuth(&auth.IAuthenticator{}, auth.RoleReader))
+
+			mockPlanetService := mock.NewMockPlanetService(ctrl)
+			mockTranslationService := mock.NewMockTranslationService(ctrl)
+
+			planetController := &controller.IPlanetController{
+				PlanetService:      mockPlanetService,
+				TranslationService: mockTranslationService,
+				LinkFilms:          true,
+			}
+			planetController.Configure(r.Group("/api"))
+
+			cs.mocking(mockPlanetService, mockTranslationService)
+
+			req := httptest.NewRequest(http.MethodGet, cs.inputURL, nil)
+			req.Header.Set("Accept-Language", cs.inputAcceptLanguage)
+
+			// when
+			r.ServeHTTP(res, req)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Code)
+			assert.Equal(t, cs.expectedContentLanguage, res.Header().Get("Content-Language"))
+			assert.Equal(t, cs.expectedBody, res.Body.String())
+		})
+	}
+}
+
+func planetLinks(planetID int) *dto.PlanetLinks {
+	self := fmt.Sprintf("http://example.com/api/planets/%d", planetID)
+
+	return &dto.PlanetLinks{Self: dto.Link{Href: self}, Films: &dto.Link{Href: self + "/films"}}
+}
+
+func Test_PlanetController_BatchGet(t *testing.T) {
+	var cases = map[string]struct {
+		mocking            func(planetService *mock.MockPlanetService)
+		inputMethod        string
+		inputURL           string
+		inputBody          string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		"should find planets by ids query": {
+			mocking: func(planetService *mock.MockPlanetService) {
+				planetService.EXPECT().FindPlanetsByIDs(gomock.Any(), []int{3, 1, 2}, false).
+					Return(dto.FindPlanetsByIDsResult{
+						Data:    []*model.Planet{{ID: 3, Name: "Yavin IV"}, {ID: 1, Name: "Tatooine"}},
+						Missing: []int{2},
+					}, nil)
+			},
+			inputMethod:        http.MethodGet,
+			inputURL:           "/api/planets?ids=3,1,2",
+			expectedStatusCode: http.StatusOK,
+			expectedBody: `{"count":2,"data":[` +
+				`{"id":3,"created_at":"0001-01-01 00:00:00","updated_at":"0001-01-01 00:00:00","name":"Yavin IV","_links":{"self":{"href":"http://example.com/api/planets/3"},"films":{"href":"http://example.com/api/planets/3/films"}}},` +
+				`{"id":1,"created_at":"0001-01-01 00:00:00","updated_at":"0001-01-01 00:00:00","name":"Tatooine","_links":{"self":{"href":"http://example.com/api/planets/1"},"films":{"href":"http://example.com/api/planets/1/films"}}}` +
+				`],"missing":[2]}`,
+		},
+		"should find planets by ids body with films": {
+			mocking: func(planetService *mock.MockPlanetService) {
+				planet := &model.Planet{ID: 1, Name: "Tatooine"}
+				planet.R = planet.R.NewStruct()
+				planet.R.Films = []*model.Film{{ID: 1, Title: "A New Hope"}}
+				planetService.EXPECT().FindPlanetsByIDs(gomock.Any(), []int{1}, true).
+					Return(dto.FindPlanetsByIDsResult{Data: []*model.Planet{planet}, Missing: []int{}}, nil)
+			},
+			inputMethod:        http.MethodPost,
+			inputURL:           "/api/planets/batch-get",
+			inputBody:          `{"ids":[1],"loadFilms":true}`,
+			expectedStatusCode: http.StatusOK,
+			expectedBody: `{"count":1,"data":[{"id":1,"created_at":"0001-01-01 00:00:00","updated_at":"0001-01-01 00:00:00",` +
+				`"films":[{"id":1,"created_at":"0001-01-01 00:00:00","updated_at":"0001-01-01 00:00:00","title":"A New Hope","release_date":"0001-01-01","_links":{"self":{"href":"http://example.com/api/films/1"},"planet":{"href":"http://example.com/api/planets/1"}}}],` +
+				`"name":"Tatooine","_links":{"self":{"href":"http://example.com/api/planets/1"},"films":{"href":"http://example.com/api/planets/1/films"}}}],"missing":[]}`,
+		},
+		"should throw bad request when ids query is invalid": {
+			mocking:            func(planetService *mock.MockPlanetService) {},
+			inputMethod:        http.MethodGet,
+			inputURL:           "/api/planets?ids=1,abc",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid ids"}`,
+		},
+		"should throw bad request when ids are not positive": {
+			mocking:            func(planetService *mock.MockPlanetService) {},
+			inputMethod:        http.MethodPost,
+			inputURL:           "/api/planets/batch-get",
+			inputBody:          `{"ids":[1,0]}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid ids"}`,
+		},
+		"should throw bad request when ids are empty": {
+			mocking:            func(planetService *mock.MockPlanetService) {},
+			inputMethod:        http.MethodPost,
+			inputURL:           "/api/planets/batch-get",
+			inputBody:          `{"ids":[]}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"ids is required"}`,
+		},
+		"should throw bad request when there are too many ids": {
+			mocking:            func(planetService *mock.MockPlanetService) {},
+			inputMethod:        http.MethodPost,
+			inputURL:           "/api/planets/batch-get",
+			inputBody:          `{"ids":[` + strings.Repeat("1,", controller.PLANETS_BATCH_MAX_IDS) + `1]}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       fmt.Sprintf(`{"error":"ids must have at most %d items"}`, controller.PLANETS_BATCH_MAX_IDS),
+		},
+		"should throw internal server error when find planets by ids": {
+			mocking: func(planetService *mock.MockPlanetService) {
+				planetService.EXPECT().FindPlanetsByIDs(gomock.Any(), []int{1}, false).Return(dto.FindPlanetsByIDsResult{}, fmt.Errorf("error"))
+			},
+			inputMethod:        http.MethodGet,
+			inputURL:           "/api/planets?ids=1",
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       `{"error":"internal server error"}`,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			_, r := gin.CreateTestContext(res)
+			r.Use(auth.GinAuth(&auth.IAuthenticator{}, auth.RoleReader))
+
+			mockPlanetService := mock.NewMockPlanetService(ctrl)
+
+			planetController := &controller.IPlanetController{PlanetService: mockPlanetService, LinkFilms: true}
+			planetController.Configure(r.Group("/api"))
+
+			cs.mocking(mockPlanetService)
+
+			// when
+			r.ServeHTTP(res, httptest.NewRequest(cs.inputMethod, cs.inputURL, strings.NewReader(cs.inputBody)))
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Code)
+			assert.Equal(t, cs.expectedBody, res.Body.String())
+		})
+	}
+}
+
+func Test_PlanetController_BulkPlanets(t *testing.T) {
+	name := "Tatooine"
+
+	var cases = map[string]struct {
+		mocking            func(planetService *mock.MockPlanetService)
+		inputBody          string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		"should run operations atomically": {
+			mocking: func(planetService *mock.MockPlanetService) {
+				planetService.EXPECT().BulkPlanets(gomock.Any(), []dto.PlanetBulkOperation{
+					{Op: "delete", ID: 1},
+					{Op: "patch", ID: 2, Patch: &dto.PlanetPatch{Name: &name}},
+				}, true).Return(dto.BulkPlanetsResult{Committed: true, Results: []dto.BulkPlanetResult{
+					{},
+					{Planet: &model.Planet{ID: 2, Name: name}},
+				}}, nil)
+			},
+			inputBody:          `{"operations":[{"op":"delete","id":1},{"op":"patch","id":2,"patch":{"name":"Tatooine"}}]}`,
+			expectedStatusCode: http.StatusOK,
+			expectedBody: `{"mode":"atomic","committed":true,"succeeded":2,"failed":0,"results":[` +
+				`{"index":0,"op":"delete","id":1,"status":204},` +
+				`{"index":1,"op":"patch","id":2,"status":200,"data":{"id":2,"created_at":"0001-01-01 00:00:00","updated_at":"0001-01-01 00:00:00","name":"Tatooine","_links":{"self":{"href":"http://example.com/api/planets/2"},"films":{"href":"http://example.com/api/planets/2/films"}}}}]}`,
+		},
+		"should answer conflict when atomic operations are rolled back": {
+			mocking: func(planetService *mock.MockPlanetService) {
+				planetService.EXPECT().BulkPlanets(gomock.Any(), gomock.Any(), true).
+					Return(dto.BulkPlanetsResult{Results: []dto.BulkPlanetResult{
+						{},
+						{Err: &exception.NotFoundException{Message: "deleted planet 2 not found"}},
+					}}, nil)
+			},
+			inputBody:          `{"mode":"atomic","operations":[{"op":"delete","id":1},{"op":"restore","id":2}]}`,
+			expectedStatusCode: http.StatusConflict,
+			expectedBody: `{"mode":"atomic","committed":false,"succeeded":0,"failed":2,"results":[` +
+				`{"index":0,"op":"delete","id":1,"status":424,"error":"operation rolled back"},` +
+				`{"index":1,"op":"restore","id":2,"status":404,"error":"deleted planet 2 not found"}]}`,
+		},
+		"should keep succeeded operations when best effort": {
+			mocking: func(planetService *mock.MockPlanetService) {
+				planetService.EXPECT().BulkPlanets(gomock.Any(), gomock.Any(), false).
+					Return(dto.BulkPlanetsResult{Committed: true, Results: []dto.BulkPlanetResult{
+						{Err: &exception.NotFoundException{Message: "planet 1 not found"}},
+						{Err: fmt.Errorf("error")},
+						{},
+					}}, nil)
+			},
+			inputBody:          `{"mode":"best_effort","operations":[{"op":"delete","id":1},{"op":"delete","id":2},{"op":"delete","id":3}]}`,
+			expectedStatusCode: http.StatusOK,
+			expectedBody: `{"mode":"best_effort","committed":true,"succeeded":1,"failed":2,"results":[` +
+				`{"index":0,"op":"delete","id":1,"status":404,"error":"planet 1 not found"},` +
+				`{"index":1,"op":"delete","id":2,"status":500,"error":"internal server error"},` +
+				`{"index":2,"op":"delete","id":3,"status":204}]}`,
+		},
+		"should throw bad request when body is invalid": {
+			mocking:            func(planetService *mock.MockPlanetService) {},
+			inputBody:          `{"operations":`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid body"}`,
+		},
+		"should throw bad request when mode is invalid": {
+			mocking:            func(planetService *mock.MockPlanetService) {},
+			inputBody:          `{"mode":"partial","operations":[{"op":"delete","id":1}]}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid mode"}`,
+		},
+		"should throw bad request when operations are empty": {
+			mocking:            func(planetService *mock.MockPlanetService) {},
+			inputBody:          `{"operations":[]}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"operations is required"}`,
+		},
+		"should throw bad request when there are too many operations": {
+			mocking:            func(planetService *mock.MockPlanetService) {},
+			inputBody:          `{"operations":[` + strings.Repeat(`{"op":"delete","id":1},`, controller.PLANETS_BULK_MAX_OPERATIONS) + `{"op":"delete","id":1}]}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       fmt.Sprintf(`{"error":"operations must have at most %d items"}`, controller.PLANETS_BULK_MAX_OPERATIONS),
+		},
+		"should throw bad request when op is invalid": {
+			mocking:            func(planetService *mock.MockPlanetService) {},
+			inputBody:          `{"operations":[{"op":"create","id":1}]}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid op create"}`,
+		},
+		"should throw bad request when planet id is invalid": {
+			mocking:            func(planetService *mock.MockPlanetService) {},
+			inputBody:          `{"operations":[{"op":"delete","id":0}]}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid planet id"}`,
+		},
+		"should throw bad request when patch is missing": {
+			mocking:            func(planetService *mock.MockPlanetService) {},
+			inputBody:          `{"operations":[{"op":"patch","id":1}]}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"patch is required"}`,
+		},
+		"should throw bad request when patched name is blank": {
+			mocking:            func(planetService *mock.MockPlanetService) {},
+			inputBody:          `{"operations":[{"op":"patch","id":1,"patch":{"name":" "}}]}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"name is required"}`,
+		},
+		"should throw internal server error when bulk planets": {
+			mocking: func(planetService *mock.MockPlanetService) {
+				planetService.EXPECT().BulkPlanets(gomock.Any(), gomock.Any(), true).Return(dto.BulkPlanetsResult{}, fmt.Errorf("error"))
+			},
+			inputBody:          `{"operations":[{"op":"delete","id":1}]}`,
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       `{"error":"internal server error"}`,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			_, r := gin.CreateTestContext(res)
+			r.Use(auth.GinAuth(&auth.IAuthenticator{}, auth.RoleAdmin))
+
+			mockPlanetService := mock.NewMockPlanetService(ctrl)
+
+			planetController := &controller.IPlanetController{PlanetService: mockPlanetService, LinkFilms: true}
+			planetController.Configure(r.Group("/api"))
+
+			cs.mocking(mockPlanetService)
+
+			// when
+			r.ServeHTTP(res, httptest.NewRequest(http.MethodPost, "/api/planets/bulk", strings.NewReader(cs.inputBody)))
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Code)
+			assert.Equal(t, cs.expectedBody, res.Body.String())
+		})
+	}
+}
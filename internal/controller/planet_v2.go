@@ -0,0 +1,207 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/starwars-api/internal/auth"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/exception"
+	"github.com/viniosilva/starwars-api/internal/i18n"
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/viniosilva/starwars-api/internal/service"
+)
+
+type IPlanetV2Controller struct {
+	PlanetService      service.PlanetService
+	TranslationService service.TranslationService
+}
+
+func (impl *IPlanetV2Controller) Configure(router *gin.RouterGroup) {
+	router.GET("/planets", auth.RequireRole(auth.RoleReader), impl.FindPlanetsAndTotal)
+	router.GET("/planets/:planetID", auth.RequireRole(auth.RoleReader), impl.FindPlanetByID)
+}
+
+// @Summary find planets
+// @Schemes
+// @Tags planet v2
+// @Accept json
+// @Produce json
+// @Param page query int false "page"
+// @Param size query int false "size"
+// @Param loadFilms query bool false "loadFilms"
+// @Param name query string false "name"
+// @Param climate query string false "climate"
+// @Param terrain query string false "terrain"
+// @Param rotationPeriodMin query number false "minimum rotationPeriod"
+// @Param rotationPeriodMax query number false "maximum rotationPeriod"
+// @Param orbitalPeriodMin query number false "minimum orbitalPeriod"
+// @Param orbitalPeriodMax query number false "maximum orbitalPeriod"
+// @Param diameterMin query number false "minimum diameter"
+// @Param diameterMax query number false "maximum diameter"
+// @Param surfaceWaterMin query number false "minimum surfaceWater"
+// @Param surfaceWaterMax query number false "maximum surfaceWater"
+// @Param populationMin query number false "minimum population"
+// @Param populationMax query number false "maximum population"
+// @Success 200 {object} dto.PlanetsV2Response
+// @Failure 400 {object} dto.ApiError
+// @Failure 401 {object} dto.ApiError
+// @Failure 403 {object} dto.ApiError
+// @Failure 429 {object} dto.ApiError
+// @Failure 500 {object} dto.ApiError
+// @Router /api/v2/planets [get]
+func (impl *IPlanetV2Controller) FindPlanetsAndTotal(ctx *gin.Context) {
+	page, err := strconv.Atoi(ctx.Query("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	size, err := strconv.Atoi(ctx.Query("size"))
+	if err != nil || size < 1 {
+		size = 10
+	}
+	loadFilms := ctx.Query("loadFilms") == "true"
+
+	opts, err := ParsePlanetFilters(ctx, model.PlanetColumns.Name)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, err.Error())})
+		return
+	}
+
+	res, err := impl.PlanetService.FindPlanetsAndTotal(ctx, page, size, loadFilms, opts...)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ApiError{Error: i18n.T(ctx, "internal server error")})
+		return
+	}
+	if err := translatePlanets(ctx, impl.TranslationService, res.Data); err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ApiError{Error: i18n.T(ctx, "internal server error")})
+		return
+	}
+
+	base := RequestBaseURL(ctx)
+	data := make([]dto.PlanetV2Dto, res.Count)
+	for i := 0; i < len(data); i += 1 {
+		data[i] = impl.ParsePlanetV2Dto(base, res.Data[i])
+	}
+
+	totalPages := (res.Total + int64(size) - 1) / int64(size)
+	links := dto.LinksV2{
+		Self:  &dto.LinkV2{Href: RequestPageURL(ctx, page)},
+		First: &dto.LinkV2{Href: RequestPageURL(ctx, 1)},
+	}
+	if page > 1 {
+		links.Prev = &dto.LinkV2{Href: RequestPageURL(ctx, page-1)}
+	}
+	if res.Next {
+		links.Next = &dto.LinkV2{Href: RequestPageURL(ctx, page+1)}
+	}
+	if totalPages > 0 {
+		links.Last = &dto.LinkV2{Href: RequestPageURL(ctx, int(totalPages))}
+	}
+
+	ctx.JSON(http.StatusOK, dto.PlanetsV2Response{
+		Links:    links,
+		Embedded: dto.PlanetsV2Embedded{Planets: data},
+		Page: dto.PageV2{
+			Number:        page,
+			Size:          size,
+			Count:         len(data),
+			TotalElements: res.Total,
+			TotalPages:    totalPages,
+		},
+	})
+}
+
+// @Summary find planet by id
+// @Schemes
+// @Tags planet v2
+// @Accept json
+// @Produce json
+// @Param planetID path int true "Planet ID"
+// @Param loadFilms query bool false "loadFilms"
+// @Success 200 {object} dto.PlanetV2Dto
+// @Failure 400 {object} dto.ApiError
+// @Failure 401 {object} dto.ApiError
+// @Failure 403 {object} dto.ApiError
+// @Failure 404 {object} dto.ApiError
+// @Failure 429 {object} dto.ApiError
+// @Failure 500 {object} dto.ApiError
+// @Router /api/v2/planets/{planetID} [get]
+func (impl *IPlanetV2Controller) FindPlanetByID(ctx *gin.Context) {
+	planetID, err := strconv.Atoi(ctx.Param("planetID"))
+	if err != nil || planetID < 1 {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, "invalid planet id")})
+		return
+	}
+	loadFilms := ctx.Query("loadFilms") == "true"
+
+	planet, err := impl.PlanetService.FindPlanetByID(ctx, planetID, loadFilms)
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); ok {
+			ctx.JSON(http.StatusNotFound, dto.ApiError{Error: i18n.T(ctx, fmt.Sprintf("planet %d not found", planetID))})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, dto.ApiError{Error: i18n.T(ctx, "internal server error")})
+		return
+	}
+
+	if err := translatePlanets(ctx, impl.TranslationService, []*model.Planet{planet}); err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ApiError{Error: i18n.T(ctx, "internal server error")})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, impl.ParsePlanetV2Dto(RequestBaseURL(ctx), planet))
+}
+
+// ParsePlanetV2Dto maps a planet to its v2 representation, with RFC 3339
+// timestamps, a self link from base and the loaded films embedded
+func (impl *IPlanetV2Controller) ParsePlanetV2Dto(base string, planet *model.Planet) dto.PlanetV2Dto {
+	climates := []string{}
+	json.Unmarshal(planet.Climates, &climates)
+
+	terrains := []string{}
+	json.Unmarshal(planet.Terrains, &terrains)
+
+	res := dto.PlanetV2Dto{
+		Links:          dto.LinksV2{Self: &dto.LinkV2{Href: fmt.Sprintf("%s/api/v2/planets/%d", base, planet.ID)}},
+		ID:             planet.ID,
+		CreatedAt:      planet.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt:      planet.UpdatedAt.UTC().Format(time.RFC3339),
+		Name:           planet.Name,
+		Climates:       climates,
+		Terrains:       terrains,
+		RotationPeriod: planet.RotationPeriod.Ptr(),
+		OrbitalPeriod:  planet.OrbitalPeriod.Ptr(),
+		Diameter:       planet.Diameter.Ptr(),
+		Gravity:        planet.Gravity.Ptr(),
+		SurfaceWater:   planet.SurfaceWater.Ptr(),
+		Population:     planet.Population.Ptr(),
+	}
+
+	if planet.R != nil && planet.R.Films != nil {
+		films := make([]dto.FilmV2Dto, len(planet.R.Films))
+		for i := 0; i < len(films); i += 1 {
+			films[i] = impl.ParseFilmV2Dto(planet.R.Films[i])
+		}
+		res.Embedded = &dto.PlanetV2Embedded{Films: films}
+	}
+
+	return res
+}
+
+func (impl *IPlanetV2Controller) ParseFilmV2Dto(film *model.Film) dto.FilmV2Dto {
+	return dto.FilmV2Dto{
+		ID:           film.ID,
+		CreatedAt:    film.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt:    film.UpdatedAt.UTC().Format(time.RFC3339),
+		Title:        film.Title,
+		Episode:      int(film.Episode),
+		Director:     film.Director,
+		Producer:     film.Producer.String,
+		ReleaseDate:  film.ReleaseDate.Format("2006-01-02"),
+		OpeningCrawl: film.OpeningCrawl.String,
+	}
+}
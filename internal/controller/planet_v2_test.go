@@ -0,0 +1,148 @@
+package controller_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/auth"
+	"github.com/viniosilva/starwars-api/internal/controller"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/exception"
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/viniosilva/starwars-api/internal/service"
+	"github.com/viniosilva/starwars-api/mock"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+func Test_PlanetV2Controller(t *testing.T) {
+	createdAt := time.Date(2014, 12, 9, 13, 50, 49, 0, time.UTC)
+
+	var cases = map[string]struct {
+		mocking            func(planetService *mock.MockPlanetService)
+		inputURL           string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		"should find planets with page metadata and links": {
+			mocking: func(planetService *mock.MockPlanetService) {
+				planetService.EXPECT().FindPlanetsAndTotal(gomock.Any(), 2, 1, false, nil, service.OptionWhereClimate("arid")).
+					Return(dto.FindPlanetsAndTotalResult{Count: 1, Total: 3, Next: true, Data: []*model.Planet{{
+						ID:        1,
+						CreatedAt: createdAt,
+						UpdatedAt: createdAt,
+						Name:      "Tatooine",
+						Climates:  types.JSON(`["arid"]`),
+						Diameter:  null.IntFrom(10465),
+					}}}, nil)
+			},
+			inputURL:           "/api/v2/planets?page=2\u0026size=1\u0026climate=arid",
+			expectedStatusCode: http.StatusOK,
+			expectedBody: `{"_links":{` +
+				`"self":{"href":"http://example.com/api/v2/planets?climate=arid\u0026page=2\u0026size=1"},` +
+				`"first":{"href":"http://example.com/api/v2/planets?climate=arid\u0026page=1\u0026size=1"},` +
+				`"prev":{"href":"http://example.com/api/v2/planets?climate=arid\u0026page=1\u0026size=1"},` +
+				`"next":{"href":"http://example.com/api/v2/planets?climate=arid\u0026page=3\u0026size=1"},` +
+				`"last":{"href":"http://example.com/api/v2/planets?climate=arid\u0026page=3\u0026size=1"}},` +
+				`"_embedded":{"planets":[{"_links":{"self":{"href":"http://example.com/api/v2/planets/1"}},` +
+				`"id":1,"created_at":"2014-12-09T13:50:49Z","updated_at":"2014-12-09T13:50:49Z","name":"Tatooine",` +
+				`"climates":["arid"],"terrains":[],"rotation_period":null,"orbital_period":null,"diameter":10465,` +
+				`"gravity":null,"surface_water":null,"population":null}]},` +
+				`"page":{"number":2,"size":1,"count":1,"total_elements":3,"total_pages":3}}`,
+		},
+		"should find no planets": {
+			mocking: func(planetService *mock.MockPlanetService) {
+				planetService.EXPECT().FindPlanetsAndTotal(gomock.Any(), 1, 10, false, nil).Return(dto.FindPlanetsAndTotalResult{}, nil)
+			},
+			inputURL:           "/api/v2/planets",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"_links":{"self":{"href":"http://example.com/api/v2/planets?page=1"},"first":{"href":"http://example.com/api/v2/planets?page=1"}},"_embedded":{"planets":[]},"page":{"number":1,"size":10,"count":0,"total_elements":0,"total_pages":0}}`,
+		},
+		"should throw bad request when range is invalid": {
+			mocking:            func(planetService *mock.MockPlanetService) {},
+			inputURL:           "/api/v2/planets?diameterMin=big",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid diameterMin"}`,
+		},
+		"should throw internal server error when find planets": {
+			mocking: func(planetService *mock.MockPlanetService) {
+				planetService.EXPECT().FindPlanetsAndTotal(gomock.Any(), 1, 10, false, nil).Return(dto.FindPlanetsAndTotalResult{}, fmt.Errorf("error"))
+			},
+			inputURL:           "/api/v2/planets",
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       `{"error":"internal server error"}`,
+		},
+		"should find planet with embedded films": {
+			mocking: func(planetService *mock.MockPlanetService) {
+				planet := &model.Planet{ID: 1, CreatedAt: createdAt, UpdatedAt: createdAt, Name: "Tatooine"}
+				planet.R = planet.R.NewStruct()
+				planet.R.Films = []*model.Film{{
+					ID:          1,
+					CreatedAt:   createdAt,
+					UpdatedAt:   createdAt,
+					Title:       "A New Hope",
+					Episode:     4,
+					Director:    "George Lucas",
+					ReleaseDate: time.Date(1977, 5, 25, 0, 0, 0, 0, time.UTC),
+				}}
+				planetService.EXPECT().FindPlanetByID(gomock.Any(), 1, true).Return(planet, nil)
+			},
+			inputURL:           "/api/v2/planets/1?loadFilms=true",
+			expectedStatusCode: http.StatusOK,
+			expectedBody: `{"_links":{"self":{"href":"http://example.com/api/v2/planets/1"}},` +
+				`"id":1,"created_at":"2014-12-09T13:50:49Z","updated_at":"2014-12-09T13:50:49Z","name":"Tatooine",` +
+				`"climates":[],"terrains":[],"rotation_period":null,"orbital_period":null,"diameter":null,` +
+				`"gravity":null,"surface_water":null,"population":null,` +
+				`"_embedded":{"films":[{"id":1,"created_at":"2014-12-09T13:50:49Z","updated_at":"2014-12-09T13:50:49Z",` +
+				`"title":"A New Hope","episode":4,"director":"George Lucas","release_date":"1977-05-25"}]}}`,
+		},
+		"should throw not found when planet does not exist": {
+			mocking: func(planetService *mock.MockPlanetService) {
+				planetService.EXPECT().FindPlanetByID(gomock.Any(), 1, false).Return(nil, &exception.NotFoundException{Message: "planet 1 not found"})
+			},
+			inputURL:           "/api/v2/planets/1",
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       `{"error":"planet 1 not found"}`,
+		},
+		"should throw bad request when planet id is invalid": {
+			mocking:            func(planetService *mock.MockPlanetService) {},
+			inputURL:           "/api/v2/planets/abc",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid planet id"}`,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			_, r := gin.CreateTestContext(res)
+			r.Use(auth.GinAuth(&auth.IAuthenticator{}, auth.RoleReader))
+
+			mockPlanetService := mock.NewMockPlanetService(ctrl)
+
+			planetV2Controller := &controller.IPlanetV2Controller{
+				PlanetService: mockPlanetService,
+			}
+			planetV2Controller.Configure(r.Group("/api/v2"))
+
+			cs.mocking(mockPlanetService)
+
+			// when
+			r.ServeHTTP(res, httptest.NewRequest(http.MethodGet, cs.inputURL, nil))
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Code)
+			assert.Equal(t, cs.expectedBody, res.Body.String())
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/starwars-api/internal/auth"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/i18n"
+	"github.com/viniosilva/starwars-api/internal/service"
+)
+
+const (
+	SEARCH_DEFAULT_LIMIT = 20
+	SEARCH_MAX_LIMIT     = 100
+)
+
+// ISearchController links the planets it finds like IPlanetController,
+// including the film links only when LinkFilms is set
+type ISearchController struct {
+	SearchService service.SearchService
+	LinkFilms     bool
+}
+
+func (impl *ISearchController) Configure(router *gin.RouterGroup) {
+	router.GET("/search", auth.RequireRole(auth.RoleReader), impl.Search)
+}
+
+// @Summary search planets and films
+// @Schemes
+// @Tags search
+// @Accept json
+// @Produce json
+// @Param q query string true "search terms, typos are tolerated"
+// @Param types query string false "comma separated result types, e.g. planet,film"
+// @Param limit query int false "limit"
+// @Success 200 {object} dto.SearchResponse
+// @Failure 400 {object} dto.ApiError
+// @Failure 401 {object} dto.ApiError
+// @Failure 403 {object} dto.ApiError
+// @Failure 429 {object} dto.ApiError
+// @Failure 500 {object} dto.ApiError
+// @Router /api/search [get]
+func (impl *ISearchController) Search(ctx *gin.Context) {
+	query := strings.TrimSpace(ctx.Query("q"))
+	if query == "" {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, "q is required")})
+		return
+	}
+
+	types := []string{}
+	if raw := ctx.Query("types"); raw != "" {
+		for _, t := range strings.Split(raw, ",") {
+			t = strings.TrimSpace(t)
+			if t != service.SEARCH_TYPE_PLANET && t != service.SEARCH_TYPE_FILM {
+				ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, fmt.Sprintf("invalid type %s", t))})
+				return
+			}
+			types = append(types, t)
+		}
+	}
+
+	limit, err := strconv.Atoi(ctx.Query("limit"))
+	if err != nil || limit < 1 {
+		limit = SEARCH_DEFAULT_LIMIT
+	}
+	if limit > SEARCH_MAX_LIMIT {
+		limit = SEARCH_MAX_LIMIT
+	}
+
+	results, err := impl.SearchService.Search(ctx, query, types, limit)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ApiError{Error: i18n.T(ctx, "internal server error")})
+		return
+	}
+
+	parser := &IPlanetController{LinkFilms: impl.LinkFilms}
+	data := make([]dto.SearchHitDto, len(results))
+	for i := 0; i < len(results); i += 1 {
+		r := results[i]
+		data[i] = dto.SearchHitDto{
+			Type:       r.Type,
+			Score:      r.Score,
+			Highlights: r.Highlights,
+		}
+		if r.Planet != nil {
+			planet := parser.ParsePlanetDto(r.Planet)
+			parser.LinkPlanetDto(ctx, &planet)
+			data[i].Planet = &planet
+		}
+		if r.Film != nil {
+			film := parser.ParseFilmDto(r.Film)
+			data[i].Film = &film
+		}
+	}
+
+	ctx.JSON(http.StatusOK, dto.SearchResponse{Count: len(data), Data: data})
+}
@@ -0,0 +1,119 @@
+package controller_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/auth"
+	"github.com/viniosilva/starwars-api/internal/controller"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/viniosilva/starwars-api/mock"
+)
+
+func Test_SearchController_Search(t *testing.T) {
+	date := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	var cases = map[string]struct {
+		mocking            func(searchService *mock.MockSearchService)
+		inputQuery         string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		"should return search results": {
+			mocking: func(searchService *mock.MockSearchService) {
+				searchService.EXPECT().Search(gomock.Any(), "tatoine", []string{}, 20).Return([]dto.SearchResult{{
+					Type:       "planet",
+					Score:      1.75,
+					Highlights: map[string]string{"name": "<em>Tatooine</em>"},
+					Planet: &model.Planet{
+						ID:        1,
+						CreatedAt: date,
+						UpdatedAt: date,
+						Name:      "Tatooine",
+						Climates:  []byte(`["arid"]`),
+						Terrains:  []byte(`["desert"]`),
+					},
+				}}, nil)
+			},
+			inputQuery:         "?q=tatoine",
+			expectedStatusCode: http.StatusOK,
+			expectedBody: `{"count":1,"data":[{"type":"planet","score":1.75,"highlights":{"name":"\u003cem\u003eTatooine\u003c/em\u003e"},` +
+				`"planet":{"id":1,"created_at":"2022-10-01 12:00:00","updated_at":"2022-10-01 12:00:00","name":"Tatooine","climates":["arid"],"terrains":["desert"],"_links":{"self":{"href":"http://example.com/api/planets/1"},"films":{"href":"http://example.com/api/planets/1/films"}}}}]}`,
+		},
+		"should return film results with types and limit": {
+			mocking: func(searchService *mock.MockSearchService) {
+				searchService.EXPECT().Search(gomock.Any(), "lucas", []string{"film"}, 100).Return([]dto.SearchResult{{
+					Type:       "film",
+					Score:      1.5,
+					Highlights: map[string]string{"director": "George <em>Lucas</em>"},
+					Film: &model.Film{
+						ID:          1,
+						CreatedAt:   date,
+						UpdatedAt:   date,
+						Title:       "A New Hope",
+						Episode:     4,
+						Director:    "George Lucas",
+						ReleaseDate: time.Date(1977, 5, 25, 0, 0, 0, 0, time.UTC),
+					},
+				}}, nil)
+			},
+			inputQuery:         "?q=lucas&types=film&limit=500",
+			expectedStatusCode: http.StatusOK,
+			expectedBody: `{"count":1,"data":[{"type":"film","score":1.5,"highlights":{"director":"George \u003cem\u003eLucas\u003c/em\u003e"},` +
+				`"film":{"id":1,"created_at":"2022-10-01 12:00:00","updated_at":"2022-10-01 12:00:00","title":"A New Hope","episode":4,"director":"George Lucas","release_date":"1977-05-25"}}]}`,
+		},
+		"should throw bad request when q is empty": {
+			mocking:            func(searchService *mock.MockSearchService) {},
+			inputQuery:         "?q=%20",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"q is required"}`,
+		},
+		"should throw bad request when type is invalid": {
+			mocking:            func(searchService *mock.MockSearchService) {},
+			inputQuery:         "?q=tatooine&types=planet,starship",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid type starship"}`,
+		},
+		"should throw internal server error when search": {
+			mocking: func(searchService *mock.MockSearchService) {
+				searchService.EXPECT().Search(gomock.Any(), "tatooine", []string{}, 20).Return(nil, fmt.Errorf("error"))
+			},
+			inputQuery:         "?q=tatooine",
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       `{"error":"internal server error"}`,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			_, r := gin.CreateTestContext(res)
+			r.Use(auth.GinAuth(&auth.IAuthenticator{}, auth.RoleReader))
+
+			mockSearchService := mock.NewMockSearchService(ctrl)
+
+			searchController := &controller.ISearchController{SearchService: mockSearchService, LinkFilms: true}
+			searchController.Configure(r.Group("/api"))
+
+			cs.mocking(mockSearchService)
+
+			// when
+			r.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/api/search"+cs.inputQuery, nil))
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Code)
+			assert.Equal(t, cs.expectedBody, res.Body.String())
+		})
+	}
+}
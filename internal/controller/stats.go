@@ -0,0 +1,244 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/starwars-api/internal/auth"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/i18n"
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/viniosilva/starwars-api/internal/service"
+)
+
+const (
+	STATS_TOP_DEFAULT_LIMIT = 10
+	STATS_TOP_MAX_LIMIT     = 100
+)
+
+type IStatsController struct {
+	StatsService service.StatsService
+}
+
+func (impl *IStatsController) Configure(router *gin.RouterGroup) {
+	router.GET("/stats/planets/climates", auth.RequireRole(auth.RoleReader), impl.CountPlanetsByClimate)
+	router.GET("/stats/planets/terrains", auth.RequireRole(auth.RoleReader), impl.CountPlanetsByTerrain)
+	router.GET("/stats/planets/films", auth.RequireRole(auth.RoleReader), impl.CountFilmsPerPlanet)
+	router.GET("/stats/planets/top", auth.RequireRole(auth.RoleReader), impl.FindTopPlanets)
+	router.GET("/stats/films/planets", auth.RequireRole(auth.RoleReader), impl.CountPlanetsByFilm)
+}
+
+// @Summary count planets by climate
+// @Schemes
+// @Tags stats
+// @Accept json
+// @Produce json
+// @Param name query string false "name"
+// @Param climate query string false "climate"
+// @Param terrain query string false "terrain"
+// @Param rotationPeriodMin query number false "minimum rotationPeriod"
+// @Param rotationPeriodMax query number false "maximum rotationPeriod"
+// @Param orbitalPeriodMin query number false "minimum orbitalPeriod"
+// @Param orbitalPeriodMax query number false "maximum orbitalPeriod"
+// @Param diameterMin query number false "minimum diameter"
+// @Param diameterMax query number false "maximum diameter"
+// @Param surfaceWaterMin query number false "minimum surfaceWater"
+// @Param surfaceWaterMax query number false "maximum surfaceWater"
+// @Param populationMin query number false "minimum population"
+// @Param populationMax query number false "maximum population"
+// @Success 200 {object} dto.StatsCountsResponse
+// @Failure 400 {object} dto.ApiError
+// @Failure 401 {object} dto.ApiError
+// @Failure 403 {object} dto.ApiError
+// @Failure 429 {object} dto.ApiError
+// @Failure 500 {object} dto.ApiError
+// @Router /api/stats/planets/climates [get]
+func (impl *IStatsController) CountPlanetsByClimate(ctx *gin.Context) {
+	opts, err := ParsePlanetFilters(ctx, model.PlanetTableColumns.Name)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, err.Error())})
+		return
+	}
+
+	res, err := impl.StatsService.CountPlanetsByClimate(ctx, opts...)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ApiError{Error: i18n.T(ctx, "internal server error")})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.StatsCountsResponse{Count: len(res), Data: res})
+}
+
+// @Summary count planets by terrain
+// @Schemes
+// @Tags stats
+// @Accept json
+// @Produce json
+// @Param name query string false "name"
+// @Param climate query string false "climate"
+// @Param terrain query string false "terrain"
+// @Param rotationPeriodMin query number false "minimum rotationPeriod"
+// @Param rotationPeriodMax query number false "maximum rotationPeriod"
+// @Param orbitalPeriodMin query number false "minimum orbitalPeriod"
+// @Param orbitalPeriodMax query number false "maximum orbitalPeriod"
+// @Param diameterMin query number false "minimum diameter"
+// @Param diameterMax query number false "maximum diameter"
+// @Param surfaceWaterMin query number false "minimum surfaceWater"
+// @Param surfaceWaterMax query number false "maximum surfaceWater"
+// @Param populationMin query number false "minimum population"
+// @Param populationMax query number false "maximum population"
+// @Success 200 {object} dto.StatsCountsResponse
+// @Failure 400 {object} dto.ApiError
+// @Failure 401 {object} dto.ApiError
+// @Failure 403 {object} dto.ApiError
+// @Failure 429 {object} dto.ApiError
+// @Failure 500 {object} dto.ApiError
+// @Router /api/stats/planets/terrains [get]
+func (impl *IStatsController) CountPlanetsByTerrain(ctx *gin.Context) {
+	opts, err := ParsePlanetFilters(ctx, model.PlanetTableColumns.Name)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, err.Error())})
+		return
+	}
+
+	res, err := impl.StatsService.CountPlanetsByTerrain(ctx, opts...)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ApiError{Error: i18n.T(ctx, "internal server error")})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.StatsCountsResponse{Count: len(res), Data: res})
+}
+
+// @Summary count planets by number of films they appear in
+// @Schemes
+// @Tags stats
+// @Accept json
+// @Produce json
+// @Param name query string false "name"
+// @Param climate query string false "climate"
+// @Param terrain query string false "terrain"
+// @Param rotationPeriodMin query number false "minimum rotationPeriod"
+// @Param rotationPeriodMax query number false "maximum rotationPeriod"
+// @Param orbitalPeriodMin query number false "minimum orbitalPeriod"
+// @Param orbitalPeriodMax query number false "maximum orbitalPeriod"
+// @Param diameterMin query number false "minimum diameter"
+// @Param diameterMax query number false "maximum diameter"
+// @Param surfaceWaterMin query number false "minimum surfaceWater"
+// @Param surfaceWaterMax query number false "maximum surfaceWater"
+// @Param populationMin query number false "minimum population"
+// @Param populationMax query number false "maximum population"
+// @Success 200 {object} dto.StatsDistributionResponse
+// @Failure 400 {object} dto.ApiError
+// @Failure 401 {object} dto.ApiError
+// @Failure 403 {object} dto.ApiError
+// @Failure 429 {object} dto.ApiError
+// @Failure 500 {object} dto.ApiError
+// @Router /api/stats/planets/films [get]
+func (impl *IStatsController) CountFilmsPerPlanet(ctx *gin.Context) {
+	opts, err := ParsePlanetFilters(ctx, model.PlanetTableColumns.Name)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, err.Error())})
+		return
+	}
+
+	res, err := impl.StatsService.CountFilmsPerPlanet(ctx, opts...)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ApiError{Error: i18n.T(ctx, "internal server error")})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.StatsDistributionResponse{Count: len(res), Data: res})
+}
+
+// @Summary find the planets appearing in the most films
+// @Schemes
+// @Tags stats
+// @Accept json
+// @Produce json
+// @Param name query string false "name"
+// @Param climate query string false "climate"
+// @Param terrain query string false "terrain"
+// @Param rotationPeriodMin query number false "minimum rotationPeriod"
+// @Param rotationPeriodMax query number false "maximum rotationPeriod"
+// @Param orbitalPeriodMin query number false "minimum orbitalPeriod"
+// @Param orbitalPeriodMax query number false "maximum orbitalPeriod"
+// @Param diameterMin query number false "minimum diameter"
+// @Param diameterMax query number false "maximum diameter"
+// @Param surfaceWaterMin query number false "minimum surfaceWater"
+// @Param surfaceWaterMax query number false "maximum surfaceWater"
+// @Param populationMin query number false "minimum population"
+// @Param populationMax query number false "maximum population"
+// @Param limit query int false "limit"
+// @Success 200 {object} dto.StatsEntityCountsResponse
+// @Failure 400 {object} dto.ApiError
+// @Failure 401 {object} dto.ApiError
+// @Failure 403 {object} dto.ApiError
+// @Failure 429 {object} dto.ApiError
+// @Failure 500 {object} dto.ApiError
+// @Router /api/stats/planets/top [get]
+func (impl *IStatsController) FindTopPlanets(ctx *gin.Context) {
+	limit, err := strconv.Atoi(ctx.Query("limit"))
+	if err != nil || limit < 1 {
+		limit = STATS_TOP_DEFAULT_LIMIT
+	}
+	if limit > STATS_TOP_MAX_LIMIT {
+		limit = STATS_TOP_MAX_LIMIT
+	}
+
+	opts, err := ParsePlanetFilters(ctx, model.PlanetTableColumns.Name)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, err.Error())})
+		return
+	}
+
+	res, err := impl.StatsService.FindTopPlanets(ctx, limit, opts...)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ApiError{Error: i18n.T(ctx, "internal server error")})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.StatsEntityCountsResponse{Count: len(res), Data: res})
+}
+
+// @Summary count planets by film
+// @Schemes
+// @Tags stats
+// @Accept json
+// @Produce json
+// @Param name query string false "name"
+// @Param climate query string false "climate"
+// @Param terrain query string false "terrain"
+// @Param rotationPeriodMin query number false "minimum rotationPeriod"
+// @Param rotationPeriodMax query number false "maximum rotationPeriod"
+// @Param orbitalPeriodMin query number false "minimum orbitalPeriod"
+// @Param orbitalPeriodMax query number false "maximum orbitalPeriod"
+// @Param diameterMin query number false "minimum diameter"
+// @Param diameterMax query number false "maximum diameter"
+// @Param surfaceWaterMin query number false "minimum surfaceWater"
+// @Param surfaceWaterMax query number false "maximum surfaceWater"
+// @Param populationMin query number false "minimum population"
+// @Param populationMax query number false "maximum population"
+// @Success 200 {object} dto.StatsEntityCountsResponse
+// @Failure 400 {object} dto.ApiError
+// @Failure 401 {object} dto.ApiError
+// @Failure 403 {object} dto.ApiError
+// @Failure 429 {object} dto.ApiError
+// @Failure 500 {object} dto.ApiError
+// @Router /api/stats/films/planets [get]
+func (impl *IStatsController) CountPlanetsByFilm(ctx *gin.Context) {
+	opts, err := ParsePlanetFilters(ctx, model.PlanetTableColumns.Name)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, err.Error())})
+		return
+	}
+
+	res, err := impl.StatsService.CountPlanetsByFilm(ctx, opts...)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ApiError{Error: i18n.T(ctx, "internal server error")})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.StatsEntityCountsResponse{Count: len(res), Data: res})
+}
@@ -0,0 +1,125 @@
+package controller_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/auth"
+	"github.com/viniosilva/starwars-api/internal/controller"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/service"
+	"github.com/viniosilva/starwars-api/mock"
+)
+
+func Test_StatsController(t *testing.T) {
+	var cases = map[string]struct {
+		mocking            func(statsService *mock.MockStatsService)
+		inputPath          string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		"should count planets by climate": {
+			mocking: func(statsService *mock.MockStatsService) {
+				statsService.EXPECT().CountPlanetsByClimate(gomock.Any(), service.OptionWhere("planets.name like ?", "Tatooine")).
+					Return([]dto.StatsCountDto{{Value: "arid", Count: 1}}, nil)
+			},
+			inputPath:          "/api/stats/planets/climates?name=Tatooine",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"count":1,"data":[{"value":"arid","count":1}]}`,
+		},
+		"should count planets by terrain": {
+			mocking: func(statsService *mock.MockStatsService) {
+				statsService.EXPECT().CountPlanetsByTerrain(gomock.Any(), nil).
+					Return([]dto.StatsCountDto{{Value: "desert", Count: 2}}, nil)
+			},
+			inputPath:          "/api/stats/planets/terrains",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"count":1,"data":[{"value":"desert","count":2}]}`,
+		},
+		"should count films per planet": {
+			mocking: func(statsService *mock.MockStatsService) {
+				statsService.EXPECT().CountFilmsPerPlanet(gomock.Any(), nil).
+					Return([]dto.StatsDistributionDto{{Films: 0, Planets: 50}, {Films: 5, Planets: 1}}, nil)
+			},
+			inputPath:          "/api/stats/planets/films",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"count":2,"data":[{"films":0,"planets":50},{"films":5,"planets":1}]}`,
+		},
+		"should find top planets with max limit": {
+			mocking: func(statsService *mock.MockStatsService) {
+				statsService.EXPECT().FindTopPlanets(gomock.Any(), 100, nil).
+					Return([]dto.StatsEntityCountDto{{ID: 1, Name: "Tatooine", Count: 5}}, nil)
+			},
+			inputPath:          "/api/stats/planets/top?limit=1000",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"count":1,"data":[{"id":1,"name":"Tatooine","count":5}]}`,
+		},
+		"should count planets by film": {
+			mocking: func(statsService *mock.MockStatsService) {
+				statsService.EXPECT().CountPlanetsByFilm(gomock.Any(), nil).
+					Return([]dto.StatsEntityCountDto{{ID: 1, Name: "A New Hope", Count: 3}}, nil)
+			},
+			inputPath:          "/api/stats/films/planets",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"count":1,"data":[{"id":1,"name":"A New Hope","count":3}]}`,
+		},
+		"should count planets by film with the planet list filters": {
+			mocking: func(statsService *mock.MockStatsService) {
+				statsService.EXPECT().CountPlanetsByFilm(gomock.Any(),
+					nil,
+					service.OptionWhereClimate("arid"),
+					service.OptionWhereTerrain("desert"),
+					service.OptionWhere("diameter >= ?", float64(10000)),
+				).Return([]dto.StatsEntityCountDto{{ID: 1, Name: "A New Hope", Count: 1}}, nil)
+			},
+			inputPath:          "/api/stats/films/planets?climate=arid&terrain=desert&diameterMin=10000",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"count":1,"data":[{"id":1,"name":"A New Hope","count":1}]}`,
+		},
+		"should throw bad request when range is invalid": {
+			mocking:            func(statsService *mock.MockStatsService) {},
+			inputPath:          "/api/stats/planets/top?populationMax=many",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid populationMax"}`,
+		},
+		"should throw internal server error when count": {
+			mocking: func(statsService *mock.MockStatsService) {
+				statsService.EXPECT().CountPlanetsByClimate(gomock.Any(), nil).Return(nil, fmt.Errorf("error"))
+			},
+			inputPath:          "/api/stats/planets/climates",
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       `{"error":"internal server error"}`,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			_, r := gin.CreateTestContext(res)
+			r.Use(auth.GinAuth(&auth.IAuthenticator{}, auth.RoleReader))
+
+			mockStatsService := mock.NewMockStatsService(ctrl)
+
+			statsController := &controller.IStatsController{StatsService: mockStatsService}
+			statsController.Configure(r.Group("/api"))
+
+			cs.mocking(mockStatsService)
+
+			// when
+			r.ServeHTTP(res, httptest.NewRequest(http.MethodGet, cs.inputPath, nil))
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Code)
+			assert.Equal(t, cs.expectedBody, res.Body.String())
+		})
+	}
+}
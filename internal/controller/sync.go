@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/starwars-api/internal/auth"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/i18n"
+	"github.com/viniosilva/starwars-api/internal/scheduler"
+)
+
+type ISyncController struct {
+	SyncJob scheduler.SyncJob
+}
+
+func (impl *ISyncController) Configure(router *gin.RouterGroup) {
+	router.GET("/admin/sync/status", auth.RequireRole(auth.RoleAdmin), impl.FindSyncStatus)
+	router.POST("/admin/sync/run", auth.RequireRole(auth.RoleAdmin), impl.TriggerSync)
+}
+
+// @Summary find the status of the SWAPI sync job
+// @Schemes
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {object} dto.SyncStatus
+// @Failure 401 {object} dto.ApiError
+// @Failure 403 {object} dto.ApiError
+// @Failure 429 {object} dto.ApiError
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/admin/sync/status [get]
+func (impl *ISyncController) FindSyncStatus(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, impl.SyncJob.Status())
+}
+
+// @Summary run the SWAPI sync job now, in background
+// @Schemes
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 202 {object} dto.SyncStatus
+// @Failure 401 {object} dto.ApiError
+// @Failure 403 {object} dto.ApiError
+// @Failure 409 {object} dto.ApiError
+// @Failure 429 {object} dto.ApiError
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/admin/sync/run [post]
+func (impl *ISyncController) TriggerSync(ctx *gin.Context) {
+	if !impl.SyncJob.Trigger() {
+		ctx.JSON(http.StatusConflict, dto.ApiError{Error: i18n.T(ctx, "sync is already running")})
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, impl.SyncJob.Status())
+}
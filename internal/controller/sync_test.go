@@ -0,0 +1,151 @@
+package controller_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/auth"
+	"github.com/viniosilva/starwars-api/internal/controller"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/i18n"
+	"github.com/viniosilva/starwars-api/mock"
+)
+
+func Test_SyncController_FindSyncStatus(t *testing.T) {
+	var cases = map[string]struct {
+		mocking            func(syncJob *mock.MockSyncJob)
+		inputRole          auth.Role
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		"should return sync status": {
+			mocking: func(syncJob *mock.MockSyncJob) {
+				syncJob.EXPECT().Status().Return(dto.SyncStatus{
+					Enabled:   true,
+					Schedule:  "0 3 * * *",
+					NextRunAt: "2022-10-03T03:00:00Z",
+					LastRun: &dto.SyncRun{
+						Trigger:    "schedule",
+						Status:     "succeeded",
+						StartedAt:  "2022-10-02T03:00:00Z",
+						FinishedAt: "2022-10-02T03:00:12Z",
+					},
+				})
+			},
+			inputRole:          auth.RoleAdmin,
+			expectedStatusCode: http.StatusOK,
+			expectedBody: `{"enabled":true,"schedule":"0 3 * * *","running":false,"next_run_at":"2022-10-03T03:00:00Z",` +
+				`"last_run":{"trigger":"schedule","status":"succeeded","started_at":"2022-10-02T03:00:00Z","finished_at":"2022-10-02T03:00:12Z"}}`,
+		},
+		"should throw forbidden when role is not admin": {
+			mocking:            func(syncJob *mock.MockSyncJob) {},
+			inputRole:          auth.RoleEditor,
+			expectedStatusCode: http.StatusForbidden,
+			expectedBody:       `{"error":"forbidden"}`,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			_, r := gin.CreateTestContext(res)
+			r.Use(auth.GinAuth(&auth.IAuthenticator{}, cs.inputRole))
+
+			mockSyncJob := mock.NewMockSyncJob(ctrl)
+
+			syncController := &controller.ISyncController{SyncJob: mockSyncJob}
+			syncController.Configure(r.Group("/api"))
+
+			cs.mocking(mockSyncJob)
+
+			// when
+			r.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/api/admin/sync/status", nil))
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Code)
+			assert.Equal(t, cs.expectedBody, res.Body.String())
+		})
+	}
+}
+
+func Test_SyncController_TriggerSync(t *testing.T) {
+	var cases = map[string]struct {
+		mocking            func(syncJob *mock.MockSyncJob)
+		inputRole          auth.Role
+		inputLanguage      string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		"should start sync": {
+			mocking: func(syncJob *mock.MockSyncJob) {
+				syncJob.EXPECT().Trigger().Return(true)
+				syncJob.EXPECT().Status().Return(dto.SyncStatus{Running: true})
+			},
+			inputRole:          auth.RoleAdmin,
+			expectedStatusCode: http.StatusAccepted,
+			expectedBody:       `{"enabled":false,"running":true}`,
+		},
+		"should throw conflict when sync is running": {
+			mocking: func(syncJob *mock.MockSyncJob) {
+				syncJob.EXPECT().Trigger().Return(false)
+			},
+			inputRole:          auth.RoleAdmin,
+			expectedStatusCode: http.StatusConflict,
+			expectedBody:       `{"error":"sync is already running"}`,
+		},
+		"should throw translated conflict when sync is running": {
+			mocking: func(syncJob *mock.MockSyncJob) {
+				syncJob.EXPECT().Trigger().Return(false)
+			},
+			inputRole:          auth.RoleAdmin,
+			inputLanguage:      "pt-BR",
+			expectedStatusCode: http.StatusConflict,
+			expectedBody:       `{"error":"a sincronização já está em execução"}`,
+		},
+		"should throw forbidden when role is not admin": {
+			mocking:            func(syncJob *mock.MockSyncJob) {},
+			inputRole:          auth.RoleReader,
+			expectedStatusCode: http.StatusForbidden,
+			expectedBody:       `{"error":"forbidden"}`,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			_, r := gin.CreateTestContext(res)
+			r.Use(auth.GinAuth(&auth.IAuthenticator{}, cs.inputRole))
+			r.Use(i18n.GinLocale())
+
+			mockSyncJob := mock.NewMockSyncJob(ctrl)
+
+			syncController := &controller.ISyncController{SyncJob: mockSyncJob}
+			syncController.Configure(r.Group("/api"))
+
+			cs.mocking(mockSyncJob)
+
+			// when
+			req := httptest.NewRequest(http.MethodPost, "/api/admin/sync/run", nil)
+			if cs.inputLanguage != "" {
+				req.Header.Set("Accept-Language", cs.inputLanguage)
+			}
+			r.ServeHTTP(res, req)
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Code)
+			assert.Equal(t, cs.expectedBody, res.Body.String())
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/starwars-api/internal/auth"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/exception"
+	"github.com/viniosilva/starwars-api/internal/i18n"
+	"github.com/viniosilva/starwars-api/internal/service"
+)
+
+type ITranslationController struct {
+	TranslationService service.TranslationService
+}
+
+func (impl *ITranslationController) Configure(router *gin.RouterGroup) {
+	router.PUT("/planets/:planetID/translations/:locale", auth.RequireRole(auth.RoleEditor), impl.SavePlanetTranslation)
+	router.DELETE("/planets/:planetID/translations/:locale", auth.RequireRole(auth.RoleEditor), impl.DeletePlanetTranslation)
+	router.PUT("/films/:filmID/translations/:locale", auth.RequireRole(auth.RoleEditor), impl.SaveFilmTranslation)
+	router.DELETE("/films/:filmID/translations/:locale", auth.RequireRole(auth.RoleEditor), impl.DeleteFilmTranslation)
+}
+
+// @Summary create or replace the name of a planet in a locale
+// @Schemes
+// @Tags translation
+// @Accept json
+// @Produce json
+// @Param planetID path int true "Planet ID"
+// @Param locale path string true "locale, e.g. pt-BR"
+// @Param translation body dto.PlanetTranslationRequest true "translation"
+// @Success 204 ""
+// @Failure 400 {object} dto.ApiError
+// @Failure 401 {object} dto.ApiError
+// @Failure 403 {object} dto.ApiError
+// @Failure 404 {object} dto.ApiError
+// @Failure 429 {object} dto.ApiError
+// @Failure 500 {object} dto.ApiError
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/planets/{planetID}/translations/{locale} [put]
+func (impl *ITranslationController) SavePlanetTranslation(ctx *gin.Context) {
+	planetID, err := strconv.Atoi(ctx.Param("planetID"))
+	if err != nil || planetID < 1 {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, "invalid planet id")})
+		return
+	}
+	locale, ok := impl.parseLocale(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.PlanetTranslationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, "invalid body")})
+		return
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, "name is required")})
+		return
+	}
+
+	if err := impl.TranslationService.SavePlanetTranslation(ctx, planetID, locale, name); err != nil {
+		impl.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, gin.H{})
+}
+
+// @Summary delete the name of a planet in a locale
+// @Schemes
+// @Tags translation
+// @Accept json
+// @Produce json
+// @Param planetID path int true "Planet ID"
+// @Param locale path string true "locale, e.g. pt-BR"
+// @Success 204 ""
+// @Failure 400 {object} dto.ApiError
+// @Failure 401 {object} dto.ApiError
+// @Failure 403 {object} dto.ApiError
+// @Failure 429 {object} dto.ApiError
+// @Failure 500 {object} dto.ApiError
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/planets/{planetID}/translations/{locale} [delete]
+func (impl *ITranslationController) DeletePlanetTranslation(ctx *gin.Context) {
+	planetID, err := strconv.Atoi(ctx.Param("planetID"))
+	if err != nil || planetID < 1 {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, "invalid planet id")})
+		return
+	}
+	locale, ok := impl.parseLocale(ctx)
+	if !ok {
+		return
+	}
+
+	if err := impl.TranslationService.DeletePlanetTranslation(ctx, planetID, locale); err != nil {
+		impl.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, gin.H{})
+}
+
+// @Summary create or replace the title of a film in a locale
+// @Schemes
+// @Tags translation
+// @Accept json
+// @Produce json
+// @Param filmID path int true "Film ID"
+// @Param locale path string true "locale, e.g. pt-BR"
+// @Param translation body dto.FilmTranslationRequest true "translation"
+// @Success 204 ""
+// @Failure 400 {object} dto.ApiError
+// @Failure 401 {object} dto.ApiError
+// @Failure 403 {object} dto.ApiError
+// @Failure 404 {object} dto.ApiError
+// @Failure 429 {object} dto.ApiError
+// @Failure 500 {object} dto.ApiError
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/films/{filmID}/translations/{locale} [put]
+func (impl *ITranslationController) SaveFilmTranslation(ctx *gin.Context) {
+	filmID, err := strconv.Atoi(ctx.Param("filmID"))
+	if err != nil || filmID < 1 {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, "invalid film id")})
+		return
+	}
+	locale, ok := impl.parseLocale(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.FilmTranslationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, "invalid body")})
+		return
+	}
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, "title is required")})
+		return
+	}
+
+	if err := impl.TranslationService.SaveFilmTranslation(ctx, filmID, locale, title); err != nil {
+		impl.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, gin.H{})
+}
+
+// @Summary delete the title of a film in a locale
+// @Schemes
+// @Tags translation
+// @Accept json
+// @Produce json
+// @Param filmID path int true "Film ID"
+// @Param locale path string true "locale, e.g. pt-BR"
+// @Success 204 ""
+// @Failure 400 {object} dto.ApiError
+// @Failure 401 {object} dto.ApiError
+// @Failure 403 {object} dto.ApiError
+// @Failure 429 {object} dto.ApiError
+// @Failure 500 {object} dto.ApiError
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/films/{filmID}/translations/{locale} [delete]
+func (impl *ITranslationController) DeleteFilmTranslation(ctx *gin.Context) {
+	filmID, err := strconv.Atoi(ctx.Param("filmID"))
+	if err != nil || filmID < 1 {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, "invalid film id")})
+		return
+	}
+	locale, ok := impl.parseLocale(ctx)
+	if !ok {
+		return
+	}
+
+	if err := impl.TranslationService.DeleteFilmTranslation(ctx, filmID, locale); err != nil {
+		impl.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, gin.H{})
+}
+
+// parseLocale accepts the supported locales other than the default one, whose
+// names and titles are the original ones
+func (impl *ITranslationController) parseLocale(ctx *gin.Context) (string, bool) {
+	locale := ctx.Param("locale")
+	if !i18n.IsLocale(locale) || locale == i18n.DEFAULT_LOCALE {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, "invalid locale")})
+		return "", false
+	}
+
+	return locale, true
+}
+
+func (impl *ITranslationController) handleError(ctx *gin.Context, err error) {
+	if e, ok := err.(*exception.NotFoundException); ok {
+		ctx.JSON(http.StatusNotFound, dto.ApiError{Error: i18n.T(ctx, e.Message)})
+		return
+	}
+
+	ctx.JSON(http.StatusInternalServerError, dto.ApiError{Error: i18n.T(ctx, "internal server error")})
+}
@@ -0,0 +1,172 @@
+package controller_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/auth"
+	"github.com/viniosilva/starwars-api/internal/controller"
+	"github.com/viniosilva/starwars-api/internal/exception"
+	"github.com/viniosilva/starwars-api/internal/i18n"
+	"github.com/viniosilva/starwars-api/mock"
+)
+
+func Test_TranslationController(t *testing.T) {
+	var cases = map[string]struct {
+		mocking            func(translationService *mock.MockTranslationService)
+		inputRole          auth.Role
+		inputMethod        string
+		inputPath          string
+		inputBody          string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		"should save planet translation": {
+			mocking: func(translationService *mock.MockTranslationService) {
+				translationService.EXPECT().SavePlanetTranslation(gomock.Any(), 1, i18n.LOCALE_PT_BR, "Tatuíne").Return(nil)
+			},
+			inputRole:          auth.RoleEditor,
+			inputMethod:        http.MethodPut,
+			inputPath:          "/api/planets/1/translations/pt-BR",
+			inputBody:          `{"name":" Tatuíne "}`,
+			expectedStatusCode: http.StatusNoContent,
+		},
+		"should delete planet translation": {
+			mocking: func(translationService *mock.MockTranslationService) {
+				translationService.EXPECT().DeletePlanetTranslation(gomock.Any(), 1, i18n.LOCALE_PT_BR).Return(nil)
+			},
+			inputRole:          auth.RoleEditor,
+			inputMethod:        http.MethodDelete,
+			inputPath:          "/api/planets/1/translations/pt-BR",
+			expectedStatusCode: http.StatusNoContent,
+		},
+		"should save film translation": {
+			mocking: func(translationService *mock.MockTranslationService) {
+				translationService.EXPECT().SaveFilmTranslation(gomock.Any(), 1, i18n.LOCALE_PT_BR, "Uma Nova Esperança").Return(nil)
+			},
+			inputRole:          auth.RoleEditor,
+			inputMethod:        http.MethodPut,
+			inputPath:          "/api/films/1/translations/pt-BR",
+			inputBody:          `{"title":"Uma Nova Esperança"}`,
+			expectedStatusCode: http.StatusNoContent,
+		},
+		"should delete film translation": {
+			mocking: func(translationService *mock.MockTranslationService) {
+				translationService.EXPECT().DeleteFilmTranslation(gomock.Any(), 1, i18n.LOCALE_PT_BR).Return(nil)
+			},
+			inputRole:          auth.RoleEditor,
+			inputMethod:        http.MethodDelete,
+			inputPath:          "/api/films/1/translations/pt-BR",
+			expectedStatusCode: http.StatusNoContent,
+		},
+		"should throw bad request when locale is not supported": {
+			mocking:            func(translationService *mock.MockTranslationService) {},
+			inputRole:          auth.RoleEditor,
+			inputMethod:        http.MethodPut,
+			inputPath:          "/api/planets/1/translations/ja",
+			inputBody:          `{"name":"タトゥイーン"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid locale"}`,
+		},
+		"should throw bad request when locale is the default one": {
+			mocking:            func(translationService *mock.MockTranslationService) {},
+			inputRole:          auth.RoleEditor,
+			inputMethod:        http.MethodDelete,
+			inputPath:          "/api/films/1/translations/en",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid locale"}`,
+		},
+		"should throw bad request when planet id is invalid": {
+			mocking:            func(translationService *mock.MockTranslationService) {},
+			inputRole:          auth.RoleEditor,
+			inputMethod:        http.MethodPut,
+			inputPath:          "/api/planets/abc/translations/pt-BR",
+			inputBody:          `{"name":"Tatuíne"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid planet id"}`,
+		},
+		"should throw bad request when name is empty": {
+			mocking:            func(translationService *mock.MockTranslationService) {},
+			inputRole:          auth.RoleEditor,
+			inputMethod:        http.MethodPut,
+			inputPath:          "/api/planets/1/translations/pt-BR",
+			inputBody:          `{"name":" "}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"name is required"}`,
+		},
+		"should throw bad request when body is invalid": {
+			mocking:            func(translationService *mock.MockTranslationService) {},
+			inputRole:          auth.RoleEditor,
+			inputMethod:        http.MethodPut,
+			inputPath:          "/api/films/1/translations/pt-BR",
+			inputBody:          `{`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid body"}`,
+		},
+		"should throw not found when planet does not exist": {
+			mocking: func(translationService *mock.MockTranslationService) {
+				translationService.EXPECT().SavePlanetTranslation(gomock.Any(), 1, i18n.LOCALE_PT_BR, "Tatuíne").
+					Return(&exception.NotFoundException{Message: "planet 1 not found"})
+			},
+			inputRole:          auth.RoleEditor,
+			inputMethod:        http.MethodPut,
+			inputPath:          "/api/planets/1/translations/pt-BR",
+			inputBody:          `{"name":"Tatuíne"}`,
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       `{"error":"planet 1 not found"}`,
+		},
+		"should throw internal server error when delete film translation": {
+			mocking: func(translationService *mock.MockTranslationService) {
+				translationService.EXPECT().DeleteFilmTranslation(gomock.Any(), 1, i18n.LOCALE_PT_BR).Return(fmt.Errorf("error"))
+			},
+			inputRole:          auth.RoleEditor,
+			inputMethod:        http.MethodDelete,
+			inputPath:          "/api/films/1/translations/pt-BR",
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       `{"error":"internal server error"}`,
+		},
+		"should throw forbidden when role is reader": {
+			mocking:            func(translationService *mock.MockTranslationService) {},
+			inputRole:          auth.RoleReader,
+			inputMethod:        http.MethodPut,
+			inputPath:          "/api/planets/1/translations/pt-BR",
+			inputBody:          `{"name":"Tatuíne"}`,
+			expectedStatusCode: http.StatusForbidden,
+			expectedBody:       `{"error":"forbidden"}`,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			_, r := gin.CreateTestContext(res)
+			r.Use(auth.GinAuth(&auth.IAuthenticator{}, cs.inputRole))
+
+			mockTranslationService := mock.NewMockTranslationService(ctrl)
+
+			translationController := &controller.ITranslationController{TranslationService: mockTranslationService}
+			translationController.Configure(r.Group("/api"))
+
+			cs.mocking(mockTranslationService)
+
+			// when
+			r.ServeHTTP(res, httptest.NewRequest(cs.inputMethod, cs.inputPath, strings.NewReader(cs.inputBody)))
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Code)
+			if cs.expectedBody != "" {
+				assert.Equal(t, cs.expectedBody, res.Body.String())
+			}
+		})
+	}
+}
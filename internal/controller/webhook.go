@@ -0,0 +1,396 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/starwars-api/internal/auth"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/exception"
+	"github.com/viniosilva/starwars-api/internal/i18n"
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/viniosilva/starwars-api/internal/service"
+)
+
+type IWebhookController struct {
+	WebhookService service.WebhookService
+}
+
+func (impl *IWebhookController) Configure(router *gin.RouterGroup) {
+	router.POST("/webhooks", auth.RequireRole(auth.RoleAdmin), impl.CreateWebhook)
+	router.GET("/webhooks", auth.RequireRole(auth.RoleAdmin), impl.FindWebhooks)
+	router.GET("/webhooks/deliveries", auth.RequireRole(auth.RoleAdmin), impl.FindDeliveriesAndTotal)
+	router.POST("/webhooks/deliveries/:deliveryID/retry", auth.RequireRole(auth.RoleAdmin), impl.RetryDelivery)
+	router.GET("/webhooks/:webhookID", auth.RequireRole(auth.RoleAdmin), impl.FindWebhookByID)
+	router.PUT("/webhooks/:webhookID", auth.RequireRole(auth.RoleAdmin), impl.UpdateWebhook)
+	router.DELETE("/webhooks/:webhookID", auth.RequireRole(auth.RoleAdmin), impl.DeleteWebhook)
+}
+
+// @Summary create webhook
+// @Schemes
+// @Tags webhook
+// @Accept json
+// @Produce json
+// @Param webhook body dto.WebhookRequest true "webhook"
+// @Success 201 {object} dto.WebhookResponse
+// @Failure 400 {object} dto.ApiError
+// @Failure 401 {object} dto.ApiError
+// @Failure 403 {object} dto.ApiError
+// @Failure 429 {object} dto.ApiError
+// @Failure 500 {object} dto.ApiError
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/webhooks [post]
+func (impl *IWebhookController) CreateWebhook(ctx *gin.Context) {
+	var req dto.WebhookRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, "invalid body")})
+		return
+	}
+	if req.Secret == "" {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, "secret is required")})
+		return
+	}
+
+	webhook := &model.Webhook{Active: true}
+	if err := impl.bindWebhook(webhook, req); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, err.Error())})
+		return
+	}
+
+	if err := impl.WebhookService.CreateWebhook(ctx, webhook); err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ApiError{Error: i18n.T(ctx, "internal server error")})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.WebhookResponse{Data: impl.ParseWebhookDto(webhook)})
+}
+
+// @Summary find webhooks
+// @Schemes
+// @Tags webhook
+// @Accept json
+// @Produce json
+// @Success 200 {object} dto.WebhooksResponse
+// @Failure 401 {object} dto.ApiError
+// @Failure 403 {object} dto.ApiError
+// @Failure 429 {object} dto.ApiError
+// @Failure 500 {object} dto.ApiError
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/webhooks [get]
+func (impl *IWebhookController) FindWebhooks(ctx *gin.Context) {
+	webhooks, err := impl.WebhookService.FindWebhooks(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ApiError{Error: i18n.T(ctx, "internal server error")})
+		return
+	}
+
+	data := make([]dto.WebhookDto, len(webhooks))
+	for i := 0; i < len(data); i += 1 {
+		data[i] = impl.ParseWebhookDto(webhooks[i])
+	}
+
+	ctx.JSON(http.StatusOK, dto.WebhooksResponse{Data: data})
+}
+
+// @Summary find webhook by id
+// @Schemes
+// @Tags webhook
+// @Accept json
+// @Produce json
+// @Param webhookID path int true "Webhook ID"
+// @Success 200 {object} dto.WebhookResponse
+// @Failure 400 {object} dto.ApiError
+// @Failure 401 {object} dto.ApiError
+// @Failure 403 {object} dto.ApiError
+// @Failure 404 {object} dto.ApiError
+// @Failure 429 {object} dto.ApiError
+// @Failure 500 {object} dto.ApiError
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/webhooks/{webhookID} [get]
+func (impl *IWebhookController) FindWebhookByID(ctx *gin.Context) {
+	webhookID, err := strconv.Atoi(ctx.Param("webhookID"))
+	if err != nil || webhookID < 1 {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, "invalid webhook id")})
+		return
+	}
+
+	webhook, err := impl.WebhookService.FindWebhookByID(ctx, webhookID)
+	if err != nil {
+		impl.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.WebhookResponse{Data: impl.ParseWebhookDto(webhook)})
+}
+
+// @Summary update webhook
+// @Schemes
+// @Tags webhook
+// @Accept json
+// @Produce json
+// @Param webhookID path int true "Webhook ID"
+// @Param webhook body dto.WebhookRequest true "webhook, keeping the secret when empty"
+// @Success 200 {object} dto.WebhookResponse
+// @Failure 400 {object} dto.ApiError
+// @Failure 401 {object} dto.ApiError
+// @Failure 403 {object} dto.ApiError
+// @Failure 404 {object} dto.ApiError
+// @Failure 429 {object} dto.ApiError
+// @Failure 500 {object} dto.ApiError
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/webhooks/{webhookID} [put]
+func (impl *IWebhookController) UpdateWebhook(ctx *gin.Context) {
+	webhookID, err := strconv.Atoi(ctx.Param("webhookID"))
+	if err != nil || webhookID < 1 {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, "invalid webhook id")})
+		return
+	}
+
+	var req dto.WebhookRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, "invalid body")})
+		return
+	}
+
+	webhook, err := impl.WebhookService.FindWebhookByID(ctx, webhookID)
+	if err != nil {
+		impl.handleError(ctx, err)
+		return
+	}
+	if err := impl.bindWebhook(webhook, req); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, err.Error())})
+		return
+	}
+
+	if err := impl.WebhookService.UpdateWebhook(ctx, webhook); err != nil {
+		impl.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.WebhookResponse{Data: impl.ParseWebhookDto(webhook)})
+}
+
+// @Summary delete webhook
+// @Schemes
+// @Tags webhook
+// @Accept json
+// @Produce json
+// @Param webhookID path int true "Webhook ID"
+// @Success 204 ""
+// @Failure 400 {object} dto.ApiError
+// @Failure 401 {object} dto.ApiError
+// @Failure 403 {object} dto.ApiError
+// @Failure 404 {object} dto.ApiError
+// @Failure 429 {object} dto.ApiError
+// @Failure 500 {object} dto.ApiError
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/webhooks/{webhookID} [delete]
+func (impl *IWebhookController) DeleteWebhook(ctx *gin.Context) {
+	webhookID, err := strconv.Atoi(ctx.Param("webhookID"))
+	if err != nil || webhookID < 1 {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, "invalid webhook id")})
+		return
+	}
+
+	if err := impl.WebhookService.DeleteWebhook(ctx, webhookID); err != nil {
+		impl.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, gin.H{})
+}
+
+// @Summary find webhook deliveries
+// @Schemes
+// @Tags webhook
+// @Accept json
+// @Produce json
+// @Param page query int false "page"
+// @Param size query int false "size"
+// @Param status query string false "pending, delivered or dead"
+// @Param webhookId query int false "webhook id"
+// @Success 200 {object} dto.WebhookDeliveriesResponse
+// @Failure 400 {object} dto.ApiError
+// @Failure 401 {object} dto.ApiError
+// @Failure 403 {object} dto.ApiError
+// @Failure 429 {object} dto.ApiError
+// @Failure 500 {object} dto.ApiError
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/webhooks/deliveries [get]
+func (impl *IWebhookController) FindDeliveriesAndTotal(ctx *gin.Context) {
+	page, err := strconv.Atoi(ctx.Query("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	size, err := strconv.Atoi(ctx.Query("size"))
+	if err != nil || size < 1 {
+		size = 10
+	}
+
+	opts := []service.Option{}
+	if status := ctx.Query("status"); status != "" {
+		if status != service.DELIVERY_STATUS_PENDING && status != service.DELIVERY_STATUS_DELIVERED && status != service.DELIVERY_STATUS_DEAD {
+			ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, "invalid status")})
+			return
+		}
+		opts = append(opts, service.OptionWhere(fmt.Sprintf("%s = ?", model.WebhookDeliveryColumns.Status), status))
+	}
+	if raw := ctx.Query("webhookId"); raw != "" {
+		webhookID, err := strconv.Atoi(raw)
+		if err != nil || webhookID < 1 {
+			ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, "invalid webhook id")})
+			return
+		}
+		opts = append(opts, service.OptionWhere(fmt.Sprintf("%s = ?", model.WebhookDeliveryColumns.WebhookID), webhookID))
+	}
+
+	res, err := impl.WebhookService.FindDeliveriesAndTotal(ctx, page, size, opts...)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ApiError{Error: i18n.T(ctx, "internal server error")})
+		return
+	}
+
+	data := make([]dto.WebhookDeliveryDto, res.Count)
+	for i := 0; i < len(data); i += 1 {
+		data[i] = impl.ParseWebhookDeliveryDto(res.Data[i])
+	}
+
+	previous := ""
+	if page > 1 {
+		previous = RequestPageURL(ctx, page-1)
+	}
+
+	next := ""
+	if res.Next {
+		next = RequestPageURL(ctx, page+1)
+	}
+
+	ctx.JSON(http.StatusOK, dto.WebhookDeliveriesResponse{
+		Pagination: dto.Pagination{
+			Count:    len(data),
+			Total:    res.Total,
+			Previous: previous,
+			Next:     next,
+		},
+		Data: data,
+	})
+}
+
+// @Summary retry dead webhook delivery
+// @Schemes
+// @Tags webhook
+// @Accept json
+// @Produce json
+// @Param deliveryID path int true "Delivery ID"
+// @Success 202 ""
+// @Failure 400 {object} dto.ApiError
+// @Failure 401 {object} dto.ApiError
+// @Failure 403 {object} dto.ApiError
+// @Failure 404 {object} dto.ApiError
+// @Failure 429 {object} dto.ApiError
+// @Failure 500 {object} dto.ApiError
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/webhooks/deliveries/{deliveryID}/retry [post]
+func (impl *IWebhookController) RetryDelivery(ctx *gin.Context) {
+	deliveryID, err := strconv.ParseInt(ctx.Param("deliveryID"), 10, 64)
+	if err != nil || deliveryID < 1 {
+		ctx.JSON(http.StatusBadRequest, dto.ApiError{Error: i18n.T(ctx, "invalid delivery id")})
+		return
+	}
+
+	if err := impl.WebhookService.RetryDelivery(ctx, deliveryID); err != nil {
+		impl.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, gin.H{})
+}
+
+// ParseWebhookDto never exposes the secret of the webhook
+func (impl *IWebhookController) ParseWebhookDto(webhook *model.Webhook) dto.WebhookDto {
+	events := []string{}
+	json.Unmarshal(webhook.Events, &events)
+
+	return dto.WebhookDto{
+		ID:        webhook.ID,
+		CreatedAt: webhook.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt: webhook.UpdatedAt.Format("2006-01-02 15:04:05"),
+		URL:       webhook.URL,
+		Events:    events,
+		Active:    webhook.Active,
+	}
+}
+
+func (impl *IWebhookController) ParseWebhookDeliveryDto(delivery *model.WebhookDelivery) dto.WebhookDeliveryDto {
+	res := dto.WebhookDeliveryDto{
+		ID:            delivery.ID,
+		CreatedAt:     delivery.CreatedAt.Format("2006-01-02 15:04:05"),
+		WebhookID:     delivery.WebhookID,
+		EventID:       delivery.EventID,
+		Event:         delivery.Event,
+		Status:        delivery.Status,
+		Attempts:      delivery.Attempts,
+		NextAttemptAt: delivery.NextAttemptAt.Format("2006-01-02 15:04:05"),
+		LastError:     delivery.LastError.String,
+		Payload:       json.RawMessage(delivery.Payload),
+	}
+	if delivery.DeliveredAt.Valid {
+		res.DeliveredAt = delivery.DeliveredAt.Time.Format("2006-01-02 15:04:05")
+	}
+
+	return res
+}
+
+// bindWebhook validates the request into webhook, keeping its secret and
+// active flag when they are not given
+func (impl *IWebhookController) bindWebhook(webhook *model.Webhook, req dto.WebhookRequest) error {
+	u, err := url.Parse(req.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid url")
+	}
+
+	events := req.Events
+	if events == nil {
+		events = []string{}
+	}
+	for _, e := range events {
+		if !service.IsEventType(e) {
+			return fmt.Errorf("invalid event %s", e)
+		}
+	}
+	raw, err := json.Marshal(events)
+	if err != nil {
+		return err
+	}
+
+	webhook.URL = req.URL
+	webhook.Events = raw
+	if req.Secret != "" {
+		webhook.Secret = req.Secret
+	}
+	if req.Active != nil {
+		webhook.Active = *req.Active
+	}
+
+	return nil
+}
+
+func (impl *IWebhookController) handleError(ctx *gin.Context, err error) {
+	if e, ok := err.(*exception.NotFoundException); ok {
+		ctx.JSON(http.StatusNotFound, dto.ApiError{Error: i18n.T(ctx, e.Message)})
+		return
+	}
+
+	ctx.JSON(http.StatusInternalServerError, dto.ApiError{Error: i18n.T(ctx, "internal server error")})
+}
@@ -0,0 +1,254 @@
+package controller_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/auth"
+	"github.com/viniosilva/starwars-api/internal/controller"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/exception"
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/viniosilva/starwars-api/internal/service"
+	"github.com/viniosilva/starwars-api/mock"
+	"github.com/volatiletech/null/v8"
+)
+
+func Test_WebhookController_CreateWebhook(t *testing.T) {
+	var cases = map[string]struct {
+		mocking            func(webhookService *mock.MockWebhookService)
+		inputBody          string
+		inputRole          auth.Role
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		"should create webhook without exposing its secret": {
+			mocking: func(webhookService *mock.MockWebhookService) {
+				webhookService.EXPECT().CreateWebhook(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, webhook *model.Webhook) error {
+						assert.Equal(t, "secret", webhook.Secret)
+						webhook.ID = 1
+						webhook.CreatedAt = time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+						webhook.UpdatedAt = time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+						return nil
+					})
+			},
+			inputBody:          `{"url":"https://example.com/hooks","secret":"secret","events":["planet.deleted"]}`,
+			inputRole:          auth.RoleAdmin,
+			expectedStatusCode: http.StatusCreated,
+			expectedBody:       `{"data":{"id":1,"created_at":"2022-10-01 12:00:00","updated_at":"2022-10-01 12:00:00","url":"https://example.com/hooks","events":["planet.deleted"],"active":true}}`,
+		},
+		"should throw bad request when url is invalid": {
+			mocking:            func(webhookService *mock.MockWebhookService) {},
+			inputBody:          `{"url":"ftp://example.com","secret":"secret"}`,
+			inputRole:          auth.RoleAdmin,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid url"}`,
+		},
+		"should throw bad request when secret is missing": {
+			mocking:            func(webhookService *mock.MockWebhookService) {},
+			inputBody:          `{"url":"https://example.com/hooks"}`,
+			inputRole:          auth.RoleAdmin,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"secret is required"}`,
+		},
+		"should throw bad request when event is invalid": {
+			mocking:            func(webhookService *mock.MockWebhookService) {},
+			inputBody:          `{"url":"https://example.com/hooks","secret":"secret","events":["starship.created"]}`,
+			inputRole:          auth.RoleAdmin,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid event starship.created"}`,
+		},
+		"should throw forbidden when role is not admin": {
+			mocking:            func(webhookService *mock.MockWebhookService) {},
+			inputBody:          `{}`,
+			inputRole:          auth.RoleEditor,
+			expectedStatusCode: http.StatusForbidden,
+			expectedBody:       `{"error":"forbidden"}`,
+		},
+		"should throw internal server error when create webhook": {
+			mocking: func(webhookService *mock.MockWebhookService) {
+				webhookService.EXPECT().CreateWebhook(gomock.Any(), gomock.Any()).Return(fmt.Errorf("error"))
+			},
+			inputBody:          `{"url":"https://example.com/hooks","secret":"secret"}`,
+			inputRole:          auth.RoleAdmin,
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       `{"error":"internal server error"}`,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			_, r := gin.CreateTestContext(res)
+			r.Use(auth.GinAuth(&auth.IAuthenticator{}, cs.inputRole))
+
+			mockWebhookService := mock.NewMockWebhookService(ctrl)
+
+			webhookController := &controller.IWebhookController{
+				WebhookService: mockWebhookService,
+			}
+			webhookController.Configure(r.Group("/api"))
+
+			cs.mocking(mockWebhookService)
+
+			// when
+			r.ServeHTTP(res, httptest.NewRequest(http.MethodPost, "/api/webhooks", strings.NewReader(cs.inputBody)))
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Code)
+			assert.Equal(t, cs.expectedBody, res.Body.String())
+		})
+	}
+}
+
+func Test_WebhookController_FindDeliveriesAndTotal(t *testing.T) {
+	var cases = map[string]struct {
+		mocking            func(webhookService *mock.MockWebhookService)
+		inputQuery         string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		"should return dead deliveries": {
+			mocking: func(webhookService *mock.MockWebhookService) {
+				webhookService.EXPECT().FindDeliveriesAndTotal(gomock.Any(), 1, 1,
+					service.OptionWhere("status = ?", "dead"),
+				).Return(dto.FindWebhookDeliveriesAndTotalResult{
+					Count: 1,
+					Total: 2,
+					Next:  true,
+					Data: []*model.WebhookDelivery{{
+						ID:            2,
+						CreatedAt:     time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC),
+						WebhookID:     1,
+						EventID:       "event-id",
+						Event:         "planet.deleted",
+						Payload:       []byte(`{"id":"event-id"}`),
+						Status:        "dead",
+						Attempts:      8,
+						NextAttemptAt: time.Date(2022, 10, 1, 13, 0, 0, 0, time.UTC),
+						LastError:     null.StringFrom("unexpected status 500"),
+					}},
+				}, nil)
+			},
+			inputQuery:         "?size=1&status=dead",
+			expectedStatusCode: http.StatusOK,
+			expectedBody: `{"count":1,"total":2,"previous":"","next":"http://example.com/api/webhooks/deliveries?page=2\u0026size=1\u0026status=dead",` +
+				`"data":[{"id":2,"created_at":"2022-10-01 12:00:00","webhook_id":1,"event_id":"event-id","event":"planet.deleted","status":"dead","attempts":8,"next_attempt_at":"2022-10-01 13:00:00","last_error":"unexpected status 500","payload":{"id":"event-id"}}]}`,
+		},
+		"should throw bad request when status is invalid": {
+			mocking:            func(webhookService *mock.MockWebhookService) {},
+			inputQuery:         "?status=lost",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid status"}`,
+		},
+		"should throw internal server error when find deliveries": {
+			mocking: func(webhookService *mock.MockWebhookService) {
+				webhookService.EXPECT().FindDeliveriesAndTotal(gomock.Any(), 1, 10).Return(dto.FindWebhookDeliveriesAndTotalResult{}, fmt.Errorf("error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       `{"error":"internal server error"}`,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			_, r := gin.CreateTestContext(res)
+			r.Use(auth.GinAuth(&auth.IAuthenticator{}, auth.RoleAdmin))
+
+			mockWebhookService := mock.NewMockWebhookService(ctrl)
+
+			webhookController := &controller.IWebhookController{
+				WebhookService: mockWebhookService,
+			}
+			webhookController.Configure(r.Group("/api"))
+
+			cs.mocking(mockWebhookService)
+
+			// when
+			r.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/api/webhooks/deliveries"+cs.inputQuery, nil))
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Code)
+			assert.Equal(t, cs.expectedBody, res.Body.String())
+		})
+	}
+}
+
+func Test_WebhookController_RetryDelivery(t *testing.T) {
+	var cases = map[string]struct {
+		mocking            func(webhookService *mock.MockWebhookService)
+		inputDeliveryID    string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		"should retry delivery": {
+			mocking: func(webhookService *mock.MockWebhookService) {
+				webhookService.EXPECT().RetryDelivery(gomock.Any(), int64(1)).Return(nil)
+			},
+			inputDeliveryID:    "1",
+			expectedStatusCode: http.StatusAccepted,
+			expectedBody:       `{}`,
+		},
+		"should throw bad request when delivery id is invalid": {
+			mocking:            func(webhookService *mock.MockWebhookService) {},
+			inputDeliveryID:    "abc",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid delivery id"}`,
+		},
+		"should throw not found when delivery is not dead": {
+			mocking: func(webhookService *mock.MockWebhookService) {
+				webhookService.EXPECT().RetryDelivery(gomock.Any(), int64(1)).
+					Return(&exception.NotFoundException{Message: "dead delivery 1 not found"})
+			},
+			inputDeliveryID:    "1",
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       `{"error":"dead delivery 1 not found"}`,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			res := httptest.NewRecorder()
+			_, r := gin.CreateTestContext(res)
+			r.Use(auth.GinAuth(&auth.IAuthenticator{}, auth.RoleAdmin))
+
+			mockWebhookService := mock.NewMockWebhookService(ctrl)
+
+			webhookController := &controller.IWebhookController{
+				WebhookService: mockWebhookService,
+			}
+			webhookController.Configure(r.Group("/api"))
+
+			cs.mocking(mockWebhookService)
+
+			// when
+			r.ServeHTTP(res, httptest.NewRequest(http.MethodPost, "/api/webhooks/deliveries/"+cs.inputDeliveryID+"/retry", nil))
+
+			// then
+			assert.Equal(t, cs.expectedStatusCode, res.Code)
+			assert.Equal(t, cs.expectedBody, res.Body.String())
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"encoding/json"
+
+	"github.com/viniosilva/starwars-api/internal/model"
+)
+
+type AuditLogDto struct {
+	ID        int64           `json:"id" example:"1"`
+	CreatedAt string          `json:"created_at" example:"2022-10-01 12:00:00"`
+	Actor     string          `json:"actor" example:"ops"`
+	RequestID string          `json:"request_id,omitempty" example:"8f14e45f-ceea-467a-9575-7e5b2f3c1a2b"`
+	Entity    string          `json:"entity" example:"planet"`
+	EntityID  int             `json:"entity_id" example:"1"`
+	Action    string          `json:"action" example:"delete"`
+	Before    json.RawMessage `json:"before,omitempty" swaggertype:"object"`
+	After     json.RawMessage `json:"after,omitempty" swaggertype:"object"`
+}
+
+type AuditLogsResponse struct {
+	Pagination
+	Data []AuditLogDto `json:"data"`
+}
+
+type FindAuditLogsAndTotalResult struct {
+	Count int
+	Total int64
+	Next  bool
+	Data  []*model.AuditLog
+}
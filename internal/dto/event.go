@@ -0,0 +1,15 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type Event struct {
+	ID         string          `json:"id" example:"8f14e45f-ceea-467a-9575-7e5b2f3c1a2b"`
+	Type       string          `json:"type" example:"planet.deleted"`
+	Entity     string          `json:"entity" example:"planet"`
+	EntityID   int             `json:"entity_id" example:"1"`
+	OccurredAt time.Time       `json:"occurred_at" example:"2022-10-01T12:00:00Z"`
+	Data       json.RawMessage `json:"data" swaggertype:"object"`
+}
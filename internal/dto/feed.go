@@ -0,0 +1,59 @@
+package dto
+
+type FeedFieldChange struct {
+	Field  string      `json:"field" example:"population"`
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+type FeedDiffEntry struct {
+	ID      int               `json:"id" example:"1"`
+	Name    string            `json:"name" example:"Tatooine"`
+	Changes []FeedFieldChange `json:"changes,omitempty"`
+}
+
+type FeedEntityDiff struct {
+	New     []FeedDiffEntry `json:"new"`
+	Changed []FeedDiffEntry `json:"changed"`
+	Missing []FeedDiffEntry `json:"missing"`
+	Deleted []FeedDiffEntry `json:"deleted"`
+}
+
+type FeedRelationship struct {
+	PlanetID int `json:"planet_id" example:"1"`
+	FilmID   int `json:"film_id" example:"1"`
+}
+
+type FeedRelationshipDiff struct {
+	New     []FeedRelationship `json:"new"`
+	Missing []FeedRelationship `json:"missing"`
+}
+
+type FeedDiff struct {
+	IgnoredCheckpoint *FeedCheckpoint      `json:"ignored_checkpoint,omitempty"`
+	Films             FeedEntityDiff       `json:"films"`
+	Planets           FeedEntityDiff       `json:"planets"`
+	Relationships     FeedRelationshipDiff `json:"relationships"`
+	Skipped           []FeedSkippedRecord  `json:"skipped"`
+}
+
+type FeedCheckpoint struct {
+	Resource string `json:"resource" example:"planets"`
+	Page     int    `json:"page" example:"3"`
+}
+
+type FeedSkippedRecord struct {
+	Resource string `json:"resource" example:"films"`
+	Page     int    `json:"page" example:"1"`
+	Url      string `json:"url" example:"https://swapi.dev/api/films/1/"`
+	Reason   string `json:"reason" example:"parsing time \"1977-13-25\": month out of range"`
+}
+
+type FeedReport struct {
+	ResumedFrom       *FeedCheckpoint     `json:"resumed_from,omitempty"`
+	IgnoredCheckpoint *FeedCheckpoint     `json:"ignored_checkpoint,omitempty"`
+	Films             int                 `json:"films" example:"6"`
+	Planets           int                 `json:"planets" example:"60"`
+	Relationships     int                 `json:"relationships" example:"67"`
+	Skipped           []FeedSkippedRecord `json:"skipped"`
+}
@@ -0,0 +1,51 @@
+package dto
+
+import "github.com/viniosilva/starwars-api/internal/model"
+
+// PlanetFields whitelists the planet fields accepted by the fields parameter
+var PlanetFields = map[string]string{
+	"id":              model.PlanetColumns.ID,
+	"created_at":      model.PlanetColumns.CreatedAt,
+	"updated_at":      model.PlanetColumns.UpdatedAt,
+	"name":            model.PlanetColumns.Name,
+	"climates":        model.PlanetColumns.Climates,
+	"terrains":        model.PlanetColumns.Terrains,
+	"rotation_period": model.PlanetColumns.RotationPeriod,
+	"orbital_period":  model.PlanetColumns.OrbitalPeriod,
+	"diameter":        model.PlanetColumns.Diameter,
+	"gravity":         model.PlanetColumns.Gravity,
+	"surface_water":   model.PlanetColumns.SurfaceWater,
+	"population":      model.PlanetColumns.Population,
+}
+
+// PlanetRangeFilters maps the numeric planet attributes accepted by the
+// <attribute>Min and <attribute>Max filters of the planet list to their columns
+var PlanetRangeFilters = map[string]string{
+	"rotationPeriod": model.PlanetColumns.RotationPeriod,
+	"orbitalPeriod":  model.PlanetColumns.OrbitalPeriod,
+	"diameter":       model.PlanetColumns.Diameter,
+	"surfaceWater":   model.PlanetColumns.SurfaceWater,
+	"population":     model.PlanetColumns.Population,
+}
+
+// FilmFields whitelists the film fields accepted by the embed parameter
+var FilmFields = map[string]string{
+	"id":            model.FilmColumns.ID,
+	"created_at":    model.FilmColumns.CreatedAt,
+	"updated_at":    model.FilmColumns.UpdatedAt,
+	"title":         model.FilmColumns.Title,
+	"episode":       model.FilmColumns.Episode,
+	"director":      model.FilmColumns.Director,
+	"release_date":  model.FilmColumns.ReleaseDate,
+	"opening_crawl": model.FilmColumns.OpeningCrawl,
+	"producer":      model.FilmColumns.Producer,
+}
+
+type SparsePlanetResponse struct {
+	Data map[string]interface{} `json:"data"`
+}
+
+type SparsePlanetsResponse struct {
+	Pagination
+	Data []map[string]interface{} `json:"data"`
+}
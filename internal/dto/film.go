@@ -1,11 +1,32 @@
 package dto
 
+import "github.com/viniosilva/starwars-api/internal/model"
+
 type FilmDto struct {
-	ID          int    `json:"id" example:"1"`
-	CreatedAt   string `json:"created_at,omitempty" example:"2014-12-09 13:50:49"`
-	UpdatedAt   string `json:"updated_at,omitempty" example:"2014-12-20 20:58:18"`
-	Title       string `json:"title,omitempty" example:"A New Hope"`
-	Episode     int    `json:"episode,omitempty" example:"4"`
-	Director    string `json:"director,omitempty" example:"George Lucas"`
-	ReleaseDate string `json:"release_date,omitempty" example:"1977-05-25"`
+	ID           int        `json:"id" example:"1"`
+	CreatedAt    string     `json:"created_at,omitempty" example:"2014-12-09 13:50:49"`
+	UpdatedAt    string     `json:"updated_at,omitempty" example:"2014-12-20 20:58:18"`
+	Title        string     `json:"title,omitempty" example:"A New Hope"`
+	Episode      int        `json:"episode,omitempty" example:"4"`
+	Director     string     `json:"director,omitempty" example:"George Lucas"`
+	Producer     string     `json:"producer,omitempty" example:"Gary Kurtz, Rick McCallum"`
+	ReleaseDate  string     `json:"release_date,omitempty" example:"1977-05-25"`
+	OpeningCrawl string     `json:"opening_crawl,omitempty" example:"It is a period of civil war."`
+	Links        *FilmLinks `json:"_links,omitempty"`
+}
+
+type FindFilmsAndTotalResult struct {
+	Count int
+	Total int64
+	Next  bool
+	Data  []*model.Film
+}
+
+type FilmResponse struct {
+	Data FilmDto `json:"data"`
+}
+
+type FilmsResponse struct {
+	Pagination
+	Data []FilmDto `json:"data"`
 }
@@ -0,0 +1,12 @@
+package dto
+
+type GraphQLRequest struct {
+	Query         string                 `json:"query" example:"{ planets(size: 2) { data { name films { title } } } }"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+type GraphQLResponse struct {
+	Data   interface{}   `json:"data,omitempty"`
+	Errors []interface{} `json:"errors,omitempty"`
+}
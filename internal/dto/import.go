@@ -0,0 +1,15 @@
+package dto
+
+type ImportRowError struct {
+	Line  int    `json:"line" example:"2"`
+	Error string `json:"error" example:"name is required"`
+}
+
+type ImportResult struct {
+	Total    int              `json:"total" example:"2"`
+	Valid    int              `json:"valid" example:"1"`
+	Imported int              `json:"imported" example:"0"`
+	DryRun   bool             `json:"dry_run" example:"false"`
+	Upsert   bool             `json:"upsert" example:"false"`
+	Errors   []ImportRowError `json:"errors"`
+}
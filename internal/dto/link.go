@@ -0,0 +1,15 @@
+package dto
+
+type Link struct {
+	Href string `json:"href" example:"http://localhost:8080/api/planets/1"`
+}
+
+type PlanetLinks struct {
+	Self  Link  `json:"self"`
+	Films *Link `json:"films,omitempty"`
+}
+
+type FilmLinks struct {
+	Self   *Link `json:"self,omitempty"`
+	Planet *Link `json:"planet,omitempty"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+type LookupDto struct {
+	ID      int    `json:"id" example:"1"`
+	Name    string `json:"name" example:"arid"`
+	Planets int    `json:"planets" example:"5"`
+}
+
+type LookupsResponse struct {
+	Count int         `json:"count" example:"1"`
+	Data  []LookupDto `json:"data"`
+}
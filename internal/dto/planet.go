@@ -3,13 +3,20 @@ package dto
 import "github.com/viniosilva/starwars-api/internal/model"
 
 type PlanetDto struct {
-	ID        int       `json:"id" example:"1"`
-	CreatedAt string    `json:"created_at,omitempty" example:"2014-12-09 13:50:49"`
-	UpdatedAt string    `json:"updated_at,omitempty" example:"2014-12-20 20:58:18"`
-	Films     []FilmDto `json:"films,omitempty"`
-	Name      string    `json:"name,omitempty" example:"Tatooine"`
-	Climates  []string  `json:"climates,omitempty" example:"arid"`
-	Terrains  []string  `json:"terrains,omitempty" example:"desert"`
+	ID             int          `json:"id" example:"1"`
+	CreatedAt      string       `json:"created_at,omitempty" example:"2014-12-09 13:50:49"`
+	UpdatedAt      string       `json:"updated_at,omitempty" example:"2014-12-20 20:58:18"`
+	Films          []FilmDto    `json:"films,omitempty"`
+	Name           string       `json:"name,omitempty" example:"Tatooine"`
+	Climates       []string     `json:"climates,omitempty" example:"arid"`
+	Terrains       []string     `json:"terrains,omitempty" example:"desert"`
+	RotationPeriod *int         `json:"rotation_period,omitempty" example:"23"`
+	OrbitalPeriod  *int         `json:"orbital_period,omitempty" example:"304"`
+	Diameter       *int         `json:"diameter,omitempty" example:"10465"`
+	Gravity        string       `json:"gravity,omitempty" example:"1 standard"`
+	SurfaceWater   *float64     `json:"surface_water,omitempty" example:"1"`
+	Population     *int64       `json:"population,omitempty" example:"200000"`
+	Links          *PlanetLinks `json:"_links,omitempty"`
 }
 
 type PlanetResponse struct {
@@ -27,3 +34,30 @@ type FindPlanetsAndTotalResult struct {
 	Next  bool
 	Data  []*model.Planet
 }
+
+type FindPlanetsByIDsResult struct {
+	Data    []*model.Planet
+	Missing []int
+}
+
+type PlanetsBatchRequest struct {
+	IDs       []int `json:"ids" example:"1,2,3"`
+	LoadFilms bool  `json:"loadFilms" example:"false"`
+}
+
+type PlanetsBatchResponse struct {
+	Count   int         `json:"count" example:"2"`
+	Data    []PlanetDto `json:"data"`
+	Missing []int       `json:"missing" example:"3"`
+}
+
+type PlanetVersionDto struct {
+	ValidFrom string `json:"valid_from" example:"2022-10-01 12:00:00"`
+	ValidTo   string `json:"valid_to,omitempty" example:"2022-10-02 12:00:00"`
+	DeletedAt string `json:"deleted_at,omitempty" example:"2022-10-02 12:00:00"`
+	PlanetDto
+}
+
+type PlanetHistoryResponse struct {
+	Data []PlanetVersionDto `json:"data"`
+}
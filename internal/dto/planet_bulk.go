@@ -0,0 +1,53 @@
+package dto
+
+import "github.com/viniosilva/starwars-api/internal/model"
+
+type PlanetPatch struct {
+	Name           *string   `json:"name,omitempty" example:"Tatooine"`
+	Climates       *[]string `json:"climates,omitempty" example:"arid"`
+	Terrains       *[]string `json:"terrains,omitempty" example:"desert"`
+	RotationPeriod *int      `json:"rotation_period,omitempty" example:"23"`
+	OrbitalPeriod  *int      `json:"orbital_period,omitempty" example:"304"`
+	Diameter       *int      `json:"diameter,omitempty" example:"10465"`
+	Gravity        *string   `json:"gravity,omitempty" example:"1 standard"`
+	SurfaceWater   *float64  `json:"surface_water,omitempty" example:"1"`
+	Population     *int64    `json:"population,omitempty" example:"200000"`
+}
+
+type PlanetBulkOperation struct {
+	Op    string       `json:"op" example:"patch" enums:"delete,restore,patch"`
+	ID    int          `json:"id" example:"1"`
+	Patch *PlanetPatch `json:"patch,omitempty"`
+}
+
+type PlanetsBulkRequest struct {
+	Mode       string                `json:"mode" example:"atomic" enums:"atomic,best_effort"`
+	Operations []PlanetBulkOperation `json:"operations"`
+}
+
+type PlanetBulkResult struct {
+	Index  int        `json:"index" example:"0"`
+	Op     string     `json:"op" example:"patch"`
+	ID     int        `json:"id" example:"1"`
+	Status int        `json:"status" example:"200"`
+	Error  string     `json:"error,omitempty" example:"planet 1 not found"`
+	Data   *PlanetDto `json:"data,omitempty"`
+}
+
+type PlanetsBulkResponse struct {
+	Mode      string             `json:"mode" example:"atomic"`
+	Committed bool               `json:"committed" example:"true"`
+	Succeeded int                `json:"succeeded" example:"2"`
+	Failed    int                `json:"failed" example:"0"`
+	Results   []PlanetBulkResult `json:"results"`
+}
+
+type BulkPlanetResult struct {
+	Planet *model.Planet
+	Err    error
+}
+
+type BulkPlanetsResult struct {
+	Committed bool
+	Results   []BulkPlanetResult
+}
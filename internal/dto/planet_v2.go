@@ -0,0 +1,64 @@
+package dto
+
+type LinkV2 struct {
+	Href string `json:"href" example:"http://localhost:8080/api/v2/planets/1"`
+}
+
+type LinksV2 struct {
+	Self  *LinkV2 `json:"self,omitempty"`
+	First *LinkV2 `json:"first,omitempty"`
+	Prev  *LinkV2 `json:"prev,omitempty"`
+	Next  *LinkV2 `json:"next,omitempty"`
+	Last  *LinkV2 `json:"last,omitempty"`
+}
+
+type PageV2 struct {
+	Number        int   `json:"number" example:"2"`
+	Size          int   `json:"size" example:"10"`
+	Count         int   `json:"count" example:"10"`
+	TotalElements int64 `json:"total_elements" example:"60"`
+	TotalPages    int64 `json:"total_pages" example:"6"`
+}
+
+type FilmV2Dto struct {
+	ID           int    `json:"id" example:"1"`
+	CreatedAt    string `json:"created_at" example:"2014-12-10T14:23:31Z"`
+	UpdatedAt    string `json:"updated_at" example:"2014-12-20T19:49:45Z"`
+	Title        string `json:"title" example:"A New Hope"`
+	Episode      int    `json:"episode" example:"4"`
+	Director     string `json:"director" example:"George Lucas"`
+	Producer     string `json:"producer,omitempty" example:"Gary Kurtz, Rick McCallum"`
+	ReleaseDate  string `json:"release_date" example:"1977-05-25"`
+	OpeningCrawl string `json:"opening_crawl,omitempty" example:"It is a period of civil war."`
+}
+
+type PlanetV2Embedded struct {
+	Films []FilmV2Dto `json:"films"`
+}
+
+type PlanetV2Dto struct {
+	Links          LinksV2           `json:"_links"`
+	ID             int               `json:"id" example:"1"`
+	CreatedAt      string            `json:"created_at" example:"2014-12-09T13:50:49Z"`
+	UpdatedAt      string            `json:"updated_at" example:"2014-12-20T20:58:18Z"`
+	Name           string            `json:"name" example:"Tatooine"`
+	Climates       []string          `json:"climates" example:"arid"`
+	Terrains       []string          `json:"terrains" example:"desert"`
+	RotationPeriod *int              `json:"rotation_period" example:"23"`
+	OrbitalPeriod  *int              `json:"orbital_period" example:"304"`
+	Diameter       *int              `json:"diameter" example:"10465"`
+	Gravity        *string           `json:"gravity" example:"1 standard"`
+	SurfaceWater   *float64          `json:"surface_water" example:"1"`
+	Population     *int64            `json:"population" example:"200000"`
+	Embedded       *PlanetV2Embedded `json:"_embedded,omitempty"`
+}
+
+type PlanetsV2Embedded struct {
+	Planets []PlanetV2Dto `json:"planets"`
+}
+
+type PlanetsV2Response struct {
+	Links    LinksV2           `json:"_links"`
+	Embedded PlanetsV2Embedded `json:"_embedded"`
+	Page     PageV2            `json:"page"`
+}
@@ -0,0 +1,24 @@
+package dto
+
+import "github.com/viniosilva/starwars-api/internal/model"
+
+type SearchHitDto struct {
+	Type       string            `json:"type" example:"planet"`
+	Score      float64           `json:"score" example:"1.5"`
+	Highlights map[string]string `json:"highlights"`
+	Planet     *PlanetDto        `json:"planet,omitempty"`
+	Film       *FilmDto          `json:"film,omitempty"`
+}
+
+type SearchResponse struct {
+	Count int            `json:"count" example:"1"`
+	Data  []SearchHitDto `json:"data"`
+}
+
+type SearchResult struct {
+	Type       string
+	Score      float64
+	Highlights map[string]string
+	Planet     *model.Planet
+	Film       *model.Film
+}
@@ -0,0 +1,32 @@
+package dto
+
+type StatsCountDto struct {
+	Value string `json:"value" example:"arid"`
+	Count int    `json:"count" example:"3"`
+}
+
+type StatsEntityCountDto struct {
+	ID    int    `json:"id" example:"1"`
+	Name  string `json:"name" example:"Tatooine"`
+	Count int    `json:"count" example:"5"`
+}
+
+type StatsDistributionDto struct {
+	Films   int `json:"films" example:"2"`
+	Planets int `json:"planets" example:"7"`
+}
+
+type StatsCountsResponse struct {
+	Count int             `json:"count" example:"1"`
+	Data  []StatsCountDto `json:"data"`
+}
+
+type StatsEntityCountsResponse struct {
+	Count int                   `json:"count" example:"1"`
+	Data  []StatsEntityCountDto `json:"data"`
+}
+
+type StatsDistributionResponse struct {
+	Count int                    `json:"count" example:"1"`
+	Data  []StatsDistributionDto `json:"data"`
+}
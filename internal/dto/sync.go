@@ -0,0 +1,17 @@
+package dto
+
+type SyncRun struct {
+	Trigger    string `json:"trigger" example:"schedule" enums:"schedule,manual"`
+	Status     string `json:"status" example:"succeeded" enums:"succeeded,failed,skipped"`
+	StartedAt  string `json:"started_at" example:"2022-10-02T03:00:00Z"`
+	FinishedAt string `json:"finished_at" example:"2022-10-02T03:00:12Z"`
+	Error      string `json:"error,omitempty" example:"sync is running in another instance"`
+}
+
+type SyncStatus struct {
+	Enabled   bool     `json:"enabled" example:"true"`
+	Schedule  string   `json:"schedule,omitempty" example:"0 3 * * *"`
+	Running   bool     `json:"running" example:"false"`
+	NextRunAt string   `json:"next_run_at,omitempty" example:"2022-10-03T03:00:00Z"`
+	LastRun   *SyncRun `json:"last_run,omitempty"`
+}
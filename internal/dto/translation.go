@@ -0,0 +1,9 @@
+package dto
+
+type PlanetTranslationRequest struct {
+	Name string `json:"name" example:"Tatuíne"`
+}
+
+type FilmTranslationRequest struct {
+	Title string `json:"title" example:"Uma Nova Esperança"`
+}
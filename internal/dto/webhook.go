@@ -0,0 +1,57 @@
+package dto
+
+import (
+	"encoding/json"
+
+	"github.com/viniosilva/starwars-api/internal/model"
+)
+
+type WebhookRequest struct {
+	URL    string   `json:"url" example:"https://example.com/hooks/starwars"`
+	Secret string   `json:"secret,omitempty" example:"a-long-random-secret"`
+	Events []string `json:"events" example:"planet.deleted"`
+	Active *bool    `json:"active,omitempty" example:"true"`
+}
+
+type WebhookDto struct {
+	ID        int      `json:"id" example:"1"`
+	CreatedAt string   `json:"created_at" example:"2022-10-01 12:00:00"`
+	UpdatedAt string   `json:"updated_at" example:"2022-10-01 12:00:00"`
+	URL       string   `json:"url" example:"https://example.com/hooks/starwars"`
+	Events    []string `json:"events" example:"planet.deleted"`
+	Active    bool     `json:"active" example:"true"`
+}
+
+type WebhookResponse struct {
+	Data WebhookDto `json:"data"`
+}
+
+type WebhooksResponse struct {
+	Data []WebhookDto `json:"data"`
+}
+
+type WebhookDeliveryDto struct {
+	ID            int64           `json:"id" example:"1"`
+	CreatedAt     string          `json:"created_at" example:"2022-10-01 12:00:00"`
+	WebhookID     int             `json:"webhook_id" example:"1"`
+	EventID       string          `json:"event_id" example:"8f14e45f-ceea-467a-9575-7e5b2f3c1a2b"`
+	Event         string          `json:"event" example:"planet.deleted"`
+	Status        string          `json:"status" example:"dead"`
+	Attempts      int             `json:"attempts" example:"8"`
+	NextAttemptAt string          `json:"next_attempt_at" example:"2022-10-01 12:00:00"`
+	LastError     string          `json:"last_error,omitempty" example:"unexpected status 500"`
+	DeliveredAt   string          `json:"delivered_at,omitempty" example:"2022-10-01 12:00:00"`
+	Payload       json.RawMessage `json:"payload" swaggertype:"object"`
+}
+
+type WebhookDeliveriesResponse struct {
+	Pagination
+	Data []WebhookDeliveryDto `json:"data"`
+}
+
+type FindWebhookDeliveriesAndTotalResult struct {
+	Count int
+	Total int64
+	Next  bool
+	Data  []*model.WebhookDelivery
+}
@@ -0,0 +1,9 @@
+package exception
+
+type ConflictException struct {
+	Message string
+}
+
+func (impl *ConflictException) Error() string {
+	return impl.Message
+}
@@ -0,0 +1,9 @@
+package exception
+
+type ValidationException struct {
+	Message string
+}
+
+func (impl *ValidationException) Error() string {
+	return impl.Message
+}
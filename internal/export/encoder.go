@@ -0,0 +1,102 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+type Format string
+
+const (
+	FormatCSV     Format = "csv"
+	FormatNDJSON  Format = "ndjson"
+	FormatParquet Format = "parquet"
+)
+
+// Record is a row written by an Encoder
+type Record interface {
+	Header() []string
+	Values() []string
+}
+
+type Encoder interface {
+	Encode(record Record) error
+	Flush() error
+	Close() error
+}
+
+// NewEncoder returns an Encoder writing records shaped like prototype to w
+func NewEncoder(format Format, w io.Writer, prototype Record) (Encoder, error) {
+	switch format {
+	case FormatCSV:
+		csvWriter := csv.NewWriter(w)
+		if err := csvWriter.Write(prototype.Header()); err != nil {
+			return nil, err
+		}
+		return &csvEncoder{writer: csvWriter}, nil
+	case FormatNDJSON:
+		return &ndjsonEncoder{encoder: json.NewEncoder(w)}, nil
+	case FormatParquet:
+		parquetWriter, err := writer.NewParquetWriterFromWriter(w, prototype, 1)
+		if err != nil {
+			return nil, err
+		}
+		return &parquetEncoder{writer: parquetWriter}, nil
+	}
+
+	return nil, fmt.Errorf("invalid format %s", format)
+}
+
+type csvEncoder struct {
+	writer *csv.Writer
+}
+
+func (impl *csvEncoder) Encode(record Record) error {
+	return impl.writer.Write(record.Values())
+}
+
+func (impl *csvEncoder) Flush() error {
+	impl.writer.Flush()
+	return impl.writer.Error()
+}
+
+func (impl *csvEncoder) Close() error {
+	return impl.Flush()
+}
+
+type ndjsonEncoder struct {
+	encoder *json.Encoder
+}
+
+func (impl *ndjsonEncoder) Encode(record Record) error {
+	return impl.encoder.Encode(record)
+}
+
+func (impl *ndjsonEncoder) Flush() error {
+	return nil
+}
+
+func (impl *ndjsonEncoder) Close() error {
+	return nil
+}
+
+type parquetEncoder struct {
+	writer *writer.ParquetWriter
+}
+
+func (impl *parquetEncoder) Encode(record Record) error {
+	return impl.writer.Write(record)
+}
+
+// Flush is a no-op, parquet row groups are written when full or on Close
+func (impl *parquetEncoder) Flush() error {
+	return nil
+}
+
+func (impl *parquetEncoder) Close() error {
+	return impl.writer.WriteStop()
+}
@@ -0,0 +1,96 @@
+package export_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/export"
+)
+
+func Test_Export_NewEncoder(t *testing.T) {
+	diameter := int32(12500)
+	gravity := "1 standard"
+	surfaceWater := float64(40)
+	population := int64(2000000000)
+
+	var cases = map[string]struct {
+		inputFormat      export.Format
+		inputRecords     []export.Record
+		expectedOutput   string
+		expectedErrorMsg string
+	}{
+		"should write csv with header": {
+			inputFormat: export.FormatCSV,
+			inputRecords: []export.Record{
+				&export.PlanetRecord{ID: 1, Name: "Tatooine", Climates: []string{"arid"}, Terrains: []string{"desert"}},
+				&export.PlanetRecord{ID: 2, Name: "Alderaan", Climates: []string{"temperate"}, Terrains: []string{"grasslands", "mountains"},
+					Diameter: &diameter, Gravity: &gravity, SurfaceWater: &surfaceWater, Population: &population},
+			},
+			expectedOutput: "id,created_at,updated_at,name,climates,terrains,rotation_period,orbital_period,diameter,gravity,surface_water,population\n" +
+				"1,,,Tatooine,arid,desert,,,,,,\n" +
+				"2,,,Alderaan,temperate,\"grasslands,mountains\",,,12500,1 standard,40,2000000000\n",
+		},
+		"should write csv header when there are no records": {
+			inputFormat:    export.FormatCSV,
+			inputRecords:   []export.Record{},
+			expectedOutput: "planet_id,film_id\n",
+		},
+		"should write ndjson": {
+			inputFormat: export.FormatNDJSON,
+			inputRecords: []export.Record{
+				&export.PlanetFilmRecord{PlanetID: 1, FilmID: 1},
+				&export.PlanetFilmRecord{PlanetID: 1, FilmID: 3},
+			},
+			expectedOutput: "{\"planet_id\":1,\"film_id\":1}\n{\"planet_id\":1,\"film_id\":3}\n",
+		},
+		"should throw error when format is invalid": {
+			inputFormat:      export.Format("xml"),
+			expectedErrorMsg: "invalid format xml",
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			var prototype export.Record = &export.PlanetFilmRecord{}
+			if len(cs.inputRecords) > 0 {
+				prototype = cs.inputRecords[0]
+			}
+			buf := &bytes.Buffer{}
+
+			// when
+			encoder, err := export.NewEncoder(cs.inputFormat, buf, prototype)
+			if err == nil {
+				for _, r := range cs.inputRecords {
+					assert.Nil(t, encoder.Encode(r))
+				}
+				err = encoder.Close()
+			}
+
+			// then
+			if cs.expectedErrorMsg != "" {
+				assert.EqualError(t, err, cs.expectedErrorMsg)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, cs.expectedOutput, buf.String())
+		})
+	}
+}
+
+func Test_Export_NewEncoderParquet(t *testing.T) {
+	// given
+	buf := &bytes.Buffer{}
+
+	// when
+	encoder, err := export.NewEncoder(export.FormatParquet, buf, &export.FilmRecord{})
+	assert.Nil(t, err)
+	assert.Nil(t, encoder.Encode(&export.FilmRecord{ID: 1, Title: "A New Hope", Episode: 4}))
+	err = encoder.Close()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, "PAR1", buf.String()[:4])
+	assert.Equal(t, "PAR1", buf.String()[buf.Len()-4:])
+}
@@ -0,0 +1,162 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/volatiletech/null/v8"
+)
+
+type PlanetRecord struct {
+	ID             int32    `json:"id" parquet:"name=id, type=INT32"`
+	CreatedAt      string   `json:"created_at" parquet:"name=created_at, type=BYTE_ARRAY, convertedtype=UTF8"`
+	UpdatedAt      string   `json:"updated_at" parquet:"name=updated_at, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Name           string   `json:"name" parquet:"name=name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Climates       []string `json:"climates" parquet:"name=climates, type=LIST, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	Terrains       []string `json:"terrains" parquet:"name=terrains, type=LIST, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	RotationPeriod *int32   `json:"rotation_period,omitempty" parquet:"name=rotation_period, type=INT32, repetitiontype=OPTIONAL"`
+	OrbitalPeriod  *int32   `json:"orbital_period,omitempty" parquet:"name=orbital_period, type=INT32, repetitiontype=OPTIONAL"`
+	Diameter       *int32   `json:"diameter,omitempty" parquet:"name=diameter, type=INT32, repetitiontype=OPTIONAL"`
+	Gravity        *string  `json:"gravity,omitempty" parquet:"name=gravity, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	SurfaceWater   *float64 `json:"surface_water,omitempty" parquet:"name=surface_water, type=DOUBLE, repetitiontype=OPTIONAL"`
+	Population     *int64   `json:"population,omitempty" parquet:"name=population, type=INT64, repetitiontype=OPTIONAL"`
+}
+
+func (impl *PlanetRecord) Header() []string {
+	return []string{"id", "created_at", "updated_at", "name", "climates", "terrains",
+		"rotation_period", "orbital_period", "diameter", "gravity", "surface_water", "population"}
+}
+
+func (impl *PlanetRecord) Values() []string {
+	return []string{
+		fmt.Sprint(impl.ID),
+		impl.CreatedAt,
+		impl.UpdatedAt,
+		impl.Name,
+		strings.Join(impl.Climates, ","),
+		strings.Join(impl.Terrains, ","),
+		formatOptional(impl.RotationPeriod),
+		formatOptional(impl.OrbitalPeriod),
+		formatOptional(impl.Diameter),
+		formatOptional(impl.Gravity),
+		formatOptional(impl.SurfaceWater),
+		formatOptional(impl.Population),
+	}
+}
+
+type FilmRecord struct {
+	ID           int32   `json:"id" parquet:"name=id, type=INT32"`
+	CreatedAt    string  `json:"created_at" parquet:"name=created_at, type=BYTE_ARRAY, convertedtype=UTF8"`
+	UpdatedAt    string  `json:"updated_at" parquet:"name=updated_at, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Title        string  `json:"title" parquet:"name=title, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Episode      int32   `json:"episode" parquet:"name=episode, type=INT32"`
+	Director     string  `json:"director" parquet:"name=director, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ReleaseDate  string  `json:"release_date" parquet:"name=release_date, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Producer     *string `json:"producer,omitempty" parquet:"name=producer, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	OpeningCrawl *string `json:"opening_crawl,omitempty" parquet:"name=opening_crawl, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+}
+
+func (impl *FilmRecord) Header() []string {
+	return []string{"id", "created_at", "updated_at", "title", "episode", "director", "release_date", "producer", "opening_crawl"}
+}
+
+func (impl *FilmRecord) Values() []string {
+	return []string{
+		fmt.Sprint(impl.ID),
+		impl.CreatedAt,
+		impl.UpdatedAt,
+		impl.Title,
+		fmt.Sprint(impl.Episode),
+		impl.Director,
+		impl.ReleaseDate,
+		formatOptional(impl.Producer),
+		formatOptional(impl.OpeningCrawl),
+	}
+}
+
+type PlanetFilmRecord struct {
+	PlanetID int32 `json:"planet_id" parquet:"name=planet_id, type=INT32"`
+	FilmID   int32 `json:"film_id" parquet:"name=film_id, type=INT32"`
+}
+
+func (impl *PlanetFilmRecord) Header() []string {
+	return []string{"planet_id", "film_id"}
+}
+
+func (impl *PlanetFilmRecord) Values() []string {
+	return []string{fmt.Sprint(impl.PlanetID), fmt.Sprint(impl.FilmID)}
+}
+
+func ParsePlanetRecord(planet *model.Planet) *PlanetRecord {
+	var climates []string
+	json.Unmarshal(planet.Climates, &climates)
+
+	var terrains []string
+	json.Unmarshal(planet.Terrains, &terrains)
+
+	return &PlanetRecord{
+		ID:             int32(planet.ID),
+		CreatedAt:      planet.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:      planet.UpdatedAt.Format("2006-01-02 15:04:05"),
+		Name:           planet.Name,
+		Climates:       climates,
+		Terrains:       terrains,
+		RotationPeriod: int32Ptr(planet.RotationPeriod),
+		OrbitalPeriod:  int32Ptr(planet.OrbitalPeriod),
+		Diameter:       int32Ptr(planet.Diameter),
+		Gravity:        planet.Gravity.Ptr(),
+		SurfaceWater:   planet.SurfaceWater.Ptr(),
+		Population:     planet.Population.Ptr(),
+	}
+}
+
+func ParseFilmRecord(film *model.Film) *FilmRecord {
+	return &FilmRecord{
+		ID:           int32(film.ID),
+		CreatedAt:    film.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:    film.UpdatedAt.Format("2006-01-02 15:04:05"),
+		Title:        film.Title,
+		Episode:      int32(film.Episode),
+		Director:     film.Director,
+		ReleaseDate:  film.ReleaseDate.Format("2006-01-02"),
+		Producer:     film.Producer.Ptr(),
+		OpeningCrawl: film.OpeningCrawl.Ptr(),
+	}
+}
+
+// formatOptional writes the value of an optional record field, or an empty
+// string when it is not set
+func formatOptional(value interface{}) string {
+	switch v := value.(type) {
+	case *int32:
+		if v != nil {
+			return strconv.FormatInt(int64(*v), 10)
+		}
+	case *int64:
+		if v != nil {
+			return strconv.FormatInt(*v, 10)
+		}
+	case *float64:
+		if v != nil {
+			return strconv.FormatFloat(*v, 'f', -1, 64)
+		}
+	case *string:
+		if v != nil {
+			return *v
+		}
+	}
+
+	return ""
+}
+
+func int32Ptr(value null.Int) *int32 {
+	if !value.Valid {
+		return nil
+	}
+
+	n := int32(value.Int)
+	return &n
+}
@@ -0,0 +1,100 @@
+package graph
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/graph-gophers/dataloader"
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/viniosilva/starwars-api/internal/service"
+)
+
+type loadersKey struct{}
+
+// Loaders batches the nested relationship lookups of a single request,
+// so resolving the films of N planets costs one query instead of N. Loaded
+// planets and films are translated to the locale of the request
+type Loaders struct {
+	FilmsByPlanetID *dataloader.Loader
+	PlanetsByFilmID *dataloader.Loader
+}
+
+func NewLoaders(planetService service.PlanetService, filmService service.FilmService, translationService service.TranslationService) *Loaders {
+	return &Loaders{
+		FilmsByPlanetID: dataloader.NewBatchedLoader(func(ctx context.Context, keys dataloader.Keys) []*dataloader.Result {
+			ids := ParseKeys(keys)
+			films, err := filmService.FindFilmsByPlanetIDs(ctx, ids)
+			if err == nil {
+				loaded := []*model.Film{}
+				for _, f := range films {
+					loaded = append(loaded, f...)
+				}
+				err = TranslateFilms(ctx, translationService, loaded)
+			}
+
+			res := make([]*dataloader.Result, len(ids))
+			for i, id := range ids {
+				res[i] = &dataloader.Result{Data: films[id], Error: err}
+			}
+
+			return res
+		}),
+		PlanetsByFilmID: dataloader.NewBatchedLoader(func(ctx context.Context, keys dataloader.Keys) []*dataloader.Result {
+			ids := ParseKeys(keys)
+			planets, err := planetService.FindPlanetsByFilmIDs(ctx, ids)
+			if err == nil {
+				loaded := []*model.Planet{}
+				for _, p := range planets {
+					loaded = append(loaded, p...)
+				}
+				err = TranslatePlanets(ctx, translationService, loaded)
+			}
+
+			res := make([]*dataloader.Result, len(ids))
+			for i, id := range ids {
+				res[i] = &dataloader.Result{Data: planets[id], Error: err}
+			}
+
+			return res
+		}),
+	}
+}
+
+func WithLoaders(ctx context.Context, loaders *Loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, loaders)
+}
+
+func LoadersFromContext(ctx context.Context) *Loaders {
+	loaders, _ := ctx.Value(loadersKey{}).(*Loaders)
+	return loaders
+}
+
+func (impl *Loaders) LoadFilms(ctx context.Context, planetID int) ([]*model.Film, error) {
+	data, err := impl.FilmsByPlanetID.Load(ctx, dataloader.StringKey(strconv.Itoa(planetID)))()
+	if err != nil {
+		return nil, err
+	}
+
+	films, _ := data.([]*model.Film)
+	return films, nil
+}
+
+func (impl *Loaders) LoadPlanets(ctx context.Context, filmID int) ([]*model.Planet, error) {
+	data, err := impl.PlanetsByFilmID.Load(ctx, dataloader.StringKey(strconv.Itoa(filmID)))()
+	if err != nil {
+		return nil, err
+	}
+
+	planets, _ := data.([]*model.Planet)
+	return planets, nil
+}
+
+func ParseKeys(keys dataloader.Keys) []int {
+	ids := make([]int, 0, len(keys))
+	for _, key := range keys {
+		id, _ := strconv.Atoi(key.String())
+		ids = append(ids, id)
+	}
+
+	return ids
+}
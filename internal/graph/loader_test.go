@@ -0,0 +1,140 @@
+package graph_test
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/graph-gophers/dataloader"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/graph"
+	"github.com/viniosilva/starwars-api/internal/i18n"
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/viniosilva/starwars-api/mock"
+)
+
+func Test_Loaders_LoadFilms(t *testing.T) {
+	var cases = map[string]struct {
+		mocking          func(filmService *mock.MockFilmService)
+		expectedFilms    map[int][]*model.Film
+		expectedErrorMsg string
+	}{
+		"should load the films of every planet in one batch": {
+			mocking: func(filmService *mock.MockFilmService) {
+				filmService.EXPECT().FindFilmsByPlanetIDs(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, planetIDs []int) (map[int][]*model.Film, error) {
+						ids := append([]int{}, planetIDs...)
+						sort.Ints(ids)
+						assert.Equal(t, []int{1, 2}, ids)
+						return map[int][]*model.Film{1: {{ID: 1, Title: "A New Hope"}}}, nil
+					}).Times(1)
+			},
+			expectedFilms: map[int][]*model.Film{1: {{ID: 1, Title: "A New Hope"}}, 2: nil},
+		},
+		"should throw error when find films": {
+			mocking: func(filmService *mock.MockFilmService) {
+				filmService.EXPECT().FindFilmsByPlanetIDs(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error")).Times(1)
+			},
+			expectedFilms:    map[int][]*model.Film{1: nil, 2: nil},
+			expectedErrorMsg: "error",
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockFilmService := mock.NewMockFilmService(ctrl)
+			loaders := graph.NewLoaders(mock.NewMockPlanetService(ctrl), mockFilmService, mock.NewMockTranslationService(ctrl))
+
+			cs.mocking(mockFilmService)
+
+			// when
+			var mutex sync.Mutex
+			var wg sync.WaitGroup
+			films := map[int][]*model.Film{}
+			errs := []error{}
+			for _, planetID := range []int{1, 2} {
+				wg.Add(1)
+				go func(planetID int) {
+					defer wg.Done()
+					res, err := loaders.LoadFilms(context.Background(), planetID)
+
+					mutex.Lock()
+					defer mutex.Unlock()
+					films[planetID] = res
+					if err != nil {
+						errs = append(errs, err)
+					}
+				}(planetID)
+			}
+			wg.Wait()
+
+			// then
+			assert.Equal(t, cs.expectedFilms, films)
+			if cs.expectedErrorMsg != "" {
+				assert.Len(t, errs, 2)
+				assert.EqualError(t, errs[0], cs.expectedErrorMsg)
+			} else {
+				assert.Empty(t, errs)
+			}
+		})
+	}
+}
+
+func Test_Loaders_LoadPlanets(t *testing.T) {
+	// given
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPlanetService := mock.NewMockPlanetService(ctrl)
+	loaders := graph.NewLoaders(mockPlanetService, mock.NewMockFilmService(ctrl), mock.NewMockTranslationService(ctrl))
+
+	mockPlanetService.EXPECT().FindPlanetsByFilmIDs(gomock.Any(), []int{1}).
+		Return(map[int][]*model.Planet{1: {{ID: 1, Name: "Tatooine"}}}, nil)
+
+	// when
+	planets, err := loaders.LoadPlanets(context.Background(), 1)
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.Planet{{ID: 1, Name: "Tatooine"}}, planets)
+}
+
+func Test_Loaders_LoadPlanetsTranslated(t *testing.T) {
+	// given
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPlanetService := mock.NewMockPlanetService(ctrl)
+	mockTranslationService := mock.NewMockTranslationService(ctrl)
+	loaders := graph.NewLoaders(mockPlanetService, mock.NewMockFilmService(ctrl), mockTranslationService)
+	ctx := i18n.WithLocale(context.Background(), i18n.LOCALE_PT_BR)
+
+	mockPlanetService.EXPECT().FindPlanetsByFilmIDs(gomock.Any(), []int{1}).
+		Return(map[int][]*model.Planet{1: {{ID: 1, Name: "Tatooine"}}}, nil)
+	mockTranslationService.EXPECT().TranslatePlanets(gomock.Any(), i18n.LOCALE_PT_BR, []*model.Planet{{ID: 1, Name: "Tatooine"}}).
+		DoAndReturn(func(ctx context.Context, locale string, planets []*model.Planet) error {
+			planets[0].Name = "Tatuíne"
+			return nil
+		})
+
+	// when
+	planets, err := loaders.LoadPlanets(ctx, 1)
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.Planet{{ID: 1, Name: "Tatuíne"}}, planets)
+}
+
+func Test_ParseKeys(t *testing.T) {
+	// when
+	ids := graph.ParseKeys(dataloader.NewKeysFromStrings([]string{"1", "20", "x"}))
+
+	// then
+	assert.Equal(t, []int{1, 20, 0}, ids)
+}
@@ -0,0 +1,391 @@
+package graph
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/exception"
+	"github.com/viniosilva/starwars-api/internal/i18n"
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/viniosilva/starwars-api/internal/service"
+)
+
+//go:embed schema.graphql
+var schema string
+
+// MAX_DEPTH allows a planet, its films and their planets, but not deeper
+// cycles, and MAX_PAGE_SIZE bounds each page, so a single query cannot fan
+// out to huge loader batches
+const (
+	MAX_DEPTH     = 5
+	MAX_PAGE_SIZE = 100
+)
+
+var errInternal = errors.New("internal server error")
+
+type Resolver struct {
+	PlanetService      service.PlanetService
+	FilmService        service.FilmService
+	LookupService      service.LookupService
+	TranslationService service.TranslationService
+}
+
+func NewSchema(planetService service.PlanetService, filmService service.FilmService, lookupService service.LookupService, translationService service.TranslationService) *graphql.Schema {
+	return graphql.MustParseSchema(schema, &Resolver{
+		PlanetService:      planetService,
+		FilmService:        filmService,
+		LookupService:      lookupService,
+		TranslationService: translationService,
+	}, graphql.MaxDepth(MAX_DEPTH))
+}
+
+type PlanetsArgs struct {
+	Page              int32
+	Size              int32
+	Name              *string
+	Climate           *string
+	Terrain           *string
+	RotationPeriodMin *float64
+	RotationPeriodMax *float64
+	OrbitalPeriodMin  *float64
+	OrbitalPeriodMax  *float64
+	DiameterMin       *float64
+	DiameterMax       *float64
+	SurfaceWaterMin   *float64
+	SurfaceWaterMax   *float64
+	PopulationMin     *float64
+	PopulationMax     *float64
+}
+
+// RangeBounds maps the given <attribute>Min and <attribute>Max arguments to
+// their values, named like the range filters of the REST planet list
+func (impl PlanetsArgs) RangeBounds() map[string]float64 {
+	args := map[string]*float64{
+		"rotationPeriodMin": impl.RotationPeriodMin,
+		"rotationPeriodMax": impl.RotationPeriodMax,
+		"orbitalPeriodMin":  impl.OrbitalPeriodMin,
+		"orbitalPeriodMax":  impl.OrbitalPeriodMax,
+		"diameterMin":       impl.DiameterMin,
+		"diameterMax":       impl.DiameterMax,
+		"surfaceWaterMin":   impl.SurfaceWaterMin,
+		"surfaceWaterMax":   impl.SurfaceWaterMax,
+		"populationMin":     impl.PopulationMin,
+		"populationMax":     impl.PopulationMax,
+	}
+
+	bounds := map[string]float64{}
+	for name, value := range args {
+		if value != nil {
+			bounds[name] = *value
+		}
+	}
+
+	return bounds
+}
+
+type FilmsArgs struct {
+	Page     int32
+	Size     int32
+	Title    *string
+	Director *string
+	Episode  *int32
+}
+
+type IDArgs struct {
+	ID int32
+}
+
+func (impl *Resolver) Planets(ctx context.Context, args PlanetsArgs) (*PlanetPageResolver, error) {
+	page, size := ParsePagination(args.Page, args.Size)
+
+	opts := []service.Option{}
+	if args.Name != nil && *args.Name != "" {
+		opts = append(opts, service.OptionWhere("name like ?", *args.Name))
+	}
+	if args.Climate != nil && *args.Climate != "" {
+		opts = append(opts, service.OptionWhereClimate(*args.Climate))
+	}
+	if args.Terrain != nil && *args.Terrain != "" {
+		opts = append(opts, service.OptionWhereTerrain(*args.Terrain))
+	}
+	opts = append(opts, service.OptionsWhereRange(args.RangeBounds())...)
+
+	res, err := impl.PlanetService.FindPlanetsAndTotal(ctx, page, size, false, opts...)
+	if err != nil {
+		return nil, errInternal
+	}
+	if err := TranslatePlanets(ctx, impl.TranslationService, res.Data); err != nil {
+		return nil, errInternal
+	}
+
+	data := make([]*PlanetResolver, len(res.Data))
+	for i, p := range res.Data {
+		data[i] = &PlanetResolver{Planet: p}
+	}
+
+	return &PlanetPageResolver{
+		pagination: &PaginationResolver{count: res.Count, total: res.Total, next: res.Next},
+		data:       data,
+	}, nil
+}
+
+func (impl *Resolver) Planet(ctx context.Context, args IDArgs) (*PlanetResolver, error) {
+	planet, err := impl.PlanetService.FindPlanetByID(ctx, int(args.ID), false)
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); ok {
+			return nil, nil
+		}
+		return nil, errInternal
+	}
+	if err := TranslatePlanets(ctx, impl.TranslationService, []*model.Planet{planet}); err != nil {
+		return nil, errInternal
+	}
+
+	return &PlanetResolver{Planet: planet}, nil
+}
+
+func (impl *Resolver) Films(ctx context.Context, args FilmsArgs) (*FilmPageResolver, error) {
+	page, size := ParsePagination(args.Page, args.Size)
+
+	opts := []service.Option{}
+	if args.Title != nil && *args.Title != "" {
+		opts = append(opts, service.OptionWhere("title like ?", *args.Title))
+	}
+	if args.Director != nil && *args.Director != "" {
+		opts = append(opts, service.OptionWhere("director like ?", *args.Director))
+	}
+	if args.Episode != nil {
+		opts = append(opts, service.OptionWhere("episode = ?", *args.Episode))
+	}
+
+	res, err := impl.FilmService.FindFilmsAndTotal(ctx, page, size, opts...)
+	if err != nil {
+		return nil, errInternal
+	}
+	if err := TranslateFilms(ctx, impl.TranslationService, res.Data); err != nil {
+		return nil, errInternal
+	}
+
+	data := make([]*FilmResolver, len(res.Data))
+	for i, f := range res.Data {
+		data[i] = &FilmResolver{Film: f}
+	}
+
+	return &FilmPageResolver{
+		pagination: &PaginationResolver{count: res.Count, total: res.Total, next: res.Next},
+		data:       data,
+	}, nil
+}
+
+func (impl *Resolver) Film(ctx context.Context, args IDArgs) (*FilmResolver, error) {
+	film, err := impl.FilmService.FindFilmByID(ctx, int(args.ID))
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); ok {
+			return nil, nil
+		}
+		return nil, errInternal
+	}
+	if err := TranslateFilms(ctx, impl.TranslationService, []*model.Film{film}); err != nil {
+		return nil, errInternal
+	}
+
+	return &FilmResolver{Film: film}, nil
+}
+
+func (impl *Resolver) Climates(ctx context.Context) ([]*LookupResolver, error) {
+	climates, err := impl.LookupService.FindClimates(ctx)
+	if err != nil {
+		return nil, errInternal
+	}
+
+	return NewLookupResolvers(climates), nil
+}
+
+func (impl *Resolver) Terrains(ctx context.Context) ([]*LookupResolver, error) {
+	terrains, err := impl.LookupService.FindTerrains(ctx)
+	if err != nil {
+		return nil, errInternal
+	}
+
+	return NewLookupResolvers(terrains), nil
+}
+
+type PaginationResolver struct {
+	count int
+	total int64
+	next  bool
+}
+
+func (impl *PaginationResolver) Count() int32  { return int32(impl.count) }
+func (impl *PaginationResolver) Total() int32  { return int32(impl.total) }
+func (impl *PaginationResolver) HasNext() bool { return impl.next }
+
+type PlanetPageResolver struct {
+	pagination *PaginationResolver
+	data       []*PlanetResolver
+}
+
+func (impl *PlanetPageResolver) Pagination() *PaginationResolver { return impl.pagination }
+func (impl *PlanetPageResolver) Data() []*PlanetResolver         { return impl.data }
+
+type FilmPageResolver struct {
+	pagination *PaginationResolver
+	data       []*FilmResolver
+}
+
+func (impl *FilmPageResolver) Pagination() *PaginationResolver { return impl.pagination }
+func (impl *FilmPageResolver) Data() []*FilmResolver           { return impl.data }
+
+type PlanetResolver struct {
+	Planet *model.Planet
+}
+
+func (impl *PlanetResolver) ID() int32 { return int32(impl.Planet.ID) }
+func (impl *PlanetResolver) CreatedAt() string {
+	return impl.Planet.CreatedAt.Format("2006-01-02 15:04:05")
+}
+func (impl *PlanetResolver) UpdatedAt() string {
+	return impl.Planet.UpdatedAt.Format("2006-01-02 15:04:05")
+}
+func (impl *PlanetResolver) Name() string       { return impl.Planet.Name }
+func (impl *PlanetResolver) Climates() []string { return ParseStrArrayJSON(impl.Planet.Climates) }
+func (impl *PlanetResolver) Terrains() []string { return ParseStrArrayJSON(impl.Planet.Terrains) }
+func (impl *PlanetResolver) RotationPeriod() *int32 {
+	return NullInt32(impl.Planet.RotationPeriod.Ptr())
+}
+func (impl *PlanetResolver) OrbitalPeriod() *int32  { return NullInt32(impl.Planet.OrbitalPeriod.Ptr()) }
+func (impl *PlanetResolver) Diameter() *int32       { return NullInt32(impl.Planet.Diameter.Ptr()) }
+func (impl *PlanetResolver) Gravity() *string       { return impl.Planet.Gravity.Ptr() }
+func (impl *PlanetResolver) SurfaceWater() *float64 { return impl.Planet.SurfaceWater.Ptr() }
+
+// Population is a Float because populations overflow the 32 bits of Int
+func (impl *PlanetResolver) Population() *float64 {
+	if !impl.Planet.Population.Valid {
+		return nil
+	}
+
+	population := float64(impl.Planet.Population.Int64)
+	return &population
+}
+
+func (impl *PlanetResolver) Films(ctx context.Context) ([]*FilmResolver, error) {
+	films, err := LoadersFromContext(ctx).LoadFilms(ctx, impl.Planet.ID)
+	if err != nil {
+		return nil, errInternal
+	}
+
+	res := make([]*FilmResolver, len(films))
+	for i, f := range films {
+		res[i] = &FilmResolver{Film: f}
+	}
+
+	return res, nil
+}
+
+type FilmResolver struct {
+	Film *model.Film
+}
+
+func (impl *FilmResolver) ID() int32 { return int32(impl.Film.ID) }
+func (impl *FilmResolver) CreatedAt() string {
+	return impl.Film.CreatedAt.Format("2006-01-02 15:04:05")
+}
+func (impl *FilmResolver) UpdatedAt() string {
+	return impl.Film.UpdatedAt.Format("2006-01-02 15:04:05")
+}
+func (impl *FilmResolver) Title() string         { return impl.Film.Title }
+func (impl *FilmResolver) Episode() int32        { return int32(impl.Film.Episode) }
+func (impl *FilmResolver) Director() string      { return impl.Film.Director }
+func (impl *FilmResolver) Producer() *string     { return impl.Film.Producer.Ptr() }
+func (impl *FilmResolver) ReleaseDate() string   { return impl.Film.ReleaseDate.Format("2006-01-02") }
+func (impl *FilmResolver) OpeningCrawl() *string { return impl.Film.OpeningCrawl.Ptr() }
+
+func (impl *FilmResolver) Planets(ctx context.Context) ([]*PlanetResolver, error) {
+	planets, err := LoadersFromContext(ctx).LoadPlanets(ctx, impl.Film.ID)
+	if err != nil {
+		return nil, errInternal
+	}
+
+	res := make([]*PlanetResolver, len(planets))
+	for i, p := range planets {
+		res[i] = &PlanetResolver{Planet: p}
+	}
+
+	return res, nil
+}
+
+type LookupResolver struct {
+	Lookup dto.LookupDto
+}
+
+func NewLookupResolvers(lookups []dto.LookupDto) []*LookupResolver {
+	res := make([]*LookupResolver, len(lookups))
+	for i := 0; i < len(lookups); i += 1 {
+		res[i] = &LookupResolver{Lookup: lookups[i]}
+	}
+
+	return res
+}
+
+func (impl *LookupResolver) ID() int32      { return int32(impl.Lookup.ID) }
+func (impl *LookupResolver) Name() string   { return impl.Lookup.Name }
+func (impl *LookupResolver) Planets() int32 { return int32(impl.Lookup.Planets) }
+
+// TranslatePlanets replaces planet names by their translations when the
+// negotiated locale is not the default one
+func TranslatePlanets(ctx context.Context, translationService service.TranslationService, planets []*model.Planet) error {
+	locale := i18n.LocaleFromContext(ctx)
+	if locale == i18n.DEFAULT_LOCALE {
+		return nil
+	}
+
+	return translationService.TranslatePlanets(ctx, locale, planets)
+}
+
+// TranslateFilms replaces film titles by their translations when the
+// negotiated locale is not the default one
+func TranslateFilms(ctx context.Context, translationService service.TranslationService, films []*model.Film) error {
+	locale := i18n.LocaleFromContext(ctx)
+	if locale == i18n.DEFAULT_LOCALE {
+		return nil
+	}
+
+	return translationService.TranslateFilms(ctx, locale, films)
+}
+
+func NullInt32(value *int) *int32 {
+	if value == nil {
+		return nil
+	}
+
+	res := int32(*value)
+	return &res
+}
+
+// ParsePagination defaults page and size like the REST pagination, limiting
+// size to MAX_PAGE_SIZE
+func ParsePagination(page, size int32) (int, int) {
+	p, s := 1, 10
+	if page > 0 {
+		p = int(page)
+	}
+	if size > 0 {
+		s = int(size)
+	}
+	if s > MAX_PAGE_SIZE {
+		s = MAX_PAGE_SIZE
+	}
+
+	return p, s
+}
+
+func ParseStrArrayJSON(value []byte) []string {
+	values := []string{}
+	json.Unmarshal(value, &values)
+
+	return values
+}
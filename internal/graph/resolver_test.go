@@ -0,0 +1,172 @@
+package graph_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/exception"
+	"github.com/viniosilva/starwars-api/internal/graph"
+	"github.com/viniosilva/starwars-api/internal/i18n"
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/viniosilva/starwars-api/internal/service"
+	"github.com/viniosilva/starwars-api/mock"
+	"github.com/volatiletech/null/v8"
+)
+
+func Test_Schema_Exec(t *testing.T) {
+	var cases = map[string]struct {
+		mocking          func(planetService *mock.MockPlanetService, filmService *mock.MockFilmService, lookupService *mock.MockLookupService, translationService *mock.MockTranslationService)
+		inputLocale      string
+		inputQuery       string
+		expectedResponse string
+	}{
+		"should return planets with their films": {
+			mocking: func(planetService *mock.MockPlanetService, filmService *mock.MockFilmService, lookupService *mock.MockLookupService, translationService *mock.MockTranslationService) {
+				planetService.EXPECT().FindPlanetsAndTotal(gomock.Any(), 2, 2, false).
+					Return(dto.FindPlanetsAndTotalResult{Count: 1, Total: 3, Data: []*model.Planet{
+						{ID: 1, Name: "Tatooine", Climates: []byte(`["arid"]`), Terrains: []byte(`["desert"]`)},
+					}}, nil)
+				filmService.EXPECT().FindFilmsByPlanetIDs(gomock.Any(), []int{1}).
+					Return(map[int][]*model.Film{1: {{ID: 1, Title: "A New Hope"}}}, nil)
+			},
+			inputQuery:       `{ planets(page: 2, size: 2) { pagination { count total hasNext } data { name climates terrains films { title } } } }`,
+			expectedResponse: `{"data":{"planets":{"pagination":{"count":1,"total":3,"hasNext":false},"data":[{"name":"Tatooine","climates":["arid"],"terrains":["desert"],"films":[{"title":"A New Hope"}]}]}}}`,
+		},
+		"should return planet attributes filtered by range": {
+			mocking: func(planetService *mock.MockPlanetService, filmService *mock.MockFilmService, lookupService *mock.MockLookupService, translationService *mock.MockTranslationService) {
+				planetService.EXPECT().FindPlanetsAndTotal(gomock.Any(), 1, 10, false,
+					service.OptionWhere("diameter >= ?", float64(10000)),
+					service.OptionWhere("population <= ?", float64(1e10)),
+				).Return(dto.FindPlanetsAndTotalResult{Count: 1, Total: 1, Data: []*model.Planet{
+					{ID: 1, Name: "Tatooine", Diameter: null.IntFrom(10465), Gravity: null.StringFrom("1 standard"), SurfaceWater: null.Float64From(1), Population: null.Int64From(200000)},
+				}}, nil)
+			},
+			inputQuery:       `{ planets(diameterMin: 10000, populationMax: 1e10) { data { name rotationPeriod diameter gravity surfaceWater population } } }`,
+			expectedResponse: `{"data":{"planets":{"data":[{"name":"Tatooine","rotationPeriod":null,"diameter":10465,"gravity":"1 standard","surfaceWater":1,"population":200000}]}}}`,
+		},
+		"should translate planets and their films": {
+			mocking: func(planetService *mock.MockPlanetService, filmService *mock.MockFilmService, lookupService *mock.MockLookupService, translationService *mock.MockTranslationService) {
+				planets := []*model.Planet{{ID: 1, Name: "Tatooine"}}
+				films := []*model.Film{{ID: 1, Title: "A New Hope"}}
+				planetService.EXPECT().FindPlanetsAndTotal(gomock.Any(), 1, 10, false).
+					Return(dto.FindPlanetsAndTotalResult{Count: 1, Total: 1, Data: planets}, nil)
+				translationService.EXPECT().TranslatePlanets(gomock.Any(), i18n.LOCALE_PT_BR, planets).
+					DoAndReturn(func(ctx context.Context, locale string, planets []*model.Planet) error {
+						planets[0].Name = "Tatuíne"
+						return nil
+					})
+				filmService.EXPECT().FindFilmsByPlanetIDs(gomock.Any(), []int{1}).Return(map[int][]*model.Film{1: films}, nil)
+				translationService.EXPECT().TranslateFilms(gomock.Any(), i18n.LOCALE_PT_BR, films).
+					DoAndReturn(func(ctx context.Context, locale string, films []*model.Film) error {
+						films[0].Title = "Uma Nova Esperança"
+						return nil
+					})
+			},
+			inputLocale:      i18n.LOCALE_PT_BR,
+			inputQuery:       `{ planets { data { name films { title } } } }`,
+			expectedResponse: `{"data":{"planets":{"data":[{"name":"Tatuíne","films":[{"title":"Uma Nova Esperança"}]}]}}}`,
+		},
+		"should hide translation errors": {
+			mocking: func(planetService *mock.MockPlanetService, filmService *mock.MockFilmService, lookupService *mock.MockLookupService, translationService *mock.MockTranslationService) {
+				film := &model.Film{ID: 1, Title: "A New Hope"}
+				filmService.EXPECT().FindFilmByID(gomock.Any(), 1).Return(film, nil)
+				translationService.EXPECT().TranslateFilms(gomock.Any(), i18n.LOCALE_PT_BR, []*model.Film{film}).Return(fmt.Errorf("error"))
+			},
+			inputLocale:      i18n.LOCALE_PT_BR,
+			inputQuery:       `{ film(id: 1) { title } }`,
+			expectedResponse: `{"errors":[{"message":"internal server error","path":["film"]}],"data":{"film":null}}`,
+		},
+		"should return climates and terrains": {
+			mocking: func(planetService *mock.MockPlanetService, filmService *mock.MockFilmService, lookupService *mock.MockLookupService, translationService *mock.MockTranslationService) {
+				lookupService.EXPECT().FindClimates(gomock.Any()).Return([]dto.LookupDto{{ID: 1, Name: "arid", Planets: 2}}, nil)
+				lookupService.EXPECT().FindTerrains(gomock.Any()).Return([]dto.LookupDto{{ID: 2, Name: "desert", Planets: 1}}, nil)
+			},
+			inputQuery:       `{ climates { id name planets } terrains { name } }`,
+			expectedResponse: `{"data":{"climates":[{"id":1,"name":"arid","planets":2}],"terrains":[{"name":"desert"}]}}`,
+		},
+		"should limit the page size": {
+			mocking: func(planetService *mock.MockPlanetService, filmService *mock.MockFilmService, lookupService *mock.MockLookupService, translationService *mock.MockTranslationService) {
+				planetService.EXPECT().FindPlanetsAndTotal(gomock.Any(), 1, graph.MAX_PAGE_SIZE, false).
+					Return(dto.FindPlanetsAndTotalResult{Data: []*model.Planet{}}, nil)
+			},
+			inputQuery:       `{ planets(size: 100000) { data { name } } }`,
+			expectedResponse: `{"data":{"planets":{"data":[]}}}`,
+		},
+		"should reject queries deeper than the limit": {
+			mocking: func(planetService *mock.MockPlanetService, filmService *mock.MockFilmService, lookupService *mock.MockLookupService, translationService *mock.MockTranslationService) {
+			},
+			inputQuery:       `{ planets { data { films { planets { films { title } } } } } }`,
+			expectedResponse: `{"errors":[{"message":"Field \"title\" has depth 6 that exceeds max depth 5","locations":[{"line":1,"column":46}]}]}`,
+		},
+		"should return null when film is not found": {
+			mocking: func(planetService *mock.MockPlanetService, filmService *mock.MockFilmService, lookupService *mock.MockLookupService, translationService *mock.MockTranslationService) {
+				filmService.EXPECT().FindFilmByID(gomock.Any(), 99).Return(nil, &exception.NotFoundException{Message: "film 99 not found"})
+			},
+			inputQuery:       `{ film(id: 99) { title } }`,
+			expectedResponse: `{"data":{"film":null}}`,
+		},
+		"should hide service errors": {
+			mocking: func(planetService *mock.MockPlanetService, filmService *mock.MockFilmService, lookupService *mock.MockLookupService, translationService *mock.MockTranslationService) {
+				planetService.EXPECT().FindPlanetByID(gomock.Any(), 1, false).Return(nil, fmt.Errorf("error"))
+			},
+			inputQuery:       `{ planet(id: 1) { name } }`,
+			expectedResponse: `{"errors":[{"message":"internal server error","path":["planet"]}],"data":{"planet":null}}`,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPlanetService := mock.NewMockPlanetService(ctrl)
+			mockFilmService := mock.NewMockFilmService(ctrl)
+			mockLookupService := mock.NewMockLookupService(ctrl)
+			mockTranslationService := mock.NewMockTranslationService(ctrl)
+			schema := graph.NewSchema(mockPlanetService, mockFilmService, mockLookupService, mockTranslationService)
+
+			ctx := context.Background()
+			if cs.inputLocale != "" {
+				ctx = i18n.WithLocale(ctx, cs.inputLocale)
+			}
+			ctx = graph.WithLoaders(ctx, graph.NewLoaders(mockPlanetService, mockFilmService, mockTranslationService))
+
+			cs.mocking(mockPlanetService, mockFilmService, mockLookupService, mockTranslationService)
+
+			// when
+			res := schema.Exec(ctx, cs.inputQuery, "", nil)
+
+			// then
+			body, _ := json.Marshal(res)
+			assert.JSONEq(t, cs.expectedResponse, string(body))
+		})
+	}
+}
+
+func Test_ParsePagination(t *testing.T) {
+	var cases = map[string]struct {
+		inputPage    int32
+		inputSize    int32
+		expectedPage int
+		expectedSize int
+	}{
+		"should keep page and size":    {inputPage: 2, inputSize: 20, expectedPage: 2, expectedSize: 20},
+		"should default page and size": {inputPage: 0, inputSize: -1, expectedPage: 1, expectedSize: 10},
+		"should limit size":            {inputPage: 1, inputSize: 1000, expectedPage: 1, expectedSize: graph.MAX_PAGE_SIZE},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// when
+			page, size := graph.ParsePagination(cs.inputPage, cs.inputSize)
+
+			// then
+			assert.Equal(t, cs.expectedPage, page)
+			assert.Equal(t, cs.expectedSize, size)
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// catalogs holds the translations of the English messages for each locale.
+// Messages with %s or %d verbs also translate the messages formatted from them
+var catalogs = map[string]map[string]string{
+	LOCALE_PT_BR: {
+		"internal server error":              "erro interno do servidor",
+		"unauthorized":                       "não autenticado",
+		"forbidden":                          "acesso negado",
+		"invalid credentials":                "credenciais inválidas",
+		"too many requests":                  "muitas requisições",
+		"invalid body":                       "corpo da requisição inválido",
+		"invalid format":                     "formato inválido",
+		"invalid fields":                     "campos inválidos",
+		"invalid embed":                      "relacionamento inválido",
+		"invalid graphql request":            "requisição graphql inválida",
+		"invalid planet id":                  "id do planeta inválido",
+		"invalid film id":                    "id do filme inválido",
+		"invalid webhook id":                 "id do webhook inválido",
+		"invalid delivery id":                "id da entrega inválido",
+		"invalid entity id":                  "id da entidade inválido",
+		"invalid last event id":              "id do último evento inválido",
+		"invalid locale":                     "idioma inválido",
+		"invalid ids":                        "ids inválidos",
+		"invalid mode":                       "modo inválido",
+		"invalid op %s":                      "operação %s inválida",
+		"operation rolled back":              "operação desfeita",
+		"invalid csv header":                 "cabeçalho do csv inválido",
+		"invalid %s":                         "%s inválido",
+		"invalid type %s":                    "tipo %s inválido",
+		"invalid event %s":                   "evento %s inválido",
+		"invalid event type %s":              "tipo de evento %s inválido",
+		"invalid format %s":                  "formato %s inválido",
+		"invalid csv: %s":                    "csv inválido: %s",
+		"%s is required":                     "%s é obrigatório",
+		"%s must have at most %d items":      "%s deve ter no máximo %d itens",
+		"expected %d columns, got %d":        "esperadas %d colunas, recebidas %d",
+		"planet %d not found":                "planeta %d não encontrado",
+		"deleted planet %d not found":        "planeta removido %d não encontrado",
+		"planet %d is deleted":               "planeta %d foi removido",
+		"film %d is not linked to planet %d": "filme %d não está ligado ao planeta %d",
+		"film %d not found":                  "filme %d não encontrado",
+		"webhook %d not found":               "webhook %d não encontrado",
+		"sync is already running":            "a sincronização já está em execução",
+		"dead delivery %d not found":         "entrega morta %d não encontrada",
+	},
+}
+
+type messagePattern struct {
+	regexp      *regexp.Regexp
+	translation string
+}
+
+// patterns are the messages with verbs of each locale, the most specific first
+var patterns = compilePatterns(catalogs)
+
+var verbs = regexp.MustCompile(`%[sd]`)
+
+// T translates message to the negotiated locale of ctx
+func T(ctx context.Context, message string) string {
+	return Translate(LocaleFromContext(ctx), message)
+}
+
+// Translate returns the translation of an English message to locale, or the
+// message itself when there is none
+func Translate(locale, message string) string {
+	catalog, ok := catalogs[locale]
+	if !ok {
+		return message
+	}
+	if translation, ok := catalog[message]; ok && !verbs.MatchString(message) {
+		return translation
+	}
+
+	for _, p := range patterns[locale] {
+		m := p.regexp.FindStringSubmatch(message)
+		if m == nil {
+			continue
+		}
+
+		args := make([]interface{}, len(m)-1)
+		for i := 1; i < len(m); i += 1 {
+			args[i-1] = m[i]
+		}
+		return fmt.Sprintf(verbs.ReplaceAllString(p.translation, "%s"), args...)
+	}
+
+	return message
+}
+
+func compilePatterns(catalogs map[string]map[string]string) map[string][]messagePattern {
+	res := map[string][]messagePattern{}
+	for locale, catalog := range catalogs {
+		messages := []string{}
+		for message := range catalog {
+			if verbs.MatchString(message) {
+				messages = append(messages, message)
+			}
+		}
+		sort.Slice(messages, func(i, j int) bool {
+			li, lj := len(verbs.ReplaceAllString(messages[i], "")), len(verbs.ReplaceAllString(messages[j], ""))
+			if li != lj {
+				return li > lj
+			}
+			return messages[i] < messages[j]
+		})
+
+		for _, message := range messages {
+			parts := verbs.Split(message, -1)
+			found := verbs.FindAllString(message, -1)
+
+			expr := "^"
+			for i, part := range parts {
+				expr += regexp.QuoteMeta(part)
+				if i < len(found) {
+					if found[i] == "%d" {
+						expr += `(-?\d+)`
+					} else {
+						expr += `(.+)`
+					}
+				}
+			}
+
+			res[locale] = append(res[locale], messagePattern{
+				regexp:      regexp.MustCompile(expr + "$"),
+				translation: catalog[message],
+			})
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,73 @@
+package i18n_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/i18n"
+)
+
+func Test_Translate(t *testing.T) {
+	var cases = map[string]struct {
+		inputLocale     string
+		inputMessage    string
+		expectedMessage string
+	}{
+		"should translate message": {
+			inputLocale:     i18n.LOCALE_PT_BR,
+			inputMessage:    "internal server error",
+			expectedMessage: "erro interno do servidor",
+		},
+		"should translate formatted message": {
+			inputLocale:     i18n.LOCALE_PT_BR,
+			inputMessage:    "planet 42 not found",
+			expectedMessage: "planeta 42 não encontrado",
+		},
+		"should translate formatted message with many verbs": {
+			inputLocale:     i18n.LOCALE_PT_BR,
+			inputMessage:    "expected 3 columns, got 2",
+			expectedMessage: "esperadas 3 colunas, recebidas 2",
+		},
+		"should translate the most specific formatted message": {
+			inputLocale:     i18n.LOCALE_PT_BR,
+			inputMessage:    "invalid type vehicle",
+			expectedMessage: "tipo vehicle inválido",
+		},
+		"should translate generic formatted message": {
+			inputLocale:     i18n.LOCALE_PT_BR,
+			inputMessage:    "invalid asOf",
+			expectedMessage: "asOf inválido",
+		},
+		"should keep message without translation": {
+			inputLocale:     i18n.LOCALE_PT_BR,
+			inputMessage:    "something else",
+			expectedMessage: "something else",
+		},
+		"should keep message of default locale": {
+			inputLocale:     i18n.LOCALE_EN,
+			inputMessage:    "internal server error",
+			expectedMessage: "internal server error",
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// when
+			message := i18n.Translate(cs.inputLocale, cs.inputMessage)
+
+			// then
+			assert.Equal(t, cs.expectedMessage, message)
+		})
+	}
+}
+
+func Test_T(t *testing.T) {
+	// given
+	ctx := i18n.WithLocale(context.Background(), i18n.LOCALE_PT_BR)
+
+	// when
+	message := i18n.T(ctx, "name is required")
+
+	// then
+	assert.Equal(t, "name é obrigatório", message)
+}
@@ -0,0 +1,78 @@
+package i18n
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/text/language"
+)
+
+const (
+	LOCALE_KEY     = "locale"
+	LOCALE_EN      = "en"
+	LOCALE_PT_BR   = "pt-BR"
+	DEFAULT_LOCALE = LOCALE_EN
+)
+
+// Locales are the supported locales, the first one being the default
+var Locales = []string{LOCALE_EN, LOCALE_PT_BR}
+
+var matcher = language.NewMatcher([]language.Tag{language.English, language.BrazilianPortuguese})
+
+type localeKey struct{}
+
+// GinLocale negotiates the locale of the Accept-Language header, keeping it in
+// the context and in the Content-Language response header
+func GinLocale() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		locale := Negotiate(ctx.GetHeader("Accept-Language"))
+		ctx.Set(LOCALE_KEY, locale)
+		ctx.Header("Content-Language", locale)
+
+		ctx.Next()
+	}
+}
+
+// Negotiate returns the supported locale best matching an Accept-Language
+// header, or DEFAULT_LOCALE when none matches
+func Negotiate(acceptLanguage string) string {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return DEFAULT_LOCALE
+	}
+
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return DEFAULT_LOCALE
+	}
+
+	return Locales[index]
+}
+
+// IsLocale reports whether locale is one of the supported locales
+func IsLocale(locale string) bool {
+	for _, l := range Locales {
+		if l == locale {
+			return true
+		}
+	}
+
+	return false
+}
+
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// LocaleFromContext returns the negotiated locale of a request context, or
+// DEFAULT_LOCALE when there is none
+func LocaleFromContext(ctx context.Context) string {
+	if locale, ok := ctx.Value(localeKey{}).(string); ok {
+		return locale
+	}
+	if locale, ok := ctx.Value(LOCALE_KEY).(string); ok {
+		return locale
+	}
+
+	return DEFAULT_LOCALE
+}
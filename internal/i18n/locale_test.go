@@ -0,0 +1,101 @@
+package i18n_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/i18n"
+)
+
+func Test_Negotiate(t *testing.T) {
+	var cases = map[string]struct {
+		inputAcceptLanguage string
+		expectedLocale      string
+	}{
+		"should negotiate default locale when header is empty": {
+			inputAcceptLanguage: "",
+			expectedLocale:      i18n.LOCALE_EN,
+		},
+		"should negotiate brazilian portuguese": {
+			inputAcceptLanguage: "pt-BR,pt;q=0.9,en;q=0.8",
+			expectedLocale:      i18n.LOCALE_PT_BR,
+		},
+		"should negotiate brazilian portuguese from portuguese": {
+			inputAcceptLanguage: "pt",
+			expectedLocale:      i18n.LOCALE_PT_BR,
+		},
+		"should negotiate by quality": {
+			inputAcceptLanguage: "pt-BR;q=0.5,en-US;q=0.9",
+			expectedLocale:      i18n.LOCALE_EN,
+		},
+		"should negotiate default locale when none matches": {
+			inputAcceptLanguage: "ja",
+			expectedLocale:      i18n.LOCALE_EN,
+		},
+		"should negotiate default locale when header is invalid": {
+			inputAcceptLanguage: "@@@",
+			expectedLocale:      i18n.LOCALE_EN,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// when
+			locale := i18n.Negotiate(cs.inputAcceptLanguage)
+
+			// then
+			assert.Equal(t, cs.expectedLocale, locale)
+		})
+	}
+}
+
+func Test_GinLocale(t *testing.T) {
+	// given
+	gin.SetMode(gin.TestMode)
+	res := httptest.NewRecorder()
+	_, r := gin.CreateTestContext(res)
+	r.Use(i18n.GinLocale())
+
+	var locale string
+	r.GET("/", func(ctx *gin.Context) {
+		locale = i18n.LocaleFromContext(ctx)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Language", "pt-BR")
+
+	// when
+	r.ServeHTTP(res, req)
+
+	// then
+	assert.Equal(t, i18n.LOCALE_PT_BR, locale)
+	assert.Equal(t, i18n.LOCALE_PT_BR, res.Header().Get("Content-Language"))
+}
+
+func Test_LocaleFromContext(t *testing.T) {
+	var cases = map[string]struct {
+		inputCtx       context.Context
+		expectedLocale string
+	}{
+		"should return locale of context": {
+			inputCtx:       i18n.WithLocale(context.Background(), i18n.LOCALE_PT_BR),
+			expectedLocale: i18n.LOCALE_PT_BR,
+		},
+		"should return default locale when context has none": {
+			inputCtx:       context.Background(),
+			expectedLocale: i18n.DEFAULT_LOCALE,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// when
+			locale := i18n.LocaleFromContext(cs.inputCtx)
+
+			// then
+			assert.Equal(t, cs.expectedLocale, locale)
+		})
+	}
+}
@@ -0,0 +1,363 @@
+package importer
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/exception"
+	"github.com/viniosilva/starwars-api/internal/export"
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/volatiletech/null/v8"
+)
+
+// ReadPlanets reads planets in the same layout written by the export,
+// returning the valid ones and an error for each invalid row
+func ReadPlanets(format export.Format, r io.Reader) ([]*model.Planet, []dto.ImportRowError, error) {
+	planets := []*model.Planet{}
+
+	rowErrors, err := readRows(format, r, func(csvRow map[string]string, jsonRow []byte) error {
+		record := &export.PlanetRecord{}
+		if csvRow != nil {
+			if err := ParsePlanetCsv(csvRow, record); err != nil {
+				return err
+			}
+		} else if err := json.Unmarshal(jsonRow, record); err != nil {
+			return fmt.Errorf("invalid json")
+		}
+
+		planet, err := ParsePlanetModel(record)
+		if err != nil {
+			return err
+		}
+
+		planets = append(planets, planet)
+		return nil
+	})
+
+	return planets, rowErrors, err
+}
+
+// ReadFilms reads films in the same layout written by the export,
+// returning the valid ones and an error for each invalid row
+func ReadFilms(format export.Format, r io.Reader) ([]*model.Film, []dto.ImportRowError, error) {
+	films := []*model.Film{}
+
+	rowErrors, err := readRows(format, r, func(csvRow map[string]string, jsonRow []byte) error {
+		record := &export.FilmRecord{}
+		if csvRow != nil {
+			if err := ParseFilmCsv(csvRow, record); err != nil {
+				return err
+			}
+		} else if err := json.Unmarshal(jsonRow, record); err != nil {
+			return fmt.Errorf("invalid json")
+		}
+
+		film, err := ParseFilmModel(record)
+		if err != nil {
+			return err
+		}
+
+		films = append(films, film)
+		return nil
+	})
+
+	return films, rowErrors, err
+}
+
+func ParsePlanetCsv(row map[string]string, record *export.PlanetRecord) error {
+	id, err := strconv.Atoi(row["id"])
+	if err != nil {
+		return fmt.Errorf("invalid id")
+	}
+
+	record.ID = int32(id)
+	record.CreatedAt = row["created_at"]
+	record.UpdatedAt = row["updated_at"]
+	record.Name = row["name"]
+	record.Climates = splitList(row["climates"])
+	record.Terrains = splitList(row["terrains"])
+
+	if record.RotationPeriod, err = parseOptionalInt32(row, "rotation_period"); err != nil {
+		return err
+	}
+	if record.OrbitalPeriod, err = parseOptionalInt32(row, "orbital_period"); err != nil {
+		return err
+	}
+	if record.Diameter, err = parseOptionalInt32(row, "diameter"); err != nil {
+		return err
+	}
+	if record.SurfaceWater, err = parseOptionalFloat64(row, "surface_water"); err != nil {
+		return err
+	}
+	if record.Population, err = parseOptionalInt64(row, "population"); err != nil {
+		return err
+	}
+	record.Gravity = parseOptionalString(row, "gravity")
+
+	return nil
+}
+
+func ParseFilmCsv(row map[string]string, record *export.FilmRecord) error {
+	id, err := strconv.Atoi(row["id"])
+	if err != nil {
+		return fmt.Errorf("invalid id")
+	}
+	episode, err := strconv.Atoi(row["episode"])
+	if err != nil {
+		return fmt.Errorf("invalid episode")
+	}
+
+	record.ID = int32(id)
+	record.CreatedAt = row["created_at"]
+	record.UpdatedAt = row["updated_at"]
+	record.Title = row["title"]
+	record.Episode = int32(episode)
+	record.Director = row["director"]
+	record.ReleaseDate = row["release_date"]
+	record.Producer = parseOptionalString(row, "producer")
+	record.OpeningCrawl = parseOptionalString(row, "opening_crawl")
+
+	return nil
+}
+
+func ParsePlanetModel(record *export.PlanetRecord) (*model.Planet, error) {
+	if record.ID < 1 {
+		return nil, fmt.Errorf("invalid id")
+	}
+	if strings.TrimSpace(record.Name) == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+
+	createdAt, updatedAt, err := parseTimestamps(record.CreatedAt, record.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	climates := record.Climates
+	if climates == nil {
+		climates = []string{}
+	}
+	terrains := record.Terrains
+	if terrains == nil {
+		terrains = []string{}
+	}
+	climatesJSON, _ := json.Marshal(climates)
+	terrainsJSON, _ := json.Marshal(terrains)
+
+	return &model.Planet{
+		ID:             int(record.ID),
+		CreatedAt:      createdAt,
+		UpdatedAt:      updatedAt,
+		Name:           strings.TrimSpace(record.Name),
+		Climates:       climatesJSON,
+		Terrains:       terrainsJSON,
+		RotationPeriod: nullInt(record.RotationPeriod),
+		OrbitalPeriod:  nullInt(record.OrbitalPeriod),
+		Diameter:       nullInt(record.Diameter),
+		Gravity:        null.StringFromPtr(record.Gravity),
+		SurfaceWater:   null.Float64FromPtr(record.SurfaceWater),
+		Population:     null.Int64FromPtr(record.Population),
+	}, nil
+}
+
+func ParseFilmModel(record *export.FilmRecord) (*model.Film, error) {
+	if record.ID < 1 {
+		return nil, fmt.Errorf("invalid id")
+	}
+	if strings.TrimSpace(record.Title) == "" {
+		return nil, fmt.Errorf("title is required")
+	}
+	if record.Episode < 1 || record.Episode > 127 {
+		return nil, fmt.Errorf("invalid episode")
+	}
+	if strings.TrimSpace(record.Director) == "" {
+		return nil, fmt.Errorf("director is required")
+	}
+
+	releaseDate, err := time.Parse("2006-01-02", record.ReleaseDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid release_date")
+	}
+
+	createdAt, updatedAt, err := parseTimestamps(record.CreatedAt, record.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Film{
+		ID:           int(record.ID),
+		CreatedAt:    createdAt,
+		UpdatedAt:    updatedAt,
+		Title:        strings.TrimSpace(record.Title),
+		Episode:      int8(record.Episode),
+		Director:     strings.TrimSpace(record.Director),
+		ReleaseDate:  releaseDate,
+		Producer:     null.StringFromPtr(record.Producer),
+		OpeningCrawl: null.StringFromPtr(record.OpeningCrawl),
+	}, nil
+}
+
+// readRows calls fn for every data row, passing the csv columns by header
+// name or the raw ndjson line, and collects its errors by line number
+func readRows(format export.Format, r io.Reader, fn func(csvRow map[string]string, jsonRow []byte) error) ([]dto.ImportRowError, error) {
+	rowErrors := []dto.ImportRowError{}
+	addRowError := func(line int, err error) {
+		rowErrors = append(rowErrors, dto.ImportRowError{Line: line, Error: err.Error()})
+	}
+
+	switch format {
+	case export.FormatCSV:
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+
+		header, err := reader.Read()
+		if err != nil {
+			return nil, &exception.ValidationException{Message: "invalid csv header"}
+		}
+
+		for {
+			values, err := reader.Read()
+			if err == io.EOF {
+				return rowErrors, nil
+			}
+			if err != nil {
+				return nil, &exception.ValidationException{Message: fmt.Sprintf("invalid csv: %s", err)}
+			}
+
+			line, _ := reader.FieldPos(0)
+			if len(values) != len(header) {
+				addRowError(line, fmt.Errorf("expected %d columns, got %d", len(header), len(values)))
+				continue
+			}
+
+			row := map[string]string{}
+			for i, h := range header {
+				row[strings.TrimSpace(h)] = values[i]
+			}
+			if err := fn(row, nil); err != nil {
+				addRowError(line, err)
+			}
+		}
+	case export.FormatNDJSON:
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+		for line := 1; scanner.Scan(); line += 1 {
+			if strings.TrimSpace(scanner.Text()) == "" {
+				continue
+			}
+			if err := fn(nil, scanner.Bytes()); err != nil {
+				addRowError(line, err)
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return rowErrors, nil
+	}
+
+	return nil, &exception.ValidationException{Message: fmt.Sprintf("invalid format %s", format)}
+}
+
+func parseTimestamps(createdAt, updatedAt string) (time.Time, time.Time, error) {
+	now := time.Now().UTC().Truncate(time.Second)
+
+	created := now
+	if createdAt != "" {
+		t, err := time.Parse("2006-01-02 15:04:05", createdAt)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid created_at")
+		}
+		created = t
+	}
+
+	updated := created
+	if updatedAt != "" {
+		t, err := time.Parse("2006-01-02 15:04:05", updatedAt)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid updated_at")
+		}
+		updated = t
+	}
+
+	return created, updated, nil
+}
+
+func splitList(value string) []string {
+	res := []string{}
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			res = append(res, v)
+		}
+	}
+
+	return res
+}
+
+func parseOptionalString(row map[string]string, key string) *string {
+	value := strings.TrimSpace(row[key])
+	if value == "" {
+		return nil
+	}
+
+	return &value
+}
+
+func parseOptionalInt32(row map[string]string, key string) (*int32, error) {
+	value := parseOptionalString(row, key)
+	if value == nil {
+		return nil, nil
+	}
+
+	n, err := strconv.ParseInt(*value, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s", key)
+	}
+
+	res := int32(n)
+	return &res, nil
+}
+
+func parseOptionalInt64(row map[string]string, key string) (*int64, error) {
+	value := parseOptionalString(row, key)
+	if value == nil {
+		return nil, nil
+	}
+
+	n, err := strconv.ParseInt(*value, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s", key)
+	}
+
+	return &n, nil
+}
+
+func parseOptionalFloat64(row map[string]string, key string) (*float64, error) {
+	value := parseOptionalString(row, key)
+	if value == nil {
+		return nil, nil
+	}
+
+	n, err := strconv.ParseFloat(*value, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s", key)
+	}
+
+	return &n, nil
+}
+
+func nullInt(value *int32) null.Int {
+	if value == nil {
+		return null.Int{}
+	}
+
+	return null.IntFrom(int(*value))
+}
@@ -0,0 +1,167 @@
+package importer_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/exception"
+	"github.com/viniosilva/starwars-api/internal/export"
+	"github.com/viniosilva/starwars-api/internal/importer"
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/volatiletech/null/v8"
+)
+
+func Test_Importer_ReadPlanets(t *testing.T) {
+	createdAt := time.Date(2014, 12, 9, 13, 50, 49, 0, time.UTC)
+	updatedAt := time.Date(2014, 12, 20, 20, 58, 18, 0, time.UTC)
+
+	var cases = map[string]struct {
+		inputFormat       export.Format
+		inputBody         string
+		expectedPlanets   []*model.Planet
+		expectedRowErrors []dto.ImportRowError
+		expectedErr       error
+	}{
+		"should read csv planets": {
+			inputFormat: export.FormatCSV,
+			inputBody: "id,created_at,updated_at,name,climates,terrains\n" +
+				"1,2014-12-09 13:50:49,2014-12-20 20:58:18,Tatooine,arid,\"desert, dunes\"\n",
+			expectedPlanets: []*model.Planet{{
+				ID: 1, CreatedAt: createdAt, UpdatedAt: updatedAt, Name: "Tatooine",
+				Climates: []byte(`["arid"]`), Terrains: []byte(`["desert","dunes"]`),
+			}},
+			expectedRowErrors: []dto.ImportRowError{},
+		},
+		"should read csv planets with attributes": {
+			inputFormat: export.FormatCSV,
+			inputBody: "id,created_at,updated_at,name,climates,terrains,rotation_period,orbital_period,diameter,gravity,surface_water,population\n" +
+				"2,2014-12-09 13:50:49,2014-12-20 20:58:18,Alderaan,temperate,mountains,24,364,12500,1 standard,40,2000000000\n" +
+				"3,2014-12-09 13:50:49,2014-12-20 20:58:18,Yavin IV,temperate,jungle,,,,,,\n",
+			expectedPlanets: []*model.Planet{
+				{
+					ID: 2, CreatedAt: createdAt, UpdatedAt: updatedAt, Name: "Alderaan",
+					Climates: []byte(`["temperate"]`), Terrains: []byte(`["mountains"]`),
+					RotationPeriod: null.IntFrom(24), OrbitalPeriod: null.IntFrom(364), Diameter: null.IntFrom(12500),
+					Gravity: null.StringFrom("1 standard"), SurfaceWater: null.Float64From(40), Population: null.Int64From(2000000000),
+				},
+				{
+					ID: 3, CreatedAt: createdAt, UpdatedAt: updatedAt, Name: "Yavin IV",
+					Climates: []byte(`["temperate"]`), Terrains: []byte(`["jungle"]`),
+				},
+			},
+			expectedRowErrors: []dto.ImportRowError{},
+		},
+		"should read ndjson planets": {
+			inputFormat: export.FormatNDJSON,
+			inputBody: `{"id":1,"created_at":"2014-12-09 13:50:49","updated_at":"2014-12-20 20:58:18","name":"Tatooine","climates":["arid"]}` + "\n\n" +
+				`{"id":2,"created_at":"2014-12-09 13:50:49","name":"Alderaan","climates":["temperate"],"terrains":["mountains"]}` + "\n",
+			expectedPlanets: []*model.Planet{
+				{ID: 1, CreatedAt: createdAt, UpdatedAt: updatedAt, Name: "Tatooine", Climates: []byte(`["arid"]`), Terrains: []byte(`[]`)},
+				{ID: 2, CreatedAt: createdAt, UpdatedAt: createdAt, Name: "Alderaan", Climates: []byte(`["temperate"]`), Terrains: []byte(`["mountains"]`)},
+			},
+			expectedRowErrors: []dto.ImportRowError{},
+		},
+		"should report invalid csv rows": {
+			inputFormat: export.FormatCSV,
+			inputBody: "id,created_at,updated_at,name,climates,terrains\n" +
+				"x,,,Tatooine,arid,desert\n" +
+				"2,,,,arid,desert\n" +
+				"3,yesterday,,Hoth,frozen,tundra\n" +
+				"4,Dagobah\n",
+			expectedPlanets: []*model.Planet{},
+			expectedRowErrors: []dto.ImportRowError{
+				{Line: 2, Error: "invalid id"},
+				{Line: 3, Error: "name is required"},
+				{Line: 4, Error: "invalid created_at"},
+				{Line: 5, Error: "expected 6 columns, got 2"},
+			},
+		},
+		"should report invalid csv attributes": {
+			inputFormat: export.FormatCSV,
+			inputBody: "id,name,population\n" +
+				"1,Tatooine,unknown\n",
+			expectedPlanets:   []*model.Planet{},
+			expectedRowErrors: []dto.ImportRowError{{Line: 2, Error: "invalid population"}},
+		},
+		"should report invalid ndjson rows": {
+			inputFormat:       export.FormatNDJSON,
+			inputBody:         `{"id":0,"name":"Tatooine"}` + "\n" + `{"id":`,
+			expectedPlanets:   []*model.Planet{},
+			expectedRowErrors: []dto.ImportRowError{{Line: 1, Error: "invalid id"}, {Line: 2, Error: "invalid json"}},
+		},
+		"should throw validation exception when csv is empty": {
+			inputFormat: export.FormatCSV,
+			inputBody:   "",
+			expectedErr: &exception.ValidationException{Message: "invalid csv header"},
+		},
+		"should throw validation exception when format is invalid": {
+			inputFormat: export.FormatParquet,
+			inputBody:   "",
+			expectedErr: &exception.ValidationException{Message: "invalid format parquet"},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// when
+			planets, rowErrors, err := importer.ReadPlanets(cs.inputFormat, strings.NewReader(cs.inputBody))
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+			if cs.expectedErr == nil {
+				assert.Equal(t, cs.expectedPlanets, planets)
+				assert.Equal(t, cs.expectedRowErrors, rowErrors)
+			}
+		})
+	}
+}
+
+func Test_Importer_ReadFilms(t *testing.T) {
+	createdAt := time.Date(2014, 12, 10, 14, 23, 31, 0, time.UTC)
+	releaseDate := time.Date(1977, 5, 25, 0, 0, 0, 0, time.UTC)
+
+	var cases = map[string]struct {
+		inputFormat       export.Format
+		inputBody         string
+		expectedFilms     []*model.Film
+		expectedRowErrors []dto.ImportRowError
+	}{
+		"should read csv films": {
+			inputFormat: export.FormatCSV,
+			inputBody: "id,created_at,updated_at,title,episode,director,release_date\n" +
+				"1,2014-12-10 14:23:31,,A New Hope,4,George Lucas,1977-05-25\n",
+			expectedFilms: []*model.Film{{
+				ID: 1, CreatedAt: createdAt, UpdatedAt: createdAt, Title: "A New Hope",
+				Episode: 4, Director: "George Lucas", ReleaseDate: releaseDate,
+			}},
+			expectedRowErrors: []dto.ImportRowError{},
+		},
+		"should report invalid film rows": {
+			inputFormat: export.FormatNDJSON,
+			inputBody: `{"id":1,"created_at":"2014-12-10 14:23:31","title":"A New Hope","episode":0,"director":"George Lucas","release_date":"1977-05-25"}` + "\n" +
+				`{"id":2,"created_at":"2014-12-10 14:23:31","title":"","episode":5,"director":"Irvin Kershner","release_date":"1980-05-17"}` + "\n" +
+				`{"id":3,"created_at":"2014-12-10 14:23:31","title":"Return of the Jedi","episode":6,"director":"","release_date":"1983-05-25"}` + "\n" +
+				`{"id":4,"created_at":"2014-12-10 14:23:31","title":"The Phantom Menace","episode":1,"director":"George Lucas","release_date":"1999"}` + "\n",
+			expectedFilms: []*model.Film{},
+			expectedRowErrors: []dto.ImportRowError{
+				{Line: 1, Error: "invalid episode"},
+				{Line: 2, Error: "title is required"},
+				{Line: 3, Error: "director is required"},
+				{Line: 4, Error: "invalid release_date"},
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// when
+			films, rowErrors, err := importer.ReadFilms(cs.inputFormat, strings.NewReader(cs.inputBody))
+
+			// then
+			assert.Nil(t, err)
+			assert.Equal(t, cs.expectedFilms, films)
+			assert.Equal(t, cs.expectedRowErrors, rowErrors)
+		})
+	}
+}
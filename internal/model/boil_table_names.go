@@ -4,13 +4,37 @@
 package model
 
 var TableNames = struct {
-	Films            string
-	Planets          string
-	PlanetsFilms     string
-	SchemaMigrations string
+	AuditLog            string
+	Climates            string
+	FeedCheckpoints     string
+	Films               string
+	FilmsTranslations   string
+	Outbox              string
+	Planets             string
+	PlanetsClimates     string
+	PlanetsFilms        string
+	PlanetsHistory      string
+	PlanetsTerrains     string
+	PlanetsTranslations string
+	SchemaMigrations    string
+	Terrains            string
+	WebhookDeliveries   string
+	Webhooks            string
 }{
-	Films:            "films",
-	Planets:          "planets",
-	PlanetsFilms:     "planets_films",
-	SchemaMigrations: "schema_migrations",
+	AuditLog:            "audit_log",
+	Climates:            "climates",
+	FeedCheckpoints:     "feed_checkpoints",
+	Films:               "films",
+	FilmsTranslations:   "films_translations",
+	Outbox:              "outbox",
+	Planets:             "planets",
+	PlanetsClimates:     "planets_climates",
+	PlanetsFilms:        "planets_films",
+	PlanetsHistory:      "planets_history",
+	PlanetsTerrains:     "planets_terrains",
+	PlanetsTranslations: "planets_translations",
+	SchemaMigrations:    "schema_migrations",
+	Terrains:            "terrains",
+	WebhookDeliveries:   "webhook_deliveries",
+	Webhooks:            "webhooks",
 }
@@ -0,0 +1,1253 @@
+// Code generated by SQLBoiler 4.13.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package model
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// Climate is an object representing the database table.
+type Climate struct {
+	ID   int    `boil:"id" json:"id" toml:"id" yaml:"id"`
+	Name string `boil:"name" json:"name" toml:"name" yaml:"name"`
+
+	R *climateR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L climateL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var ClimateColumns = struct {
+	ID   string
+	Name string
+}{
+	ID:   "id",
+	Name: "name",
+}
+
+var ClimateTableColumns = struct {
+	ID   string
+	Name string
+}{
+	ID:   "climates.id",
+	Name: "climates.name",
+}
+
+// Generated where
+
+var ClimateWhere = struct {
+	ID   whereHelperint
+	Name whereHelperstring
+}{
+	ID:   whereHelperint{field: "`climates`.`id`"},
+	Name: whereHelperstring{field: "`climates`.`name`"},
+}
+
+// ClimateRels is where relationship names are stored.
+var ClimateRels = struct {
+	Planets string
+}{
+	Planets: "Planets",
+}
+
+// climateR is where relationships are stored.
+type climateR struct {
+	Planets PlanetSlice `boil:"Planets" json:"Planets" toml:"Planets" yaml:"Planets"`
+}
+
+// NewStruct creates a new relationship struct
+func (*climateR) NewStruct() *climateR {
+	return &climateR{}
+}
+
+func (r *climateR) GetPlanets() PlanetSlice {
+	if r == nil {
+		return nil
+	}
+	return r.Planets
+}
+
+// climateL is where Load methods for each relationship are stored.
+type climateL struct{}
+
+var (
+	climateAllColumns            = []string{"id", "name"}
+	climateColumnsWithoutDefault = []string{"name"}
+	climateColumnsWithDefault    = []string{"id"}
+	climatePrimaryKeyColumns     = []string{"id"}
+	climateGeneratedColumns      = []string{}
+)
+
+type (
+	// ClimateSlice is an alias for a slice of pointers to Climate.
+	// This should almost always be used instead of []Climate.
+	ClimateSlice []*Climate
+	// ClimateHook is the signature for custom Climate hook methods
+	ClimateHook func(context.Context, boil.ContextExecutor, *Climate) error
+
+	climateQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	climateType                 = reflect.TypeOf(&Climate{})
+	climateMapping              = queries.MakeStructMapping(climateType)
+	climatePrimaryKeyMapping, _ = queries.BindMapping(climateType, climateMapping, climatePrimaryKeyColumns)
+	climateInsertCacheMut       sync.RWMutex
+	climateInsertCache          = make(map[string]insertCache)
+	climateUpdateCacheMut       sync.RWMutex
+	climateUpdateCache          = make(map[string]updateCache)
+	climateUpsertCacheMut       sync.RWMutex
+	climateUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var climateAfterSelectHooks []ClimateHook
+
+var climateBeforeInsertHooks []ClimateHook
+var climateAfterInsertHooks []ClimateHook
+
+var climateBeforeUpdateHooks []ClimateHook
+var climateAfterUpdateHooks []ClimateHook
+
+var climateBeforeDeleteHooks []ClimateHook
+var climateAfterDeleteHooks []ClimateHook
+
+var climateBeforeUpsertHooks []ClimateHook
+var climateAfterUpsertHooks []ClimateHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *Climate) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range climateAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *Climate) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range climateBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *Climate) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range climateAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *Climate) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range climateBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *Climate) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range climateAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *Climate) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range climateBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *Climate) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range climateAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *Climate) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range climateBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *Climate) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range climateAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddClimateHook registers your hook function for all future operations.
+func AddClimateHook(hookPoint boil.HookPoint, climateHook ClimateHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		climateAfterSelectHooks = append(climateAfterSelectHooks, climateHook)
+	case boil.BeforeInsertHook:
+		climateBeforeInsertHooks = append(climateBeforeInsertHooks, climateHook)
+	case boil.AfterInsertHook:
+		climateAfterInsertHooks = append(climateAfterInsertHooks, climateHook)
+	case boil.BeforeUpdateHook:
+		climateBeforeUpdateHooks = append(climateBeforeUpdateHooks, climateHook)
+	case boil.AfterUpdateHook:
+		climateAfterUpdateHooks = append(climateAfterUpdateHooks, climateHook)
+	case boil.BeforeDeleteHook:
+		climateBeforeDeleteHooks = append(climateBeforeDeleteHooks, climateHook)
+	case boil.AfterDeleteHook:
+		climateAfterDeleteHooks = append(climateAfterDeleteHooks, climateHook)
+	case boil.BeforeUpsertHook:
+		climateBeforeUpsertHooks = append(climateBeforeUpsertHooks, climateHook)
+	case boil.AfterUpsertHook:
+		climateAfterUpsertHooks = append(climateAfterUpsertHooks, climateHook)
+	}
+}
+
+// One returns a single climate record from the query.
+func (q climateQuery) One(ctx context.Context, exec boil.ContextExecutor) (*Climate, error) {
+	o := &Climate{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for climates")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all Climate records from the query.
+func (q climateQuery) All(ctx context.Context, exec boil.ContextExecutor) (ClimateSlice, error) {
+	var o []*Climate
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to Climate slice")
+	}
+
+	if len(climateAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all Climate records in the query.
+func (q climateQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count climates rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q climateQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if climates exists")
+	}
+
+	return count > 0, nil
+}
+
+// Planets retrieves all the planet's Planets with an executor.
+func (o *Climate) Planets(mods ...qm.QueryMod) planetQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.InnerJoin("`planets_climates` on `planets`.`id` = `planets_climates`.`planet_id`"),
+		qm.Where("`planets_climates`.`climate_id`=?", o.ID),
+	)
+
+	return Planets(queryMods...)
+}
+
+// LoadPlanets allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (climateL) LoadPlanets(ctx context.Context, e boil.ContextExecutor, singular bool, maybeClimate interface{}, mods queries.Applicator) error {
+	var slice []*Climate
+	var object *Climate
+
+	if singular {
+		var ok bool
+		object, ok = maybeClimate.(*Climate)
+		if !ok {
+			object = new(Climate)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeClimate)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeClimate))
+			}
+		}
+	} else {
+		s, ok := maybeClimate.(*[]*Climate)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeClimate)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeClimate))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &climateR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &climateR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.Select("`planets`.`id`, `planets`.`created_at`, `planets`.`updated_at`, `planets`.`deleted_at`, `planets`.`name`, `planets`.`climates`, `planets`.`terrains`, `planets`.`search_attributes`, `planets`.`rotation_period`, `planets`.`orbital_period`, `planets`.`diameter`, `planets`.`gravity`, `planets`.`surface_water`, `planets`.`population`, `a`.`climate_id`"),
+		qm.From("`planets`"),
+		qm.InnerJoin("`planets_climates` as `a` on `planets`.`id` = `a`.`planet_id`"),
+		qm.WhereIn("`a`.`climate_id` in ?", args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load planets")
+	}
+
+	var resultSlice []*Planet
+
+	var localJoinCols []int
+	for results.Next() {
+		one := new(Planet)
+		var localJoinCol int
+
+		err = results.Scan(&one.ID, &one.CreatedAt, &one.UpdatedAt, &one.DeletedAt, &one.Name, &one.Climates, &one.Terrains, &one.SearchAttributes, &one.RotationPeriod, &one.OrbitalPeriod, &one.Diameter, &one.Gravity, &one.SurfaceWater, &one.Population, &localJoinCol)
+		if err != nil {
+			return errors.Wrap(err, "failed to scan eager loaded results for planets")
+		}
+		if err = results.Err(); err != nil {
+			return errors.Wrap(err, "failed to plebian-bind eager loaded slice planets")
+		}
+
+		resultSlice = append(resultSlice, one)
+		localJoinCols = append(localJoinCols, localJoinCol)
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on planets")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for planets")
+	}
+
+	if len(planetAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Planets = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &planetR{}
+			}
+			foreign.R.PlanetClimates = append(foreign.R.PlanetClimates, object)
+		}
+		return nil
+	}
+
+	for i, foreign := range resultSlice {
+		localJoinCol := localJoinCols[i]
+		for _, local := range slice {
+			if local.ID == localJoinCol {
+				local.R.Planets = append(local.R.Planets, foreign)
+				if foreign.R == nil {
+					foreign.R = &planetR{}
+				}
+				foreign.R.PlanetClimates = append(foreign.R.PlanetClimates, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// AddPlanets adds the given related objects to the existing relationships
+// of the climate, optionally inserting them as new records.
+// Appends related to o.R.Planets.
+// Sets related.R.PlanetClimates appropriately.
+func (o *Climate) AddPlanets(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Planet) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		}
+	}
+
+	for _, rel := range related {
+		query := "insert into `planets_climates` (`climate_id`, `planet_id`) values (?, ?)"
+		values := []interface{}{o.ID, rel.ID}
+
+		if boil.IsDebug(ctx) {
+			writer := boil.DebugWriterFrom(ctx)
+			fmt.Fprintln(writer, query)
+			fmt.Fprintln(writer, values)
+		}
+		_, err = exec.ExecContext(ctx, query, values...)
+		if err != nil {
+			return errors.Wrap(err, "failed to insert into join table")
+		}
+	}
+	if o.R == nil {
+		o.R = &climateR{
+			Planets: related,
+		}
+	} else {
+		o.R.Planets = append(o.R.Planets, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &planetR{
+				PlanetClimates: ClimateSlice{o},
+			}
+		} else {
+			rel.R.PlanetClimates = append(rel.R.PlanetClimates, o)
+		}
+	}
+	return nil
+}
+
+// SetPlanets removes all previously related items of the
+// climate replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.PlanetClimates's Planets accordingly.
+// Replaces o.R.Planets with related.
+// Sets related.R.PlanetClimates's Planets accordingly.
+func (o *Climate) SetPlanets(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Planet) error {
+	query := "delete from `planets_climates` where `climate_id` = ?"
+	values := []interface{}{o.ID}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	removePlanetsFromPlanetClimatesSlice(o, related)
+	if o.R != nil {
+		o.R.Planets = nil
+	}
+
+	return o.AddPlanets(ctx, exec, insert, related...)
+}
+
+// RemovePlanets relationships from objects passed in.
+// Removes related items from R.Planets (uses pointer comparison, removal does not keep order)
+// Sets related.R.PlanetClimates.
+func (o *Climate) RemovePlanets(ctx context.Context, exec boil.ContextExecutor, related ...*Planet) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	query := fmt.Sprintf(
+		"delete from `planets_climates` where `climate_id` = ? and `planet_id` in (%s)",
+		strmangle.Placeholders(dialect.UseIndexPlaceholders, len(related), 2, 1),
+	)
+	values := []interface{}{o.ID}
+	for _, rel := range related {
+		values = append(values, rel.ID)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err = exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+	removePlanetsFromPlanetClimatesSlice(o, related)
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.Planets {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.Planets)
+			if ln > 1 && i < ln-1 {
+				o.R.Planets[i] = o.R.Planets[ln-1]
+			}
+			o.R.Planets = o.R.Planets[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
+func removePlanetsFromPlanetClimatesSlice(o *Climate, related []*Planet) {
+	for _, rel := range related {
+		if rel.R == nil {
+			continue
+		}
+		for i, ri := range rel.R.PlanetClimates {
+			if o.ID != ri.ID {
+				continue
+			}
+
+			ln := len(rel.R.PlanetClimates)
+			if ln > 1 && i < ln-1 {
+				rel.R.PlanetClimates[i] = rel.R.PlanetClimates[ln-1]
+			}
+			rel.R.PlanetClimates = rel.R.PlanetClimates[:ln-1]
+			break
+		}
+	}
+}
+
+// Climates retrieves all the records using an executor.
+func Climates(mods ...qm.QueryMod) climateQuery {
+	mods = append(mods, qm.From("`climates`"))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"`climates`.*"})
+	}
+
+	return climateQuery{q}
+}
+
+// FindClimate retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindClimate(ctx context.Context, exec boil.ContextExecutor, iD int, selectCols ...string) (*Climate, error) {
+	climateObj := &Climate{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from `climates` where `id`=?", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, climateObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from climates")
+	}
+
+	if err = climateObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return climateObj, err
+	}
+
+	return climateObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *Climate) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no climates provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(climateColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	climateInsertCacheMut.RLock()
+	cache, cached := climateInsertCache[key]
+	climateInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			climateAllColumns,
+			climateColumnsWithDefault,
+			climateColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(climateType, climateMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(climateType, climateMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO `climates` (`%s`) %%sVALUES (%s)%%s", strings.Join(wl, "`,`"), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO `climates` () VALUES ()%s%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			cache.retQuery = fmt.Sprintf("SELECT `%s` FROM `climates` WHERE %s", strings.Join(returnColumns, "`,`"), strmangle.WhereClause("`", "`", 0, climatePrimaryKeyColumns))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	result, err := exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into climates")
+	}
+
+	var lastID int64
+	var identifierCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	lastID, err = result.LastInsertId()
+	if err != nil {
+		return ErrSyncFail
+	}
+
+	o.ID = int(lastID)
+	if lastID != 0 && len(cache.retMapping) == 1 && cache.retMapping[0] == climateMapping["id"] {
+		goto CacheNoHooks
+	}
+
+	identifierCols = []interface{}{
+		o.ID,
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, identifierCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to populate default values for climates")
+	}
+
+CacheNoHooks:
+	if !cached {
+		climateInsertCacheMut.Lock()
+		climateInsertCache[key] = cache
+		climateInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the Climate.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *Climate) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	climateUpdateCacheMut.RLock()
+	cache, cached := climateUpdateCache[key]
+	climateUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			climateAllColumns,
+			climatePrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update climates, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE `climates` SET %s WHERE %s",
+			strmangle.SetParamNames("`", "`", 0, wl),
+			strmangle.WhereClause("`", "`", 0, climatePrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(climateType, climateMapping, append(wl, climatePrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update climates row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for climates")
+	}
+
+	if !cached {
+		climateUpdateCacheMut.Lock()
+		climateUpdateCache[key] = cache
+		climateUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q climateQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for climates")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for climates")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o ClimateSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), climatePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE `climates` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, climatePrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in climate slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all climate")
+	}
+	return rowsAff, nil
+}
+
+var mySQLClimateUniqueColumns = []string{
+	"id",
+	"name",
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *Climate) Upsert(ctx context.Context, exec boil.ContextExecutor, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no climates provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(climateColumnsWithDefault, o)
+	nzUniques := queries.NonZeroDefaultSet(mySQLClimateUniqueColumns, o)
+
+	if len(nzUniques) == 0 {
+		return errors.New("cannot upsert with a table that cannot conflict on a unique column")
+	}
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzUniques {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	climateUpsertCacheMut.RLock()
+	cache, cached := climateUpsertCache[key]
+	climateUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			climateAllColumns,
+			climateColumnsWithDefault,
+			climateColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			climateAllColumns,
+			climatePrimaryKeyColumns,
+		)
+
+		if !updateColumns.IsNone() && len(update) == 0 {
+			return errors.New("models: unable to upsert climates, could not build update column list")
+		}
+
+		ret = strmangle.SetComplement(ret, nzUniques)
+		cache.query = buildUpsertQueryMySQL(dialect, "`climates`", update, insert)
+		cache.retQuery = fmt.Sprintf(
+			"SELECT %s FROM `climates` WHERE %s",
+			strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, ret), ","),
+			strmangle.WhereClause("`", "`", 0, nzUniques),
+		)
+
+		cache.valueMapping, err = queries.BindMapping(climateType, climateMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(climateType, climateMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	result, err := exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert for climates")
+	}
+
+	var lastID int64
+	var uniqueMap []uint64
+	var nzUniqueCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	lastID, err = result.LastInsertId()
+	if err != nil {
+		return ErrSyncFail
+	}
+
+	o.ID = int(lastID)
+	if lastID != 0 && len(cache.retMapping) == 1 && cache.retMapping[0] == climateMapping["id"] {
+		goto CacheNoHooks
+	}
+
+	uniqueMap, err = queries.BindMapping(climateType, climateMapping, nzUniques)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to retrieve unique values for climates")
+	}
+	nzUniqueCols = queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), uniqueMap)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, nzUniqueCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, nzUniqueCols...).Scan(returns...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to populate default values for climates")
+	}
+
+CacheNoHooks:
+	if !cached {
+		climateUpsertCacheMut.Lock()
+		climateUpsertCache[key] = cache
+		climateUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single Climate record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *Climate) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no Climate provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), climatePrimaryKeyMapping)
+	sql := "DELETE FROM `climates` WHERE `id`=?"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from climates")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for climates")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q climateQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no climateQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from climates")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for climates")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o ClimateSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(climateBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), climatePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM `climates` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, climatePrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from climate slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for climates")
+	}
+
+	if len(climateAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *Climate) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindClimate(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *ClimateSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := ClimateSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), climatePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT `climates`.* FROM `climates` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, climatePrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in ClimateSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// ClimateExists checks if the Climate row exists.
+func ClimateExists(ctx context.Context, exec boil.ContextExecutor, iD int) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from `climates` where `id`=? limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if climates exists")
+	}
+
+	return exists, nil
+}
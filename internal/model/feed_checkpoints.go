@@ -0,0 +1,960 @@
+// Code generated by SQLBoiler 4.13.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package model
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// FeedCheckpoint is an object representing the database table.
+type FeedCheckpoint struct {
+	Name      string    `boil:"name" json:"name" toml:"name" yaml:"name"`
+	UpdatedAt time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	Resource  string    `boil:"resource" json:"resource" toml:"resource" yaml:"resource"`
+	Page      int       `boil:"page" json:"page" toml:"page" yaml:"page"`
+
+	R *feedCheckpointR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L feedCheckpointL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var FeedCheckpointColumns = struct {
+	Name      string
+	UpdatedAt string
+	Resource  string
+	Page      string
+}{
+	Name:      "name",
+	UpdatedAt: "updated_at",
+	Resource:  "resource",
+	Page:      "page",
+}
+
+var FeedCheckpointTableColumns = struct {
+	Name      string
+	UpdatedAt string
+	Resource  string
+	Page      string
+}{
+	Name:      "feed_checkpoints.name",
+	UpdatedAt: "feed_checkpoints.updated_at",
+	Resource:  "feed_checkpoints.resource",
+	Page:      "feed_checkpoints.page",
+}
+
+// Generated where
+
+var FeedCheckpointWhere = struct {
+	Name      whereHelperstring
+	UpdatedAt whereHelpertime_Time
+	Resource  whereHelperstring
+	Page      whereHelperint
+}{
+	Name:      whereHelperstring{field: "`feed_checkpoints`.`name`"},
+	UpdatedAt: whereHelpertime_Time{field: "`feed_checkpoints`.`updated_at`"},
+	Resource:  whereHelperstring{field: "`feed_checkpoints`.`resource`"},
+	Page:      whereHelperint{field: "`feed_checkpoints`.`page`"},
+}
+
+// FeedCheckpointRels is where relationship names are stored.
+var FeedCheckpointRels = struct {
+}{}
+
+// feedCheckpointR is where relationships are stored.
+type feedCheckpointR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*feedCheckpointR) NewStruct() *feedCheckpointR {
+	return &feedCheckpointR{}
+}
+
+// feedCheckpointL is where Load methods for each relationship are stored.
+type feedCheckpointL struct{}
+
+var (
+	feedCheckpointAllColumns            = []string{"name", "updated_at", "resource", "page"}
+	feedCheckpointColumnsWithoutDefault = []string{"name", "updated_at", "resource", "page"}
+	feedCheckpointColumnsWithDefault    = []string{}
+	feedCheckpointPrimaryKeyColumns     = []string{"name"}
+	feedCheckpointGeneratedColumns      = []string{}
+)
+
+type (
+	// FeedCheckpointSlice is an alias for a slice of pointers to FeedCheckpoint.
+	// This should almost always be used instead of []FeedCheckpoint.
+	FeedCheckpointSlice []*FeedCheckpoint
+	// FeedCheckpointHook is the signature for custom FeedCheckpoint hook methods
+	FeedCheckpointHook func(context.Context, boil.ContextExecutor, *FeedCheckpoint) error
+
+	feedCheckpointQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	feedCheckpointType                 = reflect.TypeOf(&FeedCheckpoint{})
+	feedCheckpointMapping              = queries.MakeStructMapping(feedCheckpointType)
+	feedCheckpointPrimaryKeyMapping, _ = queries.BindMapping(feedCheckpointType, feedCheckpointMapping, feedCheckpointPrimaryKeyColumns)
+	feedCheckpointInsertCacheMut       sync.RWMutex
+	feedCheckpointInsertCache          = make(map[string]insertCache)
+	feedCheckpointUpdateCacheMut       sync.RWMutex
+	feedCheckpointUpdateCache          = make(map[string]updateCache)
+	feedCheckpointUpsertCacheMut       sync.RWMutex
+	feedCheckpointUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var feedCheckpointAfterSelectHooks []FeedCheckpointHook
+
+var feedCheckpointBeforeInsertHooks []FeedCheckpointHook
+var feedCheckpointAfterInsertHooks []FeedCheckpointHook
+
+var feedCheckpointBeforeUpdateHooks []FeedCheckpointHook
+var feedCheckpointAfterUpdateHooks []FeedCheckpointHook
+
+var feedCheckpointBeforeDeleteHooks []FeedCheckpointHook
+var feedCheckpointAfterDeleteHooks []FeedCheckpointHook
+
+var feedCheckpointBeforeUpsertHooks []FeedCheckpointHook
+var feedCheckpointAfterUpsertHooks []FeedCheckpointHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *FeedCheckpoint) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range feedCheckpointAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *FeedCheckpoint) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range feedCheckpointBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *FeedCheckpoint) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range feedCheckpointAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *FeedCheckpoint) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range feedCheckpointBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *FeedCheckpoint) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range feedCheckpointAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *FeedCheckpoint) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range feedCheckpointBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *FeedCheckpoint) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range feedCheckpointAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *FeedCheckpoint) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range feedCheckpointBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *FeedCheckpoint) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range feedCheckpointAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddFeedCheckpointHook registers your hook function for all future operations.
+func AddFeedCheckpointHook(hookPoint boil.HookPoint, feedCheckpointHook FeedCheckpointHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		feedCheckpointAfterSelectHooks = append(feedCheckpointAfterSelectHooks, feedCheckpointHook)
+	case boil.BeforeInsertHook:
+		feedCheckpointBeforeInsertHooks = append(feedCheckpointBeforeInsertHooks, feedCheckpointHook)
+	case boil.AfterInsertHook:
+		feedCheckpointAfterInsertHooks = append(feedCheckpointAfterInsertHooks, feedCheckpointHook)
+	case boil.BeforeUpdateHook:
+		feedCheckpointBeforeUpdateHooks = append(feedCheckpointBeforeUpdateHooks, feedCheckpointHook)
+	case boil.AfterUpdateHook:
+		feedCheckpointAfterUpdateHooks = append(feedCheckpointAfterUpdateHooks, feedCheckpointHook)
+	case boil.BeforeDeleteHook:
+		feedCheckpointBeforeDeleteHooks = append(feedCheckpointBeforeDeleteHooks, feedCheckpointHook)
+	case boil.AfterDeleteHook:
+		feedCheckpointAfterDeleteHooks = append(feedCheckpointAfterDeleteHooks, feedCheckpointHook)
+	case boil.BeforeUpsertHook:
+		feedCheckpointBeforeUpsertHooks = append(feedCheckpointBeforeUpsertHooks, feedCheckpointHook)
+	case boil.AfterUpsertHook:
+		feedCheckpointAfterUpsertHooks = append(feedCheckpointAfterUpsertHooks, feedCheckpointHook)
+	}
+}
+
+// One returns a single feedCheckpoint record from the query.
+func (q feedCheckpointQuery) One(ctx context.Context, exec boil.ContextExecutor) (*FeedCheckpoint, error) {
+	o := &FeedCheckpoint{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for feed_checkpoints")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all FeedCheckpoint records from the query.
+func (q feedCheckpointQuery) All(ctx context.Context, exec boil.ContextExecutor) (FeedCheckpointSlice, error) {
+	var o []*FeedCheckpoint
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to FeedCheckpoint slice")
+	}
+
+	if len(feedCheckpointAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all FeedCheckpoint records in the query.
+func (q feedCheckpointQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count feed_checkpoints rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q feedCheckpointQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if feed_checkpoints exists")
+	}
+
+	return count > 0, nil
+}
+
+// FeedCheckpoints retrieves all the records using an executor.
+func FeedCheckpoints(mods ...qm.QueryMod) feedCheckpointQuery {
+	mods = append(mods, qm.From("`feed_checkpoints`"))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"`feed_checkpoints`.*"})
+	}
+
+	return feedCheckpointQuery{q}
+}
+
+// FindFeedCheckpoint retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindFeedCheckpoint(ctx context.Context, exec boil.ContextExecutor, name string, selectCols ...string) (*FeedCheckpoint, error) {
+	feedCheckpointObj := &FeedCheckpoint{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from `feed_checkpoints` where `name`=?", sel,
+	)
+
+	q := queries.Raw(query, name)
+
+	err := q.Bind(ctx, exec, feedCheckpointObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from feed_checkpoints")
+	}
+
+	if err = feedCheckpointObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return feedCheckpointObj, err
+	}
+
+	return feedCheckpointObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *FeedCheckpoint) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no feed_checkpoints provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.UpdatedAt.IsZero() {
+			o.UpdatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(feedCheckpointColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	feedCheckpointInsertCacheMut.RLock()
+	cache, cached := feedCheckpointInsertCache[key]
+	feedCheckpointInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			feedCheckpointAllColumns,
+			feedCheckpointColumnsWithDefault,
+			feedCheckpointColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(feedCheckpointType, feedCheckpointMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(feedCheckpointType, feedCheckpointMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO `feed_checkpoints` (`%s`) %%sVALUES (%s)%%s", strings.Join(wl, "`,`"), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO `feed_checkpoints` () VALUES ()%s%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			cache.retQuery = fmt.Sprintf("SELECT `%s` FROM `feed_checkpoints` WHERE %s", strings.Join(returnColumns, "`,`"), strmangle.WhereClause("`", "`", 0, feedCheckpointPrimaryKeyColumns))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	_, err = exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into feed_checkpoints")
+	}
+
+	var identifierCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	identifierCols = []interface{}{
+		o.Name,
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, identifierCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to populate default values for feed_checkpoints")
+	}
+
+CacheNoHooks:
+	if !cached {
+		feedCheckpointInsertCacheMut.Lock()
+		feedCheckpointInsertCache[key] = cache
+		feedCheckpointInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the FeedCheckpoint.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *FeedCheckpoint) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		o.UpdatedAt = currTime
+	}
+
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	feedCheckpointUpdateCacheMut.RLock()
+	cache, cached := feedCheckpointUpdateCache[key]
+	feedCheckpointUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			feedCheckpointAllColumns,
+			feedCheckpointPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update feed_checkpoints, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE `feed_checkpoints` SET %s WHERE %s",
+			strmangle.SetParamNames("`", "`", 0, wl),
+			strmangle.WhereClause("`", "`", 0, feedCheckpointPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(feedCheckpointType, feedCheckpointMapping, append(wl, feedCheckpointPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update feed_checkpoints row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for feed_checkpoints")
+	}
+
+	if !cached {
+		feedCheckpointUpdateCacheMut.Lock()
+		feedCheckpointUpdateCache[key] = cache
+		feedCheckpointUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q feedCheckpointQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for feed_checkpoints")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for feed_checkpoints")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o FeedCheckpointSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), feedCheckpointPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE `feed_checkpoints` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, feedCheckpointPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in feedCheckpoint slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all feedCheckpoint")
+	}
+	return rowsAff, nil
+}
+
+var mySQLFeedCheckpointUniqueColumns = []string{
+	"name",
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *FeedCheckpoint) Upsert(ctx context.Context, exec boil.ContextExecutor, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no feed_checkpoints provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		o.UpdatedAt = currTime
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(feedCheckpointColumnsWithDefault, o)
+	nzUniques := queries.NonZeroDefaultSet(mySQLFeedCheckpointUniqueColumns, o)
+
+	if len(nzUniques) == 0 {
+		return errors.New("cannot upsert with a table that cannot conflict on a unique column")
+	}
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzUniques {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	feedCheckpointUpsertCacheMut.RLock()
+	cache, cached := feedCheckpointUpsertCache[key]
+	feedCheckpointUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			feedCheckpointAllColumns,
+			feedCheckpointColumnsWithDefault,
+			feedCheckpointColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			feedCheckpointAllColumns,
+			feedCheckpointPrimaryKeyColumns,
+		)
+
+		if !updateColumns.IsNone() && len(update) == 0 {
+			return errors.New("models: unable to upsert feed_checkpoints, could not build update column list")
+		}
+
+		ret = strmangle.SetComplement(ret, nzUniques)
+		cache.query = buildUpsertQueryMySQL(dialect, "`feed_checkpoints`", update, insert)
+		cache.retQuery = fmt.Sprintf(
+			"SELECT %s FROM `feed_checkpoints` WHERE %s",
+			strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, ret), ","),
+			strmangle.WhereClause("`", "`", 0, nzUniques),
+		)
+
+		cache.valueMapping, err = queries.BindMapping(feedCheckpointType, feedCheckpointMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(feedCheckpointType, feedCheckpointMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	_, err = exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert for feed_checkpoints")
+	}
+
+	var uniqueMap []uint64
+	var nzUniqueCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	uniqueMap, err = queries.BindMapping(feedCheckpointType, feedCheckpointMapping, nzUniques)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to retrieve unique values for feed_checkpoints")
+	}
+	nzUniqueCols = queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), uniqueMap)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, nzUniqueCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, nzUniqueCols...).Scan(returns...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to populate default values for feed_checkpoints")
+	}
+
+CacheNoHooks:
+	if !cached {
+		feedCheckpointUpsertCacheMut.Lock()
+		feedCheckpointUpsertCache[key] = cache
+		feedCheckpointUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single FeedCheckpoint record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *FeedCheckpoint) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no FeedCheckpoint provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), feedCheckpointPrimaryKeyMapping)
+	sql := "DELETE FROM `feed_checkpoints` WHERE `name`=?"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from feed_checkpoints")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for feed_checkpoints")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q feedCheckpointQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no feedCheckpointQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from feed_checkpoints")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for feed_checkpoints")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o FeedCheckpointSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(feedCheckpointBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), feedCheckpointPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM `feed_checkpoints` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, feedCheckpointPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from feedCheckpoint slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for feed_checkpoints")
+	}
+
+	if len(feedCheckpointAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *FeedCheckpoint) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindFeedCheckpoint(ctx, exec, o.Name)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *FeedCheckpointSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := FeedCheckpointSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), feedCheckpointPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT `feed_checkpoints`.* FROM `feed_checkpoints` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, feedCheckpointPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in FeedCheckpointSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// FeedCheckpointExists checks if the FeedCheckpoint row exists.
+func FeedCheckpointExists(ctx context.Context, exec boil.ContextExecutor, name string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from `feed_checkpoints` where `name`=? limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, name)
+	}
+	row := exec.QueryRowContext(ctx, sql, name)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if feed_checkpoints exists")
+	}
+
+	return exists, nil
+}
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -23,123 +24,66 @@ import (
 
 // Film is an object representing the database table.
 type Film struct {
-	ID          int       `boil:"id" json:"id" toml:"id" yaml:"id"`
-	CreatedAt   time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	UpdatedAt   time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
-	Title       string    `boil:"title" json:"title" toml:"title" yaml:"title"`
-	Episode     int8      `boil:"episode" json:"episode" toml:"episode" yaml:"episode"`
-	Director    string    `boil:"director" json:"director" toml:"director" yaml:"director"`
-	ReleaseDate time.Time `boil:"release_date" json:"release_date" toml:"release_date" yaml:"release_date"`
+	ID           int         `boil:"id" json:"id" toml:"id" yaml:"id"`
+	CreatedAt    time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt    time.Time   `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	Title        string      `boil:"title" json:"title" toml:"title" yaml:"title"`
+	Episode      int8        `boil:"episode" json:"episode" toml:"episode" yaml:"episode"`
+	Director     string      `boil:"director" json:"director" toml:"director" yaml:"director"`
+	ReleaseDate  time.Time   `boil:"release_date" json:"release_date" toml:"release_date" yaml:"release_date"`
+	OpeningCrawl null.String `boil:"opening_crawl" json:"opening_crawl,omitempty" toml:"opening_crawl" yaml:"opening_crawl,omitempty"`
+	Producer     null.String `boil:"producer" json:"producer,omitempty" toml:"producer" yaml:"producer,omitempty"`
 
 	R *filmR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L filmL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var FilmColumns = struct {
-	ID          string
-	CreatedAt   string
-	UpdatedAt   string
-	Title       string
-	Episode     string
-	Director    string
-	ReleaseDate string
+	ID           string
+	CreatedAt    string
+	UpdatedAt    string
+	Title        string
+	Episode      string
+	Director     string
+	ReleaseDate  string
+	OpeningCrawl string
+	Producer     string
 }{
-	ID:          "id",
-	CreatedAt:   "created_at",
-	UpdatedAt:   "updated_at",
-	Title:       "title",
-	Episode:     "episode",
-	Director:    "director",
-	ReleaseDate: "release_date",
+	ID:           "id",
+	CreatedAt:    "created_at",
+	UpdatedAt:    "updated_at",
+	Title:        "title",
+	Episode:      "episode",
+	Director:     "director",
+	ReleaseDate:  "release_date",
+	OpeningCrawl: "opening_crawl",
+	Producer:     "producer",
 }
 
 var FilmTableColumns = struct {
-	ID          string
-	CreatedAt   string
-	UpdatedAt   string
-	Title       string
-	Episode     string
-	Director    string
-	ReleaseDate string
+	ID           string
+	CreatedAt    string
+	UpdatedAt    string
+	Title        string
+	Episode      string
+	Director     string
+	ReleaseDate  string
+	OpeningCrawl string
+	Producer     string
 }{
-	ID:          "films.id",
-	CreatedAt:   "films.created_at",
-	UpdatedAt:   "films.updated_at",
-	Title:       "films.title",
-	Episode:     "films.episode",
-	Director:    "films.director",
-	ReleaseDate: "films.release_date",
+	ID:           "films.id",
+	CreatedAt:    "films.created_at",
+	UpdatedAt:    "films.updated_at",
+	Title:        "films.title",
+	Episode:      "films.episode",
+	Director:     "films.director",
+	ReleaseDate:  "films.release_date",
+	OpeningCrawl: "films.opening_crawl",
+	Producer:     "films.producer",
 }
 
 // Generated where
 
-type whereHelperint struct{ field string }
-
-func (w whereHelperint) EQ(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperint) NEQ(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperint) LT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperint) LTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperint) GT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperint) GTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperint) IN(slice []int) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelperint) NIN(slice []int) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
-type whereHelpertime_Time struct{ field string }
-
-func (w whereHelpertime_Time) EQ(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.EQ, x)
-}
-func (w whereHelpertime_Time) NEQ(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.NEQ, x)
-}
-func (w whereHelpertime_Time) LT(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpertime_Time) LTE(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpertime_Time) GT(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpertime_Time) GTE(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-
-type whereHelperstring struct{ field string }
-
-func (w whereHelperstring) EQ(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperstring) NEQ(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperstring) LT(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperstring) LTE(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperstring) GT(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperstring) GTE(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperstring) IN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelperstring) NIN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
 type whereHelperint8 struct{ field string }
 
 func (w whereHelperint8) EQ(x int8) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
@@ -164,33 +108,40 @@ func (w whereHelperint8) NIN(slice []int8) qm.QueryMod {
 }
 
 var FilmWhere = struct {
-	ID          whereHelperint
-	CreatedAt   whereHelpertime_Time
-	UpdatedAt   whereHelpertime_Time
-	Title       whereHelperstring
-	Episode     whereHelperint8
-	Director    whereHelperstring
-	ReleaseDate whereHelpertime_Time
+	ID           whereHelperint
+	CreatedAt    whereHelpertime_Time
+	UpdatedAt    whereHelpertime_Time
+	Title        whereHelperstring
+	Episode      whereHelperint8
+	Director     whereHelperstring
+	ReleaseDate  whereHelpertime_Time
+	OpeningCrawl whereHelpernull_String
+	Producer     whereHelpernull_String
 }{
-	ID:          whereHelperint{field: "`films`.`id`"},
-	CreatedAt:   whereHelpertime_Time{field: "`films`.`created_at`"},
-	UpdatedAt:   whereHelpertime_Time{field: "`films`.`updated_at`"},
-	Title:       whereHelperstring{field: "`films`.`title`"},
-	Episode:     whereHelperint8{field: "`films`.`episode`"},
-	Director:    whereHelperstring{field: "`films`.`director`"},
-	ReleaseDate: whereHelpertime_Time{field: "`films`.`release_date`"},
+	ID:           whereHelperint{field: "`films`.`id`"},
+	CreatedAt:    whereHelpertime_Time{field: "`films`.`created_at`"},
+	UpdatedAt:    whereHelpertime_Time{field: "`films`.`updated_at`"},
+	Title:        whereHelperstring{field: "`films`.`title`"},
+	Episode:      whereHelperint8{field: "`films`.`episode`"},
+	Director:     whereHelperstring{field: "`films`.`director`"},
+	ReleaseDate:  whereHelpertime_Time{field: "`films`.`release_date`"},
+	OpeningCrawl: whereHelpernull_String{field: "`films`.`opening_crawl`"},
+	Producer:     whereHelpernull_String{field: "`films`.`producer`"},
 }
 
 // FilmRels is where relationship names are stored.
 var FilmRels = struct {
-	Planets string
+	FilmsTranslations string
+	Planets           string
 }{
-	Planets: "Planets",
+	FilmsTranslations: "FilmsTranslations",
+	Planets:           "Planets",
 }
 
 // filmR is where relationships are stored.
 type filmR struct {
-	Planets PlanetSlice `boil:"Planets" json:"Planets" toml:"Planets" yaml:"Planets"`
+	FilmsTranslations FilmsTranslationSlice `boil:"FilmsTranslations" json:"FilmsTranslations" toml:"FilmsTranslations" yaml:"FilmsTranslations"`
+	Planets           PlanetSlice           `boil:"Planets" json:"Planets" toml:"Planets" yaml:"Planets"`
 }
 
 // NewStruct creates a new relationship struct
@@ -198,6 +149,13 @@ func (*filmR) NewStruct() *filmR {
 	return &filmR{}
 }
 
+func (r *filmR) GetFilmsTranslations() FilmsTranslationSlice {
+	if r == nil {
+		return nil
+	}
+	return r.FilmsTranslations
+}
+
 func (r *filmR) GetPlanets() PlanetSlice {
 	if r == nil {
 		return nil
@@ -209,8 +167,8 @@ func (r *filmR) GetPlanets() PlanetSlice {
 type filmL struct{}
 
 var (
-	filmAllColumns            = []string{"id", "created_at", "updated_at", "title", "episode", "director", "release_date"}
-	filmColumnsWithoutDefault = []string{"created_at", "updated_at", "title", "episode", "director", "release_date"}
+	filmAllColumns            = []string{"id", "created_at", "updated_at", "title", "episode", "director", "release_date", "opening_crawl", "producer"}
+	filmColumnsWithoutDefault = []string{"created_at", "updated_at", "title", "episode", "director", "release_date", "opening_crawl", "producer"}
 	filmColumnsWithDefault    = []string{"id"}
 	filmPrimaryKeyColumns     = []string{"id"}
 	filmGeneratedColumns      = []string{}
@@ -494,6 +452,20 @@ func (q filmQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool,
 	return count > 0, nil
 }
 
+// FilmsTranslations retrieves all the films_translation's FilmsTranslations with an executor.
+func (o *Film) FilmsTranslations(mods ...qm.QueryMod) filmsTranslationQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("`films_translations`.`film_id`=?", o.ID),
+	)
+
+	return FilmsTranslations(queryMods...)
+}
+
 // Planets retrieves all the planet's Planets with an executor.
 func (o *Film) Planets(mods ...qm.QueryMod) planetQuery {
 	var queryMods []qm.QueryMod
@@ -509,6 +481,120 @@ func (o *Film) Planets(mods ...qm.QueryMod) planetQuery {
 	return Planets(queryMods...)
 }
 
+// LoadFilmsTranslations allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (filmL) LoadFilmsTranslations(ctx context.Context, e boil.ContextExecutor, singular bool, maybeFilm interface{}, mods queries.Applicator) error {
+	var slice []*Film
+	var object *Film
+
+	if singular {
+		var ok bool
+		object, ok = maybeFilm.(*Film)
+		if !ok {
+			object = new(Film)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeFilm)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeFilm))
+			}
+		}
+	} else {
+		s, ok := maybeFilm.(*[]*Film)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeFilm)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeFilm))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &filmR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &filmR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`films_translations`),
+		qm.WhereIn(`films_translations.film_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load films_translations")
+	}
+
+	var resultSlice []*FilmsTranslation
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice films_translations")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on films_translations")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for films_translations")
+	}
+
+	if len(filmsTranslationAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.FilmsTranslations = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &filmsTranslationR{}
+			}
+			foreign.R.Film = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.FilmID {
+				local.R.FilmsTranslations = append(local.R.FilmsTranslations, foreign)
+				if foreign.R == nil {
+					foreign.R = &filmsTranslationR{}
+				}
+				foreign.R.Film = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // LoadPlanets allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
 func (filmL) LoadPlanets(ctx context.Context, e boil.ContextExecutor, singular bool, maybeFilm interface{}, mods queries.Applicator) error {
@@ -565,7 +651,7 @@ func (filmL) LoadPlanets(ctx context.Context, e boil.ContextExecutor, singular b
 	}
 
 	query := NewQuery(
-		qm.Select("`planets`.`id`, `planets`.`created_at`, `planets`.`updated_at`, `planets`.`deleted_at`, `planets`.`name`, `planets`.`climates`, `planets`.`terrains`, `a`.`film_id`"),
+		qm.Select("`planets`.`id`, `planets`.`created_at`, `planets`.`updated_at`, `planets`.`deleted_at`, `planets`.`name`, `planets`.`climates`, `planets`.`terrains`, `planets`.`search_attributes`, `planets`.`rotation_period`, `planets`.`orbital_period`, `planets`.`diameter`, `planets`.`gravity`, `planets`.`surface_water`, `planets`.`population`, `a`.`film_id`"),
 		qm.From("`planets`"),
 		qm.InnerJoin("`planets_films` as `a` on `planets`.`id` = `a`.`planet_id`"),
 		qm.WhereIn("`a`.`film_id` in ?", args...),
@@ -586,7 +672,7 @@ func (filmL) LoadPlanets(ctx context.Context, e boil.ContextExecutor, singular b
 		one := new(Planet)
 		var localJoinCol int
 
-		err = results.Scan(&one.ID, &one.CreatedAt, &one.UpdatedAt, &one.DeletedAt, &one.Name, &one.Climates, &one.Terrains, &localJoinCol)
+		err = results.Scan(&one.ID, &one.CreatedAt, &one.UpdatedAt, &one.DeletedAt, &one.Name, &one.Climates, &one.Terrains, &one.SearchAttributes, &one.RotationPeriod, &one.OrbitalPeriod, &one.Diameter, &one.Gravity, &one.SurfaceWater, &one.Population, &localJoinCol)
 		if err != nil {
 			return errors.Wrap(err, "failed to scan eager loaded results for planets")
 		}
@@ -640,6 +726,59 @@ func (filmL) LoadPlanets(ctx context.Context, e boil.ContextExecutor, singular b
 	return nil
 }
 
+// AddFilmsTranslations adds the given related objects to the existing relationships
+// of the film, optionally inserting them as new records.
+// Appends related to o.R.FilmsTranslations.
+// Sets related.R.Film appropriately.
+func (o *Film) AddFilmsTranslations(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*FilmsTranslation) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.FilmID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE `films_translations` SET %s WHERE %s",
+				strmangle.SetParamNames("`", "`", 0, []string{"film_id"}),
+				strmangle.WhereClause("`", "`", 0, filmsTranslationPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.FilmID, rel.Locale}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.FilmID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &filmR{
+			FilmsTranslations: related,
+		}
+	} else {
+		o.R.FilmsTranslations = append(o.R.FilmsTranslations, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &filmsTranslationR{
+				Film: o,
+			}
+		} else {
+			rel.R.Film = o
+		}
+	}
+	return nil
+}
+
 // AddPlanets adds the given related objects to the existing relationships
 // of the film, optionally inserting them as new records.
 // Appends related to o.R.Planets.
@@ -0,0 +1,1161 @@
+// Code generated by SQLBoiler 4.13.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package model
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// FilmsTranslation is an object representing the database table.
+type FilmsTranslation struct {
+	FilmID    int       `boil:"film_id" json:"film_id" toml:"film_id" yaml:"film_id"`
+	Locale    string    `boil:"locale" json:"locale" toml:"locale" yaml:"locale"`
+	CreatedAt time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	Title     string    `boil:"title" json:"title" toml:"title" yaml:"title"`
+
+	R *filmsTranslationR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L filmsTranslationL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var FilmsTranslationColumns = struct {
+	FilmID    string
+	Locale    string
+	CreatedAt string
+	UpdatedAt string
+	Title     string
+}{
+	FilmID:    "film_id",
+	Locale:    "locale",
+	CreatedAt: "created_at",
+	UpdatedAt: "updated_at",
+	Title:     "title",
+}
+
+var FilmsTranslationTableColumns = struct {
+	FilmID    string
+	Locale    string
+	CreatedAt string
+	UpdatedAt string
+	Title     string
+}{
+	FilmID:    "films_translations.film_id",
+	Locale:    "films_translations.locale",
+	CreatedAt: "films_translations.created_at",
+	UpdatedAt: "films_translations.updated_at",
+	Title:     "films_translations.title",
+}
+
+// Generated where
+
+var FilmsTranslationWhere = struct {
+	FilmID    whereHelperint
+	Locale    whereHelperstring
+	CreatedAt whereHelpertime_Time
+	UpdatedAt whereHelpertime_Time
+	Title     whereHelperstring
+}{
+	FilmID:    whereHelperint{field: "`films_translations`.`film_id`"},
+	Locale:    whereHelperstring{field: "`films_translations`.`locale`"},
+	CreatedAt: whereHelpertime_Time{field: "`films_translations`.`created_at`"},
+	UpdatedAt: whereHelpertime_Time{field: "`films_translations`.`updated_at`"},
+	Title:     whereHelperstring{field: "`films_translations`.`title`"},
+}
+
+// FilmsTranslationRels is where relationship names are stored.
+var FilmsTranslationRels = struct {
+	Film string
+}{
+	Film: "Film",
+}
+
+// filmsTranslationR is where relationships are stored.
+type filmsTranslationR struct {
+	Film *Film `boil:"Film" json:"Film" toml:"Film" yaml:"Film"`
+}
+
+// NewStruct creates a new relationship struct
+func (*filmsTranslationR) NewStruct() *filmsTranslationR {
+	return &filmsTranslationR{}
+}
+
+func (r *filmsTranslationR) GetFilm() *Film {
+	if r == nil {
+		return nil
+	}
+	return r.Film
+}
+
+// filmsTranslationL is where Load methods for each relationship are stored.
+type filmsTranslationL struct{}
+
+var (
+	filmsTranslationAllColumns            = []string{"film_id", "locale", "created_at", "updated_at", "title"}
+	filmsTranslationColumnsWithoutDefault = []string{"film_id", "locale", "created_at", "updated_at", "title"}
+	filmsTranslationColumnsWithDefault    = []string{}
+	filmsTranslationPrimaryKeyColumns     = []string{"film_id", "locale"}
+	filmsTranslationGeneratedColumns      = []string{}
+)
+
+type (
+	// FilmsTranslationSlice is an alias for a slice of pointers to FilmsTranslation.
+	// This should almost always be used instead of []FilmsTranslation.
+	FilmsTranslationSlice []*FilmsTranslation
+	// FilmsTranslationHook is the signature for custom FilmsTranslation hook methods
+	FilmsTranslationHook func(context.Context, boil.ContextExecutor, *FilmsTranslation) error
+
+	filmsTranslationQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	filmsTranslationType                 = reflect.TypeOf(&FilmsTranslation{})
+	filmsTranslationMapping              = queries.MakeStructMapping(filmsTranslationType)
+	filmsTranslationPrimaryKeyMapping, _ = queries.BindMapping(filmsTranslationType, filmsTranslationMapping, filmsTranslationPrimaryKeyColumns)
+	filmsTranslationInsertCacheMut       sync.RWMutex
+	filmsTranslationInsertCache          = make(map[string]insertCache)
+	filmsTranslationUpdateCacheMut       sync.RWMutex
+	filmsTranslationUpdateCache          = make(map[string]updateCache)
+	filmsTranslationUpsertCacheMut       sync.RWMutex
+	filmsTranslationUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var filmsTranslationAfterSelectHooks []FilmsTranslationHook
+
+var filmsTranslationBeforeInsertHooks []FilmsTranslationHook
+var filmsTranslationAfterInsertHooks []FilmsTranslationHook
+
+var filmsTranslationBeforeUpdateHooks []FilmsTranslationHook
+var filmsTranslationAfterUpdateHooks []FilmsTranslationHook
+
+var filmsTranslationBeforeDeleteHooks []FilmsTranslationHook
+var filmsTranslationAfterDeleteHooks []FilmsTranslationHook
+
+var filmsTranslationBeforeUpsertHooks []FilmsTranslationHook
+var filmsTranslationAfterUpsertHooks []FilmsTranslationHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *FilmsTranslation) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range filmsTranslationAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *FilmsTranslation) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range filmsTranslationBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *FilmsTranslation) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range filmsTranslationAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *FilmsTranslation) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range filmsTranslationBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *FilmsTranslation) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range filmsTranslationAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *FilmsTranslation) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range filmsTranslationBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *FilmsTranslation) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range filmsTranslationAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *FilmsTranslation) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range filmsTranslationBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *FilmsTranslation) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range filmsTranslationAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddFilmsTranslationHook registers your hook function for all future operations.
+func AddFilmsTranslationHook(hookPoint boil.HookPoint, filmsTranslationHook FilmsTranslationHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		filmsTranslationAfterSelectHooks = append(filmsTranslationAfterSelectHooks, filmsTranslationHook)
+	case boil.BeforeInsertHook:
+		filmsTranslationBeforeInsertHooks = append(filmsTranslationBeforeInsertHooks, filmsTranslationHook)
+	case boil.AfterInsertHook:
+		filmsTranslationAfterInsertHooks = append(filmsTranslationAfterInsertHooks, filmsTranslationHook)
+	case boil.BeforeUpdateHook:
+		filmsTranslationBeforeUpdateHooks = append(filmsTranslationBeforeUpdateHooks, filmsTranslationHook)
+	case boil.AfterUpdateHook:
+		filmsTranslationAfterUpdateHooks = append(filmsTranslationAfterUpdateHooks, filmsTranslationHook)
+	case boil.BeforeDeleteHook:
+		filmsTranslationBeforeDeleteHooks = append(filmsTranslationBeforeDeleteHooks, filmsTranslationHook)
+	case boil.AfterDeleteHook:
+		filmsTranslationAfterDeleteHooks = append(filmsTranslationAfterDeleteHooks, filmsTranslationHook)
+	case boil.BeforeUpsertHook:
+		filmsTranslationBeforeUpsertHooks = append(filmsTranslationBeforeUpsertHooks, filmsTranslationHook)
+	case boil.AfterUpsertHook:
+		filmsTranslationAfterUpsertHooks = append(filmsTranslationAfterUpsertHooks, filmsTranslationHook)
+	}
+}
+
+// One returns a single filmsTranslation record from the query.
+func (q filmsTranslationQuery) One(ctx context.Context, exec boil.ContextExecutor) (*FilmsTranslation, error) {
+	o := &FilmsTranslation{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for films_translations")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all FilmsTranslation records from the query.
+func (q filmsTranslationQuery) All(ctx context.Context, exec boil.ContextExecutor) (FilmsTranslationSlice, error) {
+	var o []*FilmsTranslation
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to FilmsTranslation slice")
+	}
+
+	if len(filmsTranslationAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all FilmsTranslation records in the query.
+func (q filmsTranslationQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count films_translations rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q filmsTranslationQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if films_translations exists")
+	}
+
+	return count > 0, nil
+}
+
+// Film pointed to by the foreign key.
+func (o *FilmsTranslation) Film(mods ...qm.QueryMod) filmQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("`id` = ?", o.FilmID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Films(queryMods...)
+}
+
+// LoadFilm allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (filmsTranslationL) LoadFilm(ctx context.Context, e boil.ContextExecutor, singular bool, maybeFilmsTranslation interface{}, mods queries.Applicator) error {
+	var slice []*FilmsTranslation
+	var object *FilmsTranslation
+
+	if singular {
+		var ok bool
+		object, ok = maybeFilmsTranslation.(*FilmsTranslation)
+		if !ok {
+			object = new(FilmsTranslation)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeFilmsTranslation)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeFilmsTranslation))
+			}
+		}
+	} else {
+		s, ok := maybeFilmsTranslation.(*[]*FilmsTranslation)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeFilmsTranslation)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeFilmsTranslation))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &filmsTranslationR{}
+		}
+		args = append(args, object.FilmID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &filmsTranslationR{}
+			}
+
+			for _, a := range args {
+				if a == obj.FilmID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.FilmID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`films`),
+		qm.WhereIn(`films.id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Film")
+	}
+
+	var resultSlice []*Film
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Film")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for films")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for films")
+	}
+
+	if len(filmsTranslationAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Film = foreign
+		if foreign.R == nil {
+			foreign.R = &filmR{}
+		}
+		foreign.R.FilmsTranslations = append(foreign.R.FilmsTranslations, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.FilmID == foreign.ID {
+				local.R.Film = foreign
+				if foreign.R == nil {
+					foreign.R = &filmR{}
+				}
+				foreign.R.FilmsTranslations = append(foreign.R.FilmsTranslations, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetFilm of the filmsTranslation to the related item.
+// Sets o.R.Film to related.
+// Adds o to related.R.FilmsTranslations.
+func (o *FilmsTranslation) SetFilm(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Film) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE `films_translations` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, []string{"film_id"}),
+		strmangle.WhereClause("`", "`", 0, filmsTranslationPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.FilmID, o.Locale}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.FilmID = related.ID
+	if o.R == nil {
+		o.R = &filmsTranslationR{
+			Film: related,
+		}
+	} else {
+		o.R.Film = related
+	}
+
+	if related.R == nil {
+		related.R = &filmR{
+			FilmsTranslations: FilmsTranslationSlice{o},
+		}
+	} else {
+		related.R.FilmsTranslations = append(related.R.FilmsTranslations, o)
+	}
+
+	return nil
+}
+
+// FilmsTranslations retrieves all the records using an executor.
+func FilmsTranslations(mods ...qm.QueryMod) filmsTranslationQuery {
+	mods = append(mods, qm.From("`films_translations`"))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"`films_translations`.*"})
+	}
+
+	return filmsTranslationQuery{q}
+}
+
+// FindFilmsTranslation retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindFilmsTranslation(ctx context.Context, exec boil.ContextExecutor, filmID int, locale string, selectCols ...string) (*FilmsTranslation, error) {
+	filmsTranslationObj := &FilmsTranslation{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from `films_translations` where `film_id`=? AND `locale`=?", sel,
+	)
+
+	q := queries.Raw(query, filmID, locale)
+
+	err := q.Bind(ctx, exec, filmsTranslationObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from films_translations")
+	}
+
+	if err = filmsTranslationObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return filmsTranslationObj, err
+	}
+
+	return filmsTranslationObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *FilmsTranslation) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no films_translations provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+		if o.UpdatedAt.IsZero() {
+			o.UpdatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(filmsTranslationColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	filmsTranslationInsertCacheMut.RLock()
+	cache, cached := filmsTranslationInsertCache[key]
+	filmsTranslationInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			filmsTranslationAllColumns,
+			filmsTranslationColumnsWithDefault,
+			filmsTranslationColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(filmsTranslationType, filmsTranslationMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(filmsTranslationType, filmsTranslationMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO `films_translations` (`%s`) %%sVALUES (%s)%%s", strings.Join(wl, "`,`"), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO `films_translations` () VALUES ()%s%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			cache.retQuery = fmt.Sprintf("SELECT `%s` FROM `films_translations` WHERE %s", strings.Join(returnColumns, "`,`"), strmangle.WhereClause("`", "`", 0, filmsTranslationPrimaryKeyColumns))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	_, err = exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into films_translations")
+	}
+
+	var identifierCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	identifierCols = []interface{}{
+		o.FilmID,
+		o.Locale,
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, identifierCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to populate default values for films_translations")
+	}
+
+CacheNoHooks:
+	if !cached {
+		filmsTranslationInsertCacheMut.Lock()
+		filmsTranslationInsertCache[key] = cache
+		filmsTranslationInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the FilmsTranslation.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *FilmsTranslation) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		o.UpdatedAt = currTime
+	}
+
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	filmsTranslationUpdateCacheMut.RLock()
+	cache, cached := filmsTranslationUpdateCache[key]
+	filmsTranslationUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			filmsTranslationAllColumns,
+			filmsTranslationPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update films_translations, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE `films_translations` SET %s WHERE %s",
+			strmangle.SetParamNames("`", "`", 0, wl),
+			strmangle.WhereClause("`", "`", 0, filmsTranslationPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(filmsTranslationType, filmsTranslationMapping, append(wl, filmsTranslationPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update films_translations row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for films_translations")
+	}
+
+	if !cached {
+		filmsTranslationUpdateCacheMut.Lock()
+		filmsTranslationUpdateCache[key] = cache
+		filmsTranslationUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q filmsTranslationQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for films_translations")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for films_translations")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o FilmsTranslationSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), filmsTranslationPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE `films_translations` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, filmsTranslationPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in filmsTranslation slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all filmsTranslation")
+	}
+	return rowsAff, nil
+}
+
+var mySQLFilmsTranslationUniqueColumns = []string{}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *FilmsTranslation) Upsert(ctx context.Context, exec boil.ContextExecutor, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no films_translations provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+		o.UpdatedAt = currTime
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(filmsTranslationColumnsWithDefault, o)
+	nzUniques := queries.NonZeroDefaultSet(mySQLFilmsTranslationUniqueColumns, o)
+
+	if len(nzUniques) == 0 {
+		return errors.New("cannot upsert with a table that cannot conflict on a unique column")
+	}
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzUniques {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	filmsTranslationUpsertCacheMut.RLock()
+	cache, cached := filmsTranslationUpsertCache[key]
+	filmsTranslationUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			filmsTranslationAllColumns,
+			filmsTranslationColumnsWithDefault,
+			filmsTranslationColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			filmsTranslationAllColumns,
+			filmsTranslationPrimaryKeyColumns,
+		)
+
+		if !updateColumns.IsNone() && len(update) == 0 {
+			return errors.New("models: unable to upsert films_translations, could not build update column list")
+		}
+
+		ret = strmangle.SetComplement(ret, nzUniques)
+		cache.query = buildUpsertQueryMySQL(dialect, "`films_translations`", update, insert)
+		cache.retQuery = fmt.Sprintf(
+			"SELECT %s FROM `films_translations` WHERE %s",
+			strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, ret), ","),
+			strmangle.WhereClause("`", "`", 0, nzUniques),
+		)
+
+		cache.valueMapping, err = queries.BindMapping(filmsTranslationType, filmsTranslationMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(filmsTranslationType, filmsTranslationMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	_, err = exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert for films_translations")
+	}
+
+	var uniqueMap []uint64
+	var nzUniqueCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	uniqueMap, err = queries.BindMapping(filmsTranslationType, filmsTranslationMapping, nzUniques)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to retrieve unique values for films_translations")
+	}
+	nzUniqueCols = queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), uniqueMap)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, nzUniqueCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, nzUniqueCols...).Scan(returns...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to populate default values for films_translations")
+	}
+
+CacheNoHooks:
+	if !cached {
+		filmsTranslationUpsertCacheMut.Lock()
+		filmsTranslationUpsertCache[key] = cache
+		filmsTranslationUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single FilmsTranslation record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *FilmsTranslation) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no FilmsTranslation provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), filmsTranslationPrimaryKeyMapping)
+	sql := "DELETE FROM `films_translations` WHERE `film_id`=? AND `locale`=?"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from films_translations")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for films_translations")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q filmsTranslationQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no filmsTranslationQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from films_translations")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for films_translations")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o FilmsTranslationSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(filmsTranslationBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), filmsTranslationPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM `films_translations` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, filmsTranslationPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from filmsTranslation slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for films_translations")
+	}
+
+	if len(filmsTranslationAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *FilmsTranslation) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindFilmsTranslation(ctx, exec, o.FilmID, o.Locale)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *FilmsTranslationSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := FilmsTranslationSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), filmsTranslationPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT `films_translations`.* FROM `films_translations` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, filmsTranslationPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in FilmsTranslationSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// FilmsTranslationExists checks if the FilmsTranslation row exists.
+func FilmsTranslationExists(ctx context.Context, exec boil.ContextExecutor, filmID int, locale string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from `films_translations` where `film_id`=? AND `locale`=? limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, filmID, locale)
+	}
+	row := exec.QueryRowContext(ctx, sql, filmID, locale)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if films_translations exists")
+	}
+
+	return exists, nil
+}
@@ -25,129 +25,259 @@ import (
 
 // Planet is an object representing the database table.
 type Planet struct {
-	ID        int        `boil:"id" json:"id" toml:"id" yaml:"id"`
-	CreatedAt time.Time  `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	UpdatedAt time.Time  `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
-	DeletedAt null.Time  `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
-	Name      string     `boil:"name" json:"name" toml:"name" yaml:"name"`
-	Climates  types.JSON `boil:"climates" json:"climates" toml:"climates" yaml:"climates"`
-	Terrains  types.JSON `boil:"terrains" json:"terrains" toml:"terrains" yaml:"terrains"`
+	ID               int          `boil:"id" json:"id" toml:"id" yaml:"id"`
+	CreatedAt        time.Time    `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt        time.Time    `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	DeletedAt        null.Time    `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
+	Name             string       `boil:"name" json:"name" toml:"name" yaml:"name"`
+	Climates         types.JSON   `boil:"climates" json:"climates" toml:"climates" yaml:"climates"`
+	Terrains         types.JSON   `boil:"terrains" json:"terrains" toml:"terrains" yaml:"terrains"`
+	SearchAttributes null.String  `boil:"search_attributes" json:"search_attributes,omitempty" toml:"search_attributes" yaml:"search_attributes,omitempty"`
+	RotationPeriod   null.Int     `boil:"rotation_period" json:"rotation_period,omitempty" toml:"rotation_period" yaml:"rotation_period,omitempty"`
+	OrbitalPeriod    null.Int     `boil:"orbital_period" json:"orbital_period,omitempty" toml:"orbital_period" yaml:"orbital_period,omitempty"`
+	Diameter         null.Int     `boil:"diameter" json:"diameter,omitempty" toml:"diameter" yaml:"diameter,omitempty"`
+	Gravity          null.String  `boil:"gravity" json:"gravity,omitempty" toml:"gravity" yaml:"gravity,omitempty"`
+	SurfaceWater     null.Float64 `boil:"surface_water" json:"surface_water,omitempty" toml:"surface_water" yaml:"surface_water,omitempty"`
+	Population       null.Int64   `boil:"population" json:"population,omitempty" toml:"population" yaml:"population,omitempty"`
 
 	R *planetR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L planetL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var PlanetColumns = struct {
-	ID        string
-	CreatedAt string
-	UpdatedAt string
-	DeletedAt string
-	Name      string
-	Climates  string
-	Terrains  string
+	ID               string
+	CreatedAt        string
+	UpdatedAt        string
+	DeletedAt        string
+	Name             string
+	Climates         string
+	Terrains         string
+	SearchAttributes string
+	RotationPeriod   string
+	OrbitalPeriod    string
+	Diameter         string
+	Gravity          string
+	SurfaceWater     string
+	Population       string
 }{
-	ID:        "id",
-	CreatedAt: "created_at",
-	UpdatedAt: "updated_at",
-	DeletedAt: "deleted_at",
-	Name:      "name",
-	Climates:  "climates",
-	Terrains:  "terrains",
+	ID:               "id",
+	CreatedAt:        "created_at",
+	UpdatedAt:        "updated_at",
+	DeletedAt:        "deleted_at",
+	Name:             "name",
+	Climates:         "climates",
+	Terrains:         "terrains",
+	SearchAttributes: "search_attributes",
+	RotationPeriod:   "rotation_period",
+	OrbitalPeriod:    "orbital_period",
+	Diameter:         "diameter",
+	Gravity:          "gravity",
+	SurfaceWater:     "surface_water",
+	Population:       "population",
 }
 
 var PlanetTableColumns = struct {
-	ID        string
-	CreatedAt string
-	UpdatedAt string
-	DeletedAt string
-	Name      string
-	Climates  string
-	Terrains  string
+	ID               string
+	CreatedAt        string
+	UpdatedAt        string
+	DeletedAt        string
+	Name             string
+	Climates         string
+	Terrains         string
+	SearchAttributes string
+	RotationPeriod   string
+	OrbitalPeriod    string
+	Diameter         string
+	Gravity          string
+	SurfaceWater     string
+	Population       string
 }{
-	ID:        "planets.id",
-	CreatedAt: "planets.created_at",
-	UpdatedAt: "planets.updated_at",
-	DeletedAt: "planets.deleted_at",
-	Name:      "planets.name",
-	Climates:  "planets.climates",
-	Terrains:  "planets.terrains",
+	ID:               "planets.id",
+	CreatedAt:        "planets.created_at",
+	UpdatedAt:        "planets.updated_at",
+	DeletedAt:        "planets.deleted_at",
+	Name:             "planets.name",
+	Climates:         "planets.climates",
+	Terrains:         "planets.terrains",
+	SearchAttributes: "planets.search_attributes",
+	RotationPeriod:   "planets.rotation_period",
+	OrbitalPeriod:    "planets.orbital_period",
+	Diameter:         "planets.diameter",
+	Gravity:          "planets.gravity",
+	SurfaceWater:     "planets.surface_water",
+	Population:       "planets.population",
 }
 
 // Generated where
 
-type whereHelpernull_Time struct{ field string }
+type whereHelpernull_Int struct{ field string }
 
-func (w whereHelpernull_Time) EQ(x null.Time) qm.QueryMod {
+func (w whereHelpernull_Int) EQ(x null.Int) qm.QueryMod {
 	return qmhelper.WhereNullEQ(w.field, false, x)
 }
-func (w whereHelpernull_Time) NEQ(x null.Time) qm.QueryMod {
+func (w whereHelpernull_Int) NEQ(x null.Int) qm.QueryMod {
 	return qmhelper.WhereNullEQ(w.field, true, x)
 }
-func (w whereHelpernull_Time) LT(x null.Time) qm.QueryMod {
+func (w whereHelpernull_Int) LT(x null.Int) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.LT, x)
 }
-func (w whereHelpernull_Time) LTE(x null.Time) qm.QueryMod {
+func (w whereHelpernull_Int) LTE(x null.Int) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.LTE, x)
 }
-func (w whereHelpernull_Time) GT(x null.Time) qm.QueryMod {
+func (w whereHelpernull_Int) GT(x null.Int) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GT, x)
 }
-func (w whereHelpernull_Time) GTE(x null.Time) qm.QueryMod {
+func (w whereHelpernull_Int) GTE(x null.Int) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GTE, x)
 }
+func (w whereHelpernull_Int) IN(slice []int) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelpernull_Int) NIN(slice []int) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+func (w whereHelpernull_Int) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Int) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
+type whereHelpernull_Float64 struct{ field string }
+
+func (w whereHelpernull_Float64) EQ(x null.Float64) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Float64) NEQ(x null.Float64) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Float64) LT(x null.Float64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Float64) LTE(x null.Float64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Float64) GT(x null.Float64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Float64) GTE(x null.Float64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+func (w whereHelpernull_Float64) IN(slice []float64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelpernull_Float64) NIN(slice []float64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
 
-func (w whereHelpernull_Time) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
-func (w whereHelpernull_Time) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+func (w whereHelpernull_Float64) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Float64) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
 
-type whereHelpertypes_JSON struct{ field string }
+type whereHelpernull_Int64 struct{ field string }
 
-func (w whereHelpertypes_JSON) EQ(x types.JSON) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.EQ, x)
+func (w whereHelpernull_Int64) EQ(x null.Int64) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
 }
-func (w whereHelpertypes_JSON) NEQ(x types.JSON) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.NEQ, x)
+func (w whereHelpernull_Int64) NEQ(x null.Int64) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
 }
-func (w whereHelpertypes_JSON) LT(x types.JSON) qm.QueryMod {
+func (w whereHelpernull_Int64) LT(x null.Int64) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.LT, x)
 }
-func (w whereHelpertypes_JSON) LTE(x types.JSON) qm.QueryMod {
+func (w whereHelpernull_Int64) LTE(x null.Int64) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.LTE, x)
 }
-func (w whereHelpertypes_JSON) GT(x types.JSON) qm.QueryMod {
+func (w whereHelpernull_Int64) GT(x null.Int64) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GT, x)
 }
-func (w whereHelpertypes_JSON) GTE(x types.JSON) qm.QueryMod {
+func (w whereHelpernull_Int64) GTE(x null.Int64) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GTE, x)
 }
+func (w whereHelpernull_Int64) IN(slice []int64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelpernull_Int64) NIN(slice []int64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+func (w whereHelpernull_Int64) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Int64) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
 
 var PlanetWhere = struct {
-	ID        whereHelperint
-	CreatedAt whereHelpertime_Time
-	UpdatedAt whereHelpertime_Time
-	DeletedAt whereHelpernull_Time
-	Name      whereHelperstring
-	Climates  whereHelpertypes_JSON
-	Terrains  whereHelpertypes_JSON
+	ID               whereHelperint
+	CreatedAt        whereHelpertime_Time
+	UpdatedAt        whereHelpertime_Time
+	DeletedAt        whereHelpernull_Time
+	Name             whereHelperstring
+	Climates         whereHelpertypes_JSON
+	Terrains         whereHelpertypes_JSON
+	SearchAttributes whereHelpernull_String
+	RotationPeriod   whereHelpernull_Int
+	OrbitalPeriod    whereHelpernull_Int
+	Diameter         whereHelpernull_Int
+	Gravity          whereHelpernull_String
+	SurfaceWater     whereHelpernull_Float64
+	Population       whereHelpernull_Int64
 }{
-	ID:        whereHelperint{field: "`planets`.`id`"},
-	CreatedAt: whereHelpertime_Time{field: "`planets`.`created_at`"},
-	UpdatedAt: whereHelpertime_Time{field: "`planets`.`updated_at`"},
-	DeletedAt: whereHelpernull_Time{field: "`planets`.`deleted_at`"},
-	Name:      whereHelperstring{field: "`planets`.`name`"},
-	Climates:  whereHelpertypes_JSON{field: "`planets`.`climates`"},
-	Terrains:  whereHelpertypes_JSON{field: "`planets`.`terrains`"},
+	ID:               whereHelperint{field: "`planets`.`id`"},
+	CreatedAt:        whereHelpertime_Time{field: "`planets`.`created_at`"},
+	UpdatedAt:        whereHelpertime_Time{field: "`planets`.`updated_at`"},
+	DeletedAt:        whereHelpernull_Time{field: "`planets`.`deleted_at`"},
+	Name:             whereHelperstring{field: "`planets`.`name`"},
+	Climates:         whereHelpertypes_JSON{field: "`planets`.`climates`"},
+	Terrains:         whereHelpertypes_JSON{field: "`planets`.`terrains`"},
+	SearchAttributes: whereHelpernull_String{field: "`planets`.`search_attributes`"},
+	RotationPeriod:   whereHelpernull_Int{field: "`planets`.`rotation_period`"},
+	OrbitalPeriod:    whereHelpernull_Int{field: "`planets`.`orbital_period`"},
+	Diameter:         whereHelpernull_Int{field: "`planets`.`diameter`"},
+	Gravity:          whereHelpernull_String{field: "`planets`.`gravity`"},
+	SurfaceWater:     whereHelpernull_Float64{field: "`planets`.`surface_water`"},
+	Population:       whereHelpernull_Int64{field: "`planets`.`population`"},
 }
 
 // PlanetRels is where relationship names are stored.
 var PlanetRels = struct {
-	Films string
+	PlanetClimates      string
+	Films               string
+	PlanetsHistories    string
+	PlanetTerrains      string
+	PlanetsTranslations string
 }{
-	Films: "Films",
+	PlanetClimates:      "PlanetClimates",
+	Films:               "Films",
+	PlanetsHistories:    "PlanetsHistories",
+	PlanetTerrains:      "PlanetTerrains",
+	PlanetsTranslations: "PlanetsTranslations",
 }
 
 // planetR is where relationships are stored.
 type planetR struct {
-	Films FilmSlice `boil:"Films" json:"Films" toml:"Films" yaml:"Films"`
+	PlanetClimates      ClimateSlice            `boil:"PlanetClimates" json:"PlanetClimates" toml:"PlanetClimates" yaml:"PlanetClimates"`
+	Films               FilmSlice               `boil:"Films" json:"Films" toml:"Films" yaml:"Films"`
+	PlanetsHistories    PlanetsHistorySlice     `boil:"PlanetsHistories" json:"PlanetsHistories" toml:"PlanetsHistories" yaml:"PlanetsHistories"`
+	PlanetTerrains      TerrainSlice            `boil:"PlanetTerrains" json:"PlanetTerrains" toml:"PlanetTerrains" yaml:"PlanetTerrains"`
+	PlanetsTranslations PlanetsTranslationSlice `boil:"PlanetsTranslations" json:"PlanetsTranslations" toml:"PlanetsTranslations" yaml:"PlanetsTranslations"`
 }
 
 // NewStruct creates a new relationship struct
@@ -155,6 +285,13 @@ func (*planetR) NewStruct() *planetR {
 	return &planetR{}
 }
 
+func (r *planetR) GetPlanetClimates() ClimateSlice {
+	if r == nil {
+		return nil
+	}
+	return r.PlanetClimates
+}
+
 func (r *planetR) GetFilms() FilmSlice {
 	if r == nil {
 		return nil
@@ -162,15 +299,36 @@ func (r *planetR) GetFilms() FilmSlice {
 	return r.Films
 }
 
+func (r *planetR) GetPlanetsHistories() PlanetsHistorySlice {
+	if r == nil {
+		return nil
+	}
+	return r.PlanetsHistories
+}
+
+func (r *planetR) GetPlanetTerrains() TerrainSlice {
+	if r == nil {
+		return nil
+	}
+	return r.PlanetTerrains
+}
+
+func (r *planetR) GetPlanetsTranslations() PlanetsTranslationSlice {
+	if r == nil {
+		return nil
+	}
+	return r.PlanetsTranslations
+}
+
 // planetL is where Load methods for each relationship are stored.
 type planetL struct{}
 
 var (
-	planetAllColumns            = []string{"id", "created_at", "updated_at", "deleted_at", "name", "climates", "terrains"}
-	planetColumnsWithoutDefault = []string{"created_at", "updated_at", "deleted_at", "name", "climates", "terrains"}
-	planetColumnsWithDefault    = []string{"id"}
+	planetAllColumns            = []string{"id", "created_at", "updated_at", "deleted_at", "name", "climates", "terrains", "search_attributes", "rotation_period", "orbital_period", "diameter", "gravity", "surface_water", "population"}
+	planetColumnsWithoutDefault = []string{"created_at", "updated_at", "deleted_at", "name", "climates", "terrains", "rotation_period", "orbital_period", "diameter", "gravity", "surface_water", "population"}
+	planetColumnsWithDefault    = []string{"id", "search_attributes"}
 	planetPrimaryKeyColumns     = []string{"id"}
-	planetGeneratedColumns      = []string{}
+	planetGeneratedColumns      = []string{"search_attributes"}
 )
 
 type (
@@ -451,6 +609,21 @@ func (q planetQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (boo
 	return count > 0, nil
 }
 
+// PlanetClimates retrieves all the climate's Climates with an executor via id column.
+func (o *Planet) PlanetClimates(mods ...qm.QueryMod) climateQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.InnerJoin("`planets_climates` on `climates`.`id` = `planets_climates`.`climate_id`"),
+		qm.Where("`planets_climates`.`planet_id`=?", o.ID),
+	)
+
+	return Climates(queryMods...)
+}
+
 // Films retrieves all the film's Films with an executor.
 func (o *Planet) Films(mods ...qm.QueryMod) filmQuery {
 	var queryMods []qm.QueryMod
@@ -466,6 +639,180 @@ func (o *Planet) Films(mods ...qm.QueryMod) filmQuery {
 	return Films(queryMods...)
 }
 
+// PlanetsHistories retrieves all the planets_history's PlanetsHistories with an executor.
+func (o *Planet) PlanetsHistories(mods ...qm.QueryMod) planetsHistoryQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("`planets_history`.`planet_id`=?", o.ID),
+	)
+
+	return PlanetsHistories(queryMods...)
+}
+
+// PlanetTerrains retrieves all the terrain's Terrains with an executor via id column.
+func (o *Planet) PlanetTerrains(mods ...qm.QueryMod) terrainQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.InnerJoin("`planets_terrains` on `terrains`.`id` = `planets_terrains`.`terrain_id`"),
+		qm.Where("`planets_terrains`.`planet_id`=?", o.ID),
+	)
+
+	return Terrains(queryMods...)
+}
+
+// PlanetsTranslations retrieves all the planets_translation's PlanetsTranslations with an executor.
+func (o *Planet) PlanetsTranslations(mods ...qm.QueryMod) planetsTranslationQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("`planets_translations`.`planet_id`=?", o.ID),
+	)
+
+	return PlanetsTranslations(queryMods...)
+}
+
+// LoadPlanetClimates allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (planetL) LoadPlanetClimates(ctx context.Context, e boil.ContextExecutor, singular bool, maybePlanet interface{}, mods queries.Applicator) error {
+	var slice []*Planet
+	var object *Planet
+
+	if singular {
+		var ok bool
+		object, ok = maybePlanet.(*Planet)
+		if !ok {
+			object = new(Planet)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybePlanet)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybePlanet))
+			}
+		}
+	} else {
+		s, ok := maybePlanet.(*[]*Planet)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybePlanet)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybePlanet))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &planetR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &planetR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.Select("`climates`.`id`, `climates`.`name`, `a`.`planet_id`"),
+		qm.From("`climates`"),
+		qm.InnerJoin("`planets_climates` as `a` on `climates`.`id` = `a`.`climate_id`"),
+		qm.WhereIn("`a`.`planet_id` in ?", args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load climates")
+	}
+
+	var resultSlice []*Climate
+
+	var localJoinCols []int
+	for results.Next() {
+		one := new(Climate)
+		var localJoinCol int
+
+		err = results.Scan(&one.ID, &one.Name, &localJoinCol)
+		if err != nil {
+			return errors.Wrap(err, "failed to scan eager loaded results for climates")
+		}
+		if err = results.Err(); err != nil {
+			return errors.Wrap(err, "failed to plebian-bind eager loaded slice climates")
+		}
+
+		resultSlice = append(resultSlice, one)
+		localJoinCols = append(localJoinCols, localJoinCol)
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on climates")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for climates")
+	}
+
+	if len(climateAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.PlanetClimates = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &climateR{}
+			}
+			foreign.R.Planets = append(foreign.R.Planets, object)
+		}
+		return nil
+	}
+
+	for i, foreign := range resultSlice {
+		localJoinCol := localJoinCols[i]
+		for _, local := range slice {
+			if local.ID == localJoinCol {
+				local.R.PlanetClimates = append(local.R.PlanetClimates, foreign)
+				if foreign.R == nil {
+					foreign.R = &climateR{}
+				}
+				foreign.R.Planets = append(foreign.R.Planets, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // LoadFilms allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
 func (planetL) LoadFilms(ctx context.Context, e boil.ContextExecutor, singular bool, maybePlanet interface{}, mods queries.Applicator) error {
@@ -522,7 +869,7 @@ func (planetL) LoadFilms(ctx context.Context, e boil.ContextExecutor, singular b
 	}
 
 	query := NewQuery(
-		qm.Select("`films`.`id`, `films`.`created_at`, `films`.`updated_at`, `films`.`title`, `films`.`episode`, `films`.`director`, `films`.`release_date`, `a`.`planet_id`"),
+		qm.Select("`films`.`id`, `films`.`created_at`, `films`.`updated_at`, `films`.`title`, `films`.`episode`, `films`.`director`, `films`.`release_date`, `films`.`opening_crawl`, `films`.`producer`, `a`.`planet_id`"),
 		qm.From("`films`"),
 		qm.InnerJoin("`planets_films` as `a` on `films`.`id` = `a`.`film_id`"),
 		qm.WhereIn("`a`.`planet_id` in ?", args...),
@@ -530,71 +877,575 @@ func (planetL) LoadFilms(ctx context.Context, e boil.ContextExecutor, singular b
 	if mods != nil {
 		mods.Apply(query)
 	}
-
-	results, err := query.QueryContext(ctx, e)
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load films")
+	}
+
+	var resultSlice []*Film
+
+	var localJoinCols []int
+	for results.Next() {
+		one := new(Film)
+		var localJoinCol int
+
+		err = results.Scan(&one.ID, &one.CreatedAt, &one.UpdatedAt, &one.Title, &one.Episode, &one.Director, &one.ReleaseDate, &one.OpeningCrawl, &one.Producer, &localJoinCol)
+		if err != nil {
+			return errors.Wrap(err, "failed to scan eager loaded results for films")
+		}
+		if err = results.Err(); err != nil {
+			return errors.Wrap(err, "failed to plebian-bind eager loaded slice films")
+		}
+
+		resultSlice = append(resultSlice, one)
+		localJoinCols = append(localJoinCols, localJoinCol)
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on films")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for films")
+	}
+
+	if len(filmAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Films = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &filmR{}
+			}
+			foreign.R.Planets = append(foreign.R.Planets, object)
+		}
+		return nil
+	}
+
+	for i, foreign := range resultSlice {
+		localJoinCol := localJoinCols[i]
+		for _, local := range slice {
+			if local.ID == localJoinCol {
+				local.R.Films = append(local.R.Films, foreign)
+				if foreign.R == nil {
+					foreign.R = &filmR{}
+				}
+				foreign.R.Planets = append(foreign.R.Planets, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadPlanetsHistories allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (planetL) LoadPlanetsHistories(ctx context.Context, e boil.ContextExecutor, singular bool, maybePlanet interface{}, mods queries.Applicator) error {
+	var slice []*Planet
+	var object *Planet
+
+	if singular {
+		var ok bool
+		object, ok = maybePlanet.(*Planet)
+		if !ok {
+			object = new(Planet)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybePlanet)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybePlanet))
+			}
+		}
+	} else {
+		s, ok := maybePlanet.(*[]*Planet)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybePlanet)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybePlanet))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &planetR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &planetR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`planets_history`),
+		qm.WhereIn(`planets_history.planet_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load planets_history")
+	}
+
+	var resultSlice []*PlanetsHistory
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice planets_history")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on planets_history")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for planets_history")
+	}
+
+	if len(planetsHistoryAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.PlanetsHistories = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &planetsHistoryR{}
+			}
+			foreign.R.Planet = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.PlanetID {
+				local.R.PlanetsHistories = append(local.R.PlanetsHistories, foreign)
+				if foreign.R == nil {
+					foreign.R = &planetsHistoryR{}
+				}
+				foreign.R.Planet = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadPlanetTerrains allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (planetL) LoadPlanetTerrains(ctx context.Context, e boil.ContextExecutor, singular bool, maybePlanet interface{}, mods queries.Applicator) error {
+	var slice []*Planet
+	var object *Planet
+
+	if singular {
+		var ok bool
+		object, ok = maybePlanet.(*Planet)
+		if !ok {
+			object = new(Planet)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybePlanet)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybePlanet))
+			}
+		}
+	} else {
+		s, ok := maybePlanet.(*[]*Planet)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybePlanet)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybePlanet))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &planetR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &planetR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.Select("`terrains`.`id`, `terrains`.`name`, `a`.`planet_id`"),
+		qm.From("`terrains`"),
+		qm.InnerJoin("`planets_terrains` as `a` on `terrains`.`id` = `a`.`terrain_id`"),
+		qm.WhereIn("`a`.`planet_id` in ?", args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load terrains")
+	}
+
+	var resultSlice []*Terrain
+
+	var localJoinCols []int
+	for results.Next() {
+		one := new(Terrain)
+		var localJoinCol int
+
+		err = results.Scan(&one.ID, &one.Name, &localJoinCol)
+		if err != nil {
+			return errors.Wrap(err, "failed to scan eager loaded results for terrains")
+		}
+		if err = results.Err(); err != nil {
+			return errors.Wrap(err, "failed to plebian-bind eager loaded slice terrains")
+		}
+
+		resultSlice = append(resultSlice, one)
+		localJoinCols = append(localJoinCols, localJoinCol)
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on terrains")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for terrains")
+	}
+
+	if len(terrainAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.PlanetTerrains = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &terrainR{}
+			}
+			foreign.R.Planets = append(foreign.R.Planets, object)
+		}
+		return nil
+	}
+
+	for i, foreign := range resultSlice {
+		localJoinCol := localJoinCols[i]
+		for _, local := range slice {
+			if local.ID == localJoinCol {
+				local.R.PlanetTerrains = append(local.R.PlanetTerrains, foreign)
+				if foreign.R == nil {
+					foreign.R = &terrainR{}
+				}
+				foreign.R.Planets = append(foreign.R.Planets, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadPlanetsTranslations allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (planetL) LoadPlanetsTranslations(ctx context.Context, e boil.ContextExecutor, singular bool, maybePlanet interface{}, mods queries.Applicator) error {
+	var slice []*Planet
+	var object *Planet
+
+	if singular {
+		var ok bool
+		object, ok = maybePlanet.(*Planet)
+		if !ok {
+			object = new(Planet)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybePlanet)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybePlanet))
+			}
+		}
+	} else {
+		s, ok := maybePlanet.(*[]*Planet)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybePlanet)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybePlanet))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &planetR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &planetR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`planets_translations`),
+		qm.WhereIn(`planets_translations.planet_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load planets_translations")
+	}
+
+	var resultSlice []*PlanetsTranslation
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice planets_translations")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on planets_translations")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for planets_translations")
+	}
+
+	if len(planetsTranslationAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.PlanetsTranslations = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &planetsTranslationR{}
+			}
+			foreign.R.Planet = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.PlanetID {
+				local.R.PlanetsTranslations = append(local.R.PlanetsTranslations, foreign)
+				if foreign.R == nil {
+					foreign.R = &planetsTranslationR{}
+				}
+				foreign.R.Planet = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// AddPlanetClimates adds the given related objects to the existing relationships
+// of the planet, optionally inserting them as new records.
+// Appends related to o.R.PlanetClimates.
+// Sets related.R.Planets appropriately.
+func (o *Planet) AddPlanetClimates(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Climate) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		}
+	}
+
+	for _, rel := range related {
+		query := "insert into `planets_climates` (`planet_id`, `climate_id`) values (?, ?)"
+		values := []interface{}{o.ID, rel.ID}
+
+		if boil.IsDebug(ctx) {
+			writer := boil.DebugWriterFrom(ctx)
+			fmt.Fprintln(writer, query)
+			fmt.Fprintln(writer, values)
+		}
+		_, err = exec.ExecContext(ctx, query, values...)
+		if err != nil {
+			return errors.Wrap(err, "failed to insert into join table")
+		}
+	}
+	if o.R == nil {
+		o.R = &planetR{
+			PlanetClimates: related,
+		}
+	} else {
+		o.R.PlanetClimates = append(o.R.PlanetClimates, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &climateR{
+				Planets: PlanetSlice{o},
+			}
+		} else {
+			rel.R.Planets = append(rel.R.Planets, o)
+		}
+	}
+	return nil
+}
+
+// SetPlanetClimates removes all previously related items of the
+// planet replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.Planets's PlanetClimates accordingly.
+// Replaces o.R.PlanetClimates with related.
+// Sets related.R.Planets's PlanetClimates accordingly.
+func (o *Planet) SetPlanetClimates(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Climate) error {
+	query := "delete from `planets_climates` where `planet_id` = ?"
+	values := []interface{}{o.ID}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	removePlanetClimatesFromPlanetsSlice(o, related)
+	if o.R != nil {
+		o.R.PlanetClimates = nil
+	}
+
+	return o.AddPlanetClimates(ctx, exec, insert, related...)
+}
+
+// RemovePlanetClimates relationships from objects passed in.
+// Removes related items from R.PlanetClimates (uses pointer comparison, removal does not keep order)
+// Sets related.R.Planets.
+func (o *Planet) RemovePlanetClimates(ctx context.Context, exec boil.ContextExecutor, related ...*Climate) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	query := fmt.Sprintf(
+		"delete from `planets_climates` where `planet_id` = ? and `climate_id` in (%s)",
+		strmangle.Placeholders(dialect.UseIndexPlaceholders, len(related), 2, 1),
+	)
+	values := []interface{}{o.ID}
+	for _, rel := range related {
+		values = append(values, rel.ID)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err = exec.ExecContext(ctx, query, values...)
 	if err != nil {
-		return errors.Wrap(err, "failed to eager load films")
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+	removePlanetClimatesFromPlanetsSlice(o, related)
+	if o.R == nil {
+		return nil
 	}
 
-	var resultSlice []*Film
-
-	var localJoinCols []int
-	for results.Next() {
-		one := new(Film)
-		var localJoinCol int
+	for _, rel := range related {
+		for i, ri := range o.R.PlanetClimates {
+			if rel != ri {
+				continue
+			}
 
-		err = results.Scan(&one.ID, &one.CreatedAt, &one.UpdatedAt, &one.Title, &one.Episode, &one.Director, &one.ReleaseDate, &localJoinCol)
-		if err != nil {
-			return errors.Wrap(err, "failed to scan eager loaded results for films")
-		}
-		if err = results.Err(); err != nil {
-			return errors.Wrap(err, "failed to plebian-bind eager loaded slice films")
+			ln := len(o.R.PlanetClimates)
+			if ln > 1 && i < ln-1 {
+				o.R.PlanetClimates[i] = o.R.PlanetClimates[ln-1]
+			}
+			o.R.PlanetClimates = o.R.PlanetClimates[:ln-1]
+			break
 		}
-
-		resultSlice = append(resultSlice, one)
-		localJoinCols = append(localJoinCols, localJoinCol)
 	}
 
-	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results in eager load on films")
-	}
-	if err = results.Err(); err != nil {
-		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for films")
-	}
+	return nil
+}
 
-	if len(filmAfterSelectHooks) != 0 {
-		for _, obj := range resultSlice {
-			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
-				return err
-			}
+func removePlanetClimatesFromPlanetsSlice(o *Planet, related []*Climate) {
+	for _, rel := range related {
+		if rel.R == nil {
+			continue
 		}
-	}
-	if singular {
-		object.R.Films = resultSlice
-		for _, foreign := range resultSlice {
-			if foreign.R == nil {
-				foreign.R = &filmR{}
+		for i, ri := range rel.R.Planets {
+			if o.ID != ri.ID {
+				continue
 			}
-			foreign.R.Planets = append(foreign.R.Planets, object)
-		}
-		return nil
-	}
 
-	for i, foreign := range resultSlice {
-		localJoinCol := localJoinCols[i]
-		for _, local := range slice {
-			if local.ID == localJoinCol {
-				local.R.Films = append(local.R.Films, foreign)
-				if foreign.R == nil {
-					foreign.R = &filmR{}
-				}
-				foreign.R.Planets = append(foreign.R.Planets, local)
-				break
+			ln := len(rel.R.Planets)
+			if ln > 1 && i < ln-1 {
+				rel.R.Planets[i] = rel.R.Planets[ln-1]
 			}
+			rel.R.Planets = rel.R.Planets[:ln-1]
+			break
 		}
 	}
-
-	return nil
 }
 
 // AddFilms adds the given related objects to the existing relationships
@@ -742,6 +1593,257 @@ func removeFilmsFromPlanetsSlice(o *Planet, related []*Film) {
 	}
 }
 
+// AddPlanetsHistories adds the given related objects to the existing relationships
+// of the planet, optionally inserting them as new records.
+// Appends related to o.R.PlanetsHistories.
+// Sets related.R.Planet appropriately.
+func (o *Planet) AddPlanetsHistories(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*PlanetsHistory) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.PlanetID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE `planets_history` SET %s WHERE %s",
+				strmangle.SetParamNames("`", "`", 0, []string{"planet_id"}),
+				strmangle.WhereClause("`", "`", 0, planetsHistoryPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.PlanetID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &planetR{
+			PlanetsHistories: related,
+		}
+	} else {
+		o.R.PlanetsHistories = append(o.R.PlanetsHistories, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &planetsHistoryR{
+				Planet: o,
+			}
+		} else {
+			rel.R.Planet = o
+		}
+	}
+	return nil
+}
+
+// AddPlanetTerrains adds the given related objects to the existing relationships
+// of the planet, optionally inserting them as new records.
+// Appends related to o.R.PlanetTerrains.
+// Sets related.R.Planets appropriately.
+func (o *Planet) AddPlanetTerrains(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Terrain) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		}
+	}
+
+	for _, rel := range related {
+		query := "insert into `planets_terrains` (`planet_id`, `terrain_id`) values (?, ?)"
+		values := []interface{}{o.ID, rel.ID}
+
+		if boil.IsDebug(ctx) {
+			writer := boil.DebugWriterFrom(ctx)
+			fmt.Fprintln(writer, query)
+			fmt.Fprintln(writer, values)
+		}
+		_, err = exec.ExecContext(ctx, query, values...)
+		if err != nil {
+			return errors.Wrap(err, "failed to insert into join table")
+		}
+	}
+	if o.R == nil {
+		o.R = &planetR{
+			PlanetTerrains: related,
+		}
+	} else {
+		o.R.PlanetTerrains = append(o.R.PlanetTerrains, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &terrainR{
+				Planets: PlanetSlice{o},
+			}
+		} else {
+			rel.R.Planets = append(rel.R.Planets, o)
+		}
+	}
+	return nil
+}
+
+// SetPlanetTerrains removes all previously related items of the
+// planet replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.Planets's PlanetTerrains accordingly.
+// Replaces o.R.PlanetTerrains with related.
+// Sets related.R.Planets's PlanetTerrains accordingly.
+func (o *Planet) SetPlanetTerrains(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Terrain) error {
+	query := "delete from `planets_terrains` where `planet_id` = ?"
+	values := []interface{}{o.ID}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	removePlanetTerrainsFromPlanetsSlice(o, related)
+	if o.R != nil {
+		o.R.PlanetTerrains = nil
+	}
+
+	return o.AddPlanetTerrains(ctx, exec, insert, related...)
+}
+
+// RemovePlanetTerrains relationships from objects passed in.
+// Removes related items from R.PlanetTerrains (uses pointer comparison, removal does not keep order)
+// Sets related.R.Planets.
+func (o *Planet) RemovePlanetTerrains(ctx context.Context, exec boil.ContextExecutor, related ...*Terrain) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	query := fmt.Sprintf(
+		"delete from `planets_terrains` where `planet_id` = ? and `terrain_id` in (%s)",
+		strmangle.Placeholders(dialect.UseIndexPlaceholders, len(related), 2, 1),
+	)
+	values := []interface{}{o.ID}
+	for _, rel := range related {
+		values = append(values, rel.ID)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err = exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+	removePlanetTerrainsFromPlanetsSlice(o, related)
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.PlanetTerrains {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.PlanetTerrains)
+			if ln > 1 && i < ln-1 {
+				o.R.PlanetTerrains[i] = o.R.PlanetTerrains[ln-1]
+			}
+			o.R.PlanetTerrains = o.R.PlanetTerrains[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
+func removePlanetTerrainsFromPlanetsSlice(o *Planet, related []*Terrain) {
+	for _, rel := range related {
+		if rel.R == nil {
+			continue
+		}
+		for i, ri := range rel.R.Planets {
+			if o.ID != ri.ID {
+				continue
+			}
+
+			ln := len(rel.R.Planets)
+			if ln > 1 && i < ln-1 {
+				rel.R.Planets[i] = rel.R.Planets[ln-1]
+			}
+			rel.R.Planets = rel.R.Planets[:ln-1]
+			break
+		}
+	}
+}
+
+// AddPlanetsTranslations adds the given related objects to the existing relationships
+// of the planet, optionally inserting them as new records.
+// Appends related to o.R.PlanetsTranslations.
+// Sets related.R.Planet appropriately.
+func (o *Planet) AddPlanetsTranslations(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*PlanetsTranslation) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.PlanetID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE `planets_translations` SET %s WHERE %s",
+				strmangle.SetParamNames("`", "`", 0, []string{"planet_id"}),
+				strmangle.WhereClause("`", "`", 0, planetsTranslationPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.PlanetID, rel.Locale}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.PlanetID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &planetR{
+			PlanetsTranslations: related,
+		}
+	} else {
+		o.R.PlanetsTranslations = append(o.R.PlanetsTranslations, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &planetsTranslationR{
+				Planet: o,
+			}
+		} else {
+			rel.R.Planet = o
+		}
+	}
+	return nil
+}
+
 // Planets retrieves all the records using an executor.
 func Planets(mods ...qm.QueryMod) planetQuery {
 	mods = append(mods, qm.From("`planets`"))
@@ -820,6 +1922,7 @@ func (o *Planet) Insert(ctx context.Context, exec boil.ContextExecutor, columns
 			planetColumnsWithoutDefault,
 			nzDefaults,
 		)
+		wl = strmangle.SetComplement(wl, planetGeneratedColumns)
 
 		cache.valueMapping, err = queries.BindMapping(planetType, planetMapping, wl)
 		if err != nil {
@@ -923,6 +2026,7 @@ func (o *Planet) Update(ctx context.Context, exec boil.ContextExecutor, columns
 			planetAllColumns,
 			planetPrimaryKeyColumns,
 		)
+		wl = strmangle.SetComplement(wl, planetGeneratedColumns)
 
 		if !columns.IsWhitelist() {
 			wl = strmangle.SetComplement(wl, []string{"created_at"})
@@ -1105,6 +2209,9 @@ func (o *Planet) Upsert(ctx context.Context, exec boil.ContextExecutor, updateCo
 			planetPrimaryKeyColumns,
 		)
 
+		insert = strmangle.SetComplement(insert, planetGeneratedColumns)
+		update = strmangle.SetComplement(update, planetGeneratedColumns)
+
 		if !updateColumns.IsNone() && len(update) == 0 {
 			return errors.New("models: unable to upsert planets, could not build update column list")
 		}
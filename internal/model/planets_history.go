@@ -0,0 +1,1263 @@
+// Code generated by SQLBoiler 4.13.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package model
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/v4/types"
+	"github.com/volatiletech/strmangle"
+)
+
+// PlanetsHistory is an object representing the database table.
+type PlanetsHistory struct {
+	ID             int64        `boil:"id" json:"id" toml:"id" yaml:"id"`
+	PlanetID       int          `boil:"planet_id" json:"planet_id" toml:"planet_id" yaml:"planet_id"`
+	ValidFrom      time.Time    `boil:"valid_from" json:"valid_from" toml:"valid_from" yaml:"valid_from"`
+	ValidTo        null.Time    `boil:"valid_to" json:"valid_to,omitempty" toml:"valid_to" yaml:"valid_to,omitempty"`
+	CreatedAt      time.Time    `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt      time.Time    `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	DeletedAt      null.Time    `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
+	Name           string       `boil:"name" json:"name" toml:"name" yaml:"name"`
+	Climates       types.JSON   `boil:"climates" json:"climates" toml:"climates" yaml:"climates"`
+	Terrains       types.JSON   `boil:"terrains" json:"terrains" toml:"terrains" yaml:"terrains"`
+	RotationPeriod null.Int     `boil:"rotation_period" json:"rotation_period,omitempty" toml:"rotation_period" yaml:"rotation_period,omitempty"`
+	OrbitalPeriod  null.Int     `boil:"orbital_period" json:"orbital_period,omitempty" toml:"orbital_period" yaml:"orbital_period,omitempty"`
+	Diameter       null.Int     `boil:"diameter" json:"diameter,omitempty" toml:"diameter" yaml:"diameter,omitempty"`
+	Gravity        null.String  `boil:"gravity" json:"gravity,omitempty" toml:"gravity" yaml:"gravity,omitempty"`
+	SurfaceWater   null.Float64 `boil:"surface_water" json:"surface_water,omitempty" toml:"surface_water" yaml:"surface_water,omitempty"`
+	Population     null.Int64   `boil:"population" json:"population,omitempty" toml:"population" yaml:"population,omitempty"`
+
+	R *planetsHistoryR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L planetsHistoryL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var PlanetsHistoryColumns = struct {
+	ID             string
+	PlanetID       string
+	ValidFrom      string
+	ValidTo        string
+	CreatedAt      string
+	UpdatedAt      string
+	DeletedAt      string
+	Name           string
+	Climates       string
+	Terrains       string
+	RotationPeriod string
+	OrbitalPeriod  string
+	Diameter       string
+	Gravity        string
+	SurfaceWater   string
+	Population     string
+}{
+	ID:             "id",
+	PlanetID:       "planet_id",
+	ValidFrom:      "valid_from",
+	ValidTo:        "valid_to",
+	CreatedAt:      "created_at",
+	UpdatedAt:      "updated_at",
+	DeletedAt:      "deleted_at",
+	Name:           "name",
+	Climates:       "climates",
+	Terrains:       "terrains",
+	RotationPeriod: "rotation_period",
+	OrbitalPeriod:  "orbital_period",
+	Diameter:       "diameter",
+	Gravity:        "gravity",
+	SurfaceWater:   "surface_water",
+	Population:     "population",
+}
+
+var PlanetsHistoryTableColumns = struct {
+	ID             string
+	PlanetID       string
+	ValidFrom      string
+	ValidTo        string
+	CreatedAt      string
+	UpdatedAt      string
+	DeletedAt      string
+	Name           string
+	Climates       string
+	Terrains       string
+	RotationPeriod string
+	OrbitalPeriod  string
+	Diameter       string
+	Gravity        string
+	SurfaceWater   string
+	Population     string
+}{
+	ID:             "planets_history.id",
+	PlanetID:       "planets_history.planet_id",
+	ValidFrom:      "planets_history.valid_from",
+	ValidTo:        "planets_history.valid_to",
+	CreatedAt:      "planets_history.created_at",
+	UpdatedAt:      "planets_history.updated_at",
+	DeletedAt:      "planets_history.deleted_at",
+	Name:           "planets_history.name",
+	Climates:       "planets_history.climates",
+	Terrains:       "planets_history.terrains",
+	RotationPeriod: "planets_history.rotation_period",
+	OrbitalPeriod:  "planets_history.orbital_period",
+	Diameter:       "planets_history.diameter",
+	Gravity:        "planets_history.gravity",
+	SurfaceWater:   "planets_history.surface_water",
+	Population:     "planets_history.population",
+}
+
+// Generated where
+
+var PlanetsHistoryWhere = struct {
+	ID             whereHelperint64
+	PlanetID       whereHelperint
+	ValidFrom      whereHelpertime_Time
+	ValidTo        whereHelpernull_Time
+	CreatedAt      whereHelpertime_Time
+	UpdatedAt      whereHelpertime_Time
+	DeletedAt      whereHelpernull_Time
+	Name           whereHelperstring
+	Climates       whereHelpertypes_JSON
+	Terrains       whereHelpertypes_JSON
+	RotationPeriod whereHelpernull_Int
+	OrbitalPeriod  whereHelpernull_Int
+	Diameter       whereHelpernull_Int
+	Gravity        whereHelpernull_String
+	SurfaceWater   whereHelpernull_Float64
+	Population     whereHelpernull_Int64
+}{
+	ID:             whereHelperint64{field: "`planets_history`.`id`"},
+	PlanetID:       whereHelperint{field: "`planets_history`.`planet_id`"},
+	ValidFrom:      whereHelpertime_Time{field: "`planets_history`.`valid_from`"},
+	ValidTo:        whereHelpernull_Time{field: "`planets_history`.`valid_to`"},
+	CreatedAt:      whereHelpertime_Time{field: "`planets_history`.`created_at`"},
+	UpdatedAt:      whereHelpertime_Time{field: "`planets_history`.`updated_at`"},
+	DeletedAt:      whereHelpernull_Time{field: "`planets_history`.`deleted_at`"},
+	Name:           whereHelperstring{field: "`planets_history`.`name`"},
+	Climates:       whereHelpertypes_JSON{field: "`planets_history`.`climates`"},
+	Terrains:       whereHelpertypes_JSON{field: "`planets_history`.`terrains`"},
+	RotationPeriod: whereHelpernull_Int{field: "`planets_history`.`rotation_period`"},
+	OrbitalPeriod:  whereHelpernull_Int{field: "`planets_history`.`orbital_period`"},
+	Diameter:       whereHelpernull_Int{field: "`planets_history`.`diameter`"},
+	Gravity:        whereHelpernull_String{field: "`planets_history`.`gravity`"},
+	SurfaceWater:   whereHelpernull_Float64{field: "`planets_history`.`surface_water`"},
+	Population:     whereHelpernull_Int64{field: "`planets_history`.`population`"},
+}
+
+// PlanetsHistoryRels is where relationship names are stored.
+var PlanetsHistoryRels = struct {
+	Planet string
+}{
+	Planet: "Planet",
+}
+
+// planetsHistoryR is where relationships are stored.
+type planetsHistoryR struct {
+	Planet *Planet `boil:"Planet" json:"Planet" toml:"Planet" yaml:"Planet"`
+}
+
+// NewStruct creates a new relationship struct
+func (*planetsHistoryR) NewStruct() *planetsHistoryR {
+	return &planetsHistoryR{}
+}
+
+func (r *planetsHistoryR) GetPlanet() *Planet {
+	if r == nil {
+		return nil
+	}
+	return r.Planet
+}
+
+// planetsHistoryL is where Load methods for each relationship are stored.
+type planetsHistoryL struct{}
+
+var (
+	planetsHistoryAllColumns            = []string{"id", "planet_id", "valid_from", "valid_to", "created_at", "updated_at", "deleted_at", "name", "climates", "terrains", "rotation_period", "orbital_period", "diameter", "gravity", "surface_water", "population"}
+	planetsHistoryColumnsWithoutDefault = []string{"planet_id", "valid_from", "valid_to", "created_at", "updated_at", "deleted_at", "name", "climates", "terrains", "rotation_period", "orbital_period", "diameter", "gravity", "surface_water", "population"}
+	planetsHistoryColumnsWithDefault    = []string{"id"}
+	planetsHistoryPrimaryKeyColumns     = []string{"id"}
+	planetsHistoryGeneratedColumns      = []string{}
+)
+
+type (
+	// PlanetsHistorySlice is an alias for a slice of pointers to PlanetsHistory.
+	// This should almost always be used instead of []PlanetsHistory.
+	PlanetsHistorySlice []*PlanetsHistory
+	// PlanetsHistoryHook is the signature for custom PlanetsHistory hook methods
+	PlanetsHistoryHook func(context.Context, boil.ContextExecutor, *PlanetsHistory) error
+
+	planetsHistoryQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	planetsHistoryType                 = reflect.TypeOf(&PlanetsHistory{})
+	planetsHistoryMapping              = queries.MakeStructMapping(planetsHistoryType)
+	planetsHistoryPrimaryKeyMapping, _ = queries.BindMapping(planetsHistoryType, planetsHistoryMapping, planetsHistoryPrimaryKeyColumns)
+	planetsHistoryInsertCacheMut       sync.RWMutex
+	planetsHistoryInsertCache          = make(map[string]insertCache)
+	planetsHistoryUpdateCacheMut       sync.RWMutex
+	planetsHistoryUpdateCache          = make(map[string]updateCache)
+	planetsHistoryUpsertCacheMut       sync.RWMutex
+	planetsHistoryUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var planetsHistoryAfterSelectHooks []PlanetsHistoryHook
+
+var planetsHistoryBeforeInsertHooks []PlanetsHistoryHook
+var planetsHistoryAfterInsertHooks []PlanetsHistoryHook
+
+var planetsHistoryBeforeUpdateHooks []PlanetsHistoryHook
+var planetsHistoryAfterUpdateHooks []PlanetsHistoryHook
+
+var planetsHistoryBeforeDeleteHooks []PlanetsHistoryHook
+var planetsHistoryAfterDeleteHooks []PlanetsHistoryHook
+
+var planetsHistoryBeforeUpsertHooks []PlanetsHistoryHook
+var planetsHistoryAfterUpsertHooks []PlanetsHistoryHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *PlanetsHistory) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range planetsHistoryAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *PlanetsHistory) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range planetsHistoryBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *PlanetsHistory) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range planetsHistoryAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *PlanetsHistory) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range planetsHistoryBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *PlanetsHistory) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range planetsHistoryAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *PlanetsHistory) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range planetsHistoryBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *PlanetsHistory) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range planetsHistoryAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *PlanetsHistory) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range planetsHistoryBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *PlanetsHistory) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range planetsHistoryAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddPlanetsHistoryHook registers your hook function for all future operations.
+func AddPlanetsHistoryHook(hookPoint boil.HookPoint, planetsHistoryHook PlanetsHistoryHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		planetsHistoryAfterSelectHooks = append(planetsHistoryAfterSelectHooks, planetsHistoryHook)
+	case boil.BeforeInsertHook:
+		planetsHistoryBeforeInsertHooks = append(planetsHistoryBeforeInsertHooks, planetsHistoryHook)
+	case boil.AfterInsertHook:
+		planetsHistoryAfterInsertHooks = append(planetsHistoryAfterInsertHooks, planetsHistoryHook)
+	case boil.BeforeUpdateHook:
+		planetsHistoryBeforeUpdateHooks = append(planetsHistoryBeforeUpdateHooks, planetsHistoryHook)
+	case boil.AfterUpdateHook:
+		planetsHistoryAfterUpdateHooks = append(planetsHistoryAfterUpdateHooks, planetsHistoryHook)
+	case boil.BeforeDeleteHook:
+		planetsHistoryBeforeDeleteHooks = append(planetsHistoryBeforeDeleteHooks, planetsHistoryHook)
+	case boil.AfterDeleteHook:
+		planetsHistoryAfterDeleteHooks = append(planetsHistoryAfterDeleteHooks, planetsHistoryHook)
+	case boil.BeforeUpsertHook:
+		planetsHistoryBeforeUpsertHooks = append(planetsHistoryBeforeUpsertHooks, planetsHistoryHook)
+	case boil.AfterUpsertHook:
+		planetsHistoryAfterUpsertHooks = append(planetsHistoryAfterUpsertHooks, planetsHistoryHook)
+	}
+}
+
+// One returns a single planetsHistory record from the query.
+func (q planetsHistoryQuery) One(ctx context.Context, exec boil.ContextExecutor) (*PlanetsHistory, error) {
+	o := &PlanetsHistory{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for planets_history")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all PlanetsHistory records from the query.
+func (q planetsHistoryQuery) All(ctx context.Context, exec boil.ContextExecutor) (PlanetsHistorySlice, error) {
+	var o []*PlanetsHistory
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to PlanetsHistory slice")
+	}
+
+	if len(planetsHistoryAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all PlanetsHistory records in the query.
+func (q planetsHistoryQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count planets_history rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q planetsHistoryQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if planets_history exists")
+	}
+
+	return count > 0, nil
+}
+
+// Planet pointed to by the foreign key.
+func (o *PlanetsHistory) Planet(mods ...qm.QueryMod) planetQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("`id` = ?", o.PlanetID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Planets(queryMods...)
+}
+
+// LoadPlanet allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (planetsHistoryL) LoadPlanet(ctx context.Context, e boil.ContextExecutor, singular bool, maybePlanetsHistory interface{}, mods queries.Applicator) error {
+	var slice []*PlanetsHistory
+	var object *PlanetsHistory
+
+	if singular {
+		var ok bool
+		object, ok = maybePlanetsHistory.(*PlanetsHistory)
+		if !ok {
+			object = new(PlanetsHistory)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybePlanetsHistory)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybePlanetsHistory))
+			}
+		}
+	} else {
+		s, ok := maybePlanetsHistory.(*[]*PlanetsHistory)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybePlanetsHistory)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybePlanetsHistory))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &planetsHistoryR{}
+		}
+		args = append(args, object.PlanetID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &planetsHistoryR{}
+			}
+
+			for _, a := range args {
+				if a == obj.PlanetID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.PlanetID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`planets`),
+		qm.WhereIn(`planets.id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Planet")
+	}
+
+	var resultSlice []*Planet
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Planet")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for planets")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for planets")
+	}
+
+	if len(planetsHistoryAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Planet = foreign
+		if foreign.R == nil {
+			foreign.R = &planetR{}
+		}
+		foreign.R.PlanetsHistories = append(foreign.R.PlanetsHistories, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.PlanetID == foreign.ID {
+				local.R.Planet = foreign
+				if foreign.R == nil {
+					foreign.R = &planetR{}
+				}
+				foreign.R.PlanetsHistories = append(foreign.R.PlanetsHistories, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetPlanet of the planetsHistory to the related item.
+// Sets o.R.Planet to related.
+// Adds o to related.R.PlanetsHistories.
+func (o *PlanetsHistory) SetPlanet(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Planet) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE `planets_history` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, []string{"planet_id"}),
+		strmangle.WhereClause("`", "`", 0, planetsHistoryPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.PlanetID = related.ID
+	if o.R == nil {
+		o.R = &planetsHistoryR{
+			Planet: related,
+		}
+	} else {
+		o.R.Planet = related
+	}
+
+	if related.R == nil {
+		related.R = &planetR{
+			PlanetsHistories: PlanetsHistorySlice{o},
+		}
+	} else {
+		related.R.PlanetsHistories = append(related.R.PlanetsHistories, o)
+	}
+
+	return nil
+}
+
+// PlanetsHistories retrieves all the records using an executor.
+func PlanetsHistories(mods ...qm.QueryMod) planetsHistoryQuery {
+	mods = append(mods, qm.From("`planets_history`"))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"`planets_history`.*"})
+	}
+
+	return planetsHistoryQuery{q}
+}
+
+// FindPlanetsHistory retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindPlanetsHistory(ctx context.Context, exec boil.ContextExecutor, iD int64, selectCols ...string) (*PlanetsHistory, error) {
+	planetsHistoryObj := &PlanetsHistory{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from `planets_history` where `id`=?", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, planetsHistoryObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from planets_history")
+	}
+
+	if err = planetsHistoryObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return planetsHistoryObj, err
+	}
+
+	return planetsHistoryObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *PlanetsHistory) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no planets_history provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+		if o.UpdatedAt.IsZero() {
+			o.UpdatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(planetsHistoryColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	planetsHistoryInsertCacheMut.RLock()
+	cache, cached := planetsHistoryInsertCache[key]
+	planetsHistoryInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			planetsHistoryAllColumns,
+			planetsHistoryColumnsWithDefault,
+			planetsHistoryColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(planetsHistoryType, planetsHistoryMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(planetsHistoryType, planetsHistoryMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO `planets_history` (`%s`) %%sVALUES (%s)%%s", strings.Join(wl, "`,`"), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO `planets_history` () VALUES ()%s%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			cache.retQuery = fmt.Sprintf("SELECT `%s` FROM `planets_history` WHERE %s", strings.Join(returnColumns, "`,`"), strmangle.WhereClause("`", "`", 0, planetsHistoryPrimaryKeyColumns))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	result, err := exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into planets_history")
+	}
+
+	var lastID int64
+	var identifierCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	lastID, err = result.LastInsertId()
+	if err != nil {
+		return ErrSyncFail
+	}
+
+	o.ID = int64(lastID)
+	if lastID != 0 && len(cache.retMapping) == 1 && cache.retMapping[0] == planetsHistoryMapping["id"] {
+		goto CacheNoHooks
+	}
+
+	identifierCols = []interface{}{
+		o.ID,
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, identifierCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to populate default values for planets_history")
+	}
+
+CacheNoHooks:
+	if !cached {
+		planetsHistoryInsertCacheMut.Lock()
+		planetsHistoryInsertCache[key] = cache
+		planetsHistoryInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the PlanetsHistory.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *PlanetsHistory) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		o.UpdatedAt = currTime
+	}
+
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	planetsHistoryUpdateCacheMut.RLock()
+	cache, cached := planetsHistoryUpdateCache[key]
+	planetsHistoryUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			planetsHistoryAllColumns,
+			planetsHistoryPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update planets_history, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE `planets_history` SET %s WHERE %s",
+			strmangle.SetParamNames("`", "`", 0, wl),
+			strmangle.WhereClause("`", "`", 0, planetsHistoryPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(planetsHistoryType, planetsHistoryMapping, append(wl, planetsHistoryPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update planets_history row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for planets_history")
+	}
+
+	if !cached {
+		planetsHistoryUpdateCacheMut.Lock()
+		planetsHistoryUpdateCache[key] = cache
+		planetsHistoryUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q planetsHistoryQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for planets_history")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for planets_history")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o PlanetsHistorySlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), planetsHistoryPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE `planets_history` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, planetsHistoryPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in planetsHistory slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all planetsHistory")
+	}
+	return rowsAff, nil
+}
+
+var mySQLPlanetsHistoryUniqueColumns = []string{
+	"id",
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *PlanetsHistory) Upsert(ctx context.Context, exec boil.ContextExecutor, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no planets_history provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+		o.UpdatedAt = currTime
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(planetsHistoryColumnsWithDefault, o)
+	nzUniques := queries.NonZeroDefaultSet(mySQLPlanetsHistoryUniqueColumns, o)
+
+	if len(nzUniques) == 0 {
+		return errors.New("cannot upsert with a table that cannot conflict on a unique column")
+	}
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzUniques {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	planetsHistoryUpsertCacheMut.RLock()
+	cache, cached := planetsHistoryUpsertCache[key]
+	planetsHistoryUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			planetsHistoryAllColumns,
+			planetsHistoryColumnsWithDefault,
+			planetsHistoryColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			planetsHistoryAllColumns,
+			planetsHistoryPrimaryKeyColumns,
+		)
+
+		if !updateColumns.IsNone() && len(update) == 0 {
+			return errors.New("models: unable to upsert planets_history, could not build update column list")
+		}
+
+		ret = strmangle.SetComplement(ret, nzUniques)
+		cache.query = buildUpsertQueryMySQL(dialect, "`planets_history`", update, insert)
+		cache.retQuery = fmt.Sprintf(
+			"SELECT %s FROM `planets_history` WHERE %s",
+			strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, ret), ","),
+			strmangle.WhereClause("`", "`", 0, nzUniques),
+		)
+
+		cache.valueMapping, err = queries.BindMapping(planetsHistoryType, planetsHistoryMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(planetsHistoryType, planetsHistoryMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	result, err := exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert for planets_history")
+	}
+
+	var lastID int64
+	var uniqueMap []uint64
+	var nzUniqueCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	lastID, err = result.LastInsertId()
+	if err != nil {
+		return ErrSyncFail
+	}
+
+	o.ID = int64(lastID)
+	if lastID != 0 && len(cache.retMapping) == 1 && cache.retMapping[0] == planetsHistoryMapping["id"] {
+		goto CacheNoHooks
+	}
+
+	uniqueMap, err = queries.BindMapping(planetsHistoryType, planetsHistoryMapping, nzUniques)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to retrieve unique values for planets_history")
+	}
+	nzUniqueCols = queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), uniqueMap)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, nzUniqueCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, nzUniqueCols...).Scan(returns...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to populate default values for planets_history")
+	}
+
+CacheNoHooks:
+	if !cached {
+		planetsHistoryUpsertCacheMut.Lock()
+		planetsHistoryUpsertCache[key] = cache
+		planetsHistoryUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single PlanetsHistory record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *PlanetsHistory) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no PlanetsHistory provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), planetsHistoryPrimaryKeyMapping)
+	sql := "DELETE FROM `planets_history` WHERE `id`=?"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from planets_history")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for planets_history")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q planetsHistoryQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no planetsHistoryQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from planets_history")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for planets_history")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o PlanetsHistorySlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(planetsHistoryBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), planetsHistoryPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM `planets_history` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, planetsHistoryPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from planetsHistory slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for planets_history")
+	}
+
+	if len(planetsHistoryAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *PlanetsHistory) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindPlanetsHistory(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *PlanetsHistorySlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := PlanetsHistorySlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), planetsHistoryPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT `planets_history`.* FROM `planets_history` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, planetsHistoryPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in PlanetsHistorySlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// PlanetsHistoryExists checks if the PlanetsHistory row exists.
+func PlanetsHistoryExists(ctx context.Context, exec boil.ContextExecutor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from `planets_history` where `id`=? limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if planets_history exists")
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,1161 @@
+// Code generated by SQLBoiler 4.13.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package model
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// PlanetsTranslation is an object representing the database table.
+type PlanetsTranslation struct {
+	PlanetID  int       `boil:"planet_id" json:"planet_id" toml:"planet_id" yaml:"planet_id"`
+	Locale    string    `boil:"locale" json:"locale" toml:"locale" yaml:"locale"`
+	CreatedAt time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	Name      string    `boil:"name" json:"name" toml:"name" yaml:"name"`
+
+	R *planetsTranslationR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L planetsTranslationL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var PlanetsTranslationColumns = struct {
+	PlanetID  string
+	Locale    string
+	CreatedAt string
+	UpdatedAt string
+	Name      string
+}{
+	PlanetID:  "planet_id",
+	Locale:    "locale",
+	CreatedAt: "created_at",
+	UpdatedAt: "updated_at",
+	Name:      "name",
+}
+
+var PlanetsTranslationTableColumns = struct {
+	PlanetID  string
+	Locale    string
+	CreatedAt string
+	UpdatedAt string
+	Name      string
+}{
+	PlanetID:  "planets_translations.planet_id",
+	Locale:    "planets_translations.locale",
+	CreatedAt: "planets_translations.created_at",
+	UpdatedAt: "planets_translations.updated_at",
+	Name:      "planets_translations.name",
+}
+
+// Generated where
+
+var PlanetsTranslationWhere = struct {
+	PlanetID  whereHelperint
+	Locale    whereHelperstring
+	CreatedAt whereHelpertime_Time
+	UpdatedAt whereHelpertime_Time
+	Name      whereHelperstring
+}{
+	PlanetID:  whereHelperint{field: "`planets_translations`.`planet_id`"},
+	Locale:    whereHelperstring{field: "`planets_translations`.`locale`"},
+	CreatedAt: whereHelpertime_Time{field: "`planets_translations`.`created_at`"},
+	UpdatedAt: whereHelpertime_Time{field: "`planets_translations`.`updated_at`"},
+	Name:      whereHelperstring{field: "`planets_translations`.`name`"},
+}
+
+// PlanetsTranslationRels is where relationship names are stored.
+var PlanetsTranslationRels = struct {
+	Planet string
+}{
+	Planet: "Planet",
+}
+
+// planetsTranslationR is where relationships are stored.
+type planetsTranslationR struct {
+	Planet *Planet `boil:"Planet" json:"Planet" toml:"Planet" yaml:"Planet"`
+}
+
+// NewStruct creates a new relationship struct
+func (*planetsTranslationR) NewStruct() *planetsTranslationR {
+	return &planetsTranslationR{}
+}
+
+func (r *planetsTranslationR) GetPlanet() *Planet {
+	if r == nil {
+		return nil
+	}
+	return r.Planet
+}
+
+// planetsTranslationL is where Load methods for each relationship are stored.
+type planetsTranslationL struct{}
+
+var (
+	planetsTranslationAllColumns            = []string{"planet_id", "locale", "created_at", "updated_at", "name"}
+	planetsTranslationColumnsWithoutDefault = []string{"planet_id", "locale", "created_at", "updated_at", "name"}
+	planetsTranslationColumnsWithDefault    = []string{}
+	planetsTranslationPrimaryKeyColumns     = []string{"planet_id", "locale"}
+	planetsTranslationGeneratedColumns      = []string{}
+)
+
+type (
+	// PlanetsTranslationSlice is an alias for a slice of pointers to PlanetsTranslation.
+	// This should almost always be used instead of []PlanetsTranslation.
+	PlanetsTranslationSlice []*PlanetsTranslation
+	// PlanetsTranslationHook is the signature for custom PlanetsTranslation hook methods
+	PlanetsTranslationHook func(context.Context, boil.ContextExecutor, *PlanetsTranslation) error
+
+	planetsTranslationQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	planetsTranslationType                 = reflect.TypeOf(&PlanetsTranslation{})
+	planetsTranslationMapping              = queries.MakeStructMapping(planetsTranslationType)
+	planetsTranslationPrimaryKeyMapping, _ = queries.BindMapping(planetsTranslationType, planetsTranslationMapping, planetsTranslationPrimaryKeyColumns)
+	planetsTranslationInsertCacheMut       sync.RWMutex
+	planetsTranslationInsertCache          = make(map[string]insertCache)
+	planetsTranslationUpdateCacheMut       sync.RWMutex
+	planetsTranslationUpdateCache          = make(map[string]updateCache)
+	planetsTranslationUpsertCacheMut       sync.RWMutex
+	planetsTranslationUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var planetsTranslationAfterSelectHooks []PlanetsTranslationHook
+
+var planetsTranslationBeforeInsertHooks []PlanetsTranslationHook
+var planetsTranslationAfterInsertHooks []PlanetsTranslationHook
+
+var planetsTranslationBeforeUpdateHooks []PlanetsTranslationHook
+var planetsTranslationAfterUpdateHooks []PlanetsTranslationHook
+
+var planetsTranslationBeforeDeleteHooks []PlanetsTranslationHook
+var planetsTranslationAfterDeleteHooks []PlanetsTranslationHook
+
+var planetsTranslationBeforeUpsertHooks []PlanetsTranslationHook
+var planetsTranslationAfterUpsertHooks []PlanetsTranslationHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *PlanetsTranslation) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range planetsTranslationAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *PlanetsTranslation) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range planetsTranslationBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *PlanetsTranslation) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range planetsTranslationAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *PlanetsTranslation) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range planetsTranslationBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *PlanetsTranslation) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range planetsTranslationAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *PlanetsTranslation) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range planetsTranslationBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *PlanetsTranslation) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range planetsTranslationAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *PlanetsTranslation) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range planetsTranslationBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *PlanetsTranslation) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range planetsTranslationAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddPlanetsTranslationHook registers your hook function for all future operations.
+func AddPlanetsTranslationHook(hookPoint boil.HookPoint, planetsTranslationHook PlanetsTranslationHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		planetsTranslationAfterSelectHooks = append(planetsTranslationAfterSelectHooks, planetsTranslationHook)
+	case boil.BeforeInsertHook:
+		planetsTranslationBeforeInsertHooks = append(planetsTranslationBeforeInsertHooks, planetsTranslationHook)
+	case boil.AfterInsertHook:
+		planetsTranslationAfterInsertHooks = append(planetsTranslationAfterInsertHooks, planetsTranslationHook)
+	case boil.BeforeUpdateHook:
+		planetsTranslationBeforeUpdateHooks = append(planetsTranslationBeforeUpdateHooks, planetsTranslationHook)
+	case boil.AfterUpdateHook:
+		planetsTranslationAfterUpdateHooks = append(planetsTranslationAfterUpdateHooks, planetsTranslationHook)
+	case boil.BeforeDeleteHook:
+		planetsTranslationBeforeDeleteHooks = append(planetsTranslationBeforeDeleteHooks, planetsTranslationHook)
+	case boil.AfterDeleteHook:
+		planetsTranslationAfterDeleteHooks = append(planetsTranslationAfterDeleteHooks, planetsTranslationHook)
+	case boil.BeforeUpsertHook:
+		planetsTranslationBeforeUpsertHooks = append(planetsTranslationBeforeUpsertHooks, planetsTranslationHook)
+	case boil.AfterUpsertHook:
+		planetsTranslationAfterUpsertHooks = append(planetsTranslationAfterUpsertHooks, planetsTranslationHook)
+	}
+}
+
+// One returns a single planetsTranslation record from the query.
+func (q planetsTranslationQuery) One(ctx context.Context, exec boil.ContextExecutor) (*PlanetsTranslation, error) {
+	o := &PlanetsTranslation{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for planets_translations")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all PlanetsTranslation records from the query.
+func (q planetsTranslationQuery) All(ctx context.Context, exec boil.ContextExecutor) (PlanetsTranslationSlice, error) {
+	var o []*PlanetsTranslation
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to PlanetsTranslation slice")
+	}
+
+	if len(planetsTranslationAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all PlanetsTranslation records in the query.
+func (q planetsTranslationQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count planets_translations rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q planetsTranslationQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if planets_translations exists")
+	}
+
+	return count > 0, nil
+}
+
+// Planet pointed to by the foreign key.
+func (o *PlanetsTranslation) Planet(mods ...qm.QueryMod) planetQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("`id` = ?", o.PlanetID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Planets(queryMods...)
+}
+
+// LoadPlanet allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (planetsTranslationL) LoadPlanet(ctx context.Context, e boil.ContextExecutor, singular bool, maybePlanetsTranslation interface{}, mods queries.Applicator) error {
+	var slice []*PlanetsTranslation
+	var object *PlanetsTranslation
+
+	if singular {
+		var ok bool
+		object, ok = maybePlanetsTranslation.(*PlanetsTranslation)
+		if !ok {
+			object = new(PlanetsTranslation)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybePlanetsTranslation)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybePlanetsTranslation))
+			}
+		}
+	} else {
+		s, ok := maybePlanetsTranslation.(*[]*PlanetsTranslation)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybePlanetsTranslation)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybePlanetsTranslation))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &planetsTranslationR{}
+		}
+		args = append(args, object.PlanetID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &planetsTranslationR{}
+			}
+
+			for _, a := range args {
+				if a == obj.PlanetID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.PlanetID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`planets`),
+		qm.WhereIn(`planets.id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Planet")
+	}
+
+	var resultSlice []*Planet
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Planet")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for planets")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for planets")
+	}
+
+	if len(planetsTranslationAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Planet = foreign
+		if foreign.R == nil {
+			foreign.R = &planetR{}
+		}
+		foreign.R.PlanetsTranslations = append(foreign.R.PlanetsTranslations, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.PlanetID == foreign.ID {
+				local.R.Planet = foreign
+				if foreign.R == nil {
+					foreign.R = &planetR{}
+				}
+				foreign.R.PlanetsTranslations = append(foreign.R.PlanetsTranslations, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetPlanet of the planetsTranslation to the related item.
+// Sets o.R.Planet to related.
+// Adds o to related.R.PlanetsTranslations.
+func (o *PlanetsTranslation) SetPlanet(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Planet) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE `planets_translations` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, []string{"planet_id"}),
+		strmangle.WhereClause("`", "`", 0, planetsTranslationPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.PlanetID, o.Locale}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.PlanetID = related.ID
+	if o.R == nil {
+		o.R = &planetsTranslationR{
+			Planet: related,
+		}
+	} else {
+		o.R.Planet = related
+	}
+
+	if related.R == nil {
+		related.R = &planetR{
+			PlanetsTranslations: PlanetsTranslationSlice{o},
+		}
+	} else {
+		related.R.PlanetsTranslations = append(related.R.PlanetsTranslations, o)
+	}
+
+	return nil
+}
+
+// PlanetsTranslations retrieves all the records using an executor.
+func PlanetsTranslations(mods ...qm.QueryMod) planetsTranslationQuery {
+	mods = append(mods, qm.From("`planets_translations`"))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"`planets_translations`.*"})
+	}
+
+	return planetsTranslationQuery{q}
+}
+
+// FindPlanetsTranslation retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindPlanetsTranslation(ctx context.Context, exec boil.ContextExecutor, planetID int, locale string, selectCols ...string) (*PlanetsTranslation, error) {
+	planetsTranslationObj := &PlanetsTranslation{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from `planets_translations` where `planet_id`=? AND `locale`=?", sel,
+	)
+
+	q := queries.Raw(query, planetID, locale)
+
+	err := q.Bind(ctx, exec, planetsTranslationObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from planets_translations")
+	}
+
+	if err = planetsTranslationObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return planetsTranslationObj, err
+	}
+
+	return planetsTranslationObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *PlanetsTranslation) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no planets_translations provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+		if o.UpdatedAt.IsZero() {
+			o.UpdatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(planetsTranslationColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	planetsTranslationInsertCacheMut.RLock()
+	cache, cached := planetsTranslationInsertCache[key]
+	planetsTranslationInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			planetsTranslationAllColumns,
+			planetsTranslationColumnsWithDefault,
+			planetsTranslationColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(planetsTranslationType, planetsTranslationMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(planetsTranslationType, planetsTranslationMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO `planets_translations` (`%s`) %%sVALUES (%s)%%s", strings.Join(wl, "`,`"), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO `planets_translations` () VALUES ()%s%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			cache.retQuery = fmt.Sprintf("SELECT `%s` FROM `planets_translations` WHERE %s", strings.Join(returnColumns, "`,`"), strmangle.WhereClause("`", "`", 0, planetsTranslationPrimaryKeyColumns))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	_, err = exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into planets_translations")
+	}
+
+	var identifierCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	identifierCols = []interface{}{
+		o.PlanetID,
+		o.Locale,
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, identifierCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to populate default values for planets_translations")
+	}
+
+CacheNoHooks:
+	if !cached {
+		planetsTranslationInsertCacheMut.Lock()
+		planetsTranslationInsertCache[key] = cache
+		planetsTranslationInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the PlanetsTranslation.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *PlanetsTranslation) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		o.UpdatedAt = currTime
+	}
+
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	planetsTranslationUpdateCacheMut.RLock()
+	cache, cached := planetsTranslationUpdateCache[key]
+	planetsTranslationUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			planetsTranslationAllColumns,
+			planetsTranslationPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update planets_translations, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE `planets_translations` SET %s WHERE %s",
+			strmangle.SetParamNames("`", "`", 0, wl),
+			strmangle.WhereClause("`", "`", 0, planetsTranslationPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(planetsTranslationType, planetsTranslationMapping, append(wl, planetsTranslationPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update planets_translations row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for planets_translations")
+	}
+
+	if !cached {
+		planetsTranslationUpdateCacheMut.Lock()
+		planetsTranslationUpdateCache[key] = cache
+		planetsTranslationUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q planetsTranslationQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for planets_translations")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for planets_translations")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o PlanetsTranslationSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), planetsTranslationPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE `planets_translations` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, planetsTranslationPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in planetsTranslation slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all planetsTranslation")
+	}
+	return rowsAff, nil
+}
+
+var mySQLPlanetsTranslationUniqueColumns = []string{}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *PlanetsTranslation) Upsert(ctx context.Context, exec boil.ContextExecutor, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no planets_translations provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+		o.UpdatedAt = currTime
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(planetsTranslationColumnsWithDefault, o)
+	nzUniques := queries.NonZeroDefaultSet(mySQLPlanetsTranslationUniqueColumns, o)
+
+	if len(nzUniques) == 0 {
+		return errors.New("cannot upsert with a table that cannot conflict on a unique column")
+	}
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzUniques {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	planetsTranslationUpsertCacheMut.RLock()
+	cache, cached := planetsTranslationUpsertCache[key]
+	planetsTranslationUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			planetsTranslationAllColumns,
+			planetsTranslationColumnsWithDefault,
+			planetsTranslationColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			planetsTranslationAllColumns,
+			planetsTranslationPrimaryKeyColumns,
+		)
+
+		if !updateColumns.IsNone() && len(update) == 0 {
+			return errors.New("models: unable to upsert planets_translations, could not build update column list")
+		}
+
+		ret = strmangle.SetComplement(ret, nzUniques)
+		cache.query = buildUpsertQueryMySQL(dialect, "`planets_translations`", update, insert)
+		cache.retQuery = fmt.Sprintf(
+			"SELECT %s FROM `planets_translations` WHERE %s",
+			strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, ret), ","),
+			strmangle.WhereClause("`", "`", 0, nzUniques),
+		)
+
+		cache.valueMapping, err = queries.BindMapping(planetsTranslationType, planetsTranslationMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(planetsTranslationType, planetsTranslationMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	_, err = exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert for planets_translations")
+	}
+
+	var uniqueMap []uint64
+	var nzUniqueCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	uniqueMap, err = queries.BindMapping(planetsTranslationType, planetsTranslationMapping, nzUniques)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to retrieve unique values for planets_translations")
+	}
+	nzUniqueCols = queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), uniqueMap)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, nzUniqueCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, nzUniqueCols...).Scan(returns...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to populate default values for planets_translations")
+	}
+
+CacheNoHooks:
+	if !cached {
+		planetsTranslationUpsertCacheMut.Lock()
+		planetsTranslationUpsertCache[key] = cache
+		planetsTranslationUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single PlanetsTranslation record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *PlanetsTranslation) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no PlanetsTranslation provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), planetsTranslationPrimaryKeyMapping)
+	sql := "DELETE FROM `planets_translations` WHERE `planet_id`=? AND `locale`=?"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from planets_translations")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for planets_translations")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q planetsTranslationQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no planetsTranslationQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from planets_translations")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for planets_translations")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o PlanetsTranslationSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(planetsTranslationBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), planetsTranslationPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM `planets_translations` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, planetsTranslationPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from planetsTranslation slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for planets_translations")
+	}
+
+	if len(planetsTranslationAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *PlanetsTranslation) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindPlanetsTranslation(ctx, exec, o.PlanetID, o.Locale)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *PlanetsTranslationSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := PlanetsTranslationSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), planetsTranslationPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT `planets_translations`.* FROM `planets_translations` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, planetsTranslationPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in PlanetsTranslationSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// PlanetsTranslationExists checks if the PlanetsTranslation row exists.
+func PlanetsTranslationExists(ctx context.Context, exec boil.ContextExecutor, planetID int, locale string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from `planets_translations` where `planet_id`=? AND `locale`=? limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, planetID, locale)
+	}
+	row := exec.QueryRowContext(ctx, sql, planetID, locale)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if planets_translations exists")
+	}
+
+	return exists, nil
+}
@@ -1,13 +1,15 @@
 package model
 
 type SwapiFilm struct {
-	Url         string `json:"url"`
-	Created     string `json:"created"`
-	Edited      string `json:"edited"`
-	Title       string `json:"title"`
-	EpisodeID   int    `json:"episode_id"`
-	Director    string `json:"director"`
-	ReleaseDate string `json:"release_date"`
+	Url          string `json:"url"`
+	Created      string `json:"created"`
+	Edited       string `json:"edited"`
+	Title        string `json:"title"`
+	EpisodeID    int    `json:"episode_id"`
+	OpeningCrawl string `json:"opening_crawl"`
+	Director     string `json:"director"`
+	Producer     string `json:"producer"`
+	ReleaseDate  string `json:"release_date"`
 }
 
 type SwapiFilmsResponse struct {
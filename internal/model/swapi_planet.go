@@ -1,13 +1,19 @@
 package model
 
 type SwapiPlanet struct {
-	Url     string   `json:"url"`
-	Created string   `json:"created"`
-	Edited  string   `json:"edited"`
-	Name    string   `json:"name"`
-	Climate string   `json:"climate"`
-	Terrain string   `json:"terrain"`
-	Films   []string `json:"films"`
+	Url            string   `json:"url"`
+	Created        string   `json:"created"`
+	Edited         string   `json:"edited"`
+	Name           string   `json:"name"`
+	RotationPeriod string   `json:"rotation_period"`
+	OrbitalPeriod  string   `json:"orbital_period"`
+	Diameter       string   `json:"diameter"`
+	Climate        string   `json:"climate"`
+	Gravity        string   `json:"gravity"`
+	Terrain        string   `json:"terrain"`
+	SurfaceWater   string   `json:"surface_water"`
+	Population     string   `json:"population"`
+	Films          []string `json:"films"`
 }
 
 type SwapiPlanetsResponse struct {
@@ -0,0 +1,1253 @@
+// Code generated by SQLBoiler 4.13.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package model
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// Terrain is an object representing the database table.
+type Terrain struct {
+	ID   int    `boil:"id" json:"id" toml:"id" yaml:"id"`
+	Name string `boil:"name" json:"name" toml:"name" yaml:"name"`
+
+	R *terrainR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L terrainL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var TerrainColumns = struct {
+	ID   string
+	Name string
+}{
+	ID:   "id",
+	Name: "name",
+}
+
+var TerrainTableColumns = struct {
+	ID   string
+	Name string
+}{
+	ID:   "terrains.id",
+	Name: "terrains.name",
+}
+
+// Generated where
+
+var TerrainWhere = struct {
+	ID   whereHelperint
+	Name whereHelperstring
+}{
+	ID:   whereHelperint{field: "`terrains`.`id`"},
+	Name: whereHelperstring{field: "`terrains`.`name`"},
+}
+
+// TerrainRels is where relationship names are stored.
+var TerrainRels = struct {
+	Planets string
+}{
+	Planets: "Planets",
+}
+
+// terrainR is where relationships are stored.
+type terrainR struct {
+	Planets PlanetSlice `boil:"Planets" json:"Planets" toml:"Planets" yaml:"Planets"`
+}
+
+// NewStruct creates a new relationship struct
+func (*terrainR) NewStruct() *terrainR {
+	return &terrainR{}
+}
+
+func (r *terrainR) GetPlanets() PlanetSlice {
+	if r == nil {
+		return nil
+	}
+	return r.Planets
+}
+
+// terrainL is where Load methods for each relationship are stored.
+type terrainL struct{}
+
+var (
+	terrainAllColumns            = []string{"id", "name"}
+	terrainColumnsWithoutDefault = []string{"name"}
+	terrainColumnsWithDefault    = []string{"id"}
+	terrainPrimaryKeyColumns     = []string{"id"}
+	terrainGeneratedColumns      = []string{}
+)
+
+type (
+	// TerrainSlice is an alias for a slice of pointers to Terrain.
+	// This should almost always be used instead of []Terrain.
+	TerrainSlice []*Terrain
+	// TerrainHook is the signature for custom Terrain hook methods
+	TerrainHook func(context.Context, boil.ContextExecutor, *Terrain) error
+
+	terrainQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	terrainType                 = reflect.TypeOf(&Terrain{})
+	terrainMapping              = queries.MakeStructMapping(terrainType)
+	terrainPrimaryKeyMapping, _ = queries.BindMapping(terrainType, terrainMapping, terrainPrimaryKeyColumns)
+	terrainInsertCacheMut       sync.RWMutex
+	terrainInsertCache          = make(map[string]insertCache)
+	terrainUpdateCacheMut       sync.RWMutex
+	terrainUpdateCache          = make(map[string]updateCache)
+	terrainUpsertCacheMut       sync.RWMutex
+	terrainUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var terrainAfterSelectHooks []TerrainHook
+
+var terrainBeforeInsertHooks []TerrainHook
+var terrainAfterInsertHooks []TerrainHook
+
+var terrainBeforeUpdateHooks []TerrainHook
+var terrainAfterUpdateHooks []TerrainHook
+
+var terrainBeforeDeleteHooks []TerrainHook
+var terrainAfterDeleteHooks []TerrainHook
+
+var terrainBeforeUpsertHooks []TerrainHook
+var terrainAfterUpsertHooks []TerrainHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *Terrain) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range terrainAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *Terrain) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range terrainBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *Terrain) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range terrainAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *Terrain) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range terrainBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *Terrain) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range terrainAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *Terrain) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range terrainBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *Terrain) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range terrainAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *Terrain) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range terrainBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *Terrain) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range terrainAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddTerrainHook registers your hook function for all future operations.
+func AddTerrainHook(hookPoint boil.HookPoint, terrainHook TerrainHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		terrainAfterSelectHooks = append(terrainAfterSelectHooks, terrainHook)
+	case boil.BeforeInsertHook:
+		terrainBeforeInsertHooks = append(terrainBeforeInsertHooks, terrainHook)
+	case boil.AfterInsertHook:
+		terrainAfterInsertHooks = append(terrainAfterInsertHooks, terrainHook)
+	case boil.BeforeUpdateHook:
+		terrainBeforeUpdateHooks = append(terrainBeforeUpdateHooks, terrainHook)
+	case boil.AfterUpdateHook:
+		terrainAfterUpdateHooks = append(terrainAfterUpdateHooks, terrainHook)
+	case boil.BeforeDeleteHook:
+		terrainBeforeDeleteHooks = append(terrainBeforeDeleteHooks, terrainHook)
+	case boil.AfterDeleteHook:
+		terrainAfterDeleteHooks = append(terrainAfterDeleteHooks, terrainHook)
+	case boil.BeforeUpsertHook:
+		terrainBeforeUpsertHooks = append(terrainBeforeUpsertHooks, terrainHook)
+	case boil.AfterUpsertHook:
+		terrainAfterUpsertHooks = append(terrainAfterUpsertHooks, terrainHook)
+	}
+}
+
+// One returns a single terrain record from the query.
+func (q terrainQuery) One(ctx context.Context, exec boil.ContextExecutor) (*Terrain, error) {
+	o := &Terrain{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for terrains")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all Terrain records from the query.
+func (q terrainQuery) All(ctx context.Context, exec boil.ContextExecutor) (TerrainSlice, error) {
+	var o []*Terrain
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to Terrain slice")
+	}
+
+	if len(terrainAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all Terrain records in the query.
+func (q terrainQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count terrains rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q terrainQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if terrains exists")
+	}
+
+	return count > 0, nil
+}
+
+// Planets retrieves all the planet's Planets with an executor.
+func (o *Terrain) Planets(mods ...qm.QueryMod) planetQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.InnerJoin("`planets_terrains` on `planets`.`id` = `planets_terrains`.`planet_id`"),
+		qm.Where("`planets_terrains`.`terrain_id`=?", o.ID),
+	)
+
+	return Planets(queryMods...)
+}
+
+// LoadPlanets allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (terrainL) LoadPlanets(ctx context.Context, e boil.ContextExecutor, singular bool, maybeTerrain interface{}, mods queries.Applicator) error {
+	var slice []*Terrain
+	var object *Terrain
+
+	if singular {
+		var ok bool
+		object, ok = maybeTerrain.(*Terrain)
+		if !ok {
+			object = new(Terrain)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeTerrain)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeTerrain))
+			}
+		}
+	} else {
+		s, ok := maybeTerrain.(*[]*Terrain)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeTerrain)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeTerrain))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &terrainR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &terrainR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.Select("`planets`.`id`, `planets`.`created_at`, `planets`.`updated_at`, `planets`.`deleted_at`, `planets`.`name`, `planets`.`climates`, `planets`.`terrains`, `planets`.`search_attributes`, `planets`.`rotation_period`, `planets`.`orbital_period`, `planets`.`diameter`, `planets`.`gravity`, `planets`.`surface_water`, `planets`.`population`, `a`.`terrain_id`"),
+		qm.From("`planets`"),
+		qm.InnerJoin("`planets_terrains` as `a` on `planets`.`id` = `a`.`planet_id`"),
+		qm.WhereIn("`a`.`terrain_id` in ?", args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load planets")
+	}
+
+	var resultSlice []*Planet
+
+	var localJoinCols []int
+	for results.Next() {
+		one := new(Planet)
+		var localJoinCol int
+
+		err = results.Scan(&one.ID, &one.CreatedAt, &one.UpdatedAt, &one.DeletedAt, &one.Name, &one.Climates, &one.Terrains, &one.SearchAttributes, &one.RotationPeriod, &one.OrbitalPeriod, &one.Diameter, &one.Gravity, &one.SurfaceWater, &one.Population, &localJoinCol)
+		if err != nil {
+			return errors.Wrap(err, "failed to scan eager loaded results for planets")
+		}
+		if err = results.Err(); err != nil {
+			return errors.Wrap(err, "failed to plebian-bind eager loaded slice planets")
+		}
+
+		resultSlice = append(resultSlice, one)
+		localJoinCols = append(localJoinCols, localJoinCol)
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on planets")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for planets")
+	}
+
+	if len(planetAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Planets = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &planetR{}
+			}
+			foreign.R.PlanetTerrains = append(foreign.R.PlanetTerrains, object)
+		}
+		return nil
+	}
+
+	for i, foreign := range resultSlice {
+		localJoinCol := localJoinCols[i]
+		for _, local := range slice {
+			if local.ID == localJoinCol {
+				local.R.Planets = append(local.R.Planets, foreign)
+				if foreign.R == nil {
+					foreign.R = &planetR{}
+				}
+				foreign.R.PlanetTerrains = append(foreign.R.PlanetTerrains, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// AddPlanets adds the given related objects to the existing relationships
+// of the terrain, optionally inserting them as new records.
+// Appends related to o.R.Planets.
+// Sets related.R.PlanetTerrains appropriately.
+func (o *Terrain) AddPlanets(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Planet) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		}
+	}
+
+	for _, rel := range related {
+		query := "insert into `planets_terrains` (`terrain_id`, `planet_id`) values (?, ?)"
+		values := []interface{}{o.ID, rel.ID}
+
+		if boil.IsDebug(ctx) {
+			writer := boil.DebugWriterFrom(ctx)
+			fmt.Fprintln(writer, query)
+			fmt.Fprintln(writer, values)
+		}
+		_, err = exec.ExecContext(ctx, query, values...)
+		if err != nil {
+			return errors.Wrap(err, "failed to insert into join table")
+		}
+	}
+	if o.R == nil {
+		o.R = &terrainR{
+			Planets: related,
+		}
+	} else {
+		o.R.Planets = append(o.R.Planets, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &planetR{
+				PlanetTerrains: TerrainSlice{o},
+			}
+		} else {
+			rel.R.PlanetTerrains = append(rel.R.PlanetTerrains, o)
+		}
+	}
+	return nil
+}
+
+// SetPlanets removes all previously related items of the
+// terrain replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.PlanetTerrains's Planets accordingly.
+// Replaces o.R.Planets with related.
+// Sets related.R.PlanetTerrains's Planets accordingly.
+func (o *Terrain) SetPlanets(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Planet) error {
+	query := "delete from `planets_terrains` where `terrain_id` = ?"
+	values := []interface{}{o.ID}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	removePlanetsFromPlanetTerrainsSlice(o, related)
+	if o.R != nil {
+		o.R.Planets = nil
+	}
+
+	return o.AddPlanets(ctx, exec, insert, related...)
+}
+
+// RemovePlanets relationships from objects passed in.
+// Removes related items from R.Planets (uses pointer comparison, removal does not keep order)
+// Sets related.R.PlanetTerrains.
+func (o *Terrain) RemovePlanets(ctx context.Context, exec boil.ContextExecutor, related ...*Planet) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	query := fmt.Sprintf(
+		"delete from `planets_terrains` where `terrain_id` = ? and `planet_id` in (%s)",
+		strmangle.Placeholders(dialect.UseIndexPlaceholders, len(related), 2, 1),
+	)
+	values := []interface{}{o.ID}
+	for _, rel := range related {
+		values = append(values, rel.ID)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err = exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+	removePlanetsFromPlanetTerrainsSlice(o, related)
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.Planets {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.Planets)
+			if ln > 1 && i < ln-1 {
+				o.R.Planets[i] = o.R.Planets[ln-1]
+			}
+			o.R.Planets = o.R.Planets[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
+func removePlanetsFromPlanetTerrainsSlice(o *Terrain, related []*Planet) {
+	for _, rel := range related {
+		if rel.R == nil {
+			continue
+		}
+		for i, ri := range rel.R.PlanetTerrains {
+			if o.ID != ri.ID {
+				continue
+			}
+
+			ln := len(rel.R.PlanetTerrains)
+			if ln > 1 && i < ln-1 {
+				rel.R.PlanetTerrains[i] = rel.R.PlanetTerrains[ln-1]
+			}
+			rel.R.PlanetTerrains = rel.R.PlanetTerrains[:ln-1]
+			break
+		}
+	}
+}
+
+// Terrains retrieves all the records using an executor.
+func Terrains(mods ...qm.QueryMod) terrainQuery {
+	mods = append(mods, qm.From("`terrains`"))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"`terrains`.*"})
+	}
+
+	return terrainQuery{q}
+}
+
+// FindTerrain retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindTerrain(ctx context.Context, exec boil.ContextExecutor, iD int, selectCols ...string) (*Terrain, error) {
+	terrainObj := &Terrain{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from `terrains` where `id`=?", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, terrainObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from terrains")
+	}
+
+	if err = terrainObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return terrainObj, err
+	}
+
+	return terrainObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *Terrain) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no terrains provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(terrainColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	terrainInsertCacheMut.RLock()
+	cache, cached := terrainInsertCache[key]
+	terrainInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			terrainAllColumns,
+			terrainColumnsWithDefault,
+			terrainColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(terrainType, terrainMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(terrainType, terrainMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO `terrains` (`%s`) %%sVALUES (%s)%%s", strings.Join(wl, "`,`"), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO `terrains` () VALUES ()%s%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			cache.retQuery = fmt.Sprintf("SELECT `%s` FROM `terrains` WHERE %s", strings.Join(returnColumns, "`,`"), strmangle.WhereClause("`", "`", 0, terrainPrimaryKeyColumns))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	result, err := exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into terrains")
+	}
+
+	var lastID int64
+	var identifierCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	lastID, err = result.LastInsertId()
+	if err != nil {
+		return ErrSyncFail
+	}
+
+	o.ID = int(lastID)
+	if lastID != 0 && len(cache.retMapping) == 1 && cache.retMapping[0] == terrainMapping["id"] {
+		goto CacheNoHooks
+	}
+
+	identifierCols = []interface{}{
+		o.ID,
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, identifierCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to populate default values for terrains")
+	}
+
+CacheNoHooks:
+	if !cached {
+		terrainInsertCacheMut.Lock()
+		terrainInsertCache[key] = cache
+		terrainInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the Terrain.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *Terrain) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	terrainUpdateCacheMut.RLock()
+	cache, cached := terrainUpdateCache[key]
+	terrainUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			terrainAllColumns,
+			terrainPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update terrains, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE `terrains` SET %s WHERE %s",
+			strmangle.SetParamNames("`", "`", 0, wl),
+			strmangle.WhereClause("`", "`", 0, terrainPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(terrainType, terrainMapping, append(wl, terrainPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update terrains row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for terrains")
+	}
+
+	if !cached {
+		terrainUpdateCacheMut.Lock()
+		terrainUpdateCache[key] = cache
+		terrainUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q terrainQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for terrains")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for terrains")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o TerrainSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), terrainPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE `terrains` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, terrainPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in terrain slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all terrain")
+	}
+	return rowsAff, nil
+}
+
+var mySQLTerrainUniqueColumns = []string{
+	"id",
+	"name",
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *Terrain) Upsert(ctx context.Context, exec boil.ContextExecutor, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no terrains provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(terrainColumnsWithDefault, o)
+	nzUniques := queries.NonZeroDefaultSet(mySQLTerrainUniqueColumns, o)
+
+	if len(nzUniques) == 0 {
+		return errors.New("cannot upsert with a table that cannot conflict on a unique column")
+	}
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzUniques {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	terrainUpsertCacheMut.RLock()
+	cache, cached := terrainUpsertCache[key]
+	terrainUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			terrainAllColumns,
+			terrainColumnsWithDefault,
+			terrainColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			terrainAllColumns,
+			terrainPrimaryKeyColumns,
+		)
+
+		if !updateColumns.IsNone() && len(update) == 0 {
+			return errors.New("models: unable to upsert terrains, could not build update column list")
+		}
+
+		ret = strmangle.SetComplement(ret, nzUniques)
+		cache.query = buildUpsertQueryMySQL(dialect, "`terrains`", update, insert)
+		cache.retQuery = fmt.Sprintf(
+			"SELECT %s FROM `terrains` WHERE %s",
+			strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, ret), ","),
+			strmangle.WhereClause("`", "`", 0, nzUniques),
+		)
+
+		cache.valueMapping, err = queries.BindMapping(terrainType, terrainMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(terrainType, terrainMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	result, err := exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert for terrains")
+	}
+
+	var lastID int64
+	var uniqueMap []uint64
+	var nzUniqueCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	lastID, err = result.LastInsertId()
+	if err != nil {
+		return ErrSyncFail
+	}
+
+	o.ID = int(lastID)
+	if lastID != 0 && len(cache.retMapping) == 1 && cache.retMapping[0] == terrainMapping["id"] {
+		goto CacheNoHooks
+	}
+
+	uniqueMap, err = queries.BindMapping(terrainType, terrainMapping, nzUniques)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to retrieve unique values for terrains")
+	}
+	nzUniqueCols = queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), uniqueMap)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, nzUniqueCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, nzUniqueCols...).Scan(returns...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to populate default values for terrains")
+	}
+
+CacheNoHooks:
+	if !cached {
+		terrainUpsertCacheMut.Lock()
+		terrainUpsertCache[key] = cache
+		terrainUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single Terrain record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *Terrain) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no Terrain provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), terrainPrimaryKeyMapping)
+	sql := "DELETE FROM `terrains` WHERE `id`=?"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from terrains")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for terrains")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q terrainQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no terrainQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from terrains")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for terrains")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o TerrainSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(terrainBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), terrainPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM `terrains` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, terrainPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from terrain slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for terrains")
+	}
+
+	if len(terrainAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *Terrain) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindTerrain(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *TerrainSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := TerrainSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), terrainPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT `terrains`.* FROM `terrains` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, terrainPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in TerrainSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// TerrainExists checks if the Terrain row exists.
+func TerrainExists(ctx context.Context, exec boil.ContextExecutor, iD int) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from `terrains` where `id`=? limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if terrains exists")
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,1235 @@
+// Code generated by SQLBoiler 4.13.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package model
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/v4/types"
+	"github.com/volatiletech/strmangle"
+)
+
+// WebhookDelivery is an object representing the database table.
+type WebhookDelivery struct {
+	ID            int64       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	CreatedAt     time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt     time.Time   `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	WebhookID     int         `boil:"webhook_id" json:"webhook_id" toml:"webhook_id" yaml:"webhook_id"`
+	EventID       string      `boil:"event_id" json:"event_id" toml:"event_id" yaml:"event_id"`
+	Event         string      `boil:"event" json:"event" toml:"event" yaml:"event"`
+	Payload       types.JSON  `boil:"payload" json:"payload" toml:"payload" yaml:"payload"`
+	Status        string      `boil:"status" json:"status" toml:"status" yaml:"status"`
+	Attempts      int         `boil:"attempts" json:"attempts" toml:"attempts" yaml:"attempts"`
+	NextAttemptAt time.Time   `boil:"next_attempt_at" json:"next_attempt_at" toml:"next_attempt_at" yaml:"next_attempt_at"`
+	LastError     null.String `boil:"last_error" json:"last_error,omitempty" toml:"last_error" yaml:"last_error,omitempty"`
+	DeliveredAt   null.Time   `boil:"delivered_at" json:"delivered_at,omitempty" toml:"delivered_at" yaml:"delivered_at,omitempty"`
+
+	R *webhookDeliveryR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L webhookDeliveryL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var WebhookDeliveryColumns = struct {
+	ID            string
+	CreatedAt     string
+	UpdatedAt     string
+	WebhookID     string
+	EventID       string
+	Event         string
+	Payload       string
+	Status        string
+	Attempts      string
+	NextAttemptAt string
+	LastError     string
+	DeliveredAt   string
+}{
+	ID:            "id",
+	CreatedAt:     "created_at",
+	UpdatedAt:     "updated_at",
+	WebhookID:     "webhook_id",
+	EventID:       "event_id",
+	Event:         "event",
+	Payload:       "payload",
+	Status:        "status",
+	Attempts:      "attempts",
+	NextAttemptAt: "next_attempt_at",
+	LastError:     "last_error",
+	DeliveredAt:   "delivered_at",
+}
+
+var WebhookDeliveryTableColumns = struct {
+	ID            string
+	CreatedAt     string
+	UpdatedAt     string
+	WebhookID     string
+	EventID       string
+	Event         string
+	Payload       string
+	Status        string
+	Attempts      string
+	NextAttemptAt string
+	LastError     string
+	DeliveredAt   string
+}{
+	ID:            "webhook_deliveries.id",
+	CreatedAt:     "webhook_deliveries.created_at",
+	UpdatedAt:     "webhook_deliveries.updated_at",
+	WebhookID:     "webhook_deliveries.webhook_id",
+	EventID:       "webhook_deliveries.event_id",
+	Event:         "webhook_deliveries.event",
+	Payload:       "webhook_deliveries.payload",
+	Status:        "webhook_deliveries.status",
+	Attempts:      "webhook_deliveries.attempts",
+	NextAttemptAt: "webhook_deliveries.next_attempt_at",
+	LastError:     "webhook_deliveries.last_error",
+	DeliveredAt:   "webhook_deliveries.delivered_at",
+}
+
+// Generated where
+
+var WebhookDeliveryWhere = struct {
+	ID            whereHelperint64
+	CreatedAt     whereHelpertime_Time
+	UpdatedAt     whereHelpertime_Time
+	WebhookID     whereHelperint
+	EventID       whereHelperstring
+	Event         whereHelperstring
+	Payload       whereHelpertypes_JSON
+	Status        whereHelperstring
+	Attempts      whereHelperint
+	NextAttemptAt whereHelpertime_Time
+	LastError     whereHelpernull_String
+	DeliveredAt   whereHelpernull_Time
+}{
+	ID:            whereHelperint64{field: "`webhook_deliveries`.`id`"},
+	CreatedAt:     whereHelpertime_Time{field: "`webhook_deliveries`.`created_at`"},
+	UpdatedAt:     whereHelpertime_Time{field: "`webhook_deliveries`.`updated_at`"},
+	WebhookID:     whereHelperint{field: "`webhook_deliveries`.`webhook_id`"},
+	EventID:       whereHelperstring{field: "`webhook_deliveries`.`event_id`"},
+	Event:         whereHelperstring{field: "`webhook_deliveries`.`event`"},
+	Payload:       whereHelpertypes_JSON{field: "`webhook_deliveries`.`payload`"},
+	Status:        whereHelperstring{field: "`webhook_deliveries`.`status`"},
+	Attempts:      whereHelperint{field: "`webhook_deliveries`.`attempts`"},
+	NextAttemptAt: whereHelpertime_Time{field: "`webhook_deliveries`.`next_attempt_at`"},
+	LastError:     whereHelpernull_String{field: "`webhook_deliveries`.`last_error`"},
+	DeliveredAt:   whereHelpernull_Time{field: "`webhook_deliveries`.`delivered_at`"},
+}
+
+// WebhookDeliveryRels is where relationship names are stored.
+var WebhookDeliveryRels = struct {
+	Webhook string
+}{
+	Webhook: "Webhook",
+}
+
+// webhookDeliveryR is where relationships are stored.
+type webhookDeliveryR struct {
+	Webhook *Webhook `boil:"Webhook" json:"Webhook" toml:"Webhook" yaml:"Webhook"`
+}
+
+// NewStruct creates a new relationship struct
+func (*webhookDeliveryR) NewStruct() *webhookDeliveryR {
+	return &webhookDeliveryR{}
+}
+
+func (r *webhookDeliveryR) GetWebhook() *Webhook {
+	if r == nil {
+		return nil
+	}
+	return r.Webhook
+}
+
+// webhookDeliveryL is where Load methods for each relationship are stored.
+type webhookDeliveryL struct{}
+
+var (
+	webhookDeliveryAllColumns            = []string{"id", "created_at", "updated_at", "webhook_id", "event_id", "event", "payload", "status", "attempts", "next_attempt_at", "last_error", "delivered_at"}
+	webhookDeliveryColumnsWithoutDefault = []string{"created_at", "updated_at", "webhook_id", "event_id", "event", "payload", "status", "next_attempt_at", "last_error", "delivered_at"}
+	webhookDeliveryColumnsWithDefault    = []string{"id", "attempts"}
+	webhookDeliveryPrimaryKeyColumns     = []string{"id"}
+	webhookDeliveryGeneratedColumns      = []string{}
+)
+
+type (
+	// WebhookDeliverySlice is an alias for a slice of pointers to WebhookDelivery.
+	// This should almost always be used instead of []WebhookDelivery.
+	WebhookDeliverySlice []*WebhookDelivery
+	// WebhookDeliveryHook is the signature for custom WebhookDelivery hook methods
+	WebhookDeliveryHook func(context.Context, boil.ContextExecutor, *WebhookDelivery) error
+
+	webhookDeliveryQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	webhookDeliveryType                 = reflect.TypeOf(&WebhookDelivery{})
+	webhookDeliveryMapping              = queries.MakeStructMapping(webhookDeliveryType)
+	webhookDeliveryPrimaryKeyMapping, _ = queries.BindMapping(webhookDeliveryType, webhookDeliveryMapping, webhookDeliveryPrimaryKeyColumns)
+	webhookDeliveryInsertCacheMut       sync.RWMutex
+	webhookDeliveryInsertCache          = make(map[string]insertCache)
+	webhookDeliveryUpdateCacheMut       sync.RWMutex
+	webhookDeliveryUpdateCache          = make(map[string]updateCache)
+	webhookDeliveryUpsertCacheMut       sync.RWMutex
+	webhookDeliveryUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var webhookDeliveryAfterSelectHooks []WebhookDeliveryHook
+
+var webhookDeliveryBeforeInsertHooks []WebhookDeliveryHook
+var webhookDeliveryAfterInsertHooks []WebhookDeliveryHook
+
+var webhookDeliveryBeforeUpdateHooks []WebhookDeliveryHook
+var webhookDeliveryAfterUpdateHooks []WebhookDeliveryHook
+
+var webhookDeliveryBeforeDeleteHooks []WebhookDeliveryHook
+var webhookDeliveryAfterDeleteHooks []WebhookDeliveryHook
+
+var webhookDeliveryBeforeUpsertHooks []WebhookDeliveryHook
+var webhookDeliveryAfterUpsertHooks []WebhookDeliveryHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *WebhookDelivery) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range webhookDeliveryAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *WebhookDelivery) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range webhookDeliveryBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *WebhookDelivery) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range webhookDeliveryAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *WebhookDelivery) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range webhookDeliveryBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *WebhookDelivery) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range webhookDeliveryAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *WebhookDelivery) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range webhookDeliveryBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *WebhookDelivery) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range webhookDeliveryAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *WebhookDelivery) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range webhookDeliveryBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *WebhookDelivery) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range webhookDeliveryAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddWebhookDeliveryHook registers your hook function for all future operations.
+func AddWebhookDeliveryHook(hookPoint boil.HookPoint, webhookDeliveryHook WebhookDeliveryHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		webhookDeliveryAfterSelectHooks = append(webhookDeliveryAfterSelectHooks, webhookDeliveryHook)
+	case boil.BeforeInsertHook:
+		webhookDeliveryBeforeInsertHooks = append(webhookDeliveryBeforeInsertHooks, webhookDeliveryHook)
+	case boil.AfterInsertHook:
+		webhookDeliveryAfterInsertHooks = append(webhookDeliveryAfterInsertHooks, webhookDeliveryHook)
+	case boil.BeforeUpdateHook:
+		webhookDeliveryBeforeUpdateHooks = append(webhookDeliveryBeforeUpdateHooks, webhookDeliveryHook)
+	case boil.AfterUpdateHook:
+		webhookDeliveryAfterUpdateHooks = append(webhookDeliveryAfterUpdateHooks, webhookDeliveryHook)
+	case boil.BeforeDeleteHook:
+		webhookDeliveryBeforeDeleteHooks = append(webhookDeliveryBeforeDeleteHooks, webhookDeliveryHook)
+	case boil.AfterDeleteHook:
+		webhookDeliveryAfterDeleteHooks = append(webhookDeliveryAfterDeleteHooks, webhookDeliveryHook)
+	case boil.BeforeUpsertHook:
+		webhookDeliveryBeforeUpsertHooks = append(webhookDeliveryBeforeUpsertHooks, webhookDeliveryHook)
+	case boil.AfterUpsertHook:
+		webhookDeliveryAfterUpsertHooks = append(webhookDeliveryAfterUpsertHooks, webhookDeliveryHook)
+	}
+}
+
+// One returns a single webhookDelivery record from the query.
+func (q webhookDeliveryQuery) One(ctx context.Context, exec boil.ContextExecutor) (*WebhookDelivery, error) {
+	o := &WebhookDelivery{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for webhook_deliveries")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all WebhookDelivery records from the query.
+func (q webhookDeliveryQuery) All(ctx context.Context, exec boil.ContextExecutor) (WebhookDeliverySlice, error) {
+	var o []*WebhookDelivery
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to WebhookDelivery slice")
+	}
+
+	if len(webhookDeliveryAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all WebhookDelivery records in the query.
+func (q webhookDeliveryQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count webhook_deliveries rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q webhookDeliveryQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if webhook_deliveries exists")
+	}
+
+	return count > 0, nil
+}
+
+// Webhook pointed to by the foreign key.
+func (o *WebhookDelivery) Webhook(mods ...qm.QueryMod) webhookQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("`id` = ?", o.WebhookID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Webhooks(queryMods...)
+}
+
+// LoadWebhook allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (webhookDeliveryL) LoadWebhook(ctx context.Context, e boil.ContextExecutor, singular bool, maybeWebhookDelivery interface{}, mods queries.Applicator) error {
+	var slice []*WebhookDelivery
+	var object *WebhookDelivery
+
+	if singular {
+		var ok bool
+		object, ok = maybeWebhookDelivery.(*WebhookDelivery)
+		if !ok {
+			object = new(WebhookDelivery)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeWebhookDelivery)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeWebhookDelivery))
+			}
+		}
+	} else {
+		s, ok := maybeWebhookDelivery.(*[]*WebhookDelivery)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeWebhookDelivery)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeWebhookDelivery))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &webhookDeliveryR{}
+		}
+		args = append(args, object.WebhookID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &webhookDeliveryR{}
+			}
+
+			for _, a := range args {
+				if a == obj.WebhookID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.WebhookID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`webhooks`),
+		qm.WhereIn(`webhooks.id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Webhook")
+	}
+
+	var resultSlice []*Webhook
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Webhook")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for webhooks")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for webhooks")
+	}
+
+	if len(webhookDeliveryAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Webhook = foreign
+		if foreign.R == nil {
+			foreign.R = &webhookR{}
+		}
+		foreign.R.WebhookDeliveries = append(foreign.R.WebhookDeliveries, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.WebhookID == foreign.ID {
+				local.R.Webhook = foreign
+				if foreign.R == nil {
+					foreign.R = &webhookR{}
+				}
+				foreign.R.WebhookDeliveries = append(foreign.R.WebhookDeliveries, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetWebhook of the webhookDelivery to the related item.
+// Sets o.R.Webhook to related.
+// Adds o to related.R.WebhookDeliveries.
+func (o *WebhookDelivery) SetWebhook(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Webhook) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE `webhook_deliveries` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, []string{"webhook_id"}),
+		strmangle.WhereClause("`", "`", 0, webhookDeliveryPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.WebhookID = related.ID
+	if o.R == nil {
+		o.R = &webhookDeliveryR{
+			Webhook: related,
+		}
+	} else {
+		o.R.Webhook = related
+	}
+
+	if related.R == nil {
+		related.R = &webhookR{
+			WebhookDeliveries: WebhookDeliverySlice{o},
+		}
+	} else {
+		related.R.WebhookDeliveries = append(related.R.WebhookDeliveries, o)
+	}
+
+	return nil
+}
+
+// WebhookDeliveries retrieves all the records using an executor.
+func WebhookDeliveries(mods ...qm.QueryMod) webhookDeliveryQuery {
+	mods = append(mods, qm.From("`webhook_deliveries`"))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"`webhook_deliveries`.*"})
+	}
+
+	return webhookDeliveryQuery{q}
+}
+
+// FindWebhookDelivery retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindWebhookDelivery(ctx context.Context, exec boil.ContextExecutor, iD int64, selectCols ...string) (*WebhookDelivery, error) {
+	webhookDeliveryObj := &WebhookDelivery{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from `webhook_deliveries` where `id`=?", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, webhookDeliveryObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from webhook_deliveries")
+	}
+
+	if err = webhookDeliveryObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return webhookDeliveryObj, err
+	}
+
+	return webhookDeliveryObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *WebhookDelivery) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no webhook_deliveries provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+		if o.UpdatedAt.IsZero() {
+			o.UpdatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(webhookDeliveryColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	webhookDeliveryInsertCacheMut.RLock()
+	cache, cached := webhookDeliveryInsertCache[key]
+	webhookDeliveryInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			webhookDeliveryAllColumns,
+			webhookDeliveryColumnsWithDefault,
+			webhookDeliveryColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(webhookDeliveryType, webhookDeliveryMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(webhookDeliveryType, webhookDeliveryMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO `webhook_deliveries` (`%s`) %%sVALUES (%s)%%s", strings.Join(wl, "`,`"), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO `webhook_deliveries` () VALUES ()%s%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			cache.retQuery = fmt.Sprintf("SELECT `%s` FROM `webhook_deliveries` WHERE %s", strings.Join(returnColumns, "`,`"), strmangle.WhereClause("`", "`", 0, webhookDeliveryPrimaryKeyColumns))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	result, err := exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into webhook_deliveries")
+	}
+
+	var lastID int64
+	var identifierCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	lastID, err = result.LastInsertId()
+	if err != nil {
+		return ErrSyncFail
+	}
+
+	o.ID = int64(lastID)
+	if lastID != 0 && len(cache.retMapping) == 1 && cache.retMapping[0] == webhookDeliveryMapping["id"] {
+		goto CacheNoHooks
+	}
+
+	identifierCols = []interface{}{
+		o.ID,
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, identifierCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to populate default values for webhook_deliveries")
+	}
+
+CacheNoHooks:
+	if !cached {
+		webhookDeliveryInsertCacheMut.Lock()
+		webhookDeliveryInsertCache[key] = cache
+		webhookDeliveryInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the WebhookDelivery.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *WebhookDelivery) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		o.UpdatedAt = currTime
+	}
+
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	webhookDeliveryUpdateCacheMut.RLock()
+	cache, cached := webhookDeliveryUpdateCache[key]
+	webhookDeliveryUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			webhookDeliveryAllColumns,
+			webhookDeliveryPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update webhook_deliveries, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE `webhook_deliveries` SET %s WHERE %s",
+			strmangle.SetParamNames("`", "`", 0, wl),
+			strmangle.WhereClause("`", "`", 0, webhookDeliveryPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(webhookDeliveryType, webhookDeliveryMapping, append(wl, webhookDeliveryPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update webhook_deliveries row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for webhook_deliveries")
+	}
+
+	if !cached {
+		webhookDeliveryUpdateCacheMut.Lock()
+		webhookDeliveryUpdateCache[key] = cache
+		webhookDeliveryUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q webhookDeliveryQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for webhook_deliveries")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for webhook_deliveries")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o WebhookDeliverySlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), webhookDeliveryPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE `webhook_deliveries` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, webhookDeliveryPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in webhookDelivery slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all webhookDelivery")
+	}
+	return rowsAff, nil
+}
+
+var mySQLWebhookDeliveryUniqueColumns = []string{
+	"id",
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *WebhookDelivery) Upsert(ctx context.Context, exec boil.ContextExecutor, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no webhook_deliveries provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+		o.UpdatedAt = currTime
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(webhookDeliveryColumnsWithDefault, o)
+	nzUniques := queries.NonZeroDefaultSet(mySQLWebhookDeliveryUniqueColumns, o)
+
+	if len(nzUniques) == 0 {
+		return errors.New("cannot upsert with a table that cannot conflict on a unique column")
+	}
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzUniques {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	webhookDeliveryUpsertCacheMut.RLock()
+	cache, cached := webhookDeliveryUpsertCache[key]
+	webhookDeliveryUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			webhookDeliveryAllColumns,
+			webhookDeliveryColumnsWithDefault,
+			webhookDeliveryColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			webhookDeliveryAllColumns,
+			webhookDeliveryPrimaryKeyColumns,
+		)
+
+		if !updateColumns.IsNone() && len(update) == 0 {
+			return errors.New("models: unable to upsert webhook_deliveries, could not build update column list")
+		}
+
+		ret = strmangle.SetComplement(ret, nzUniques)
+		cache.query = buildUpsertQueryMySQL(dialect, "`webhook_deliveries`", update, insert)
+		cache.retQuery = fmt.Sprintf(
+			"SELECT %s FROM `webhook_deliveries` WHERE %s",
+			strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, ret), ","),
+			strmangle.WhereClause("`", "`", 0, nzUniques),
+		)
+
+		cache.valueMapping, err = queries.BindMapping(webhookDeliveryType, webhookDeliveryMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(webhookDeliveryType, webhookDeliveryMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	result, err := exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert for webhook_deliveries")
+	}
+
+	var lastID int64
+	var uniqueMap []uint64
+	var nzUniqueCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	lastID, err = result.LastInsertId()
+	if err != nil {
+		return ErrSyncFail
+	}
+
+	o.ID = int64(lastID)
+	if lastID != 0 && len(cache.retMapping) == 1 && cache.retMapping[0] == webhookDeliveryMapping["id"] {
+		goto CacheNoHooks
+	}
+
+	uniqueMap, err = queries.BindMapping(webhookDeliveryType, webhookDeliveryMapping, nzUniques)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to retrieve unique values for webhook_deliveries")
+	}
+	nzUniqueCols = queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), uniqueMap)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, nzUniqueCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, nzUniqueCols...).Scan(returns...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to populate default values for webhook_deliveries")
+	}
+
+CacheNoHooks:
+	if !cached {
+		webhookDeliveryUpsertCacheMut.Lock()
+		webhookDeliveryUpsertCache[key] = cache
+		webhookDeliveryUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single WebhookDelivery record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *WebhookDelivery) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no WebhookDelivery provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), webhookDeliveryPrimaryKeyMapping)
+	sql := "DELETE FROM `webhook_deliveries` WHERE `id`=?"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from webhook_deliveries")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for webhook_deliveries")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q webhookDeliveryQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no webhookDeliveryQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from webhook_deliveries")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for webhook_deliveries")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o WebhookDeliverySlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(webhookDeliveryBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), webhookDeliveryPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM `webhook_deliveries` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, webhookDeliveryPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from webhookDelivery slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for webhook_deliveries")
+	}
+
+	if len(webhookDeliveryAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *WebhookDelivery) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindWebhookDelivery(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *WebhookDeliverySlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := WebhookDeliverySlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), webhookDeliveryPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT `webhook_deliveries`.* FROM `webhook_deliveries` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, webhookDeliveryPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in WebhookDeliverySlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// WebhookDeliveryExists checks if the WebhookDelivery row exists.
+func WebhookDeliveryExists(ctx context.Context, exec boil.ContextExecutor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from `webhook_deliveries` where `id`=? limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if webhook_deliveries exists")
+	}
+
+	return exists, nil
+}
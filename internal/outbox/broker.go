@@ -0,0 +1,90 @@
+package outbox
+
+import (
+	"context"
+	"sync"
+)
+
+const MEMORY_BROKER_RETENTION = 1000
+
+type Message struct {
+	Topic string
+	Key   string
+	Data  []byte
+}
+
+// Broker is the subset of NATS and Kafka producers used by the relay: the key
+// routes the messages of an entity to the same partition, keeping their order
+//
+//go:generate mockgen -destination=../../mock/outbox_broker_mock.go -package=mock . Broker
+type Broker interface {
+	Publish(ctx context.Context, topic, key string, data []byte) error
+}
+
+// IMemoryBroker is the local stand-in of a message broker, keeping the last
+// messages of each topic and forwarding new ones to the subscribers
+type IMemoryBroker struct {
+	mutex       sync.RWMutex
+	messages    map[string][]Message
+	subscribers map[string]map[chan Message]bool
+}
+
+func NewMemoryBroker() *IMemoryBroker {
+	return &IMemoryBroker{
+		messages:    map[string][]Message{},
+		subscribers: map[string]map[chan Message]bool{},
+	}
+}
+
+func (impl *IMemoryBroker) Publish(ctx context.Context, topic, key string, data []byte) error {
+	impl.mutex.Lock()
+	defer impl.mutex.Unlock()
+
+	msg := Message{Topic: topic, Key: key, Data: data}
+	messages := append(impl.messages[topic], msg)
+	if len(messages) > MEMORY_BROKER_RETENTION {
+		messages = messages[len(messages)-MEMORY_BROKER_RETENTION:]
+	}
+	impl.messages[topic] = messages
+
+	for ch := range impl.subscribers[topic] {
+		select {
+		case ch <- msg:
+		default:
+		}
+	}
+
+	return nil
+}
+
+// Subscribe returns the channel receiving the new messages of topic and the
+// function to stop receiving them. Slow subscribers miss messages instead of
+// blocking the publisher
+func (impl *IMemoryBroker) Subscribe(topic string, buffer int) (<-chan Message, func()) {
+	impl.mutex.Lock()
+	defer impl.mutex.Unlock()
+
+	ch := make(chan Message, buffer)
+	if impl.subscribers[topic] == nil {
+		impl.subscribers[topic] = map[chan Message]bool{}
+	}
+	impl.subscribers[topic][ch] = true
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			impl.mutex.Lock()
+			defer impl.mutex.Unlock()
+
+			delete(impl.subscribers[topic], ch)
+			close(ch)
+		})
+	}
+}
+
+func (impl *IMemoryBroker) Messages(topic string) []Message {
+	impl.mutex.RLock()
+	defer impl.mutex.RUnlock()
+
+	return append([]Message{}, impl.messages[topic]...)
+}
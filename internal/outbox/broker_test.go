@@ -0,0 +1,44 @@
+package outbox_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/outbox"
+)
+
+func Test_MemoryBroker_Publish(t *testing.T) {
+	// given
+	broker := outbox.NewMemoryBroker()
+	messages, unsubscribe := broker.Subscribe("starwars.events", 1)
+
+	// when
+	err := broker.Publish(context.Background(), "starwars.events", "planet:1", []byte(`{}`))
+	unsubscribe()
+
+	// then
+	expected := outbox.Message{Topic: "starwars.events", Key: "planet:1", Data: []byte(`{}`)}
+	assert.Nil(t, err)
+	assert.Equal(t, expected, <-messages)
+	assert.Equal(t, []outbox.Message{expected}, broker.Messages("starwars.events"))
+
+	_, open := <-messages
+	assert.False(t, open)
+}
+
+func Test_MemoryBroker_Retention(t *testing.T) {
+	// given
+	broker := outbox.NewMemoryBroker()
+
+	// when
+	for i := 0; i < outbox.MEMORY_BROKER_RETENTION+1; i += 1 {
+		broker.Publish(context.Background(), "starwars.events", fmt.Sprintf("planet:%d", i), nil)
+	}
+
+	// then
+	messages := broker.Messages("starwars.events")
+	assert.Len(t, messages, outbox.MEMORY_BROKER_RETENTION)
+	assert.Equal(t, "planet:1", messages[0].Key)
+}
@@ -0,0 +1,107 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/viniosilva/starwars-api/internal/config"
+	"github.com/viniosilva/starwars-api/internal/pubsub"
+	"github.com/viniosilva/starwars-api/internal/service"
+)
+
+const (
+	DEFAULT_FOLLOW_INTERVAL = 250 * time.Millisecond
+	DEFAULT_FOLLOW_BATCH    = 500
+)
+
+// IFollower reads every event of the outbox, published or not, and forwards
+// it to the hub of this instance, so each instance streams the whole feed
+// regardless of which one relayed it
+type IFollower struct {
+	OutboxService service.OutboxService
+	Hub           pubsub.Hub
+	Interval      time.Duration
+	BatchSize     int
+	BufferSize    int
+	Settle        time.Duration
+	lastID        int64
+}
+
+func NewFollower(outboxService service.OutboxService, hub pubsub.Hub, c config.StreamConfig) *IFollower {
+	interval := time.Duration(c.PollIntervalMs) * time.Millisecond
+	if interval <= 0 {
+		interval = DEFAULT_FOLLOW_INTERVAL
+	}
+	batchSize := c.BatchSize
+	if batchSize < 1 {
+		batchSize = DEFAULT_FOLLOW_BATCH
+	}
+
+	return &IFollower{
+		OutboxService: outboxService,
+		Hub:           hub,
+		Interval:      interval,
+		BatchSize:     batchSize,
+		BufferSize:    c.BufferSize,
+		Settle:        time.Duration(c.SettleSeconds) * time.Second,
+	}
+}
+
+// Run starts from the last BufferSize events, so clients can resume right
+// after a restart, and follows the outbox every interval until ctx is done
+func (impl *IFollower) Run(ctx context.Context) {
+	lastID, err := impl.OutboxService.FindLastEventID(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.outbox.follower.run:find_last_event_id"}).Error(err)
+	}
+	impl.Start(lastID - int64(impl.BufferSize))
+
+	ticker := time.NewTicker(impl.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := impl.Follow(ctx, now); err != nil {
+				logrus.WithFields(logrus.Fields{"trace": "internal.outbox.follower.run:follow"}).Error(err)
+			}
+		}
+	}
+}
+
+// Start sets the id of the last event already followed
+func (impl *IFollower) Start(lastID int64) {
+	if lastID < 0 {
+		lastID = 0
+	}
+	impl.lastID = lastID
+}
+
+// Follow forwards the events written after the last one followed. Ids are
+// taken before commit, so an event after a missing id waits until it is older
+// than Settle, giving the transaction holding the missing id time to commit
+func (impl *IFollower) Follow(ctx context.Context, now time.Time) error {
+	for {
+		rows, err := impl.OutboxService.FindEventsAfter(ctx, impl.lastID, impl.BatchSize)
+		if err != nil {
+			return err
+		}
+
+		entries := []pubsub.Entry{}
+		for _, row := range rows {
+			if row.ID != impl.lastID+1 && now.Sub(row.CreatedAt) < impl.Settle {
+				break
+			}
+			entries = append(entries, pubsub.Entry{ID: uint64(row.ID), Event: service.ParseOutboxEvent(row)})
+			impl.lastID = row.ID
+		}
+		impl.Hub.Publish(entries...)
+
+		if len(rows) < impl.BatchSize || len(entries) < len(rows) {
+			return nil
+		}
+	}
+}
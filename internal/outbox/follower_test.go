@@ -0,0 +1,97 @@
+package outbox_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/viniosilva/starwars-api/internal/outbox"
+	"github.com/viniosilva/starwars-api/internal/pubsub"
+	"github.com/viniosilva/starwars-api/mock"
+)
+
+func Test_Follower_Follow(t *testing.T) {
+	now := time.Date(2022, 10, 1, 12, 0, 10, 0, time.UTC)
+	old := now.Add(-time.Minute)
+
+	var cases = map[string]struct {
+		mocking     func(outboxService *mock.MockOutboxService)
+		inputLastID int64
+		expectedIDs []uint64
+		expectedErr error
+	}{
+		"should follow until a partial batch": {
+			mocking: func(outboxService *mock.MockOutboxService) {
+				gomock.InOrder(
+					outboxService.EXPECT().FindEventsAfter(gomock.Any(), int64(1), 2).
+						Return([]*model.Outbox{{ID: 2, CreatedAt: now}, {ID: 3, CreatedAt: now}}, nil),
+					outboxService.EXPECT().FindEventsAfter(gomock.Any(), int64(3), 2).
+						Return([]*model.Outbox{{ID: 4, CreatedAt: now}}, nil),
+				)
+			},
+			inputLastID: 1,
+			expectedIDs: []uint64{2, 3, 4},
+		},
+		"should wait for a missing id to settle": {
+			mocking: func(outboxService *mock.MockOutboxService) {
+				outboxService.EXPECT().FindEventsAfter(gomock.Any(), int64(1), 2).
+					Return([]*model.Outbox{{ID: 2, CreatedAt: now}, {ID: 4, CreatedAt: now}}, nil)
+			},
+			inputLastID: 1,
+			expectedIDs: []uint64{2},
+		},
+		"should skip a missing id after it settles": {
+			mocking: func(outboxService *mock.MockOutboxService) {
+				outboxService.EXPECT().FindEventsAfter(gomock.Any(), int64(1), 2).
+					Return([]*model.Outbox{{ID: 3, CreatedAt: old}}, nil)
+			},
+			inputLastID: 1,
+			expectedIDs: []uint64{3},
+		},
+		"should throw error when find events after": {
+			mocking: func(outboxService *mock.MockOutboxService) {
+				outboxService.EXPECT().FindEventsAfter(gomock.Any(), int64(1), 2).Return(nil, fmt.Errorf("error"))
+			},
+			inputLastID: 1,
+			expectedIDs: []uint64{},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			hub := pubsub.NewHub(10)
+			mockOutboxService := mock.NewMockOutboxService(ctrl)
+			follower := &outbox.IFollower{
+				OutboxService: mockOutboxService,
+				Hub:           hub,
+				BatchSize:     2,
+				Settle:        5 * time.Second,
+			}
+			follower.Start(cs.inputLastID)
+
+			cs.mocking(mockOutboxService)
+
+			// when
+			err := follower.Follow(context.Background(), now)
+
+			// then
+			replay, _, unsubscribe := hub.Subscribe(uint64(cs.inputLastID), 1)
+			defer unsubscribe()
+
+			ids := []uint64{}
+			for _, entry := range replay {
+				ids = append(ids, entry.ID)
+			}
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedIDs, ids)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/viniosilva/starwars-api/internal/config"
+	"github.com/viniosilva/starwars-api/internal/service"
+)
+
+const PURGE_INTERVAL = time.Hour
+
+type IRelay struct {
+	OutboxService service.OutboxService
+	Sink          service.EventPublisher
+	Interval      time.Duration
+	BatchSize     int
+	Retention     time.Duration
+	lastPurge     time.Time
+}
+
+func NewRelay(outboxService service.OutboxService, sink service.EventPublisher, c config.OutboxConfig) *IRelay {
+	return &IRelay{
+		OutboxService: outboxService,
+		Sink:          sink,
+		Interval:      time.Duration(c.IntervalSeconds) * time.Second,
+		BatchSize:     c.BatchSize,
+		Retention:     time.Duration(c.RetentionHours) * time.Hour,
+	}
+}
+
+// Run relays the outbox every interval until ctx is done
+func (impl *IRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(impl.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := impl.Relay(ctx); err != nil {
+				logrus.WithFields(logrus.Fields{"trace": "internal.outbox.relay.run:relay"}).Error(err)
+			}
+			impl.purge(ctx, now)
+		}
+	}
+}
+
+// Relay publishes batches of events until the outbox has no more than a
+// partial batch left or some event failed
+func (impl *IRelay) Relay(ctx context.Context) error {
+	for {
+		published, err := impl.OutboxService.RelayEvents(ctx, impl.BatchSize, impl.Sink)
+		if err != nil {
+			return err
+		}
+		if published < impl.BatchSize {
+			return nil
+		}
+	}
+}
+
+func (impl *IRelay) purge(ctx context.Context, now time.Time) {
+	if impl.Retention <= 0 || now.Sub(impl.lastPurge) < PURGE_INTERVAL {
+		return
+	}
+	impl.lastPurge = now
+
+	if _, err := impl.OutboxService.PurgePublishedEvents(ctx, now.Add(-impl.Retention)); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.outbox.relay.purge:purge_published_events"}).Error(err)
+	}
+}
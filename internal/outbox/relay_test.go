@@ -0,0 +1,56 @@
+package outbox_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/outbox"
+	"github.com/viniosilva/starwars-api/mock"
+)
+
+func Test_Relay_Relay(t *testing.T) {
+	var cases = map[string]struct {
+		mocking     func(outboxService *mock.MockOutboxService)
+		expectedErr error
+	}{
+		"should relay until a partial batch": {
+			mocking: func(outboxService *mock.MockOutboxService) {
+				gomock.InOrder(
+					outboxService.EXPECT().RelayEvents(gomock.Any(), 2, gomock.Any()).Return(2, nil),
+					outboxService.EXPECT().RelayEvents(gomock.Any(), 2, gomock.Any()).Return(1, nil),
+				)
+			},
+		},
+		"should throw error when relay events": {
+			mocking: func(outboxService *mock.MockOutboxService) {
+				outboxService.EXPECT().RelayEvents(gomock.Any(), 2, gomock.Any()).Return(0, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockOutboxService := mock.NewMockOutboxService(ctrl)
+			relay := &outbox.IRelay{
+				OutboxService: mockOutboxService,
+				Sink:          mock.NewMockEventPublisher(ctrl),
+				BatchSize:     2,
+			}
+
+			cs.mocking(mockOutboxService)
+
+			// when
+			err := relay.Relay(context.Background())
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/viniosilva/starwars-api/internal/config"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/service"
+)
+
+const (
+	SINK_NONE   = "none"
+	SINK_STDOUT = "stdout"
+	SINK_FILE   = "file"
+	SINK_BROKER = "broker"
+
+	DEFAULT_TOPIC = "starwars.events"
+)
+
+// IWriterSink writes each event as a NDJSON line
+type IWriterSink struct {
+	Writer io.Writer
+	mutex  sync.Mutex
+}
+
+func (impl *IWriterSink) Publish(ctx context.Context, events ...dto.Event) error {
+	impl.mutex.Lock()
+	defer impl.mutex.Unlock()
+
+	encoder := json.NewEncoder(impl.Writer)
+	for _, e := range events {
+		if err := encoder.Encode(e); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// IBrokerSink produces each event to Topic keyed by its entity
+type IBrokerSink struct {
+	Broker Broker
+	Topic  string
+}
+
+func (impl *IBrokerSink) Publish(ctx context.Context, events ...dto.Event) error {
+	for _, e := range events {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+
+		if err := impl.Broker.Publish(ctx, impl.Topic, EventKey(e), data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// IFanoutSink publishes the events to every sink, failing when any of them
+// fails so that the events are relayed again
+type IFanoutSink struct {
+	Sinks []service.EventPublisher
+}
+
+func (impl *IFanoutSink) Publish(ctx context.Context, events ...dto.Event) error {
+	for _, s := range impl.Sinks {
+		if err := s.Publish(ctx, events...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// NewSink returns the sink configured for the outbox, nil when it is none.
+// The broker is only used by the broker sink
+func NewSink(c config.OutboxConfig, broker Broker) (service.EventPublisher, error) {
+	switch c.Sink {
+	case "", SINK_NONE:
+		return nil, nil
+	case SINK_STDOUT:
+		return &IWriterSink{Writer: os.Stdout}, nil
+	case SINK_FILE:
+		file, err := os.OpenFile(c.File, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			return nil, err
+		}
+		return &IWriterSink{Writer: file}, nil
+	case SINK_BROKER:
+		topic := c.Topic
+		if topic == "" {
+			topic = DEFAULT_TOPIC
+		}
+		return &IBrokerSink{Broker: broker, Topic: topic}, nil
+	}
+
+	return nil, fmt.Errorf("invalid outbox sink %s", c.Sink)
+}
+
+func EventKey(event dto.Event) string {
+	return fmt.Sprintf("%s:%d", event.Entity, event.EntityID)
+}
@@ -0,0 +1,185 @@
+package outbox_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/config"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/viniosilva/starwars-api/internal/outbox"
+	"github.com/viniosilva/starwars-api/internal/service"
+	"github.com/viniosilva/starwars-api/mock"
+)
+
+var event = dto.Event{
+	ID:         "event-id",
+	Type:       "planet.deleted",
+	Entity:     "planet",
+	EntityID:   1,
+	OccurredAt: time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC),
+	Data:       []byte(`{"id":1}`),
+}
+
+func Test_WriterSink_Publish(t *testing.T) {
+	// given
+	buffer := &bytes.Buffer{}
+	sink := &outbox.IWriterSink{Writer: buffer}
+
+	// when
+	err := sink.Publish(context.Background(), event, event)
+
+	// then
+	line := `{"id":"event-id","type":"planet.deleted","entity":"planet","entity_id":1,"occurred_at":"2022-10-01T12:00:00Z","data":{"id":1}}` + "\n"
+	assert.Nil(t, err)
+	assert.Equal(t, line+line, buffer.String())
+}
+
+func Test_BrokerSink_Publish(t *testing.T) {
+	var cases = map[string]struct {
+		mocking     func(broker *mock.MockBroker)
+		expectedErr error
+	}{
+		"should produce event keyed by entity": {
+			mocking: func(broker *mock.MockBroker) {
+				broker.EXPECT().Publish(gomock.Any(), "starwars.events", "planet:1", gomock.Any()).Return(nil)
+			},
+		},
+		"should throw error when produce": {
+			mocking: func(broker *mock.MockBroker) {
+				broker.EXPECT().Publish(gomock.Any(), "starwars.events", "planet:1", gomock.Any()).Return(fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockBroker := mock.NewMockBroker(ctrl)
+			sink := &outbox.IBrokerSink{Broker: mockBroker, Topic: "starwars.events"}
+
+			cs.mocking(mockBroker)
+
+			// when
+			err := sink.Publish(context.Background(), event)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func Test_FanoutSink_Publish(t *testing.T) {
+	var cases = map[string]struct {
+		mocking     func(first, second *mock.MockEventPublisher)
+		expectedErr error
+	}{
+		"should publish to every sink": {
+			mocking: func(first, second *mock.MockEventPublisher) {
+				first.EXPECT().Publish(gomock.Any(), event).Return(nil)
+				second.EXPECT().Publish(gomock.Any(), event).Return(nil)
+			},
+		},
+		"should throw error when a sink fails": {
+			mocking: func(first, second *mock.MockEventPublisher) {
+				first.EXPECT().Publish(gomock.Any(), event).Return(fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			first := mock.NewMockEventPublisher(ctrl)
+			second := mock.NewMockEventPublisher(ctrl)
+			sink := &outbox.IFanoutSink{Sinks: []service.EventPublisher{first, second}}
+
+			cs.mocking(first, second)
+
+			// when
+			err := sink.Publish(context.Background(), event)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func Test_FanoutSink_PublishAgainAfterLaterSinkFails(t *testing.T) {
+	// given
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	db, mockDB, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	for i := 0; i < 2; i += 1 {
+		mockDB.ExpectQuery("SELECT `webhooks`.\\* FROM `webhooks`").
+			WillReturnRows(sqlmock.NewRows([]string{model.WebhookColumns.ID, model.WebhookColumns.Events, model.WebhookColumns.Active}).
+				AddRow(1, []byte(`[]`), true))
+		mockDB.ExpectExec("INSERT IGNORE INTO webhook_deliveries").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 1, "event-id", "planet.deleted", sqlmock.AnyArg(), service.DELIVERY_STATUS_PENDING, 0, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, int64(1-i)))
+	}
+
+	later := mock.NewMockEventPublisher(ctrl)
+	gomock.InOrder(
+		later.EXPECT().Publish(gomock.Any(), event).Return(fmt.Errorf("error")),
+		later.EXPECT().Publish(gomock.Any(), event).Return(nil),
+	)
+	sink := &outbox.IFanoutSink{Sinks: []service.EventPublisher{&service.IWebhookService{DB: db}, later}}
+
+	// when
+	errFirst := sink.Publish(context.Background(), event)
+	errAgain := sink.Publish(context.Background(), event)
+
+	// then
+	assert.Equal(t, fmt.Errorf("error"), errFirst)
+	assert.Nil(t, errAgain)
+	assert.Nil(t, mockDB.ExpectationsWereMet())
+}
+
+func Test_NewSink(t *testing.T) {
+	var cases = map[string]struct {
+		inputConfig  config.OutboxConfig
+		expectedSink service.EventPublisher
+		expectedErr  error
+	}{
+		"should return no sink": {
+			inputConfig: config.OutboxConfig{Sink: outbox.SINK_NONE},
+		},
+		"should return broker sink with default topic": {
+			inputConfig:  config.OutboxConfig{Sink: outbox.SINK_BROKER},
+			expectedSink: &outbox.IBrokerSink{Topic: outbox.DEFAULT_TOPIC},
+		},
+		"should throw error when sink is invalid": {
+			inputConfig: config.OutboxConfig{Sink: "kafka"},
+			expectedErr: fmt.Errorf("invalid outbox sink kafka"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// when
+			sink, err := outbox.NewSink(cs.inputConfig, nil)
+
+			// then
+			assert.Equal(t, cs.expectedSink, sink)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
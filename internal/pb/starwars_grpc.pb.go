@@ -0,0 +1,385 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.21.12
+// source: starwars.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	PlanetService_ListPlanets_FullMethodName     = "/starwars.PlanetService/ListPlanets"
+	PlanetService_GetPlanet_FullMethodName       = "/starwars.PlanetService/GetPlanet"
+	PlanetService_DeletePlanet_FullMethodName    = "/starwars.PlanetService/DeletePlanet"
+	PlanetService_ListPlanetFilms_FullMethodName = "/starwars.PlanetService/ListPlanetFilms"
+)
+
+// PlanetServiceClient is the client API for PlanetService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PlanetServiceClient interface {
+	ListPlanets(ctx context.Context, in *ListPlanetsRequest, opts ...grpc.CallOption) (*ListPlanetsResponse, error)
+	GetPlanet(ctx context.Context, in *GetPlanetRequest, opts ...grpc.CallOption) (*Planet, error)
+	DeletePlanet(ctx context.Context, in *DeletePlanetRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListPlanetFilms(ctx context.Context, in *ListPlanetFilmsRequest, opts ...grpc.CallOption) (*ListFilmsResponse, error)
+}
+
+type planetServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPlanetServiceClient(cc grpc.ClientConnInterface) PlanetServiceClient {
+	return &planetServiceClient{cc}
+}
+
+func (c *planetServiceClient) ListPlanets(ctx context.Context, in *ListPlanetsRequest, opts ...grpc.CallOption) (*ListPlanetsResponse, error) {
+	out := new(ListPlanetsResponse)
+	err := c.cc.Invoke(ctx, PlanetService_ListPlanets_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *planetServiceClient) GetPlanet(ctx context.Context, in *GetPlanetRequest, opts ...grpc.CallOption) (*Planet, error) {
+	out := new(Planet)
+	err := c.cc.Invoke(ctx, PlanetService_GetPlanet_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *planetServiceClient) DeletePlanet(ctx context.Context, in *DeletePlanetRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, PlanetService_DeletePlanet_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *planetServiceClient) ListPlanetFilms(ctx context.Context, in *ListPlanetFilmsRequest, opts ...grpc.CallOption) (*ListFilmsResponse, error) {
+	out := new(ListFilmsResponse)
+	err := c.cc.Invoke(ctx, PlanetService_ListPlanetFilms_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PlanetServiceServer is the server API for PlanetService service.
+// All implementations must embed UnimplementedPlanetServiceServer
+// for forward compatibility
+type PlanetServiceServer interface {
+	ListPlanets(context.Context, *ListPlanetsRequest) (*ListPlanetsResponse, error)
+	GetPlanet(context.Context, *GetPlanetRequest) (*Planet, error)
+	DeletePlanet(context.Context, *DeletePlanetRequest) (*emptypb.Empty, error)
+	ListPlanetFilms(context.Context, *ListPlanetFilmsRequest) (*ListFilmsResponse, error)
+	mustEmbedUnimplementedPlanetServiceServer()
+}
+
+// UnimplementedPlanetServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedPlanetServiceServer struct {
+}
+
+func (UnimplementedPlanetServiceServer) ListPlanets(context.Context, *ListPlanetsRequest) (*ListPlanetsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPlanets not implemented")
+}
+func (UnimplementedPlanetServiceServer) GetPlanet(context.Context, *GetPlanetRequest) (*Planet, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPlanet not implemented")
+}
+func (UnimplementedPlanetServiceServer) DeletePlanet(context.Context, *DeletePlanetRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePlanet not implemented")
+}
+func (UnimplementedPlanetServiceServer) ListPlanetFilms(context.Context, *ListPlanetFilmsRequest) (*ListFilmsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPlanetFilms not implemented")
+}
+func (UnimplementedPlanetServiceServer) mustEmbedUnimplementedPlanetServiceServer() {}
+
+// UnsafePlanetServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PlanetServiceServer will
+// result in compilation errors.
+type UnsafePlanetServiceServer interface {
+	mustEmbedUnimplementedPlanetServiceServer()
+}
+
+func RegisterPlanetServiceServer(s grpc.ServiceRegistrar, srv PlanetServiceServer) {
+	s.RegisterService(&PlanetService_ServiceDesc, srv)
+}
+
+func _PlanetService_ListPlanets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPlanetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlanetServiceServer).ListPlanets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlanetService_ListPlanets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlanetServiceServer).ListPlanets(ctx, req.(*ListPlanetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlanetService_GetPlanet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPlanetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlanetServiceServer).GetPlanet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlanetService_GetPlanet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlanetServiceServer).GetPlanet(ctx, req.(*GetPlanetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlanetService_DeletePlanet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePlanetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlanetServiceServer).DeletePlanet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlanetService_DeletePlanet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlanetServiceServer).DeletePlanet(ctx, req.(*DeletePlanetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlanetService_ListPlanetFilms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPlanetFilmsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlanetServiceServer).ListPlanetFilms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlanetService_ListPlanetFilms_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlanetServiceServer).ListPlanetFilms(ctx, req.(*ListPlanetFilmsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PlanetService_ServiceDesc is the grpc.ServiceDesc for PlanetService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PlanetService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "starwars.PlanetService",
+	HandlerType: (*PlanetServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListPlanets",
+			Handler:    _PlanetService_ListPlanets_Handler,
+		},
+		{
+			MethodName: "GetPlanet",
+			Handler:    _PlanetService_GetPlanet_Handler,
+		},
+		{
+			MethodName: "DeletePlanet",
+			Handler:    _PlanetService_DeletePlanet_Handler,
+		},
+		{
+			MethodName: "ListPlanetFilms",
+			Handler:    _PlanetService_ListPlanetFilms_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "starwars.proto",
+}
+
+const (
+	FilmService_ListFilms_FullMethodName       = "/starwars.FilmService/ListFilms"
+	FilmService_GetFilm_FullMethodName         = "/starwars.FilmService/GetFilm"
+	FilmService_ListFilmPlanets_FullMethodName = "/starwars.FilmService/ListFilmPlanets"
+)
+
+// FilmServiceClient is the client API for FilmService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type FilmServiceClient interface {
+	ListFilms(ctx context.Context, in *ListFilmsRequest, opts ...grpc.CallOption) (*ListFilmsResponse, error)
+	GetFilm(ctx context.Context, in *GetFilmRequest, opts ...grpc.CallOption) (*Film, error)
+	ListFilmPlanets(ctx context.Context, in *ListFilmPlanetsRequest, opts ...grpc.CallOption) (*ListPlanetsResponse, error)
+}
+
+type filmServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFilmServiceClient(cc grpc.ClientConnInterface) FilmServiceClient {
+	return &filmServiceClient{cc}
+}
+
+func (c *filmServiceClient) ListFilms(ctx context.Context, in *ListFilmsRequest, opts ...grpc.CallOption) (*ListFilmsResponse, error) {
+	out := new(ListFilmsResponse)
+	err := c.cc.Invoke(ctx, FilmService_ListFilms_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *filmServiceClient) GetFilm(ctx context.Context, in *GetFilmRequest, opts ...grpc.CallOption) (*Film, error) {
+	out := new(Film)
+	err := c.cc.Invoke(ctx, FilmService_GetFilm_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *filmServiceClient) ListFilmPlanets(ctx context.Context, in *ListFilmPlanetsRequest, opts ...grpc.CallOption) (*ListPlanetsResponse, error) {
+	out := new(ListPlanetsResponse)
+	err := c.cc.Invoke(ctx, FilmService_ListFilmPlanets_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FilmServiceServer is the server API for FilmService service.
+// All implementations must embed UnimplementedFilmServiceServer
+// for forward compatibility
+type FilmServiceServer interface {
+	ListFilms(context.Context, *ListFilmsRequest) (*ListFilmsResponse, error)
+	GetFilm(context.Context, *GetFilmRequest) (*Film, error)
+	ListFilmPlanets(context.Context, *ListFilmPlanetsRequest) (*ListPlanetsResponse, error)
+	mustEmbedUnimplementedFilmServiceServer()
+}
+
+// UnimplementedFilmServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedFilmServiceServer struct {
+}
+
+func (UnimplementedFilmServiceServer) ListFilms(context.Context, *ListFilmsRequest) (*ListFilmsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFilms not implemented")
+}
+func (UnimplementedFilmServiceServer) GetFilm(context.Context, *GetFilmRequest) (*Film, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFilm not implemented")
+}
+func (UnimplementedFilmServiceServer) ListFilmPlanets(context.Context, *ListFilmPlanetsRequest) (*ListPlanetsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFilmPlanets not implemented")
+}
+func (UnimplementedFilmServiceServer) mustEmbedUnimplementedFilmServiceServer() {}
+
+// UnsafeFilmServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FilmServiceServer will
+// result in compilation errors.
+type UnsafeFilmServiceServer interface {
+	mustEmbedUnimplementedFilmServiceServer()
+}
+
+func RegisterFilmServiceServer(s grpc.ServiceRegistrar, srv FilmServiceServer) {
+	s.RegisterService(&FilmService_ServiceDesc, srv)
+}
+
+func _FilmService_ListFilms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFilmsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FilmServiceServer).ListFilms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FilmService_ListFilms_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FilmServiceServer).ListFilms(ctx, req.(*ListFilmsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FilmService_GetFilm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFilmRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FilmServiceServer).GetFilm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FilmService_GetFilm_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FilmServiceServer).GetFilm(ctx, req.(*GetFilmRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FilmService_ListFilmPlanets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFilmPlanetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FilmServiceServer).ListFilmPlanets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FilmService_ListFilmPlanets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FilmServiceServer).ListFilmPlanets(ctx, req.(*ListFilmPlanetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FilmService_ServiceDesc is the grpc.ServiceDesc for FilmService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FilmService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "starwars.FilmService",
+	HandlerType: (*FilmServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListFilms",
+			Handler:    _FilmService_ListFilms_Handler,
+		},
+		{
+			MethodName: "GetFilm",
+			Handler:    _FilmService_GetFilm_Handler,
+		},
+		{
+			MethodName: "ListFilmPlanets",
+			Handler:    _FilmService_ListFilmPlanets_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "starwars.proto",
+}
@@ -0,0 +1,111 @@
+package pubsub
+
+import (
+	"sync"
+
+	"github.com/viniosilva/starwars-api/internal/dto"
+)
+
+const DEFAULT_BUFFER_SIZE = 1000
+
+type Entry struct {
+	ID    uint64
+	Event dto.Event
+}
+
+type Hub interface {
+	Publish(entries ...Entry)
+	Subscribe(lastID uint64, buffer int) ([]Entry, <-chan Entry, func())
+}
+
+// IHub forwards the entries it receives to the subscribers, keeping the last
+// ones in a ring buffer so that subscribers can resume. Entries are numbered
+// by the outbox, so their ids survive restarts and are the same in every
+// instance
+type IHub struct {
+	mutex       sync.Mutex
+	ring        []Entry
+	start       int
+	lastID      uint64
+	subscribers map[chan Entry]bool
+}
+
+func NewHub(size int) *IHub {
+	if size < 1 {
+		size = DEFAULT_BUFFER_SIZE
+	}
+
+	return &IHub{
+		ring:        make([]Entry, 0, size),
+		subscribers: map[chan Entry]bool{},
+	}
+}
+
+// Publish never blocks: subscribers too slow to receive an event are
+// unsubscribed and may resume from the last event they received. Entries not
+// newer than the last published one are ignored
+func (impl *IHub) Publish(entries ...Entry) {
+	impl.mutex.Lock()
+	defer impl.mutex.Unlock()
+
+	for _, entry := range entries {
+		if entry.ID <= impl.lastID {
+			continue
+		}
+		impl.lastID = entry.ID
+
+		if len(impl.ring) < cap(impl.ring) {
+			impl.ring = append(impl.ring, entry)
+		} else {
+			impl.ring[impl.start] = entry
+			impl.start = (impl.start + 1) % len(impl.ring)
+		}
+
+		for ch := range impl.subscribers {
+			select {
+			case ch <- entry:
+			default:
+				delete(impl.subscribers, ch)
+				close(ch)
+			}
+		}
+	}
+}
+
+// Subscribe returns the buffered entries after lastID, the channel of the
+// next entries and the function to unsubscribe. A lastID of 0 skips the
+// buffered entries
+func (impl *IHub) Subscribe(lastID uint64, buffer int) ([]Entry, <-chan Entry, func()) {
+	impl.mutex.Lock()
+	defer impl.mutex.Unlock()
+
+	replay := []Entry{}
+	if lastID > 0 {
+		for i := 0; i < len(impl.ring); i += 1 {
+			entry := impl.ring[(impl.start+i)%len(impl.ring)]
+			if entry.ID > lastID {
+				replay = append(replay, entry)
+			}
+		}
+	}
+
+	ch := make(chan Entry, buffer)
+	impl.subscribers[ch] = true
+
+	return replay, ch, func() {
+		impl.mutex.Lock()
+		defer impl.mutex.Unlock()
+
+		if impl.subscribers[ch] {
+			delete(impl.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+func (impl *IHub) Len() int {
+	impl.mutex.Lock()
+	defer impl.mutex.Unlock()
+
+	return len(impl.subscribers)
+}
@@ -0,0 +1,71 @@
+package pubsub_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/pubsub"
+)
+
+func Test_Hub_Subscribe(t *testing.T) {
+	var cases = map[string]struct {
+		inputLastID    uint64
+		expectedReplay []uint64
+	}{
+		"should skip buffered entries without last id": {
+			inputLastID:    0,
+			expectedReplay: []uint64{},
+		},
+		"should replay entries after last id": {
+			inputLastID:    3,
+			expectedReplay: []uint64{4},
+		},
+		"should replay the whole buffer when last id was evicted": {
+			inputLastID:    1,
+			expectedReplay: []uint64{2, 3, 4},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			hub := pubsub.NewHub(3)
+			for i := 1; i <= 4; i += 1 {
+				hub.Publish(pubsub.Entry{ID: uint64(i), Event: dto.Event{EntityID: i}})
+			}
+
+			// when
+			replay, _, unsubscribe := hub.Subscribe(cs.inputLastID, 1)
+			defer unsubscribe()
+
+			// then
+			ids := []uint64{}
+			for _, entry := range replay {
+				ids = append(ids, entry.ID)
+				assert.Equal(t, int(entry.ID), entry.Event.EntityID)
+			}
+			assert.Equal(t, cs.expectedReplay, ids)
+		})
+	}
+}
+
+func Test_Hub_Publish(t *testing.T) {
+	// given
+	hub := pubsub.NewHub(10)
+	_, entries, unsubscribe := hub.Subscribe(0, 1)
+	defer unsubscribe()
+
+	// when
+	hub.Publish(pubsub.Entry{ID: 1, Event: dto.Event{ID: "first"}})
+	first := <-entries
+	hub.Publish(pubsub.Entry{ID: 1, Event: dto.Event{ID: "repeated"}})
+	hub.Publish(pubsub.Entry{ID: 2, Event: dto.Event{ID: "second"}}, pubsub.Entry{ID: 3, Event: dto.Event{ID: "third"}})
+
+	// then
+	assert.Equal(t, pubsub.Entry{ID: 1, Event: dto.Event{ID: "first"}}, first)
+	assert.Equal(t, pubsub.Entry{ID: 2, Event: dto.Event{ID: "second"}}, <-entries)
+
+	_, open := <-entries
+	assert.False(t, open, "slow subscriber should be unsubscribed")
+	assert.Equal(t, 0, hub.Len())
+}
@@ -0,0 +1,72 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const MEMORY_CLEANUP_INTERVAL = time.Minute
+
+type IMemoryStore struct {
+	mutex       sync.Mutex
+	buckets     map[string]*bucket
+	lastCleanup time.Time
+}
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+	fullAt    time.Time
+}
+
+func NewMemoryStore() *IMemoryStore {
+	return &IMemoryStore{buckets: map[string]*bucket{}}
+}
+
+func (impl *IMemoryStore) Take(ctx context.Context, key string, limit Limit, now time.Time) (Result, error) {
+	impl.mutex.Lock()
+	defer impl.mutex.Unlock()
+
+	impl.cleanup(now)
+
+	b, ok := impl.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Capacity()), updatedAt: now}
+		impl.buckets[key] = b
+	}
+
+	b.tokens = limit.Refill(b.tokens, now.Sub(b.updatedAt))
+	b.updatedAt = now
+
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens -= 1
+	}
+
+	res := limit.Result(b.tokens, allowed)
+	b.fullAt = now.Add(res.Reset)
+
+	return res, nil
+}
+
+// cleanup drops the buckets already refilled, which behave like new ones
+func (impl *IMemoryStore) cleanup(now time.Time) {
+	if now.Sub(impl.lastCleanup) < MEMORY_CLEANUP_INTERVAL {
+		return
+	}
+
+	for key, b := range impl.buckets {
+		if !now.Before(b.fullAt) {
+			delete(impl.buckets, key)
+		}
+	}
+	impl.lastCleanup = now
+}
+
+func (impl *IMemoryStore) Len() int {
+	impl.mutex.Lock()
+	defer impl.mutex.Unlock()
+
+	return len(impl.buckets)
+}
@@ -0,0 +1,73 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/ratelimit"
+)
+
+func Test_MemoryStore_Take(t *testing.T) {
+	now := time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)
+	limit := ratelimit.Limit{RequestsPerMinute: 60, Burst: 2}
+
+	var cases = map[string]struct {
+		inputTimes      []time.Time
+		expectedResults []ratelimit.Result
+	}{
+		"should allow requests until the burst is consumed": {
+			inputTimes: []time.Time{now, now, now},
+			expectedResults: []ratelimit.Result{
+				{Allowed: true, Limit: 2, Remaining: 1, Reset: time.Second},
+				{Allowed: true, Limit: 2, Remaining: 0, Reset: 2 * time.Second},
+				{Allowed: false, Limit: 2, Remaining: 0, Reset: 2 * time.Second, RetryAfter: time.Second},
+			},
+		},
+		"should refill tokens over time": {
+			inputTimes: []time.Time{now, now, now.Add(time.Second)},
+			expectedResults: []ratelimit.Result{
+				{Allowed: true, Limit: 2, Remaining: 1, Reset: time.Second},
+				{Allowed: true, Limit: 2, Remaining: 0, Reset: 2 * time.Second},
+				{Allowed: true, Limit: 2, Remaining: 0, Reset: 2 * time.Second},
+			},
+		},
+		"should not refill above the burst": {
+			inputTimes: []time.Time{now, now.Add(time.Hour)},
+			expectedResults: []ratelimit.Result{
+				{Allowed: true, Limit: 2, Remaining: 1, Reset: time.Second},
+				{Allowed: true, Limit: 2, Remaining: 1, Reset: time.Second},
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			store := ratelimit.NewMemoryStore()
+
+			for i := 0; i < len(cs.inputTimes); i += 1 {
+				// when
+				res, err := store.Take(context.Background(), "ip:127.0.0.1", limit, cs.inputTimes[i])
+
+				// then
+				assert.Nil(t, err)
+				assert.Equal(t, cs.expectedResults[i], res)
+			}
+		})
+	}
+}
+
+func Test_MemoryStore_Cleanup(t *testing.T) {
+	// given
+	now := time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)
+	limit := ratelimit.Limit{RequestsPerMinute: 60, Burst: 2}
+	store := ratelimit.NewMemoryStore()
+
+	// when
+	store.Take(context.Background(), "ip:127.0.0.1", limit, now)
+	store.Take(context.Background(), "ip:127.0.0.2", limit, now.Add(2*time.Minute))
+
+	// then
+	assert.Equal(t, 1, store.Len())
+}
@@ -0,0 +1,65 @@
+package ratelimit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/i18n"
+)
+
+// GinRateLimit takes a token from the bucket of the client, identified by the
+// X-Api-Key header or by the client IP, for the route limit or the default one.
+// Routes with their own limit have their own bucket, the remaining ones share it
+func GinRateLimit(store Store, rules Rules) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		route := RouteKey(ctx.Request.Method, ctx.FullPath())
+		limit, ok := rules.Routes[route]
+		if !ok {
+			limit = rules.Default
+			route = "*"
+		}
+		if limit.RequestsPerMinute <= 0 {
+			ctx.Next()
+			return
+		}
+
+		res, err := store.Take(ctx, ClientKey(ctx)+"|"+route, limit, time.Now())
+		if err != nil {
+			logrus.WithFields(logrus.Fields{"trace": "ratelimit.GinRateLimit"}).Error(err)
+			ctx.Next()
+			return
+		}
+
+		ctx.Header("X-RateLimit-Limit", strconv.Itoa(res.Limit))
+		ctx.Header("X-RateLimit-Remaining", strconv.Itoa(res.Remaining))
+		ctx.Header("X-RateLimit-Reset", strconv.Itoa(seconds(res.Reset)))
+		if !res.Allowed {
+			ctx.Header("Retry-After", strconv.Itoa(seconds(res.RetryAfter)))
+			ctx.AbortWithStatusJSON(http.StatusTooManyRequests, dto.ApiError{Error: i18n.T(ctx, "too many requests")})
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// ClientKey hashes the api key so it is never kept in the store
+func ClientKey(ctx *gin.Context) string {
+	if apiKey := ctx.GetHeader("X-Api-Key"); apiKey != "" {
+		sum := sha256.Sum256([]byte(apiKey))
+		return "key:" + hex.EncodeToString(sum[:])
+	}
+
+	return "ip:" + ctx.ClientIP()
+}
+
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,125 @@
+package ratelimit_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/ratelimit"
+	"github.com/viniosilva/starwars-api/mock"
+)
+
+func Test_RateLimit_GinRateLimit(t *testing.T) {
+	rules := ratelimit.Rules{
+		Default: ratelimit.Limit{RequestsPerMinute: 60, Burst: 2},
+		Routes: map[string]ratelimit.Limit{
+			ratelimit.RouteKey(http.MethodPost, "/import"): {RequestsPerMinute: 1},
+			ratelimit.RouteKey(http.MethodGet, "/health"):  {},
+		},
+	}
+
+	var cases = map[string]struct {
+		inputRequests       []*http.Request
+		expectedStatusCodes []int
+		expectedHeaders     map[string]string
+		expectedBody        string
+	}{
+		"should throw too many requests when the burst is consumed": {
+			inputRequests: []*http.Request{
+				httptest.NewRequest(http.MethodGet, "/planets", nil),
+				httptest.NewRequest(http.MethodGet, "/planets/1", nil),
+				httptest.NewRequest(http.MethodGet, "/planets", nil),
+			},
+			expectedStatusCodes: []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests},
+			expectedHeaders:     map[string]string{"X-RateLimit-Limit": "2", "X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "2", "Retry-After": "1"},
+			expectedBody:        `{"error":"too many requests"}`,
+		},
+		"should use the route limit": {
+			inputRequests: []*http.Request{
+				httptest.NewRequest(http.MethodPost, "/import", nil),
+				httptest.NewRequest(http.MethodGet, "/planets", nil),
+				httptest.NewRequest(http.MethodPost, "/import", nil),
+			},
+			expectedStatusCodes: []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests},
+			expectedHeaders:     map[string]string{"X-RateLimit-Limit": "1", "Retry-After": "60"},
+			expectedBody:        `{"error":"too many requests"}`,
+		},
+		"should not limit routes without limit": {
+			inputRequests: []*http.Request{
+				httptest.NewRequest(http.MethodGet, "/health", nil),
+				httptest.NewRequest(http.MethodGet, "/health", nil),
+				httptest.NewRequest(http.MethodGet, "/health", nil),
+			},
+			expectedStatusCodes: []int{http.StatusOK, http.StatusOK, http.StatusOK},
+			expectedHeaders:     map[string]string{"X-RateLimit-Limit": ""},
+		},
+		"should keep a bucket for each api key": {
+			inputRequests: []*http.Request{
+				withApiKey(httptest.NewRequest(http.MethodPost, "/import", nil), "luke"),
+				withApiKey(httptest.NewRequest(http.MethodPost, "/import", nil), "leia"),
+				httptest.NewRequest(http.MethodPost, "/import", nil),
+			},
+			expectedStatusCodes: []int{http.StatusOK, http.StatusOK, http.StatusOK},
+			expectedHeaders:     map[string]string{"X-RateLimit-Limit": "1", "X-RateLimit-Remaining": "0"},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			gin.SetMode(gin.TestMode)
+			_, r := gin.CreateTestContext(httptest.NewRecorder())
+
+			r.Use(ratelimit.GinRateLimit(ratelimit.NewMemoryStore(), rules))
+			r.GET("/health", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+			r.GET("/planets", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+			r.GET("/planets/:planetID", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+			r.POST("/import", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+
+			var res *httptest.ResponseRecorder
+			for i := 0; i < len(cs.inputRequests); i += 1 {
+				// when
+				res = httptest.NewRecorder()
+				r.ServeHTTP(res, cs.inputRequests[i])
+
+				// then
+				assert.Equal(t, cs.expectedStatusCodes[i], res.Code, fmt.Sprintf("request %d", i))
+			}
+			for header, value := range cs.expectedHeaders {
+				assert.Equal(t, value, res.Header().Get(header), header)
+			}
+			assert.Equal(t, cs.expectedBody, res.Body.String())
+		})
+	}
+}
+
+func Test_RateLimit_GinRateLimitStoreError(t *testing.T) {
+	// given
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gin.SetMode(gin.TestMode)
+	res := httptest.NewRecorder()
+	_, r := gin.CreateTestContext(res)
+
+	mockStore := mock.NewMockStore(ctrl)
+	mockStore.EXPECT().Take(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(ratelimit.Result{}, fmt.Errorf("error"))
+
+	r.Use(ratelimit.GinRateLimit(mockStore, ratelimit.Rules{Default: ratelimit.Limit{RequestsPerMinute: 1}}))
+	r.GET("/planets", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+
+	// when
+	r.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/planets", nil))
+
+	// then
+	assert.Equal(t, http.StatusOK, res.Code)
+	assert.Equal(t, "", res.Header().Get("X-RateLimit-Limit"))
+}
+
+func withApiKey(req *http.Request, apiKey string) *http.Request {
+	req.Header.Set("X-Api-Key", apiKey)
+	return req
+}
@@ -0,0 +1,109 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/viniosilva/starwars-api/internal/config"
+)
+
+const (
+	STORE_MEMORY = "memory"
+	STORE_REDIS  = "redis"
+)
+
+// Limit is a token bucket refilled with RequestsPerMinute tokens per minute
+// and holding up to Burst tokens, a non positive RequestsPerMinute means no limit
+type Limit struct {
+	RequestsPerMinute int
+	Burst             int
+}
+
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration
+	Reset      time.Duration
+}
+
+//go:generate mockgen -destination=../../mock/ratelimit_store_mock.go -package=mock . Store
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit, now time.Time) (Result, error)
+}
+
+type Rules struct {
+	Default Limit
+	Routes  map[string]Limit
+}
+
+// NewStore builds the memory store, or the redis store shared between
+// instances when rate_limit.store is redis
+func NewStore(c config.RateLimitConfig) (Store, error) {
+	switch c.Store {
+	case "", STORE_MEMORY:
+		return NewMemoryStore(), nil
+	case STORE_REDIS:
+		client := redis.NewClient(&redis.Options{
+			Addr:     c.Redis.Address,
+			Password: c.Redis.Password,
+			DB:       c.Redis.DB,
+		})
+		return &IRedisStore{Client: client}, nil
+	}
+
+	return nil, fmt.Errorf("invalid rate limit store %s", c.Store)
+}
+
+func NewRules(c config.RateLimitConfig) Rules {
+	rules := Rules{
+		Default: Limit{RequestsPerMinute: c.RequestsPerMinute, Burst: c.Burst},
+		Routes:  map[string]Limit{},
+	}
+	for _, r := range c.Routes {
+		rules.Routes[RouteKey(r.Method, r.Path)] = Limit{RequestsPerMinute: r.RequestsPerMinute, Burst: r.Burst}
+	}
+
+	return rules
+}
+
+func RouteKey(method, path string) string {
+	return fmt.Sprintf("%s %s", strings.ToUpper(method), path)
+}
+
+func (impl Limit) Capacity() int {
+	if impl.Burst > 0 {
+		return impl.Burst
+	}
+
+	return impl.RequestsPerMinute
+}
+
+// Refill returns the bucket tokens after elapsed time, never above Capacity
+func (impl Limit) Refill(tokens float64, elapsed time.Duration) float64 {
+	if elapsed > 0 {
+		tokens += elapsed.Minutes() * float64(impl.RequestsPerMinute)
+	}
+
+	return math.Min(tokens, float64(impl.Capacity()))
+}
+
+// Result describes the bucket left with tokens after a request was allowed or not
+func (impl Limit) Result(tokens float64, allowed bool) Result {
+	perToken := time.Minute / time.Duration(impl.RequestsPerMinute)
+	res := Result{
+		Allowed:   allowed,
+		Limit:     impl.Capacity(),
+		Remaining: int(math.Floor(tokens)),
+		Reset:     time.Duration((float64(impl.Capacity()) - tokens) * float64(perToken)),
+	}
+	if !allowed {
+		res.RetryAfter = time.Duration((1 - tokens) * float64(perToken))
+	}
+
+	return res
+}
@@ -0,0 +1,67 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const REDIS_KEY_PREFIX = "ratelimit:"
+
+// takeScript refills and takes a token atomically, returning whether it was
+// allowed and the tokens left as a string, since redis truncates lua numbers
+var takeScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local perMillisecond = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'updated_at')
+local tokens = tonumber(bucket[1])
+local updatedAt = tonumber(bucket[2])
+if tokens == nil or updatedAt == nil then
+	tokens = capacity
+	updatedAt = now
+end
+
+tokens = math.min(capacity, tokens + math.max(0, now - updatedAt) * perMillisecond)
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'updated_at', tostring(now))
+redis.call('PEXPIRE', KEYS[1], math.ceil((capacity - tokens) / perMillisecond) + 1000)
+
+return {allowed, tostring(tokens)}
+`)
+
+type IRedisStore struct {
+	Client redis.Scripter
+}
+
+func (impl *IRedisStore) Take(ctx context.Context, key string, limit Limit, now time.Time) (Result, error) {
+	perMillisecond := float64(limit.RequestsPerMinute) / float64(time.Minute.Milliseconds())
+
+	values, err := takeScript.Run(ctx, impl.Client, []string{REDIS_KEY_PREFIX + key},
+		limit.Capacity(), strconv.FormatFloat(perMillisecond, 'f', -1, 64), now.UnixMilli()).Slice()
+	if err != nil {
+		return Result{}, err
+	}
+	if len(values) != 2 {
+		return Result{}, fmt.Errorf("unexpected rate limit script result %v", values)
+	}
+
+	allowed, _ := values[0].(int64)
+	raw, _ := values[1].(string)
+	tokens, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return Result{}, err
+	}
+
+	return limit.Result(tokens, allowed == 1), nil
+}
@@ -0,0 +1,75 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/ratelimit"
+)
+
+func Test_RedisStore_Take(t *testing.T) {
+	now := time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)
+	limit := ratelimit.Limit{RequestsPerMinute: 60, Burst: 2}
+
+	var cases = map[string]struct {
+		inputTimes      []time.Time
+		expectedResults []ratelimit.Result
+	}{
+		"should allow requests until the burst is consumed": {
+			inputTimes: []time.Time{now, now, now},
+			expectedResults: []ratelimit.Result{
+				{Allowed: true, Limit: 2, Remaining: 1, Reset: time.Second},
+				{Allowed: true, Limit: 2, Remaining: 0, Reset: 2 * time.Second},
+				{Allowed: false, Limit: 2, Remaining: 0, Reset: 2 * time.Second, RetryAfter: time.Second},
+			},
+		},
+		"should refill tokens over time": {
+			inputTimes: []time.Time{now, now, now.Add(time.Second)},
+			expectedResults: []ratelimit.Result{
+				{Allowed: true, Limit: 2, Remaining: 1, Reset: time.Second},
+				{Allowed: true, Limit: 2, Remaining: 0, Reset: 2 * time.Second},
+				{Allowed: true, Limit: 2, Remaining: 0, Reset: 2 * time.Second},
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			server := miniredis.RunT(t)
+			client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+			defer client.Close()
+
+			store := &ratelimit.IRedisStore{Client: client}
+
+			for i := 0; i < len(cs.inputTimes); i += 1 {
+				// when
+				res, err := store.Take(context.Background(), "ip:127.0.0.1", limit, cs.inputTimes[i])
+
+				// then
+				assert.Nil(t, err)
+				assert.Equal(t, cs.expectedResults[i], res)
+			}
+			assert.True(t, server.Exists(ratelimit.REDIS_KEY_PREFIX+"ip:127.0.0.1"))
+		})
+	}
+}
+
+func Test_RedisStore_TakeError(t *testing.T) {
+	// given
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+	server.Close()
+
+	store := &ratelimit.IRedisStore{Client: client}
+
+	// when
+	_, err := store.Take(context.Background(), "ip:127.0.0.1", ratelimit.Limit{RequestsPerMinute: 60}, time.Now())
+
+	// then
+	assert.NotNil(t, err)
+}
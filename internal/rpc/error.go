@@ -0,0 +1,20 @@
+package rpc
+
+import (
+	"github.com/viniosilva/starwars-api/internal/exception"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func ParseError(err error) error {
+	switch e := err.(type) {
+	case *exception.NotFoundException:
+		return status.Error(codes.NotFound, e.Message)
+	case *exception.ConflictException:
+		return status.Error(codes.FailedPrecondition, e.Message)
+	case *exception.ValidationException:
+		return status.Error(codes.InvalidArgument, e.Message)
+	}
+
+	return status.Error(codes.Internal, "internal server error")
+}
@@ -0,0 +1,45 @@
+package rpc_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/exception"
+	"github.com/viniosilva/starwars-api/internal/rpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_Rpc_ParseError(t *testing.T) {
+	var cases = map[string]struct {
+		inputErr    error
+		expectedErr error
+	}{
+		"should return not found": {
+			inputErr:    &exception.NotFoundException{Message: "planet 1 not found"},
+			expectedErr: status.Error(codes.NotFound, "planet 1 not found"),
+		},
+		"should return failed precondition when conflict": {
+			inputErr:    &exception.ConflictException{Message: "planet 1 is deleted"},
+			expectedErr: status.Error(codes.FailedPrecondition, "planet 1 is deleted"),
+		},
+		"should return invalid argument when validation fails": {
+			inputErr:    &exception.ValidationException{Message: "name is required"},
+			expectedErr: status.Error(codes.InvalidArgument, "name is required"),
+		},
+		"should return internal when error is unknown": {
+			inputErr:    fmt.Errorf("error"),
+			expectedErr: status.Error(codes.Internal, "internal server error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// when
+			err := rpc.ParseError(cs.inputErr)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/viniosilva/starwars-api/internal/pb"
+	"github.com/viniosilva/starwars-api/internal/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type IFilmServer struct {
+	pb.UnimplementedFilmServiceServer
+	PlanetService service.PlanetService
+	FilmService   service.FilmService
+}
+
+func (impl *IFilmServer) ListFilms(ctx context.Context, req *pb.ListFilmsRequest) (*pb.ListFilmsResponse, error) {
+	page := int(req.Page)
+	if page < 1 {
+		page = 1
+	}
+	size := int(req.Size)
+	if size < 1 {
+		size = 10
+	}
+
+	opts := []service.Option{}
+	if req.Title != "" {
+		opts = append(opts, service.OptionWhere("title like ?", req.Title))
+	}
+	if req.Director != "" {
+		opts = append(opts, service.OptionWhere("director like ?", req.Director))
+	}
+	if req.Episode > 0 {
+		opts = append(opts, service.OptionWhere("episode = ?", req.Episode))
+	}
+
+	res, err := impl.FilmService.FindFilmsAndTotal(ctx, page, size, opts...)
+	if err != nil {
+		return nil, ParseError(err)
+	}
+
+	data := make([]*pb.Film, len(res.Data))
+	for i, f := range res.Data {
+		data[i] = ParseFilmProto(f)
+	}
+
+	return &pb.ListFilmsResponse{
+		Pagination: &pb.Pagination{Count: int32(res.Count), Total: res.Total, HasNext: res.Next},
+		Data:       data,
+	}, nil
+}
+
+func (impl *IFilmServer) GetFilm(ctx context.Context, req *pb.GetFilmRequest) (*pb.Film, error) {
+	if req.Id < 1 {
+		return nil, status.Error(codes.InvalidArgument, "invalid film id")
+	}
+
+	film, err := impl.FilmService.FindFilmByID(ctx, int(req.Id))
+	if err != nil {
+		return nil, ParseError(err)
+	}
+
+	return ParseFilmProto(film), nil
+}
+
+func (impl *IFilmServer) ListFilmPlanets(ctx context.Context, req *pb.ListFilmPlanetsRequest) (*pb.ListPlanetsResponse, error) {
+	if req.FilmId < 1 {
+		return nil, status.Error(codes.InvalidArgument, "invalid film id")
+	}
+
+	if _, err := impl.FilmService.FindFilmByID(ctx, int(req.FilmId)); err != nil {
+		return nil, ParseError(err)
+	}
+
+	planets, err := impl.PlanetService.FindPlanetsByFilmIDs(ctx, []int{int(req.FilmId)})
+	if err != nil {
+		return nil, ParseError(err)
+	}
+
+	data := make([]*pb.Planet, len(planets[int(req.FilmId)]))
+	for i, p := range planets[int(req.FilmId)] {
+		data[i] = ParsePlanetProto(p)
+	}
+
+	return &pb.ListPlanetsResponse{
+		Pagination: &pb.Pagination{Count: int32(len(data)), Total: int64(len(data))},
+		Data:       data,
+	}, nil
+}
@@ -0,0 +1,191 @@
+package rpc_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/exception"
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/viniosilva/starwars-api/internal/pb"
+	"github.com/viniosilva/starwars-api/internal/rpc"
+	"github.com/viniosilva/starwars-api/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func Test_FilmServer_ListFilms(t *testing.T) {
+	var cases = map[string]struct {
+		mocking      func(filmService *mock.MockFilmService)
+		inputRequest *pb.ListFilmsRequest
+		expectedLen  int
+		expectedCode codes.Code
+	}{
+		"should return films list": {
+			mocking: func(filmService *mock.MockFilmService) {
+				filmService.EXPECT().FindFilmsAndTotal(gomock.Any(), 1, 10).
+					Return(dto.FindFilmsAndTotalResult{Count: 2, Total: 2, Data: []*model.Film{{ID: 1}, {ID: 2}}}, nil)
+			},
+			inputRequest: &pb.ListFilmsRequest{},
+			expectedLen:  2,
+			expectedCode: codes.OK,
+		},
+		"should return films list when filtering": {
+			mocking: func(filmService *mock.MockFilmService) {
+				filmService.EXPECT().FindFilmsAndTotal(gomock.Any(), 1, 10, gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(dto.FindFilmsAndTotalResult{Count: 1, Total: 1, Data: []*model.Film{{ID: 1}}}, nil)
+			},
+			inputRequest: &pb.ListFilmsRequest{Title: "A New Hope", Director: "George Lucas", Episode: 4},
+			expectedLen:  1,
+			expectedCode: codes.OK,
+		},
+		"should throw internal error": {
+			mocking: func(filmService *mock.MockFilmService) {
+				filmService.EXPECT().FindFilmsAndTotal(gomock.Any(), 1, 10).
+					Return(dto.FindFilmsAndTotalResult{}, fmt.Errorf("error"))
+			},
+			inputRequest: &pb.ListFilmsRequest{},
+			expectedCode: codes.Internal,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockFilmService := mock.NewMockFilmService(ctrl)
+			filmServer := &rpc.IFilmServer{FilmService: mockFilmService}
+
+			cs.mocking(mockFilmService)
+
+			// when
+			res, err := filmServer.ListFilms(context.Background(), cs.inputRequest)
+
+			// then
+			assert.Equal(t, cs.expectedCode, status.Code(err))
+			assert.Equal(t, cs.expectedLen, len(res.GetData()))
+		})
+	}
+}
+
+func Test_FilmServer_GetFilm(t *testing.T) {
+	var cases = map[string]struct {
+		mocking      func(filmService *mock.MockFilmService)
+		inputRequest *pb.GetFilmRequest
+		expectedRes  *pb.Film
+		expectedErr  error
+	}{
+		"should return film": {
+			mocking: func(filmService *mock.MockFilmService) {
+				filmService.EXPECT().FindFilmByID(gomock.Any(), 1).Return(&model.Film{
+					ID:          1,
+					Title:       "A New Hope",
+					Episode:     4,
+					Director:    "George Lucas",
+					ReleaseDate: time.Date(1977, 5, 25, 0, 0, 0, 0, time.UTC),
+				}, nil)
+			},
+			inputRequest: &pb.GetFilmRequest{Id: 1},
+			expectedRes: &pb.Film{
+				Id:          1,
+				CreatedAt:   timestamppb.New(time.Time{}),
+				UpdatedAt:   timestamppb.New(time.Time{}),
+				Title:       "A New Hope",
+				Episode:     4,
+				Director:    "George Lucas",
+				ReleaseDate: "1977-05-25",
+			},
+		},
+		"should throw invalid argument when id is invalid": {
+			mocking:      func(filmService *mock.MockFilmService) {},
+			inputRequest: &pb.GetFilmRequest{},
+			expectedErr:  status.Error(codes.InvalidArgument, "invalid film id"),
+		},
+		"should throw not found": {
+			mocking: func(filmService *mock.MockFilmService) {
+				filmService.EXPECT().FindFilmByID(gomock.Any(), 1).
+					Return(nil, &exception.NotFoundException{Message: "film 1 not found"})
+			},
+			inputRequest: &pb.GetFilmRequest{Id: 1},
+			expectedErr:  status.Error(codes.NotFound, "film 1 not found"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockFilmService := mock.NewMockFilmService(ctrl)
+			filmServer := &rpc.IFilmServer{FilmService: mockFilmService}
+
+			cs.mocking(mockFilmService)
+
+			// when
+			res, err := filmServer.GetFilm(context.Background(), cs.inputRequest)
+
+			// then
+			assert.Equal(t, cs.expectedRes, res)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func Test_FilmServer_ListFilmPlanets(t *testing.T) {
+	var cases = map[string]struct {
+		mocking      func(planetService *mock.MockPlanetService, filmService *mock.MockFilmService)
+		inputRequest *pb.ListFilmPlanetsRequest
+		expectedLen  int
+		expectedCode codes.Code
+	}{
+		"should return film planets": {
+			mocking: func(planetService *mock.MockPlanetService, filmService *mock.MockFilmService) {
+				filmService.EXPECT().FindFilmByID(gomock.Any(), 1).Return(&model.Film{ID: 1}, nil)
+				planetService.EXPECT().FindPlanetsByFilmIDs(gomock.Any(), []int{1}).
+					Return(map[int][]*model.Planet{1: {{ID: 1}}}, nil)
+			},
+			inputRequest: &pb.ListFilmPlanetsRequest{FilmId: 1},
+			expectedLen:  1,
+			expectedCode: codes.OK,
+		},
+		"should throw invalid argument when id is invalid": {
+			mocking:      func(planetService *mock.MockPlanetService, filmService *mock.MockFilmService) {},
+			inputRequest: &pb.ListFilmPlanetsRequest{},
+			expectedCode: codes.InvalidArgument,
+		},
+		"should throw not found when film does not exist": {
+			mocking: func(planetService *mock.MockPlanetService, filmService *mock.MockFilmService) {
+				filmService.EXPECT().FindFilmByID(gomock.Any(), 1).
+					Return(nil, &exception.NotFoundException{Message: "film 1 not found"})
+			},
+			inputRequest: &pb.ListFilmPlanetsRequest{FilmId: 1},
+			expectedCode: codes.NotFound,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPlanetService := mock.NewMockPlanetService(ctrl)
+			mockFilmService := mock.NewMockFilmService(ctrl)
+			filmServer := &rpc.IFilmServer{PlanetService: mockPlanetService, FilmService: mockFilmService}
+
+			cs.mocking(mockPlanetService, mockFilmService)
+
+			// when
+			res, err := filmServer.ListFilmPlanets(context.Background(), cs.inputRequest)
+
+			// then
+			assert.Equal(t, cs.expectedCode, status.Code(err))
+			assert.Equal(t, cs.expectedLen, len(res.GetData()))
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/viniosilva/starwars-api/internal/pb"
+	"github.com/viniosilva/starwars-api/internal/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type IPlanetServer struct {
+	pb.UnimplementedPlanetServiceServer
+	PlanetService service.PlanetService
+	FilmService   service.FilmService
+}
+
+func (impl *IPlanetServer) ListPlanets(ctx context.Context, req *pb.ListPlanetsRequest) (*pb.ListPlanetsResponse, error) {
+	page := int(req.Page)
+	if page < 1 {
+		page = 1
+	}
+	size := int(req.Size)
+	if size < 1 {
+		size = 10
+	}
+
+	opts := []service.Option{}
+	if req.Name != "" {
+		opts = append(opts, service.OptionWhere("name like ?", req.Name))
+	}
+
+	res, err := impl.PlanetService.FindPlanetsAndTotal(ctx, page, size, req.LoadFilms, opts...)
+	if err != nil {
+		return nil, ParseError(err)
+	}
+
+	data := make([]*pb.Planet, len(res.Data))
+	for i, p := range res.Data {
+		data[i] = ParsePlanetProto(p)
+	}
+
+	return &pb.ListPlanetsResponse{
+		Pagination: &pb.Pagination{Count: int32(res.Count), Total: res.Total, HasNext: res.Next},
+		Data:       data,
+	}, nil
+}
+
+func (impl *IPlanetServer) GetPlanet(ctx context.Context, req *pb.GetPlanetRequest) (*pb.Planet, error) {
+	if req.Id < 1 {
+		return nil, status.Error(codes.InvalidArgument, "invalid planet id")
+	}
+
+	planet, err := impl.PlanetService.FindPlanetByID(ctx, int(req.Id), req.LoadFilms)
+	if err != nil {
+		return nil, ParseError(err)
+	}
+
+	return ParsePlanetProto(planet), nil
+}
+
+func (impl *IPlanetServer) DeletePlanet(ctx context.Context, req *pb.DeletePlanetRequest) (*emptypb.Empty, error) {
+	if req.Id < 1 {
+		return nil, status.Error(codes.InvalidArgument, "invalid planet id")
+	}
+
+	if err := impl.PlanetService.DeletePlanet(ctx, int(req.Id)); err != nil {
+		return nil, ParseError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (impl *IPlanetServer) ListPlanetFilms(ctx context.Context, req *pb.ListPlanetFilmsRequest) (*pb.ListFilmsResponse, error) {
+	if req.PlanetId < 1 {
+		return nil, status.Error(codes.InvalidArgument, "invalid planet id")
+	}
+
+	if _, err := impl.PlanetService.FindPlanetByID(ctx, int(req.PlanetId), false); err != nil {
+		return nil, ParseError(err)
+	}
+
+	films, err := impl.FilmService.FindFilmsByPlanetIDs(ctx, []int{int(req.PlanetId)})
+	if err != nil {
+		return nil, ParseError(err)
+	}
+
+	data := make([]*pb.Film, len(films[int(req.PlanetId)]))
+	for i, f := range films[int(req.PlanetId)] {
+		data[i] = ParseFilmProto(f)
+	}
+
+	return &pb.ListFilmsResponse{
+		Pagination: &pb.Pagination{Count: int32(len(data)), Total: int64(len(data))},
+		Data:       data,
+	}, nil
+}
+
+func ParsePlanetProto(planet *model.Planet) *pb.Planet {
+	var climates []string
+	json.Unmarshal(planet.Climates, &climates)
+
+	var terrains []string
+	json.Unmarshal(planet.Terrains, &terrains)
+
+	var films []*pb.Film
+	if planet.R != nil && len(planet.R.Films) > 0 {
+		films = make([]*pb.Film, len(planet.R.Films))
+		for i, f := range planet.R.Films {
+			films[i] = ParseFilmProto(f)
+		}
+	}
+
+	return &pb.Planet{
+		Id:        int32(planet.ID),
+		CreatedAt: timestamppb.New(planet.CreatedAt),
+		UpdatedAt: timestamppb.New(planet.UpdatedAt),
+		Name:      planet.Name,
+		Climates:  climates,
+		Terrains:  terrains,
+		Films:     films,
+	}
+}
+
+func ParseFilmProto(film *model.Film) *pb.Film {
+	return &pb.Film{
+		Id:          int32(film.ID),
+		CreatedAt:   timestamppb.New(film.CreatedAt),
+		UpdatedAt:   timestamppb.New(film.UpdatedAt),
+		Title:       film.Title,
+		Episode:     int32(film.Episode),
+		Director:    film.Director,
+		ReleaseDate: film.ReleaseDate.Format("2006-01-02"),
+	}
+}
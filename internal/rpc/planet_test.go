@@ -0,0 +1,259 @@
+package rpc_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/exception"
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/viniosilva/starwars-api/internal/pb"
+	"github.com/viniosilva/starwars-api/internal/rpc"
+	"github.com/viniosilva/starwars-api/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func Test_PlanetServer_ListPlanets(t *testing.T) {
+	var cases = map[string]struct {
+		mocking      func(planetService *mock.MockPlanetService)
+		inputRequest *pb.ListPlanetsRequest
+		expectedRes  *pb.ListPlanetsResponse
+		expectedCode codes.Code
+	}{
+		"should return planets list": {
+			mocking: func(planetService *mock.MockPlanetService) {
+				planetService.EXPECT().FindPlanetsAndTotal(gomock.Any(), 1, 10, false).
+					Return(dto.FindPlanetsAndTotalResult{Count: 1, Total: 2, Next: true, Data: []*model.Planet{{ID: 1}}}, nil)
+			},
+			inputRequest: &pb.ListPlanetsRequest{},
+			expectedRes: &pb.ListPlanetsResponse{
+				Pagination: &pb.Pagination{Count: 1, Total: 2, HasNext: true},
+				Data: []*pb.Planet{{
+					Id:        1,
+					CreatedAt: timestamppb.New(time.Time{}),
+					UpdatedAt: timestamppb.New(time.Time{}),
+				}},
+			},
+			expectedCode: codes.OK,
+		},
+		"should return planets list when name is tatooine": {
+			mocking: func(planetService *mock.MockPlanetService) {
+				planetService.EXPECT().FindPlanetsAndTotal(gomock.Any(), 2, 5, true, gomock.Any()).
+					Return(dto.FindPlanetsAndTotalResult{}, nil)
+			},
+			inputRequest: &pb.ListPlanetsRequest{Page: 2, Size: 5, Name: "tatooine", LoadFilms: true},
+			expectedRes: &pb.ListPlanetsResponse{
+				Pagination: &pb.Pagination{},
+				Data:       []*pb.Planet{},
+			},
+			expectedCode: codes.OK,
+		},
+		"should throw internal error": {
+			mocking: func(planetService *mock.MockPlanetService) {
+				planetService.EXPECT().FindPlanetsAndTotal(gomock.Any(), 1, 10, false).
+					Return(dto.FindPlanetsAndTotalResult{}, fmt.Errorf("error"))
+			},
+			inputRequest: &pb.ListPlanetsRequest{},
+			expectedCode: codes.Internal,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPlanetService := mock.NewMockPlanetService(ctrl)
+			planetServer := &rpc.IPlanetServer{PlanetService: mockPlanetService}
+
+			cs.mocking(mockPlanetService)
+
+			// when
+			res, err := planetServer.ListPlanets(context.Background(), cs.inputRequest)
+
+			// then
+			assert.Equal(t, cs.expectedRes, res)
+			assert.Equal(t, cs.expectedCode, status.Code(err))
+		})
+	}
+}
+
+func Test_PlanetServer_GetPlanet(t *testing.T) {
+	var cases = map[string]struct {
+		mocking      func(planetService *mock.MockPlanetService)
+		inputRequest *pb.GetPlanetRequest
+		expectedRes  *pb.Planet
+		expectedErr  error
+	}{
+		"should return planet with films": {
+			mocking: func(planetService *mock.MockPlanetService) {
+				planet := &model.Planet{ID: 1, Name: "Tatooine"}
+				planet.R = planet.R.NewStruct()
+				planet.R.Films = model.FilmSlice{{ID: 1, Title: "A New Hope"}}
+				planetService.EXPECT().FindPlanetByID(gomock.Any(), 1, true).Return(planet, nil)
+			},
+			inputRequest: &pb.GetPlanetRequest{Id: 1, LoadFilms: true},
+			expectedRes: &pb.Planet{
+				Id:        1,
+				CreatedAt: timestamppb.New(time.Time{}),
+				UpdatedAt: timestamppb.New(time.Time{}),
+				Name:      "Tatooine",
+				Films: []*pb.Film{{
+					Id:          1,
+					CreatedAt:   timestamppb.New(time.Time{}),
+					UpdatedAt:   timestamppb.New(time.Time{}),
+					Title:       "A New Hope",
+					ReleaseDate: "0001-01-01",
+				}},
+			},
+		},
+		"should throw invalid argument when id is invalid": {
+			mocking:      func(planetService *mock.MockPlanetService) {},
+			inputRequest: &pb.GetPlanetRequest{},
+			expectedErr:  status.Error(codes.InvalidArgument, "invalid planet id"),
+		},
+		"should throw not found": {
+			mocking: func(planetService *mock.MockPlanetService) {
+				planetService.EXPECT().FindPlanetByID(gomock.Any(), 1, false).
+					Return(nil, &exception.NotFoundException{Message: "planet 1 not found"})
+			},
+			inputRequest: &pb.GetPlanetRequest{Id: 1},
+			expectedErr:  status.Error(codes.NotFound, "planet 1 not found"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPlanetService := mock.NewMockPlanetService(ctrl)
+			planetServer := &rpc.IPlanetServer{PlanetService: mockPlanetService}
+
+			cs.mocking(mockPlanetService)
+
+			// when
+			res, err := planetServer.GetPlanet(context.Background(), cs.inputRequest)
+
+			// then
+			assert.Equal(t, cs.expectedRes, res)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func Test_PlanetServer_DeletePlanet(t *testing.T) {
+	var cases = map[string]struct {
+		mocking      func(planetService *mock.MockPlanetService)
+		inputRequest *pb.DeletePlanetRequest
+		expectedCode codes.Code
+	}{
+		"should delete planet": {
+			mocking: func(planetService *mock.MockPlanetService) {
+				planetService.EXPECT().DeletePlanet(gomock.Any(), 1).Return(nil)
+			},
+			inputRequest: &pb.DeletePlanetRequest{Id: 1},
+			expectedCode: codes.OK,
+		},
+		"should throw invalid argument when id is invalid": {
+			mocking:      func(planetService *mock.MockPlanetService) {},
+			inputRequest: &pb.DeletePlanetRequest{},
+			expectedCode: codes.InvalidArgument,
+		},
+		"should throw failed precondition when conflict": {
+			mocking: func(planetService *mock.MockPlanetService) {
+				planetService.EXPECT().DeletePlanet(gomock.Any(), 1).
+					Return(&exception.ConflictException{Message: "planet 1 is deleted"})
+			},
+			inputRequest: &pb.DeletePlanetRequest{Id: 1},
+			expectedCode: codes.FailedPrecondition,
+		},
+		"should throw internal error": {
+			mocking: func(planetService *mock.MockPlanetService) {
+				planetService.EXPECT().DeletePlanet(gomock.Any(), 1).Return(fmt.Errorf("error"))
+			},
+			inputRequest: &pb.DeletePlanetRequest{Id: 1},
+			expectedCode: codes.Internal,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPlanetService := mock.NewMockPlanetService(ctrl)
+			planetServer := &rpc.IPlanetServer{PlanetService: mockPlanetService}
+
+			cs.mocking(mockPlanetService)
+
+			// when
+			_, err := planetServer.DeletePlanet(context.Background(), cs.inputRequest)
+
+			// then
+			assert.Equal(t, cs.expectedCode, status.Code(err))
+		})
+	}
+}
+
+func Test_PlanetServer_ListPlanetFilms(t *testing.T) {
+	var cases = map[string]struct {
+		mocking      func(planetService *mock.MockPlanetService, filmService *mock.MockFilmService)
+		inputRequest *pb.ListPlanetFilmsRequest
+		expectedLen  int
+		expectedCode codes.Code
+	}{
+		"should return planet films": {
+			mocking: func(planetService *mock.MockPlanetService, filmService *mock.MockFilmService) {
+				planetService.EXPECT().FindPlanetByID(gomock.Any(), 1, false).Return(&model.Planet{ID: 1}, nil)
+				filmService.EXPECT().FindFilmsByPlanetIDs(gomock.Any(), []int{1}).
+					Return(map[int][]*model.Film{1: {{ID: 1}, {ID: 2}}}, nil)
+			},
+			inputRequest: &pb.ListPlanetFilmsRequest{PlanetId: 1},
+			expectedLen:  2,
+			expectedCode: codes.OK,
+		},
+		"should throw not found when planet does not exist": {
+			mocking: func(planetService *mock.MockPlanetService, filmService *mock.MockFilmService) {
+				planetService.EXPECT().FindPlanetByID(gomock.Any(), 1, false).
+					Return(nil, &exception.NotFoundException{Message: "planet 1 not found"})
+			},
+			inputRequest: &pb.ListPlanetFilmsRequest{PlanetId: 1},
+			expectedCode: codes.NotFound,
+		},
+		"should throw internal error when find films": {
+			mocking: func(planetService *mock.MockPlanetService, filmService *mock.MockFilmService) {
+				planetService.EXPECT().FindPlanetByID(gomock.Any(), 1, false).Return(&model.Planet{ID: 1}, nil)
+				filmService.EXPECT().FindFilmsByPlanetIDs(gomock.Any(), []int{1}).Return(nil, fmt.Errorf("error"))
+			},
+			inputRequest: &pb.ListPlanetFilmsRequest{PlanetId: 1},
+			expectedCode: codes.Internal,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPlanetService := mock.NewMockPlanetService(ctrl)
+			mockFilmService := mock.NewMockFilmService(ctrl)
+			planetServer := &rpc.IPlanetServer{PlanetService: mockPlanetService, FilmService: mockFilmService}
+
+			cs.mocking(mockPlanetService, mockFilmService)
+
+			// when
+			res, err := planetServer.ListPlanetFilms(context.Background(), cs.inputRequest)
+
+			// then
+			assert.Equal(t, cs.expectedCode, status.Code(err))
+			assert.Equal(t, cs.expectedLen, len(res.GetData()))
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CRON_MAX_LOOKAHEAD bounds the search for the next run, so schedules that
+// never match, such as February 30, do not loop forever
+const CRON_MAX_LOOKAHEAD = 5 * 366 * 24 * time.Hour
+
+type cronField struct {
+	name string
+	min  int
+	max  int
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12},
+	{name: "day of week", min: 0, max: 6},
+}
+
+// Schedule is a parsed five field cron expression (minute, hour, day of
+// month, month and day of week)
+type Schedule struct {
+	expr   string
+	fields [5]uint64
+	// restricted day of month and day of week match when either one does,
+	// like in the standard cron
+	anyDom bool
+	anyDow bool
+}
+
+// ParseCron parses a cron expression whose fields accept *, values, ranges
+// (1-5), lists (1,3) and steps (*/15 or 0-30/10). Sunday is 0 or 7
+func ParseCron(expr string) (*Schedule, error) {
+	parts := strings.Fields(expr)
+	if len(parts) != len(cronFields) {
+		return nil, fmt.Errorf("invalid cron %s: expected %d fields, got %d", expr, len(cronFields), len(parts))
+	}
+
+	s := &Schedule{expr: strings.Join(parts, " ")}
+	for i, field := range cronFields {
+		max := field.max
+		if i == 4 {
+			max = 7
+		}
+
+		bits, err := parseCronField(parts[i], field.min, max)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron %s: %s %s", expr, field.name, err)
+		}
+		s.fields[i] = bits
+	}
+
+	if s.fields[4]&(1<<7) != 0 {
+		s.fields[4] = s.fields[4]&^(1<<7) | 1
+	}
+	s.anyDom = strings.HasPrefix(parts[2], "*")
+	s.anyDow = strings.HasPrefix(parts[4], "*")
+
+	return s, nil
+}
+
+func parseCronField(raw string, min, max int) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(raw, ",") {
+		rng, step := item, 1
+		if i := strings.Index(item, "/"); i >= 0 {
+			n, err := strconv.Atoi(item[i+1:])
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("has invalid step %s", item)
+			}
+			rng, step = item[:i], n
+		}
+
+		from, to := min, max
+		if rng != "*" {
+			bounds := strings.SplitN(rng, "-", 2)
+			n, err := strconv.Atoi(bounds[0])
+			if err != nil {
+				return 0, fmt.Errorf("has invalid value %s", item)
+			}
+			from, to = n, n
+			if len(bounds) == 2 {
+				if to, err = strconv.Atoi(bounds[1]); err != nil {
+					return 0, fmt.Errorf("has invalid value %s", item)
+				}
+			} else if step > 1 {
+				to = max
+			}
+		}
+		if from < min || to > max || from > to {
+			return 0, fmt.Errorf("is out of range %s", item)
+		}
+
+		for v := from; v <= to; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+
+	return bits, nil
+}
+
+func (impl *Schedule) String() string {
+	return impl.expr
+}
+
+// Next returns the first minute after t matching the schedule, in the
+// location of t, or the zero time when there is none
+func (impl *Schedule) Next(t time.Time) time.Time {
+	next := t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(CRON_MAX_LOOKAHEAD)
+
+	for next.Before(limit) {
+		if !impl.has(3, int(next.Month())) {
+			next = time.Date(next.Year(), next.Month()+1, 1, 0, 0, 0, 0, next.Location())
+			continue
+		}
+		if !impl.matchDay(next) {
+			next = time.Date(next.Year(), next.Month(), next.Day()+1, 0, 0, 0, 0, next.Location())
+			continue
+		}
+		if !impl.has(1, next.Hour()) {
+			next = time.Date(next.Year(), next.Month(), next.Day(), next.Hour()+1, 0, 0, 0, next.Location())
+			continue
+		}
+		if !impl.has(0, next.Minute()) {
+			next = next.Add(time.Minute)
+			continue
+		}
+
+		return next
+	}
+
+	return time.Time{}
+}
+
+func (impl *Schedule) has(field, value int) bool {
+	return impl.fields[field]&(1<<uint(value)) != 0
+}
+
+func (impl *Schedule) matchDay(t time.Time) bool {
+	dom := impl.has(2, t.Day())
+	dow := impl.has(4, int(t.Weekday()))
+	if impl.anyDom || impl.anyDow {
+		return dom && dow
+	}
+
+	return dom || dow
+}
@@ -0,0 +1,188 @@
+package scheduler_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/scheduler"
+)
+
+func Test_Cron_Next(t *testing.T) {
+	// 2022-10-01 is a saturday
+	now := time.Date(2022, 10, 1, 12, 30, 15, 0, time.UTC)
+
+	var cases = map[string]struct {
+		inputExpr    string
+		inputNow     time.Time
+		expectedNext time.Time
+	}{
+		"should run every minute": {
+			inputExpr:    "* * * * *",
+			expectedNext: time.Date(2022, 10, 1, 12, 31, 0, 0, time.UTC),
+		},
+		"should run every fifteen minutes": {
+			inputExpr:    "*/15 * * * *",
+			expectedNext: time.Date(2022, 10, 1, 12, 45, 0, 0, time.UTC),
+		},
+		"should run daily on the next day": {
+			inputExpr:    "0 3 * * *",
+			expectedNext: time.Date(2022, 10, 2, 3, 0, 0, 0, time.UTC),
+		},
+		"should run on lists and ranges": {
+			inputExpr:    "5,10 9-17/4 * * *",
+			expectedNext: time.Date(2022, 10, 1, 13, 5, 0, 0, time.UTC),
+		},
+		"should run on week days": {
+			inputExpr:    "0 0 * * 1-5",
+			expectedNext: time.Date(2022, 10, 3, 0, 0, 0, 0, time.UTC),
+		},
+		"should run on sunday written as seven": {
+			inputExpr:    "0 0 * * 7",
+			expectedNext: time.Date(2022, 10, 2, 0, 0, 0, 0, time.UTC),
+		},
+		"should run on the first of the next month": {
+			inputExpr:    "0 0 1 * *",
+			expectedNext: time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"should run when either day of month or day of week matches": {
+			inputExpr:    "0 0 15 * 1",
+			expectedNext: time.Date(2022, 10, 3, 0, 0, 0, 0, time.UTC),
+		},
+		"should run on the next year": {
+			inputExpr:    "0 0 1 1 *",
+			expectedNext: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"should run on the day of month when day of week is any": {
+			inputExpr:    "0 0 13 * *",
+			expectedNext: time.Date(2022, 10, 13, 0, 0, 0, 0, time.UTC),
+		},
+		"should run when day of month matches before day of week": {
+			inputExpr:    "0 0 2 * 3",
+			expectedNext: time.Date(2022, 10, 2, 0, 0, 0, 0, time.UTC),
+		},
+		"should run when both match when day of month is a step of any": {
+			inputExpr:    "0 0 */2 * 2",
+			expectedNext: time.Date(2022, 10, 11, 0, 0, 0, 0, time.UTC),
+		},
+		"should run when both match when day of week is a step of any": {
+			inputExpr:    "0 0 10-20 * */3",
+			expectedNext: time.Date(2022, 10, 12, 0, 0, 0, 0, time.UTC),
+		},
+		"should run on a range of week days ending on sunday as seven": {
+			inputExpr:    "0 0 * * 5-7",
+			expectedNext: time.Date(2022, 10, 2, 0, 0, 0, 0, time.UTC),
+		},
+		"should run on the next day when the time was reached exactly": {
+			inputExpr:    "30 12 * * *",
+			inputNow:     time.Date(2022, 10, 1, 12, 30, 0, 0, time.UTC),
+			expectedNext: time.Date(2022, 10, 2, 12, 30, 0, 0, time.UTC),
+		},
+		"should roll over to the next hour": {
+			inputExpr:    "* * * * *",
+			inputNow:     time.Date(2022, 10, 1, 12, 59, 30, 0, time.UTC),
+			expectedNext: time.Date(2022, 10, 1, 13, 0, 0, 0, time.UTC),
+		},
+		"should roll over to the next day": {
+			inputExpr:    "*/15 * * * *",
+			inputNow:     time.Date(2022, 10, 1, 23, 59, 0, 0, time.UTC),
+			expectedNext: time.Date(2022, 10, 2, 0, 0, 0, 0, time.UTC),
+		},
+		"should roll over to the next month": {
+			inputExpr:    "0 0 * * *",
+			inputNow:     time.Date(2022, 10, 31, 23, 59, 0, 0, time.UTC),
+			expectedNext: time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"should roll over to the next year": {
+			inputExpr:    "* * * * *",
+			inputNow:     time.Date(2022, 12, 31, 23, 59, 59, 0, time.UTC),
+			expectedNext: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"should skip the months without the day of month": {
+			inputExpr:    "0 0 31 * *",
+			inputNow:     time.Date(2022, 10, 31, 12, 0, 0, 0, time.UTC),
+			expectedNext: time.Date(2022, 12, 31, 0, 0, 0, 0, time.UTC),
+		},
+		"should run on the next leap day": {
+			inputExpr:    "0 0 29 2 *",
+			expectedNext: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		"should run on the leap day or on mondays of february": {
+			inputExpr:    "0 0 29 2 1",
+			inputNow:     time.Date(2023, 2, 28, 12, 0, 0, 0, time.UTC),
+			expectedNext: time.Date(2024, 2, 5, 0, 0, 0, 0, time.UTC),
+		},
+		"should keep the location of the time": {
+			inputExpr:    "0 3 * * *",
+			inputNow:     time.Date(2022, 10, 1, 12, 30, 0, 0, time.FixedZone("UTC-3", -3*60*60)),
+			expectedNext: time.Date(2022, 10, 2, 3, 0, 0, 0, time.FixedZone("UTC-3", -3*60*60)),
+		},
+		"should not run when the date does not exist": {
+			inputExpr: "0 0 30 2 *",
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			schedule, err := scheduler.ParseCron(cs.inputExpr)
+			assert.Nil(t, err)
+
+			inputNow := now
+			if !cs.inputNow.IsZero() {
+				inputNow = cs.inputNow
+			}
+
+			// when
+			next := schedule.Next(inputNow)
+
+			// then
+			assert.Equal(t, cs.expectedNext, next)
+		})
+	}
+}
+
+func Test_Cron_ParseCron(t *testing.T) {
+	var cases = map[string]struct {
+		inputExpr        string
+		expectedErrorMsg string
+	}{
+		"should parse expression": {
+			inputExpr: " 0  3 * * 1-5 ",
+		},
+		"should throw error when fields are missing": {
+			inputExpr:        "0 3 * *",
+			expectedErrorMsg: "invalid cron 0 3 * *: expected 5 fields, got 4",
+		},
+		"should throw error when value is not a number": {
+			inputExpr:        "a 3 * * *",
+			expectedErrorMsg: "invalid cron a 3 * * *: minute has invalid value a",
+		},
+		"should throw error when value is out of range": {
+			inputExpr:        "0 24 * * *",
+			expectedErrorMsg: "invalid cron 0 24 * * *: hour is out of range 24",
+		},
+		"should throw error when range is reversed": {
+			inputExpr:        "0 3 10-5 * *",
+			expectedErrorMsg: "invalid cron 0 3 10-5 * *: day of month is out of range 10-5",
+		},
+		"should throw error when step is invalid": {
+			inputExpr:        "*/0 * * * *",
+			expectedErrorMsg: "invalid cron */0 * * * *: minute has invalid step */0",
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// when
+			schedule, err := scheduler.ParseCron(cs.inputExpr)
+
+			// then
+			if cs.expectedErrorMsg != "" {
+				assert.Nil(t, schedule)
+				assert.EqualError(t, err, cs.expectedErrorMsg)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, "0 3 * * 1-5", schedule.String())
+		})
+	}
+}
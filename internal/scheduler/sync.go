@@ -0,0 +1,187 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/viniosilva/starwars-api/internal/config"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/service"
+)
+
+const (
+	SYNC_TRIGGER_SCHEDULE = "schedule"
+	SYNC_TRIGGER_MANUAL   = "manual"
+	SYNC_STATUS_SUCCEEDED = "succeeded"
+	SYNC_STATUS_FAILED    = "failed"
+	SYNC_STATUS_SKIPPED   = "skipped"
+	SYNC_DEFAULT_LOCK     = "starwars.sync"
+)
+
+//go:generate mockgen -destination=../../mock/scheduler_runner_mock.go -package=mock . Runner
+type Runner interface {
+	Execute(ctx context.Context) error
+}
+
+//go:generate mockgen -destination=../../mock/scheduler_sync_job_mock.go -package=mock . SyncJob
+type SyncJob interface {
+	Status() dto.SyncStatus
+	Trigger() bool
+}
+
+type ISyncJob struct {
+	Runner      Runner
+	LockService service.LockService
+	LockName    string
+	Schedule    *Schedule
+	mutex       sync.Mutex
+	running     bool
+	nextRun     time.Time
+	lastRun     *dto.SyncRun
+}
+
+// NewSyncJob builds the job running the feed, scheduled by the cron
+// expression of c when it is enabled
+func NewSyncJob(runner Runner, lockService service.LockService, c config.SyncConfig) (*ISyncJob, error) {
+	job := &ISyncJob{
+		Runner:      runner,
+		LockService: lockService,
+		LockName:    SyncLockName(c),
+	}
+
+	if c.Enabled {
+		schedule, err := ParseCron(c.Cron)
+		if err != nil {
+			return nil, err
+		}
+		job.Schedule = schedule
+	}
+
+	return job, nil
+}
+
+// SyncLockName returns the named lock taken by every run of the feed, so a
+// sync and a feed started by hand never run at the same time
+func SyncLockName(c config.SyncConfig) string {
+	if c.LockName == "" {
+		return SYNC_DEFAULT_LOCK
+	}
+
+	return c.LockName
+}
+
+// Run runs the job at each time of the schedule until ctx is done. Times
+// reached while a run is in progress are skipped
+func (impl *ISyncJob) Run(ctx context.Context) {
+	if impl.Schedule == nil {
+		return
+	}
+
+	for {
+		next := impl.Schedule.Next(time.Now())
+		if next.IsZero() {
+			logrus.WithFields(logrus.Fields{"trace": "internal.scheduler.sync.run:schedule.next"}).Warnf("schedule %s never runs", impl.Schedule)
+			return
+		}
+		impl.mutex.Lock()
+		impl.nextRun = next
+		impl.mutex.Unlock()
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			impl.RunOnce(ctx, SYNC_TRIGGER_SCHEDULE)
+		}
+	}
+}
+
+// Trigger starts a run in background, returning false when one is already
+// in progress in this instance. The run is not bound to the request that
+// triggered it, which ends as soon as Trigger returns
+func (impl *ISyncJob) Trigger() bool {
+	if !impl.begin() {
+		return false
+	}
+
+	go impl.run(context.Background(), SYNC_TRIGGER_MANUAL)
+	return true
+}
+
+// RunOnce runs the job and waits for it, returning false when a run is
+// already in progress in this instance. Cancelling ctx cancels the run
+func (impl *ISyncJob) RunOnce(ctx context.Context, trigger string) bool {
+	if !impl.begin() {
+		return false
+	}
+
+	impl.run(ctx, trigger)
+	return true
+}
+
+func (impl *ISyncJob) Status() dto.SyncStatus {
+	impl.mutex.Lock()
+	defer impl.mutex.Unlock()
+
+	res := dto.SyncStatus{
+		Enabled: impl.Schedule != nil,
+		Running: impl.running,
+	}
+	if impl.Schedule != nil {
+		res.Schedule = impl.Schedule.String()
+	}
+	if !impl.nextRun.IsZero() {
+		res.NextRunAt = impl.nextRun.UTC().Format(time.RFC3339)
+	}
+	if impl.lastRun != nil {
+		lastRun := *impl.lastRun
+		res.LastRun = &lastRun
+	}
+
+	return res
+}
+
+func (impl *ISyncJob) begin() bool {
+	impl.mutex.Lock()
+	defer impl.mutex.Unlock()
+
+	if impl.running {
+		return false
+	}
+	impl.running = true
+
+	return true
+}
+
+// run executes the feed holding the distributed lock, so that a single
+// instance runs it at a time
+func (impl *ISyncJob) run(ctx context.Context, trigger string) {
+	run := dto.SyncRun{Trigger: trigger, StartedAt: time.Now().UTC().Format(time.RFC3339)}
+	logrus.WithFields(logrus.Fields{"trace": "internal.scheduler.sync.run", "trigger": trigger}).Info("starting")
+
+	acquired, err := impl.LockService.WithLock(ctx, impl.LockName, func(ctx context.Context) error {
+		return impl.Runner.Execute(ctx)
+	})
+	switch {
+	case err != nil:
+		logrus.WithFields(logrus.Fields{"trace": "internal.scheduler.sync.run:runner.execute"}).Error(err)
+		run.Status = SYNC_STATUS_FAILED
+		run.Error = err.Error()
+	case !acquired:
+		logrus.WithFields(logrus.Fields{"trace": "internal.scheduler.sync.run:lock_service.with_lock"}).Info("sync is running in another instance")
+		run.Status = SYNC_STATUS_SKIPPED
+		run.Error = "sync is running in another instance"
+	default:
+		run.Status = SYNC_STATUS_SUCCEEDED
+	}
+	run.FinishedAt = time.Now().UTC().Format(time.RFC3339)
+
+	impl.mutex.Lock()
+	defer impl.mutex.Unlock()
+	impl.running = false
+	impl.lastRun = &run
+}
@@ -0,0 +1,170 @@
+package scheduler_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/config"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/scheduler"
+	"github.com/viniosilva/starwars-api/mock"
+)
+
+func Test_SyncJob_NewSyncJob(t *testing.T) {
+	var cases = map[string]struct {
+		inputConfig      config.SyncConfig
+		expectedStatus   dto.SyncStatus
+		expectedLockName string
+		expectedErrorMsg string
+	}{
+		"should build scheduled job": {
+			inputConfig:      config.SyncConfig{Enabled: true, Cron: "0 3 * * *", LockName: "sync"},
+			expectedStatus:   dto.SyncStatus{Enabled: true, Schedule: "0 3 * * *"},
+			expectedLockName: "sync",
+		},
+		"should build manual job with default lock": {
+			inputConfig:      config.SyncConfig{Cron: "invalid"},
+			expectedStatus:   dto.SyncStatus{},
+			expectedLockName: scheduler.SYNC_DEFAULT_LOCK,
+		},
+		"should throw error when cron is invalid": {
+			inputConfig:      config.SyncConfig{Enabled: true, Cron: "invalid"},
+			expectedErrorMsg: "invalid cron invalid: expected 5 fields, got 1",
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// when
+			job, err := scheduler.NewSyncJob(nil, nil, cs.inputConfig)
+
+			// then
+			if cs.expectedErrorMsg != "" {
+				assert.Nil(t, job)
+				assert.EqualError(t, err, cs.expectedErrorMsg)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, cs.expectedStatus, job.Status())
+			assert.Equal(t, cs.expectedLockName, job.LockName)
+		})
+	}
+}
+
+func Test_SyncJob_RunOnce(t *testing.T) {
+	var cases = map[string]struct {
+		mocking           func(ctx context.Context, runner *mock.MockRunner, lockService *mock.MockLockService)
+		expectedStatus    string
+		expectedErrorMsg  string
+		expectedExecution bool
+	}{
+		"should run feed holding the lock": {
+			mocking: func(ctx context.Context, runner *mock.MockRunner, lockService *mock.MockLockService) {
+				lockService.EXPECT().WithLock(ctx, "sync", gomock.Any()).
+					DoAndReturn(func(ctx context.Context, name string, fn func(ctx context.Context) error) (bool, error) {
+						return true, fn(ctx)
+					})
+				runner.EXPECT().Execute(ctx).Return(nil)
+			},
+			expectedStatus: scheduler.SYNC_STATUS_SUCCEEDED,
+		},
+		"should fail when feed fails": {
+			mocking: func(ctx context.Context, runner *mock.MockRunner, lockService *mock.MockLockService) {
+				lockService.EXPECT().WithLock(ctx, "sync", gomock.Any()).
+					DoAndReturn(func(ctx context.Context, name string, fn func(ctx context.Context) error) (bool, error) {
+						return true, fn(ctx)
+					})
+				runner.EXPECT().Execute(ctx).Return(fmt.Errorf("error"))
+			},
+			expectedStatus:   scheduler.SYNC_STATUS_FAILED,
+			expectedErrorMsg: "error",
+		},
+		"should fail when ctx is cancelled during feed": {
+			mocking: func(ctx context.Context, runner *mock.MockRunner, lockService *mock.MockLockService) {
+				lockCtx, cancel := context.WithCancel(ctx)
+				lockService.EXPECT().WithLock(ctx, "sync", gomock.Any()).
+					DoAndReturn(func(ctx context.Context, name string, fn func(ctx context.Context) error) (bool, error) {
+						return true, fn(lockCtx)
+					})
+				runner.EXPECT().Execute(lockCtx).
+					DoAndReturn(func(ctx context.Context) error {
+						cancel()
+						return ctx.Err()
+					})
+			},
+			expectedStatus:   scheduler.SYNC_STATUS_FAILED,
+			expectedErrorMsg: "context canceled",
+		},
+		"should skip when another instance holds the lock": {
+			mocking: func(ctx context.Context, runner *mock.MockRunner, lockService *mock.MockLockService) {
+				lockService.EXPECT().WithLock(ctx, "sync", gomock.Any()).Return(false, nil)
+			},
+			expectedStatus:   scheduler.SYNC_STATUS_SKIPPED,
+			expectedErrorMsg: "sync is running in another instance",
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRunner := mock.NewMockRunner(ctrl)
+			mockLockService := mock.NewMockLockService(ctrl)
+			job := &scheduler.ISyncJob{Runner: mockRunner, LockService: mockLockService, LockName: "sync"}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			cs.mocking(ctx, mockRunner, mockLockService)
+
+			// when
+			ran := job.RunOnce(ctx, scheduler.SYNC_TRIGGER_MANUAL)
+
+			// then
+			status := job.Status()
+			assert.True(t, ran)
+			assert.False(t, status.Running)
+			assert.Equal(t, scheduler.SYNC_TRIGGER_MANUAL, status.LastRun.Trigger)
+			assert.Equal(t, cs.expectedStatus, status.LastRun.Status)
+			assert.Equal(t, cs.expectedErrorMsg, status.LastRun.Error)
+			assert.NotEmpty(t, status.LastRun.StartedAt)
+			assert.NotEmpty(t, status.LastRun.FinishedAt)
+		})
+	}
+}
+
+func Test_SyncJob_Trigger(t *testing.T) {
+	// given
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRunner := mock.NewMockRunner(ctrl)
+	mockLockService := mock.NewMockLockService(ctrl)
+	job := &scheduler.ISyncJob{Runner: mockRunner, LockService: mockLockService, LockName: "sync"}
+
+	release := make(chan struct{})
+	finished := make(chan struct{})
+	mockLockService.EXPECT().WithLock(gomock.Any(), "sync", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, name string, fn func(ctx context.Context) error) (bool, error) {
+			<-release
+			defer close(finished)
+			return true, fn(ctx)
+		})
+	mockRunner.EXPECT().Execute(gomock.Any()).Return(nil)
+
+	// when
+	first := job.Trigger()
+	second := job.Trigger()
+	running := job.Status().Running
+	close(release)
+	<-finished
+
+	// then
+	assert.True(t, first)
+	assert.False(t, second)
+	assert.True(t, running)
+	assert.Eventually(t, func() bool { return job.Status().LastRun != nil }, time.Second, time.Millisecond)
+}
@@ -0,0 +1,91 @@
+package script
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+	"github.com/viniosilva/starwars-api/internal/export"
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/viniosilva/starwars-api/internal/service"
+)
+
+type IExportScript struct {
+	FilmService   service.FilmService
+	PlanetService service.PlanetService
+	Format        export.Format
+	Dir           string
+	Options       []service.Option
+}
+
+const TRACE_EXPORT = "internal.script.export"
+
+func (impl *IExportScript) Execute() error {
+	logrus.WithFields(logrus.Fields{"trace": TRACE_EXPORT}).Info("starting")
+
+	ctx := context.Background()
+
+	if err := os.MkdirAll(impl.Dir, 0755); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": fmt.Sprintf("%s:mkdir_all", TRACE_EXPORT)}).Error(err)
+		return err
+	}
+
+	err := impl.WriteFile("planets", &export.PlanetRecord{}, func(encoder export.Encoder) error {
+		return impl.PlanetService.StreamPlanets(ctx, func(planet *model.Planet) error {
+			return encoder.Encode(export.ParsePlanetRecord(planet))
+		}, impl.Options...)
+	})
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": fmt.Sprintf("%s:write_planets", TRACE_EXPORT)}).Error(err)
+		return err
+	}
+
+	err = impl.WriteFile("films", &export.FilmRecord{}, func(encoder export.Encoder) error {
+		return impl.FilmService.StreamFilms(ctx, func(film *model.Film) error {
+			return encoder.Encode(export.ParseFilmRecord(film))
+		})
+	})
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": fmt.Sprintf("%s:write_films", TRACE_EXPORT)}).Error(err)
+		return err
+	}
+
+	err = impl.WriteFile("planets_films", &export.PlanetFilmRecord{}, func(encoder export.Encoder) error {
+		return impl.PlanetService.StreamPlanetsFilms(ctx, func(planetID, filmID int) error {
+			return encoder.Encode(&export.PlanetFilmRecord{PlanetID: int32(planetID), FilmID: int32(filmID)})
+		}, impl.Options...)
+	})
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": fmt.Sprintf("%s:write_planets_films", TRACE_EXPORT)}).Error(err)
+		return err
+	}
+
+	logrus.WithFields(logrus.Fields{"trace": TRACE_EXPORT}).Info("finished")
+	return nil
+}
+
+// WriteFile creates <Dir>/<name>.<Format> and encodes the records written by fn
+func (impl *IExportScript) WriteFile(name string, prototype export.Record, fn func(encoder export.Encoder) error) error {
+	file, err := os.Create(filepath.Join(impl.Dir, fmt.Sprintf("%s.%s", name, impl.Format)))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	encoder, err := export.NewEncoder(impl.Format, file, prototype)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(encoder); err != nil {
+		return err
+	}
+
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,102 @@
+package script_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/export"
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/viniosilva/starwars-api/internal/script"
+	"github.com/viniosilva/starwars-api/internal/service"
+	"github.com/viniosilva/starwars-api/mock"
+	"github.com/volatiletech/null/v8"
+)
+
+func Test_ExportScript_Execute(t *testing.T) {
+	var cases = map[string]struct {
+		mocking          func(filmService *mock.MockFilmService, planetService *mock.MockPlanetService)
+		inputFormat      export.Format
+		expectedFiles    map[string]string
+		expectedErrorMsg string
+	}{
+		"should export planets, films and planets_films as csv": {
+			mocking: func(filmService *mock.MockFilmService, planetService *mock.MockPlanetService) {
+				planetService.EXPECT().StreamPlanets(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(planet *model.Planet) error, opts ...service.Option) error {
+						return fn(&model.Planet{ID: 1, Name: "Tatooine", Climates: []byte(`["arid"]`), Terrains: []byte(`["desert"]`)})
+					})
+				filmService.EXPECT().StreamFilms(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(film *model.Film) error) error {
+						return fn(&model.Film{ID: 1, Title: "A New Hope", Episode: 4, Director: "George Lucas", Producer: null.StringFrom("Gary Kurtz, Rick McCallum")})
+					})
+				planetService.EXPECT().StreamPlanetsFilms(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(planetID, filmID int) error, opts ...service.Option) error {
+						return fn(1, 1)
+					})
+			},
+			inputFormat: export.FormatCSV,
+			expectedFiles: map[string]string{
+				"planets.csv": "id,created_at,updated_at,name,climates,terrains,rotation_period,orbital_period,diameter,gravity,surface_water,population\n" +
+					"1,0001-01-01 00:00:00,0001-01-01 00:00:00,Tatooine,arid,desert,,,,,,\n",
+				"films.csv": "id,created_at,updated_at,title,episode,director,release_date,producer,opening_crawl\n" +
+					"1,0001-01-01 00:00:00,0001-01-01 00:00:00,A New Hope,4,George Lucas,0001-01-01,\"Gary Kurtz, Rick McCallum\",\n",
+				"planets_films.csv": "planet_id,film_id\n1,1\n",
+			},
+		},
+		"should throw error when stream films": {
+			mocking: func(filmService *mock.MockFilmService, planetService *mock.MockPlanetService) {
+				planetService.EXPECT().StreamPlanets(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				filmService.EXPECT().StreamFilms(gomock.Any(), gomock.Any()).Return(fmt.Errorf("error"))
+			},
+			inputFormat:      export.FormatNDJSON,
+			expectedErrorMsg: "error",
+		},
+		"should throw error when format is invalid": {
+			mocking:          func(filmService *mock.MockFilmService, planetService *mock.MockPlanetService) {},
+			inputFormat:      export.Format("xml"),
+			expectedErrorMsg: "invalid format xml",
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			dir := t.TempDir()
+			mockFilmService := mock.NewMockFilmService(ctrl)
+			mockPlanetService := mock.NewMockPlanetService(ctrl)
+
+			exportScript := &script.IExportScript{
+				FilmService:   mockFilmService,
+				PlanetService: mockPlanetService,
+				Format:        cs.inputFormat,
+				Dir:           dir,
+				Options:       []service.Option{service.OptionWhere("name like ?", "Tatooine")},
+			}
+
+			cs.mocking(mockFilmService, mockPlanetService)
+
+			// when
+			err := exportScript.Execute()
+
+			// then
+			if cs.expectedErrorMsg != "" {
+				assert.EqualError(t, err, cs.expectedErrorMsg)
+				return
+			}
+
+			assert.Nil(t, err)
+			for file, content := range cs.expectedFiles {
+				b, err := os.ReadFile(filepath.Join(dir, file))
+				assert.Nil(t, err)
+				assert.Equal(t, content, string(b))
+			}
+		})
+	}
+}
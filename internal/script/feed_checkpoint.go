@@ -0,0 +1,95 @@
+package script
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/viniosilva/starwars-api/internal/dto"
+)
+
+const (
+	FEED_CHECKPOINT_NAME        = "swapi"
+	FEED_RESOURCE_FILMS         = "films"
+	FEED_RESOURCE_PLANETS       = "planets"
+	FEED_RESOURCE_RELATIONSHIPS = "planets_films"
+	FEED_ON_INVALID_ABORT       = "abort"
+	FEED_ON_INVALID_SKIP        = "skip"
+)
+
+var FeedOnInvalidPolicies = []string{FEED_ON_INVALID_ABORT, FEED_ON_INVALID_SKIP}
+
+func IsFeedOnInvalid(policy string) bool {
+	for _, p := range FeedOnInvalidPolicies {
+		if p == policy {
+			return true
+		}
+	}
+
+	return false
+}
+
+// LoadCheckpoint returns the page the feed starts from: the saved checkpoint
+// when an earlier run was interrupted, or the first page of films. Dry runs
+// always start from the beginning, since the diff compares every page with
+// the database, and report the checkpoint they ignore
+func (impl *IFeedDatabaseScript) LoadCheckpoint(ctx context.Context, report *dto.FeedReport) (dto.FeedCheckpoint, error) {
+	start := dto.FeedCheckpoint{Resource: FEED_RESOURCE_FILMS, Page: 1}
+	if impl.CheckpointService == nil {
+		return start, nil
+	}
+
+	if impl.Restart {
+		if impl.DryRun {
+			return start, nil
+		}
+		if err := impl.ClearCheckpoint(ctx); err != nil {
+			return start, err
+		}
+		return start, nil
+	}
+
+	checkpoint, err := impl.CheckpointService.FindFeedCheckpoint(ctx, FEED_CHECKPOINT_NAME)
+	if err != nil {
+		return start, err
+	}
+	if checkpoint == nil || checkpoint.Page < 1 {
+		return start, nil
+	}
+
+	if impl.DryRun {
+		logrus.WithFields(logrus.Fields{
+			"trace":    fmt.Sprintf("%s:load_checkpoint", TRACE_EXECUTE),
+			"resource": checkpoint.Resource,
+			"page":     checkpoint.Page,
+		}).Info("ignoring checkpoint")
+		report.IgnoredCheckpoint = checkpoint
+
+		return start, nil
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"trace":    fmt.Sprintf("%s:load_checkpoint", TRACE_EXECUTE),
+		"resource": checkpoint.Resource,
+		"page":     checkpoint.Page,
+	}).Info("resuming")
+	report.ResumedFrom = checkpoint
+
+	return *checkpoint, nil
+}
+
+func (impl *IFeedDatabaseScript) SaveCheckpoint(ctx context.Context, checkpoint dto.FeedCheckpoint) error {
+	if impl.CheckpointService == nil {
+		return nil
+	}
+
+	return impl.CheckpointService.SaveFeedCheckpoint(ctx, FEED_CHECKPOINT_NAME, checkpoint)
+}
+
+func (impl *IFeedDatabaseScript) ClearCheckpoint(ctx context.Context) error {
+	if impl.CheckpointService == nil {
+		return nil
+	}
+
+	return impl.CheckpointService.DeleteFeedCheckpoint(ctx, FEED_CHECKPOINT_NAME)
+}
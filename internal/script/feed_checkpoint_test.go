@@ -0,0 +1,224 @@
+package script_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/viniosilva/starwars-api/internal/script"
+	"github.com/viniosilva/starwars-api/internal/service"
+	"github.com/viniosilva/starwars-api/mock"
+)
+
+func Test_FeedDatabaseScript_ExecuteWithCheckpoint(t *testing.T) {
+	climates, _ := json.Marshal([]string{"arid"})
+	terrains, _ := json.Marshal([]string{"desert"})
+
+	swapiFilm := model.SwapiFilm{
+		Url:         "https://swapi.dev/api/films/1/",
+		Created:     "2014-12-10T14:23:31.880000Z",
+		Edited:      "2014-12-20T19:49:45.256000Z",
+		Title:       "A New Hope",
+		EpisodeID:   4,
+		Director:    "George Lucas",
+		ReleaseDate: "1977-05-25",
+	}
+	invalidSwapiFilm := swapiFilm
+	invalidSwapiFilm.Url = "https://swapi.dev/api/films/2/"
+	invalidSwapiFilm.ReleaseDate = "unknown"
+	swapiPlanet := model.SwapiPlanet{
+		Url:     "https://swapi.dev/api/planets/1/",
+		Created: "2014-12-09T13:50:49.641000Z",
+		Edited:  "2014-12-20T20:58:18.411000Z",
+		Name:    "Tatooine",
+		Climate: "arid",
+		Terrain: "desert",
+		Films:   []string{"https://swapi.dev/api/films/1/", "invalid"},
+	}
+	invalidSwapiPlanet := model.SwapiPlanet{Url: "https://swapi.dev/api/planets/2/", Created: "yesterday"}
+	film := model.Film{
+		ID:          1,
+		CreatedAt:   time.Date(2014, 12, 10, 14, 23, 31, 0, time.UTC),
+		UpdatedAt:   time.Date(2014, 12, 20, 19, 49, 45, 0, time.UTC),
+		Title:       "A New Hope",
+		Episode:     4,
+		Director:    "George Lucas",
+		ReleaseDate: time.Date(1977, 5, 25, 0, 0, 0, 0, time.UTC),
+	}
+	planet := model.Planet{
+		ID:        1,
+		CreatedAt: time.Date(2014, 12, 9, 13, 50, 49, 0, time.UTC),
+		UpdatedAt: time.Date(2014, 12, 20, 20, 58, 18, 0, time.UTC),
+		Name:      "Tatooine",
+		Climates:  climates,
+		Terrains:  terrains,
+	}
+	_, errInvalidDate := time.Parse("2006-01-02", "unknown")
+
+	var cases = map[string]struct {
+		mocking           func(swapiRequest *mock.MockSwapiRequest, filmService *mock.MockFilmService, planetService *mock.MockPlanetService, checkpointService *mock.MockFeedCheckpointService)
+		inputOnInvalid    string
+		inputRestart      bool
+		inputDryRun       bool
+		inputReportFormat string
+		expectedReport    string
+		expectedErr       error
+	}{
+		"should save a checkpoint after each page and clear it at the end": {
+			mocking: func(swapiRequest *mock.MockSwapiRequest, filmService *mock.MockFilmService, planetService *mock.MockPlanetService, checkpointService *mock.MockFeedCheckpointService) {
+				checkpointService.EXPECT().FindFeedCheckpoint(gomock.Any(), script.FEED_CHECKPOINT_NAME).Return(nil, nil)
+				gomock.InOrder(
+					swapiRequest.EXPECT().GetFilms(gomock.Any(), 1).Return(&model.SwapiFilmsResponse{Results: []model.SwapiFilm{swapiFilm}}, nil),
+					filmService.EXPECT().CreateFilms(gomock.Any(), []*model.Film{&film}).Return(nil),
+					checkpointService.EXPECT().SaveFeedCheckpoint(gomock.Any(), script.FEED_CHECKPOINT_NAME, dto.FeedCheckpoint{Resource: "planets", Page: 1}).Return(nil),
+					swapiRequest.EXPECT().GetPlanets(gomock.Any(), 1).Return(&model.SwapiPlanetsResponse{
+						SwapiPaginateResponse: model.SwapiPaginateResponse{Next: "https://swapi.dev/api/planets/?page=2"},
+					}, nil),
+					checkpointService.EXPECT().SaveFeedCheckpoint(gomock.Any(), script.FEED_CHECKPOINT_NAME, dto.FeedCheckpoint{Resource: "planets", Page: 2}).Return(nil),
+					swapiRequest.EXPECT().GetPlanets(gomock.Any(), 2).Return(&model.SwapiPlanetsResponse{Results: []model.SwapiPlanet{swapiPlanet}}, nil),
+					planetService.EXPECT().CreatePlanets(gomock.Any(), []*model.Planet{&planet}).Return(nil),
+					planetService.EXPECT().CreateRelationshipFilmsToPlanets(gomock.Any(), map[int][]int{1: {1}}).Return(nil),
+					checkpointService.EXPECT().DeleteFeedCheckpoint(gomock.Any(), script.FEED_CHECKPOINT_NAME).Return(nil),
+				)
+			},
+			inputOnInvalid: script.FEED_ON_INVALID_SKIP,
+			expectedReport: "films: 1\nplanets: 1\nrelationships: 1\nskipped: 1\n" +
+				"  ! planets_films page 2 https://swapi.dev/api/planets/1/: film invalid: strconv.Atoi: parsing \"\": invalid syntax\n",
+		},
+		"should resume from the saved checkpoint": {
+			mocking: func(swapiRequest *mock.MockSwapiRequest, filmService *mock.MockFilmService, planetService *mock.MockPlanetService, checkpointService *mock.MockFeedCheckpointService) {
+				checkpointService.EXPECT().FindFeedCheckpoint(gomock.Any(), script.FEED_CHECKPOINT_NAME).
+					Return(&dto.FeedCheckpoint{Resource: "planets", Page: 2}, nil)
+				swapiRequest.EXPECT().GetPlanets(gomock.Any(), 2).
+					Return(&model.SwapiPlanetsResponse{Results: []model.SwapiPlanet{invalidSwapiPlanet}}, nil)
+				checkpointService.EXPECT().DeleteFeedCheckpoint(gomock.Any(), script.FEED_CHECKPOINT_NAME).Return(nil)
+			},
+			inputOnInvalid:    script.FEED_ON_INVALID_SKIP,
+			inputReportFormat: script.FEED_REPORT_JSON,
+			expectedReport: "{\n" +
+				"  \"resumed_from\": {\n    \"resource\": \"planets\",\n    \"page\": 2\n  },\n" +
+				"  \"films\": 0,\n  \"planets\": 0,\n  \"relationships\": 0,\n" +
+				"  \"skipped\": [\n    {\n      \"resource\": \"planets\",\n      \"page\": 2,\n" +
+				"      \"url\": \"https://swapi.dev/api/planets/2/\",\n" +
+				"      \"reason\": \"parsing time \\\"yesterday\\\" as \\\"2006-01-02 15:04:05\\\": cannot parse \\\"yesterday\\\" as \\\"2006\\\"\"\n" +
+				"    }\n  ]\n}\n",
+		},
+		"should discard the saved checkpoint when restart": {
+			mocking: func(swapiRequest *mock.MockSwapiRequest, filmService *mock.MockFilmService, planetService *mock.MockPlanetService, checkpointService *mock.MockFeedCheckpointService) {
+				gomock.InOrder(
+					checkpointService.EXPECT().DeleteFeedCheckpoint(gomock.Any(), script.FEED_CHECKPOINT_NAME).Return(nil),
+					swapiRequest.EXPECT().GetFilms(gomock.Any(), 1).Return(&model.SwapiFilmsResponse{Results: []model.SwapiFilm{invalidSwapiFilm}}, nil),
+				)
+			},
+			inputRestart: true,
+			expectedErr:  errInvalidDate,
+		},
+		"should report the checkpoint ignored when dry run": {
+			mocking: func(swapiRequest *mock.MockSwapiRequest, filmService *mock.MockFilmService, planetService *mock.MockPlanetService, checkpointService *mock.MockFeedCheckpointService) {
+				checkpointService.EXPECT().FindFeedCheckpoint(gomock.Any(), script.FEED_CHECKPOINT_NAME).
+					Return(&dto.FeedCheckpoint{Resource: "planets", Page: 2}, nil)
+				swapiRequest.EXPECT().GetFilms(gomock.Any(), 1).Return(&model.SwapiFilmsResponse{Results: []model.SwapiFilm{swapiFilm}}, nil)
+				swapiRequest.EXPECT().GetPlanets(gomock.Any(), 1).Return(&model.SwapiPlanetsResponse{}, nil)
+				filmService.EXPECT().StreamFilms(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(film *model.Film) error) error {
+						return fn(&film)
+					})
+				planetService.EXPECT().StreamPlanets(gomock.Any(), gomock.Any(), service.OptionWithDeleted()).Return(nil)
+				planetService.EXPECT().StreamPlanetsFilms(gomock.Any(), gomock.Any(), service.OptionWithDeleted()).Return(nil)
+			},
+			inputDryRun: true,
+			expectedReport: "ignored checkpoint: planets page 2, the dry run compares every page\n" +
+				"films: 0 new, 0 changed, 0 missing, 0 deleted\n" +
+				"planets: 0 new, 0 changed, 0 missing, 0 deleted\n" +
+				"relationships: 0 new, 0 missing\n" +
+				"skipped: 0\n",
+		},
+		"should keep the checkpoint when dry run and restart": {
+			mocking: func(swapiRequest *mock.MockSwapiRequest, filmService *mock.MockFilmService, planetService *mock.MockPlanetService, checkpointService *mock.MockFeedCheckpointService) {
+				swapiRequest.EXPECT().GetFilms(gomock.Any(), 1).Return(nil, fmt.Errorf("error"))
+			},
+			inputDryRun:  true,
+			inputRestart: true,
+			expectedErr:  fmt.Errorf("error"),
+		},
+		"should keep the checkpoint when feed fails": {
+			mocking: func(swapiRequest *mock.MockSwapiRequest, filmService *mock.MockFilmService, planetService *mock.MockPlanetService, checkpointService *mock.MockFeedCheckpointService) {
+				checkpointService.EXPECT().FindFeedCheckpoint(gomock.Any(), script.FEED_CHECKPOINT_NAME).
+					Return(&dto.FeedCheckpoint{Resource: "films", Page: 2}, nil)
+				swapiRequest.EXPECT().GetFilms(gomock.Any(), 2).Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+		"should throw error when on invalid policy is invalid": {
+			mocking: func(swapiRequest *mock.MockSwapiRequest, filmService *mock.MockFilmService, planetService *mock.MockPlanetService, checkpointService *mock.MockFeedCheckpointService) {
+			},
+			inputOnInvalid: "ignore",
+			expectedErr:    fmt.Errorf("invalid on invalid policy ignore"),
+		},
+		"should throw error when find checkpoint": {
+			mocking: func(swapiRequest *mock.MockSwapiRequest, filmService *mock.MockFilmService, planetService *mock.MockPlanetService, checkpointService *mock.MockFeedCheckpointService) {
+				checkpointService.EXPECT().FindFeedCheckpoint(gomock.Any(), script.FEED_CHECKPOINT_NAME).Return(nil, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+		"should throw error when save checkpoint": {
+			mocking: func(swapiRequest *mock.MockSwapiRequest, filmService *mock.MockFilmService, planetService *mock.MockPlanetService, checkpointService *mock.MockFeedCheckpointService) {
+				checkpointService.EXPECT().FindFeedCheckpoint(gomock.Any(), script.FEED_CHECKPOINT_NAME).Return(nil, nil)
+				swapiRequest.EXPECT().GetFilms(gomock.Any(), 1).Return(&model.SwapiFilmsResponse{Results: []model.SwapiFilm{swapiFilm, invalidSwapiFilm}}, nil)
+				filmService.EXPECT().CreateFilms(gomock.Any(), []*model.Film{&film}).Return(nil)
+				checkpointService.EXPECT().SaveFeedCheckpoint(gomock.Any(), script.FEED_CHECKPOINT_NAME, gomock.Any()).Return(fmt.Errorf("error"))
+			},
+			inputOnInvalid: script.FEED_ON_INVALID_SKIP,
+			expectedErr:    fmt.Errorf("error"),
+		},
+		"should throw error when clear checkpoint": {
+			mocking: func(swapiRequest *mock.MockSwapiRequest, filmService *mock.MockFilmService, planetService *mock.MockPlanetService, checkpointService *mock.MockFeedCheckpointService) {
+				checkpointService.EXPECT().FindFeedCheckpoint(gomock.Any(), script.FEED_CHECKPOINT_NAME).
+					Return(&dto.FeedCheckpoint{Resource: "planets", Page: 1}, nil)
+				swapiRequest.EXPECT().GetPlanets(gomock.Any(), 1).Return(&model.SwapiPlanetsResponse{}, nil)
+				checkpointService.EXPECT().DeleteFeedCheckpoint(gomock.Any(), script.FEED_CHECKPOINT_NAME).Return(fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSwapiRequest := mock.NewMockSwapiRequest(ctrl)
+			mockFilmService := mock.NewMockFilmService(ctrl)
+			mockPlanetService := mock.NewMockPlanetService(ctrl)
+			mockCheckpointService := mock.NewMockFeedCheckpointService(ctrl)
+			report := &bytes.Buffer{}
+			feedDatabaseScript := &script.IFeedDatabaseScript{
+				Swapi:             mockSwapiRequest,
+				FilmService:       mockFilmService,
+				PlanetService:     mockPlanetService,
+				CheckpointService: mockCheckpointService,
+				Restart:           cs.inputRestart,
+				DryRun:            cs.inputDryRun,
+				OnInvalid:         cs.inputOnInvalid,
+				ReportFormat:      cs.inputReportFormat,
+				Report:            report,
+			}
+
+			cs.mocking(mockSwapiRequest, mockFilmService, mockPlanetService, mockCheckpointService)
+
+			// when
+			err := feedDatabaseScript.Execute(context.Background())
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedReport, report.String())
+		})
+	}
+}
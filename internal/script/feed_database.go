@@ -4,164 +4,292 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"github.com/viniosilva/starwars-api/internal/dto"
 	"github.com/viniosilva/starwars-api/internal/model"
 	"github.com/viniosilva/starwars-api/internal/request"
 	"github.com/viniosilva/starwars-api/internal/service"
+	"github.com/volatiletech/null/v8"
 )
 
 type IFeedDatabaseScript struct {
 	Swapi         request.SwapiRequest
 	FilmService   service.FilmService
 	PlanetService service.PlanetService
+	// CheckpointService keeps where the feed stopped; without it every run
+	// starts over. Restart discards the saved checkpoint
+	CheckpointService service.FeedCheckpointService
+	Restart           bool
+	OnInvalid         string
+	// Upsert updates the films and planets already in the database with the
+	// SWAPI data instead of keeping them as they are
+	Upsert bool
+	// DryRun writes what the feed would change, in ReportFormat, to Report
+	// instead of writing it to the database. It compares every page, so a
+	// saved checkpoint is ignored and listed in the diff. Otherwise the report
+	// lists what was fed and skipped
+	DryRun       bool
+	ReportFormat string
+	Report       io.Writer
 }
 
 const TRACE_EXECUTE = "internal.script.execute"
 
-func (impl *IFeedDatabaseScript) Execute() error {
-	logrus.WithFields(logrus.Fields{"trace": TRACE_EXECUTE}).Info("starting")
+// Execute feeds the films and then the planets of SWAPI page by page, saving
+// a checkpoint after each page so that an interrupted feed resumes where it
+// stopped. Invalid records abort the feed unless OnInvalid is skip, in which
+// case they are left out and listed in the final report
+func (impl *IFeedDatabaseScript) Execute(ctx context.Context) error {
+	logrus.WithFields(logrus.Fields{"trace": TRACE_EXECUTE, "dry_run": impl.DryRun, "upsert": impl.Upsert, "on_invalid": impl.OnInvalid}).Info("starting")
 
-	ctx := context.Background()
-
-	swapiFilms, err := impl.GetSwapiFilms(ctx)
-	if err != nil {
-		logrus.WithFields(logrus.Fields{"trace": fmt.Sprintf("%s:get_swapi_films", TRACE_EXECUTE)}).Error(err)
+	if impl.ReportFormat != "" && !IsFeedReportFormat(impl.ReportFormat) {
+		err := fmt.Errorf("invalid report format %s", impl.ReportFormat)
+		logrus.WithFields(logrus.Fields{"trace": fmt.Sprintf("%s:is_feed_report_format", TRACE_EXECUTE)}).Error(err)
 
 		return err
 	}
+	if impl.OnInvalid != "" && !IsFeedOnInvalid(impl.OnInvalid) {
+		err := fmt.Errorf("invalid on invalid policy %s", impl.OnInvalid)
+		logrus.WithFields(logrus.Fields{"trace": fmt.Sprintf("%s:is_feed_on_invalid", TRACE_EXECUTE)}).Error(err)
 
-	films := make([]*model.Film, len(swapiFilms))
-	for i := 0; i < len(films); i += 1 {
-		film, err := impl.ParseSwapiFilmToModel(swapiFilms[i])
-		if err != nil {
-			logrus.WithFields(logrus.Fields{
-				"trace":    fmt.Sprintf("%s:parse_swapi_film_to_model", TRACE_EXECUTE),
-				"film_url": swapiFilms[i].Url,
-			}).Error(err)
-
-			return err
-		}
+		return err
+	}
 
-		films[i] = film
+	opts := []service.Option{}
+	if impl.Upsert {
+		opts = append(opts, service.OptionUpsert())
 	}
 
-	swapiPlanets, err := impl.GetSwapiPlanets(ctx)
+	report := &dto.FeedReport{Skipped: []dto.FeedSkippedRecord{}}
+	checkpoint, err := impl.LoadCheckpoint(ctx, report)
 	if err != nil {
-		logrus.WithFields(logrus.Fields{"trace": fmt.Sprintf("%s:get_swapi_planets", TRACE_EXECUTE)}).Error(err)
+		logrus.WithFields(logrus.Fields{"trace": fmt.Sprintf("%s:load_checkpoint", TRACE_EXECUTE)}).Error(err)
 
 		return err
 	}
 
-	planets := make([]*model.Planet, len(swapiPlanets))
-	for i := 0; i < len(planets); i += 1 {
-		planet, err := impl.ParseSwapiPlanetToModel(swapiPlanets[i])
-		if err != nil {
-			logrus.WithFields(logrus.Fields{
-				"trace":      fmt.Sprintf("%s:parse_swapi_planet_to_model", TRACE_EXECUTE),
-				"planet_url": swapiPlanets[i].Url,
-			}).Error(err)
+	films := []*model.Film{}
+	if checkpoint.Resource != FEED_RESOURCE_PLANETS {
+		for page := checkpoint.Page; page != 0; {
+			res, err := impl.Swapi.GetFilms(ctx, page)
+			if err != nil {
+				logrus.WithFields(logrus.Fields{"trace": fmt.Sprintf("%s:get_swapi_films", TRACE_EXECUTE), "page": page}).Error(err)
 
-			return err
-		}
+				return err
+			}
 
-		planets[i] = planet
+			pageFilms, err := impl.ParseSwapiFilms(res.Results, page, report)
+			if err != nil {
+				return err
+			}
+
+			page += 1
+			next := dto.FeedCheckpoint{Resource: FEED_RESOURCE_FILMS, Page: page}
+			if res.Next == "" {
+				page = 0
+				next = dto.FeedCheckpoint{Resource: FEED_RESOURCE_PLANETS, Page: 1}
+			}
+
+			if impl.DryRun {
+				films = append(films, pageFilms...)
+				continue
+			}
+
+			if len(pageFilms) > 0 {
+				if err := impl.FilmService.CreateFilms(ctx, pageFilms, opts...); err != nil {
+					logrus.WithFields(logrus.Fields{"trace": fmt.Sprintf("%s:create_films", TRACE_EXECUTE)}).Error(err)
+
+					return err
+				}
+			}
+			report.Films += len(pageFilms)
+
+			if err := impl.SaveCheckpoint(ctx, next); err != nil {
+				logrus.WithFields(logrus.Fields{"trace": fmt.Sprintf("%s:save_checkpoint", TRACE_EXECUTE)}).Error(err)
+
+				return err
+			}
+		}
+		checkpoint = dto.FeedCheckpoint{Resource: FEED_RESOURCE_PLANETS, Page: 1}
 	}
 
+	planets := []*model.Planet{}
 	relationships := map[int][]int{}
-	for _, p := range swapiPlanets {
-		planetID, err := impl.GetIDFromUrl(p.Url)
+	for page := checkpoint.Page; page != 0; {
+		res, err := impl.Swapi.GetPlanets(ctx, page)
 		if err != nil {
-			logrus.WithFields(logrus.Fields{"trace": fmt.Sprintf("%s:get_id_from_url", TRACE_EXECUTE)}).Error(err)
+			logrus.WithFields(logrus.Fields{"trace": fmt.Sprintf("%s:get_swapi_planets", TRACE_EXECUTE), "page": page}).Error(err)
+
 			return err
 		}
 
-		for _, f := range p.Films {
-			filmID, err := impl.GetIDFromUrl(f)
-			if err != nil {
-				logrus.WithFields(logrus.Fields{"trace": fmt.Sprintf("%s:get_id_from_url", TRACE_EXECUTE)}).Error(err)
+		pagePlanets, pageRelationships, err := impl.ParseSwapiPlanets(res.Results, page, report)
+		if err != nil {
+			return err
+		}
+
+		page += 1
+		if res.Next == "" {
+			page = 0
+		}
+
+		if impl.DryRun {
+			planets = append(planets, pagePlanets...)
+			for planetID, filmIDs := range pageRelationships {
+				relationships[planetID] = append(relationships[planetID], filmIDs...)
+			}
+			continue
+		}
+
+		if len(pagePlanets) > 0 {
+			if err := impl.PlanetService.CreatePlanets(ctx, pagePlanets, opts...); err != nil {
+				logrus.WithFields(logrus.Fields{"trace": fmt.Sprintf("%s:create_planets", TRACE_EXECUTE)}).Error(err)
+
+				return err
+			}
+		}
+		report.Planets += len(pagePlanets)
+
+		if len(pageRelationships) > 0 {
+			if err := impl.PlanetService.CreateRelationshipFilmsToPlanets(ctx, pageRelationships); err != nil {
+				logrus.WithFields(logrus.Fields{"trace": fmt.Sprintf("%s:create_relationship_films_to_planets", TRACE_EXECUTE)}).Error(err)
+
+				return err
+			}
+		}
+		for _, filmIDs := range pageRelationships {
+			report.Relationships += len(filmIDs)
+		}
+
+		if page != 0 {
+			if err := impl.SaveCheckpoint(ctx, dto.FeedCheckpoint{Resource: FEED_RESOURCE_PLANETS, Page: page}); err != nil {
+				logrus.WithFields(logrus.Fields{"trace": fmt.Sprintf("%s:save_checkpoint", TRACE_EXECUTE)}).Error(err)
 
 				return err
 			}
-			relationships[planetID] = append(relationships[planetID], filmID)
 		}
 	}
 
-	err = impl.FilmService.CreateFilms(ctx, films)
-	if err != nil {
-		logrus.WithFields(logrus.Fields{"trace": fmt.Sprintf("%s:create_films", TRACE_EXECUTE)}).Error(err)
+	if impl.DryRun {
+		if err := impl.ReportDiff(ctx, films, planets, relationships, report); err != nil {
+			logrus.WithFields(logrus.Fields{"trace": fmt.Sprintf("%s:report_diff", TRACE_EXECUTE)}).Error(err)
 
-		return err
+			return err
+		}
+
+		logrus.WithFields(logrus.Fields{"trace": TRACE_EXECUTE}).Info("finished")
+		return nil
 	}
 
-	err = impl.PlanetService.CreatePlanets(ctx, planets)
-	if err != nil {
-		logrus.WithFields(logrus.Fields{"trace": fmt.Sprintf("%s:create_planets", TRACE_EXECUTE)}).Error(err)
+	if err := impl.ClearCheckpoint(ctx); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": fmt.Sprintf("%s:clear_checkpoint", TRACE_EXECUTE)}).Error(err)
 
 		return err
 	}
 
-	err = impl.PlanetService.CreateRelationshipFilmsToPlanets(ctx, relationships)
-	if err != nil {
-		logrus.WithFields(logrus.Fields{"trace": fmt.Sprintf("%s:create_planets", TRACE_EXECUTE)}).Error(err)
+	if err := impl.WriteReport(report); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": fmt.Sprintf("%s:write_report", TRACE_EXECUTE)}).Error(err)
 
 		return err
 	}
 
-	logrus.WithFields(logrus.Fields{"trace": TRACE_EXECUTE}).Info("finished")
+	logrus.WithFields(logrus.Fields{
+		"trace":         TRACE_EXECUTE,
+		"films":         report.Films,
+		"planets":       report.Planets,
+		"relationships": report.Relationships,
+		"skipped":       len(report.Skipped),
+	}).Info("finished")
 	return nil
 }
 
-func (impl *IFeedDatabaseScript) GetSwapiFilms(ctx context.Context) ([]model.SwapiFilm, error) {
-	page := 1
-	films := []model.SwapiFilm{}
-	for page != 0 {
-		res, err := impl.Swapi.GetFilms(ctx, page)
+// ParseSwapiFilms parses the films of a SWAPI page, skipping the invalid ones
+// according to OnInvalid
+func (impl *IFeedDatabaseScript) ParseSwapiFilms(swapiFilms []model.SwapiFilm, page int, report *dto.FeedReport) ([]*model.Film, error) {
+	films := []*model.Film{}
+	for _, f := range swapiFilms {
+		film, err := impl.ParseSwapiFilmToModel(f)
 		if err != nil {
-			return nil, err
-		}
-
-		page += 1
-		if res.Next == "" {
-			page = 0
+			record := dto.FeedSkippedRecord{Resource: FEED_RESOURCE_FILMS, Page: page, Url: f.Url}
+			if err := impl.SkipInvalid(report, record, "parse_swapi_film_to_model", err); err != nil {
+				return nil, err
+			}
+			continue
 		}
 
-		films = append(films, res.Results...)
+		films = append(films, film)
 	}
 
 	return films, nil
 }
 
-func (impl *IFeedDatabaseScript) GetSwapiPlanets(ctx context.Context) ([]model.SwapiPlanet, error) {
-	page := 1
-	planets := []model.SwapiPlanet{}
-	for page != 0 {
-		res, err := impl.Swapi.GetPlanets(ctx, page)
+// ParseSwapiPlanets parses the planets of a SWAPI page and their films,
+// skipping the invalid ones according to OnInvalid. A film url that cannot be
+// parsed only skips its relationship
+func (impl *IFeedDatabaseScript) ParseSwapiPlanets(swapiPlanets []model.SwapiPlanet, page int, report *dto.FeedReport) ([]*model.Planet, map[int][]int, error) {
+	planets := []*model.Planet{}
+	relationships := map[int][]int{}
+	for _, p := range swapiPlanets {
+		planet, err := impl.ParseSwapiPlanetToModel(p)
 		if err != nil {
-			return nil, err
+			record := dto.FeedSkippedRecord{Resource: FEED_RESOURCE_PLANETS, Page: page, Url: p.Url}
+			if err := impl.SkipInvalid(report, record, "parse_swapi_planet_to_model", err); err != nil {
+				return nil, nil, err
+			}
+			continue
 		}
+		planets = append(planets, planet)
 
-		page += 1
-		if res.Next == "" {
-			page = 0
+		for _, f := range p.Films {
+			filmID, err := impl.GetIDFromUrl(f)
+			if err != nil {
+				record := dto.FeedSkippedRecord{Resource: FEED_RESOURCE_RELATIONSHIPS, Page: page, Url: p.Url}
+				if err := impl.SkipInvalid(report, record, "get_id_from_url", fmt.Errorf("film %s: %w", f, err)); err != nil {
+					return nil, nil, err
+				}
+				continue
+			}
+			relationships[planet.ID] = append(relationships[planet.ID], filmID)
 		}
+	}
 
-		planets = append(planets, res.Results...)
+	return planets, relationships, nil
+}
 
+// SkipInvalid adds the invalid record to the report when OnInvalid is skip,
+// otherwise it returns err to abort the feed
+func (impl *IFeedDatabaseScript) SkipInvalid(report *dto.FeedReport, record dto.FeedSkippedRecord, step string, err error) error {
+	entry := logrus.WithFields(logrus.Fields{
+		"trace":    fmt.Sprintf("%s:%s", TRACE_EXECUTE, step),
+		"resource": record.Resource,
+		"page":     record.Page,
+		"url":      record.Url,
+	})
+	if impl.OnInvalid != FEED_ON_INVALID_SKIP {
+		entry.Error(err)
+		return err
 	}
 
-	return planets, nil
+	entry.Warn(err)
+	record.Reason = err.Error()
+	report.Skipped = append(report.Skipped, record)
+
+	return nil
 }
 
 func (impl *IFeedDatabaseScript) ParseSwapiFilmToModel(swapiFilm model.SwapiFilm) (*model.Film, error) {
 	var err error
 	film := &model.Film{
-		Title:    swapiFilm.Title,
-		Episode:  int8(swapiFilm.EpisodeID),
-		Director: swapiFilm.Director,
+		Title:        swapiFilm.Title,
+		Episode:      int8(swapiFilm.EpisodeID),
+		Director:     swapiFilm.Director,
+		OpeningCrawl: impl.ParseToNullString(swapiFilm.OpeningCrawl),
+		Producer:     impl.ParseToNullString(swapiFilm.Producer),
 	}
 
 	film.ID, err = impl.GetIDFromUrl(swapiFilm.Url)
@@ -185,9 +313,15 @@ func (impl *IFeedDatabaseScript) ParseSwapiPlanetToModel(swapiPlanet model.Swapi
 	var err error
 
 	planet := &model.Planet{
-		Name:     swapiPlanet.Name,
-		Climates: impl.ParseToStrArrayJSON(swapiPlanet.Climate),
-		Terrains: impl.ParseToStrArrayJSON(swapiPlanet.Terrain),
+		Name:           swapiPlanet.Name,
+		Climates:       impl.ParseToStrArrayJSON(swapiPlanet.Climate),
+		Terrains:       impl.ParseToStrArrayJSON(swapiPlanet.Terrain),
+		RotationPeriod: impl.ParseToNullInt(swapiPlanet.RotationPeriod),
+		OrbitalPeriod:  impl.ParseToNullInt(swapiPlanet.OrbitalPeriod),
+		Diameter:       impl.ParseToNullInt(swapiPlanet.Diameter),
+		Gravity:        impl.ParseToNullString(swapiPlanet.Gravity),
+		SurfaceWater:   impl.ParseToNullFloat64(swapiPlanet.SurfaceWater),
+		Population:     impl.ParseToNullInt64(swapiPlanet.Population),
 	}
 
 	planet.ID, err = impl.GetIDFromUrl(swapiPlanet.Url)
@@ -236,3 +370,52 @@ func (impl *IFeedDatabaseScript) ParseToStrArrayJSON(value string) []byte {
 
 	return b
 }
+
+// ParseToNullString returns NULL for the values SWAPI uses when an attribute
+// is not known, such as "unknown" and "n/a"
+func (impl *IFeedDatabaseScript) ParseToNullString(value string) null.String {
+	value = strings.TrimSpace(value)
+	switch strings.ToLower(value) {
+	case "", "unknown", "n/a", "none":
+		return null.String{}
+	}
+
+	return null.StringFrom(value)
+}
+
+func (impl *IFeedDatabaseScript) ParseToNullInt(value string) null.Int {
+	n := impl.ParseToNullInt64(value)
+	if !n.Valid {
+		return null.Int{}
+	}
+
+	return null.IntFrom(int(n.Int64))
+}
+
+func (impl *IFeedDatabaseScript) ParseToNullInt64(value string) null.Int64 {
+	str := impl.ParseToNullString(strings.ReplaceAll(value, ",", ""))
+	if !str.Valid {
+		return null.Int64{}
+	}
+
+	n, err := strconv.ParseInt(str.String, 10, 64)
+	if err != nil {
+		return null.Int64{}
+	}
+
+	return null.Int64From(n)
+}
+
+func (impl *IFeedDatabaseScript) ParseToNullFloat64(value string) null.Float64 {
+	str := impl.ParseToNullString(strings.ReplaceAll(value, ",", ""))
+	if !str.Valid {
+		return null.Float64{}
+	}
+
+	n, err := strconv.ParseFloat(str.String, 64)
+	if err != nil {
+		return null.Float64{}
+	}
+
+	return null.Float64From(n)
+}
@@ -1,6 +1,7 @@
 package script_test
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -12,7 +13,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/viniosilva/starwars-api/internal/model"
 	"github.com/viniosilva/starwars-api/internal/script"
+	"github.com/viniosilva/starwars-api/internal/service"
 	"github.com/viniosilva/starwars-api/mock"
+	"github.com/volatiletech/null/v8"
 )
 
 func Test_FeedDatabaseScript_Execute(t *testing.T) {
@@ -60,11 +63,17 @@ func Test_FeedDatabaseScript_Execute(t *testing.T) {
 		Climates:  climates,
 		Terrains:  terrains,
 	}
+	invalidFilmPlanet := swapiPlanet
+	invalidFilmPlanet.Films = []string{"invalid"}
 	_, errInvalidUrl := strconv.Atoi("")
 
 	var cases = map[string]struct {
-		mocking     func(swapiRequest *mock.MockSwapiRequest, filmService *mock.MockFilmService, planetService *mock.MockPlanetService)
-		expectedErr error
+		mocking           func(swapiRequest *mock.MockSwapiRequest, filmService *mock.MockFilmService, planetService *mock.MockPlanetService)
+		inputDryRun       bool
+		inputUpsert       bool
+		inputReportFormat string
+		expectedReport    string
+		expectedErr       error
 	}{
 		"should be successful": {
 			mocking: func(swapiRequest *mock.MockSwapiRequest, filmService *mock.MockFilmService, planetService *mock.MockPlanetService) {
@@ -82,6 +91,81 @@ func Test_FeedDatabaseScript_Execute(t *testing.T) {
 				planetService.EXPECT().CreatePlanets(gomock.Any(), []*model.Planet{&planet}).Return(nil)
 				planetService.EXPECT().CreateRelationshipFilmsToPlanets(gomock.Any(), map[int][]int{1: {1, 3, 4, 5, 6}}).Return(nil)
 			},
+			expectedReport: "films: 1\nplanets: 1\nrelationships: 5\nskipped: 0\n",
+		},
+		"should update existing records when upsert": {
+			mocking: func(swapiRequest *mock.MockSwapiRequest, filmService *mock.MockFilmService, planetService *mock.MockPlanetService) {
+				swapiRequest.EXPECT().GetFilms(gomock.Any(), gomock.Any()).
+					Return(&model.SwapiFilmsResponse{
+						SwapiPaginateResponse: model.SwapiPaginateResponse{Count: 1},
+						Results:               []model.SwapiFilm{swapiFilm},
+					}, nil)
+				swapiRequest.EXPECT().GetPlanets(gomock.Any(), gomock.Any()).
+					Return(&model.SwapiPlanetsResponse{
+						SwapiPaginateResponse: model.SwapiPaginateResponse{Count: 1},
+						Results:               []model.SwapiPlanet{swapiPlanet},
+					}, nil)
+				filmService.EXPECT().CreateFilms(gomock.Any(), []*model.Film{&film}, service.OptionUpsert()).Return(nil)
+				planetService.EXPECT().CreatePlanets(gomock.Any(), []*model.Planet{&planet}, service.OptionUpsert()).Return(nil)
+				planetService.EXPECT().CreateRelationshipFilmsToPlanets(gomock.Any(), map[int][]int{1: {1, 3, 4, 5, 6}}).Return(nil)
+			},
+			inputUpsert:    true,
+			expectedReport: "films: 1\nplanets: 1\nrelationships: 5\nskipped: 0\n",
+		},
+		"should report diff without writing when dry run": {
+			mocking: func(swapiRequest *mock.MockSwapiRequest, filmService *mock.MockFilmService, planetService *mock.MockPlanetService) {
+				swapiRequest.EXPECT().GetFilms(gomock.Any(), gomock.Any()).
+					Return(&model.SwapiFilmsResponse{
+						SwapiPaginateResponse: model.SwapiPaginateResponse{Count: 1},
+						Results:               []model.SwapiFilm{swapiFilm},
+					}, nil)
+				swapiRequest.EXPECT().GetPlanets(gomock.Any(), gomock.Any()).
+					Return(&model.SwapiPlanetsResponse{
+						SwapiPaginateResponse: model.SwapiPaginateResponse{Count: 1},
+						Results:               []model.SwapiPlanet{swapiPlanet},
+					}, nil)
+				filmService.EXPECT().StreamFilms(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(film *model.Film) error) error {
+						return fn(&film)
+					})
+				planetService.EXPECT().StreamPlanets(gomock.Any(), gomock.Any(), service.OptionWithDeleted()).Return(nil)
+				planetService.EXPECT().StreamPlanetsFilms(gomock.Any(), gomock.Any(), service.OptionWithDeleted()).Return(nil)
+			},
+			inputDryRun: true,
+			expectedReport: "films: 0 new, 0 changed, 0 missing, 0 deleted\n" +
+				"planets: 1 new, 0 changed, 0 missing, 0 deleted\n" +
+				"  + 1 Tatooine\n" +
+				"relationships: 5 new, 0 missing\n" +
+				"  + planet 1 film 1\n" +
+				"  + planet 1 film 3\n" +
+				"  + planet 1 film 4\n" +
+				"  + planet 1 film 5\n" +
+				"  + planet 1 film 6\n" +
+				"skipped: 0\n",
+		},
+		"should throw error when report format is invalid": {
+			mocking: func(swapiRequest *mock.MockSwapiRequest, filmService *mock.MockFilmService, planetService *mock.MockPlanetService) {
+			},
+			inputDryRun:       true,
+			inputReportFormat: "xml",
+			expectedErr:       fmt.Errorf("invalid report format xml"),
+		},
+		"should throw error when report diff": {
+			mocking: func(swapiRequest *mock.MockSwapiRequest, filmService *mock.MockFilmService, planetService *mock.MockPlanetService) {
+				swapiRequest.EXPECT().GetFilms(gomock.Any(), gomock.Any()).
+					Return(&model.SwapiFilmsResponse{
+						SwapiPaginateResponse: model.SwapiPaginateResponse{Count: 1},
+						Results:               []model.SwapiFilm{swapiFilm},
+					}, nil)
+				swapiRequest.EXPECT().GetPlanets(gomock.Any(), gomock.Any()).
+					Return(&model.SwapiPlanetsResponse{
+						SwapiPaginateResponse: model.SwapiPaginateResponse{Count: 1},
+						Results:               []model.SwapiPlanet{swapiPlanet},
+					}, nil)
+				filmService.EXPECT().StreamFilms(gomock.Any(), gomock.Any()).Return(fmt.Errorf("error"))
+			},
+			inputDryRun: true,
+			expectedErr: fmt.Errorf("error"),
 		},
 		"should throw error when get swapi films": {
 			mocking: func(swapiRequest *mock.MockSwapiRequest, filmService *mock.MockFilmService, planetService *mock.MockPlanetService) {
@@ -96,6 +180,7 @@ func Test_FeedDatabaseScript_Execute(t *testing.T) {
 						SwapiPaginateResponse: model.SwapiPaginateResponse{Count: 1},
 						Results:               []model.SwapiFilm{swapiFilm},
 					}, nil)
+				filmService.EXPECT().CreateFilms(gomock.Any(), []*model.Film{&film}).Return(nil)
 				swapiRequest.EXPECT().GetPlanets(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
 			},
 			expectedErr: fmt.Errorf("error"),
@@ -117,6 +202,7 @@ func Test_FeedDatabaseScript_Execute(t *testing.T) {
 						SwapiPaginateResponse: model.SwapiPaginateResponse{Count: 1},
 						Results:               []model.SwapiFilm{swapiFilm},
 					}, nil)
+				filmService.EXPECT().CreateFilms(gomock.Any(), []*model.Film{&film}).Return(nil)
 				swapiRequest.EXPECT().GetPlanets(gomock.Any(), gomock.Any()).
 					Return(&model.SwapiPlanetsResponse{
 						SwapiPaginateResponse: model.SwapiPaginateResponse{Count: 1},
@@ -132,13 +218,14 @@ func Test_FeedDatabaseScript_Execute(t *testing.T) {
 						SwapiPaginateResponse: model.SwapiPaginateResponse{Count: 1},
 						Results:               []model.SwapiFilm{swapiFilm},
 					}, nil)
+				filmService.EXPECT().CreateFilms(gomock.Any(), []*model.Film{&film}).Return(nil)
 				swapiRequest.EXPECT().GetPlanets(gomock.Any(), gomock.Any()).
 					Return(&model.SwapiPlanetsResponse{
 						SwapiPaginateResponse: model.SwapiPaginateResponse{Count: 1},
-						Results:               []model.SwapiPlanet{{Url: ""}},
+						Results:               []model.SwapiPlanet{invalidFilmPlanet},
 					}, nil)
 			},
-			expectedErr: errInvalidUrl,
+			expectedErr: fmt.Errorf("film invalid: %w", errInvalidUrl),
 		},
 		"should throw error when create films": {
 			mocking: func(swapiRequest *mock.MockSwapiRequest, filmService *mock.MockFilmService, planetService *mock.MockPlanetService) {
@@ -147,11 +234,6 @@ func Test_FeedDatabaseScript_Execute(t *testing.T) {
 						SwapiPaginateResponse: model.SwapiPaginateResponse{Count: 1},
 						Results:               []model.SwapiFilm{swapiFilm},
 					}, nil)
-				swapiRequest.EXPECT().GetPlanets(gomock.Any(), gomock.Any()).
-					Return(&model.SwapiPlanetsResponse{
-						SwapiPaginateResponse: model.SwapiPaginateResponse{Count: 1},
-						Results:               []model.SwapiPlanet{swapiPlanet},
-					}, nil)
 				filmService.EXPECT().CreateFilms(gomock.Any(), []*model.Film{&film}).Return(fmt.Errorf("error"))
 			},
 			expectedErr: fmt.Errorf("error"),
@@ -202,138 +284,28 @@ func Test_FeedDatabaseScript_Execute(t *testing.T) {
 			mockSwapiRequest := mock.NewMockSwapiRequest(ctrl)
 			mockFilmService := mock.NewMockFilmService(ctrl)
 			mockPlanetService := mock.NewMockPlanetService(ctrl)
+			report := &bytes.Buffer{}
 			feedDatabaseScript := &script.IFeedDatabaseScript{
 				Swapi:         mockSwapiRequest,
 				FilmService:   mockFilmService,
 				PlanetService: mockPlanetService,
+				Upsert:        cs.inputUpsert,
+				DryRun:        cs.inputDryRun,
+				ReportFormat:  cs.inputReportFormat,
+				Report:        report,
 			}
 
 			cs.mocking(mockSwapiRequest, mockFilmService, mockPlanetService)
 
 			// when
-			err := feedDatabaseScript.Execute()
-
-			// then
-			assert.Equal(t, cs.expectedErr, err)
-		})
-	}
-
-}
-
-func Test_FeedDatabaseScript_GetSwapiFilms(t *testing.T) {
-	swapiFilm := model.SwapiFilm{
-		Url:         "https://swapi.dev/api/films/1/",
-		Created:     "2014-12-10T14:23:31.880000Z",
-		Edited:      "2014-12-20T19:49:45.256000Z",
-		Title:       "A New Hope",
-		EpisodeID:   4,
-		Director:    "George Lucas",
-		ReleaseDate: "1977-05-25",
-	}
-
-	var cases = map[string]struct {
-		mocking            func(swapiRequest *mock.MockSwapiRequest)
-		expectedSwapiFilms []model.SwapiFilm
-		expectedErr        error
-	}{
-		"should return swapi films": {
-			mocking: func(swapiRequest *mock.MockSwapiRequest) {
-				swapiRequest.EXPECT().GetFilms(gomock.Any(), gomock.Any()).
-					Return(&model.SwapiFilmsResponse{
-						SwapiPaginateResponse: model.SwapiPaginateResponse{Count: 1},
-						Results:               []model.SwapiFilm{swapiFilm},
-					}, nil)
-			},
-			expectedSwapiFilms: []model.SwapiFilm{swapiFilm},
-		},
-		"should throw error when get films": {
-			mocking: func(swapiRequest *mock.MockSwapiRequest) {
-				swapiRequest.EXPECT().GetFilms(gomock.Any(), gomock.Any()).
-					Return(nil, fmt.Errorf("error"))
-			},
-			expectedErr: fmt.Errorf("error"),
-		},
-	}
-	for name, cs := range cases {
-		t.Run(name, func(t *testing.T) {
-			// given
-			ctrl := gomock.NewController(t)
-			defer ctrl.Finish()
-
-			mockSwapiRequest := mock.NewMockSwapiRequest(ctrl)
-			feedDatabaseScript := &script.IFeedDatabaseScript{Swapi: mockSwapiRequest}
-
-			cs.mocking(mockSwapiRequest)
-
-			// when
-			swapiPlanets, err := feedDatabaseScript.GetSwapiFilms(context.Background())
+			err := feedDatabaseScript.Execute(context.Background())
 
 			// then
-			assert.Equal(t, cs.expectedSwapiFilms, swapiPlanets)
 			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedReport, report.String())
 		})
 	}
-}
-
-func Test_FeedDatabaseScript_GetSwapiPlanets(t *testing.T) {
-	swapiPlanet := model.SwapiPlanet{
-		Url:     "https://swapi.dev/api/planets/1/",
-		Created: "2014-12-09T13:50:49.641000Z",
-		Edited:  "2014-12-20T20:58:18.411000Z",
-		Name:    "Tatooine",
-		Climate: "arid",
-		Terrain: "desert",
-		Films: []string{
-			"https://swapi.dev/api/films/1/",
-			"https://swapi.dev/api/films/3/",
-			"https://swapi.dev/api/films/4/",
-			"https://swapi.dev/api/films/5/",
-			"https://swapi.dev/api/films/6/",
-		},
-	}
-
-	var cases = map[string]struct {
-		mocking              func(swapiRequest *mock.MockSwapiRequest)
-		expectedSwapiPlanets []model.SwapiPlanet
-		expectedErr          error
-	}{
-		"should return swapi planets": {
-			mocking: func(swapiRequest *mock.MockSwapiRequest) {
-				swapiRequest.EXPECT().GetPlanets(gomock.Any(), gomock.Any()).
-					Return(&model.SwapiPlanetsResponse{
-						SwapiPaginateResponse: model.SwapiPaginateResponse{Count: 1},
-						Results:               []model.SwapiPlanet{swapiPlanet},
-					}, nil)
-			},
-			expectedSwapiPlanets: []model.SwapiPlanet{swapiPlanet},
-		},
-		"should throw error when get planets": {
-			mocking: func(swapiRequest *mock.MockSwapiRequest) {
-				swapiRequest.EXPECT().GetPlanets(gomock.Any(), gomock.Any()).
-					Return(nil, fmt.Errorf("error"))
-			},
-			expectedErr: fmt.Errorf("error"),
-		},
-	}
-	for name, cs := range cases {
-		t.Run(name, func(t *testing.T) {
-			// given
-			ctrl := gomock.NewController(t)
-			defer ctrl.Finish()
-
-			mockSwapiRequest := mock.NewMockSwapiRequest(ctrl)
-			feedDatabaseScript := &script.IFeedDatabaseScript{Swapi: mockSwapiRequest}
 
-			cs.mocking(mockSwapiRequest)
-
-			// when
-			swapiPlanets, err := feedDatabaseScript.GetSwapiPlanets(context.Background())
-
-			// then
-			assert.Equal(t, cs.expectedSwapiPlanets, swapiPlanets)
-			assert.Equal(t, cs.expectedErr, err)
-		})
-	}
 }
 
 func Test_FeedDatabaseScript_ParseSwapiFilmToModel(t *testing.T) {
@@ -425,12 +397,18 @@ func Test_FeedDatabaseScript_ParseSwapiPlanetToModel(t *testing.T) {
 	}{
 		"should return planet": {
 			inputSwapiPlanet: model.SwapiPlanet{
-				Url:     "https://swapi.dev/api/planets/1/",
-				Created: "2014-12-09T13:50:49.641000Z",
-				Edited:  "2014-12-20T20:58:18.411000Z",
-				Name:    "Tatooine",
-				Climate: "arid",
-				Terrain: "desert",
+				Url:            "https://swapi.dev/api/planets/1/",
+				Created:        "2014-12-09T13:50:49.641000Z",
+				Edited:         "2014-12-20T20:58:18.411000Z",
+				Name:           "Tatooine",
+				Climate:        "arid",
+				Terrain:        "desert",
+				RotationPeriod: "23",
+				OrbitalPeriod:  "304",
+				Diameter:       "10465",
+				Gravity:        "1 standard",
+				SurfaceWater:   "1",
+				Population:     "unknown",
 				Films: []string{
 					"https://swapi.dev/api/films/1/",
 					"https://swapi.dev/api/films/3/",
@@ -440,12 +418,17 @@ func Test_FeedDatabaseScript_ParseSwapiPlanetToModel(t *testing.T) {
 				},
 			},
 			expectedPlanet: &model.Planet{
-				ID:        1,
-				CreatedAt: time.Date(2014, 12, 9, 13, 50, 49, 0, time.UTC),
-				UpdatedAt: time.Date(2014, 12, 20, 20, 58, 18, 0, time.UTC),
-				Name:      "Tatooine",
-				Climates:  climates,
-				Terrains:  terrains,
+				ID:             1,
+				CreatedAt:      time.Date(2014, 12, 9, 13, 50, 49, 0, time.UTC),
+				UpdatedAt:      time.Date(2014, 12, 20, 20, 58, 18, 0, time.UTC),
+				Name:           "Tatooine",
+				Climates:       climates,
+				Terrains:       terrains,
+				RotationPeriod: null.IntFrom(23),
+				OrbitalPeriod:  null.IntFrom(304),
+				Diameter:       null.IntFrom(10465),
+				Gravity:        null.StringFrom("1 standard"),
+				SurfaceWater:   null.Float64From(1),
 			},
 		},
 		"should throw error when url is invalid": {
@@ -584,3 +567,67 @@ func Test_FeedDatabaseScript_ParseToStrArrayJSON(t *testing.T) {
 		})
 	}
 }
+
+func Test_FeedDatabaseScript_ParseToNullInt64(t *testing.T) {
+	var cases = map[string]struct {
+		inputValue    string
+		expectedValue null.Int64
+	}{
+		"should return number": {
+			inputValue:    "200000",
+			expectedValue: null.Int64From(200000),
+		},
+		"should return number when value has thousands separator": {
+			inputValue:    "1,000,000,000,000",
+			expectedValue: null.Int64From(1000000000000),
+		},
+		"should return null when value is unknown": {
+			inputValue:    "unknown",
+			expectedValue: null.Int64{},
+		},
+		"should return null when value is not a number": {
+			inputValue:    "1 standard",
+			expectedValue: null.Int64{},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			feedDatabaseScript := &script.IFeedDatabaseScript{}
+
+			// when
+			value := feedDatabaseScript.ParseToNullInt64(cs.inputValue)
+
+			// then
+			assert.Equal(t, cs.expectedValue, value)
+		})
+	}
+}
+
+func Test_FeedDatabaseScript_ParseToNullFloat64(t *testing.T) {
+	var cases = map[string]struct {
+		inputValue    string
+		expectedValue null.Float64
+	}{
+		"should return number": {
+			inputValue:    "0.9",
+			expectedValue: null.Float64From(0.9),
+		},
+		"should return null when value is n/a": {
+			inputValue:    "N/A",
+			expectedValue: null.Float64{},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			feedDatabaseScript := &script.IFeedDatabaseScript{}
+
+			// when
+			value := feedDatabaseScript.ParseToNullFloat64(cs.inputValue)
+
+			// then
+			assert.Equal(t, cs.expectedValue, value)
+		})
+	}
+}
@@ -0,0 +1,376 @@
+package script
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/viniosilva/starwars-api/internal/service"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+const (
+	FEED_REPORT_TEXT = "text"
+	FEED_REPORT_JSON = "json"
+)
+
+var FeedReportFormats = []string{FEED_REPORT_TEXT, FEED_REPORT_JSON}
+
+func IsFeedReportFormat(format string) bool {
+	for _, f := range FeedReportFormats {
+		if f == format {
+			return true
+		}
+	}
+
+	return false
+}
+
+type feedField struct {
+	name   string
+	before interface{}
+	after  interface{}
+}
+
+// ReportDiff compares the SWAPI data with the database and writes the diff,
+// along with the records skipped as invalid and the checkpoint ignored, to
+// Report
+func (impl *IFeedDatabaseScript) ReportDiff(ctx context.Context, films []*model.Film, planets []*model.Planet, relationships map[int][]int, report *dto.FeedReport) error {
+	diff, err := impl.Diff(ctx, films, planets, relationships)
+	if err != nil {
+		return err
+	}
+	diff.IgnoredCheckpoint = report.IgnoredCheckpoint
+	diff.Skipped = append(diff.Skipped, report.Skipped...)
+
+	logrus.WithFields(logrus.Fields{
+		"trace":                 fmt.Sprintf("%s:report_diff", TRACE_EXECUTE),
+		"new_films":             len(diff.Films.New),
+		"changed_films":         len(diff.Films.Changed),
+		"missing_films":         len(diff.Films.Missing),
+		"new_planets":           len(diff.Planets.New),
+		"changed_planets":       len(diff.Planets.Changed),
+		"missing_planets":       len(diff.Planets.Missing),
+		"deleted_planets":       len(diff.Planets.Deleted),
+		"new_relationships":     len(diff.Relationships.New),
+		"missing_relationships": len(diff.Relationships.Missing),
+		"skipped":               len(diff.Skipped),
+	}).Info("diff")
+
+	if impl.Report == nil {
+		return nil
+	}
+
+	return WriteFeedDiff(impl.Report, impl.ReportFormat, diff)
+}
+
+// WriteReport writes the films, planets and relationships fed and the
+// records skipped as invalid to Report
+func (impl *IFeedDatabaseScript) WriteReport(report *dto.FeedReport) error {
+	if impl.Report == nil {
+		return nil
+	}
+
+	return WriteFeedReport(impl.Report, impl.ReportFormat, report)
+}
+
+// Diff lists what Execute would write: the films, planets and relationships
+// of SWAPI that are not in the database and, with Upsert, the films and
+// planets whose fields differ from it. Deleted planets are never written, so
+// the ones still in SWAPI are listed apart. The films, planets and
+// relationships of the database no longer in SWAPI are listed as missing,
+// although the feed keeps them
+func (impl *IFeedDatabaseScript) Diff(ctx context.Context, films []*model.Film, planets []*model.Planet, relationships map[int][]int) (dto.FeedDiff, error) {
+	diff := dto.FeedDiff{
+		Films:         newFeedEntityDiff(),
+		Planets:       newFeedEntityDiff(),
+		Relationships: dto.FeedRelationshipDiff{New: []dto.FeedRelationship{}, Missing: []dto.FeedRelationship{}},
+		Skipped:       []dto.FeedSkippedRecord{},
+	}
+
+	currentFilms := map[int]*model.Film{}
+	err := impl.FilmService.StreamFilms(ctx, func(film *model.Film) error {
+		currentFilms[film.ID] = film
+		return nil
+	})
+	if err != nil {
+		return dto.FeedDiff{}, err
+	}
+
+	currentPlanets := map[int]*model.Planet{}
+	deletedPlanets := map[int]bool{}
+	err = impl.PlanetService.StreamPlanets(ctx, func(planet *model.Planet) error {
+		currentPlanets[planet.ID] = planet
+		deletedPlanets[planet.ID] = planet.DeletedAt.Valid
+		return nil
+	}, service.OptionWithDeleted())
+	if err != nil {
+		return dto.FeedDiff{}, err
+	}
+
+	// currentRelationships tells, for each relationship of the database,
+	// whether it is also in SWAPI
+	currentRelationships := map[dto.FeedRelationship]bool{}
+	err = impl.PlanetService.StreamPlanetsFilms(ctx, func(planetID, filmID int) error {
+		currentRelationships[dto.FeedRelationship{PlanetID: planetID, FilmID: filmID}] = false
+		return nil
+	}, service.OptionWithDeleted())
+	if err != nil {
+		return dto.FeedDiff{}, err
+	}
+
+	for _, film := range films {
+		entry := dto.FeedDiffEntry{ID: film.ID, Name: film.Title}
+		current, ok := currentFilms[film.ID]
+		if !ok {
+			diff.Films.New = append(diff.Films.New, entry)
+			continue
+		}
+		delete(currentFilms, film.ID)
+
+		if !impl.Upsert {
+			continue
+		}
+		if entry.Changes = DiffFilm(current, film); len(entry.Changes) > 0 {
+			diff.Films.Changed = append(diff.Films.Changed, entry)
+		}
+	}
+	for _, film := range currentFilms {
+		diff.Films.Missing = append(diff.Films.Missing, dto.FeedDiffEntry{ID: film.ID, Name: film.Title})
+	}
+
+	for _, planet := range planets {
+		entry := dto.FeedDiffEntry{ID: planet.ID, Name: planet.Name}
+		current, ok := currentPlanets[planet.ID]
+		if !ok {
+			diff.Planets.New = append(diff.Planets.New, entry)
+			continue
+		}
+		delete(currentPlanets, planet.ID)
+
+		if current.DeletedAt.Valid {
+			diff.Planets.Deleted = append(diff.Planets.Deleted, entry)
+			continue
+		}
+		if !impl.Upsert {
+			continue
+		}
+		if entry.Changes = DiffPlanet(current, planet); len(entry.Changes) > 0 {
+			diff.Planets.Changed = append(diff.Planets.Changed, entry)
+		}
+	}
+	for _, planet := range currentPlanets {
+		if !planet.DeletedAt.Valid {
+			diff.Planets.Missing = append(diff.Planets.Missing, dto.FeedDiffEntry{ID: planet.ID, Name: planet.Name})
+		}
+	}
+
+	for planetID, filmIDs := range relationships {
+		for _, filmID := range filmIDs {
+			relationship := dto.FeedRelationship{PlanetID: planetID, FilmID: filmID}
+			if _, ok := currentRelationships[relationship]; !ok {
+				diff.Relationships.New = append(diff.Relationships.New, relationship)
+			}
+			currentRelationships[relationship] = true
+		}
+	}
+	for relationship, fed := range currentRelationships {
+		if !fed && !deletedPlanets[relationship.PlanetID] {
+			diff.Relationships.Missing = append(diff.Relationships.Missing, relationship)
+		}
+	}
+
+	sortFeedDiffEntries(diff.Films)
+	sortFeedDiffEntries(diff.Planets)
+	sortFeedRelationships(diff.Relationships.New)
+	sortFeedRelationships(diff.Relationships.Missing)
+
+	return diff, nil
+}
+
+func newFeedEntityDiff() dto.FeedEntityDiff {
+	return dto.FeedEntityDiff{
+		New:     []dto.FeedDiffEntry{},
+		Changed: []dto.FeedDiffEntry{},
+		Missing: []dto.FeedDiffEntry{},
+		Deleted: []dto.FeedDiffEntry{},
+	}
+}
+
+// DiffFilm lists the fields of the SWAPI film that differ from the database
+func DiffFilm(before, after *model.Film) []dto.FeedFieldChange {
+	return diffFeedFields([]feedField{
+		{model.FilmColumns.Title, before.Title, after.Title},
+		{model.FilmColumns.Episode, before.Episode, after.Episode},
+		{model.FilmColumns.Director, before.Director, after.Director},
+		{model.FilmColumns.ReleaseDate, before.ReleaseDate.Format("2006-01-02"), after.ReleaseDate.Format("2006-01-02")},
+		{model.FilmColumns.OpeningCrawl, before.OpeningCrawl, after.OpeningCrawl},
+		{model.FilmColumns.Producer, before.Producer, after.Producer},
+	})
+}
+
+// DiffPlanet lists the fields of the SWAPI planet that differ from the
+// database. Climates and terrains are compared as lists, since MySQL does not
+// keep the JSON formatting
+func DiffPlanet(before, after *model.Planet) []dto.FeedFieldChange {
+	return diffFeedFields([]feedField{
+		{model.PlanetColumns.Name, before.Name, after.Name},
+		{model.PlanetColumns.Climates, decodeFeedStrArray(before.Climates), decodeFeedStrArray(after.Climates)},
+		{model.PlanetColumns.Terrains, decodeFeedStrArray(before.Terrains), decodeFeedStrArray(after.Terrains)},
+		{model.PlanetColumns.RotationPeriod, before.RotationPeriod, after.RotationPeriod},
+		{model.PlanetColumns.OrbitalPeriod, before.OrbitalPeriod, after.OrbitalPeriod},
+		{model.PlanetColumns.Diameter, before.Diameter, after.Diameter},
+		{model.PlanetColumns.Gravity, before.Gravity, after.Gravity},
+		{model.PlanetColumns.SurfaceWater, before.SurfaceWater, after.SurfaceWater},
+		{model.PlanetColumns.Population, before.Population, after.Population},
+	})
+}
+
+func diffFeedFields(fields []feedField) []dto.FeedFieldChange {
+	changes := []dto.FeedFieldChange{}
+	for _, f := range fields {
+		if !reflect.DeepEqual(f.before, f.after) {
+			changes = append(changes, dto.FeedFieldChange{Field: f.name, Before: f.before, After: f.after})
+		}
+	}
+
+	return changes
+}
+
+func decodeFeedStrArray(value types.JSON) []string {
+	values := []string{}
+	if err := json.Unmarshal(value, &values); err != nil {
+		return nil
+	}
+
+	return values
+}
+
+func sortFeedDiffEntries(diff dto.FeedEntityDiff) {
+	for _, entries := range [][]dto.FeedDiffEntry{diff.New, diff.Changed, diff.Missing, diff.Deleted} {
+		sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+	}
+}
+
+func sortFeedRelationships(relationships []dto.FeedRelationship) {
+	sort.Slice(relationships, func(i, j int) bool {
+		if relationships[i].PlanetID != relationships[j].PlanetID {
+			return relationships[i].PlanetID < relationships[j].PlanetID
+		}
+		return relationships[i].FilmID < relationships[j].FilmID
+	})
+}
+
+// WriteFeedDiff writes the diff as indented JSON or, by default, as text with
+// one line per film, planet, relationship and changed field
+func WriteFeedDiff(w io.Writer, format string, diff dto.FeedDiff) error {
+	if format == FEED_REPORT_JSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(diff)
+	}
+
+	if diff.IgnoredCheckpoint != nil {
+		if _, err := fmt.Fprintf(w, "ignored checkpoint: %s page %d, the dry run compares every page\n",
+			diff.IgnoredCheckpoint.Resource, diff.IgnoredCheckpoint.Page); err != nil {
+			return err
+		}
+	}
+
+	for _, entity := range []struct {
+		name string
+		diff dto.FeedEntityDiff
+	}{{"films", diff.Films}, {"planets", diff.Planets}} {
+		if _, err := fmt.Fprintf(w, "%s: %d new, %d changed, %d missing, %d deleted\n",
+			entity.name, len(entity.diff.New), len(entity.diff.Changed), len(entity.diff.Missing), len(entity.diff.Deleted)); err != nil {
+			return err
+		}
+		if err := writeFeedDiffEntries(w, "+", entity.diff.New); err != nil {
+			return err
+		}
+		if err := writeFeedDiffEntries(w, "~", entity.diff.Changed); err != nil {
+			return err
+		}
+		if err := writeFeedDiffEntries(w, "-", entity.diff.Missing); err != nil {
+			return err
+		}
+		if err := writeFeedDiffEntries(w, "x", entity.diff.Deleted); err != nil {
+			return err
+		}
+	}
+
+	if _, err := fmt.Fprintf(w, "relationships: %d new, %d missing\n",
+		len(diff.Relationships.New), len(diff.Relationships.Missing)); err != nil {
+		return err
+	}
+	for _, r := range diff.Relationships.New {
+		if _, err := fmt.Fprintf(w, "  + planet %d film %d\n", r.PlanetID, r.FilmID); err != nil {
+			return err
+		}
+	}
+	for _, r := range diff.Relationships.Missing {
+		if _, err := fmt.Fprintf(w, "  - planet %d film %d\n", r.PlanetID, r.FilmID); err != nil {
+			return err
+		}
+	}
+
+	return writeFeedSkipped(w, diff.Skipped)
+}
+
+// WriteFeedReport writes the report as indented JSON or, by default, as text
+// with one line per skipped record
+func WriteFeedReport(w io.Writer, format string, report *dto.FeedReport) error {
+	if format == FEED_REPORT_JSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+
+	if report.ResumedFrom != nil {
+		if _, err := fmt.Fprintf(w, "resumed from: %s page %d\n", report.ResumedFrom.Resource, report.ResumedFrom.Page); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintf(w, "films: %d\nplanets: %d\nrelationships: %d\n", report.Films, report.Planets, report.Relationships); err != nil {
+		return err
+	}
+
+	return writeFeedSkipped(w, report.Skipped)
+}
+
+func writeFeedSkipped(w io.Writer, skipped []dto.FeedSkippedRecord) error {
+	if _, err := fmt.Fprintf(w, "skipped: %d\n", len(skipped)); err != nil {
+		return err
+	}
+	for _, s := range skipped {
+		if _, err := fmt.Fprintf(w, "  ! %s page %d %s: %s\n", s.Resource, s.Page, s.Url, s.Reason); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeFeedDiffEntries(w io.Writer, sign string, entries []dto.FeedDiffEntry) error {
+	for _, entry := range entries {
+		if _, err := fmt.Fprintf(w, "  %s %d %s\n", sign, entry.ID, entry.Name); err != nil {
+			return err
+		}
+
+		for _, change := range entry.Changes {
+			before, _ := json.Marshal(change.Before)
+			after, _ := json.Marshal(change.After)
+			if _, err := fmt.Fprintf(w, "      %s: %s -> %s\n", change.Field, before, after); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
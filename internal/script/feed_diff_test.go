@@ -0,0 +1,506 @@
+package script_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/viniosilva/starwars-api/internal/script"
+	"github.com/viniosilva/starwars-api/internal/service"
+	"github.com/viniosilva/starwars-api/mock"
+	"github.com/volatiletech/null/v8"
+)
+
+func Test_FeedDatabaseScript_Diff(t *testing.T) {
+	arid, _ := json.Marshal([]string{"arid"})
+	desert, _ := json.Marshal([]string{"desert"})
+
+	films := []*model.Film{
+		{ID: 1, Title: "A New Hope", Episode: 4, Director: "George Lucas", ReleaseDate: time.Date(1977, 5, 25, 0, 0, 0, 0, time.UTC)},
+		{ID: 2, Title: "The Empire Strikes Back", Episode: 5, Director: "Irvin Kershner", ReleaseDate: time.Date(1980, 5, 17, 0, 0, 0, 0, time.UTC)},
+	}
+	planets := []*model.Planet{
+		{ID: 1, Name: "Tatooine", Climates: arid, Terrains: desert, Population: null.Int64From(200000)},
+		{ID: 2, Name: "Alderaan", Climates: []byte(`["temperate"]`), Terrains: []byte(`["grasslands"]`)},
+	}
+	relationships := map[int][]int{1: {1, 2}}
+
+	var cases = map[string]struct {
+		mocking          func(filmService *mock.MockFilmService, planetService *mock.MockPlanetService)
+		inputUpsert      bool
+		expectedDiff     dto.FeedDiff
+		expectedErrorMsg string
+	}{
+		"should list new, changed and missing data when upsert": {
+			mocking: func(filmService *mock.MockFilmService, planetService *mock.MockPlanetService) {
+				filmService.EXPECT().StreamFilms(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(film *model.Film) error) error {
+						fn(&model.Film{ID: 1, Title: "A New Hope", Episode: 4, Director: "G. Lucas", ReleaseDate: time.Date(1977, 5, 25, 0, 0, 0, 0, time.UTC)})
+						fn(&model.Film{ID: 7, Title: "The Force Awakens", Episode: 7})
+						return nil
+					})
+				planetService.EXPECT().StreamPlanets(gomock.Any(), gomock.Any(), service.OptionWithDeleted()).
+					DoAndReturn(func(ctx context.Context, fn func(planet *model.Planet) error, opts ...service.Option) error {
+						fn(&model.Planet{ID: 1, Name: "Tatooine", Climates: []byte(`["arid"]`), Terrains: []byte(`["desert"]`), Population: null.Int64From(200000)})
+						fn(&model.Planet{ID: 2, Name: "Alderaan", Climates: []byte(`["temperate", "cold"]`), Terrains: []byte(`["grasslands"]`)})
+						return nil
+					})
+				planetService.EXPECT().StreamPlanetsFilms(gomock.Any(), gomock.Any(), service.OptionWithDeleted()).
+					DoAndReturn(func(ctx context.Context, fn func(planetID, filmID int) error, opts ...service.Option) error {
+						fn(1, 1)
+						fn(2, 7)
+						return nil
+					})
+			},
+			expectedDiff: dto.FeedDiff{
+				Films: dto.FeedEntityDiff{
+					New: []dto.FeedDiffEntry{{ID: 2, Name: "The Empire Strikes Back"}},
+					Changed: []dto.FeedDiffEntry{{ID: 1, Name: "A New Hope", Changes: []dto.FeedFieldChange{
+						{Field: "director", Before: "G. Lucas", After: "George Lucas"},
+					}}},
+					Missing: []dto.FeedDiffEntry{{ID: 7, Name: "The Force Awakens"}},
+					Deleted: []dto.FeedDiffEntry{},
+				},
+				Planets: dto.FeedEntityDiff{
+					New: []dto.FeedDiffEntry{},
+					Changed: []dto.FeedDiffEntry{{ID: 2, Name: "Alderaan", Changes: []dto.FeedFieldChange{
+						{Field: "climates", Before: []string{"temperate", "cold"}, After: []string{"temperate"}},
+					}}},
+					Missing: []dto.FeedDiffEntry{},
+					Deleted: []dto.FeedDiffEntry{},
+				},
+				Relationships: dto.FeedRelationshipDiff{
+					New:     []dto.FeedRelationship{{PlanetID: 1, FilmID: 2}},
+					Missing: []dto.FeedRelationship{{PlanetID: 2, FilmID: 7}},
+				},
+				Skipped: []dto.FeedSkippedRecord{},
+			},
+			inputUpsert: true,
+		},
+		"should list only new data and deleted planets when insert ignore": {
+			mocking: func(filmService *mock.MockFilmService, planetService *mock.MockPlanetService) {
+				filmService.EXPECT().StreamFilms(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(film *model.Film) error) error {
+						fn(&model.Film{ID: 1, Title: "A New Hope", Episode: 4, Director: "G. Lucas", ReleaseDate: time.Date(1977, 5, 25, 0, 0, 0, 0, time.UTC)})
+						return nil
+					})
+				planetService.EXPECT().StreamPlanets(gomock.Any(), gomock.Any(), service.OptionWithDeleted()).
+					DoAndReturn(func(ctx context.Context, fn func(planet *model.Planet) error, opts ...service.Option) error {
+						fn(&model.Planet{ID: 1, Name: "Tattoine", Climates: []byte(`["arid"]`), Terrains: []byte(`["desert"]`)})
+						fn(&model.Planet{ID: 2, Name: "Alderaan", Climates: []byte(`["temperate"]`), Terrains: []byte(`["grasslands"]`),
+							DeletedAt: null.TimeFrom(time.Date(2022, 10, 9, 0, 0, 0, 0, time.UTC))})
+						fn(&model.Planet{ID: 3, Name: "Yavin IV", DeletedAt: null.TimeFrom(time.Date(2022, 10, 9, 0, 0, 0, 0, time.UTC))})
+						return nil
+					})
+				planetService.EXPECT().StreamPlanetsFilms(gomock.Any(), gomock.Any(), service.OptionWithDeleted()).
+					DoAndReturn(func(ctx context.Context, fn func(planetID, filmID int) error, opts ...service.Option) error {
+						fn(1, 1)
+						fn(3, 1)
+						return nil
+					})
+			},
+			expectedDiff: dto.FeedDiff{
+				Films: dto.FeedEntityDiff{
+					New:     []dto.FeedDiffEntry{{ID: 2, Name: "The Empire Strikes Back"}},
+					Changed: []dto.FeedDiffEntry{},
+					Missing: []dto.FeedDiffEntry{},
+					Deleted: []dto.FeedDiffEntry{},
+				},
+				Planets: dto.FeedEntityDiff{
+					New:     []dto.FeedDiffEntry{},
+					Changed: []dto.FeedDiffEntry{},
+					Missing: []dto.FeedDiffEntry{},
+					Deleted: []dto.FeedDiffEntry{{ID: 2, Name: "Alderaan"}},
+				},
+				Relationships: dto.FeedRelationshipDiff{
+					New:     []dto.FeedRelationship{{PlanetID: 1, FilmID: 2}},
+					Missing: []dto.FeedRelationship{},
+				},
+				Skipped: []dto.FeedSkippedRecord{},
+			},
+		},
+		"should throw error when stream films": {
+			mocking: func(filmService *mock.MockFilmService, planetService *mock.MockPlanetService) {
+				filmService.EXPECT().StreamFilms(gomock.Any(), gomock.Any()).Return(fmt.Errorf("error"))
+			},
+			expectedErrorMsg: "error",
+		},
+		"should throw error when stream planets": {
+			mocking: func(filmService *mock.MockFilmService, planetService *mock.MockPlanetService) {
+				filmService.EXPECT().StreamFilms(gomock.Any(), gomock.Any()).Return(nil)
+				planetService.EXPECT().StreamPlanets(gomock.Any(), gomock.Any(), service.OptionWithDeleted()).Return(fmt.Errorf("error"))
+			},
+			expectedErrorMsg: "error",
+		},
+		"should throw error when stream planets films": {
+			mocking: func(filmService *mock.MockFilmService, planetService *mock.MockPlanetService) {
+				filmService.EXPECT().StreamFilms(gomock.Any(), gomock.Any()).Return(nil)
+				planetService.EXPECT().StreamPlanets(gomock.Any(), gomock.Any(), service.OptionWithDeleted()).Return(nil)
+				planetService.EXPECT().StreamPlanetsFilms(gomock.Any(), gomock.Any(), service.OptionWithDeleted()).Return(fmt.Errorf("error"))
+			},
+			expectedErrorMsg: "error",
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockFilmService := mock.NewMockFilmService(ctrl)
+			mockPlanetService := mock.NewMockPlanetService(ctrl)
+			feedDatabaseScript := &script.IFeedDatabaseScript{
+				FilmService:   mockFilmService,
+				PlanetService: mockPlanetService,
+				Upsert:        cs.inputUpsert,
+			}
+
+			cs.mocking(mockFilmService, mockPlanetService)
+
+			// when
+			diff, err := feedDatabaseScript.Diff(context.Background(), films, planets, relationships)
+
+			// then
+			assert.Equal(t, cs.expectedDiff, diff)
+			if err != nil || cs.expectedErrorMsg != "" {
+				assert.EqualError(t, err, cs.expectedErrorMsg)
+			}
+		})
+	}
+}
+
+func Test_FeedDatabaseScript_WriteFeedDiff(t *testing.T) {
+	diff := dto.FeedDiff{
+		Films: dto.FeedEntityDiff{
+			New:     []dto.FeedDiffEntry{{ID: 2, Name: "The Empire Strikes Back"}},
+			Changed: []dto.FeedDiffEntry{},
+			Missing: []dto.FeedDiffEntry{},
+			Deleted: []dto.FeedDiffEntry{},
+		},
+		Planets: dto.FeedEntityDiff{
+			New: []dto.FeedDiffEntry{},
+			Changed: []dto.FeedDiffEntry{{ID: 1, Name: "Tatooine", Changes: []dto.FeedFieldChange{
+				{Field: "population", Before: null.Int64{}, After: null.Int64From(200000)},
+			}}},
+			Missing: []dto.FeedDiffEntry{{ID: 61, Name: "Jakku"}},
+			Deleted: []dto.FeedDiffEntry{{ID: 2, Name: "Alderaan"}},
+		},
+		Relationships: dto.FeedRelationshipDiff{
+			New:     []dto.FeedRelationship{{PlanetID: 1, FilmID: 2}},
+			Missing: []dto.FeedRelationship{},
+		},
+		Skipped: []dto.FeedSkippedRecord{
+			{Resource: "films", Page: 1, Url: "https://swapi.dev/api/films/3/", Reason: "invalid date"},
+		},
+	}
+
+	var cases = map[string]struct {
+		inputFormat    string
+		expectedReport string
+	}{
+		"should write text": {
+			inputFormat: script.FEED_REPORT_TEXT,
+			expectedReport: "films: 1 new, 0 changed, 0 missing, 0 deleted\n" +
+				"  + 2 The Empire Strikes Back\n" +
+				"planets: 0 new, 1 changed, 1 missing, 1 deleted\n" +
+				"  ~ 1 Tatooine\n" +
+				"      population: null -> 200000\n" +
+				"  - 61 Jakku\n" +
+				"  x 2 Alderaan\n" +
+				"relationships: 1 new, 0 missing\n" +
+				"  + planet 1 film 2\n" +
+				"skipped: 1\n" +
+				"  ! films page 1 https://swapi.dev/api/films/3/: invalid date\n",
+		},
+		"should write json": {
+			inputFormat: script.FEED_REPORT_JSON,
+			expectedReport: `{"films":{"new":[{"id":2,"name":"The Empire Strikes Back"}],"changed":[],"missing":[],"deleted":[]},` +
+				`"planets":{"new":[],"changed":[{"id":1,"name":"Tatooine","changes":[{"field":"population","before":null,"after":200000}]}],"missing":[{"id":61,"name":"Jakku"}],"deleted":[{"id":2,"name":"Alderaan"}]},` +
+				`"relationships":{"new":[{"planet_id":1,"film_id":2}],"missing":[]},` +
+				`"skipped":[{"resource":"films","page":1,"url":"https://swapi.dev/api/films/3/","reason":"invalid date"}]}`,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			report := &bytes.Buffer{}
+
+			// when
+			err := script.WriteFeedDiff(report, cs.inputFormat, diff)
+
+			// then
+			assert.Nil(t, err)
+			if cs.inputFormat == script.FEED_REPORT_JSON {
+				assert.JSONEq(t, cs.expectedReport, report.String())
+				return
+			}
+			assert.Equal(t, cs.expectedReport, report.String())
+		})
+	}
+}
+
+// feedStore keeps films, planets and relationships in memory the way the
+// film and planet services keep them in the database, recording what each
+// write changed
+type feedStore struct {
+	films   map[int]*model.Film
+	planets map[int]*model.Planet
+	links   map[dto.FeedRelationship]bool
+	written feedDiffIDs
+}
+
+type feedDiffIDs struct {
+	NewFilms         []int
+	ChangedFilms     []int
+	NewPlanets       []int
+	ChangedPlanets   []int
+	DeletedPlanets   []int
+	NewRelationships []dto.FeedRelationship
+}
+
+type fakeFilmService struct {
+	service.FilmService
+	store *feedStore
+}
+
+func (impl *fakeFilmService) CreateFilms(ctx context.Context, films []*model.Film, opts ...service.Option) error {
+	for _, film := range films {
+		current, ok := impl.store.films[film.ID]
+		if !ok {
+			impl.store.films[film.ID] = film
+			impl.store.written.NewFilms = append(impl.store.written.NewFilms, film.ID)
+		} else if service.GetOptionUpsert(opts) && len(script.DiffFilm(current, film)) > 0 {
+			impl.store.films[film.ID] = film
+			impl.store.written.ChangedFilms = append(impl.store.written.ChangedFilms, film.ID)
+		}
+	}
+
+	return nil
+}
+
+func (impl *fakeFilmService) StreamFilms(ctx context.Context, fn func(film *model.Film) error) error {
+	for _, film := range impl.store.films {
+		if err := fn(film); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type fakePlanetService struct {
+	service.PlanetService
+	store *feedStore
+}
+
+func (impl *fakePlanetService) CreatePlanets(ctx context.Context, planets []*model.Planet, opts ...service.Option) error {
+	for _, planet := range planets {
+		current, ok := impl.store.planets[planet.ID]
+		if !ok {
+			impl.store.planets[planet.ID] = planet
+			impl.store.written.NewPlanets = append(impl.store.written.NewPlanets, planet.ID)
+		} else if current.DeletedAt.Valid {
+			impl.store.written.DeletedPlanets = append(impl.store.written.DeletedPlanets, planet.ID)
+		} else if service.GetOptionUpsert(opts) && len(script.DiffPlanet(current, planet)) > 0 {
+			impl.store.planets[planet.ID] = planet
+			impl.store.written.ChangedPlanets = append(impl.store.written.ChangedPlanets, planet.ID)
+		}
+	}
+
+	return nil
+}
+
+func (impl *fakePlanetService) CreateRelationshipFilmsToPlanets(ctx context.Context, relationships map[int][]int) error {
+	for planetID, filmIDs := range relationships {
+		for _, filmID := range filmIDs {
+			relationship := dto.FeedRelationship{PlanetID: planetID, FilmID: filmID}
+			if !impl.store.links[relationship] {
+				impl.store.links[relationship] = true
+				impl.store.written.NewRelationships = append(impl.store.written.NewRelationships, relationship)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (impl *fakePlanetService) StreamPlanets(ctx context.Context, fn func(planet *model.Planet) error, opts ...service.Option) error {
+	for _, planet := range impl.store.planets {
+		if planet.DeletedAt.Valid && !service.GetOptionWithDeleted(opts) {
+			continue
+		}
+		if err := fn(planet); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (impl *fakePlanetService) StreamPlanetsFilms(ctx context.Context, fn func(planetID, filmID int) error, opts ...service.Option) error {
+	for relationship := range impl.store.links {
+		if impl.store.planets[relationship.PlanetID].DeletedAt.Valid && !service.GetOptionWithDeleted(opts) {
+			continue
+		}
+		if err := fn(relationship.PlanetID, relationship.FilmID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func Test_FeedDatabaseScript_DiffAgreesWithExecute(t *testing.T) {
+	swapiFilms := []model.SwapiFilm{
+		{
+			Url: "https://swapi.dev/api/films/1/", Created: "2014-12-10T14:23:31.880000Z", Edited: "2014-12-20T19:49:45.256000Z",
+			Title: "A New Hope", EpisodeID: 4, Director: "George Lucas", ReleaseDate: "1977-05-25",
+		},
+		{
+			Url: "https://swapi.dev/api/films/2/", Created: "2014-12-12T11:26:24.656000Z", Edited: "2014-12-15T13:07:53.386000Z",
+			Title: "The Empire Strikes Back", EpisodeID: 5, Director: "Irvin Kershner", ReleaseDate: "1980-05-17",
+		},
+	}
+	swapiPlanets := []model.SwapiPlanet{
+		{
+			Url: "https://swapi.dev/api/planets/1/", Created: "2014-12-09T13:50:49.641000Z", Edited: "2014-12-20T20:58:18.411000Z",
+			Name: "Tatooine", Climate: "arid", Terrain: "desert", Population: "200000",
+			Films: []string{"https://swapi.dev/api/films/1/", "https://swapi.dev/api/films/2/"},
+		},
+		{
+			Url: "https://swapi.dev/api/planets/2/", Created: "2014-12-10T11:35:48.479000Z", Edited: "2014-12-20T20:58:18.420000Z",
+			Name: "Alderaan", Climate: "temperate", Terrain: "grasslands, mountains",
+			Films: []string{"https://swapi.dev/api/films/1/"},
+		},
+		{
+			Url: "https://swapi.dev/api/planets/4/", Created: "2014-12-10T11:39:13.934000Z", Edited: "2014-12-20T20:58:18.425000Z",
+			Name: "Hoth", Climate: "frozen", Terrain: "tundra, ice caves, mountain ranges",
+			Films: []string{"https://swapi.dev/api/films/2/", "https://swapi.dev/api/films/2/"},
+		},
+	}
+	newStore := func() *feedStore {
+		return &feedStore{
+			films: map[int]*model.Film{
+				1: {ID: 1, Title: "A New Hope", Episode: 4, Director: "G. Lucas", ReleaseDate: time.Date(1977, 5, 25, 0, 0, 0, 0, time.UTC)},
+			},
+			planets: map[int]*model.Planet{
+				1: {ID: 1, Name: "Tattoine", Climates: []byte(`["arid"]`), Terrains: []byte(`["desert"]`), Population: null.Int64From(200000)},
+				2: {ID: 2, Name: "Alderaan", Climates: []byte(`["temperate"]`), Terrains: []byte(`["grasslands"]`),
+					DeletedAt: null.TimeFrom(time.Date(2022, 10, 9, 0, 0, 0, 0, time.UTC))},
+				3: {ID: 3, Name: "Yavin IV", Climates: []byte(`["temperate", "tropical"]`), Terrains: []byte(`["jungle"]`)},
+			},
+			links: map[dto.FeedRelationship]bool{{PlanetID: 1, FilmID: 1}: true, {PlanetID: 3, FilmID: 1}: true},
+		}
+	}
+
+	var cases = map[string]struct {
+		inputUpsert     bool
+		expectedWritten feedDiffIDs
+	}{
+		"should agree when insert ignore": {
+			expectedWritten: feedDiffIDs{
+				NewFilms:         []int{2},
+				NewPlanets:       []int{4},
+				DeletedPlanets:   []int{2},
+				NewRelationships: []dto.FeedRelationship{{PlanetID: 1, FilmID: 2}, {PlanetID: 2, FilmID: 1}, {PlanetID: 4, FilmID: 2}},
+			},
+		},
+		"should agree when upsert": {
+			inputUpsert: true,
+			expectedWritten: feedDiffIDs{
+				NewFilms:         []int{2},
+				ChangedFilms:     []int{1},
+				NewPlanets:       []int{4},
+				ChangedPlanets:   []int{1},
+				DeletedPlanets:   []int{2},
+				NewRelationships: []dto.FeedRelationship{{PlanetID: 1, FilmID: 2}, {PlanetID: 2, FilmID: 1}, {PlanetID: 4, FilmID: 2}},
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSwapiRequest := mock.NewMockSwapiRequest(ctrl)
+			mockSwapiRequest.EXPECT().GetFilms(gomock.Any(), 1).Times(2).
+				Return(&model.SwapiFilmsResponse{Results: swapiFilms}, nil)
+			mockSwapiRequest.EXPECT().GetPlanets(gomock.Any(), 1).Times(2).
+				Return(&model.SwapiPlanetsResponse{Results: swapiPlanets}, nil)
+
+			store := newStore()
+			report := &bytes.Buffer{}
+			feedDatabaseScript := &script.IFeedDatabaseScript{
+				Swapi:         mockSwapiRequest,
+				FilmService:   &fakeFilmService{store: store},
+				PlanetService: &fakePlanetService{store: store},
+				Upsert:        cs.inputUpsert,
+				DryRun:        true,
+				ReportFormat:  script.FEED_REPORT_JSON,
+				Report:        report,
+			}
+
+			// when
+			errDiff := feedDatabaseScript.Execute(context.Background())
+			feedDatabaseScript.DryRun = false
+			feedDatabaseScript.Report = nil
+			errExecute := feedDatabaseScript.Execute(context.Background())
+
+			// then
+			assert.Nil(t, errDiff)
+			assert.Nil(t, errExecute)
+
+			var diff dto.FeedDiff
+			assert.Nil(t, json.Unmarshal(report.Bytes(), &diff))
+			assert.Equal(t, cs.expectedWritten, feedDiffToIDs(diff))
+
+			sortFeedDiffIDs(&store.written)
+			assert.Equal(t, cs.expectedWritten, store.written)
+		})
+	}
+}
+
+func feedDiffToIDs(diff dto.FeedDiff) feedDiffIDs {
+	ids := func(entries []dto.FeedDiffEntry) []int {
+		var res []int
+		for _, entry := range entries {
+			res = append(res, entry.ID)
+		}
+		return res
+	}
+
+	var relationships []dto.FeedRelationship
+	if len(diff.Relationships.New) > 0 {
+		relationships = diff.Relationships.New
+	}
+
+	return feedDiffIDs{
+		NewFilms:         ids(diff.Films.New),
+		ChangedFilms:     ids(diff.Films.Changed),
+		NewPlanets:       ids(diff.Planets.New),
+		ChangedPlanets:   ids(diff.Planets.Changed),
+		DeletedPlanets:   ids(diff.Planets.Deleted),
+		NewRelationships: relationships,
+	}
+}
+
+func sortFeedDiffIDs(ids *feedDiffIDs) {
+	for _, values := range [][]int{ids.NewFilms, ids.ChangedFilms, ids.NewPlanets, ids.ChangedPlanets, ids.DeletedPlanets} {
+		sort.Ints(values)
+	}
+	sort.Slice(ids.NewRelationships, func(i, j int) bool {
+		if ids.NewRelationships[i].PlanetID != ids.NewRelationships[j].PlanetID {
+			return ids.NewRelationships[i].PlanetID < ids.NewRelationships[j].PlanetID
+		}
+		return ids.NewRelationships[i].FilmID < ids.NewRelationships[j].FilmID
+	})
+}
@@ -0,0 +1,89 @@
+package script
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/export"
+	"github.com/viniosilva/starwars-api/internal/service"
+)
+
+type IImportScript struct {
+	ImportService service.ImportService
+	FilmsPath     string
+	PlanetsPath   string
+	DryRun        bool
+	Options       []service.Option
+}
+
+const TRACE_IMPORT = "internal.script.import"
+
+type importFunc func(ctx context.Context, format export.Format, r io.Reader, dryRun bool, opts ...service.Option) (dto.ImportResult, error)
+
+// Execute imports the films file and then the planets file, stopping at the
+// first file with invalid rows
+func (impl *IImportScript) Execute() error {
+	logrus.WithFields(logrus.Fields{"trace": TRACE_IMPORT}).Info("starting")
+
+	if impl.FilmsPath == "" && impl.PlanetsPath == "" {
+		return fmt.Errorf("no file to import")
+	}
+
+	if impl.FilmsPath != "" {
+		if err := impl.ImportFile("films", impl.FilmsPath, impl.ImportService.ImportFilms); err != nil {
+			return err
+		}
+	}
+
+	if impl.PlanetsPath != "" {
+		if err := impl.ImportFile("planets", impl.PlanetsPath, impl.ImportService.ImportPlanets); err != nil {
+			return err
+		}
+	}
+
+	logrus.WithFields(logrus.Fields{"trace": TRACE_IMPORT}).Info("finished")
+	return nil
+}
+
+func (impl *IImportScript) ImportFile(name, path string, fn importFunc) error {
+	trace := fmt.Sprintf("%s:import_%s", TRACE_IMPORT, name)
+
+	file, err := os.Open(path)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": trace}).Error(err)
+		return err
+	}
+	defer file.Close()
+
+	format := export.Format(strings.TrimPrefix(filepath.Ext(path), "."))
+
+	res, err := fn(context.Background(), format, file, impl.DryRun, impl.Options...)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": trace}).Error(err)
+		return err
+	}
+
+	for _, rowErr := range res.Errors {
+		logrus.WithFields(logrus.Fields{"trace": trace, "file": path, "line": rowErr.Line}).Error(rowErr.Error)
+	}
+	logrus.WithFields(logrus.Fields{
+		"trace":    trace,
+		"total":    res.Total,
+		"valid":    res.Valid,
+		"imported": res.Imported,
+		"dry_run":  res.DryRun,
+		"upsert":   res.Upsert,
+	}).Info("imported")
+
+	if len(res.Errors) > 0 {
+		return fmt.Errorf("%s has %d invalid rows", path, len(res.Errors))
+	}
+
+	return nil
+}
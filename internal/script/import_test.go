@@ -0,0 +1,93 @@
+package script_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/export"
+	"github.com/viniosilva/starwars-api/internal/script"
+	"github.com/viniosilva/starwars-api/mock"
+)
+
+func Test_ImportScript_Execute(t *testing.T) {
+	var cases = map[string]struct {
+		mocking          func(importService *mock.MockImportService)
+		inputFilms       string
+		inputPlanets     string
+		expectedErrorMsg string
+	}{
+		"should import films and planets": {
+			mocking: func(importService *mock.MockImportService) {
+				gomock.InOrder(
+					importService.EXPECT().ImportFilms(gomock.Any(), export.FormatNDJSON, gomock.Any(), true).
+						Return(dto.ImportResult{Total: 1, Valid: 1, DryRun: true}, nil),
+					importService.EXPECT().ImportPlanets(gomock.Any(), export.FormatCSV, gomock.Any(), true).
+						Return(dto.ImportResult{Total: 1, Valid: 1, DryRun: true}, nil),
+				)
+			},
+			inputFilms:   "films.ndjson",
+			inputPlanets: "planets.csv",
+		},
+		"should throw error when there are invalid rows": {
+			mocking: func(importService *mock.MockImportService) {
+				importService.EXPECT().ImportPlanets(gomock.Any(), export.FormatCSV, gomock.Any(), true).
+					Return(dto.ImportResult{Total: 1, Errors: []dto.ImportRowError{{Line: 2, Error: "name is required"}}}, nil)
+			},
+			inputPlanets:     "planets.csv",
+			expectedErrorMsg: "planets.csv has 1 invalid rows",
+		},
+		"should throw error when import fails": {
+			mocking: func(importService *mock.MockImportService) {
+				importService.EXPECT().ImportFilms(gomock.Any(), export.FormatCSV, gomock.Any(), true).
+					Return(dto.ImportResult{}, fmt.Errorf("error"))
+			},
+			inputFilms:       "films.csv",
+			expectedErrorMsg: "error",
+		},
+		"should throw error when there is no file": {
+			mocking:          func(importService *mock.MockImportService) {},
+			expectedErrorMsg: "no file to import",
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			dir := t.TempDir()
+			path := func(file string) string {
+				if file == "" {
+					return ""
+				}
+				os.WriteFile(filepath.Join(dir, file), []byte{}, 0644)
+				return filepath.Join(dir, file)
+			}
+
+			mockImportService := mock.NewMockImportService(ctrl)
+			importScript := &script.IImportScript{
+				ImportService: mockImportService,
+				FilmsPath:     path(cs.inputFilms),
+				PlanetsPath:   path(cs.inputPlanets),
+				DryRun:        true,
+			}
+
+			cs.mocking(mockImportService)
+
+			// when
+			err := importScript.Execute()
+
+			// then
+			if cs.expectedErrorMsg != "" {
+				assert.ErrorContains(t, err, cs.expectedErrorMsg)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/viniosilva/starwars-api/internal/auth"
+	"github.com/viniosilva/starwars-api/internal/config"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+const (
+	AUDIT_ENTITY_PLANET       = "planet"
+	AUDIT_ENTITY_FILM         = "film"
+	AUDIT_ACTION_CREATE       = "create"
+	AUDIT_ACTION_UPDATE       = "update"
+	AUDIT_ACTION_DELETE       = "delete"
+	AUDIT_ACTION_RESTORE      = "restore"
+	AUDIT_ACTION_LINK_FILMS   = "link_films"
+	AUDIT_ACTION_UNLINK_FILMS = "unlink_films"
+	AUDIT_ACTION_TRANSLATE    = "translate"
+	AUDIT_SYSTEM_ACTOR        = "system"
+)
+
+//go:generate mockgen -destination=../../mock/audit_service_mock.go -package=mock . AuditService
+type AuditService interface {
+	FindAuditLogsAndTotal(ctx context.Context, page, size int, opts ...Option) (dto.FindAuditLogsAndTotalResult, error)
+}
+
+type IAuditService struct {
+	DB *sql.DB
+}
+
+func (impl *IAuditService) FindAuditLogsAndTotal(ctx context.Context, page, size int, opts ...Option) (dto.FindAuditLogsAndTotalResult, error) {
+	offset := 0
+	if page > 1 {
+		offset = size * (page - 1)
+	}
+
+	wheres := GetOptionsWhere(opts)
+	qms := append([]qm.QueryMod{
+		qm.Limit(size + 1),
+		qm.Offset(offset),
+		qm.OrderBy(fmt.Sprintf("%s DESC", model.AuditLogColumns.ID)),
+	}, wheres...)
+
+	logs, err := model.AuditLogs(qms...).All(ctx, impl.DB)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.audit.find_audit_logs_and_total:audit_logs.all"}).Error(err)
+		return dto.FindAuditLogsAndTotalResult{}, err
+	}
+
+	total, err := model.AuditLogs(wheres...).Count(ctx, impl.DB)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.audit.find_audit_logs_and_total:audit_logs.count"}).Error(err)
+		return dto.FindAuditLogsAndTotalResult{}, err
+	}
+
+	data := logs
+	next := false
+	if len(logs) > size {
+		next = true
+		data = logs[:size]
+	}
+
+	return dto.FindAuditLogsAndTotalResult{
+		Total: total,
+		Count: len(data),
+		Next:  next,
+		Data:  data,
+	}, nil
+}
+
+// NewAuditLog records the action of the principal and request of ctx on an
+// entity, before and after being marshaled to JSON when not nil
+func NewAuditLog(ctx context.Context, entity string, entityID int, action string, before, after interface{}) (*model.AuditLog, error) {
+	actor := AUDIT_SYSTEM_ACTOR
+	if principal := auth.PrincipalFromContext(ctx); principal != nil {
+		actor = principal.Subject
+	}
+
+	log := &model.AuditLog{
+		CreatedAt: time.Now(),
+		Actor:     actor,
+		Entity:    entity,
+		EntityID:  entityID,
+		Action:    action,
+	}
+	if requestID := config.RequestIDFromContext(ctx); requestID != "" {
+		log.RequestID = null.StringFrom(requestID)
+	}
+
+	if before != nil {
+		data, err := json.Marshal(before)
+		if err != nil {
+			return nil, err
+		}
+		log.BeforeData = null.JSONFrom(data)
+	}
+	if after != nil {
+		data, err := json.Marshal(after)
+		if err != nil {
+			return nil, err
+		}
+		log.AfterData = null.JSONFrom(data)
+	}
+
+	return log, nil
+}
+
+func InsertAuditLogs(ctx context.Context, exec boil.ContextExecutor, logs []*model.AuditLog) error {
+	if len(logs) == 0 {
+		return nil
+	}
+
+	values := make([]string, len(logs))
+	args := []interface{}{}
+	for i := 0; i < len(values); i += 1 {
+		l := logs[i]
+		values[i] = "(?, ?, ?, ?, ?, ?, ?, ?)"
+		args = append(args,
+			l.CreatedAt.Format("2006-01-02 15:04:05"),
+			l.Actor,
+			l.RequestID,
+			l.Entity,
+			l.EntityID,
+			l.Action,
+			l.BeforeData,
+			l.AfterData,
+		)
+	}
+
+	columns := []string{
+		model.AuditLogColumns.CreatedAt,
+		model.AuditLogColumns.Actor,
+		model.AuditLogColumns.RequestID,
+		model.AuditLogColumns.Entity,
+		model.AuditLogColumns.EntityID,
+		model.AuditLogColumns.Action,
+		model.AuditLogColumns.BeforeData,
+		model.AuditLogColumns.AfterData,
+	}
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES %s;",
+		model.TableNames.AuditLog, strings.Join(columns, ", "), strings.Join(values, ",\n"))
+
+	if _, err := exec.ExecContext(ctx, query, args...); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.audit.insert_audit_logs:exec.exec_context"}).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func rollback(tx *sql.Tx, trace string) {
+	if err := tx.Rollback(); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": trace}).Error(err)
+	}
+}
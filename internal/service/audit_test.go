@@ -0,0 +1,122 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/auth"
+	"github.com/viniosilva/starwars-api/internal/config"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/viniosilva/starwars-api/internal/service"
+	"github.com/volatiletech/null/v8"
+)
+
+func Test_AuditService_FindAuditLogsAndTotal(t *testing.T) {
+	var cases = map[string]struct {
+		mocking        func(db sqlmock.Sqlmock)
+		inputPage      int
+		inputSize      int
+		inputOptions   []service.Option
+		expectedResult dto.FindAuditLogsAndTotalResult
+		expectedErr    error
+	}{
+		"should return audit logs": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT `audit_log`.\\* FROM `audit_log` WHERE \\(entity = \\?\\) ORDER BY id DESC LIMIT 2").WithArgs("planet").
+					WillReturnRows(sqlmock.NewRows([]string{model.AuditLogColumns.ID, model.AuditLogColumns.Entity}).AddRow(2, "planet").AddRow(1, "planet"))
+				db.ExpectQuery("SELECT COUNT").WithArgs("planet").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+			},
+			inputPage:    1,
+			inputSize:    1,
+			inputOptions: []service.Option{service.OptionWhere("entity = ?", "planet")},
+			expectedResult: dto.FindAuditLogsAndTotalResult{
+				Count: 1,
+				Total: 2,
+				Next:  true,
+				Data:  []*model.AuditLog{{ID: 2, Entity: "planet"}},
+			},
+		},
+		"should throw error when select": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error"))
+			},
+			inputPage:   1,
+			inputSize:   10,
+			expectedErr: fmt.Errorf("models: failed to assign all query results to AuditLog slice: bind failed to execute query: error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			auditService := service.IAuditService{DB: db}
+
+			cs.mocking(mockDB)
+
+			// when
+			res, err := auditService.FindAuditLogsAndTotal(context.Background(), cs.inputPage, cs.inputSize, cs.inputOptions...)
+
+			// then
+			assert.Equal(t, cs.expectedResult, res)
+			if cs.expectedErr != nil {
+				assert.EqualError(t, err, cs.expectedErr.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func Test_AuditService_NewAuditLog(t *testing.T) {
+	var cases = map[string]struct {
+		inputContext       context.Context
+		inputBefore        interface{}
+		inputAfter         interface{}
+		expectedActor      string
+		expectedRequestID  null.String
+		expectedBeforeData null.JSON
+		expectedAfterData  null.JSON
+	}{
+		"should record the principal and request id": {
+			inputContext:       config.WithRequestID(auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "luke"}), "request-id"),
+			inputBefore:        map[string]string{"name": "Tatooine"},
+			inputAfter:         map[string]string{"name": "Alderaan"},
+			expectedActor:      "luke",
+			expectedRequestID:  null.StringFrom("request-id"),
+			expectedBeforeData: null.JSONFrom([]byte(`{"name":"Tatooine"}`)),
+			expectedAfterData:  null.JSONFrom([]byte(`{"name":"Alderaan"}`)),
+		},
+		"should record the system actor": {
+			inputContext:      context.Background(),
+			inputAfter:        map[string]string{"name": "Alderaan"},
+			expectedActor:     service.AUDIT_SYSTEM_ACTOR,
+			expectedAfterData: null.JSONFrom([]byte(`{"name":"Alderaan"}`)),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// when
+			log, err := service.NewAuditLog(cs.inputContext, service.AUDIT_ENTITY_PLANET, 1, service.AUDIT_ACTION_UPDATE, cs.inputBefore, cs.inputAfter)
+
+			// then
+			assert.Nil(t, err)
+			assert.Equal(t, cs.expectedActor, log.Actor)
+			assert.Equal(t, cs.expectedRequestID, log.RequestID)
+			assert.Equal(t, cs.expectedBeforeData, log.BeforeData)
+			assert.Equal(t, cs.expectedAfterData, log.AfterData)
+			assert.Equal(t, service.AUDIT_ENTITY_PLANET, log.Entity)
+			assert.Equal(t, 1, log.EntityID)
+			assert.Equal(t, service.AUDIT_ACTION_UPDATE, log.Action)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/viniosilva/starwars-api/internal/dto"
+)
+
+const (
+	EVENT_PLANET_CREATED  = "planet.created"
+	EVENT_PLANET_UPDATED  = "planet.updated"
+	EVENT_PLANET_DELETED  = "planet.deleted"
+	EVENT_PLANET_RESTORED = "planet.restored"
+	EVENT_FILM_CREATED    = "film.created"
+	EVENT_FILM_UPDATED    = "film.updated"
+	EVENT_FILM_LINKED     = "film.linked"
+	EVENT_FILM_UNLINKED   = "film.unlinked"
+)
+
+var EventTypes = []string{
+	EVENT_PLANET_CREATED,
+	EVENT_PLANET_UPDATED,
+	EVENT_PLANET_DELETED,
+	EVENT_PLANET_RESTORED,
+	EVENT_FILM_CREATED,
+	EVENT_FILM_UPDATED,
+	EVENT_FILM_LINKED,
+	EVENT_FILM_UNLINKED,
+}
+
+//go:generate mockgen -destination=../../mock/event_publisher_mock.go -package=mock . EventPublisher
+type EventPublisher interface {
+	Publish(ctx context.Context, events ...dto.Event) error
+}
+
+func NewEvent(eventType, entity string, entityID int, data interface{}) (dto.Event, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return dto.Event{}, err
+	}
+
+	return dto.Event{
+		ID:         uuid.NewString(),
+		Type:       eventType,
+		Entity:     entity,
+		EntityID:   entityID,
+		OccurredAt: time.Now().UTC(),
+		Data:       raw,
+	}, nil
+}
+
+func IsEventType(eventType string) bool {
+	for _, t := range EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+
+	return false
+}
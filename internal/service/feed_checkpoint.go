@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+//go:generate mockgen -destination=../../mock/feed_checkpoint_service_mock.go -package=mock . FeedCheckpointService
+type FeedCheckpointService interface {
+	FindFeedCheckpoint(ctx context.Context, name string) (*dto.FeedCheckpoint, error)
+	SaveFeedCheckpoint(ctx context.Context, name string, checkpoint dto.FeedCheckpoint) error
+	DeleteFeedCheckpoint(ctx context.Context, name string) error
+}
+
+type IFeedCheckpointService struct {
+	DB *sql.DB
+}
+
+// FindFeedCheckpoint returns where the feed name stopped, or nil when it has
+// no checkpoint
+func (impl *IFeedCheckpointService) FindFeedCheckpoint(ctx context.Context, name string) (*dto.FeedCheckpoint, error) {
+	checkpoint, err := model.FeedCheckpoints(
+		qm.Where(fmt.Sprintf("%s = ?", model.FeedCheckpointColumns.Name), name),
+	).One(ctx, impl.DB)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.feed_checkpoint.find_feed_checkpoint:feed_checkpoints.one"}).Error(err)
+		return nil, err
+	}
+
+	return &dto.FeedCheckpoint{Resource: checkpoint.Resource, Page: checkpoint.Page}, nil
+}
+
+// SaveFeedCheckpoint creates or replaces the checkpoint of the feed name,
+// stamping its updated_at
+func (impl *IFeedCheckpointService) SaveFeedCheckpoint(ctx context.Context, name string, checkpoint dto.FeedCheckpoint) error {
+	row := &model.FeedCheckpoint{Name: name, Resource: checkpoint.Resource, Page: checkpoint.Page}
+	update := boil.Whitelist(model.FeedCheckpointColumns.UpdatedAt, model.FeedCheckpointColumns.Resource, model.FeedCheckpointColumns.Page)
+	if err := row.Upsert(ctx, impl.DB, update, boil.Infer()); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.feed_checkpoint.save_feed_checkpoint:feed_checkpoint.upsert"}).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (impl *IFeedCheckpointService) DeleteFeedCheckpoint(ctx context.Context, name string) error {
+	_, err := model.FeedCheckpoints(
+		qm.Where(fmt.Sprintf("%s = ?", model.FeedCheckpointColumns.Name), name),
+	).DeleteAll(ctx, impl.DB)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.feed_checkpoint.delete_feed_checkpoint:feed_checkpoints.delete_all"}).Error(err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/service"
+)
+
+func Test_FeedCheckpointService_FindFeedCheckpoint(t *testing.T) {
+	var cases = map[string]struct {
+		mocking            func(db sqlmock.Sqlmock)
+		expectedCheckpoint *dto.FeedCheckpoint
+		expectedErrorMsg   string
+	}{
+		"should return checkpoint": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery(regexp.QuoteMeta("SELECT `feed_checkpoints`.* FROM `feed_checkpoints` WHERE (name = ?) LIMIT 1;")).WithArgs("swapi").
+					WillReturnRows(sqlmock.NewRows([]string{"name", "updated_at", "resource", "page"}).AddRow("swapi", time.Now(), "planets", 3))
+			},
+			expectedCheckpoint: &dto.FeedCheckpoint{Resource: "planets", Page: 3},
+		},
+		"should return nil when there is no checkpoint": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT `feed_checkpoints`.\\* FROM `feed_checkpoints`").WithArgs("swapi").
+					WillReturnRows(sqlmock.NewRows([]string{"name", "updated_at", "resource", "page"}))
+			},
+		},
+		"should throw error": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT `feed_checkpoints`.\\* FROM `feed_checkpoints`").WithArgs("swapi").WillReturnError(fmt.Errorf("error"))
+			},
+			expectedErrorMsg: "models: failed to execute a one query for feed_checkpoints: bind failed to execute query: error",
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			feedCheckpointService := service.IFeedCheckpointService{DB: db}
+
+			cs.mocking(mockDB)
+
+			// when
+			checkpoint, err := feedCheckpointService.FindFeedCheckpoint(context.Background(), "swapi")
+
+			// then
+			assert.Equal(t, cs.expectedCheckpoint, checkpoint)
+			if err != nil || cs.expectedErrorMsg != "" {
+				assert.EqualError(t, err, cs.expectedErrorMsg)
+			}
+			assert.Nil(t, mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_FeedCheckpointService_SaveFeedCheckpoint(t *testing.T) {
+	var cases = map[string]struct {
+		mocking          func(db sqlmock.Sqlmock)
+		expectedErrorMsg string
+	}{
+		"should be successful": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectExec(regexp.QuoteMeta("INSERT INTO `feed_checkpoints` (`name`,`updated_at`,`resource`,`page`) VALUES (?,?,?,?) ON DUPLICATE KEY UPDATE `updated_at` = VALUES(`updated_at`),`resource` = VALUES(`resource`),`page` = VALUES(`page`)")).
+					WithArgs("swapi", sqlmock.AnyArg(), "planets", 3).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		"should throw error": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectExec("INSERT INTO `feed_checkpoints`").WillReturnError(fmt.Errorf("error"))
+			},
+			expectedErrorMsg: "models: unable to upsert for feed_checkpoints: error",
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			feedCheckpointService := service.IFeedCheckpointService{DB: db}
+
+			cs.mocking(mockDB)
+
+			// when
+			err = feedCheckpointService.SaveFeedCheckpoint(context.Background(), "swapi", dto.FeedCheckpoint{Resource: "planets", Page: 3})
+
+			// then
+			if err != nil || cs.expectedErrorMsg != "" {
+				assert.EqualError(t, err, cs.expectedErrorMsg)
+			}
+			assert.Nil(t, mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_FeedCheckpointService_DeleteFeedCheckpoint(t *testing.T) {
+	var cases = map[string]struct {
+		mocking          func(db sqlmock.Sqlmock)
+		expectedErrorMsg string
+	}{
+		"should be successful": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectExec(regexp.QuoteMeta("DELETE FROM `feed_checkpoints` WHERE (name = ?);")).WithArgs("swapi").WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		"should throw error": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectExec("DELETE FROM `feed_checkpoints`").WithArgs("swapi").WillReturnError(fmt.Errorf("error"))
+			},
+			expectedErrorMsg: "models: unable to delete all from feed_checkpoints: error",
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			feedCheckpointService := service.IFeedCheckpointService{DB: db}
+
+			cs.mocking(mockDB)
+
+			// when
+			err = feedCheckpointService.DeleteFeedCheckpoint(context.Background(), "swapi")
+
+			// then
+			if err != nil || cs.expectedErrorMsg != "" {
+				assert.EqualError(t, err, cs.expectedErrorMsg)
+			}
+			assert.Nil(t, mockDB.ExpectationsWereMet())
+		})
+	}
+}
@@ -4,26 +4,64 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"strings"
+	"reflect"
 
 	"github.com/sirupsen/logrus"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/exception"
 	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 )
 
 //go:generate mockgen -destination=../../mock/film_service_mock.go -package=mock . FilmService
 type FilmService interface {
-	CreateFilms(ctx context.Context, Films []*model.Film) error
+	CreateFilms(ctx context.Context, Films []*model.Film, opts ...Option) error
+	CreateFilmsTx(ctx context.Context, tx *sql.Tx, films []*model.Film, opts ...Option) error
+	FindFilmsAndTotal(ctx context.Context, page, size int, opts ...Option) (dto.FindFilmsAndTotalResult, error)
+	FindFilmByID(ctx context.Context, filmID int) (*model.Film, error)
+	FindFilmsByPlanetIDs(ctx context.Context, planetIDs []int) (map[int][]*model.Film, error)
+	StreamFilms(ctx context.Context, fn func(film *model.Film) error) error
 }
 
 type IFilmService struct {
 	DB *sql.DB
 }
 
-func (impl *IFilmService) CreateFilms(ctx context.Context, films []*model.Film) error {
+type filmWithPlanetID struct {
+	model.Film `boil:",bind"`
+	PlanetID   int `boil:"planet_id"`
+}
+
+func (impl *IFilmService) CreateFilms(ctx context.Context, films []*model.Film, opts ...Option) error {
+	tx, err := impl.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.film.create_films:db.begin_tx"}).Error(err)
+		return err
+	}
+
+	if err := impl.CreateFilmsTx(ctx, tx, films, opts...); err != nil {
+		rollback(tx, "internal.service.film.create_films:tx.rollback")
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.film.create_films:tx.commit"}).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// CreateFilmsTx inserts, or upserts with OptionUpsert, the films within tx,
+// recording the changes like CreateFilms. The caller commits or rolls back tx
+func (impl *IFilmService) CreateFilmsTx(ctx context.Context, tx *sql.Tx, films []*model.Film, opts ...Option) error {
 	values := make([]string, len(films))
+	args := []interface{}{}
 	for i := 0; i < len(values); i += 1 {
 		f := films[i]
-		values[i] = fmt.Sprintf("(%d, '%s', '%s', '%s', '%s', %d, '%s')",
+		values[i] = "(?, ?, ?, ?, ?, ?, ?, ?, ?)"
+		args = append(args,
 			f.ID,
 			f.CreatedAt.Format("2006-01-02 15:04:05"),
 			f.UpdatedAt.Format("2006-01-02 15:04:05"),
@@ -31,6 +69,8 @@ func (impl *IFilmService) CreateFilms(ctx context.Context, films []*model.Film)
 			f.Title,
 			f.Episode,
 			f.ReleaseDate.Format("2006-01-02"),
+			f.OpeningCrawl,
+			f.Producer,
 		)
 	}
 
@@ -42,16 +82,267 @@ func (impl *IFilmService) CreateFilms(ctx context.Context, films []*model.Film)
 		model.FilmColumns.Title,
 		model.FilmColumns.Episode,
 		model.FilmColumns.ReleaseDate,
+		model.FilmColumns.OpeningCrawl,
+		model.FilmColumns.Producer,
+	}
+	upsert := GetOptionUpsert(opts)
+	query := BuildInsertQuery(model.TableNames.Films, columns, values, upsert)
+
+	ids := make([]int, len(films))
+	for i, f := range films {
+		ids[i] = f.ID
+	}
+
+	before, err := findFilmsByIDs(ctx, tx, ids)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.film.create_films_tx:find_films_by_ids"}).Error(err)
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.film.create_films_tx:tx.exec_context"}).Error(err)
+		return err
+	}
+
+	after, err := findFilmsByIDs(ctx, tx, ids)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.film.create_films_tx:find_films_by_ids"}).Error(err)
+		return err
+	}
+
+	logs := []*model.AuditLog{}
+	events := []dto.Event{}
+	for _, id := range ids {
+		a, ok := after[id]
+		if !ok {
+			continue
+		}
+
+		var log *model.AuditLog
+		var event dto.Event
+		if b, existed := before[id]; !existed {
+			log, err = NewAuditLog(ctx, AUDIT_ENTITY_FILM, id, AUDIT_ACTION_CREATE, nil, a)
+			if err == nil {
+				event, err = NewEvent(EVENT_FILM_CREATED, AUDIT_ENTITY_FILM, id, a)
+			}
+		} else if upsert && !reflect.DeepEqual(b, a) {
+			log, err = NewAuditLog(ctx, AUDIT_ENTITY_FILM, id, AUDIT_ACTION_UPDATE, b, a)
+			if err == nil {
+				event, err = NewEvent(EVENT_FILM_UPDATED, AUDIT_ENTITY_FILM, id, a)
+			}
+		}
+		if err != nil {
+			return err
+		}
+		if log != nil {
+			logs = append(logs, log)
+			events = append(events, event)
+			delete(after, id)
+		}
+	}
+
+	if err := InsertAuditLogs(ctx, tx, logs); err != nil {
+		return err
+	}
+
+	if err := InsertOutboxEvents(ctx, tx, events); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (impl *IFilmService) FindFilmsAndTotal(ctx context.Context, page, size int, opts ...Option) (dto.FindFilmsAndTotalResult, error) {
+	offset := 0
+	if page > 1 {
+		offset = size * (page - 1)
+	}
+
+	tx, err := impl.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.film.find_films_and_total:db.begin_tx"}).Error(err)
+		return dto.FindFilmsAndTotalResult{}, err
+	}
+
+	wheres := GetOptionsWhere(opts)
+	qms := append([]qm.QueryMod{
+		qm.Limit(size + 1),
+		qm.Offset(offset),
+		qm.OrderBy(model.FilmColumns.Episode),
+	}, wheres...)
+
+	films, err := model.Films(qms...).All(ctx, tx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.film.find_films_and_total:films.all"}).Error(err)
+		if err := tx.Rollback(); err != nil {
+			logrus.WithFields(logrus.Fields{"trace": "internal.service.film.find_films_and_total:tx.rollback"}).Error(err)
+			return dto.FindFilmsAndTotalResult{}, err
+		}
+
+		return dto.FindFilmsAndTotalResult{}, err
 	}
 
-	query := fmt.Sprintf("INSERT IGNORE INTO %s (%s) VALUES %s;",
-		model.TableNames.Films, strings.Join(columns, ", "), strings.Join(values, ",\n"))
+	total, err := model.Films(wheres...).Count(ctx, tx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.film.find_films_and_total:films.count"}).Error(err)
+		if err := tx.Rollback(); err != nil {
+			logrus.WithFields(logrus.Fields{"trace": "internal.service.film.find_films_and_total:tx.rollback"}).Error(err)
+			return dto.FindFilmsAndTotalResult{}, err
+		}
+
+		return dto.FindFilmsAndTotalResult{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.film.find_films_and_total:tx.commit"}).Error(err)
+		return dto.FindFilmsAndTotalResult{}, err
+	}
+
+	data := films
+	next := false
+	if len(films) > size {
+		next = true
+		data = films[:size]
+	}
 
-	_, err := impl.DB.ExecContext(ctx, query)
+	return dto.FindFilmsAndTotalResult{
+		Total: total,
+		Count: len(data),
+		Next:  next,
+		Data:  data,
+	}, nil
+}
+
+func (impl *IFilmService) FindFilmByID(ctx context.Context, filmID int) (*model.Film, error) {
+	film, err := model.Films(qm.Where(fmt.Sprintf("%s = ?", model.FilmColumns.ID), filmID)).One(ctx, impl.DB)
 	if err != nil {
-		logrus.WithFields(logrus.Fields{"trace": "internal.service.film.create_films:tx.exec_context"}).Error(err)
+		if err.Error() == "sql: no rows in result set" {
+			return nil, &exception.NotFoundException{
+				Message: fmt.Sprintf("film %d not found", filmID),
+			}
+		}
+
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.film.find_film_by_id:films.one"}).Error(err)
+		return nil, err
+	}
+
+	return film, nil
+}
+
+func (impl *IFilmService) FindFilmsByPlanetIDs(ctx context.Context, planetIDs []int) (map[int][]*model.Film, error) {
+	res, err := findFilmsByPlanetIDs(ctx, impl.DB, planetIDs, nil)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.film.find_films_by_planet_ids:films.bind"}).Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// StreamFilms reads films one row at a time from the database cursor
+func (impl *IFilmService) StreamFilms(ctx context.Context, fn func(film *model.Film) error) error {
+	rows, err := model.Films(
+		qm.Select(
+			model.FilmColumns.ID,
+			model.FilmColumns.CreatedAt,
+			model.FilmColumns.UpdatedAt,
+			model.FilmColumns.Title,
+			model.FilmColumns.Episode,
+			model.FilmColumns.Director,
+			model.FilmColumns.ReleaseDate,
+			model.FilmColumns.OpeningCrawl,
+			model.FilmColumns.Producer,
+		),
+		qm.OrderBy(model.FilmColumns.ID),
+	).QueryContext(ctx, impl.DB)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.film.stream_films:films.query_context"}).Error(err)
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		film := &model.Film{}
+		err := rows.Scan(&film.ID, &film.CreatedAt, &film.UpdatedAt, &film.Title, &film.Episode, &film.Director, &film.ReleaseDate,
+			&film.OpeningCrawl, &film.Producer)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{"trace": "internal.service.film.stream_films:rows.scan"}).Error(err)
+			return err
+		}
+
+		if err := fn(film); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.film.stream_films:rows.err"}).Error(err)
 		return err
 	}
 
 	return nil
 }
+
+func findFilmsByPlanetIDs(ctx context.Context, exec boil.ContextExecutor, planetIDs []int, columns []string) (map[int][]*model.Film, error) {
+	res := map[int][]*model.Film{}
+	if len(planetIDs) == 0 {
+		return res, nil
+	}
+
+	ids := make([]interface{}, len(planetIDs))
+	for i, id := range planetIDs {
+		ids[i] = id
+	}
+
+	selects := []string{fmt.Sprintf("%s.*", model.TableNames.Films)}
+	if len(columns) > 0 {
+		selects = []string{model.FilmTableColumns.ID}
+		for _, c := range columns {
+			if c != model.FilmColumns.ID {
+				selects = append(selects, fmt.Sprintf("%s.%s", model.TableNames.Films, c))
+			}
+		}
+	}
+	selects = append(selects, fmt.Sprintf("%s.planet_id", model.TableNames.PlanetsFilms))
+
+	var rows []*filmWithPlanetID
+	err := model.Films(
+		qm.Select(selects...),
+		qm.InnerJoin(fmt.Sprintf("%s ON %s = %s.film_id", model.TableNames.PlanetsFilms, model.FilmTableColumns.ID, model.TableNames.PlanetsFilms)),
+		qm.WhereIn(fmt.Sprintf("%s.planet_id IN ?", model.TableNames.PlanetsFilms), ids...),
+		qm.OrderBy(model.FilmTableColumns.Episode),
+	).Bind(ctx, exec, &rows)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		film := row.Film
+		res[row.PlanetID] = append(res[row.PlanetID], &film)
+	}
+
+	return res, nil
+}
+
+func findFilmsByIDs(ctx context.Context, exec boil.ContextExecutor, filmIDs []int) (map[int]*model.Film, error) {
+	res := map[int]*model.Film{}
+	if len(filmIDs) == 0 {
+		return res, nil
+	}
+
+	ids := make([]interface{}, len(filmIDs))
+	for i, id := range filmIDs {
+		ids[i] = id
+	}
+
+	films, err := model.Films(qm.WhereIn(fmt.Sprintf("%s IN ?", model.FilmColumns.ID), ids...)).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range films {
+		res[f.ID] = f
+	}
+
+	return res, nil
+}
@@ -8,19 +8,35 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/exception"
 	"github.com/viniosilva/starwars-api/internal/model"
 	"github.com/viniosilva/starwars-api/internal/service"
+	"github.com/volatiletech/null/v8"
 )
 
 func Test_FilmService_CreateFilms(t *testing.T) {
 	var cases = map[string]struct {
-		mocking     func(db sqlmock.Sqlmock)
-		inputFilms  []*model.Film
-		expectedErr error
+		mocking      func(db sqlmock.Sqlmock)
+		inputFilms   []*model.Film
+		inputOptions []service.Option
+		expectedErr  error
 	}{
 		"should create films": {
 			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT `films`.\\* FROM `films` WHERE \\(`id` IN \\(\\?\\)\\)").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{model.FilmColumns.ID}))
 				db.ExpectExec("INSERT IGNORE INTO").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectQuery("SELECT `films`.\\* FROM `films`").
+					WillReturnRows(sqlmock.NewRows([]string{model.FilmColumns.ID, model.FilmColumns.Title}).AddRow(1, "A New Hope, Episode 4"))
+				db.ExpectExec("INSERT INTO audit_log").
+					WithArgs(sqlmock.AnyArg(), "system", nil, "film", 1, "create", nil, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("INSERT INTO outbox").
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), service.EVENT_FILM_CREATED, "film", 1, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectCommit()
 			},
 			inputFilms: []*model.Film{{
 				ID:          1,
@@ -32,9 +48,43 @@ func Test_FilmService_CreateFilms(t *testing.T) {
 			},
 			},
 		},
+		"should upsert films": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT `films`.\\* FROM `films`").
+					WillReturnRows(sqlmock.NewRows([]string{model.FilmColumns.ID, model.FilmColumns.Title}).AddRow(1, "A New Hope, Episode 4"))
+				db.ExpectExec("INSERT INTO films .* ON DUPLICATE KEY UPDATE").
+					WithArgs(1, "2014-12-10 14:23:31", "2014-12-20 19:49:45", "George Lucas", "A New Hope", 4, "1977-05-25", "It is a period of civil war.", "Gary Kurtz, Rick McCallum").
+					WillReturnResult(sqlmock.NewResult(1, 2))
+				db.ExpectQuery("SELECT `films`.\\* FROM `films`").
+					WillReturnRows(sqlmock.NewRows([]string{model.FilmColumns.ID, model.FilmColumns.Title}).AddRow(1, "A New Hope"))
+				db.ExpectExec("INSERT INTO audit_log").
+					WithArgs(sqlmock.AnyArg(), "system", nil, "film", 1, "update", sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("INSERT INTO outbox").
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), service.EVENT_FILM_UPDATED, "film", 1, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectCommit()
+			},
+			inputFilms: []*model.Film{{
+				ID:           1,
+				CreatedAt:    time.Date(2014, 12, 10, 14, 23, 31, 88000, time.UTC),
+				UpdatedAt:    time.Date(2014, 12, 20, 19, 49, 45, 25600, time.UTC),
+				Title:        "A New Hope",
+				Episode:      4,
+				Director:     "George Lucas",
+				ReleaseDate:  time.Date(1977, 05, 25, 0, 0, 0, 0, time.UTC),
+				OpeningCrawl: null.StringFrom("It is a period of civil war."),
+				Producer:     null.StringFrom("Gary Kurtz, Rick McCallum"),
+			}},
+			inputOptions: []service.Option{service.OptionUpsert()},
+		},
 		"should throw error when insert": {
 			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT `films`.\\* FROM `films`").WillReturnRows(sqlmock.NewRows([]string{model.FilmColumns.ID}))
 				db.ExpectExec("INSERT IGNORE INTO").WillReturnError(fmt.Errorf("error"))
+				db.ExpectRollback()
 			},
 			inputFilms: []*model.Film{{
 				ID:          1,
@@ -61,10 +111,274 @@ func Test_FilmService_CreateFilms(t *testing.T) {
 			cs.mocking(mockDB)
 
 			// when
-			err = filmService.CreateFilms(context.Background(), cs.inputFilms)
+			err = filmService.CreateFilms(context.Background(), cs.inputFilms, cs.inputOptions...)
 
 			// then
 			assert.Equal(t, cs.expectedErr, err)
+			assert.Nil(t, mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_FilmService_FindFilmsAndTotal(t *testing.T) {
+	var cases = map[string]struct {
+		mocking      func(db sqlmock.Sqlmock)
+		inputPage    int
+		inputSize    int
+		inputOptions []service.Option
+		expectedRes  dto.FindFilmsAndTotalResult
+		expectedErr  error
+	}{
+		"should return films list": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{model.FilmColumns.ID}).
+					AddRow(1).AddRow(2))
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+				db.ExpectCommit()
+			},
+			inputPage: 1,
+			inputSize: 1,
+			expectedRes: dto.FindFilmsAndTotalResult{
+				Count: 1,
+				Total: 2,
+				Next:  true,
+				Data:  []*model.Film{{ID: 1}},
+			},
+		},
+		"should return films list when filter episode is 4": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{model.FilmColumns.ID}).AddRow(1))
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				db.ExpectCommit()
+			},
+			inputPage:    1,
+			inputSize:    1,
+			inputOptions: []service.Option{service.OptionWhere("episode = ?", 4)},
+			expectedRes: dto.FindFilmsAndTotalResult{
+				Count: 1,
+				Total: 1,
+				Data:  []*model.Film{{ID: 1}},
+			},
+		},
+		"should throw error when begin tx": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin().WillReturnError(fmt.Errorf("error"))
+			},
+			inputPage:   1,
+			inputSize:   1,
+			expectedErr: fmt.Errorf("error"),
+		},
+		"should throw error when films all rollback": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error"))
+				db.ExpectRollback().WillReturnError(fmt.Errorf("error"))
+			},
+			inputPage:   1,
+			inputSize:   1,
+			expectedErr: fmt.Errorf("error"),
+		},
+		"should throw error when films count rollback": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{model.FilmColumns.ID}))
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error"))
+				db.ExpectRollback().WillReturnError(fmt.Errorf("error"))
+			},
+			inputPage:   1,
+			inputSize:   1,
+			expectedErr: fmt.Errorf("error"),
+		},
+		"should throw error when commit": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{model.FilmColumns.ID}))
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				db.ExpectCommit().WillReturnError(fmt.Errorf("error"))
+			},
+			inputPage:   1,
+			inputSize:   1,
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			filmService := service.IFilmService{DB: db}
+
+			cs.mocking(mockDB)
+
+			// when
+			res, err := filmService.FindFilmsAndTotal(context.Background(), cs.inputPage, cs.inputSize, cs.inputOptions...)
+
+			// then
+			assert.Equal(t, cs.expectedRes, res)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func Test_FilmService_FindFilmByID(t *testing.T) {
+	var cases = map[string]struct {
+		mocking      func(db sqlmock.Sqlmock)
+		inputFilmID  int
+		expectedFilm *model.Film
+		expectedErr  error
+	}{
+		"should return film": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{model.FilmColumns.ID}).AddRow(1))
+			},
+			inputFilmID:  1,
+			expectedFilm: &model.Film{ID: 1},
+		},
+		"should throw not found exception": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{model.FilmColumns.ID}))
+			},
+			inputFilmID: 1,
+			expectedErr: &exception.NotFoundException{Message: "film 1 not found"},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			filmService := service.IFilmService{DB: db}
+
+			cs.mocking(mockDB)
+
+			// when
+			film, err := filmService.FindFilmByID(context.Background(), cs.inputFilmID)
+
+			// then
+			assert.Equal(t, cs.expectedFilm, film)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func Test_FilmService_FindFilmsByPlanetIDs(t *testing.T) {
+	var cases = map[string]struct {
+		mocking          func(db sqlmock.Sqlmock)
+		inputPlanetIDs   []int
+		expectedFilms    map[int][]*model.Film
+		expectedErrorMsg string
+	}{
+		"should return films grouped by planet": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{model.FilmColumns.ID, "planet_id"}).
+					AddRow(1, 1).AddRow(3, 1).AddRow(1, 2))
+			},
+			inputPlanetIDs: []int{1, 2},
+			expectedFilms: map[int][]*model.Film{
+				1: {{ID: 1}, {ID: 3}},
+				2: {{ID: 1}},
+			},
+		},
+		"should return empty map when there are no planet ids": {
+			mocking:        func(db sqlmock.Sqlmock) {},
+			inputPlanetIDs: []int{},
+			expectedFilms:  map[int][]*model.Film{},
+		},
+		"should throw error when select": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error"))
+			},
+			inputPlanetIDs:   []int{1},
+			expectedErrorMsg: "bind failed to execute query: error",
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			filmService := service.IFilmService{DB: db}
+
+			cs.mocking(mockDB)
+
+			// when
+			films, err := filmService.FindFilmsByPlanetIDs(context.Background(), cs.inputPlanetIDs)
+
+			// then
+			assert.Equal(t, cs.expectedFilms, films)
+			if cs.expectedErrorMsg != "" {
+				assert.EqualError(t, err, cs.expectedErrorMsg)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func Test_FilmService_StreamFilms(t *testing.T) {
+	var cases = map[string]struct {
+		mocking          func(db sqlmock.Sqlmock)
+		expectedFilms    []*model.Film
+		expectedErrorMsg string
+	}{
+		"should stream films": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{
+					model.FilmColumns.ID, model.FilmColumns.CreatedAt, model.FilmColumns.UpdatedAt, model.FilmColumns.Title,
+					model.FilmColumns.Episode, model.FilmColumns.Director, model.FilmColumns.ReleaseDate,
+					model.FilmColumns.OpeningCrawl, model.FilmColumns.Producer,
+				}).AddRow(1, time.Time{}, time.Time{}, "A New Hope", 4, "George Lucas", time.Time{}, nil, "Gary Kurtz"))
+			},
+			expectedFilms: []*model.Film{{ID: 1, Title: "A New Hope", Episode: 4, Director: "George Lucas", Producer: null.StringFrom("Gary Kurtz")}},
+		},
+		"should throw error when query": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error"))
+			},
+			expectedErrorMsg: "error",
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			filmService := service.IFilmService{DB: db}
+
+			cs.mocking(mockDB)
+
+			// when
+			var films []*model.Film
+			err = filmService.StreamFilms(context.Background(), func(film *model.Film) error {
+				films = append(films, film)
+				return nil
+			})
+
+			// then
+			assert.Equal(t, cs.expectedFilms, films)
+			if cs.expectedErrorMsg != "" {
+				assert.EqualError(t, err, cs.expectedErrorMsg)
+			} else {
+				assert.Nil(t, err)
+			}
 		})
 	}
 }
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"io"
+
+	"github.com/sirupsen/logrus"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/export"
+	"github.com/viniosilva/starwars-api/internal/importer"
+)
+
+const IMPORT_BATCH_SIZE = 500
+
+//go:generate mockgen -destination=../../mock/import_service_mock.go -package=mock . ImportService
+type ImportService interface {
+	ImportPlanets(ctx context.Context, format export.Format, r io.Reader, dryRun bool, opts ...Option) (dto.ImportResult, error)
+	ImportFilms(ctx context.Context, format export.Format, r io.Reader, dryRun bool, opts ...Option) (dto.ImportResult, error)
+}
+
+type IImportService struct {
+	DB            *sql.DB
+	PlanetService PlanetService
+	FilmService   FilmService
+}
+
+// ImportPlanets validates every row and, when all of them are valid and it is
+// not a dry run, inserts the planets in batches of IMPORT_BATCH_SIZE within a
+// single transaction, so either every planet is imported or none is
+func (impl *IImportService) ImportPlanets(ctx context.Context, format export.Format, r io.Reader, dryRun bool, opts ...Option) (dto.ImportResult, error) {
+	planets, rowErrors, err := importer.ReadPlanets(format, r)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.import.import_planets:read_planets"}).Error(err)
+		return dto.ImportResult{}, err
+	}
+
+	res := impl.newResult(len(planets), rowErrors, dryRun, opts)
+	if dryRun || len(rowErrors) > 0 {
+		return res, nil
+	}
+
+	tx, err := impl.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.import.import_planets:db.begin_tx"}).Error(err)
+		return dto.ImportResult{}, err
+	}
+
+	for i := 0; i < len(planets); i += IMPORT_BATCH_SIZE {
+		batch := planets[i:minInt(i+IMPORT_BATCH_SIZE, len(planets))]
+		if err := impl.PlanetService.CreatePlanetsTx(ctx, tx, batch, opts...); err != nil {
+			logrus.WithFields(logrus.Fields{"trace": "internal.service.import.import_planets:create_planets_tx"}).Error(err)
+			rollback(tx, "internal.service.import.import_planets:tx.rollback")
+			return dto.ImportResult{}, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.import.import_planets:tx.commit"}).Error(err)
+		return dto.ImportResult{}, err
+	}
+	res.Imported = len(planets)
+
+	return res, nil
+}
+
+// ImportFilms validates every row and, when all of them are valid and it is
+// not a dry run, inserts the films in batches of IMPORT_BATCH_SIZE within a
+// single transaction, so either every film is imported or none is
+func (impl *IImportService) ImportFilms(ctx context.Context, format export.Format, r io.Reader, dryRun bool, opts ...Option) (dto.ImportResult, error) {
+	films, rowErrors, err := importer.ReadFilms(format, r)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.import.import_films:read_films"}).Error(err)
+		return dto.ImportResult{}, err
+	}
+
+	res := impl.newResult(len(films), rowErrors, dryRun, opts)
+	if dryRun || len(rowErrors) > 0 {
+		return res, nil
+	}
+
+	tx, err := impl.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.import.import_films:db.begin_tx"}).Error(err)
+		return dto.ImportResult{}, err
+	}
+
+	for i := 0; i < len(films); i += IMPORT_BATCH_SIZE {
+		batch := films[i:minInt(i+IMPORT_BATCH_SIZE, len(films))]
+		if err := impl.FilmService.CreateFilmsTx(ctx, tx, batch, opts...); err != nil {
+			logrus.WithFields(logrus.Fields{"trace": "internal.service.import.import_films:create_films_tx"}).Error(err)
+			rollback(tx, "internal.service.import.import_films:tx.rollback")
+			return dto.ImportResult{}, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.import.import_films:tx.commit"}).Error(err)
+		return dto.ImportResult{}, err
+	}
+	res.Imported = len(films)
+
+	return res, nil
+}
+
+func (impl *IImportService) newResult(valid int, rowErrors []dto.ImportRowError, dryRun bool, opts []Option) dto.ImportResult {
+	return dto.ImportResult{
+		Total:  valid + len(rowErrors),
+		Valid:  valid,
+		DryRun: dryRun,
+		Upsert: GetOptionUpsert(opts),
+		Errors: rowErrors,
+	}
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
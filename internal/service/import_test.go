@@ -0,0 +1,187 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/exception"
+	"github.com/viniosilva/starwars-api/internal/export"
+	"github.com/viniosilva/starwars-api/internal/service"
+	"github.com/viniosilva/starwars-api/mock"
+)
+
+func Test_ImportService_ImportPlanets(t *testing.T) {
+	validBody := "id,created_at,updated_at,name,climates,terrains\n" +
+		"1,2014-12-09 13:50:49,2014-12-20 20:58:18,Tatooine,arid,desert\n" +
+		"2,2014-12-10 11:35:48,2014-12-20 20:58:18,Alderaan,temperate,grasslands\n"
+
+	var cases = map[string]struct {
+		mocking      func(mockDB sqlmock.Sqlmock, planetService *mock.MockPlanetService)
+		inputBody    string
+		inputDryRun  bool
+		inputOptions []service.Option
+		expectedRes  dto.ImportResult
+		expectedErr  error
+	}{
+		"should import planets": {
+			mocking: func(mockDB sqlmock.Sqlmock, planetService *mock.MockPlanetService) {
+				mockDB.ExpectBegin()
+				planetService.EXPECT().CreatePlanetsTx(gomock.Any(), gomock.Any(), gomock.Len(2)).Return(nil)
+				mockDB.ExpectCommit()
+			},
+			inputBody:   validBody,
+			expectedRes: dto.ImportResult{Total: 2, Valid: 2, Imported: 2, Errors: []dto.ImportRowError{}},
+		},
+		"should upsert planets": {
+			mocking: func(mockDB sqlmock.Sqlmock, planetService *mock.MockPlanetService) {
+				mockDB.ExpectBegin()
+				planetService.EXPECT().CreatePlanetsTx(gomock.Any(), gomock.Any(), gomock.Len(2), gomock.Any()).Return(nil)
+				mockDB.ExpectCommit()
+			},
+			inputBody:    validBody,
+			inputOptions: []service.Option{service.OptionUpsert()},
+			expectedRes:  dto.ImportResult{Total: 2, Valid: 2, Imported: 2, Upsert: true, Errors: []dto.ImportRowError{}},
+		},
+		"should only validate planets when dry run": {
+			mocking:     func(mockDB sqlmock.Sqlmock, planetService *mock.MockPlanetService) {},
+			inputBody:   validBody,
+			inputDryRun: true,
+			expectedRes: dto.ImportResult{Total: 2, Valid: 2, DryRun: true, Errors: []dto.ImportRowError{}},
+		},
+		"should not import when there are invalid rows": {
+			mocking:   func(mockDB sqlmock.Sqlmock, planetService *mock.MockPlanetService) {},
+			inputBody: validBody + "3,,,,arid,desert\n",
+			expectedRes: dto.ImportResult{Total: 3, Valid: 2, Errors: []dto.ImportRowError{
+				{Line: 4, Error: "name is required"},
+			}},
+		},
+		"should throw validation exception when file is invalid": {
+			mocking:     func(mockDB sqlmock.Sqlmock, planetService *mock.MockPlanetService) {},
+			inputBody:   "",
+			expectedErr: &exception.ValidationException{Message: "invalid csv header"},
+		},
+		"should throw error when begin tx": {
+			mocking: func(mockDB sqlmock.Sqlmock, planetService *mock.MockPlanetService) {
+				mockDB.ExpectBegin().WillReturnError(fmt.Errorf("error"))
+			},
+			inputBody:   validBody,
+			expectedErr: fmt.Errorf("error"),
+		},
+		"should throw error when create planets": {
+			mocking: func(mockDB sqlmock.Sqlmock, planetService *mock.MockPlanetService) {
+				mockDB.ExpectBegin()
+				planetService.EXPECT().CreatePlanetsTx(gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("error"))
+				mockDB.ExpectRollback()
+			},
+			inputBody:   validBody,
+			expectedErr: fmt.Errorf("error"),
+		},
+		"should throw error when commit": {
+			mocking: func(mockDB sqlmock.Sqlmock, planetService *mock.MockPlanetService) {
+				mockDB.ExpectBegin()
+				planetService.EXPECT().CreatePlanetsTx(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockDB.ExpectCommit().WillReturnError(fmt.Errorf("error"))
+			},
+			inputBody:   validBody,
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			mockPlanetService := mock.NewMockPlanetService(ctrl)
+			importService := &service.IImportService{DB: db, PlanetService: mockPlanetService}
+
+			cs.mocking(mockDB, mockPlanetService)
+
+			// when
+			res, err := importService.ImportPlanets(context.Background(), export.FormatCSV, strings.NewReader(cs.inputBody), cs.inputDryRun, cs.inputOptions...)
+
+			// then
+			assert.Equal(t, cs.expectedRes, res)
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Nil(t, mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_ImportService_ImportFilms(t *testing.T) {
+	var films strings.Builder
+	for i := 1; i <= service.IMPORT_BATCH_SIZE+1; i += 1 {
+		films.WriteString(fmt.Sprintf(`{"id":%d,"title":"Film %d","episode":1,"director":"George Lucas","release_date":"1977-05-25"}`+"\n", i, i))
+	}
+
+	var cases = map[string]struct {
+		mocking     func(mockDB sqlmock.Sqlmock, filmService *mock.MockFilmService)
+		inputBody   string
+		expectedRes dto.ImportResult
+		expectedErr error
+	}{
+		"should import films in batches": {
+			mocking: func(mockDB sqlmock.Sqlmock, filmService *mock.MockFilmService) {
+				mockDB.ExpectBegin()
+				filmService.EXPECT().CreateFilmsTx(gomock.Any(), gomock.Any(), gomock.Len(service.IMPORT_BATCH_SIZE)).Return(nil)
+				filmService.EXPECT().CreateFilmsTx(gomock.Any(), gomock.Any(), gomock.Len(1)).Return(nil)
+				mockDB.ExpectCommit()
+			},
+			inputBody: films.String(),
+			expectedRes: dto.ImportResult{
+				Total:    service.IMPORT_BATCH_SIZE + 1,
+				Valid:    service.IMPORT_BATCH_SIZE + 1,
+				Imported: service.IMPORT_BATCH_SIZE + 1,
+				Errors:   []dto.ImportRowError{},
+			},
+		},
+		"should roll back every batch when create films": {
+			mocking: func(mockDB sqlmock.Sqlmock, filmService *mock.MockFilmService) {
+				mockDB.ExpectBegin()
+				filmService.EXPECT().CreateFilmsTx(gomock.Any(), gomock.Any(), gomock.Len(service.IMPORT_BATCH_SIZE)).Return(nil)
+				filmService.EXPECT().CreateFilmsTx(gomock.Any(), gomock.Any(), gomock.Len(1)).Return(fmt.Errorf("error"))
+				mockDB.ExpectRollback()
+			},
+			inputBody:   films.String(),
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			mockFilmService := mock.NewMockFilmService(ctrl)
+			importService := &service.IImportService{DB: db, FilmService: mockFilmService}
+
+			cs.mocking(mockDB, mockFilmService)
+
+			// when
+			res, err := importService.ImportFilms(context.Background(), export.FormatNDJSON, strings.NewReader(cs.inputBody), false)
+
+			// then
+			assert.Equal(t, cs.expectedRes, res)
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Nil(t, mockDB.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BuildInsertQuery returns a batched INSERT IGNORE for the given placeholders,
+// or an INSERT ... ON DUPLICATE KEY UPDATE of every column when upsert is set
+func BuildInsertQuery(table string, columns, values []string, upsert bool) string {
+	if !upsert {
+		return fmt.Sprintf("INSERT IGNORE INTO %s (%s) VALUES %s;",
+			table, strings.Join(columns, ", "), strings.Join(values, ",\n"))
+	}
+
+	updates := make([]string, len(columns))
+	for i, c := range columns {
+		updates[i] = fmt.Sprintf("%s = VALUES(%s)", c, c)
+	}
+
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES %s ON DUPLICATE KEY UPDATE %s;",
+		table, strings.Join(columns, ", "), strings.Join(values, ",\n"), strings.Join(updates, ", "))
+}
@@ -0,0 +1,32 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/service"
+)
+
+func Test_InsertService_BuildInsertQuery(t *testing.T) {
+	var cases = map[string]struct {
+		inputUpsert   bool
+		expectedQuery string
+	}{
+		"should build insert ignore query": {
+			expectedQuery: "INSERT IGNORE INTO planets (id, name) VALUES (?, ?),\n(?, ?);",
+		},
+		"should build upsert query": {
+			inputUpsert:   true,
+			expectedQuery: "INSERT INTO planets (id, name) VALUES (?, ?),\n(?, ?) ON DUPLICATE KEY UPDATE id = VALUES(id), name = VALUES(name);",
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// when
+			query := service.BuildInsertQuery("planets", []string{"id", "name"}, []string{"(?, ?)", "(?, ?)"}, cs.inputUpsert)
+
+			// then
+			assert.Equal(t, cs.expectedQuery, query)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+)
+
+//go:generate mockgen -destination=../../mock/lock_service_mock.go -package=mock . LockService
+type LockService interface {
+	WithLock(ctx context.Context, name string, fn func(ctx context.Context) error) (bool, error)
+}
+
+type ILockService struct {
+	DB *sql.DB
+}
+
+// WithLock runs fn holding the MySQL named lock, shared by every instance
+// connected to the database. It returns false without running fn when the
+// lock is held elsewhere. The lock belongs to a single connection, so it is
+// taken and released on a connection reserved for fn
+func (impl *ILockService) WithLock(ctx context.Context, name string, fn func(ctx context.Context) error) (bool, error) {
+	conn, err := impl.DB.Conn(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.lock.with_lock:db.conn"}).Error(err)
+		return false, err
+	}
+	defer conn.Close()
+
+	var acquired sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, 0);", name).Scan(&acquired); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.lock.with_lock:get_lock"}).Error(err)
+		return false, err
+	}
+	if !acquired.Valid {
+		err := fmt.Errorf("unable to get lock %s", name)
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.lock.with_lock:get_lock"}).Error(err)
+		return false, err
+	}
+	if acquired.Int64 == 0 {
+		return false, nil
+	}
+
+	defer func() {
+		if _, err := conn.ExecContext(context.Background(), "DO RELEASE_LOCK(?);", name); err != nil {
+			logrus.WithFields(logrus.Fields{"trace": "internal.service.lock.with_lock:release_lock"}).Error(err)
+		}
+	}()
+
+	return true, fn(ctx)
+}
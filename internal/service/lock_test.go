@@ -0,0 +1,90 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/service"
+)
+
+func Test_LockService_WithLock(t *testing.T) {
+	var cases = map[string]struct {
+		mocking          func(db sqlmock.Sqlmock)
+		inputFnErr       error
+		expectedAcquired bool
+		expectedCalled   bool
+		expectedErrorMsg string
+	}{
+		"should run fn holding the lock": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT GET_LOCK\\(\\?, 0\\);").WithArgs("sync").
+					WillReturnRows(sqlmock.NewRows([]string{"lock"}).AddRow(1))
+				db.ExpectExec("DO RELEASE_LOCK\\(\\?\\);").WithArgs("sync").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectedAcquired: true,
+			expectedCalled:   true,
+		},
+		"should release the lock when fn fails": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT GET_LOCK\\(\\?, 0\\);").WithArgs("sync").
+					WillReturnRows(sqlmock.NewRows([]string{"lock"}).AddRow(1))
+				db.ExpectExec("DO RELEASE_LOCK\\(\\?\\);").WithArgs("sync").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			inputFnErr:       fmt.Errorf("error"),
+			expectedAcquired: true,
+			expectedCalled:   true,
+			expectedErrorMsg: "error",
+		},
+		"should not run fn when lock is held elsewhere": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT GET_LOCK\\(\\?, 0\\);").WithArgs("sync").
+					WillReturnRows(sqlmock.NewRows([]string{"lock"}).AddRow(0))
+			},
+		},
+		"should throw error when get lock returns null": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT GET_LOCK\\(\\?, 0\\);").WithArgs("sync").
+					WillReturnRows(sqlmock.NewRows([]string{"lock"}).AddRow(nil))
+			},
+			expectedErrorMsg: "unable to get lock sync",
+		},
+		"should throw error when get lock": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT GET_LOCK\\(\\?, 0\\);").WithArgs("sync").WillReturnError(fmt.Errorf("error"))
+			},
+			expectedErrorMsg: "error",
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			lockService := service.ILockService{DB: db}
+
+			cs.mocking(mockDB)
+
+			// when
+			called := false
+			acquired, err := lockService.WithLock(context.Background(), "sync", func(ctx context.Context) error {
+				called = true
+				return cs.inputFnErr
+			})
+
+			// then
+			assert.Equal(t, cs.expectedAcquired, acquired)
+			assert.Equal(t, cs.expectedCalled, called)
+			if err != nil || cs.expectedErrorMsg != "" {
+				assert.EqualError(t, err, cs.expectedErrorMsg)
+			}
+			assert.Nil(t, mockDB.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+const LOOKUP_NAME_LENGTH = 100
+
+//go:generate mockgen -destination=../../mock/lookup_service_mock.go -package=mock . LookupService
+type LookupService interface {
+	FindClimates(ctx context.Context) ([]dto.LookupDto, error)
+	FindTerrains(ctx context.Context) ([]dto.LookupDto, error)
+}
+
+type ILookupService struct {
+	DB *sql.DB
+}
+
+// planetLookup describes a lookup table normalizing one of the JSON array
+// columns of the planets table
+type planetLookup struct {
+	table        string
+	joinTable    string
+	joinColumn   string
+	planetColumn string
+}
+
+var (
+	climateLookup = planetLookup{
+		table:        model.TableNames.Climates,
+		joinTable:    model.TableNames.PlanetsClimates,
+		joinColumn:   "climate_id",
+		planetColumn: model.PlanetTableColumns.Climates,
+	}
+	terrainLookup = planetLookup{
+		table:        model.TableNames.Terrains,
+		joinTable:    model.TableNames.PlanetsTerrains,
+		joinColumn:   "terrain_id",
+		planetColumn: model.PlanetTableColumns.Terrains,
+	}
+)
+
+func (impl *ILookupService) FindClimates(ctx context.Context) ([]dto.LookupDto, error) {
+	rows := []dto.LookupDto{}
+	if err := model.Climates(findLookupQueryMods(climateLookup)...).Bind(ctx, impl.DB, &rows); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.lookup.find_climates:climates.bind"}).Error(err)
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func (impl *ILookupService) FindTerrains(ctx context.Context) ([]dto.LookupDto, error) {
+	rows := []dto.LookupDto{}
+	if err := model.Terrains(findLookupQueryMods(terrainLookup)...).Bind(ctx, impl.DB, &rows); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.lookup.find_terrains:terrains.bind"}).Error(err)
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// findLookupQueryMods lists the lookup values with how many planets, not
+// deleted, reference each of them
+func findLookupQueryMods(lookup planetLookup) []qm.QueryMod {
+	return []qm.QueryMod{
+		qm.Select(fmt.Sprintf("%s.id AS id", lookup.table), fmt.Sprintf("%s.name AS name", lookup.table), fmt.Sprintf("COUNT(%s) AS planets", model.PlanetTableColumns.ID)),
+		qm.LeftOuterJoin(fmt.Sprintf("%s ON %s.%s = %s.id", lookup.joinTable, lookup.joinTable, lookup.joinColumn, lookup.table)),
+		qm.LeftOuterJoin(fmt.Sprintf("%s ON %s = %s.planet_id AND %s IS NULL",
+			model.TableNames.Planets, model.PlanetTableColumns.ID, lookup.joinTable, model.PlanetTableColumns.DeletedAt)),
+		qm.GroupBy(fmt.Sprintf("%s.id, %s.name", lookup.table, lookup.table)),
+		qm.OrderBy(fmt.Sprintf("%s.name", lookup.table)),
+	}
+}
+
+// OptionWhereClimate filters the planets having the climate through the
+// indexed join table
+func OptionWhereClimate(name string) Option {
+	return OptionWhere(lookupWhere(climateLookup), name)
+}
+
+// OptionWhereTerrain filters the planets having the terrain through the
+// indexed join table
+func OptionWhereTerrain(name string) Option {
+	return OptionWhere(lookupWhere(terrainLookup), name)
+}
+
+func lookupWhere(lookup planetLookup) string {
+	return fmt.Sprintf("%s IN (SELECT %s.planet_id FROM %s INNER JOIN %s ON %s.id = %s.%s WHERE %s.name = ?)",
+		model.PlanetTableColumns.ID, lookup.joinTable, lookup.joinTable, lookup.table, lookup.table, lookup.joinTable, lookup.joinColumn, lookup.table)
+}
+
+// SyncPlanetLookups rebuilds the climates and terrains of the given planets
+// from their JSON columns, creating the values not seen before
+func SyncPlanetLookups(ctx context.Context, exec boil.ContextExecutor, planetIDs []int) error {
+	if len(planetIDs) == 0 {
+		return nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(planetIDs)), ", ")
+	args := make([]interface{}, len(planetIDs))
+	for i, id := range planetIDs {
+		args[i] = id
+	}
+
+	for _, lookup := range []planetLookup{climateLookup, terrainLookup} {
+		jsonTable := fmt.Sprintf("JSON_TABLE(%s, '$[*]' COLUMNS (name varchar(%d) PATH '$')) AS jt", lookup.planetColumn, LOOKUP_NAME_LENGTH)
+		queries := []string{
+			fmt.Sprintf("INSERT IGNORE INTO %s (name) SELECT DISTINCT jt.name FROM %s INNER JOIN %s WHERE %s IN (%s) AND jt.name <> '';",
+				lookup.table, model.TableNames.Planets, jsonTable, model.PlanetTableColumns.ID, placeholders),
+			fmt.Sprintf("DELETE FROM %s WHERE planet_id IN (%s);",
+				lookup.joinTable, placeholders),
+			fmt.Sprintf("INSERT IGNORE INTO %s (planet_id, %s) SELECT %s, %s.id FROM %s INNER JOIN %s INNER JOIN %s ON %s.name = jt.name WHERE %s IN (%s);",
+				lookup.joinTable, lookup.joinColumn, model.PlanetTableColumns.ID, lookup.table, model.TableNames.Planets, jsonTable,
+				lookup.table, lookup.table, model.PlanetTableColumns.ID, placeholders),
+		}
+
+		for _, query := range queries {
+			if _, err := exec.ExecContext(ctx, query, args...); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
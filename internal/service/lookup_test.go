@@ -0,0 +1,149 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/service"
+)
+
+func Test_LookupService_FindClimates(t *testing.T) {
+	var cases = map[string]struct {
+		mocking          func(db sqlmock.Sqlmock)
+		expectedClimates []dto.LookupDto
+		expectedErr      error
+	}{
+		"should find climates with planet counts": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT climates.id AS id, climates.name AS name, COUNT\\(planets.id\\) AS planets FROM `climates` LEFT JOIN planets_climates ON planets_climates.climate_id = climates.id LEFT JOIN planets ON planets.id = planets_climates.planet_id AND planets.deleted_at IS NULL GROUP BY climates.id, climates.name ORDER BY climates.name;").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "planets"}).
+						AddRow(1, "arid", 3).
+						AddRow(2, "frozen", 0))
+			},
+			expectedClimates: []dto.LookupDto{
+				{ID: 1, Name: "arid", Planets: 3},
+				{ID: 2, Name: "frozen", Planets: 0},
+			},
+		},
+		"should throw error when bind": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("bind failed to execute query: error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			lookupService := &service.ILookupService{DB: db}
+
+			cs.mocking(mockDB)
+
+			// when
+			climates, err := lookupService.FindClimates(context.Background())
+
+			// then
+			assert.Equal(t, cs.expectedClimates, climates)
+			if cs.expectedErr != nil {
+				assert.EqualError(t, err, cs.expectedErr.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Nil(t, mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_LookupService_FindTerrains(t *testing.T) {
+	// given
+	db, mockDB, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	lookupService := &service.ILookupService{DB: db}
+
+	mockDB.ExpectQuery("SELECT terrains.id AS id, terrains.name AS name, COUNT\\(planets.id\\) AS planets FROM `terrains` LEFT JOIN planets_terrains ON planets_terrains.terrain_id = terrains.id").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "planets"}).AddRow(1, "desert", 2))
+
+	// when
+	terrains, err := lookupService.FindTerrains(context.Background())
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, []dto.LookupDto{{ID: 1, Name: "desert", Planets: 2}}, terrains)
+	assert.Nil(t, mockDB.ExpectationsWereMet())
+}
+
+func Test_LookupService_SyncPlanetLookups(t *testing.T) {
+	var cases = map[string]struct {
+		mocking        func(db sqlmock.Sqlmock)
+		inputPlanetIDs []int
+		expectedErr    error
+	}{
+		"should sync climates and terrains": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectExec("INSERT IGNORE INTO climates \\(name\\) SELECT DISTINCT jt.name FROM planets INNER JOIN JSON_TABLE\\(planets.climates, '\\$\\[\\*\\]' COLUMNS \\(name varchar\\(100\\) PATH '\\$'\\)\\) AS jt WHERE planets.id IN \\(\\?, \\?\\) AND jt.name <> '';").
+					WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(1, 2))
+				db.ExpectExec("DELETE FROM planets_climates WHERE planet_id IN \\(\\?, \\?\\);").
+					WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+				db.ExpectExec("INSERT IGNORE INTO planets_climates \\(planet_id, climate_id\\) SELECT planets.id, climates.id FROM planets INNER JOIN JSON_TABLE\\(planets.climates, '\\$\\[\\*\\]' COLUMNS \\(name varchar\\(100\\) PATH '\\$'\\)\\) AS jt INNER JOIN climates ON climates.name = jt.name WHERE planets.id IN \\(\\?, \\?\\);").
+					WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(0, 3))
+				db.ExpectExec("INSERT IGNORE INTO terrains").WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("DELETE FROM planets_terrains").WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+				db.ExpectExec("INSERT IGNORE INTO planets_terrains").WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+			inputPlanetIDs: []int{1, 2},
+		},
+		"should do nothing when there are no planets": {
+			mocking:        func(db sqlmock.Sqlmock) {},
+			inputPlanetIDs: []int{},
+		},
+		"should throw error when exec": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectExec("INSERT IGNORE INTO climates").WillReturnError(fmt.Errorf("error"))
+			},
+			inputPlanetIDs: []int{1},
+			expectedErr:    fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			cs.mocking(mockDB)
+
+			// when
+			err = service.SyncPlanetLookups(context.Background(), db, cs.inputPlanetIDs)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Nil(t, mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_LookupService_OptionWhereClimate(t *testing.T) {
+	// when
+	query, arg := service.GetOptionWhere([]service.Option{service.OptionWhereClimate("arid")})
+
+	// then
+	assert.Equal(t, "planets.id IN (SELECT planets_climates.planet_id FROM planets_climates INNER JOIN climates ON climates.id = planets_climates.climate_id WHERE climates.name = ?)", query)
+	assert.Equal(t, "arid", arg)
+}
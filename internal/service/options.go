@@ -1,8 +1,24 @@
 package service
 
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
 type option string
 
-const whereOption option = "where"
+const (
+	whereOption       option = "where"
+	selectOption      option = "select"
+	filmsSelectOption option = "films_select"
+	upsertOption      option = "upsert"
+	asOfOption        option = "as_of"
+	withDeletedOption option = "with_deleted"
+)
 
 type Option interface {
 	name() string
@@ -30,6 +46,72 @@ func OptionWhere(query string, arg interface{}) Option {
 	}
 }
 
+// OptionsWhereRange turns the <attribute>Min and <attribute>Max bounds of the
+// attributes in dto.PlanetRangeFilters into inclusive where options, sorted by
+// bound name so the REST and GraphQL planet lists build the same query
+func OptionsWhereRange(bounds map[string]float64) []Option {
+	params := make([]string, 0, len(dto.PlanetRangeFilters))
+	for param := range dto.PlanetRangeFilters {
+		params = append(params, param)
+	}
+	sort.Strings(params)
+
+	opts := []Option{}
+	for _, param := range params {
+		column := dto.PlanetRangeFilters[param]
+		for _, bound := range [][2]string{{"Min", ">="}, {"Max", "<="}} {
+			suffix, operator := bound[0], bound[1]
+			if value, ok := bounds[param+suffix]; ok {
+				opts = append(opts, OptionWhere(fmt.Sprintf("%s %s ?", column, operator), value))
+			}
+		}
+	}
+
+	return opts
+}
+
+// OptionSelect restricts the planet columns read from the database
+func OptionSelect(columns ...string) Option {
+	return &iOption{
+		Name:  string(selectOption),
+		Value: columns,
+	}
+}
+
+// OptionFilmsSelect restricts the film columns read when films are loaded
+func OptionFilmsSelect(columns ...string) Option {
+	return &iOption{
+		Name:  string(filmsSelectOption),
+		Value: columns,
+	}
+}
+
+// OptionUpsert makes batched inserts update rows whose primary key already exists
+// instead of ignoring them
+func OptionUpsert() Option {
+	return &iOption{
+		Name:  string(upsertOption),
+		Value: true,
+	}
+}
+
+// OptionAsOf reads the planet version valid at the given time instead of the current one
+func OptionAsOf(asOf time.Time) Option {
+	return &iOption{
+		Name:  string(asOfOption),
+		Value: asOf,
+	}
+}
+
+// OptionWithDeleted makes streams also read the deleted planets, along with
+// their deletion time
+func OptionWithDeleted() Option {
+	return &iOption{
+		Name:  string(withDeletedOption),
+		Value: true,
+	}
+}
+
 func GetOptionWhere(opts []Option) (string, interface{}) {
 	for _, opt := range opts {
 		if opt != nil && opt.name() == string(whereOption) {
@@ -40,3 +122,63 @@ func GetOptionWhere(opts []Option) (string, interface{}) {
 
 	return "", ""
 }
+
+func GetOptionsWhere(opts []Option) []qm.QueryMod {
+	qms := []qm.QueryMod{}
+	for _, opt := range opts {
+		if opt != nil && opt.name() == string(whereOption) {
+			v := opt.value().([]interface{})
+			qms = append(qms, qm.And(v[0].(string), v[1]))
+		}
+	}
+
+	return qms
+}
+
+func GetOptionSelect(opts []Option) []string {
+	return getOptionColumns(opts, selectOption)
+}
+
+func GetOptionFilmsSelect(opts []Option) []string {
+	return getOptionColumns(opts, filmsSelectOption)
+}
+
+func getOptionColumns(opts []Option, name option) []string {
+	for _, opt := range opts {
+		if opt != nil && opt.name() == string(name) {
+			return opt.value().([]string)
+		}
+	}
+
+	return nil
+}
+
+func GetOptionUpsert(opts []Option) bool {
+	for _, opt := range opts {
+		if opt != nil && opt.name() == string(upsertOption) {
+			return opt.value().(bool)
+		}
+	}
+
+	return false
+}
+
+func GetOptionAsOf(opts []Option) (time.Time, bool) {
+	for _, opt := range opts {
+		if opt != nil && opt.name() == string(asOfOption) {
+			return opt.value().(time.Time), true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func GetOptionWithDeleted(opts []Option) bool {
+	for _, opt := range opts {
+		if opt != nil && opt.name() == string(withDeletedOption) {
+			return opt.value().(bool)
+		}
+	}
+
+	return false
+}
@@ -2,6 +2,7 @@ package service_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/viniosilva/starwars-api/internal/service"
@@ -35,3 +36,111 @@ func Test_OptionService_GetOptionWhere(t *testing.T) {
 		})
 	}
 }
+
+func Test_OptionService_GetOptionsWhere(t *testing.T) {
+	var cases = map[string]struct {
+		inputOptions   []service.Option
+		expectedLength int
+	}{
+		"should return every where option": {
+			inputOptions:   []service.Option{service.OptionWhere("name like ?", "test"), service.OptionWhere("episode = ?", 4)},
+			expectedLength: 2,
+		},
+		"should ignore nil options": {
+			inputOptions:   []service.Option{nil, service.OptionWhere("name like ?", "test")},
+			expectedLength: 1,
+		},
+		"should return empty list when option not exist": {
+			inputOptions:   []service.Option{},
+			expectedLength: 0,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// when
+			qms := service.GetOptionsWhere(cs.inputOptions)
+
+			// then
+			assert.Len(t, qms, cs.expectedLength)
+		})
+	}
+}
+
+func Test_OptionService_GetOptionSelect(t *testing.T) {
+	var cases = map[string]struct {
+		inputOptions         []service.Option
+		expectedColumns      []string
+		expectedFilmsColumns []string
+	}{
+		"should return selected columns": {
+			inputOptions:         []service.Option{nil, service.OptionSelect("name"), service.OptionFilmsSelect("title", "episode")},
+			expectedColumns:      []string{"name"},
+			expectedFilmsColumns: []string{"title", "episode"},
+		},
+		"should return nil when option not exist": {
+			inputOptions: []service.Option{service.OptionWhere("name like ?", "test")},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// when
+			columns := service.GetOptionSelect(cs.inputOptions)
+			filmsColumns := service.GetOptionFilmsSelect(cs.inputOptions)
+
+			// then
+			assert.Equal(t, cs.expectedColumns, columns)
+			assert.Equal(t, cs.expectedFilmsColumns, filmsColumns)
+		})
+	}
+}
+
+func Test_OptionService_GetOptionUpsert(t *testing.T) {
+	var cases = map[string]struct {
+		inputOptions   []service.Option
+		expectedUpsert bool
+	}{
+		"should return true when upsert option exists": {
+			inputOptions:   []service.Option{nil, service.OptionUpsert()},
+			expectedUpsert: true,
+		},
+		"should return false when option not exist": {
+			inputOptions: []service.Option{service.OptionWhere("name like ?", "test")},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// when
+			upsert := service.GetOptionUpsert(cs.inputOptions)
+
+			// then
+			assert.Equal(t, cs.expectedUpsert, upsert)
+		})
+	}
+}
+
+func Test_OptionService_GetOptionAsOf(t *testing.T) {
+	var cases = map[string]struct {
+		inputOptions []service.Option
+		expectedAsOf time.Time
+		expectedOk   bool
+	}{
+		"should return as of time when option exists": {
+			inputOptions: []service.Option{nil, service.OptionAsOf(time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC))},
+			expectedAsOf: time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC),
+			expectedOk:   true,
+		},
+		"should return false when option not exist": {
+			inputOptions: []service.Option{service.OptionWhere("name like ?", "test")},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// when
+			asOf, ok := service.GetOptionAsOf(cs.inputOptions)
+
+			// then
+			assert.Equal(t, cs.expectedAsOf, asOf)
+			assert.Equal(t, cs.expectedOk, ok)
+		})
+	}
+}
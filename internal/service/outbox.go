@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// OUTBOX_CLAIM_LEASE is how long a relay holds the events it claimed. An
+// event not published by then, as when the relay stops, is claimed again
+const OUTBOX_CLAIM_LEASE = 5 * time.Minute
+
+//go:generate mockgen -destination=../../mock/outbox_service_mock.go -package=mock . OutboxService
+type OutboxService interface {
+	RelayEvents(ctx context.Context, limit int, sink EventPublisher) (int, error)
+	PurgePublishedEvents(ctx context.Context, before time.Time) (int64, error)
+	FindEventsAfter(ctx context.Context, afterID int64, limit int) ([]*model.Outbox, error)
+	FindLastEventID(ctx context.Context) (int64, error)
+}
+
+type IOutboxService struct {
+	DB *sql.DB
+}
+
+// RelayEvents publishes the oldest unpublished events to sink in the order
+// they were written. The events are first claimed for OUTBOX_CLAIM_LEASE in a
+// short transaction, so no row lock is held while sink publishes them, and
+// the events of an entity with a claimed event wait for it, so concurrent
+// relays never publish out of order. When an event fails, the next events of
+// the same entity are released for the following relay
+func (impl *IOutboxService) RelayEvents(ctx context.Context, limit int, sink EventPublisher) (int, error) {
+	rows, err := impl.claimEvents(ctx, limit, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+
+	failed := map[string]bool{}
+	published := model.OutboxSlice{}
+	released := model.OutboxSlice{}
+	for _, row := range rows {
+		key := fmt.Sprintf("%s:%d", row.Entity, row.EntityID)
+		if failed[key] {
+			released = append(released, row)
+			continue
+		}
+
+		if err := sink.Publish(ctx, ParseOutboxEvent(row)); err != nil {
+			logrus.WithFields(logrus.Fields{"trace": "internal.service.outbox.relay_events:sink.publish"}).Error(err)
+			failed[key] = true
+			released = append(released, row)
+			continue
+		}
+		published = append(published, row)
+	}
+
+	if len(published) > 0 {
+		_, err = published.UpdateAll(ctx, impl.DB, model.M{model.OutboxColumns.PublishedAt: time.Now()})
+		if err != nil {
+			logrus.WithFields(logrus.Fields{"trace": "internal.service.outbox.relay_events:outboxes.update_all"}).Error(err)
+			return 0, err
+		}
+	}
+
+	if len(released) > 0 {
+		if _, err = released.UpdateAll(ctx, impl.DB, model.M{model.OutboxColumns.ClaimedUntil: nil}); err != nil {
+			logrus.WithFields(logrus.Fields{"trace": "internal.service.outbox.relay_events:outboxes.update_all"}).Error(err)
+			return 0, err
+		}
+	}
+
+	return len(published), nil
+}
+
+// claimEvents locks the oldest unpublished events just long enough to claim
+// the ones not claimed yet, leaving out the entities with an event claimed by
+// another relay
+func (impl *IOutboxService) claimEvents(ctx context.Context, limit int, now time.Time) (model.OutboxSlice, error) {
+	tx, err := impl.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.outbox.claim_events:db.begin_tx"}).Error(err)
+		return nil, err
+	}
+
+	rows, err := model.Outboxes(
+		qm.Where(fmt.Sprintf("%s IS NULL", model.OutboxColumns.PublishedAt)),
+		qm.OrderBy(model.OutboxColumns.ID),
+		qm.Limit(limit),
+		qm.For("UPDATE"),
+	).All(ctx, tx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.outbox.claim_events:outboxes.all"}).Error(err)
+		rollback(tx, "internal.service.outbox.claim_events:tx.rollback")
+		return nil, err
+	}
+
+	claimedElsewhere := map[string]bool{}
+	claimed := model.OutboxSlice{}
+	for _, row := range rows {
+		key := fmt.Sprintf("%s:%d", row.Entity, row.EntityID)
+		if row.ClaimedUntil.Valid && row.ClaimedUntil.Time.After(now) {
+			claimedElsewhere[key] = true
+			continue
+		}
+		if claimedElsewhere[key] {
+			continue
+		}
+		claimed = append(claimed, row)
+	}
+
+	if len(claimed) > 0 {
+		_, err = claimed.UpdateAll(ctx, tx, model.M{model.OutboxColumns.ClaimedUntil: now.Add(OUTBOX_CLAIM_LEASE)})
+		if err != nil {
+			logrus.WithFields(logrus.Fields{"trace": "internal.service.outbox.claim_events:outboxes.update_all"}).Error(err)
+			rollback(tx, "internal.service.outbox.claim_events:tx.rollback")
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.outbox.claim_events:tx.commit"}).Error(err)
+		return nil, err
+	}
+
+	return claimed, nil
+}
+
+func (impl *IOutboxService) PurgePublishedEvents(ctx context.Context, before time.Time) (int64, error) {
+	rows, err := model.Outboxes(
+		qm.Where(fmt.Sprintf("%s < ?", model.OutboxColumns.PublishedAt), before),
+	).DeleteAll(ctx, impl.DB)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.outbox.purge_published_events:outboxes.delete_all"}).Error(err)
+		return 0, err
+	}
+
+	return rows, nil
+}
+
+// FindEventsAfter reads, without locking, the events written after afterID,
+// published or not, in the order of their ids
+func (impl *IOutboxService) FindEventsAfter(ctx context.Context, afterID int64, limit int) ([]*model.Outbox, error) {
+	rows, err := model.Outboxes(
+		qm.Where(fmt.Sprintf("%s > ?", model.OutboxColumns.ID), afterID),
+		qm.OrderBy(model.OutboxColumns.ID),
+		qm.Limit(limit),
+	).All(ctx, impl.DB)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.outbox.find_events_after:outboxes.all"}).Error(err)
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// FindLastEventID returns the id of the last event written, or 0 when the
+// outbox is empty
+func (impl *IOutboxService) FindLastEventID(ctx context.Context) (int64, error) {
+	var id sql.NullInt64
+	query := fmt.Sprintf("SELECT MAX(%s) FROM %s;", model.OutboxColumns.ID, model.TableNames.Outbox)
+	if err := impl.DB.QueryRowContext(ctx, query).Scan(&id); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.outbox.find_last_event_id:db.query_row_context"}).Error(err)
+		return 0, err
+	}
+
+	return id.Int64, nil
+}
+
+// InsertOutboxEvents writes the events of a mutation in its own transaction,
+// so they are relayed if and only if the mutation is committed
+func InsertOutboxEvents(ctx context.Context, exec boil.ContextExecutor, events []dto.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	values := make([]string, len(events))
+	args := []interface{}{}
+	for i := 0; i < len(values); i += 1 {
+		e := events[i]
+		values[i] = "(?, ?, ?, ?, ?, ?)"
+		args = append(args,
+			e.OccurredAt.Format("2006-01-02 15:04:05"),
+			e.ID,
+			e.Type,
+			e.Entity,
+			e.EntityID,
+			string(e.Data),
+		)
+	}
+
+	columns := []string{
+		model.OutboxColumns.CreatedAt,
+		model.OutboxColumns.EventID,
+		model.OutboxColumns.Event,
+		model.OutboxColumns.Entity,
+		model.OutboxColumns.EntityID,
+		model.OutboxColumns.Data,
+	}
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES %s;",
+		model.TableNames.Outbox, strings.Join(columns, ", "), strings.Join(values, ",\n"))
+
+	if _, err := exec.ExecContext(ctx, query, args...); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.outbox.insert_outbox_events:exec.exec_context"}).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func ParseOutboxEvent(row *model.Outbox) dto.Event {
+	return dto.Event{
+		ID:         row.EventID,
+		Type:       row.Event,
+		Entity:     row.Entity,
+		EntityID:   row.EntityID,
+		OccurredAt: row.CreatedAt.UTC(),
+		Data:       []byte(row.Data),
+	}
+}
@@ -0,0 +1,309 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/viniosilva/starwars-api/internal/service"
+	"github.com/viniosilva/starwars-api/mock"
+)
+
+func Test_OutboxService_RelayEvents(t *testing.T) {
+	outboxColumns := []string{
+		model.OutboxColumns.ID,
+		model.OutboxColumns.EventID,
+		model.OutboxColumns.Event,
+		model.OutboxColumns.Entity,
+		model.OutboxColumns.EntityID,
+		model.OutboxColumns.Data,
+		model.OutboxColumns.ClaimedUntil,
+	}
+
+	var cases = map[string]struct {
+		mocking           func(db sqlmock.Sqlmock)
+		mockingSink       func(sink *mock.MockEventPublisher)
+		expectedPublished int
+		expectedErr       error
+	}{
+		"should publish events in order": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT `outbox`.\\* FROM `outbox` WHERE \\(published_at IS NULL\\) ORDER BY id LIMIT 10 FOR UPDATE").
+					WillReturnRows(sqlmock.NewRows(outboxColumns).
+						AddRow(1, "event-1", service.EVENT_PLANET_CREATED, "planet", 1, []byte(`{}`), nil).
+						AddRow(2, "event-2", service.EVENT_PLANET_DELETED, "planet", 1, []byte(`{}`), nil))
+				db.ExpectExec("UPDATE `outbox` SET `claimed_until`=\\? WHERE \\(`id`=\\?\\) OR \\(`id`=\\?\\)").
+					WithArgs(sqlmock.AnyArg(), 1, 2).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				db.ExpectCommit()
+				db.ExpectExec("UPDATE `outbox` SET `published_at`=\\? WHERE \\(`id`=\\?\\) OR \\(`id`=\\?\\)").
+					WithArgs(sqlmock.AnyArg(), 1, 2).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+			mockingSink: func(sink *mock.MockEventPublisher) {
+				gomock.InOrder(
+					sink.EXPECT().Publish(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, events ...dto.Event) error {
+							assert.Equal(t, "event-1", events[0].ID)
+							return nil
+						}),
+					sink.EXPECT().Publish(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, events ...dto.Event) error {
+							assert.Equal(t, "event-2", events[0].ID)
+							return nil
+						}),
+				)
+			},
+			expectedPublished: 2,
+		},
+		"should release the next events of an entity when one fails": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").
+					WillReturnRows(sqlmock.NewRows(outboxColumns).
+						AddRow(1, "event-1", service.EVENT_PLANET_CREATED, "planet", 1, []byte(`{}`), nil).
+						AddRow(2, "event-2", service.EVENT_PLANET_DELETED, "planet", 1, []byte(`{}`), nil).
+						AddRow(3, "event-3", service.EVENT_FILM_CREATED, "film", 1, []byte(`{}`), nil))
+				db.ExpectExec("UPDATE `outbox` SET `claimed_until`=\\?").
+					WithArgs(sqlmock.AnyArg(), 1, 2, 3).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				db.ExpectCommit()
+				db.ExpectExec("UPDATE `outbox` SET `published_at`=\\? WHERE \\(`id`=\\?\\)").
+					WithArgs(sqlmock.AnyArg(), 3).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				db.ExpectExec("UPDATE `outbox` SET `claimed_until`=\\? WHERE \\(`id`=\\?\\) OR \\(`id`=\\?\\)").
+					WithArgs(nil, 1, 2).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+			mockingSink: func(sink *mock.MockEventPublisher) {
+				sink.EXPECT().Publish(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, events ...dto.Event) error {
+						if events[0].Entity == "planet" {
+							return fmt.Errorf("error")
+						}
+						return nil
+					}).Times(2)
+			},
+			expectedPublished: 1,
+		},
+		"should leave the entities claimed by another relay": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").
+					WillReturnRows(sqlmock.NewRows(outboxColumns).
+						AddRow(1, "event-1", service.EVENT_PLANET_CREATED, "planet", 1, []byte(`{}`), time.Now().Add(time.Minute)).
+						AddRow(2, "event-2", service.EVENT_PLANET_DELETED, "planet", 1, []byte(`{}`), nil).
+						AddRow(3, "event-3", service.EVENT_FILM_CREATED, "film", 1, []byte(`{}`), time.Now().Add(-time.Minute)))
+				db.ExpectExec("UPDATE `outbox` SET `claimed_until`=\\? WHERE \\(`id`=\\?\\)").
+					WithArgs(sqlmock.AnyArg(), 3).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				db.ExpectCommit()
+				db.ExpectExec("UPDATE `outbox` SET `published_at`=\\? WHERE \\(`id`=\\?\\)").
+					WithArgs(sqlmock.AnyArg(), 3).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			mockingSink: func(sink *mock.MockEventPublisher) {
+				sink.EXPECT().Publish(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, events ...dto.Event) error {
+						assert.Equal(t, "event-3", events[0].ID)
+						return nil
+					})
+			},
+			expectedPublished: 1,
+		},
+		"should not update without events": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows(outboxColumns))
+				db.ExpectCommit()
+			},
+			mockingSink: func(sink *mock.MockEventPublisher) {},
+		},
+		"should throw error when select": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error"))
+				db.ExpectRollback()
+			},
+			mockingSink: func(sink *mock.MockEventPublisher) {},
+			expectedErr: fmt.Errorf("models: failed to assign all query results to Outbox slice: bind failed to execute query: error"),
+		},
+		"should throw error when claim": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").
+					WillReturnRows(sqlmock.NewRows(outboxColumns).AddRow(1, "event-1", service.EVENT_PLANET_CREATED, "planet", 1, []byte(`{}`), nil))
+				db.ExpectExec("UPDATE `outbox` SET `claimed_until`=\\?").WillReturnError(fmt.Errorf("error"))
+				db.ExpectRollback()
+			},
+			mockingSink: func(sink *mock.MockEventPublisher) {},
+			expectedErr: fmt.Errorf("models: unable to update all in outbox slice: error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSink := mock.NewMockEventPublisher(ctrl)
+			outboxService := service.IOutboxService{DB: db}
+
+			cs.mocking(mockDB)
+			cs.mockingSink(mockSink)
+
+			// when
+			published, err := outboxService.RelayEvents(context.Background(), 10, mockSink)
+
+			// then
+			assert.Equal(t, cs.expectedPublished, published)
+			if cs.expectedErr != nil {
+				assert.EqualError(t, err, cs.expectedErr.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Nil(t, mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_OutboxService_FindEventsAfter(t *testing.T) {
+	var cases = map[string]struct {
+		mocking     func(db sqlmock.Sqlmock)
+		expectedIDs []int64
+		expectedErr error
+	}{
+		"should find events after id": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT `outbox`.\\* FROM `outbox` WHERE \\(id > \\?\\) ORDER BY id LIMIT 10;").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{model.OutboxColumns.ID}).AddRow(2).AddRow(3))
+			},
+			expectedIDs: []int64{2, 3},
+		},
+		"should throw error when find events": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT `outbox`").WillReturnError(fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("models: failed to assign all query results to Outbox slice: bind failed to execute query: error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			outboxService := service.IOutboxService{DB: db}
+
+			cs.mocking(mockDB)
+
+			// when
+			rows, err := outboxService.FindEventsAfter(context.Background(), 1, 10)
+
+			// then
+			if cs.expectedErr != nil {
+				assert.EqualError(t, err, cs.expectedErr.Error())
+				return
+			}
+			ids := []int64{}
+			for _, row := range rows {
+				ids = append(ids, row.ID)
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, cs.expectedIDs, ids)
+		})
+	}
+}
+
+func Test_OutboxService_FindLastEventID(t *testing.T) {
+	var cases = map[string]struct {
+		mocking     func(db sqlmock.Sqlmock)
+		expectedID  int64
+		expectedErr error
+	}{
+		"should find last event id": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT MAX\\(id\\) FROM outbox;").
+					WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow(7))
+			},
+			expectedID: 7,
+		},
+		"should return zero when outbox is empty": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT MAX").WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow(nil))
+			},
+		},
+		"should throw error when query": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT MAX").WillReturnError(fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			outboxService := service.IOutboxService{DB: db}
+
+			cs.mocking(mockDB)
+
+			// when
+			id, err := outboxService.FindLastEventID(context.Background())
+
+			// then
+			assert.Equal(t, cs.expectedID, id)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func Test_OutboxService_InsertOutboxEvents(t *testing.T) {
+	// given
+	db, mockDB, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	event := dto.Event{
+		ID:         "event-id",
+		Type:       service.EVENT_PLANET_DELETED,
+		Entity:     "planet",
+		EntityID:   1,
+		OccurredAt: time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC),
+		Data:       []byte(`{"id":1}`),
+	}
+
+	mockDB.ExpectExec("INSERT INTO outbox \\(created_at, event_id, event, entity, entity_id, data\\)").
+		WithArgs("2022-10-01 12:00:00", "event-id", service.EVENT_PLANET_DELETED, "planet", 1, `{"id":1}`).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	// when
+	err = service.InsertOutboxEvents(context.Background(), db, []dto.Event{event})
+
+	// then
+	assert.Nil(t, err)
+	assert.Nil(t, mockDB.ExpectationsWereMet())
+}
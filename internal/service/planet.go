@@ -3,7 +3,10 @@ package service
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"reflect"
+	"sort"
 	"strings"
 	"time"
 
@@ -11,33 +14,103 @@ import (
 	"github.com/viniosilva/starwars-api/internal/dto"
 	"github.com/viniosilva/starwars-api/internal/exception"
 	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 )
 
 //go:generate mockgen -destination=../../mock/planet_service_mock.go -package=mock . PlanetService
 type PlanetService interface {
-	CreatePlanets(ctx context.Context, planets []*model.Planet) error
+	CreatePlanets(ctx context.Context, planets []*model.Planet, opts ...Option) error
+	CreatePlanetsTx(ctx context.Context, tx *sql.Tx, planets []*model.Planet, opts ...Option) error
 	CreateRelationshipFilmsToPlanets(ctx context.Context, relationships map[int][]int) error
+	LinkFilmToPlanet(ctx context.Context, planetID, filmID int) error
+	UnlinkFilmFromPlanet(ctx context.Context, planetID, filmID int) error
 	FindPlanetsAndTotal(ctx context.Context, page, size int, loadFilms bool, opts ...Option) (dto.FindPlanetsAndTotalResult, error)
-	FindPlanetByID(ctx context.Context, planetID int, loadFilms bool) (*model.Planet, error)
+	FindPlanetByID(ctx context.Context, planetID int, loadFilms bool, opts ...Option) (*model.Planet, error)
+	FindPlanetsByIDs(ctx context.Context, planetIDs []int, loadFilms bool, opts ...Option) (dto.FindPlanetsByIDsResult, error)
+	FindPlanetsByFilmIDs(ctx context.Context, filmIDs []int) (map[int][]*model.Planet, error)
+	FindPlanetHistory(ctx context.Context, planetID int) ([]*model.PlanetsHistory, error)
 	DeletePlanet(ctx context.Context, planetID int) error
+	DeletePlanetTx(ctx context.Context, tx *sql.Tx, planetID int) error
+	RestorePlanetTx(ctx context.Context, tx *sql.Tx, planetID int) (*model.Planet, error)
+	PatchPlanetTx(ctx context.Context, tx *sql.Tx, planetID int, patch dto.PlanetPatch) (*model.Planet, error)
+	BulkPlanets(ctx context.Context, operations []dto.PlanetBulkOperation, atomic bool) (dto.BulkPlanetsResult, error)
+	StreamPlanets(ctx context.Context, fn func(planet *model.Planet) error, opts ...Option) error
+	StreamPlanetsFilms(ctx context.Context, fn func(planetID, filmID int) error, opts ...Option) error
 }
 
 type IPlanetService struct {
 	DB *sql.DB
 }
 
-func (impl *IPlanetService) CreatePlanets(ctx context.Context, planets []*model.Planet) error {
+type planetWithFilmID struct {
+	model.Planet `boil:",bind"`
+	FilmID       int `boil:"film_id"`
+}
+
+func (impl *IPlanetService) CreatePlanets(ctx context.Context, planets []*model.Planet, opts ...Option) error {
+	tx, err := impl.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.create_planets:db.begin_tx"}).Error(err)
+		return err
+	}
+
+	if err := impl.CreatePlanetsTx(ctx, tx, planets, opts...); err != nil {
+		rollback(tx, "internal.service.planet.create_planets:tx.rollback")
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.create_planets:tx.commit"}).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// CreatePlanetsTx inserts, or upserts with OptionUpsert, the planets within
+// tx, recording the changes like CreatePlanets. Deleted planets are left as
+// they are even on upsert, so they are neither edited nor brought back. The
+// caller commits or rolls back tx
+func (impl *IPlanetService) CreatePlanetsTx(ctx context.Context, tx *sql.Tx, planets []*model.Planet, opts ...Option) error {
+	ids := make([]int, len(planets))
+	for i, p := range planets {
+		ids[i] = p.ID
+	}
+
+	before, err := findPlanetsByIDs(ctx, tx, ids)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.create_planets_tx:find_planets_by_ids"}).Error(err)
+		return err
+	}
+
+	upsert := GetOptionUpsert(opts)
+	if upsert {
+		planets = withoutDeletedPlanets(planets, before)
+		if len(planets) == 0 {
+			return nil
+		}
+	}
+
 	values := make([]string, len(planets))
+	args := []interface{}{}
 	for i := 0; i < len(values); i += 1 {
 		p := planets[i]
-		values[i] = fmt.Sprintf("(%d, '%s', '%s', '%s', '%s', '%s')",
+		values[i] = "(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+		args = append(args,
 			p.ID,
 			p.CreatedAt.Format("2006-01-02 15:04:05"),
 			p.UpdatedAt.Format("2006-01-02 15:04:05"),
 			p.Name,
 			p.Climates.String(),
 			p.Terrains.String(),
+			p.RotationPeriod,
+			p.OrbitalPeriod,
+			p.Diameter,
+			p.Gravity,
+			p.SurfaceWater,
+			p.Population,
 		)
 	}
 
@@ -48,40 +121,370 @@ func (impl *IPlanetService) CreatePlanets(ctx context.Context, planets []*model.
 		model.PlanetColumns.Name,
 		model.PlanetColumns.Climates,
 		model.PlanetColumns.Terrains,
+		model.PlanetColumns.RotationPeriod,
+		model.PlanetColumns.OrbitalPeriod,
+		model.PlanetColumns.Diameter,
+		model.PlanetColumns.Gravity,
+		model.PlanetColumns.SurfaceWater,
+		model.PlanetColumns.Population,
+	}
+	query := BuildInsertQuery(model.TableNames.Planets, columns, values, upsert)
+
+	if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.create_planets_tx:tx.exec_context"}).Error(err)
+		return err
 	}
-	query := fmt.Sprintf("INSERT IGNORE INTO %s (%s) VALUES %s;",
-		model.TableNames.Planets, strings.Join(columns, ", "), strings.Join(values, ",\n"))
 
-	_, err := impl.DB.ExecContext(ctx, query)
+	after, err := findPlanetsByIDs(ctx, tx, ids)
 	if err != nil {
-		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.create_planets:tx.exec_context"}).Error(err)
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.create_planets_tx:find_planets_by_ids"}).Error(err)
+		return err
+	}
+
+	logs := []*model.AuditLog{}
+	events := []dto.Event{}
+	changed := []*model.Planet{}
+	for _, id := range ids {
+		a, ok := after[id]
+		if !ok {
+			continue
+		}
+
+		var log *model.AuditLog
+		var event dto.Event
+		if b, existed := before[id]; !existed {
+			log, err = NewAuditLog(ctx, AUDIT_ENTITY_PLANET, id, AUDIT_ACTION_CREATE, nil, a)
+			if err == nil {
+				event, err = NewEvent(EVENT_PLANET_CREATED, AUDIT_ENTITY_PLANET, id, a)
+			}
+		} else if upsert && !reflect.DeepEqual(b, a) {
+			log, err = NewAuditLog(ctx, AUDIT_ENTITY_PLANET, id, AUDIT_ACTION_UPDATE, b, a)
+			if err == nil {
+				event, err = NewEvent(EVENT_PLANET_UPDATED, AUDIT_ENTITY_PLANET, id, a)
+			}
+		}
+		if err != nil {
+			return err
+		}
+		if log != nil {
+			logs = append(logs, log)
+			events = append(events, event)
+			changed = append(changed, a)
+			delete(after, id)
+		}
+	}
+
+	if err := InsertAuditLogs(ctx, tx, logs); err != nil {
+		return err
+	}
+
+	if err := RecordPlanetsHistory(ctx, tx, changed, time.Now()); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.create_planets_tx:record_planets_history"}).Error(err)
+		return err
+	}
+
+	changedIDs := make([]int, len(changed))
+	for i, p := range changed {
+		changedIDs[i] = p.ID
+	}
+	if err := SyncPlanetLookups(ctx, tx, changedIDs); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.create_planets_tx:sync_planet_lookups"}).Error(err)
+		return err
+	}
+
+	if err := InsertOutboxEvents(ctx, tx, events); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// CreateRelationshipFilmsToPlanets relates the films to the planets, locking
+// the current relationships of the planets so that only the missing ones are
+// inserted, audited and published in the outbox
 func (impl *IPlanetService) CreateRelationshipFilmsToPlanets(ctx context.Context, relationships map[int][]int) error {
+	planetIDs := make([]int, 0, len(relationships))
+	for planetID := range relationships {
+		planetIDs = append(planetIDs, planetID)
+	}
+	sort.Ints(planetIDs)
+
+	tx, err := impl.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.create_relationship_films_to_planets:db.begin_tx"}).Error(err)
+		return err
+	}
+
+	existing, err := lockPlanetsFilms(ctx, tx, planetIDs)
+	if err != nil {
+		rollback(tx, "internal.service.planet.create_relationship_films_to_planets:tx.rollback")
+		return err
+	}
+
+	missing := map[int][]int{}
 	values := []string{}
-	for planetID, filmIDs := range relationships {
-		for _, filmID := range filmIDs {
+	for _, planetID := range planetIDs {
+		for _, filmID := range relationships[planetID] {
+			if existing[planetID][filmID] {
+				continue
+			}
+			if existing[planetID] == nil {
+				existing[planetID] = map[int]bool{}
+			}
+			existing[planetID][filmID] = true
+
+			missing[planetID] = append(missing[planetID], filmID)
 			values = append(values, fmt.Sprintf("(%d, %d)", planetID, filmID))
 		}
 	}
 
+	if len(values) == 0 {
+		rollback(tx, "internal.service.planet.create_relationship_films_to_planets:tx.rollback")
+		return nil
+	}
+
 	query := fmt.Sprintf("INSERT IGNORE INTO %s (planet_id, film_id) VALUES %s;",
 		model.TableNames.PlanetsFilms, strings.Join(values, ",\n"))
 
-	_, err := impl.DB.ExecContext(ctx, query)
+	if _, err = tx.ExecContext(ctx, query); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.create_relationship_films_to_planets:tx.exec_context"}).Error(err)
+		rollback(tx, "internal.service.planet.create_relationship_films_to_planets:tx.rollback")
+		return err
+	}
+
+	logs := []*model.AuditLog{}
+	events := []dto.Event{}
+	for _, planetID := range planetIDs {
+		filmIDs := missing[planetID]
+		if len(filmIDs) == 0 {
+			continue
+		}
+
+		log, err := NewAuditLog(ctx, AUDIT_ENTITY_PLANET, planetID, AUDIT_ACTION_LINK_FILMS,
+			nil, map[string][]int{"film_ids": filmIDs})
+		if err != nil {
+			rollback(tx, "internal.service.planet.create_relationship_films_to_planets:tx.rollback")
+			return err
+		}
+		logs = append(logs, log)
+
+		for _, filmID := range filmIDs {
+			event, err := NewEvent(EVENT_FILM_LINKED, AUDIT_ENTITY_FILM, filmID, map[string]int{"film_id": filmID, "planet_id": planetID})
+			if err != nil {
+				rollback(tx, "internal.service.planet.create_relationship_films_to_planets:tx.rollback")
+				return err
+			}
+			events = append(events, event)
+		}
+	}
+
+	if err := InsertAuditLogs(ctx, tx, logs); err != nil {
+		rollback(tx, "internal.service.planet.create_relationship_films_to_planets:tx.rollback")
+		return err
+	}
+
+	if err := InsertOutboxEvents(ctx, tx, events); err != nil {
+		rollback(tx, "internal.service.planet.create_relationship_films_to_planets:tx.rollback")
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.create_relationship_films_to_planets:tx.commit"}).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// lockPlanetsFilms reads the films related to the planets, locking the rows
+// until tx ends
+func lockPlanetsFilms(ctx context.Context, tx *sql.Tx, planetIDs []int) (map[int]map[int]bool, error) {
+	res := map[int]map[int]bool{}
+	if len(planetIDs) == 0 {
+		return res, nil
+	}
+
+	placeholders := make([]string, len(planetIDs))
+	args := make([]interface{}, len(planetIDs))
+	for i, planetID := range planetIDs {
+		placeholders[i] = "?"
+		args[i] = planetID
+	}
+
+	query := fmt.Sprintf("SELECT planet_id, film_id FROM %s WHERE planet_id IN (%s) FOR UPDATE;",
+		model.TableNames.PlanetsFilms, strings.Join(placeholders, ", "))
+
+	rows, err := tx.QueryContext(ctx, query, args...)
 	if err != nil {
-		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.create_relationship_films_to_planets:db.exec_context"}).Error(err)
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.lock_planets_films:tx.query_context"}).Error(err)
+		return nil, err
+	}
+	defer rows.Close()
 
+	for rows.Next() {
+		var planetID, filmID int
+		if err := rows.Scan(&planetID, &filmID); err != nil {
+			logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.lock_planets_films:rows.scan"}).Error(err)
+			return nil, err
+		}
+		if res[planetID] == nil {
+			res[planetID] = map[int]bool{}
+		}
+		res[planetID][filmID] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.lock_planets_films:rows.err"}).Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// LinkFilmToPlanet relates the film to the planet, recording it in the audit
+// log and the outbox. Films already related to the planet are left untouched
+func (impl *IPlanetService) LinkFilmToPlanet(ctx context.Context, planetID, filmID int) error {
+	tx, err := impl.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.link_film_to_planet:db.begin_tx"}).Error(err)
 		return err
 	}
 
+	if err := lockPlanetAndFilm(ctx, tx, planetID, filmID); err != nil {
+		rollback(tx, "internal.service.planet.link_film_to_planet:tx.rollback")
+		return err
+	}
+
+	query := fmt.Sprintf("INSERT IGNORE INTO %s (planet_id, film_id) VALUES (?, ?);", model.TableNames.PlanetsFilms)
+	result, err := tx.ExecContext(ctx, query, planetID, filmID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.link_film_to_planet:tx.exec_context"}).Error(err)
+		rollback(tx, "internal.service.planet.link_film_to_planet:tx.rollback")
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.link_film_to_planet:result.rows_affected"}).Error(err)
+		rollback(tx, "internal.service.planet.link_film_to_planet:tx.rollback")
+		return err
+	}
+	if affected == 0 {
+		rollback(tx, "internal.service.planet.link_film_to_planet:tx.rollback")
+		return nil
+	}
+
+	if err := recordFilmLink(ctx, tx, planetID, filmID, AUDIT_ACTION_LINK_FILMS, EVENT_FILM_LINKED); err != nil {
+		rollback(tx, "internal.service.planet.link_film_to_planet:tx.rollback")
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.link_film_to_planet:tx.commit"}).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// UnlinkFilmFromPlanet removes the relationship between the film and the
+// planet, recording it like LinkFilmToPlanet
+func (impl *IPlanetService) UnlinkFilmFromPlanet(ctx context.Context, planetID, filmID int) error {
+	tx, err := impl.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.unlink_film_from_planet:db.begin_tx"}).Error(err)
+		return err
+	}
+
+	if err := lockPlanetAndFilm(ctx, tx, planetID, filmID); err != nil {
+		rollback(tx, "internal.service.planet.unlink_film_from_planet:tx.rollback")
+		return err
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE planet_id = ? AND film_id = ?;", model.TableNames.PlanetsFilms)
+	result, err := tx.ExecContext(ctx, query, planetID, filmID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.unlink_film_from_planet:tx.exec_context"}).Error(err)
+		rollback(tx, "internal.service.planet.unlink_film_from_planet:tx.rollback")
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.unlink_film_from_planet:result.rows_affected"}).Error(err)
+		rollback(tx, "internal.service.planet.unlink_film_from_planet:tx.rollback")
+		return err
+	}
+	if affected == 0 {
+		rollback(tx, "internal.service.planet.unlink_film_from_planet:tx.rollback")
+		return &exception.NotFoundException{Message: fmt.Sprintf("film %d is not linked to planet %d", filmID, planetID)}
+	}
+
+	if err := recordFilmLink(ctx, tx, planetID, filmID, AUDIT_ACTION_UNLINK_FILMS, EVENT_FILM_UNLINKED); err != nil {
+		rollback(tx, "internal.service.planet.unlink_film_from_planet:tx.rollback")
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.unlink_film_from_planet:tx.commit"}).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// lockPlanetAndFilm locks the planet row and checks that the film exists.
+// Deleted planets conflict instead of not being found
+func lockPlanetAndFilm(ctx context.Context, tx *sql.Tx, planetID, filmID int) error {
+	planet, err := model.Planets(
+		qm.Where(fmt.Sprintf("%s = ?", model.PlanetColumns.ID), planetID),
+		qm.For("UPDATE"),
+	).One(ctx, tx)
+	if err == sql.ErrNoRows {
+		return &exception.NotFoundException{Message: fmt.Sprintf("planet %d not found", planetID)}
+	}
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.lock_planet_and_film:planets.one"}).Error(err)
+		return err
+	}
+	if planet.DeletedAt.Valid {
+		return &exception.ConflictException{Message: fmt.Sprintf("planet %d is deleted", planetID)}
+	}
+
+	exists, err := model.Films(qm.Where(fmt.Sprintf("%s = ?", model.FilmColumns.ID), filmID)).Exists(ctx, tx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.lock_planet_and_film:films.exists"}).Error(err)
+		return err
+	}
+	if !exists {
+		return &exception.NotFoundException{Message: fmt.Sprintf("film %d not found", filmID)}
+	}
+
 	return nil
 }
 
+func recordFilmLink(ctx context.Context, tx *sql.Tx, planetID, filmID int, action, eventType string) error {
+	var before, after interface{}
+	if action == AUDIT_ACTION_LINK_FILMS {
+		after = map[string][]int{"film_ids": {filmID}}
+	} else {
+		before = map[string][]int{"film_ids": {filmID}}
+	}
+
+	log, err := NewAuditLog(ctx, AUDIT_ENTITY_PLANET, planetID, action, before, after)
+	if err != nil {
+		return err
+	}
+	if err := InsertAuditLogs(ctx, tx, []*model.AuditLog{log}); err != nil {
+		return err
+	}
+
+	event, err := NewEvent(eventType, AUDIT_ENTITY_FILM, filmID, map[string]int{"film_id": filmID, "planet_id": planetID})
+	if err != nil {
+		return err
+	}
+
+	return InsertOutboxEvents(ctx, tx, []dto.Event{event})
+}
+
 func (impl *IPlanetService) FindPlanetsAndTotal(ctx context.Context, page, size int, loadFilms bool, opts ...Option) (dto.FindPlanetsAndTotalResult, error) {
 	offset := 0
 	if page > 1 {
@@ -101,12 +504,12 @@ func (impl *IPlanetService) FindPlanetsAndTotal(ctx context.Context, page, size
 		whereIsNotDeleted,
 	}
 
-	where, arg := GetOptionWhere(opts)
-	if where != "" {
-		qms = append(qms, qm.And(where, arg))
-	}
+	wheres := GetOptionsWhere(opts)
+	qms = append(qms, wheres...)
+	qms = append(qms, SelectPlanetColumns(opts)...)
 
-	if loadFilms {
+	filmColumns := GetOptionFilmsSelect(opts)
+	if loadFilms && len(filmColumns) == 0 {
 		qms = append(qms, qm.Load("Films"))
 	}
 
@@ -121,11 +524,20 @@ func (impl *IPlanetService) FindPlanetsAndTotal(ctx context.Context, page, size
 		return dto.FindPlanetsAndTotalResult{}, err
 	}
 
-	qms = []qm.QueryMod{whereIsNotDeleted}
-	if where != "" {
-		qms = append(qms, qm.And(where, arg))
+	if loadFilms && len(filmColumns) > 0 {
+		if err := LoadPlanetsFilms(ctx, tx, planets, filmColumns); err != nil {
+			logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.FindPlanets:load_planets_films"}).Error(err)
+			if err := tx.Rollback(); err != nil {
+				logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.FindPlanets:tx.rollback"}).Error(err)
+				return dto.FindPlanetsAndTotalResult{}, err
+			}
+
+			return dto.FindPlanetsAndTotalResult{}, err
+		}
 	}
 
+	qms = append([]qm.QueryMod{whereIsNotDeleted}, wheres...)
+
 	total, err := model.Planets(qms...).Count(ctx, tx)
 	if err != nil {
 		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.FindPlanets:planets.count"}).Error(err)
@@ -157,12 +569,19 @@ func (impl *IPlanetService) FindPlanetsAndTotal(ctx context.Context, page, size
 	}, nil
 }
 
-func (impl *IPlanetService) FindPlanetByID(ctx context.Context, planetID int, loadFilms bool) (*model.Planet, error) {
+func (impl *IPlanetService) FindPlanetByID(ctx context.Context, planetID int, loadFilms bool, opts ...Option) (*model.Planet, error) {
+	if asOf, ok := GetOptionAsOf(opts); ok {
+		return impl.findPlanetAsOf(ctx, planetID, asOf, loadFilms, GetOptionFilmsSelect(opts))
+	}
+
 	qms := []qm.QueryMod{
 		qm.Where(fmt.Sprintf("%s = ?", model.PlanetColumns.ID), planetID),
 		qm.Where(fmt.Sprintf("%s IS NULL", model.PlanetColumns.DeletedAt)),
 	}
-	if loadFilms {
+	qms = append(qms, SelectPlanetColumns(opts)...)
+
+	filmColumns := GetOptionFilmsSelect(opts)
+	if loadFilms && len(filmColumns) == 0 {
 		qms = append(qms, qm.Load("Films"))
 	}
 
@@ -177,16 +596,563 @@ func (impl *IPlanetService) FindPlanetByID(ctx context.Context, planetID int, lo
 		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.find_planet_by_id:planets.one"}).Error(err)
 		return nil, err
 	}
+
+	if loadFilms && len(filmColumns) > 0 {
+		if err := LoadPlanetsFilms(ctx, impl.DB, []*model.Planet{planet}, filmColumns); err != nil {
+			logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.find_planet_by_id:load_planets_films"}).Error(err)
+			return nil, err
+		}
+	}
+
+	return planet, nil
+}
+
+// FindPlanetsByIDs reads the planets not deleted among planetIDs in a single
+// query, returning them in the requested order along with the ids not found
+func (impl *IPlanetService) FindPlanetsByIDs(ctx context.Context, planetIDs []int, loadFilms bool, opts ...Option) (dto.FindPlanetsByIDsResult, error) {
+	res := dto.FindPlanetsByIDsResult{Data: []*model.Planet{}, Missing: []int{}}
+	if len(planetIDs) == 0 {
+		return res, nil
+	}
+
+	unique := []int{}
+	ids := []interface{}{}
+	seen := map[int]bool{}
+	for _, id := range planetIDs {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+			ids = append(ids, id)
+		}
+	}
+
+	qms := []qm.QueryMod{
+		qm.WhereIn(fmt.Sprintf("%s IN ?", model.PlanetColumns.ID), ids...),
+		qm.Where(fmt.Sprintf("%s IS NULL", model.PlanetColumns.DeletedAt)),
+	}
+	qms = append(qms, SelectPlanetColumns(opts)...)
+
+	filmColumns := GetOptionFilmsSelect(opts)
+	if loadFilms && len(filmColumns) == 0 {
+		qms = append(qms, qm.Load("Films"))
+	}
+
+	planets, err := model.Planets(qms...).All(ctx, impl.DB)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.find_planets_by_ids:planets.all"}).Error(err)
+		return dto.FindPlanetsByIDsResult{}, err
+	}
+
+	if loadFilms && len(filmColumns) > 0 && len(planets) > 0 {
+		if err := LoadPlanetsFilms(ctx, impl.DB, planets, filmColumns); err != nil {
+			logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.find_planets_by_ids:load_planets_films"}).Error(err)
+			return dto.FindPlanetsByIDsResult{}, err
+		}
+	}
+
+	found := make(map[int]*model.Planet, len(planets))
+	for _, p := range planets {
+		found[p.ID] = p
+	}
+
+	for _, id := range unique {
+		if p, ok := found[id]; ok {
+			res.Data = append(res.Data, p)
+		} else {
+			res.Missing = append(res.Missing, id)
+		}
+	}
+
+	return res, nil
+}
+
+// findPlanetAsOf reads the planet version valid at asOf, the films are the
+// current ones since relationships are not versioned
+func (impl *IPlanetService) findPlanetAsOf(ctx context.Context, planetID int, asOf time.Time, loadFilms bool, filmColumns []string) (*model.Planet, error) {
+	version, err := model.PlanetsHistories(
+		qm.Where(fmt.Sprintf("%s = ?", model.PlanetsHistoryColumns.PlanetID), planetID),
+		qm.Where(fmt.Sprintf("%s <= ?", model.PlanetsHistoryColumns.ValidFrom), asOf),
+		qm.Where(fmt.Sprintf("(%s IS NULL OR %s > ?)", model.PlanetsHistoryColumns.ValidTo, model.PlanetsHistoryColumns.ValidTo), asOf),
+		qm.OrderBy(fmt.Sprintf("%s DESC, %s DESC", model.PlanetsHistoryColumns.ValidFrom, model.PlanetsHistoryColumns.ID)),
+	).One(ctx, impl.DB)
+	if err != nil && err != sql.ErrNoRows {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.find_planet_as_of:planets_histories.one"}).Error(err)
+		return nil, err
+	}
+	if err == sql.ErrNoRows || version.DeletedAt.Valid {
+		return nil, &exception.NotFoundException{
+			Message: fmt.Sprintf("planet %d not found", planetID),
+		}
+	}
+
+	planet := ParsePlanetVersion(version)
+	if loadFilms {
+		if err := LoadPlanetsFilms(ctx, impl.DB, []*model.Planet{planet}, filmColumns); err != nil {
+			logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.find_planet_as_of:load_planets_films"}).Error(err)
+			return nil, err
+		}
+	}
+
 	return planet, nil
 }
 
+// FindPlanetHistory returns every version of the planet, oldest first
+func (impl *IPlanetService) FindPlanetHistory(ctx context.Context, planetID int) ([]*model.PlanetsHistory, error) {
+	versions, err := model.PlanetsHistories(
+		qm.Where(fmt.Sprintf("%s = ?", model.PlanetsHistoryColumns.PlanetID), planetID),
+		qm.OrderBy(fmt.Sprintf("%s, %s", model.PlanetsHistoryColumns.ValidFrom, model.PlanetsHistoryColumns.ID)),
+	).All(ctx, impl.DB)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.find_planet_history:planets_histories.all"}).Error(err)
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, &exception.NotFoundException{
+			Message: fmt.Sprintf("planet %d not found", planetID),
+		}
+	}
+
+	return versions, nil
+}
+
+func (impl *IPlanetService) FindPlanetsByFilmIDs(ctx context.Context, filmIDs []int) (map[int][]*model.Planet, error) {
+	res := map[int][]*model.Planet{}
+	if len(filmIDs) == 0 {
+		return res, nil
+	}
+
+	ids := make([]interface{}, len(filmIDs))
+	for i, id := range filmIDs {
+		ids[i] = id
+	}
+
+	var rows []*planetWithFilmID
+	err := model.Planets(
+		qm.Select(fmt.Sprintf("%s.*", model.TableNames.Planets), fmt.Sprintf("%s.film_id", model.TableNames.PlanetsFilms)),
+		qm.InnerJoin(fmt.Sprintf("%s ON %s = %s.planet_id", model.TableNames.PlanetsFilms, model.PlanetTableColumns.ID, model.TableNames.PlanetsFilms)),
+		qm.WhereIn(fmt.Sprintf("%s.film_id IN ?", model.TableNames.PlanetsFilms), ids...),
+		qm.Where(fmt.Sprintf("%s IS NULL", model.PlanetTableColumns.DeletedAt)),
+		qm.OrderBy(model.PlanetTableColumns.ID),
+	).Bind(ctx, impl.DB, &rows)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.find_planets_by_film_ids:planets.bind"}).Error(err)
+		return nil, err
+	}
+
+	for _, row := range rows {
+		planet := row.Planet
+		res[row.FilmID] = append(res[row.FilmID], &planet)
+	}
+
+	return res, nil
+}
+
+// DeletePlanet soft deletes the planet, recording it in the audit log and the
+// outbox in the same transaction. Planets not found or already deleted are left untouched
 func (impl *IPlanetService) DeletePlanet(ctx context.Context, planetID int) error {
-	_, err := model.Planets(qm.Where(fmt.Sprintf("%s = ?", model.PlanetColumns.ID), planetID)).
-		UpdateAll(ctx, impl.DB, model.M{model.PlanetColumns.DeletedAt: time.Now()})
+	tx, err := impl.DB.BeginTx(ctx, nil)
 	if err != nil {
-		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.delete_planet:planets.update_all"}).Error(err)
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.delete_planet:db.begin_tx"}).Error(err)
+		return err
+	}
+
+	err = impl.DeletePlanetTx(ctx, tx, planetID)
+	if _, ok := err.(*exception.NotFoundException); ok {
+		rollback(tx, "internal.service.planet.delete_planet:tx.rollback")
+		return nil
+	}
+	if err != nil {
+		rollback(tx, "internal.service.planet.delete_planet:tx.rollback")
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.delete_planet:tx.commit"}).Error(err)
 		return err
 	}
 
 	return nil
 }
+
+// DeletePlanetTx soft deletes the planet within tx, recording it in the audit
+// log, the history and the outbox. The caller commits or rolls back tx
+func (impl *IPlanetService) DeletePlanetTx(ctx context.Context, tx *sql.Tx, planetID int) error {
+	planet, err := findPlanetForUpdate(ctx, tx, planetID, false)
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); !ok {
+			logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.delete_planet_tx:find_planet_for_update"}).Error(err)
+		}
+		return err
+	}
+
+	before := *planet
+	now := time.Now()
+	planet.DeletedAt = null.TimeFrom(now)
+
+	_, err = model.Planets(qm.Where(fmt.Sprintf("%s = ?", model.PlanetColumns.ID), planetID)).
+		UpdateAll(ctx, tx, model.M{model.PlanetColumns.DeletedAt: planet.DeletedAt.Time})
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.delete_planet_tx:planets.update_all"}).Error(err)
+		return err
+	}
+
+	return impl.recordPlanetChange(ctx, tx, AUDIT_ACTION_DELETE, EVENT_PLANET_DELETED, &before, planet, now)
+}
+
+// RestorePlanetTx undoes the soft delete of the planet within tx, recording
+// it like DeletePlanetTx. Planets not deleted are not found
+func (impl *IPlanetService) RestorePlanetTx(ctx context.Context, tx *sql.Tx, planetID int) (*model.Planet, error) {
+	planet, err := findPlanetForUpdate(ctx, tx, planetID, true)
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); !ok {
+			logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.restore_planet_tx:find_planet_for_update"}).Error(err)
+		}
+		return nil, err
+	}
+
+	before := *planet
+	planet.DeletedAt = null.Time{}
+
+	_, err = model.Planets(qm.Where(fmt.Sprintf("%s = ?", model.PlanetColumns.ID), planetID)).
+		UpdateAll(ctx, tx, model.M{model.PlanetColumns.DeletedAt: nil})
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.restore_planet_tx:planets.update_all"}).Error(err)
+		return nil, err
+	}
+
+	if err := impl.recordPlanetChange(ctx, tx, AUDIT_ACTION_RESTORE, EVENT_PLANET_RESTORED, &before, planet, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return planet, nil
+}
+
+// PatchPlanetTx updates the given attributes of the planet within tx,
+// rebuilding its climates and terrains and recording the change like
+// DeletePlanetTx. Patches that change nothing are not recorded
+func (impl *IPlanetService) PatchPlanetTx(ctx context.Context, tx *sql.Tx, planetID int, patch dto.PlanetPatch) (*model.Planet, error) {
+	if err := ValidatePlanetPatch(patch); err != nil {
+		return nil, err
+	}
+
+	planet, err := findPlanetForUpdate(ctx, tx, planetID, false)
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); !ok {
+			logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.patch_planet_tx:find_planet_for_update"}).Error(err)
+		}
+		return nil, err
+	}
+
+	before := *planet
+	if err := applyPlanetPatch(planet, patch); err != nil {
+		return nil, err
+	}
+	if reflect.DeepEqual(&before, planet) {
+		return planet, nil
+	}
+
+	now := time.Now()
+	planet.UpdatedAt = now
+	_, err = model.Planets(qm.Where(fmt.Sprintf("%s = ?", model.PlanetColumns.ID), planetID)).
+		UpdateAll(ctx, tx, model.M{
+			model.PlanetColumns.UpdatedAt:      planet.UpdatedAt,
+			model.PlanetColumns.Name:           planet.Name,
+			model.PlanetColumns.Climates:       planet.Climates,
+			model.PlanetColumns.Terrains:       planet.Terrains,
+			model.PlanetColumns.RotationPeriod: planet.RotationPeriod,
+			model.PlanetColumns.OrbitalPeriod:  planet.OrbitalPeriod,
+			model.PlanetColumns.Diameter:       planet.Diameter,
+			model.PlanetColumns.Gravity:        planet.Gravity,
+			model.PlanetColumns.SurfaceWater:   planet.SurfaceWater,
+			model.PlanetColumns.Population:     planet.Population,
+		})
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.patch_planet_tx:planets.update_all"}).Error(err)
+		return nil, err
+	}
+
+	if err := SyncPlanetLookups(ctx, tx, []int{planetID}); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.patch_planet_tx:sync_planet_lookups"}).Error(err)
+		return nil, err
+	}
+
+	if err := impl.recordPlanetChange(ctx, tx, AUDIT_ACTION_UPDATE, EVENT_PLANET_UPDATED, &before, planet, now); err != nil {
+		return nil, err
+	}
+
+	return planet, nil
+}
+
+// ValidatePlanetPatch rejects patches without attributes or with a blank name
+func ValidatePlanetPatch(patch dto.PlanetPatch) error {
+	if reflect.DeepEqual(patch, dto.PlanetPatch{}) {
+		return &exception.ValidationException{Message: "patch is required"}
+	}
+	if patch.Name != nil && strings.TrimSpace(*patch.Name) == "" {
+		return &exception.ValidationException{Message: "name is required"}
+	}
+
+	return nil
+}
+
+func applyPlanetPatch(planet *model.Planet, patch dto.PlanetPatch) error {
+	if patch.Name != nil {
+		planet.Name = strings.TrimSpace(*patch.Name)
+	}
+	if patch.Climates != nil {
+		climates, err := json.Marshal(*patch.Climates)
+		if err != nil {
+			return err
+		}
+		planet.Climates = climates
+	}
+	if patch.Terrains != nil {
+		terrains, err := json.Marshal(*patch.Terrains)
+		if err != nil {
+			return err
+		}
+		planet.Terrains = terrains
+	}
+	if patch.RotationPeriod != nil {
+		planet.RotationPeriod = null.IntFrom(*patch.RotationPeriod)
+	}
+	if patch.OrbitalPeriod != nil {
+		planet.OrbitalPeriod = null.IntFrom(*patch.OrbitalPeriod)
+	}
+	if patch.Diameter != nil {
+		planet.Diameter = null.IntFrom(*patch.Diameter)
+	}
+	if patch.Gravity != nil {
+		planet.Gravity = null.StringFrom(*patch.Gravity)
+	}
+	if patch.SurfaceWater != nil {
+		planet.SurfaceWater = null.Float64From(*patch.SurfaceWater)
+	}
+	if patch.Population != nil {
+		planet.Population = null.Int64From(*patch.Population)
+	}
+
+	return nil
+}
+
+// findPlanetForUpdate locks the planet row, looking for it among the deleted
+// planets when deleted is true
+func findPlanetForUpdate(ctx context.Context, tx *sql.Tx, planetID int, deleted bool) (*model.Planet, error) {
+	deletedAt := fmt.Sprintf("%s IS NULL", model.PlanetColumns.DeletedAt)
+	message := fmt.Sprintf("planet %d not found", planetID)
+	if deleted {
+		deletedAt = fmt.Sprintf("%s IS NOT NULL", model.PlanetColumns.DeletedAt)
+		message = fmt.Sprintf("deleted planet %d not found", planetID)
+	}
+
+	planet, err := model.Planets(
+		qm.Where(fmt.Sprintf("%s = ?", model.PlanetColumns.ID), planetID),
+		qm.Where(deletedAt),
+		qm.For("UPDATE"),
+	).One(ctx, tx)
+	if err == sql.ErrNoRows {
+		return nil, &exception.NotFoundException{Message: message}
+	}
+
+	return planet, err
+}
+
+// recordPlanetChange writes the audit log, the history and the outbox event
+// of a planet changed within tx
+func (impl *IPlanetService) recordPlanetChange(ctx context.Context, tx *sql.Tx, action, eventType string, before, after *model.Planet, now time.Time) error {
+	log, err := NewAuditLog(ctx, AUDIT_ENTITY_PLANET, after.ID, action, before, after)
+	if err != nil {
+		return err
+	}
+	if err := InsertAuditLogs(ctx, tx, []*model.AuditLog{log}); err != nil {
+		return err
+	}
+
+	if err := RecordPlanetsHistory(ctx, tx, []*model.Planet{after}, now); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.record_planet_change:record_planets_history"}).Error(err)
+		return err
+	}
+
+	event, err := NewEvent(eventType, AUDIT_ENTITY_PLANET, after.ID, after)
+	if err != nil {
+		return err
+	}
+
+	return InsertOutboxEvents(ctx, tx, []dto.Event{event})
+}
+
+// StreamPlanets reads planets one row at a time from the database cursor,
+// calling fn for each one without loading the whole table in memory. Deleted
+// planets are left out unless OptionWithDeleted is given
+func (impl *IPlanetService) StreamPlanets(ctx context.Context, fn func(planet *model.Planet) error, opts ...Option) error {
+	columns := []string{
+		model.PlanetColumns.ID,
+		model.PlanetColumns.CreatedAt,
+		model.PlanetColumns.UpdatedAt,
+		model.PlanetColumns.Name,
+		model.PlanetColumns.Climates,
+		model.PlanetColumns.Terrains,
+		model.PlanetColumns.RotationPeriod,
+		model.PlanetColumns.OrbitalPeriod,
+		model.PlanetColumns.Diameter,
+		model.PlanetColumns.Gravity,
+		model.PlanetColumns.SurfaceWater,
+		model.PlanetColumns.Population,
+	}
+	qms := []qm.QueryMod{}
+	withDeleted := GetOptionWithDeleted(opts)
+	if withDeleted {
+		columns = append(columns, model.PlanetColumns.DeletedAt)
+	} else {
+		qms = append(qms, qm.Where(fmt.Sprintf("%s IS NULL", model.PlanetColumns.DeletedAt)))
+	}
+	qms = append(qms, qm.Select(columns...), qm.OrderBy(model.PlanetColumns.ID))
+	qms = append(qms, GetOptionsWhere(opts)...)
+
+	rows, err := model.Planets(qms...).QueryContext(ctx, impl.DB)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.stream_planets:planets.query_context"}).Error(err)
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		planet := &model.Planet{}
+		dest := []interface{}{&planet.ID, &planet.CreatedAt, &planet.UpdatedAt, &planet.Name, &planet.Climates, &planet.Terrains,
+			&planet.RotationPeriod, &planet.OrbitalPeriod, &planet.Diameter, &planet.Gravity, &planet.SurfaceWater, &planet.Population}
+		if withDeleted {
+			dest = append(dest, &planet.DeletedAt)
+		}
+		if err := rows.Scan(dest...); err != nil {
+			logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.stream_planets:rows.scan"}).Error(err)
+			return err
+		}
+
+		if err := fn(planet); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.stream_planets:rows.err"}).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// StreamPlanetsFilms reads the planets_films relationships of the planets
+// matching opts one row at a time from the database cursor, leaving out the
+// deleted planets unless OptionWithDeleted is given
+func (impl *IPlanetService) StreamPlanetsFilms(ctx context.Context, fn func(planetID, filmID int) error, opts ...Option) error {
+	qms := []qm.QueryMod{
+		qm.Select(fmt.Sprintf("%s.planet_id", model.TableNames.PlanetsFilms), fmt.Sprintf("%s.film_id", model.TableNames.PlanetsFilms)),
+		qm.InnerJoin(fmt.Sprintf("%s ON %s = %s.planet_id", model.TableNames.PlanetsFilms, model.PlanetTableColumns.ID, model.TableNames.PlanetsFilms)),
+		qm.OrderBy(fmt.Sprintf("%s.planet_id, %s.film_id", model.TableNames.PlanetsFilms, model.TableNames.PlanetsFilms)),
+	}
+	if !GetOptionWithDeleted(opts) {
+		qms = append(qms, qm.Where(fmt.Sprintf("%s IS NULL", model.PlanetTableColumns.DeletedAt)))
+	}
+	qms = append(qms, GetOptionsWhere(opts)...)
+
+	rows, err := model.Planets(qms...).QueryContext(ctx, impl.DB)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.stream_planets_films:planets.query_context"}).Error(err)
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var planetID, filmID int
+		if err := rows.Scan(&planetID, &filmID); err != nil {
+			logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.stream_planets_films:rows.scan"}).Error(err)
+			return err
+		}
+
+		if err := fn(planetID, filmID); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet.stream_planets_films:rows.err"}).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// SelectPlanetColumns narrows the planet query to the columns requested by
+// OptionSelect, always keeping the id so relationships can still be loaded
+func SelectPlanetColumns(opts []Option) []qm.QueryMod {
+	columns := GetOptionSelect(opts)
+	if len(columns) == 0 {
+		return nil
+	}
+
+	selects := []string{model.PlanetTableColumns.ID}
+	for _, c := range columns {
+		if c != model.PlanetColumns.ID {
+			selects = append(selects, fmt.Sprintf("%s.%s", model.TableNames.Planets, c))
+		}
+	}
+
+	return []qm.QueryMod{qm.Select(selects...)}
+}
+
+// LoadPlanetsFilms fills planet.R.Films reading only the given film columns,
+// which the generated eager loading is not able to do
+func LoadPlanetsFilms(ctx context.Context, exec boil.ContextExecutor, planets []*model.Planet, columns []string) error {
+	ids := make([]int, len(planets))
+	for i, p := range planets {
+		ids[i] = p.ID
+	}
+
+	films, err := findFilmsByPlanetIDs(ctx, exec, ids, columns)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range planets {
+		p.R = p.R.NewStruct()
+		p.R.Films = films[p.ID]
+	}
+
+	return nil
+}
+
+// withoutDeletedPlanets leaves out the planets deleted in the database
+func withoutDeletedPlanets(planets []*model.Planet, current map[int]*model.Planet) []*model.Planet {
+	res := []*model.Planet{}
+	for _, p := range planets {
+		if c, ok := current[p.ID]; ok && c.DeletedAt.Valid {
+			continue
+		}
+		res = append(res, p)
+	}
+
+	return res
+}
+
+func findPlanetsByIDs(ctx context.Context, exec boil.ContextExecutor, planetIDs []int) (map[int]*model.Planet, error) {
+	res := map[int]*model.Planet{}
+	if len(planetIDs) == 0 {
+		return res, nil
+	}
+
+	ids := make([]interface{}, len(planetIDs))
+	for i, id := range planetIDs {
+		ids[i] = id
+	}
+
+	planets, err := model.Planets(qm.WhereIn(fmt.Sprintf("%s IN ?", model.PlanetColumns.ID), ids...)).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range planets {
+		res[p.ID] = p
+	}
+
+	return res, nil
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/exception"
+	"github.com/viniosilva/starwars-api/internal/model"
+)
+
+const (
+	BULK_OP_DELETE  = "delete"
+	BULK_OP_RESTORE = "restore"
+	BULK_OP_PATCH   = "patch"
+
+	bulkSavepoint = "bulk_operation"
+)
+
+var BulkOps = []string{
+	BULK_OP_DELETE,
+	BULK_OP_RESTORE,
+	BULK_OP_PATCH,
+}
+
+func IsBulkOp(op string) bool {
+	for _, o := range BulkOps {
+		if o == op {
+			return true
+		}
+	}
+
+	return false
+}
+
+// BulkPlanets runs the operations in order within a single transaction. When
+// atomic, the first failed operation rolls all of them back and the remaining
+// ones are not run; otherwise each operation runs in a savepoint and only the
+// failed ones are undone. The returned error is set when the transaction
+// itself fails
+func (impl *IPlanetService) BulkPlanets(ctx context.Context, operations []dto.PlanetBulkOperation, atomic bool) (dto.BulkPlanetsResult, error) {
+	res := dto.BulkPlanetsResult{Results: make([]dto.BulkPlanetResult, len(operations))}
+
+	tx, err := impl.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet_bulk.bulk_planets:db.begin_tx"}).Error(err)
+		return res, err
+	}
+
+	for i := 0; i < len(operations); i += 1 {
+		if !atomic {
+			if _, err := tx.ExecContext(ctx, fmt.Sprintf("SAVEPOINT %s;", bulkSavepoint)); err != nil {
+				logrus.WithFields(logrus.Fields{"trace": "internal.service.planet_bulk.bulk_planets:tx.savepoint"}).Error(err)
+				rollback(tx, "internal.service.planet_bulk.bulk_planets:tx.rollback")
+				return dto.BulkPlanetsResult{}, err
+			}
+		}
+
+		planet, err := impl.runBulkOperation(ctx, tx, operations[i])
+		res.Results[i] = dto.BulkPlanetResult{Planet: planet, Err: err}
+		if err == nil {
+			continue
+		}
+
+		if atomic {
+			rollback(tx, "internal.service.planet_bulk.bulk_planets:tx.rollback")
+			for j := 0; j < len(res.Results); j += 1 {
+				res.Results[j].Planet = nil
+			}
+			return res, nil
+		}
+
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("ROLLBACK TO SAVEPOINT %s;", bulkSavepoint)); err != nil {
+			logrus.WithFields(logrus.Fields{"trace": "internal.service.planet_bulk.bulk_planets:tx.rollback_to_savepoint"}).Error(err)
+			rollback(tx, "internal.service.planet_bulk.bulk_planets:tx.rollback")
+			return dto.BulkPlanetsResult{}, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.planet_bulk.bulk_planets:tx.commit"}).Error(err)
+		return dto.BulkPlanetsResult{}, err
+	}
+	res.Committed = true
+
+	return res, nil
+}
+
+func (impl *IPlanetService) runBulkOperation(ctx context.Context, tx *sql.Tx, operation dto.PlanetBulkOperation) (*model.Planet, error) {
+	switch operation.Op {
+	case BULK_OP_DELETE:
+		return nil, impl.DeletePlanetTx(ctx, tx, operation.ID)
+	case BULK_OP_RESTORE:
+		return impl.RestorePlanetTx(ctx, tx, operation.ID)
+	case BULK_OP_PATCH:
+		if operation.Patch == nil {
+			return nil, &exception.ValidationException{Message: "patch is required"}
+		}
+		return impl.PatchPlanetTx(ctx, tx, operation.ID, *operation.Patch)
+	}
+
+	return nil, &exception.ValidationException{Message: fmt.Sprintf("invalid op %s", operation.Op)}
+}
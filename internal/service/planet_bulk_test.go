@@ -0,0 +1,154 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/exception"
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/viniosilva/starwars-api/internal/service"
+)
+
+func Test_PlanetService_BulkPlanets(t *testing.T) {
+	climates, _ := json.Marshal([]string{"arid"})
+	terrains, _ := json.Marshal([]string{"desert"})
+	planetColumns := []string{model.PlanetColumns.ID, model.PlanetColumns.Name, model.PlanetColumns.Climates, model.PlanetColumns.Terrains}
+	expectDeletePlanet := func(db sqlmock.Sqlmock, planetID int) {
+		db.ExpectQuery("SELECT `planets`.\\* FROM `planets` .* FOR UPDATE").WithArgs(planetID).
+			WillReturnRows(sqlmock.NewRows(planetColumns).AddRow(planetID, "Tatooine", climates, terrains))
+		db.ExpectExec("UPDATE `planets` SET `deleted_at` = \\?").WillReturnResult(sqlmock.NewResult(1, 1))
+		db.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(1, 1))
+		db.ExpectExec("UPDATE `planets_history` SET `valid_to` = \\?").WillReturnResult(sqlmock.NewResult(0, 1))
+		db.ExpectExec("INSERT INTO planets_history").WillReturnResult(sqlmock.NewResult(1, 1))
+		db.ExpectExec("INSERT INTO outbox").WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+	operations := []dto.PlanetBulkOperation{
+		{Op: service.BULK_OP_DELETE, ID: 1},
+		{Op: service.BULK_OP_RESTORE, ID: 2},
+		{Op: service.BULK_OP_DELETE, ID: 3},
+	}
+
+	var cases = map[string]struct {
+		mocking          func(db sqlmock.Sqlmock)
+		inputOperations  []dto.PlanetBulkOperation
+		inputAtomic      bool
+		expectedResult   dto.BulkPlanetsResult
+		expectedErrorMsg string
+	}{
+		"should commit operations when atomic": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				expectDeletePlanet(db, 1)
+				db.ExpectCommit()
+			},
+			inputOperations: operations[:1],
+			inputAtomic:     true,
+			expectedResult:  dto.BulkPlanetsResult{Committed: true, Results: []dto.BulkPlanetResult{{}}},
+		},
+		"should roll back every operation when atomic and one fails": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				expectDeletePlanet(db, 1)
+				db.ExpectQuery("SELECT `planets`.\\* FROM `planets` WHERE \\(id = \\?\\) AND \\(deleted_at IS NOT NULL\\)").WithArgs(2).
+					WillReturnRows(sqlmock.NewRows(planetColumns))
+				db.ExpectRollback()
+			},
+			inputOperations: operations,
+			inputAtomic:     true,
+			expectedResult: dto.BulkPlanetsResult{Results: []dto.BulkPlanetResult{
+				{},
+				{Err: &exception.NotFoundException{Message: "deleted planet 2 not found"}},
+				{},
+			}},
+		},
+		"should roll back only failed operations when best effort": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectExec("SAVEPOINT bulk_operation;").WillReturnResult(sqlmock.NewResult(0, 0))
+				expectDeletePlanet(db, 1)
+				db.ExpectExec("SAVEPOINT bulk_operation;").WillReturnResult(sqlmock.NewResult(0, 0))
+				db.ExpectQuery("SELECT").WithArgs(2).WillReturnRows(sqlmock.NewRows(planetColumns))
+				db.ExpectExec("ROLLBACK TO SAVEPOINT bulk_operation;").WillReturnResult(sqlmock.NewResult(0, 0))
+				db.ExpectExec("SAVEPOINT bulk_operation;").WillReturnResult(sqlmock.NewResult(0, 0))
+				expectDeletePlanet(db, 3)
+				db.ExpectCommit()
+			},
+			inputOperations: operations,
+			expectedResult: dto.BulkPlanetsResult{Committed: true, Results: []dto.BulkPlanetResult{
+				{},
+				{Err: &exception.NotFoundException{Message: "deleted planet 2 not found"}},
+				{},
+			}},
+		},
+		"should throw validation exception when op is invalid": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectRollback()
+			},
+			inputOperations: []dto.PlanetBulkOperation{{Op: "create", ID: 1}, {Op: service.BULK_OP_PATCH, ID: 1}},
+			inputAtomic:     true,
+			expectedResult: dto.BulkPlanetsResult{Results: []dto.BulkPlanetResult{
+				{Err: &exception.ValidationException{Message: "invalid op create"}},
+				{},
+			}},
+		},
+		"should throw error when begin transaction": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin().WillReturnError(fmt.Errorf("error"))
+			},
+			inputOperations:  operations[:1],
+			expectedResult:   dto.BulkPlanetsResult{Results: []dto.BulkPlanetResult{{}}},
+			expectedErrorMsg: "error",
+		},
+		"should throw error when roll back to savepoint": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectExec("SAVEPOINT bulk_operation;").WillReturnResult(sqlmock.NewResult(0, 0))
+				db.ExpectQuery("SELECT").WithArgs(2).WillReturnRows(sqlmock.NewRows(planetColumns))
+				db.ExpectExec("ROLLBACK TO SAVEPOINT bulk_operation;").WillReturnError(fmt.Errorf("error"))
+				db.ExpectRollback()
+			},
+			inputOperations:  operations[1:2],
+			expectedErrorMsg: "error",
+		},
+		"should throw error when commit": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				expectDeletePlanet(db, 1)
+				db.ExpectCommit().WillReturnError(fmt.Errorf("error"))
+			},
+			inputOperations:  operations[:1],
+			inputAtomic:      true,
+			expectedErrorMsg: "error",
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			planetService := service.IPlanetService{DB: db}
+
+			cs.mocking(mockDB)
+
+			// when
+			result, err := planetService.BulkPlanets(context.Background(), cs.inputOperations, cs.inputAtomic)
+
+			// then
+			assert.Equal(t, cs.expectedResult, result)
+			if err != nil || cs.expectedErrorMsg != "" {
+				assert.EqualError(t, err, cs.expectedErrorMsg)
+			}
+			assert.Nil(t, mockDB.ExpectationsWereMet())
+		})
+	}
+}
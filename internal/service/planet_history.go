@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// RecordPlanetsHistory closes the current version of each planet at now and
+// opens a new one holding the given state
+func RecordPlanetsHistory(ctx context.Context, exec boil.ContextExecutor, planets []*model.Planet, now time.Time) error {
+	if len(planets) == 0 {
+		return nil
+	}
+
+	ids := make([]interface{}, len(planets))
+	values := make([]string, len(planets))
+	args := []interface{}{}
+	for i := 0; i < len(planets); i += 1 {
+		p := planets[i]
+		ids[i] = p.ID
+		values[i] = "(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+		args = append(args,
+			p.ID,
+			now.Format("2006-01-02 15:04:05"),
+			p.CreatedAt.Format("2006-01-02 15:04:05"),
+			p.UpdatedAt.Format("2006-01-02 15:04:05"),
+			p.DeletedAt,
+			p.Name,
+			p.Climates.String(),
+			p.Terrains.String(),
+			p.RotationPeriod,
+			p.OrbitalPeriod,
+			p.Diameter,
+			p.Gravity,
+			p.SurfaceWater,
+			p.Population,
+		)
+	}
+
+	_, err := model.PlanetsHistories(
+		qm.WhereIn(fmt.Sprintf("%s IN ?", model.PlanetsHistoryColumns.PlanetID), ids...),
+		qm.Where(fmt.Sprintf("%s IS NULL", model.PlanetsHistoryColumns.ValidTo)),
+	).UpdateAll(ctx, exec, model.M{model.PlanetsHistoryColumns.ValidTo: now})
+	if err != nil {
+		return err
+	}
+
+	columns := []string{
+		model.PlanetsHistoryColumns.PlanetID,
+		model.PlanetsHistoryColumns.ValidFrom,
+		model.PlanetsHistoryColumns.CreatedAt,
+		model.PlanetsHistoryColumns.UpdatedAt,
+		model.PlanetsHistoryColumns.DeletedAt,
+		model.PlanetsHistoryColumns.Name,
+		model.PlanetsHistoryColumns.Climates,
+		model.PlanetsHistoryColumns.Terrains,
+		model.PlanetsHistoryColumns.RotationPeriod,
+		model.PlanetsHistoryColumns.OrbitalPeriod,
+		model.PlanetsHistoryColumns.Diameter,
+		model.PlanetsHistoryColumns.Gravity,
+		model.PlanetsHistoryColumns.SurfaceWater,
+		model.PlanetsHistoryColumns.Population,
+	}
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES %s;",
+		model.TableNames.PlanetsHistory, strings.Join(columns, ", "), strings.Join(values, ",\n"))
+
+	_, err = exec.ExecContext(ctx, query, args...)
+	return err
+}
+
+// ParsePlanetVersion returns the planet as stored in a history version
+func ParsePlanetVersion(version *model.PlanetsHistory) *model.Planet {
+	return &model.Planet{
+		ID:             version.PlanetID,
+		CreatedAt:      version.CreatedAt,
+		UpdatedAt:      version.UpdatedAt,
+		DeletedAt:      version.DeletedAt,
+		Name:           version.Name,
+		Climates:       version.Climates,
+		Terrains:       version.Terrains,
+		RotationPeriod: version.RotationPeriod,
+		OrbitalPeriod:  version.OrbitalPeriod,
+		Diameter:       version.Diameter,
+		Gravity:        version.Gravity,
+		SurfaceWater:   version.SurfaceWater,
+		Population:     version.Population,
+	}
+}
@@ -9,24 +9,55 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/auth"
+	"github.com/viniosilva/starwars-api/internal/config"
 	"github.com/viniosilva/starwars-api/internal/dto"
 	"github.com/viniosilva/starwars-api/internal/exception"
 	"github.com/viniosilva/starwars-api/internal/model"
 	"github.com/viniosilva/starwars-api/internal/service"
+	"github.com/volatiletech/null/v8"
 )
 
 func Test_PlanetService_CreatePlanets(t *testing.T) {
 	climates, _ := json.Marshal([]string{"arid"})
 	terrains, _ := json.Marshal([]string{"desert"})
+	planetColumns := []string{model.PlanetColumns.ID, model.PlanetColumns.Name, model.PlanetColumns.Climates, model.PlanetColumns.Terrains}
+	expectSyncPlanetLookups := func(db sqlmock.Sqlmock) {
+		for _, lookup := range []string{"climate", "terrain"} {
+			db.ExpectExec(fmt.Sprintf("INSERT IGNORE INTO %ss \\(name\\) SELECT DISTINCT jt.name FROM planets INNER JOIN JSON_TABLE\\(planets.%ss", lookup, lookup)).
+				WithArgs(1).WillReturnResult(sqlmock.NewResult(1, 1))
+			db.ExpectExec(fmt.Sprintf("DELETE FROM planets_%ss WHERE planet_id IN \\(\\?\\);", lookup)).
+				WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+			db.ExpectExec(fmt.Sprintf("INSERT IGNORE INTO planets_%ss \\(planet_id, %s_id\\) SELECT planets.id, %ss.id", lookup, lookup, lookup)).
+				WithArgs(1).WillReturnResult(sqlmock.NewResult(1, 1))
+		}
+	}
 
 	var cases = map[string]struct {
 		mocking      func(db sqlmock.Sqlmock)
+		inputContext context.Context
 		inputPlanets []*model.Planet
+		inputOptions []service.Option
 		expectedErr  error
 	}{
 		"should create planets": {
 			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT `planets`.\\* FROM `planets` WHERE \\(`id` IN \\(\\?\\)\\)").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{model.PlanetColumns.ID}))
 				db.ExpectExec("INSERT IGNORE INTO").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectQuery("SELECT `planets`.\\* FROM `planets`").
+					WillReturnRows(sqlmock.NewRows(planetColumns).AddRow(1, "Tatooine", climates, terrains))
+				db.ExpectExec("INSERT INTO audit_log").
+					WithArgs(sqlmock.AnyArg(), "system", nil, "planet", 1, "create", nil, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("UPDATE `planets_history` SET `valid_to` = \\?").WillReturnResult(sqlmock.NewResult(0, 1))
+				db.ExpectExec("INSERT INTO planets_history").WillReturnResult(sqlmock.NewResult(1, 1))
+				expectSyncPlanetLookups(db)
+				db.ExpectExec("INSERT INTO outbox").
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), service.EVENT_PLANET_CREATED, "planet", 1, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectCommit()
 			},
 			inputPlanets: []*model.Planet{{
 				ID:        1,
@@ -37,9 +68,72 @@ func Test_PlanetService_CreatePlanets(t *testing.T) {
 				Terrains:  terrains,
 			}},
 		},
+		"should upsert planets": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT `planets`.\\* FROM `planets`").
+					WillReturnRows(sqlmock.NewRows(planetColumns).AddRow(1, "Tattoine", climates, terrains))
+				db.ExpectExec("INSERT INTO planets .* ON DUPLICATE KEY UPDATE").
+					WithArgs(1, "2014-12-09 13:50:49", "2014-12-20 20:58:18", "Tatooine", `["arid"]`, `["desert"]`, 23, 304, 10465, "1 standard", nil, 200000).
+					WillReturnResult(sqlmock.NewResult(1, 2))
+				db.ExpectQuery("SELECT `planets`.\\* FROM `planets`").
+					WillReturnRows(sqlmock.NewRows(planetColumns).AddRow(1, "Tatooine", climates, terrains))
+				db.ExpectExec("INSERT INTO audit_log").
+					WithArgs(sqlmock.AnyArg(), "luke", "request-id", "planet", 1, "update", sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("UPDATE `planets_history` SET `valid_to` = \\?").WillReturnResult(sqlmock.NewResult(0, 1))
+				db.ExpectExec("INSERT INTO planets_history").WillReturnResult(sqlmock.NewResult(1, 1))
+				expectSyncPlanetLookups(db)
+				db.ExpectExec("INSERT INTO outbox").
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), service.EVENT_PLANET_UPDATED, "planet", 1, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectCommit()
+			},
+			inputContext: config.WithRequestID(auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "luke"}), "request-id"),
+			inputPlanets: []*model.Planet{{
+				ID:             1,
+				CreatedAt:      time.Date(2014, 12, 9, 13, 50, 49, 641000, time.UTC),
+				UpdatedAt:      time.Date(2014, 12, 20, 20, 58, 18, 411000, time.UTC),
+				Name:           "Tatooine",
+				Climates:       climates,
+				Terrains:       terrains,
+				RotationPeriod: null.IntFrom(23),
+				OrbitalPeriod:  null.IntFrom(304),
+				Diameter:       null.IntFrom(10465),
+				Gravity:        null.StringFrom("1 standard"),
+				Population:     null.Int64From(200000),
+			}},
+			inputOptions: []service.Option{service.OptionUpsert()},
+		},
+		"should not upsert deleted planets": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT `planets`.\\* FROM `planets`").
+					WillReturnRows(sqlmock.NewRows([]string{model.PlanetColumns.ID, model.PlanetColumns.DeletedAt, model.PlanetColumns.Name}).
+						AddRow(1, time.Date(2022, 10, 9, 0, 0, 0, 0, time.UTC), "Tattoine"))
+				db.ExpectCommit()
+			},
+			inputPlanets: []*model.Planet{{ID: 1, Name: "Tatooine", Climates: climates, Terrains: terrains}},
+			inputOptions: []service.Option{service.OptionUpsert()},
+		},
+		"should not audit ignored planets": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT `planets`.\\* FROM `planets`").
+					WillReturnRows(sqlmock.NewRows(planetColumns).AddRow(1, "Tatooine", climates, terrains))
+				db.ExpectExec("INSERT IGNORE INTO").WillReturnResult(sqlmock.NewResult(0, 0))
+				db.ExpectQuery("SELECT `planets`.\\* FROM `planets`").
+					WillReturnRows(sqlmock.NewRows(planetColumns).AddRow(1, "Tatooine", climates, terrains))
+				db.ExpectCommit()
+			},
+			inputPlanets: []*model.Planet{{ID: 1, Name: "Tatooine", Climates: climates, Terrains: terrains}},
+		},
 		"should throw error when insert": {
 			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT `planets`.\\* FROM `planets`").WillReturnRows(sqlmock.NewRows([]string{model.PlanetColumns.ID}))
 				db.ExpectExec("INSERT IGNORE INTO").WillReturnError(fmt.Errorf("error"))
+				db.ExpectRollback()
 			},
 			inputPlanets: []*model.Planet{{
 				ID:        1,
@@ -51,6 +145,19 @@ func Test_PlanetService_CreatePlanets(t *testing.T) {
 			}},
 			expectedErr: fmt.Errorf("error"),
 		},
+		"should throw error when insert audit log": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT `planets`.\\* FROM `planets`").WillReturnRows(sqlmock.NewRows([]string{model.PlanetColumns.ID}))
+				db.ExpectExec("INSERT IGNORE INTO").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectQuery("SELECT `planets`.\\* FROM `planets`").
+					WillReturnRows(sqlmock.NewRows(planetColumns).AddRow(1, "Tatooine", climates, terrains))
+				db.ExpectExec("INSERT INTO audit_log").WillReturnError(fmt.Errorf("error"))
+				db.ExpectRollback()
+			},
+			inputPlanets: []*model.Planet{{ID: 1, Name: "Tatooine", Climates: climates, Terrains: terrains}},
+			expectedErr:  fmt.Errorf("error"),
+		},
 	}
 	for name, cs := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -65,11 +172,17 @@ func Test_PlanetService_CreatePlanets(t *testing.T) {
 
 			cs.mocking(mockDB)
 
+			ctx := cs.inputContext
+			if ctx == nil {
+				ctx = context.Background()
+			}
+
 			// when
-			err = planetService.CreatePlanets(context.Background(), cs.inputPlanets)
+			err = planetService.CreatePlanets(ctx, cs.inputPlanets, cs.inputOptions...)
 
 			// then
 			assert.Equal(t, cs.expectedErr, err)
+			assert.Nil(t, mockDB.ExpectationsWereMet())
 		})
 	}
 }
@@ -82,13 +195,61 @@ func Test_PlanetService_CreateRelationshipFilmsToPlanets(t *testing.T) {
 	}{
 		"should create relationships": {
 			mocking: func(db sqlmock.Sqlmock) {
-				db.ExpectExec("INSERT IGNORE INTO").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT planet_id, film_id FROM planets_films WHERE planet_id IN \\(\\?\\) FOR UPDATE;").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"planet_id", "film_id"}))
+				db.ExpectExec("INSERT IGNORE INTO planets_films \\(planet_id, film_id\\) VALUES \\(1, 1\\);").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("INSERT INTO audit_log").
+					WithArgs(sqlmock.AnyArg(), "system", nil, "planet", 1, "link_films", nil, []byte(`{"film_ids":[1]}`)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("INSERT INTO outbox").
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), service.EVENT_FILM_LINKED, "film", 1, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectCommit()
+			},
+			inputRelationships: map[int][]int{1: {1}},
+		},
+		"should create only missing relationships": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT planet_id, film_id FROM planets_films WHERE planet_id IN \\(\\?, \\?\\) FOR UPDATE;").WithArgs(1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"planet_id", "film_id"}).AddRow(1, 1).AddRow(2, 1))
+				db.ExpectExec("INSERT IGNORE INTO planets_films \\(planet_id, film_id\\) VALUES \\(1, 2\\);").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("INSERT INTO audit_log").
+					WithArgs(sqlmock.AnyArg(), "system", nil, "planet", 1, "link_films", nil, []byte(`{"film_ids":[2]}`)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("INSERT INTO outbox").
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), service.EVENT_FILM_LINKED, "film", 2, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectCommit()
+			},
+			inputRelationships: map[int][]int{1: {1, 2}, 2: {1}},
+		},
+		"should not audit nor publish when every relationship exists": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT planet_id, film_id FROM planets_films").
+					WillReturnRows(sqlmock.NewRows([]string{"planet_id", "film_id"}).AddRow(1, 1))
+				db.ExpectRollback()
+			},
+			inputRelationships: map[int][]int{1: {1, 1}},
+		},
+		"should throw error when lock relationships": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT planet_id, film_id FROM planets_films").WillReturnError(fmt.Errorf("error"))
+				db.ExpectRollback()
 			},
 			inputRelationships: map[int][]int{1: {1}},
+			expectedErr:        fmt.Errorf("error"),
 		},
 		"should throw error when insert": {
 			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT planet_id, film_id FROM planets_films").
+					WillReturnRows(sqlmock.NewRows([]string{"planet_id", "film_id"}))
 				db.ExpectExec("INSERT IGNORE INTO").WillReturnError(fmt.Errorf("error"))
+				db.ExpectRollback()
 			},
 			inputRelationships: map[int][]int{1: {1}},
 			expectedErr:        fmt.Errorf("error"),
@@ -112,6 +273,192 @@ func Test_PlanetService_CreateRelationshipFilmsToPlanets(t *testing.T) {
 
 			// then
 			assert.Equal(t, cs.expectedErr, err)
+			assert.Nil(t, mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_PlanetService_LinkFilmToPlanet(t *testing.T) {
+	deletedAt := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	planetColumns := []string{model.PlanetColumns.ID, model.PlanetColumns.DeletedAt}
+
+	var cases = map[string]struct {
+		mocking       func(db sqlmock.Sqlmock)
+		inputPlanetID int
+		inputFilmID   int
+		expectedErr   error
+	}{
+		"should link film to planet": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT `planets`.\\* FROM `planets` WHERE \\(id = \\?\\) LIMIT 1 FOR UPDATE").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(planetColumns).AddRow(1, nil))
+				db.ExpectQuery("SELECT COUNT\\(\\*\\) FROM `films` WHERE \\(id = \\?\\) LIMIT 1;").WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				db.ExpectExec("INSERT IGNORE INTO planets_films \\(planet_id, film_id\\) VALUES \\(\\?, \\?\\);").WithArgs(1, 2).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("INSERT INTO audit_log").
+					WithArgs(sqlmock.AnyArg(), "system", nil, "planet", 1, "link_films", nil, []byte(`{"film_ids":[2]}`)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("INSERT INTO outbox").
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), service.EVENT_FILM_LINKED, "film", 2, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectCommit()
+			},
+			inputPlanetID: 1,
+			inputFilmID:   2,
+		},
+		"should ignore film already linked": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows(planetColumns).AddRow(1, nil))
+				db.ExpectQuery("SELECT COUNT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				db.ExpectExec("INSERT IGNORE INTO planets_films").WillReturnResult(sqlmock.NewResult(0, 0))
+				db.ExpectRollback()
+			},
+			inputPlanetID: 1,
+			inputFilmID:   2,
+		},
+		"should throw not found exception when planet does not exist": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows(planetColumns))
+				db.ExpectRollback()
+			},
+			inputPlanetID: 1,
+			inputFilmID:   2,
+			expectedErr:   &exception.NotFoundException{Message: "planet 1 not found"},
+		},
+		"should throw conflict exception when planet is deleted": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows(planetColumns).AddRow(1, deletedAt))
+				db.ExpectRollback()
+			},
+			inputPlanetID: 1,
+			inputFilmID:   2,
+			expectedErr:   &exception.ConflictException{Message: "planet 1 is deleted"},
+		},
+		"should throw not found exception when film does not exist": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows(planetColumns).AddRow(1, nil))
+				db.ExpectQuery("SELECT COUNT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				db.ExpectRollback()
+			},
+			inputPlanetID: 1,
+			inputFilmID:   2,
+			expectedErr:   &exception.NotFoundException{Message: "film 2 not found"},
+		},
+		"should throw error when insert relationship": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows(planetColumns).AddRow(1, nil))
+				db.ExpectQuery("SELECT COUNT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				db.ExpectExec("INSERT IGNORE INTO planets_films").WillReturnError(fmt.Errorf("error"))
+				db.ExpectRollback()
+			},
+			inputPlanetID: 1,
+			inputFilmID:   2,
+			expectedErr:   fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			planetService := service.IPlanetService{DB: db}
+
+			cs.mocking(mockDB)
+
+			// when
+			err = planetService.LinkFilmToPlanet(context.Background(), cs.inputPlanetID, cs.inputFilmID)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Nil(t, mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_PlanetService_UnlinkFilmFromPlanet(t *testing.T) {
+	planetColumns := []string{model.PlanetColumns.ID, model.PlanetColumns.DeletedAt}
+
+	var cases = map[string]struct {
+		mocking       func(db sqlmock.Sqlmock)
+		inputPlanetID int
+		inputFilmID   int
+		expectedErr   error
+	}{
+		"should unlink film from planet": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT `planets`.\\* FROM `planets` WHERE \\(id = \\?\\) LIMIT 1 FOR UPDATE").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(planetColumns).AddRow(1, nil))
+				db.ExpectQuery("SELECT COUNT\\(\\*\\) FROM `films`").WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				db.ExpectExec("DELETE FROM planets_films WHERE planet_id = \\? AND film_id = \\?;").WithArgs(1, 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				db.ExpectExec("INSERT INTO audit_log").
+					WithArgs(sqlmock.AnyArg(), "system", nil, "planet", 1, "unlink_films", []byte(`{"film_ids":[2]}`), nil).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("INSERT INTO outbox").
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), service.EVENT_FILM_UNLINKED, "film", 2, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectCommit()
+			},
+			inputPlanetID: 1,
+			inputFilmID:   2,
+		},
+		"should throw not found exception when film is not linked": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows(planetColumns).AddRow(1, nil))
+				db.ExpectQuery("SELECT COUNT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				db.ExpectExec("DELETE FROM planets_films").WillReturnResult(sqlmock.NewResult(0, 0))
+				db.ExpectRollback()
+			},
+			inputPlanetID: 1,
+			inputFilmID:   2,
+			expectedErr:   &exception.NotFoundException{Message: "film 2 is not linked to planet 1"},
+		},
+		"should throw error when delete relationship": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows(planetColumns).AddRow(1, nil))
+				db.ExpectQuery("SELECT COUNT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				db.ExpectExec("DELETE FROM planets_films").WillReturnError(fmt.Errorf("error"))
+				db.ExpectRollback()
+			},
+			inputPlanetID: 1,
+			inputFilmID:   2,
+			expectedErr:   fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			planetService := service.IPlanetService{DB: db}
+
+			cs.mocking(mockDB)
+
+			// when
+			err = planetService.UnlinkFilmFromPlanet(context.Background(), cs.inputPlanetID, cs.inputFilmID)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Nil(t, mockDB.ExpectationsWereMet())
 		})
 	}
 }
@@ -253,11 +600,74 @@ func Test_PlanetService_FindPlanetsAndTotal(t *testing.T) {
 	}
 }
 
+func Test_PlanetService_FindPlanetsByIDs(t *testing.T) {
+	var cases = map[string]struct {
+		mocking        func(db sqlmock.Sqlmock)
+		inputPlanetIDs []int
+		expectedResult dto.FindPlanetsByIDsResult
+		expectedErr    error
+	}{
+		"should return planets in requested order with missing ids": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT `planets`.\\* FROM `planets` WHERE \\(`id` IN \\(\\?,\\?,\\?\\)\\) AND \\(deleted_at IS NULL\\);").
+					WithArgs(3, 1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{model.PlanetColumns.ID, model.PlanetColumns.Name}).
+						AddRow(1, "Tatooine").
+						AddRow(3, "Yavin IV"))
+			},
+			inputPlanetIDs: []int{3, 1, 2, 3},
+			expectedResult: dto.FindPlanetsByIDsResult{
+				Data:    []*model.Planet{{ID: 3, Name: "Yavin IV"}, {ID: 1, Name: "Tatooine"}},
+				Missing: []int{2},
+			},
+		},
+		"should return nothing when there are no ids": {
+			mocking:        func(db sqlmock.Sqlmock) {},
+			inputPlanetIDs: []int{},
+			expectedResult: dto.FindPlanetsByIDsResult{Data: []*model.Planet{}, Missing: []int{}},
+		},
+		"should throw error when planets all": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error"))
+			},
+			inputPlanetIDs: []int{1},
+			expectedErr:    fmt.Errorf("models: failed to assign all query results to Planet slice: bind failed to execute query: error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			planetService := service.IPlanetService{DB: db}
+
+			cs.mocking(mockDB)
+
+			// when
+			res, err := planetService.FindPlanetsByIDs(context.Background(), cs.inputPlanetIDs, false)
+
+			// then
+			assert.Equal(t, cs.expectedResult, res)
+			if cs.expectedErr != nil {
+				assert.EqualError(t, err, cs.expectedErr.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Nil(t, mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
 func Test_PlanetService_FindPlanetByID(t *testing.T) {
 	var cases = map[string]struct {
 		mocking        func(db sqlmock.Sqlmock)
 		inputPlanetID  int
 		inputLoadFilms bool
+		inputOptions   []service.Option
 		expectedPlanet *model.Planet
 		expectedErr    error
 	}{
@@ -268,6 +678,15 @@ func Test_PlanetService_FindPlanetByID(t *testing.T) {
 			inputPlanetID:  1,
 			expectedPlanet: &model.Planet{ID: 1},
 		},
+		"should return planet selecting only name": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT `planets`.`id`, `planets`.`name` FROM `planets`").
+					WillReturnRows(sqlmock.NewRows([]string{model.PlanetColumns.ID, model.PlanetColumns.Name}).AddRow(1, "Tatooine"))
+			},
+			inputPlanetID:  1,
+			inputOptions:   []service.Option{service.OptionSelect(model.PlanetColumns.Name)},
+			expectedPlanet: &model.Planet{ID: 1, Name: "Tatooine"},
+		},
 		"should throw not found exception": {
 			mocking: func(db sqlmock.Sqlmock) {
 				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{model.PlanetColumns.ID}))
@@ -275,6 +694,27 @@ func Test_PlanetService_FindPlanetByID(t *testing.T) {
 			inputPlanetID: 1,
 			expectedErr:   &exception.NotFoundException{Message: "planet 1 not found"},
 		},
+		"should return planet as of time": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT `planets_history`.\\* FROM `planets_history` .* ORDER BY valid_from DESC, id DESC").
+					WithArgs(1, time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)).
+					WillReturnRows(sqlmock.NewRows([]string{model.PlanetsHistoryColumns.ID, model.PlanetsHistoryColumns.PlanetID, model.PlanetsHistoryColumns.Name}).
+						AddRow(3, 1, "Tatooine"))
+			},
+			inputPlanetID:  1,
+			inputOptions:   []service.Option{service.OptionAsOf(time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC))},
+			expectedPlanet: &model.Planet{ID: 1, Name: "Tatooine"},
+		},
+		"should throw not found exception when planet was deleted as of time": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT `planets_history`.\\* FROM `planets_history`").
+					WillReturnRows(sqlmock.NewRows([]string{model.PlanetsHistoryColumns.ID, model.PlanetsHistoryColumns.PlanetID, model.PlanetsHistoryColumns.DeletedAt}).
+						AddRow(3, 1, time.Date(2022, 9, 1, 0, 0, 0, 0, time.UTC)))
+			},
+			inputPlanetID: 1,
+			inputOptions:  []service.Option{service.OptionAsOf(time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC))},
+			expectedErr:   &exception.NotFoundException{Message: "planet 1 not found"},
+		},
 	}
 	for name, cs := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -290,7 +730,7 @@ func Test_PlanetService_FindPlanetByID(t *testing.T) {
 			cs.mocking(mockDB)
 
 			// when
-			planet, err := planetService.FindPlanetByID(context.Background(), cs.inputPlanetID, cs.inputLoadFilms)
+			planet, err := planetService.FindPlanetByID(context.Background(), cs.inputPlanetID, cs.inputLoadFilms, cs.inputOptions...)
 
 			// then
 			assert.Equal(t, cs.expectedPlanet, planet)
@@ -300,6 +740,10 @@ func Test_PlanetService_FindPlanetByID(t *testing.T) {
 }
 
 func Test_PlanetService_DeletePlanet(t *testing.T) {
+	climates, _ := json.Marshal([]string{"arid"})
+	terrains, _ := json.Marshal([]string{"desert"})
+	planetColumns := []string{model.PlanetColumns.ID, model.PlanetColumns.Name, model.PlanetColumns.Climates, model.PlanetColumns.Terrains}
+
 	var cases = map[string]struct {
 		mocking       func(db sqlmock.Sqlmock)
 		inputPlanetID int
@@ -307,7 +751,27 @@ func Test_PlanetService_DeletePlanet(t *testing.T) {
 	}{
 		"should delete planet": {
 			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT `planets`.\\* FROM `planets` .* FOR UPDATE").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(planetColumns).AddRow(1, "Tatooine", climates, terrains))
 				db.ExpectExec("UPDATE").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("INSERT INTO audit_log").
+					WithArgs(sqlmock.AnyArg(), "system", nil, "planet", 1, "delete", sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("UPDATE `planets_history` SET `valid_to` = \\?").WillReturnResult(sqlmock.NewResult(0, 1))
+				db.ExpectExec("INSERT INTO planets_history").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("INSERT INTO outbox").
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), service.EVENT_PLANET_DELETED, "planet", 1, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectCommit()
+			},
+			inputPlanetID: 1,
+		},
+		"should ignore planet not found": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{model.PlanetColumns.ID}))
+				db.ExpectRollback()
 			},
 			inputPlanetID: 1,
 		},
@@ -330,6 +794,492 @@ func Test_PlanetService_DeletePlanet(t *testing.T) {
 
 			// then
 			assert.Equal(t, cs.expectedErr, err)
+			assert.Nil(t, mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_PlanetService_RestorePlanetTx(t *testing.T) {
+	climates, _ := json.Marshal([]string{"arid"})
+	terrains, _ := json.Marshal([]string{"desert"})
+	deletedAt := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	planetColumns := []string{model.PlanetColumns.ID, model.PlanetColumns.DeletedAt, model.PlanetColumns.Name, model.PlanetColumns.Climates, model.PlanetColumns.Terrains}
+
+	var cases = map[string]struct {
+		mocking        func(db sqlmock.Sqlmock)
+		inputPlanetID  int
+		expectedPlanet *model.Planet
+		expectedErr    error
+	}{
+		"should restore planet": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT `planets`.\\* FROM `planets` WHERE \\(id = \\?\\) AND \\(deleted_at IS NOT NULL\\) LIMIT 1 FOR UPDATE").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(planetColumns).AddRow(1, deletedAt, "Tatooine", climates, terrains))
+				db.ExpectExec("UPDATE `planets` SET `deleted_at` = \\?").WithArgs(nil, 1).WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("INSERT INTO audit_log").
+					WithArgs(sqlmock.AnyArg(), "system", nil, "planet", 1, "restore", sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("UPDATE `planets_history` SET `valid_to` = \\?").WillReturnResult(sqlmock.NewResult(0, 1))
+				db.ExpectExec("INSERT INTO planets_history").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("INSERT INTO outbox").
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), service.EVENT_PLANET_RESTORED, "planet", 1, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			inputPlanetID:  1,
+			expectedPlanet: &model.Planet{ID: 1, Name: "Tatooine", Climates: climates, Terrains: terrains},
+		},
+		"should throw not found exception when planet is not deleted": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows(planetColumns))
+			},
+			inputPlanetID: 1,
+			expectedErr:   &exception.NotFoundException{Message: "deleted planet 1 not found"},
+		},
+		"should throw error when update planet": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows(planetColumns).AddRow(1, deletedAt, "Tatooine", climates, terrains))
+				db.ExpectExec("UPDATE").WillReturnError(fmt.Errorf("error"))
+			},
+			inputPlanetID: 1,
+			expectedErr:   fmt.Errorf("models: unable to update all for planets: error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			planetService := service.IPlanetService{DB: db}
+
+			mockDB.ExpectBegin()
+			cs.mocking(mockDB)
+			tx, _ := db.Begin()
+
+			// when
+			planet, err := planetService.RestorePlanetTx(context.Background(), tx, cs.inputPlanetID)
+
+			// then
+			assert.Equal(t, cs.expectedPlanet, planet)
+			if cs.expectedErr != nil {
+				assert.EqualError(t, err, cs.expectedErr.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Nil(t, mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_PlanetService_PatchPlanetTx(t *testing.T) {
+	climates, _ := json.Marshal([]string{"arid"})
+	terrains, _ := json.Marshal([]string{"desert"})
+	patchedClimates, _ := json.Marshal([]string{"arid", "temperate"})
+	planetColumns := []string{model.PlanetColumns.ID, model.PlanetColumns.Name, model.PlanetColumns.Climates, model.PlanetColumns.Terrains}
+	name := "Tatooine II"
+	blank := " "
+	diameter := 10465
+
+	var cases = map[string]struct {
+		mocking        func(db sqlmock.Sqlmock)
+		inputPlanetID  int
+		inputPatch     dto.PlanetPatch
+		expectedPlanet *model.Planet
+		expectedErr    error
+	}{
+		"should patch planet": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT `planets`.\\* FROM `planets` WHERE \\(id = \\?\\) AND \\(deleted_at IS NULL\\) LIMIT 1 FOR UPDATE").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(planetColumns).AddRow(1, "Tatooine", climates, terrains))
+				db.ExpectExec("UPDATE `planets` SET").WillReturnResult(sqlmock.NewResult(1, 1))
+				for _, lookup := range []string{"climates", "terrains"} {
+					db.ExpectExec("INSERT IGNORE INTO " + lookup).WithArgs(1).WillReturnResult(sqlmock.NewResult(1, 1))
+					db.ExpectExec("DELETE FROM planets_" + lookup).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+					db.ExpectExec("INSERT IGNORE INTO planets_" + lookup).WithArgs(1).WillReturnResult(sqlmock.NewResult(1, 1))
+				}
+				db.ExpectExec("INSERT INTO audit_log").
+					WithArgs(sqlmock.AnyArg(), "system", nil, "planet", 1, "update", sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("UPDATE `planets_history` SET `valid_to` = \\?").WillReturnResult(sqlmock.NewResult(0, 1))
+				db.ExpectExec("INSERT INTO planets_history").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("INSERT INTO outbox").
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), service.EVENT_PLANET_UPDATED, "planet", 1, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			inputPlanetID:  1,
+			inputPatch:     dto.PlanetPatch{Name: &name, Climates: &[]string{"arid", "temperate"}, Diameter: &diameter},
+			expectedPlanet: &model.Planet{ID: 1, Name: name, Climates: patchedClimates, Terrains: terrains, Diameter: null.IntFrom(diameter)},
+		},
+		"should not record patch without changes": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows(planetColumns).AddRow(1, "Tatooine", climates, terrains))
+			},
+			inputPlanetID:  1,
+			inputPatch:     dto.PlanetPatch{Climates: &[]string{"arid"}},
+			expectedPlanet: &model.Planet{ID: 1, Name: "Tatooine", Climates: climates, Terrains: terrains},
+		},
+		"should throw validation exception when patch is empty": {
+			mocking:       func(db sqlmock.Sqlmock) {},
+			inputPlanetID: 1,
+			expectedErr:   &exception.ValidationException{Message: "patch is required"},
+		},
+		"should throw validation exception when name is blank": {
+			mocking:       func(db sqlmock.Sqlmock) {},
+			inputPlanetID: 1,
+			inputPatch:    dto.PlanetPatch{Name: &blank},
+			expectedErr:   &exception.ValidationException{Message: "name is required"},
+		},
+		"should throw not found exception": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows(planetColumns))
+			},
+			inputPlanetID: 1,
+			inputPatch:    dto.PlanetPatch{Name: &name},
+			expectedErr:   &exception.NotFoundException{Message: "planet 1 not found"},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			planetService := service.IPlanetService{DB: db}
+
+			mockDB.ExpectBegin()
+			cs.mocking(mockDB)
+			tx, _ := db.Begin()
+
+			// when
+			planet, err := planetService.PatchPlanetTx(context.Background(), tx, cs.inputPlanetID, cs.inputPatch)
+
+			// then
+			if planet != nil {
+				planet.UpdatedAt = time.Time{}
+			}
+			assert.Equal(t, cs.expectedPlanet, planet)
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Nil(t, mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_PlanetService_FindPlanetHistory(t *testing.T) {
+	var cases = map[string]struct {
+		mocking          func(db sqlmock.Sqlmock)
+		inputPlanetID    int
+		expectedVersions []*model.PlanetsHistory
+		expectedErr      error
+	}{
+		"should return planet versions": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT `planets_history`.\\* FROM `planets_history` WHERE \\(planet_id = \\?\\) ORDER BY valid_from, id").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{model.PlanetsHistoryColumns.ID, model.PlanetsHistoryColumns.PlanetID, model.PlanetsHistoryColumns.Name}).
+						AddRow(1, 1, "Tattoine").
+						AddRow(2, 1, "Tatooine"))
+			},
+			inputPlanetID: 1,
+			expectedVersions: []*model.PlanetsHistory{
+				{ID: 1, PlanetID: 1, Name: "Tattoine"},
+				{ID: 2, PlanetID: 1, Name: "Tatooine"},
+			},
+		},
+		"should throw not found exception": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{model.PlanetsHistoryColumns.ID}))
+			},
+			inputPlanetID: 1,
+			expectedErr:   &exception.NotFoundException{Message: "planet 1 not found"},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			planetService := service.IPlanetService{DB: db}
+
+			cs.mocking(mockDB)
+
+			// when
+			versions, err := planetService.FindPlanetHistory(context.Background(), cs.inputPlanetID)
+
+			// then
+			assert.Equal(t, cs.expectedVersions, versions)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func Test_PlanetService_FindPlanetsByFilmIDs(t *testing.T) {
+	var cases = map[string]struct {
+		mocking          func(db sqlmock.Sqlmock)
+		inputFilmIDs     []int
+		expectedPlanets  map[int][]*model.Planet
+		expectedErrorMsg string
+	}{
+		"should return planets grouped by film": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{model.PlanetColumns.ID, "film_id"}).
+					AddRow(1, 1).AddRow(2, 1).AddRow(1, 2))
+			},
+			inputFilmIDs: []int{1, 2},
+			expectedPlanets: map[int][]*model.Planet{
+				1: {{ID: 1}, {ID: 2}},
+				2: {{ID: 1}},
+			},
+		},
+		"should return empty map when there are no film ids": {
+			mocking:         func(db sqlmock.Sqlmock) {},
+			inputFilmIDs:    []int{},
+			expectedPlanets: map[int][]*model.Planet{},
+		},
+		"should throw error when select": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error"))
+			},
+			inputFilmIDs:     []int{1},
+			expectedErrorMsg: "bind failed to execute query: error",
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			planetService := service.IPlanetService{DB: db}
+
+			cs.mocking(mockDB)
+
+			// when
+			planets, err := planetService.FindPlanetsByFilmIDs(context.Background(), cs.inputFilmIDs)
+
+			// then
+			assert.Equal(t, cs.expectedPlanets, planets)
+			if cs.expectedErrorMsg != "" {
+				assert.EqualError(t, err, cs.expectedErrorMsg)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func Test_PlanetService_LoadPlanetsFilms(t *testing.T) {
+	var cases = map[string]struct {
+		mocking          func(db sqlmock.Sqlmock)
+		inputPlanets     []*model.Planet
+		inputColumns     []string
+		expectedFilms    []model.FilmSlice
+		expectedErrorMsg string
+	}{
+		"should load only selected film columns": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT `films`.`id` .*, `films`.`title` .*, `planets_films`.`planet_id` .* FROM `films`").
+					WillReturnRows(sqlmock.NewRows([]string{model.FilmColumns.ID, model.FilmColumns.Title, "planet_id"}).
+						AddRow(1, "A New Hope", 1))
+			},
+			inputPlanets:  []*model.Planet{{ID: 1}, {ID: 2}},
+			inputColumns:  []string{model.FilmColumns.Title},
+			expectedFilms: []model.FilmSlice{{{ID: 1, Title: "A New Hope"}}, nil},
+		},
+		"should throw error when select": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error"))
+			},
+			inputPlanets:     []*model.Planet{{ID: 1}},
+			inputColumns:     []string{model.FilmColumns.Title},
+			expectedErrorMsg: "bind failed to execute query: error",
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			cs.mocking(mockDB)
+
+			// when
+			err = service.LoadPlanetsFilms(context.Background(), db, cs.inputPlanets, cs.inputColumns)
+
+			// then
+			if cs.expectedErrorMsg != "" {
+				assert.EqualError(t, err, cs.expectedErrorMsg)
+				return
+			}
+
+			assert.Nil(t, err)
+			for i, p := range cs.inputPlanets {
+				assert.Equal(t, cs.expectedFilms[i], p.R.Films)
+			}
+		})
+	}
+}
+
+func Test_PlanetService_StreamPlanets(t *testing.T) {
+	streamColumns := []string{
+		model.PlanetColumns.ID, model.PlanetColumns.CreatedAt, model.PlanetColumns.UpdatedAt,
+		model.PlanetColumns.Name, model.PlanetColumns.Climates, model.PlanetColumns.Terrains,
+		model.PlanetColumns.RotationPeriod, model.PlanetColumns.OrbitalPeriod, model.PlanetColumns.Diameter,
+		model.PlanetColumns.Gravity, model.PlanetColumns.SurfaceWater, model.PlanetColumns.Population,
+	}
+
+	var cases = map[string]struct {
+		mocking          func(db sqlmock.Sqlmock)
+		inputOptions     []service.Option
+		inputFnErr       error
+		expectedPlanets  []*model.Planet
+		expectedErrorMsg string
+	}{
+		"should stream planets": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows(streamColumns).
+					AddRow(1, time.Time{}, time.Time{}, "Tatooine", []byte(`["arid"]`), []byte(`["desert"]`), 23, 304, 10465, "1 standard", 1, 200000).
+					AddRow(2, time.Time{}, time.Time{}, "Alderaan", []byte(`["temperate"]`), []byte(`["mountains"]`), nil, nil, nil, nil, nil, nil))
+			},
+			inputOptions: []service.Option{service.OptionWhere("name like ?", "%a%")},
+			expectedPlanets: []*model.Planet{
+				{
+					ID: 1, Name: "Tatooine", Climates: []byte(`["arid"]`), Terrains: []byte(`["desert"]`),
+					RotationPeriod: null.IntFrom(23), OrbitalPeriod: null.IntFrom(304), Diameter: null.IntFrom(10465),
+					Gravity: null.StringFrom("1 standard"), SurfaceWater: null.Float64From(1), Population: null.Int64From(200000),
+				},
+				{ID: 2, Name: "Alderaan", Climates: []byte(`["temperate"]`), Terrains: []byte(`["mountains"]`)},
+			},
+		},
+		"should stream deleted planets": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT .*`deleted_at` FROM `planets` ORDER BY id;").
+					WillReturnRows(sqlmock.NewRows(append(streamColumns, model.PlanetColumns.DeletedAt)).
+						AddRow(2, time.Time{}, time.Time{}, "Alderaan", []byte(`["temperate"]`), []byte(`["mountains"]`), nil, nil, nil, nil, nil, nil, time.Date(2022, 10, 9, 0, 0, 0, 0, time.UTC)))
+			},
+			inputOptions: []service.Option{service.OptionWithDeleted()},
+			expectedPlanets: []*model.Planet{{
+				ID: 2, Name: "Alderaan", Climates: []byte(`["temperate"]`), Terrains: []byte(`["mountains"]`),
+				DeletedAt: null.TimeFrom(time.Date(2022, 10, 9, 0, 0, 0, 0, time.UTC)),
+			}},
+		},
+		"should throw error when query": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error"))
+			},
+			expectedErrorMsg: "error",
+		},
+		"should throw error when fn fails": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows(streamColumns).
+					AddRow(1, time.Time{}, time.Time{}, "Tatooine", []byte(`[]`), []byte(`[]`), nil, nil, nil, nil, nil, nil))
+			},
+			inputFnErr:       fmt.Errorf("write error"),
+			expectedPlanets:  []*model.Planet{{ID: 1, Name: "Tatooine", Climates: []byte(`[]`), Terrains: []byte(`[]`)}},
+			expectedErrorMsg: "write error",
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			planetService := service.IPlanetService{DB: db}
+
+			cs.mocking(mockDB)
+
+			// when
+			var planets []*model.Planet
+			err = planetService.StreamPlanets(context.Background(), func(planet *model.Planet) error {
+				planets = append(planets, planet)
+				return cs.inputFnErr
+			}, cs.inputOptions...)
+
+			// then
+			assert.Equal(t, cs.expectedPlanets, planets)
+			if cs.expectedErrorMsg != "" {
+				assert.EqualError(t, err, cs.expectedErrorMsg)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func Test_PlanetService_StreamPlanetsFilms(t *testing.T) {
+	var cases = map[string]struct {
+		mocking          func(db sqlmock.Sqlmock)
+		inputOptions     []service.Option
+		expectedRows     [][2]int
+		expectedErrorMsg string
+	}{
+		"should stream planets films": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT `planets_films`.`planet_id`.*INNER JOIN planets_films.* WHERE \\(planets.deleted_at IS NULL\\)").
+					WillReturnRows(sqlmock.NewRows([]string{"planet_id", "film_id"}).AddRow(1, 1).AddRow(1, 3))
+			},
+			expectedRows: [][2]int{{1, 1}, {1, 3}},
+		},
+		"should stream planets films of deleted planets": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT `planets_films`.`planet_id`.*INNER JOIN planets_films ON planets.id = planets_films.planet_id ORDER BY").
+					WillReturnRows(sqlmock.NewRows([]string{"planet_id", "film_id"}).AddRow(2, 1))
+			},
+			inputOptions: []service.Option{service.OptionWithDeleted()},
+			expectedRows: [][2]int{{2, 1}},
+		},
+		"should throw error when query": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error"))
+			},
+			expectedErrorMsg: "error",
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			planetService := service.IPlanetService{DB: db}
+
+			cs.mocking(mockDB)
+
+			// when
+			var rows [][2]int
+			err = planetService.StreamPlanetsFilms(context.Background(), func(planetID, filmID int) error {
+				rows = append(rows, [2]int{planetID, filmID})
+				return nil
+			}, cs.inputOptions...)
+
+			// then
+			assert.Equal(t, cs.expectedRows, rows)
+			if cs.expectedErrorMsg != "" {
+				assert.EqualError(t, err, cs.expectedErrorMsg)
+			} else {
+				assert.Nil(t, err)
+			}
 		})
 	}
 }
@@ -0,0 +1,311 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"html"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/sirupsen/logrus"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+const (
+	SEARCH_TYPE_PLANET = "planet"
+	SEARCH_TYPE_FILM   = "film"
+
+	SEARCH_MAX_TERMS         = 10
+	SEARCH_MIN_TERM_LENGTH   = 2
+	SEARCH_CANDIDATES_FACTOR = 4
+)
+
+var SearchTypes = []string{SEARCH_TYPE_PLANET, SEARCH_TYPE_FILM}
+
+//go:generate mockgen -destination=../../mock/search_service_mock.go -package=mock . SearchService
+type SearchService interface {
+	Search(ctx context.Context, query string, types []string, limit int) ([]dto.SearchResult, error)
+}
+
+type ISearchService struct {
+	DB *sql.DB
+}
+
+type planetWithScore struct {
+	model.Planet `boil:",bind"`
+	Score        float64 `boil:"score"`
+}
+
+type filmWithScore struct {
+	model.Film `boil:",bind"`
+	Score      float64 `boil:"score"`
+}
+
+type searchField struct {
+	name   string
+	text   string
+	weight float64
+}
+
+// Search finds candidates with the ngram FULLTEXT indexes, which tolerate
+// typos, and ranks them by how well their words match the terms of query,
+// using the FULLTEXT relevance to break ties. Candidates without any word
+// close enough to a term are dropped
+func (impl *ISearchService) Search(ctx context.Context, query string, types []string, limit int) ([]dto.SearchResult, error) {
+	terms := SearchTerms(query)
+	if len(terms) == 0 {
+		return []dto.SearchResult{}, nil
+	}
+	against := strings.Join(terms, " ")
+	candidates := limit * SEARCH_CANDIDATES_FACTOR
+
+	results := []dto.SearchResult{}
+	if includesType(types, SEARCH_TYPE_PLANET) {
+		planets, err := impl.searchPlanets(ctx, against, candidates)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range planets {
+			planet := p.Planet
+			fields := []searchField{
+				{name: "name", text: planet.Name, weight: 2},
+				{name: "climates", text: joinJSONList(planet.Climates), weight: 1},
+				{name: "terrains", text: joinJSONList(planet.Terrains), weight: 1},
+			}
+			if res, ok := rankSearchResult(SEARCH_TYPE_PLANET, fields, terms, p.Score); ok {
+				res.Planet = &planet
+				results = append(results, res)
+			}
+		}
+	}
+	if includesType(types, SEARCH_TYPE_FILM) {
+		films, err := impl.searchFilms(ctx, against, candidates)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range films {
+			film := f.Film
+			fields := []searchField{
+				{name: "title", text: film.Title, weight: 2},
+				{name: "director", text: film.Director, weight: 1},
+			}
+			if res, ok := rankSearchResult(SEARCH_TYPE_FILM, fields, terms, f.Score); ok {
+				res.Film = &film
+				results = append(results, res)
+			}
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	return results, nil
+}
+
+func (impl *ISearchService) searchPlanets(ctx context.Context, against string, limit int) ([]*planetWithScore, error) {
+	match := fmt.Sprintf("(MATCH(%s) AGAINST (? IN NATURAL LANGUAGE MODE) * 2 + MATCH(search_attributes) AGAINST (? IN NATURAL LANGUAGE MODE))",
+		model.PlanetColumns.Name)
+	query := fmt.Sprintf("SELECT %s.*, %s AS score FROM %s WHERE %s IS NULL AND %s > 0 ORDER BY score DESC LIMIT ?",
+		model.TableNames.Planets, match, model.TableNames.Planets, model.PlanetColumns.DeletedAt, match)
+
+	var rows []*planetWithScore
+	err := queries.Raw(query, against, against, against, against, limit).Bind(ctx, impl.DB, &rows)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.search.search_planets:queries.bind"}).Error(err)
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func (impl *ISearchService) searchFilms(ctx context.Context, against string, limit int) ([]*filmWithScore, error) {
+	match := fmt.Sprintf("MATCH(%s, %s) AGAINST (? IN NATURAL LANGUAGE MODE)", model.FilmColumns.Title, model.FilmColumns.Director)
+	query := fmt.Sprintf("SELECT %s.*, %s AS score FROM %s WHERE %s ORDER BY score DESC LIMIT ?",
+		model.TableNames.Films, match, model.TableNames.Films, match)
+
+	var rows []*filmWithScore
+	err := queries.Raw(query, against, against, limit).Bind(ctx, impl.DB, &rows)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.search.search_films:queries.bind"}).Error(err)
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// SearchTerms returns the distinct lower case words of query, ignoring the
+// ones too short to be indexed
+func SearchTerms(query string) []string {
+	terms := []string{}
+	seen := map[string]bool{}
+	for _, word := range strings.FieldsFunc(strings.ToLower(query), isSeparator) {
+		if len([]rune(word)) < SEARCH_MIN_TERM_LENGTH || seen[word] {
+			continue
+		}
+		seen[word] = true
+		terms = append(terms, word)
+		if len(terms) == SEARCH_MAX_TERMS {
+			break
+		}
+	}
+
+	return terms
+}
+
+// Highlight wraps the words of text matching any of the terms in <em> tags
+// and returns the best match quality of each term
+func Highlight(text string, terms []string) (string, []float64) {
+	qualities := make([]float64, len(terms))
+	builder := strings.Builder{}
+	runes := []rune(text)
+	matched := false
+
+	for i := 0; i < len(runes); {
+		j := i
+		for j < len(runes) && isSeparator(runes[j]) == isSeparator(runes[i]) {
+			j += 1
+		}
+		segment := string(runes[i:j])
+
+		best := 0.0
+		if !isSeparator(runes[i]) {
+			word := strings.ToLower(segment)
+			for k, term := range terms {
+				q := matchQuality(word, term)
+				if q > qualities[k] {
+					qualities[k] = q
+				}
+				if q > best {
+					best = q
+				}
+			}
+		}
+
+		if best > 0 {
+			matched = true
+			builder.WriteString("<em>" + html.EscapeString(segment) + "</em>")
+		} else {
+			builder.WriteString(html.EscapeString(segment))
+		}
+		i = j
+	}
+
+	if !matched {
+		return "", qualities
+	}
+	return builder.String(), qualities
+}
+
+func rankSearchResult(searchType string, fields []searchField, terms []string, relevance float64) (dto.SearchResult, bool) {
+	highlights := map[string]string{}
+	best := make([]float64, len(terms))
+	for _, f := range fields {
+		highlighted, qualities := Highlight(f.text, terms)
+		if highlighted == "" {
+			continue
+		}
+		highlights[f.name] = highlighted
+		for i, q := range qualities {
+			best[i] = math.Max(best[i], q*f.weight)
+		}
+	}
+	if len(highlights) == 0 {
+		return dto.SearchResult{}, false
+	}
+
+	score := relevance / (1 + relevance)
+	for _, q := range best {
+		score += q
+	}
+
+	return dto.SearchResult{
+		Type:       searchType,
+		Score:      math.Round(score*1000) / 1000,
+		Highlights: highlights,
+	}, true
+}
+
+// matchQuality is 1 for the same word, less for a word starting with term or
+// a few edits away from it and 0 otherwise
+func matchQuality(word, term string) float64 {
+	if word == term {
+		return 1
+	}
+	length := len([]rune(term))
+	if length >= 3 && strings.HasPrefix(word, term) {
+		return 0.8
+	}
+
+	maxEdits := 0
+	if length >= 8 {
+		maxEdits = 2
+	} else if length >= 4 {
+		maxEdits = 1
+	}
+	if maxEdits == 0 {
+		return 0
+	}
+
+	distance := levenshtein([]rune(word), []rune(term))
+	if distance > maxEdits {
+		return 0
+	}
+
+	return 0.8 - 0.2*float64(distance)
+}
+
+func levenshtein(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := 0; j <= len(b); j += 1 {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i += 1 {
+		current[0] = i
+		for j := 1; j <= len(b); j += 1 {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(minInt(previous[j]+1, current[j-1]+1), previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
+
+func isSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+func includesType(types []string, searchType string) bool {
+	if len(types) == 0 {
+		return true
+	}
+	for _, t := range types {
+		if t == searchType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func joinJSONList(raw []byte) string {
+	var list []string
+	json.Unmarshal(raw, &list)
+
+	return strings.Join(list, ", ")
+}
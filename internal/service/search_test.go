@@ -0,0 +1,158 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/viniosilva/starwars-api/internal/service"
+	"github.com/volatiletech/null/v8"
+)
+
+func Test_SearchService_Search(t *testing.T) {
+	date := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	planetColumns := []string{"id", "created_at", "updated_at", "deleted_at", "name", "climates", "terrains", "search_attributes", "score"}
+	filmColumns := []string{"id", "created_at", "updated_at", "title", "episode", "director", "release_date", "score"}
+
+	var cases = map[string]struct {
+		mocking         func(db sqlmock.Sqlmock)
+		inputQuery      string
+		inputTypes      []string
+		expectedResults []dto.SearchResult
+		expectedErr     error
+	}{
+		"should rank planets tolerating typos": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT planets.\\*, \\(MATCH\\(name\\) AGAINST \\(\\? IN NATURAL LANGUAGE MODE\\) \\* 2 \\+ MATCH\\(search_attributes\\) AGAINST \\(\\? IN NATURAL LANGUAGE MODE\\)\\) AS score FROM planets WHERE deleted_at IS NULL").
+					WithArgs("tatoine desrt", "tatoine desrt", "tatoine desrt", "tatoine desrt", 40).
+					WillReturnRows(sqlmock.NewRows(planetColumns).
+						AddRow(2, date, date, nil, "Naboo", []byte(`["temperate"]`), []byte(`["grassy hills"]`), "temperate grassy hills", 3).
+						AddRow(1, date, date, nil, "Tatooine", []byte(`["arid"]`), []byte(`["desert"]`), "arid desert", 1))
+			},
+			inputQuery: "Tatoine, desrt",
+			inputTypes: []string{service.SEARCH_TYPE_PLANET},
+			expectedResults: []dto.SearchResult{{
+				Type:  service.SEARCH_TYPE_PLANET,
+				Score: 2.3,
+				Highlights: map[string]string{
+					"name":     "<em>Tatooine</em>",
+					"terrains": "<em>desert</em>",
+				},
+				Planet: &model.Planet{
+					ID:               1,
+					CreatedAt:        date,
+					UpdatedAt:        date,
+					Name:             "Tatooine",
+					Climates:         []byte(`["arid"]`),
+					Terrains:         []byte(`["desert"]`),
+					SearchAttributes: null.StringFrom("arid desert"),
+				},
+			}},
+		},
+		"should find films by director": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT films.\\*, MATCH\\(title, director\\) AGAINST \\(\\? IN NATURAL LANGUAGE MODE\\) AS score FROM films").
+					WithArgs("lucas", "lucas", 40).
+					WillReturnRows(sqlmock.NewRows(filmColumns).
+						AddRow(1, date, date, "A New Hope", 4, "George Lucas", time.Date(1977, 5, 25, 0, 0, 0, 0, time.UTC), 3))
+			},
+			inputQuery: "lucas",
+			inputTypes: []string{service.SEARCH_TYPE_FILM},
+			expectedResults: []dto.SearchResult{{
+				Type:       service.SEARCH_TYPE_FILM,
+				Score:      1.75,
+				Highlights: map[string]string{"director": "George <em>Lucas</em>"},
+				Film: &model.Film{
+					ID:          1,
+					CreatedAt:   date,
+					UpdatedAt:   date,
+					Title:       "A New Hope",
+					Episode:     4,
+					Director:    "George Lucas",
+					ReleaseDate: time.Date(1977, 5, 25, 0, 0, 0, 0, time.UTC),
+				},
+			}},
+		},
+		"should not query without terms": {
+			mocking:         func(db sqlmock.Sqlmock) {},
+			inputQuery:      "a !",
+			expectedResults: []dto.SearchResult{},
+		},
+		"should throw error when query planets": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT planets").WillReturnError(fmt.Errorf("error"))
+			},
+			inputQuery:  "tatooine",
+			expectedErr: fmt.Errorf("bind failed to execute query: error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			searchService := service.ISearchService{DB: db}
+
+			cs.mocking(mockDB)
+
+			// when
+			results, err := searchService.Search(context.Background(), cs.inputQuery, cs.inputTypes, 10)
+
+			// then
+			assert.Equal(t, cs.expectedResults, results)
+			if cs.expectedErr != nil {
+				assert.EqualError(t, err, cs.expectedErr.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Nil(t, mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_Highlight(t *testing.T) {
+	var cases = map[string]struct {
+		inputText         string
+		inputTerms        []string
+		expectedHighlight string
+		expectedQualities []float64
+	}{
+		"should highlight exact, prefix and fuzzy matches": {
+			inputText:         "Return of the Jedi",
+			inputTerms:        []string{"jedi", "retu", "teh"},
+			expectedHighlight: "<em>Return</em> of the <em>Jedi</em>",
+			expectedQualities: []float64{1, 0.8, 0},
+		},
+		"should escape html": {
+			inputText:         "Hoth <ice>",
+			inputTerms:        []string{"hoth"},
+			expectedHighlight: "<em>Hoth</em> &lt;ice&gt;",
+			expectedQualities: []float64{1},
+		},
+		"should return empty without matches": {
+			inputText:         "Naboo",
+			inputTerms:        []string{"hoth"},
+			expectedHighlight: "",
+			expectedQualities: []float64{0},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// when
+			highlight, qualities := service.Highlight(cs.inputText, cs.inputTerms)
+
+			// then
+			assert.Equal(t, cs.expectedHighlight, highlight)
+			assert.Equal(t, cs.expectedQualities, qualities)
+		})
+	}
+}
@@ -0,0 +1,291 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+const (
+	STATS_CACHE_TTL         = time.Minute
+	STATS_CACHE_MAX_ENTRIES = 1000
+	STATS_KEY_CLIMATE       = "climates"
+	STATS_KEY_TERRAIN       = "terrains"
+)
+
+//go:generate mockgen -destination=../../mock/stats_service_mock.go -package=mock . StatsService
+type StatsService interface {
+	CountPlanetsByClimate(ctx context.Context, opts ...Option) ([]dto.StatsCountDto, error)
+	CountPlanetsByTerrain(ctx context.Context, opts ...Option) ([]dto.StatsCountDto, error)
+	CountPlanetsByFilm(ctx context.Context, opts ...Option) ([]dto.StatsEntityCountDto, error)
+	CountFilmsPerPlanet(ctx context.Context, opts ...Option) ([]dto.StatsDistributionDto, error)
+	FindTopPlanets(ctx context.Context, limit int, opts ...Option) ([]dto.StatsEntityCountDto, error)
+}
+
+// IStatsService keeps the aggregations in memory for TTL, or STATS_CACHE_TTL
+// when it is not set, holding at most MaxEntries of them, or
+// STATS_CACHE_MAX_ENTRIES, since filters are client supplied. As an
+// EventPublisher it drops the cache whenever the relay publishes a change
+type IStatsService struct {
+	DB         *sql.DB
+	TTL        time.Duration
+	MaxEntries int
+
+	mutex      sync.Mutex
+	cache      map[string]statsCacheEntry
+	generation int
+}
+
+type statsCacheEntry struct {
+	expiresAt time.Time
+	value     interface{}
+}
+
+type planetFilmsCount struct {
+	ID    int `boil:"id"`
+	Count int `boil:"count"`
+}
+
+func (impl *IStatsService) Publish(ctx context.Context, events ...dto.Event) error {
+	impl.mutex.Lock()
+	defer impl.mutex.Unlock()
+
+	impl.cache = nil
+	impl.generation += 1
+	return nil
+}
+
+func (impl *IStatsService) CountPlanetsByClimate(ctx context.Context, opts ...Option) ([]dto.StatsCountDto, error) {
+	return impl.countPlanetsByLookup(ctx, climateLookup, STATS_KEY_CLIMATE, opts)
+}
+
+func (impl *IStatsService) CountPlanetsByTerrain(ctx context.Context, opts ...Option) ([]dto.StatsCountDto, error) {
+	return impl.countPlanetsByLookup(ctx, terrainLookup, STATS_KEY_TERRAIN, opts)
+}
+
+// countPlanetsByLookup counts the planets of each value of the lookup table,
+// joining it through its indexed join table
+func (impl *IStatsService) countPlanetsByLookup(ctx context.Context, lookup planetLookup, key string, opts []Option) ([]dto.StatsCountDto, error) {
+	value, err := impl.cached(statsCacheKey(key, opts), func() (interface{}, error) {
+		qms := []qm.QueryMod{
+			qm.Select(fmt.Sprintf("%s.name AS value", lookup.table), "COUNT(*) AS count"),
+			qm.InnerJoin(fmt.Sprintf("%s ON %s.planet_id = %s", lookup.joinTable, lookup.joinTable, model.PlanetTableColumns.ID)),
+			qm.InnerJoin(fmt.Sprintf("%s ON %s.id = %s.%s", lookup.table, lookup.table, lookup.joinTable, lookup.joinColumn)),
+			qm.Where(fmt.Sprintf("%s IS NULL", model.PlanetTableColumns.DeletedAt)),
+			qm.GroupBy(fmt.Sprintf("%s.name", lookup.table)),
+			qm.OrderBy("count DESC, value"),
+		}
+		qms = append(qms, GetOptionsWhere(opts)...)
+
+		rows := []dto.StatsCountDto{}
+		if err := model.Planets(qms...).Bind(ctx, impl.DB, &rows); err != nil {
+			logrus.WithFields(logrus.Fields{"trace": "internal.service.stats.count_planets_by_lookup:planets.bind"}).Error(err)
+			return nil, err
+		}
+
+		return rows, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return value.([]dto.StatsCountDto), nil
+}
+
+// CountPlanetsByFilm counts the planets matching opts that appear in each
+// film, including the films without any
+func (impl *IStatsService) CountPlanetsByFilm(ctx context.Context, opts ...Option) ([]dto.StatsEntityCountDto, error) {
+	value, err := impl.cached(statsCacheKey("planets_by_film", opts), func() (interface{}, error) {
+		filter, args := joinOptionsWhere(opts)
+
+		rows := []dto.StatsEntityCountDto{}
+		err := model.Films(
+			qm.Select(fmt.Sprintf("%s AS id", model.FilmTableColumns.ID), fmt.Sprintf("%s AS name", model.FilmTableColumns.Title), fmt.Sprintf("COUNT(%s) AS count", model.PlanetTableColumns.ID)),
+			qm.LeftOuterJoin(fmt.Sprintf("%s ON %s.film_id = %s", model.TableNames.PlanetsFilms, model.TableNames.PlanetsFilms, model.FilmTableColumns.ID)),
+			qm.LeftOuterJoin(fmt.Sprintf("%s ON %s = %s.planet_id AND %s IS NULL%s",
+				model.TableNames.Planets, model.PlanetTableColumns.ID, model.TableNames.PlanetsFilms, model.PlanetTableColumns.DeletedAt, filter), args...),
+			qm.GroupBy(fmt.Sprintf("%s, %s", model.FilmTableColumns.ID, model.FilmTableColumns.Title)),
+			qm.OrderBy(fmt.Sprintf("count DESC, %s", model.FilmTableColumns.ID)),
+		).Bind(ctx, impl.DB, &rows)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{"trace": "internal.service.stats.count_planets_by_film:films.bind"}).Error(err)
+			return nil, err
+		}
+
+		return rows, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return value.([]dto.StatsEntityCountDto), nil
+}
+
+// CountFilmsPerPlanet returns how many planets matching opts appear in each
+// number of films
+func (impl *IStatsService) CountFilmsPerPlanet(ctx context.Context, opts ...Option) ([]dto.StatsDistributionDto, error) {
+	value, err := impl.cached(statsCacheKey("films_per_planet", opts), func() (interface{}, error) {
+		qms := []qm.QueryMod{
+			qm.Select(fmt.Sprintf("%s AS id", model.PlanetTableColumns.ID), fmt.Sprintf("COUNT(%s.film_id) AS count", model.TableNames.PlanetsFilms)),
+			qm.LeftOuterJoin(fmt.Sprintf("%s ON %s.planet_id = %s", model.TableNames.PlanetsFilms, model.TableNames.PlanetsFilms, model.PlanetTableColumns.ID)),
+			qm.Where(fmt.Sprintf("%s IS NULL", model.PlanetTableColumns.DeletedAt)),
+			qm.GroupBy(model.PlanetTableColumns.ID),
+		}
+		qms = append(qms, GetOptionsWhere(opts)...)
+
+		var rows []*planetFilmsCount
+		if err := model.Planets(qms...).Bind(ctx, impl.DB, &rows); err != nil {
+			logrus.WithFields(logrus.Fields{"trace": "internal.service.stats.count_films_per_planet:planets.bind"}).Error(err)
+			return nil, err
+		}
+
+		planets := map[int]int{}
+		for _, r := range rows {
+			planets[r.Count] += 1
+		}
+
+		distribution := []dto.StatsDistributionDto{}
+		for films, count := range planets {
+			distribution = append(distribution, dto.StatsDistributionDto{Films: films, Planets: count})
+		}
+		sort.Slice(distribution, func(i, j int) bool {
+			return distribution[i].Films < distribution[j].Films
+		})
+
+		return distribution, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return value.([]dto.StatsDistributionDto), nil
+}
+
+// FindTopPlanets returns the planets matching opts that appear in the most
+// films
+func (impl *IStatsService) FindTopPlanets(ctx context.Context, limit int, opts ...Option) ([]dto.StatsEntityCountDto, error) {
+	value, err := impl.cached(statsCacheKey(fmt.Sprintf("top_planets:%d", limit), opts), func() (interface{}, error) {
+		qms := []qm.QueryMod{
+			qm.Select(fmt.Sprintf("%s AS id", model.PlanetTableColumns.ID), fmt.Sprintf("%s AS name", model.PlanetTableColumns.Name), fmt.Sprintf("COUNT(%s.film_id) AS count", model.TableNames.PlanetsFilms)),
+			qm.InnerJoin(fmt.Sprintf("%s ON %s.planet_id = %s", model.TableNames.PlanetsFilms, model.TableNames.PlanetsFilms, model.PlanetTableColumns.ID)),
+			qm.Where(fmt.Sprintf("%s IS NULL", model.PlanetTableColumns.DeletedAt)),
+			qm.GroupBy(fmt.Sprintf("%s, %s", model.PlanetTableColumns.ID, model.PlanetTableColumns.Name)),
+			qm.OrderBy(fmt.Sprintf("count DESC, %s", model.PlanetTableColumns.ID)),
+			qm.Limit(limit),
+		}
+		qms = append(qms, GetOptionsWhere(opts)...)
+
+		rows := []dto.StatsEntityCountDto{}
+		if err := model.Planets(qms...).Bind(ctx, impl.DB, &rows); err != nil {
+			logrus.WithFields(logrus.Fields{"trace": "internal.service.stats.find_top_planets:planets.bind"}).Error(err)
+			return nil, err
+		}
+
+		return rows, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return value.([]dto.StatsEntityCountDto), nil
+}
+
+func (impl *IStatsService) cached(key string, load func() (interface{}, error)) (interface{}, error) {
+	now := time.Now()
+
+	impl.mutex.Lock()
+	entry, ok := impl.cache[key]
+	if ok && !now.Before(entry.expiresAt) {
+		delete(impl.cache, key)
+		ok = false
+	}
+	generation := impl.generation
+	impl.mutex.Unlock()
+	if ok {
+		return entry.value, nil
+	}
+
+	value, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := impl.TTL
+	if ttl <= 0 {
+		ttl = STATS_CACHE_TTL
+	}
+
+	impl.mutex.Lock()
+	defer impl.mutex.Unlock()
+	if impl.generation != generation {
+		return value, nil
+	}
+	if impl.cache == nil {
+		impl.cache = map[string]statsCacheEntry{}
+	}
+	impl.evict(now)
+	impl.cache[key] = statsCacheEntry{expiresAt: now.Add(ttl), value: value}
+
+	return value, nil
+}
+
+// evict drops the expired entries and, while the cache is still full, the
+// entries closest to expiring. It must be called holding the mutex
+func (impl *IStatsService) evict(now time.Time) {
+	for key, entry := range impl.cache {
+		if !now.Before(entry.expiresAt) {
+			delete(impl.cache, key)
+		}
+	}
+
+	maxEntries := impl.MaxEntries
+	if maxEntries <= 0 {
+		maxEntries = STATS_CACHE_MAX_ENTRIES
+	}
+
+	for len(impl.cache) >= maxEntries {
+		oldest := ""
+		for key, entry := range impl.cache {
+			if oldest == "" || entry.expiresAt.Before(impl.cache[oldest].expiresAt) {
+				oldest = key
+			}
+		}
+		delete(impl.cache, oldest)
+	}
+}
+
+func statsCacheKey(name string, opts []Option) string {
+	parts := []string{name}
+	for _, opt := range opts {
+		if opt != nil && opt.name() == string(whereOption) {
+			parts = append(parts, fmt.Sprint(opt.value()))
+		}
+	}
+
+	return strings.Join(parts, "|")
+}
+
+// joinOptionsWhere turns the where options into a condition appended to a
+// join clause, so that the rows they filter are still counted as zero
+func joinOptionsWhere(opts []Option) (string, []interface{}) {
+	filter := ""
+	args := []interface{}{}
+	for _, opt := range opts {
+		if opt != nil && opt.name() == string(whereOption) {
+			v := opt.value().([]interface{})
+			filter += fmt.Sprintf(" AND (%s)", v[0].(string))
+			args = append(args, v[1])
+		}
+	}
+
+	return filter, args
+}
@@ -0,0 +1,232 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/service"
+)
+
+func Test_StatsService_CountPlanetsByClimate(t *testing.T) {
+	var cases = map[string]struct {
+		mocking        func(db sqlmock.Sqlmock)
+		inputOpts      []service.Option
+		expectedCounts []dto.StatsCountDto
+		expectedErr    error
+	}{
+		"should count planets by climate": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT climates.name AS value, COUNT\\(\\*\\) AS count FROM `planets` INNER JOIN planets_climates ON planets_climates.planet_id = planets.id INNER JOIN climates ON climates.id = planets_climates.climate_id WHERE \\(planets.deleted_at IS NULL\\) AND \\(planets.name like \\?\\) GROUP BY climates.name ORDER BY count DESC, value;").
+					WithArgs("T%").
+					WillReturnRows(sqlmock.NewRows([]string{"value", "count"}).
+						AddRow("arid", 2).
+						AddRow("temperate", 1))
+			},
+			inputOpts: []service.Option{service.OptionWhere("planets.name like ?", "T%")},
+			expectedCounts: []dto.StatsCountDto{
+				{Value: "arid", Count: 2},
+				{Value: "temperate", Count: 1},
+			},
+		},
+		"should throw error when bind": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("bind failed to execute query: error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			statsService := &service.IStatsService{DB: db}
+
+			cs.mocking(mockDB)
+
+			// when
+			counts, err := statsService.CountPlanetsByClimate(context.Background(), cs.inputOpts...)
+
+			// then
+			assert.Equal(t, cs.expectedCounts, counts)
+			if cs.expectedErr != nil {
+				assert.EqualError(t, err, cs.expectedErr.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Nil(t, mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_StatsService_CountFilmsPerPlanet(t *testing.T) {
+	// given
+	db, mockDB, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	statsService := &service.IStatsService{DB: db}
+
+	mockDB.ExpectQuery("SELECT planets.id AS id, COUNT\\(planets_films.film_id\\) AS count FROM `planets` LEFT JOIN planets_films ON planets_films.planet_id = planets.id WHERE \\(planets.deleted_at IS NULL\\) GROUP BY planets.id;").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "count"}).
+			AddRow(1, 5).
+			AddRow(2, 0).
+			AddRow(3, 5).
+			AddRow(4, 1))
+
+	// when
+	distribution, err := statsService.CountFilmsPerPlanet(context.Background())
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, []dto.StatsDistributionDto{
+		{Films: 0, Planets: 1},
+		{Films: 1, Planets: 1},
+		{Films: 5, Planets: 2},
+	}, distribution)
+	assert.Nil(t, mockDB.ExpectationsWereMet())
+}
+
+func Test_StatsService_CountPlanetsByFilm(t *testing.T) {
+	// given
+	db, mockDB, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	statsService := &service.IStatsService{DB: db}
+
+	mockDB.ExpectQuery("SELECT films.id AS id, films.title AS name, COUNT\\(planets.id\\) AS count FROM `films` LEFT JOIN planets_films ON planets_films.film_id = films.id LEFT JOIN planets ON planets.id = planets_films.planet_id AND planets.deleted_at IS NULL AND \\(planets.name like \\?\\) GROUP BY films.id, films.title ORDER BY count DESC, films.id;").
+		WithArgs("Tatooine").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "count"}).
+			AddRow(1, "A New Hope", 1).
+			AddRow(2, "The Empire Strikes Back", 0))
+
+	// when
+	counts, err := statsService.CountPlanetsByFilm(context.Background(), service.OptionWhere("planets.name like ?", "Tatooine"))
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, []dto.StatsEntityCountDto{
+		{ID: 1, Name: "A New Hope", Count: 1},
+		{ID: 2, Name: "The Empire Strikes Back", Count: 0},
+	}, counts)
+	assert.Nil(t, mockDB.ExpectationsWereMet())
+}
+
+func Test_StatsService_FindTopPlanets(t *testing.T) {
+	// given
+	db, mockDB, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	statsService := &service.IStatsService{DB: db}
+
+	mockDB.ExpectQuery("SELECT planets.id AS id, planets.name AS name, COUNT\\(planets_films.film_id\\) AS count FROM `planets` INNER JOIN planets_films ON planets_films.planet_id = planets.id WHERE \\(planets.deleted_at IS NULL\\) GROUP BY planets.id, planets.name ORDER BY count DESC, planets.id LIMIT 3;").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "count"}).
+			AddRow(1, "Tatooine", 5).
+			AddRow(8, "Naboo", 4))
+
+	// when
+	planets, err := statsService.FindTopPlanets(context.Background(), 3)
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, []dto.StatsEntityCountDto{
+		{ID: 1, Name: "Tatooine", Count: 5},
+		{ID: 8, Name: "Naboo", Count: 4},
+	}, planets)
+	assert.Nil(t, mockDB.ExpectationsWereMet())
+}
+
+func Test_StatsService_Cache(t *testing.T) {
+	// given
+	db, mockDB, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	statsService := &service.IStatsService{DB: db}
+
+	mockDB.ExpectQuery("SELECT terrains.name AS value").
+		WillReturnRows(sqlmock.NewRows([]string{"value", "count"}).AddRow("desert", 1))
+	mockDB.ExpectQuery("SELECT terrains.name AS value").
+		WillReturnRows(sqlmock.NewRows([]string{"value", "count"}).AddRow("desert", 2))
+
+	// when
+	first, _ := statsService.CountPlanetsByTerrain(context.Background())
+	cached, _ := statsService.CountPlanetsByTerrain(context.Background())
+	statsService.Publish(context.Background(), dto.Event{Type: service.EVENT_PLANET_DELETED})
+	refreshed, _ := statsService.CountPlanetsByTerrain(context.Background())
+
+	// then
+	assert.Equal(t, []dto.StatsCountDto{{Value: "desert", Count: 1}}, first)
+	assert.Equal(t, first, cached)
+	assert.Equal(t, []dto.StatsCountDto{{Value: "desert", Count: 2}}, refreshed)
+	assert.Nil(t, mockDB.ExpectationsWereMet())
+}
+
+func Test_StatsService_CacheEviction(t *testing.T) {
+	var cases = map[string]struct {
+		inputStatsService func(db *sql.DB) *service.IStatsService
+		inputNames        []string
+		expectedQueries   int
+	}{
+		"should reload expired entries": {
+			inputStatsService: func(db *sql.DB) *service.IStatsService {
+				return &service.IStatsService{DB: db, TTL: time.Nanosecond}
+			},
+			inputNames:      []string{"Tatooine", "Tatooine"},
+			expectedQueries: 2,
+		},
+		"should evict the oldest entry when full": {
+			inputStatsService: func(db *sql.DB) *service.IStatsService {
+				return &service.IStatsService{DB: db, MaxEntries: 2}
+			},
+			inputNames:      []string{"Tatooine", "Naboo", "Hoth", "Naboo", "Tatooine"},
+			expectedQueries: 4,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			statsService := cs.inputStatsService(db)
+			for i := 0; i < cs.expectedQueries; i += 1 {
+				mockDB.ExpectQuery("SELECT terrains.name AS value").
+					WillReturnRows(sqlmock.NewRows([]string{"value", "count"}).AddRow("desert", 1))
+			}
+
+			// when
+			for _, n := range cs.inputNames {
+				_, err = statsService.CountPlanetsByTerrain(context.Background(), service.OptionWhere("planets.name like ?", n))
+				time.Sleep(time.Millisecond)
+				assert.Nil(t, err)
+			}
+
+			// then
+			assert.Nil(t, mockDB.ExpectationsWereMet())
+		})
+	}
+}
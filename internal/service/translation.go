@@ -0,0 +1,351 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/viniosilva/starwars-api/internal/exception"
+	"github.com/viniosilva/starwars-api/internal/i18n"
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+//go:generate mockgen -destination=../../mock/translation_service_mock.go -package=mock . TranslationService
+type TranslationService interface {
+	TranslatePlanets(ctx context.Context, locale string, planets []*model.Planet) error
+	TranslateFilms(ctx context.Context, locale string, films []*model.Film) error
+	SavePlanetTranslation(ctx context.Context, planetID int, locale, name string) error
+	DeletePlanetTranslation(ctx context.Context, planetID int, locale string) error
+	SaveFilmTranslation(ctx context.Context, filmID int, locale, title string) error
+	DeleteFilmTranslation(ctx context.Context, filmID int, locale string) error
+}
+
+type ITranslationService struct {
+	DB *sql.DB
+}
+
+// TranslatePlanets replaces the names of planets, and the titles of their
+// loaded films, by their locale translations. Untranslated ones keep the original
+func (impl *ITranslationService) TranslatePlanets(ctx context.Context, locale string, planets []*model.Planet) error {
+	if locale == i18n.DEFAULT_LOCALE || len(planets) == 0 {
+		return nil
+	}
+
+	planetIDs := make([]interface{}, len(planets))
+	films := []*model.Film{}
+	for i := 0; i < len(planets); i += 1 {
+		planetIDs[i] = planets[i].ID
+		if planets[i].R != nil {
+			films = append(films, planets[i].R.Films...)
+		}
+	}
+
+	translations, err := model.PlanetsTranslations(
+		qm.Where(fmt.Sprintf("%s = ?", model.PlanetsTranslationColumns.Locale), locale),
+		qm.WhereIn(fmt.Sprintf("%s IN ?", model.PlanetsTranslationColumns.PlanetID), planetIDs...),
+	).All(ctx, impl.DB)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.translation.translate_planets:planets_translations.all"}).Error(err)
+		return err
+	}
+
+	names := map[int]string{}
+	for _, t := range translations {
+		names[t.PlanetID] = t.Name
+	}
+	for _, p := range planets {
+		if name, ok := names[p.ID]; ok {
+			p.Name = name
+		}
+	}
+
+	return impl.TranslateFilms(ctx, locale, films)
+}
+
+// TranslateFilms replaces the titles of films by their locale translations.
+// Untranslated ones keep the original
+func (impl *ITranslationService) TranslateFilms(ctx context.Context, locale string, films []*model.Film) error {
+	if locale == i18n.DEFAULT_LOCALE || len(films) == 0 {
+		return nil
+	}
+
+	filmIDs := make([]interface{}, len(films))
+	for i := 0; i < len(films); i += 1 {
+		filmIDs[i] = films[i].ID
+	}
+
+	translations, err := model.FilmsTranslations(
+		qm.Where(fmt.Sprintf("%s = ?", model.FilmsTranslationColumns.Locale), locale),
+		qm.WhereIn(fmt.Sprintf("%s IN ?", model.FilmsTranslationColumns.FilmID), filmIDs...),
+	).All(ctx, impl.DB)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.translation.translate_films:films_translations.all"}).Error(err)
+		return err
+	}
+
+	titles := map[int]string{}
+	for _, t := range translations {
+		titles[t.FilmID] = t.Title
+	}
+	for _, f := range films {
+		if title, ok := titles[f.ID]; ok {
+			f.Title = title
+		}
+	}
+
+	return nil
+}
+
+// SavePlanetTranslation creates or replaces the locale name of a planet not
+// deleted, recording it in the audit log in the same transaction
+func (impl *ITranslationService) SavePlanetTranslation(ctx context.Context, planetID int, locale, name string) error {
+	tx, err := impl.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.translation.save_planet_translation:db.begin_tx"}).Error(err)
+		return err
+	}
+
+	exists, err := model.Planets(
+		qm.Where(fmt.Sprintf("%s = ?", model.PlanetColumns.ID), planetID),
+		qm.Where(fmt.Sprintf("%s IS NULL", model.PlanetColumns.DeletedAt)),
+	).Exists(ctx, tx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.translation.save_planet_translation:planets.exists"}).Error(err)
+		rollback(tx, "internal.service.translation.save_planet_translation:tx.rollback")
+		return err
+	}
+	if !exists {
+		rollback(tx, "internal.service.translation.save_planet_translation:tx.rollback")
+		return &exception.NotFoundException{Message: fmt.Sprintf("planet %d not found", planetID)}
+	}
+
+	var before interface{}
+	translation, err := model.PlanetsTranslations(
+		qm.Where(fmt.Sprintf("%s = ?", model.PlanetsTranslationColumns.PlanetID), planetID),
+		qm.Where(fmt.Sprintf("%s = ?", model.PlanetsTranslationColumns.Locale), locale),
+	).One(ctx, tx)
+	if err != nil && err != sql.ErrNoRows {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.translation.save_planet_translation:planets_translations.one"}).Error(err)
+		rollback(tx, "internal.service.translation.save_planet_translation:tx.rollback")
+		return err
+	}
+
+	now := time.Now()
+	after := &model.PlanetsTranslation{PlanetID: planetID, Locale: locale, CreatedAt: now, UpdatedAt: now, Name: name}
+	if translation != nil {
+		before = translation
+		after.CreatedAt = translation.CreatedAt
+	}
+
+	columns := []string{
+		model.PlanetsTranslationColumns.PlanetID,
+		model.PlanetsTranslationColumns.Locale,
+		model.PlanetsTranslationColumns.CreatedAt,
+		model.PlanetsTranslationColumns.UpdatedAt,
+		model.PlanetsTranslationColumns.Name,
+	}
+	args := []interface{}{planetID, locale, after.CreatedAt.Format("2006-01-02 15:04:05"), now.Format("2006-01-02 15:04:05"), name}
+	query := buildUpsertTranslationQuery(model.TableNames.PlanetsTranslations, columns, model.PlanetsTranslationColumns.Name)
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.translation.save_planet_translation:tx.exec_context"}).Error(err)
+		rollback(tx, "internal.service.translation.save_planet_translation:tx.rollback")
+		return err
+	}
+
+	log, err := NewAuditLog(ctx, AUDIT_ENTITY_PLANET, planetID, AUDIT_ACTION_TRANSLATE, before, after)
+	if err != nil {
+		rollback(tx, "internal.service.translation.save_planet_translation:tx.rollback")
+		return err
+	}
+	if err := InsertAuditLogs(ctx, tx, []*model.AuditLog{log}); err != nil {
+		rollback(tx, "internal.service.translation.save_planet_translation:tx.rollback")
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.translation.save_planet_translation:tx.commit"}).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// DeletePlanetTranslation removes the locale name of a planet, recording it in
+// the audit log in the same transaction. Translations not found are left untouched
+func (impl *ITranslationService) DeletePlanetTranslation(ctx context.Context, planetID int, locale string) error {
+	tx, err := impl.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.translation.delete_planet_translation:db.begin_tx"}).Error(err)
+		return err
+	}
+
+	wheres := []qm.QueryMod{
+		qm.Where(fmt.Sprintf("%s = ?", model.PlanetsTranslationColumns.PlanetID), planetID),
+		qm.Where(fmt.Sprintf("%s = ?", model.PlanetsTranslationColumns.Locale), locale),
+	}
+	translation, err := model.PlanetsTranslations(wheres...).One(ctx, tx)
+	if err == sql.ErrNoRows {
+		rollback(tx, "internal.service.translation.delete_planet_translation:tx.rollback")
+		return nil
+	}
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.translation.delete_planet_translation:planets_translations.one"}).Error(err)
+		rollback(tx, "internal.service.translation.delete_planet_translation:tx.rollback")
+		return err
+	}
+
+	if _, err := model.PlanetsTranslations(wheres...).DeleteAll(ctx, tx); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.translation.delete_planet_translation:planets_translations.delete_all"}).Error(err)
+		rollback(tx, "internal.service.translation.delete_planet_translation:tx.rollback")
+		return err
+	}
+
+	log, err := NewAuditLog(ctx, AUDIT_ENTITY_PLANET, planetID, AUDIT_ACTION_TRANSLATE, translation, nil)
+	if err != nil {
+		rollback(tx, "internal.service.translation.delete_planet_translation:tx.rollback")
+		return err
+	}
+	if err := InsertAuditLogs(ctx, tx, []*model.AuditLog{log}); err != nil {
+		rollback(tx, "internal.service.translation.delete_planet_translation:tx.rollback")
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.translation.delete_planet_translation:tx.commit"}).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// SaveFilmTranslation creates or replaces the locale title of a film,
+// recording it in the audit log in the same transaction
+func (impl *ITranslationService) SaveFilmTranslation(ctx context.Context, filmID int, locale, title string) error {
+	tx, err := impl.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.translation.save_film_translation:db.begin_tx"}).Error(err)
+		return err
+	}
+
+	exists, err := model.Films(qm.Where(fmt.Sprintf("%s = ?", model.FilmColumns.ID), filmID)).Exists(ctx, tx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.translation.save_film_translation:films.exists"}).Error(err)
+		rollback(tx, "internal.service.translation.save_film_translation:tx.rollback")
+		return err
+	}
+	if !exists {
+		rollback(tx, "internal.service.translation.save_film_translation:tx.rollback")
+		return &exception.NotFoundException{Message: fmt.Sprintf("film %d not found", filmID)}
+	}
+
+	var before interface{}
+	translation, err := model.FilmsTranslations(
+		qm.Where(fmt.Sprintf("%s = ?", model.FilmsTranslationColumns.FilmID), filmID),
+		qm.Where(fmt.Sprintf("%s = ?", model.FilmsTranslationColumns.Locale), locale),
+	).One(ctx, tx)
+	if err != nil && err != sql.ErrNoRows {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.translation.save_film_translation:films_translations.one"}).Error(err)
+		rollback(tx, "internal.service.translation.save_film_translation:tx.rollback")
+		return err
+	}
+
+	now := time.Now()
+	after := &model.FilmsTranslation{FilmID: filmID, Locale: locale, CreatedAt: now, UpdatedAt: now, Title: title}
+	if translation != nil {
+		before = translation
+		after.CreatedAt = translation.CreatedAt
+	}
+
+	columns := []string{
+		model.FilmsTranslationColumns.FilmID,
+		model.FilmsTranslationColumns.Locale,
+		model.FilmsTranslationColumns.CreatedAt,
+		model.FilmsTranslationColumns.UpdatedAt,
+		model.FilmsTranslationColumns.Title,
+	}
+	args := []interface{}{filmID, locale, after.CreatedAt.Format("2006-01-02 15:04:05"), now.Format("2006-01-02 15:04:05"), title}
+	query := buildUpsertTranslationQuery(model.TableNames.FilmsTranslations, columns, model.FilmsTranslationColumns.Title)
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.translation.save_film_translation:tx.exec_context"}).Error(err)
+		rollback(tx, "internal.service.translation.save_film_translation:tx.rollback")
+		return err
+	}
+
+	log, err := NewAuditLog(ctx, AUDIT_ENTITY_FILM, filmID, AUDIT_ACTION_TRANSLATE, before, after)
+	if err != nil {
+		rollback(tx, "internal.service.translation.save_film_translation:tx.rollback")
+		return err
+	}
+	if err := InsertAuditLogs(ctx, tx, []*model.AuditLog{log}); err != nil {
+		rollback(tx, "internal.service.translation.save_film_translation:tx.rollback")
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.translation.save_film_translation:tx.commit"}).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// DeleteFilmTranslation removes the locale title of a film, recording it in
+// the audit log in the same transaction. Translations not found are left untouched
+func (impl *ITranslationService) DeleteFilmTranslation(ctx context.Context, filmID int, locale string) error {
+	tx, err := impl.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.translation.delete_film_translation:db.begin_tx"}).Error(err)
+		return err
+	}
+
+	wheres := []qm.QueryMod{
+		qm.Where(fmt.Sprintf("%s = ?", model.FilmsTranslationColumns.FilmID), filmID),
+		qm.Where(fmt.Sprintf("%s = ?", model.FilmsTranslationColumns.Locale), locale),
+	}
+	translation, err := model.FilmsTranslations(wheres...).One(ctx, tx)
+	if err == sql.ErrNoRows {
+		rollback(tx, "internal.service.translation.delete_film_translation:tx.rollback")
+		return nil
+	}
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.translation.delete_film_translation:films_translations.one"}).Error(err)
+		rollback(tx, "internal.service.translation.delete_film_translation:tx.rollback")
+		return err
+	}
+
+	if _, err := model.FilmsTranslations(wheres...).DeleteAll(ctx, tx); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.translation.delete_film_translation:films_translations.delete_all"}).Error(err)
+		rollback(tx, "internal.service.translation.delete_film_translation:tx.rollback")
+		return err
+	}
+
+	log, err := NewAuditLog(ctx, AUDIT_ENTITY_FILM, filmID, AUDIT_ACTION_TRANSLATE, translation, nil)
+	if err != nil {
+		rollback(tx, "internal.service.translation.delete_film_translation:tx.rollback")
+		return err
+	}
+	if err := InsertAuditLogs(ctx, tx, []*model.AuditLog{log}); err != nil {
+		rollback(tx, "internal.service.translation.delete_film_translation:tx.rollback")
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.translation.delete_film_translation:tx.commit"}).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// buildUpsertTranslationQuery inserts a translation row or, when the entity
+// already has one in the locale, updates its value keeping created_at
+func buildUpsertTranslationQuery(table string, columns []string, valueColumn string) string {
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ")
+
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) ON DUPLICATE KEY UPDATE updated_at = VALUES(updated_at), %s = VALUES(%s);",
+		table, strings.Join(columns, ", "), placeholders, valueColumn, valueColumn)
+}
@@ -0,0 +1,328 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/exception"
+	"github.com/viniosilva/starwars-api/internal/i18n"
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/viniosilva/starwars-api/internal/service"
+)
+
+func Test_TranslationService_TranslatePlanets(t *testing.T) {
+	var cases = map[string]struct {
+		mocking         func(db sqlmock.Sqlmock)
+		inputLocale     string
+		expectedPlanets []*model.Planet
+		expectedErr     error
+	}{
+		"should translate planets and films": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT `planets_translations`.\\* FROM `planets_translations` WHERE \\(locale = \\?\\) AND \\(`planet_id` IN \\(\\?,\\?\\)\\);").
+					WithArgs(i18n.LOCALE_PT_BR, 1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"planet_id", "locale", "name"}).AddRow(1, i18n.LOCALE_PT_BR, "Tatuíne"))
+				db.ExpectQuery("SELECT `films_translations`.\\* FROM `films_translations` WHERE \\(locale = \\?\\) AND \\(`film_id` IN \\(\\?\\)\\);").
+					WithArgs(i18n.LOCALE_PT_BR, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"film_id", "locale", "title"}).AddRow(1, i18n.LOCALE_PT_BR, "Uma Nova Esperança"))
+			},
+			inputLocale: i18n.LOCALE_PT_BR,
+			expectedPlanets: []*model.Planet{
+				newPlanetWithFilms(1, "Tatuíne", []*model.Film{{ID: 1, Title: "Uma Nova Esperança"}}),
+				{ID: 2, Name: "Alderaan"},
+			},
+		},
+		"should keep planets of default locale": {
+			mocking:     func(db sqlmock.Sqlmock) {},
+			inputLocale: i18n.LOCALE_EN,
+			expectedPlanets: []*model.Planet{
+				newPlanetWithFilms(1, "Tatooine", []*model.Film{{ID: 1, Title: "A New Hope"}}),
+				{ID: 2, Name: "Alderaan"},
+			},
+		},
+		"should throw error when planets translations all": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error"))
+			},
+			inputLocale: i18n.LOCALE_PT_BR,
+			expectedPlanets: []*model.Planet{
+				newPlanetWithFilms(1, "Tatooine", []*model.Film{{ID: 1, Title: "A New Hope"}}),
+				{ID: 2, Name: "Alderaan"},
+			},
+			expectedErr: fmt.Errorf("models: failed to assign all query results to PlanetsTranslation slice: bind failed to execute query: error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			translationService := &service.ITranslationService{DB: db}
+			planets := []*model.Planet{
+				newPlanetWithFilms(1, "Tatooine", []*model.Film{{ID: 1, Title: "A New Hope"}}),
+				{ID: 2, Name: "Alderaan"},
+			}
+
+			cs.mocking(mockDB)
+
+			// when
+			err = translationService.TranslatePlanets(context.Background(), cs.inputLocale, planets)
+
+			// then
+			assert.Equal(t, cs.expectedPlanets, planets)
+			if cs.expectedErr != nil {
+				assert.EqualError(t, err, cs.expectedErr.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Nil(t, mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_TranslationService_SavePlanetTranslation(t *testing.T) {
+	createdAt := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	var cases = map[string]struct {
+		mocking     func(db sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		"should create planet translation": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT COUNT\\(\\*\\) FROM `planets` WHERE \\(id = \\?\\) AND \\(deleted_at IS NULL\\) LIMIT 1;").
+					WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				db.ExpectQuery("SELECT `planets_translations`.\\* FROM `planets_translations` WHERE \\(planet_id = \\?\\) AND \\(locale = \\?\\) LIMIT 1;").
+					WithArgs(1, i18n.LOCALE_PT_BR).WillReturnRows(sqlmock.NewRows([]string{"planet_id"}))
+				db.ExpectExec("INSERT INTO planets_translations \\(planet_id, locale, created_at, updated_at, name\\) VALUES \\(\\?, \\?, \\?, \\?, \\?\\) ON DUPLICATE KEY UPDATE updated_at = VALUES\\(updated_at\\), name = VALUES\\(name\\);").
+					WithArgs(1, i18n.LOCALE_PT_BR, sqlmock.AnyArg(), sqlmock.AnyArg(), "Tatuíne").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("INSERT INTO audit_log ").
+					WithArgs(sqlmock.AnyArg(), "system", sqlmock.AnyArg(), service.AUDIT_ENTITY_PLANET, 1, service.AUDIT_ACTION_TRANSLATE, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectCommit()
+			},
+		},
+		"should replace planet translation keeping created at": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT COUNT\\(\\*\\) FROM `planets`").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				db.ExpectQuery("SELECT `planets_translations`.\\*").
+					WithArgs(1, i18n.LOCALE_PT_BR).
+					WillReturnRows(sqlmock.NewRows([]string{"planet_id", "locale", "created_at", "updated_at", "name"}).
+						AddRow(1, i18n.LOCALE_PT_BR, createdAt, createdAt, "Tatuine"))
+				db.ExpectExec("INSERT INTO planets_translations").
+					WithArgs(1, i18n.LOCALE_PT_BR, "2022-10-01 12:00:00", sqlmock.AnyArg(), "Tatuíne").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				db.ExpectExec("INSERT INTO audit_log ").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectCommit()
+			},
+		},
+		"should throw not found exception when planet does not exist": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT COUNT\\(\\*\\) FROM `planets`").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				db.ExpectRollback()
+			},
+			expectedErr: &exception.NotFoundException{Message: "planet 1 not found"},
+		},
+		"should throw error when exec": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT COUNT\\(\\*\\) FROM `planets`").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				db.ExpectQuery("SELECT `planets_translations`.\\*").WillReturnRows(sqlmock.NewRows([]string{"planet_id"}))
+				db.ExpectExec("INSERT INTO planets_translations").WillReturnError(fmt.Errorf("error"))
+				db.ExpectRollback()
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			translationService := &service.ITranslationService{DB: db}
+
+			cs.mocking(mockDB)
+
+			// when
+			err = translationService.SavePlanetTranslation(context.Background(), 1, i18n.LOCALE_PT_BR, "Tatuíne")
+
+			// then
+			if cs.expectedErr != nil {
+				assert.EqualError(t, err, cs.expectedErr.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Nil(t, mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_TranslationService_DeletePlanetTranslation(t *testing.T) {
+	var cases = map[string]struct {
+		mocking     func(db sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		"should delete planet translation": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT `planets_translations`.\\* FROM `planets_translations` WHERE \\(planet_id = \\?\\) AND \\(locale = \\?\\) LIMIT 1;").
+					WithArgs(1, i18n.LOCALE_PT_BR).
+					WillReturnRows(sqlmock.NewRows([]string{"planet_id", "locale", "name"}).AddRow(1, i18n.LOCALE_PT_BR, "Tatuíne"))
+				db.ExpectExec("DELETE FROM `planets_translations` WHERE \\(planet_id = \\?\\) AND \\(locale = \\?\\);").
+					WithArgs(1, i18n.LOCALE_PT_BR).WillReturnResult(sqlmock.NewResult(0, 1))
+				db.ExpectExec("INSERT INTO audit_log ").
+					WithArgs(sqlmock.AnyArg(), "system", sqlmock.AnyArg(), service.AUDIT_ENTITY_PLANET, 1, service.AUDIT_ACTION_TRANSLATE, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectCommit()
+			},
+		},
+		"should do nothing when translation does not exist": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT `planets_translations`.\\*").WillReturnRows(sqlmock.NewRows([]string{"planet_id"}))
+				db.ExpectRollback()
+			},
+		},
+		"should throw error when delete all": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT `planets_translations`.\\*").
+					WillReturnRows(sqlmock.NewRows([]string{"planet_id", "locale", "name"}).AddRow(1, i18n.LOCALE_PT_BR, "Tatuíne"))
+				db.ExpectExec("DELETE FROM `planets_translations`").WillReturnError(fmt.Errorf("error"))
+				db.ExpectRollback()
+			},
+			expectedErr: fmt.Errorf("models: unable to delete all from planets_translations: error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			translationService := &service.ITranslationService{DB: db}
+
+			cs.mocking(mockDB)
+
+			// when
+			err = translationService.DeletePlanetTranslation(context.Background(), 1, i18n.LOCALE_PT_BR)
+
+			// then
+			if cs.expectedErr != nil {
+				assert.EqualError(t, err, cs.expectedErr.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Nil(t, mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_TranslationService_SaveFilmTranslation(t *testing.T) {
+	var cases = map[string]struct {
+		mocking     func(db sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		"should create film translation": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT COUNT\\(\\*\\) FROM `films` WHERE \\(id = \\?\\) LIMIT 1;").
+					WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				db.ExpectQuery("SELECT `films_translations`.\\* FROM `films_translations` WHERE \\(film_id = \\?\\) AND \\(locale = \\?\\) LIMIT 1;").
+					WithArgs(1, i18n.LOCALE_PT_BR).WillReturnRows(sqlmock.NewRows([]string{"film_id"}))
+				db.ExpectExec("INSERT INTO films_translations \\(film_id, locale, created_at, updated_at, title\\) VALUES \\(\\?, \\?, \\?, \\?, \\?\\) ON DUPLICATE KEY UPDATE updated_at = VALUES\\(updated_at\\), title = VALUES\\(title\\);").
+					WithArgs(1, i18n.LOCALE_PT_BR, sqlmock.AnyArg(), sqlmock.AnyArg(), "Uma Nova Esperança").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("INSERT INTO audit_log ").
+					WithArgs(sqlmock.AnyArg(), "system", sqlmock.AnyArg(), service.AUDIT_ENTITY_FILM, 1, service.AUDIT_ACTION_TRANSLATE, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectCommit()
+			},
+		},
+		"should throw not found exception when film does not exist": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT COUNT\\(\\*\\) FROM `films`").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				db.ExpectRollback()
+			},
+			expectedErr: &exception.NotFoundException{Message: "film 1 not found"},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			translationService := &service.ITranslationService{DB: db}
+
+			cs.mocking(mockDB)
+
+			// when
+			err = translationService.SaveFilmTranslation(context.Background(), 1, i18n.LOCALE_PT_BR, "Uma Nova Esperança")
+
+			// then
+			if cs.expectedErr != nil {
+				assert.EqualError(t, err, cs.expectedErr.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Nil(t, mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_TranslationService_DeleteFilmTranslation(t *testing.T) {
+	// given
+	db, mockDB, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	translationService := &service.ITranslationService{DB: db}
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectQuery("SELECT `films_translations`.\\*").
+		WithArgs(1, i18n.LOCALE_PT_BR).
+		WillReturnRows(sqlmock.NewRows([]string{"film_id", "locale", "title"}).AddRow(1, i18n.LOCALE_PT_BR, "Uma Nova Esperança"))
+	mockDB.ExpectExec("DELETE FROM `films_translations`").WithArgs(1, i18n.LOCALE_PT_BR).WillReturnResult(sqlmock.NewResult(0, 1))
+	mockDB.ExpectExec("INSERT INTO audit_log ").WillReturnResult(sqlmock.NewResult(1, 1))
+	mockDB.ExpectCommit()
+
+	// when
+	err = translationService.DeleteFilmTranslation(context.Background(), 1, i18n.LOCALE_PT_BR)
+
+	// then
+	assert.Nil(t, err)
+	assert.Nil(t, mockDB.ExpectationsWereMet())
+}
+
+func newPlanetWithFilms(id int, name string, films []*model.Film) *model.Planet {
+	planet := &model.Planet{ID: id, Name: name}
+	planet.R = planet.R.NewStruct()
+	planet.R.Films = films
+
+	return planet
+}
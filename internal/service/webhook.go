@@ -0,0 +1,319 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/exception"
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+const (
+	DELIVERY_STATUS_PENDING   = "pending"
+	DELIVERY_STATUS_DELIVERED = "delivered"
+	DELIVERY_STATUS_DEAD      = "dead"
+)
+
+//go:generate mockgen -destination=../../mock/webhook_service_mock.go -package=mock . WebhookService
+type WebhookService interface {
+	EventPublisher
+	CreateWebhook(ctx context.Context, webhook *model.Webhook) error
+	FindWebhooks(ctx context.Context) ([]*model.Webhook, error)
+	FindWebhookByID(ctx context.Context, webhookID int) (*model.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhook *model.Webhook) error
+	DeleteWebhook(ctx context.Context, webhookID int) error
+	ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*model.WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *model.WebhookDelivery) error
+	FindDeliveriesAndTotal(ctx context.Context, page, size int, opts ...Option) (dto.FindWebhookDeliveriesAndTotalResult, error)
+	RetryDelivery(ctx context.Context, deliveryID int64) error
+}
+
+type IWebhookService struct {
+	DB *sql.DB
+}
+
+func (impl *IWebhookService) CreateWebhook(ctx context.Context, webhook *model.Webhook) error {
+	now := time.Now().UTC()
+	webhook.CreatedAt = now
+	webhook.UpdatedAt = now
+
+	if err := webhook.Insert(ctx, impl.DB, boil.Greylist(model.WebhookColumns.Active)); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.webhook.create_webhook:webhook.insert"}).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (impl *IWebhookService) FindWebhooks(ctx context.Context) ([]*model.Webhook, error) {
+	webhooks, err := model.Webhooks(qm.OrderBy(model.WebhookColumns.ID)).All(ctx, impl.DB)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.webhook.find_webhooks:webhooks.all"}).Error(err)
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func (impl *IWebhookService) FindWebhookByID(ctx context.Context, webhookID int) (*model.Webhook, error) {
+	webhook, err := model.FindWebhook(ctx, impl.DB, webhookID)
+	if err == sql.ErrNoRows {
+		return nil, &exception.NotFoundException{
+			Message: fmt.Sprintf("webhook %d not found", webhookID),
+		}
+	}
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.webhook.find_webhook_by_id:find_webhook"}).Error(err)
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (impl *IWebhookService) UpdateWebhook(ctx context.Context, webhook *model.Webhook) error {
+	webhook.UpdatedAt = time.Now().UTC()
+
+	rows, err := webhook.Update(ctx, impl.DB, boil.Blacklist(model.WebhookColumns.CreatedAt))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.webhook.update_webhook:webhook.update"}).Error(err)
+		return err
+	}
+	if rows == 0 {
+		return &exception.NotFoundException{
+			Message: fmt.Sprintf("webhook %d not found", webhook.ID),
+		}
+	}
+
+	return nil
+}
+
+func (impl *IWebhookService) DeleteWebhook(ctx context.Context, webhookID int) error {
+	rows, err := model.Webhooks(qm.Where(fmt.Sprintf("%s = ?", model.WebhookColumns.ID), webhookID)).DeleteAll(ctx, impl.DB)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.webhook.delete_webhook:webhooks.delete_all"}).Error(err)
+		return err
+	}
+	if rows == 0 {
+		return &exception.NotFoundException{
+			Message: fmt.Sprintf("webhook %d not found", webhookID),
+		}
+	}
+
+	return nil
+}
+
+// Publish queues a delivery of each event to every active webhook subscribed
+// to it, webhooks without events being subscribed to all of them. An event
+// published again, as when a later sink of the relay fails, is queued once
+func (impl *IWebhookService) Publish(ctx context.Context, events ...dto.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	webhooks, err := model.Webhooks(qm.Where(fmt.Sprintf("%s = ?", model.WebhookColumns.Active), true)).All(ctx, impl.DB)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.webhook.publish:webhooks.all"}).Error(err)
+		return err
+	}
+
+	now := time.Now()
+	values := []string{}
+	args := []interface{}{}
+	for _, w := range webhooks {
+		var subscribed []string
+		json.Unmarshal(w.Events, &subscribed)
+
+		for _, e := range events {
+			if !isSubscribed(subscribed, e.Type) {
+				continue
+			}
+
+			payload, err := json.Marshal(e)
+			if err != nil {
+				return err
+			}
+
+			values = append(values, "(?, ?, ?, ?, ?, ?, ?, ?, ?)")
+			args = append(args,
+				now.UTC().Format("2006-01-02 15:04:05"),
+				now.UTC().Format("2006-01-02 15:04:05"),
+				w.ID,
+				e.ID,
+				e.Type,
+				string(payload),
+				DELIVERY_STATUS_PENDING,
+				0,
+				now.UTC().Format("2006-01-02 15:04:05"),
+			)
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	columns := []string{
+		model.WebhookDeliveryColumns.CreatedAt,
+		model.WebhookDeliveryColumns.UpdatedAt,
+		model.WebhookDeliveryColumns.WebhookID,
+		model.WebhookDeliveryColumns.EventID,
+		model.WebhookDeliveryColumns.Event,
+		model.WebhookDeliveryColumns.Payload,
+		model.WebhookDeliveryColumns.Status,
+		model.WebhookDeliveryColumns.Attempts,
+		model.WebhookDeliveryColumns.NextAttemptAt,
+	}
+	query := BuildInsertQuery(model.TableNames.WebhookDeliveries, columns, values, false)
+
+	if _, err := impl.DB.ExecContext(ctx, query, args...); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.webhook.publish:db.exec_context"}).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// ClaimDueDeliveries locks the pending deliveries whose next attempt is due,
+// skipping those locked by other instances, and postpones them by lease so
+// they are not sent twice while being delivered
+func (impl *IWebhookService) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*model.WebhookDelivery, error) {
+	tx, err := impl.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.webhook.claim_due_deliveries:db.begin_tx"}).Error(err)
+		return nil, err
+	}
+
+	deliveries, err := model.WebhookDeliveries(
+		qm.Where(fmt.Sprintf("%s = ?", model.WebhookDeliveryColumns.Status), DELIVERY_STATUS_PENDING),
+		qm.Where(fmt.Sprintf("%s <= ?", model.WebhookDeliveryColumns.NextAttemptAt), now),
+		qm.OrderBy(model.WebhookDeliveryColumns.ID),
+		qm.Limit(limit),
+		qm.Load(model.WebhookDeliveryRels.Webhook),
+		qm.For("UPDATE SKIP LOCKED"),
+	).All(ctx, tx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.webhook.claim_due_deliveries:webhook_deliveries.all"}).Error(err)
+		rollback(tx, "internal.service.webhook.claim_due_deliveries:tx.rollback")
+		return nil, err
+	}
+
+	if len(deliveries) > 0 {
+		_, err = deliveries.UpdateAll(ctx, tx, model.M{model.WebhookDeliveryColumns.NextAttemptAt: now.Add(lease)})
+		if err != nil {
+			logrus.WithFields(logrus.Fields{"trace": "internal.service.webhook.claim_due_deliveries:webhook_deliveries.update_all"}).Error(err)
+			rollback(tx, "internal.service.webhook.claim_due_deliveries:tx.rollback")
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.webhook.claim_due_deliveries:tx.commit"}).Error(err)
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (impl *IWebhookService) UpdateDelivery(ctx context.Context, delivery *model.WebhookDelivery) error {
+	delivery.UpdatedAt = time.Now().UTC()
+
+	_, err := delivery.Update(ctx, impl.DB, boil.Whitelist(
+		model.WebhookDeliveryColumns.UpdatedAt,
+		model.WebhookDeliveryColumns.Status,
+		model.WebhookDeliveryColumns.Attempts,
+		model.WebhookDeliveryColumns.NextAttemptAt,
+		model.WebhookDeliveryColumns.LastError,
+		model.WebhookDeliveryColumns.DeliveredAt,
+	))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.webhook.update_delivery:delivery.update"}).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (impl *IWebhookService) FindDeliveriesAndTotal(ctx context.Context, page, size int, opts ...Option) (dto.FindWebhookDeliveriesAndTotalResult, error) {
+	offset := 0
+	if page > 1 {
+		offset = size * (page - 1)
+	}
+
+	wheres := GetOptionsWhere(opts)
+	qms := append([]qm.QueryMod{
+		qm.Limit(size + 1),
+		qm.Offset(offset),
+		qm.OrderBy(fmt.Sprintf("%s DESC", model.WebhookDeliveryColumns.ID)),
+	}, wheres...)
+
+	deliveries, err := model.WebhookDeliveries(qms...).All(ctx, impl.DB)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.webhook.find_deliveries_and_total:webhook_deliveries.all"}).Error(err)
+		return dto.FindWebhookDeliveriesAndTotalResult{}, err
+	}
+
+	total, err := model.WebhookDeliveries(wheres...).Count(ctx, impl.DB)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.webhook.find_deliveries_and_total:webhook_deliveries.count"}).Error(err)
+		return dto.FindWebhookDeliveriesAndTotalResult{}, err
+	}
+
+	data := deliveries
+	next := false
+	if len(deliveries) > size {
+		next = true
+		data = deliveries[:size]
+	}
+
+	return dto.FindWebhookDeliveriesAndTotalResult{
+		Total: total,
+		Count: len(data),
+		Next:  next,
+		Data:  data,
+	}, nil
+}
+
+// RetryDelivery moves a dead delivery back to the queue with its attempts reset
+func (impl *IWebhookService) RetryDelivery(ctx context.Context, deliveryID int64) error {
+	now := time.Now().UTC()
+
+	rows, err := model.WebhookDeliveries(
+		qm.Where(fmt.Sprintf("%s = ?", model.WebhookDeliveryColumns.ID), deliveryID),
+		qm.Where(fmt.Sprintf("%s = ?", model.WebhookDeliveryColumns.Status), DELIVERY_STATUS_DEAD),
+	).UpdateAll(ctx, impl.DB, model.M{
+		model.WebhookDeliveryColumns.UpdatedAt:     now,
+		model.WebhookDeliveryColumns.Status:        DELIVERY_STATUS_PENDING,
+		model.WebhookDeliveryColumns.Attempts:      0,
+		model.WebhookDeliveryColumns.NextAttemptAt: now,
+	})
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"trace": "internal.service.webhook.retry_delivery:webhook_deliveries.update_all"}).Error(err)
+		return err
+	}
+	if rows == 0 {
+		return &exception.NotFoundException{
+			Message: fmt.Sprintf("dead delivery %d not found", deliveryID),
+		}
+	}
+
+	return nil
+}
+
+func isSubscribed(subscribed []string, eventType string) bool {
+	if len(subscribed) == 0 {
+		return true
+	}
+	for _, s := range subscribed {
+		if s == eventType {
+			return true
+		}
+	}
+
+	return false
+}
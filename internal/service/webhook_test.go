@@ -0,0 +1,298 @@
+package service_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/dto"
+	"github.com/viniosilva/starwars-api/internal/exception"
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/viniosilva/starwars-api/internal/service"
+)
+
+func Test_WebhookService_CreateWebhook(t *testing.T) {
+	var cases = map[string]struct {
+		mocking      func(db sqlmock.Sqlmock)
+		inputWebhook *model.Webhook
+		expectedErr  error
+	}{
+		"should create inactive webhook": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectExec("INSERT INTO `webhooks` \\(`created_at`,`updated_at`,`url`,`secret`,`events`,`active`\\) VALUES").
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), "https://example.com", "secret", []byte(`[]`), false).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			inputWebhook: &model.Webhook{URL: "https://example.com", Secret: "secret", Events: []byte(`[]`)},
+		},
+		"should throw error when insert": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectExec("INSERT INTO `webhooks`").WillReturnError(fmt.Errorf("error"))
+			},
+			inputWebhook: &model.Webhook{URL: "https://example.com", Secret: "secret", Events: []byte(`[]`)},
+			expectedErr:  fmt.Errorf("models: unable to insert into webhooks: error"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			webhookService := service.IWebhookService{DB: db}
+
+			cs.mocking(mockDB)
+
+			// when
+			err = webhookService.CreateWebhook(context.Background(), cs.inputWebhook)
+
+			// then
+			if cs.expectedErr != nil {
+				assert.EqualError(t, err, cs.expectedErr.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, time.UTC, cs.inputWebhook.CreatedAt.Location())
+			}
+		})
+	}
+}
+
+func Test_WebhookService_FindWebhookByID(t *testing.T) {
+	var cases = map[string]struct {
+		mocking         func(db sqlmock.Sqlmock)
+		inputWebhookID  int
+		expectedWebhook *model.Webhook
+		expectedErr     error
+	}{
+		"should return webhook": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("select \\* from `webhooks` where `id`=\\?").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{model.WebhookColumns.ID, model.WebhookColumns.URL, model.WebhookColumns.Events}).
+						AddRow(1, "https://example.com", []byte(`[]`)))
+			},
+			inputWebhookID:  1,
+			expectedWebhook: &model.Webhook{ID: 1, URL: "https://example.com", Events: []byte(`[]`)},
+		},
+		"should throw not found exception": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("select").WillReturnRows(sqlmock.NewRows([]string{model.WebhookColumns.ID}))
+			},
+			inputWebhookID: 1,
+			expectedErr:    &exception.NotFoundException{Message: "webhook 1 not found"},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			webhookService := service.IWebhookService{DB: db}
+
+			cs.mocking(mockDB)
+
+			// when
+			webhook, err := webhookService.FindWebhookByID(context.Background(), cs.inputWebhookID)
+
+			// then
+			assert.Equal(t, cs.expectedWebhook, webhook)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func Test_WebhookService_Publish(t *testing.T) {
+	webhookColumns := []string{model.WebhookColumns.ID, model.WebhookColumns.Events, model.WebhookColumns.Active}
+	event := dto.Event{ID: "event-id", Type: service.EVENT_PLANET_DELETED, Entity: "planet", EntityID: 1, Data: []byte(`{}`)}
+
+	var cases = map[string]struct {
+		mocking     func(db sqlmock.Sqlmock)
+		inputEvents []dto.Event
+		expectedErr error
+	}{
+		"should queue deliveries to subscribed webhooks": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT `webhooks`.\\* FROM `webhooks` WHERE \\(active = \\?\\)").WithArgs(true).
+					WillReturnRows(sqlmock.NewRows(webhookColumns).
+						AddRow(1, []byte(`[]`), true).
+						AddRow(2, []byte(`["planet.deleted"]`), true).
+						AddRow(3, []byte(`["film.linked"]`), true))
+				db.ExpectExec("INSERT IGNORE INTO webhook_deliveries").
+					WithArgs(
+						utcNow{}, utcNow{}, 1, "event-id", service.EVENT_PLANET_DELETED, sqlmock.AnyArg(), service.DELIVERY_STATUS_PENDING, 0, utcNow{},
+						utcNow{}, utcNow{}, 2, "event-id", service.EVENT_PLANET_DELETED, sqlmock.AnyArg(), service.DELIVERY_STATUS_PENDING, 0, utcNow{},
+					).
+					WillReturnResult(sqlmock.NewResult(1, 2))
+			},
+			inputEvents: []dto.Event{event},
+		},
+		"should not queue deliveries without subscribed webhooks": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows(webhookColumns).AddRow(3, []byte(`["film.linked"]`), true))
+			},
+			inputEvents: []dto.Event{event},
+		},
+		"should not select webhooks without events": {
+			mocking: func(db sqlmock.Sqlmock) {},
+		},
+		"should throw error when insert": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows(webhookColumns).AddRow(1, []byte(`[]`), true))
+				db.ExpectExec("INSERT").WillReturnError(fmt.Errorf("error"))
+			},
+			inputEvents: []dto.Event{event},
+			expectedErr: fmt.Errorf("error"),
+		},
+	}
+	local := time.Local
+	time.Local = time.FixedZone("UTC-3", -3*60*60)
+	defer func() { time.Local = local }()
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			webhookService := service.IWebhookService{DB: db}
+
+			cs.mocking(mockDB)
+
+			// when
+			err = webhookService.Publish(context.Background(), cs.inputEvents...)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Nil(t, mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+// utcNow matches a timestamp formatted for the database from the current
+// time in UTC, whatever the local time zone
+type utcNow struct{}
+
+func (utcNow) Match(v driver.Value) bool {
+	raw, ok := v.(string)
+	if !ok {
+		return false
+	}
+	at, err := time.Parse("2006-01-02 15:04:05", raw)
+	if err != nil {
+		return false
+	}
+
+	return time.Since(at).Abs() < time.Minute
+}
+
+func Test_WebhookService_ClaimDueDeliveries(t *testing.T) {
+	now := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	var cases = map[string]struct {
+		mocking       func(db sqlmock.Sqlmock)
+		expectedCount int
+		expectedErr   error
+	}{
+		"should claim due deliveries": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT `webhook_deliveries`.\\* FROM `webhook_deliveries` WHERE \\(status = \\?\\) AND \\(next_attempt_at <= \\?\\) ORDER BY id LIMIT 10 FOR UPDATE SKIP LOCKED").
+					WithArgs(service.DELIVERY_STATUS_PENDING, now).
+					WillReturnRows(sqlmock.NewRows([]string{model.WebhookDeliveryColumns.ID, model.WebhookDeliveryColumns.WebhookID}).AddRow(1, 1))
+				db.ExpectQuery("SELECT \\* FROM `webhooks` WHERE \\(`webhooks`.`id` IN \\(\\?\\)\\)").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{model.WebhookColumns.ID}).AddRow(1))
+				db.ExpectExec("UPDATE `webhook_deliveries` SET `next_attempt_at`=\\? WHERE \\(`id`=\\?\\)").
+					WithArgs(now.Add(time.Minute), 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				db.ExpectCommit()
+			},
+			expectedCount: 1,
+		},
+		"should not update without due deliveries": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{model.WebhookDeliveryColumns.ID}))
+				db.ExpectCommit()
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			webhookService := service.IWebhookService{DB: db}
+
+			cs.mocking(mockDB)
+
+			// when
+			deliveries, err := webhookService.ClaimDueDeliveries(context.Background(), now, time.Minute, 10)
+
+			// then
+			assert.Len(t, deliveries, cs.expectedCount)
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Nil(t, mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_WebhookService_RetryDelivery(t *testing.T) {
+	var cases = map[string]struct {
+		mocking         func(db sqlmock.Sqlmock)
+		inputDeliveryID int64
+		expectedErr     error
+	}{
+		"should retry dead delivery": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectExec("UPDATE `webhook_deliveries` SET .* WHERE \\(id = \\?\\) AND \\(status = \\?\\)").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			inputDeliveryID: 1,
+		},
+		"should throw not found exception": {
+			mocking: func(db sqlmock.Sqlmock) {
+				db.ExpectExec("UPDATE").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			inputDeliveryID: 1,
+			expectedErr:     &exception.NotFoundException{Message: "dead delivery 1 not found"},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			webhookService := service.IWebhookService{DB: db}
+
+			cs.mocking(mockDB)
+
+			// when
+			err = webhookService.RetryDelivery(context.Background(), cs.inputDeliveryID)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Nil(t, mockDB.ExpectationsWereMet())
+		})
+	}
+}
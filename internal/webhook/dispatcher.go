@@ -0,0 +1,233 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/viniosilva/starwars-api/internal/config"
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/viniosilva/starwars-api/internal/service"
+	"github.com/volatiletech/null/v8"
+)
+
+const (
+	HEADER_EVENT     = "X-Webhook-Event"
+	HEADER_EVENT_ID  = "X-Webhook-Event-Id"
+	HEADER_DELIVERY  = "X-Webhook-Delivery"
+	HEADER_TIMESTAMP = "X-Webhook-Timestamp"
+	HEADER_SIGNATURE = "X-Webhook-Signature"
+
+	MAX_ERROR_LENGTH = 1000
+
+	MIN_LEASE = time.Minute
+)
+
+type IDispatcher struct {
+	WebhookService service.WebhookService
+	Client         *http.Client
+	Interval       time.Duration
+	BatchSize      int
+	MaxAttempts    int
+	Backoff        time.Duration
+	MaxBackoff     time.Duration
+	Lease          time.Duration
+}
+
+func NewDispatcher(webhookService service.WebhookService, c config.WebhooksConfig) *IDispatcher {
+	timeout := time.Duration(c.TimeoutSeconds) * time.Second
+
+	lease := 2 * timeout * time.Duration(c.BatchSize)
+	if lease < MIN_LEASE {
+		lease = MIN_LEASE
+	}
+
+	return &IDispatcher{
+		WebhookService: webhookService,
+		Client:         NewClient(timeout, c.AllowPrivateTargets),
+		Interval:       time.Duration(c.IntervalSeconds) * time.Second,
+		BatchSize:      c.BatchSize,
+		MaxAttempts:    c.MaxAttempts,
+		Backoff:        time.Duration(c.BackoffSeconds) * time.Second,
+		MaxBackoff:     time.Duration(c.MaxBackoffSeconds) * time.Second,
+		Lease:          lease,
+	}
+}
+
+// NewClient returns the http client of the deliveries. It does not follow
+// redirects and, unless allowPrivate, refuses to connect to loopback, private,
+// shared (carrier-grade NAT), link-local and unspecified addresses, checked
+// after the name resolution
+func NewClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivate {
+		dialer.Control = controlPublicAddress
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// blockedNetworks are the reserved ranges net.IP has no predicate for: the
+// shared address space of carrier-grade NAT and "this network"
+var blockedNetworks = parseNetworks("100.64.0.0/10", "0.0.0.0/8")
+
+func parseNetworks(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks[i] = network
+	}
+
+	return networks
+}
+
+func controlPublicAddress(network, address string, conn syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return fmt.Errorf("address %s is not allowed", host)
+	}
+	for _, blocked := range blockedNetworks {
+		if blocked.Contains(ip) {
+			return fmt.Errorf("address %s is not allowed", host)
+		}
+	}
+
+	return nil
+}
+
+// Run dispatches the due deliveries every interval until ctx is done
+func (impl *IDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(impl.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := impl.Dispatch(ctx, now); err != nil {
+				logrus.WithFields(logrus.Fields{"trace": "internal.webhook.dispatcher.run:dispatch"}).Error(err)
+			}
+		}
+	}
+}
+
+// Dispatch sends the deliveries due at now, rescheduling the failed ones with
+// an exponential backoff until they are dead after MaxAttempts
+func (impl *IDispatcher) Dispatch(ctx context.Context, now time.Time) error {
+	deliveries, err := impl.WebhookService.ClaimDueDeliveries(ctx, now, impl.Lease, impl.BatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range deliveries {
+		d.Attempts += 1
+
+		if err := impl.send(ctx, d, time.Now()); err != nil {
+			msg := err.Error()
+			if len(msg) > MAX_ERROR_LENGTH {
+				msg = msg[:MAX_ERROR_LENGTH]
+			}
+			d.LastError = null.StringFrom(msg)
+
+			if d.Attempts >= impl.MaxAttempts {
+				d.Status = service.DELIVERY_STATUS_DEAD
+			} else {
+				d.NextAttemptAt = time.Now().Add(impl.backoff(d.Attempts))
+			}
+		} else {
+			d.Status = service.DELIVERY_STATUS_DELIVERED
+			d.DeliveredAt = null.TimeFrom(time.Now())
+			d.LastError = null.String{}
+		}
+
+		if err := impl.WebhookService.UpdateDelivery(ctx, d); err != nil {
+			logrus.WithFields(logrus.Fields{"trace": "internal.webhook.dispatcher.dispatch:update_delivery"}).Error(err)
+		}
+	}
+
+	return nil
+}
+
+func (impl *IDispatcher) send(ctx context.Context, delivery *model.WebhookDelivery, now time.Time) error {
+	if delivery.R == nil || delivery.R.Webhook == nil {
+		return fmt.Errorf("webhook %d not found", delivery.WebhookID)
+	}
+	webhook := delivery.R.Webhook
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return err
+	}
+
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HEADER_EVENT, delivery.Event)
+	req.Header.Set(HEADER_EVENT_ID, delivery.EventID)
+	req.Header.Set(HEADER_DELIVERY, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(HEADER_TIMESTAMP, timestamp)
+	req.Header.Set(HEADER_SIGNATURE, Sign(webhook.Secret, timestamp, delivery.Payload))
+
+	res, err := impl.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %d", res.StatusCode)
+	}
+
+	return nil
+}
+
+func (impl *IDispatcher) backoff(attempts int) time.Duration {
+	backoff := impl.Backoff
+	for i := 1; i < attempts; i += 1 {
+		backoff *= 2
+		if impl.MaxBackoff > 0 && backoff >= impl.MaxBackoff {
+			return impl.MaxBackoff
+		}
+	}
+
+	return backoff
+}
+
+// Sign returns the signature of a delivery: the hex HMAC-SHA256 of
+// "timestamp.body" keyed by the webhook secret
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,226 @@
+package webhook_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/starwars-api/internal/config"
+	"github.com/viniosilva/starwars-api/internal/model"
+	"github.com/viniosilva/starwars-api/internal/service"
+	"github.com/viniosilva/starwars-api/internal/webhook"
+	"github.com/viniosilva/starwars-api/mock"
+	"github.com/volatiletech/null/v8"
+)
+
+func Test_Dispatcher_Dispatch(t *testing.T) {
+	now := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	payload := []byte(`{"id":"event-id","type":"planet.deleted"}`)
+
+	var cases = map[string]struct {
+		inputStatus       int
+		inputAttempts     int
+		expectedStatus    string
+		expectedAttempts  int
+		expectedLastError null.String
+		expectedDelivered bool
+	}{
+		"should deliver signed event": {
+			inputStatus:       http.StatusOK,
+			expectedStatus:    service.DELIVERY_STATUS_DELIVERED,
+			expectedAttempts:  1,
+			expectedDelivered: true,
+		},
+		"should reschedule failed delivery": {
+			inputStatus:       http.StatusInternalServerError,
+			inputAttempts:     1,
+			expectedStatus:    service.DELIVERY_STATUS_PENDING,
+			expectedAttempts:  2,
+			expectedLastError: null.StringFrom("unexpected status 500"),
+		},
+		"should move delivery to dead after max attempts": {
+			inputStatus:       http.StatusInternalServerError,
+			inputAttempts:     2,
+			expectedStatus:    service.DELIVERY_STATUS_DEAD,
+			expectedAttempts:  3,
+			expectedLastError: null.StringFrom("unexpected status 500"),
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				timestamp := r.Header.Get(webhook.HEADER_TIMESTAMP)
+
+				assert.Equal(t, payload, body)
+				assert.Equal(t, "planet.deleted", r.Header.Get(webhook.HEADER_EVENT))
+				assert.Equal(t, "event-id", r.Header.Get(webhook.HEADER_EVENT_ID))
+				assert.Equal(t, "1", r.Header.Get(webhook.HEADER_DELIVERY))
+				assert.Equal(t, webhook.Sign("secret", timestamp, body), r.Header.Get(webhook.HEADER_SIGNATURE))
+
+				w.WriteHeader(cs.inputStatus)
+			}))
+			defer server.Close()
+
+			delivery := &model.WebhookDelivery{
+				ID:        1,
+				WebhookID: 1,
+				EventID:   "event-id",
+				Event:     "planet.deleted",
+				Payload:   payload,
+				Status:    service.DELIVERY_STATUS_PENDING,
+				Attempts:  cs.inputAttempts,
+			}
+			delivery.R = delivery.R.NewStruct()
+			delivery.R.Webhook = &model.Webhook{ID: 1, URL: server.URL, Secret: "secret"}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockWebhookService := mock.NewMockWebhookService(ctrl)
+			mockWebhookService.EXPECT().ClaimDueDeliveries(gomock.Any(), now, time.Minute, 10).
+				Return([]*model.WebhookDelivery{delivery}, nil)
+			mockWebhookService.EXPECT().UpdateDelivery(gomock.Any(), delivery).Return(nil)
+
+			dispatcher := &webhook.IDispatcher{
+				WebhookService: mockWebhookService,
+				Client:         server.Client(),
+				BatchSize:      10,
+				MaxAttempts:    3,
+				Backoff:        time.Second,
+				MaxBackoff:     time.Minute,
+				Lease:          time.Minute,
+			}
+
+			// when
+			err := dispatcher.Dispatch(context.Background(), now)
+
+			// then
+			assert.Nil(t, err)
+			assert.Equal(t, cs.expectedStatus, delivery.Status)
+			assert.Equal(t, cs.expectedAttempts, delivery.Attempts)
+			assert.Equal(t, cs.expectedLastError, delivery.LastError)
+			assert.Equal(t, cs.expectedDelivered, delivery.DeliveredAt.Valid)
+		})
+	}
+}
+
+func Test_NewDispatcher(t *testing.T) {
+	var cases = map[string]struct {
+		inputConfig   config.WebhooksConfig
+		expectedLease time.Duration
+	}{
+		"should lease twice the timeout of the batch": {
+			inputConfig:   config.WebhooksConfig{BatchSize: 50, TimeoutSeconds: 10},
+			expectedLease: 1000 * time.Second,
+		},
+		"should keep the minimum lease when timeout is zero": {
+			inputConfig:   config.WebhooksConfig{BatchSize: 50},
+			expectedLease: webhook.MIN_LEASE,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// when
+			dispatcher := webhook.NewDispatcher(nil, cs.inputConfig)
+
+			// then
+			assert.Equal(t, cs.expectedLease, dispatcher.Lease)
+		})
+	}
+}
+
+func Test_NewClient(t *testing.T) {
+	var cases = map[string]struct {
+		inputAllowPrivate bool
+		inputUrl          string
+		inputPath         string
+		expectedStatus    int
+		expectedErr       string
+	}{
+		"should refuse loopback address": {
+			inputPath:   "/",
+			expectedErr: "address 127.0.0.1 is not allowed",
+		},
+		"should refuse private address": {
+			inputUrl:    "http://10.0.0.1:8080",
+			inputPath:   "/",
+			expectedErr: "address 10.0.0.1 is not allowed",
+		},
+		"should refuse shared address space": {
+			inputUrl:    "http://100.64.0.1:8080",
+			inputPath:   "/",
+			expectedErr: "address 100.64.0.1 is not allowed",
+		},
+		"should refuse the end of shared address space": {
+			inputUrl:    "http://100.127.255.254:8080",
+			inputPath:   "/",
+			expectedErr: "address 100.127.255.254 is not allowed",
+		},
+		"should refuse this network address": {
+			inputUrl:    "http://0.1.2.3:8080",
+			inputPath:   "/",
+			expectedErr: "address 0.1.2.3 is not allowed",
+		},
+		"should refuse link-local address": {
+			inputUrl:    "http://169.254.169.254:8080",
+			inputPath:   "/",
+			expectedErr: "address 169.254.169.254 is not allowed",
+		},
+		"should connect to loopback address when private targets are allowed": {
+			inputAllowPrivate: true,
+			inputPath:         "/",
+			expectedStatus:    http.StatusOK,
+		},
+		"should not follow redirects": {
+			inputAllowPrivate: true,
+			inputPath:         "/redirect",
+			expectedStatus:    http.StatusFound,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/redirect" {
+					http.Redirect(w, r, "/", http.StatusFound)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			client := webhook.NewClient(time.Second, cs.inputAllowPrivate)
+			url := server.URL
+			if cs.inputUrl != "" {
+				url = cs.inputUrl
+			}
+
+			// when
+			res, err := client.Post(url+cs.inputPath, "application/json", nil)
+
+			// then
+			if cs.expectedErr != "" {
+				assert.ErrorContains(t, err, cs.expectedErr)
+				return
+			}
+			defer res.Body.Close()
+			assert.Nil(t, err)
+			assert.Equal(t, cs.expectedStatus, res.StatusCode)
+		})
+	}
+}
+
+func Test_Sign(t *testing.T) {
+	// when
+	signature := webhook.Sign("secret", "1664625600", []byte(`{}`))
+
+	// then
+	assert.Equal(t, "sha256=32197eef12d8dab5ed4dd596d117f97e8dd825017a91b513a42d6d5e5b916236", signature)
+}
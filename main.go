@@ -1,12 +1,16 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"flag"
 	"fmt"
 	"io"
+	"net"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"gopkg.in/natefinch/lumberjack.v2"
@@ -16,16 +20,29 @@ import (
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"github.com/viniosilva/starwars-api/docs"
+	"github.com/viniosilva/starwars-api/internal/auth"
 	"github.com/viniosilva/starwars-api/internal/config"
 	"github.com/viniosilva/starwars-api/internal/controller"
+	"github.com/viniosilva/starwars-api/internal/export"
+	"github.com/viniosilva/starwars-api/internal/i18n"
+	"github.com/viniosilva/starwars-api/internal/outbox"
+	"github.com/viniosilva/starwars-api/internal/pb"
+	"github.com/viniosilva/starwars-api/internal/pubsub"
+	"github.com/viniosilva/starwars-api/internal/ratelimit"
 	"github.com/viniosilva/starwars-api/internal/request"
+	"github.com/viniosilva/starwars-api/internal/rpc"
+	"github.com/viniosilva/starwars-api/internal/scheduler"
 	"github.com/viniosilva/starwars-api/internal/script"
 	"github.com/viniosilva/starwars-api/internal/service"
+	"github.com/viniosilva/starwars-api/internal/webhook"
+	"google.golang.org/grpc"
 )
 
 const (
 	LOGS_PATH         = "log/logrus.log"
 	ARG_FEED_DATABASE = "feed_database"
+	ARG_EXPORT        = "export"
+	ARG_IMPORT        = "import"
 )
 
 func main() {
@@ -38,8 +55,12 @@ func main() {
 		MaxSize:  50, // megabytes
 	}))
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
 	c := config.LoadConfig()
 	host := fmt.Sprintf("%s:%s", c.Server.Host, c.Server.Port)
+	grpcHost := fmt.Sprintf("%s:%s", c.GRPC.Host, c.GRPC.Port)
 
 	db_conn_string := fmt.Sprintf("%s:%s@(%s:%s)/%s?parseTime=true",
 		c.MySQL.Username, c.MySQL.Password, c.MySQL.Host, c.MySQL.Port, c.MySQL.Database)
@@ -48,17 +69,100 @@ func main() {
 		panic(err)
 	}
 
+	authenticator, err := auth.NewAuthenticator(c.Auth)
+	if err != nil {
+		panic(err)
+	}
+	anonymousRole, ok := auth.ParseRole(c.Auth.AnonymousRole)
+	if !ok && c.Auth.AnonymousRole != "" {
+		panic(fmt.Errorf("invalid anonymous role %s", c.Auth.AnonymousRole))
+	}
+
+	var v1Sunset time.Time
+	if c.Api.V1Sunset != "" {
+		v1Sunset, err = time.Parse("2006-01-02", c.Api.V1Sunset)
+		if err != nil {
+			panic(fmt.Errorf("invalid api v1 sunset %s", c.Api.V1Sunset))
+		}
+	}
+
+	var rateLimitStore ratelimit.Store
+	if c.RateLimit.Enabled {
+		rateLimitStore, err = ratelimit.NewStore(c.RateLimit)
+		if err != nil {
+			panic(err)
+		}
+	}
+
 	healthService := &service.IHealthService{DB: db}
+	webhookService := &service.IWebhookService{DB: db}
+	searchService := &service.ISearchService{DB: db}
+	statsService := &service.IStatsService{DB: db, TTL: time.Duration(c.Stats.CacheSeconds) * time.Second}
+	lookupService := &service.ILookupService{DB: db}
+	outboxService := &service.IOutboxService{DB: db}
 	filmService := &service.IFilmService{DB: db}
 	planetService := &service.IPlanetService{DB: db}
+	importService := &service.IImportService{DB: db, PlanetService: planetService, FilmService: filmService}
+	auditService := &service.IAuditService{DB: db}
+	translationService := &service.ITranslationService{DB: db}
+	lockService := &service.ILockService{DB: db}
+	var feedCheckpointService service.FeedCheckpointService
+	if c.Feed.Checkpoint {
+		feedCheckpointService = &service.IFeedCheckpointService{DB: db}
+	}
 
 	if len(os.Args) > 1 && os.Args[1] == ARG_FEED_DATABASE {
-		go runScript(filmService, planetService)
+		go runScript(os.Args[2:], c.Feed, scheduler.SyncLockName(c.Sync), lockService, filmService, planetService, feedCheckpointService)
+	} else if len(os.Args) > 1 && os.Args[1] == ARG_EXPORT {
+		go runExport(os.Args[2:], filmService, planetService)
+	} else if len(os.Args) > 1 && os.Args[1] == ARG_IMPORT {
+		go runImport(os.Args[2:], importService)
 	} else {
-		go runApi(host, healthService, planetService)
+		hub := pubsub.NewHub(c.Stream.BufferSize)
+
+		sinks := []service.EventPublisher{}
+		if c.Webhooks.Enabled {
+			dispatcher := webhook.NewDispatcher(webhookService, c.Webhooks)
+			go dispatcher.Run(ctx)
+			sinks = append(sinks, webhookService)
+		}
+		if c.Outbox.Enabled {
+			sink, err := outbox.NewSink(c.Outbox, outbox.NewMemoryBroker())
+			if err != nil {
+				panic(err)
+			}
+			if sink != nil {
+				sinks = append(sinks, sink)
+			}
+		}
+		sinks = append(sinks, statsService)
+
+		relay := outbox.NewRelay(outboxService, &outbox.IFanoutSink{Sinks: sinks}, c.Outbox)
+		go relay.Run(ctx)
+
+		follower := outbox.NewFollower(outboxService, hub, c.Stream)
+		go follower.Run(ctx)
+
+		feedDatabase := &script.IFeedDatabaseScript{
+			Swapi:             &request.ISwapiRequest{},
+			FilmService:       filmService,
+			PlanetService:     planetService,
+			CheckpointService: feedCheckpointService,
+			OnInvalid:         c.Feed.OnInvalid,
+			Upsert:            true,
+		}
+		syncJob, err := scheduler.NewSyncJob(feedDatabase, lockService, c.Sync)
+		if err != nil {
+			panic(err)
+		}
+		go syncJob.Run(ctx)
+
+		go runApi(host, c.Server.TrustedProxies, v1Sunset, authenticator, anonymousRole, rateLimitStore, ratelimit.NewRules(c.RateLimit), healthService, filmService, planetService, importService, auditService, webhookService, searchService, statsService, lookupService, translationService, syncJob, hub, time.Duration(c.Stream.KeepAliveSeconds)*time.Second)
+		go runGrpc(grpcHost, authenticator, anonymousRole, filmService, planetService)
 	}
 
 	<-gracefulShutdown
+	cancel()
 	if err := db.Close(); err != nil {
 		logrus.WithField("trace", "main").Error(err)
 	}
@@ -66,15 +170,105 @@ func main() {
 	logrus.WithField("trace", "main").Info("shutdown")
 }
 
-func runScript(filmService service.FilmService, planetService service.PlanetService) {
+func runScript(args []string, c config.FeedConfig, lockName string, lockService service.LockService, filmService service.FilmService, planetService service.PlanetService, checkpointService service.FeedCheckpointService) {
+	flags := flag.NewFlagSet(ARG_FEED_DATABASE, flag.ExitOnError)
+	dryRun := flags.Bool("dry-run", false, "only report what would change, without writing")
+	format := flags.String("format", script.FEED_REPORT_TEXT, "report format: text or json")
+	output := flags.String("output", "", "report file, defaults to the standard output")
+	onInvalid := flags.String("on-invalid", c.OnInvalid, "what to do with invalid records: abort or skip")
+	restart := flags.Bool("restart", false, "discard the saved checkpoint and start over")
+	upsert := flags.Bool("upsert", false, "update existing films and planets instead of ignoring them")
+	flags.Parse(args)
+
 	swapi := &request.ISwapiRequest{}
 	feedDatabase := &script.IFeedDatabaseScript{
-		Swapi:         swapi,
+		Swapi:             swapi,
+		FilmService:       filmService,
+		PlanetService:     planetService,
+		CheckpointService: checkpointService,
+		Restart:           *restart,
+		OnInvalid:         *onInvalid,
+		Upsert:            *upsert,
+		DryRun:            *dryRun,
+		ReportFormat:      *format,
+		Report:            os.Stdout,
+	}
+
+	var report *os.File
+	if *output != "" {
+		var err error
+		if report, err = os.Create(*output); err != nil {
+			panic(err)
+		}
+		feedDatabase.Report = report
+	}
+
+	acquired, err := lockService.WithLock(context.Background(), lockName, func(ctx context.Context) error {
+		return feedDatabase.Execute(ctx)
+	})
+	if err != nil {
+		panic(err)
+	}
+	if !acquired {
+		fmt.Fprintf(os.Stderr, "another feed or sync is already running, lock %s is held\n", lockName)
+		os.Exit(1)
+	}
+	if report != nil {
+		if err := report.Close(); err != nil {
+			panic(err)
+		}
+	}
+	os.Exit(1)
+}
+
+func runExport(args []string, filmService service.FilmService, planetService service.PlanetService) {
+	flags := flag.NewFlagSet(ARG_EXPORT, flag.ExitOnError)
+	format := flags.String("format", string(export.FormatCSV), "csv, ndjson or parquet")
+	dir := flags.String("dir", "export", "output directory")
+	name := flags.String("name", "", "planet name filter")
+	flags.Parse(args)
+
+	opts := []service.Option{}
+	if *name != "" {
+		opts = append(opts, service.OptionWhere("name like ?", *name))
+	}
+
+	exportScript := &script.IExportScript{
 		FilmService:   filmService,
 		PlanetService: planetService,
+		Format:        export.Format(*format),
+		Dir:           *dir,
+		Options:       opts,
+	}
+
+	if err := exportScript.Execute(); err != nil {
+		panic(err)
+	}
+	os.Exit(1)
+}
+
+func runImport(args []string, importService service.ImportService) {
+	flags := flag.NewFlagSet(ARG_IMPORT, flag.ExitOnError)
+	films := flags.String("films", "", "films .csv or .ndjson file")
+	planets := flags.String("planets", "", "planets .csv or .ndjson file")
+	dryRun := flags.Bool("dry-run", false, "only validate the rows")
+	upsert := flags.Bool("upsert", false, "update existing rows instead of ignoring them")
+	flags.Parse(args)
+
+	opts := []service.Option{}
+	if *upsert {
+		opts = append(opts, service.OptionUpsert())
+	}
+
+	importScript := &script.IImportScript{
+		ImportService: importService,
+		FilmsPath:     *films,
+		PlanetsPath:   *planets,
+		DryRun:        *dryRun,
+		Options:       opts,
 	}
 
-	if err := feedDatabase.Execute(); err != nil {
+	if err := importScript.Execute(); err != nil {
 		panic(err)
 	}
 	os.Exit(1)
@@ -83,20 +277,81 @@ func runScript(filmService service.FilmService, planetService service.PlanetServ
 // @title		Star Wars API
 // @version		1.0
 // @BasePath	/api
-func runApi(host string, healthService service.HealthService, planetService service.PlanetService) {
+// @securityDefinitions.apikey	ApiKeyAuth
+// @in							header
+// @name						X-Api-Key
+// @securityDefinitions.apikey	BearerAuth
+// @in							header
+// @name						Authorization
+func runApi(host string, trustedProxies []string, v1Sunset time.Time, authenticator auth.Authenticator, anonymousRole auth.Role, rateLimitStore ratelimit.Store, rateLimitRules ratelimit.Rules, healthService service.HealthService, filmService service.FilmService, planetService service.PlanetService, importService service.ImportService, auditService service.AuditService, webhookService service.WebhookService, searchService service.SearchService, statsService service.StatsService, lookupService service.LookupService, translationService service.TranslationService, syncJob scheduler.SyncJob, hub pubsub.Hub, keepAlive time.Duration) {
 	r := gin.Default()
+	if err := r.SetTrustedProxies(trustedProxies); err != nil {
+		panic(err)
+	}
+	trustedProxy, err := config.GinTrustedProxies(trustedProxies)
+	if err != nil {
+		panic(err)
+	}
+	r.Use(trustedProxy)
+	r.Use(config.GinRequestID())
+	r.Use(i18n.GinLocale())
 	r.Use(config.GinLogger())
+	if rateLimitStore != nil {
+		r.Use(ratelimit.GinRateLimit(rateLimitStore, rateLimitRules))
+	}
+	r.Use(auth.GinAuth(authenticator, anonymousRole))
 
 	router := r.Group("/api")
+	routerV2 := r.Group("/api/v2")
 
 	healthController := &controller.IHealthController{HealthService: healthService}
 	planetController := &controller.IPlanetController{
-		Host:          fmt.Sprintf("http://%s/api/planets", host),
-		PlanetService: planetService,
+		PlanetService:      planetService,
+		ImportService:      importService,
+		TranslationService: translationService,
+		Deprecation:        config.GinDeprecation("/api/v2/planets", v1Sunset),
+		LinkFilms:          true,
+	}
+	auditController := &controller.IAuditController{AuditService: auditService}
+	webhookController := &controller.IWebhookController{WebhookService: webhookService}
+	eventController := &controller.IEventController{
+		Hub:       hub,
+		KeepAlive: keepAlive,
+	}
+	searchController := &controller.ISearchController{SearchService: searchService, LinkFilms: true}
+	statsController := &controller.IStatsController{StatsService: statsService}
+	lookupController := &controller.ILookupController{LookupService: lookupService}
+	translationController := &controller.ITranslationController{TranslationService: translationService}
+	syncController := &controller.ISyncController{SyncJob: syncJob}
+	planetFilmController := &controller.IPlanetFilmController{
+		PlanetService:      planetService,
+		FilmService:        filmService,
+		TranslationService: translationService,
+	}
+	planetV2Controller := &controller.IPlanetV2Controller{
+		PlanetService:      planetService,
+		TranslationService: translationService,
+	}
+	graphqlController := &controller.IGraphQLController{
+		PlanetService:      planetService,
+		FilmService:        filmService,
+		LookupService:      lookupService,
+		TranslationService: translationService,
 	}
 
 	healthController.Configure(router)
 	planetController.Configure(router)
+	graphqlController.Configure(router)
+	auditController.Configure(router)
+	webhookController.Configure(router)
+	eventController.Configure(router)
+	searchController.Configure(router)
+	statsController.Configure(router)
+	lookupController.Configure(router)
+	translationController.Configure(router)
+	planetFilmController.Configure(router)
+	syncController.Configure(router)
+	planetV2Controller.Configure(routerV2)
 
 	docs.SwaggerInfo.Host = host
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
@@ -104,3 +359,25 @@ func runApi(host string, healthService service.HealthService, planetService serv
 	logrus.WithFields(logrus.Fields{"trace": "main"}).Infof("listening on %s", host)
 	r.Run(host)
 }
+
+func runGrpc(host string, authenticator auth.Authenticator, anonymousRole auth.Role, filmService service.FilmService, planetService service.PlanetService) {
+	lis, err := net.Listen("tcp", host)
+	if err != nil {
+		panic(err)
+	}
+
+	s := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		config.GrpcRequestID(),
+		config.GrpcLogger(),
+		auth.GrpcAuth(authenticator, anonymousRole, map[string]auth.Role{
+			pb.PlanetService_DeletePlanet_FullMethodName: auth.RoleAdmin,
+		}),
+	))
+	pb.RegisterPlanetServiceServer(s, &rpc.IPlanetServer{PlanetService: planetService, FilmService: filmService})
+	pb.RegisterFilmServiceServer(s, &rpc.IFilmServer{PlanetService: planetService, FilmService: filmService})
+
+	logrus.WithFields(logrus.Fields{"trace": "main"}).Infof("grpc listening on %s", host)
+	if err := s.Serve(lis); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/starwars-api/internal/service (interfaces: AuditService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/viniosilva/starwars-api/internal/dto"
+	service "github.com/viniosilva/starwars-api/internal/service"
+)
+
+// MockAuditService is a mock of AuditService interface.
+type MockAuditService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditServiceMockRecorder
+}
+
+// MockAuditServiceMockRecorder is the mock recorder for MockAuditService.
+type MockAuditServiceMockRecorder struct {
+	mock *MockAuditService
+}
+
+// NewMockAuditService creates a new mock instance.
+func NewMockAuditService(ctrl *gomock.Controller) *MockAuditService {
+	mock := &MockAuditService{ctrl: ctrl}
+	mock.recorder = &MockAuditServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditService) EXPECT() *MockAuditServiceMockRecorder {
+	return m.recorder
+}
+
+// FindAuditLogsAndTotal mocks base method.
+func (m *MockAuditService) FindAuditLogsAndTotal(arg0 context.Context, arg1, arg2 int, arg3 ...service.Option) (dto.FindAuditLogsAndTotalResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FindAuditLogsAndTotal", varargs...)
+	ret0, _ := ret[0].(dto.FindAuditLogsAndTotalResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAuditLogsAndTotal indicates an expected call of FindAuditLogsAndTotal.
+func (mr *MockAuditServiceMockRecorder) FindAuditLogsAndTotal(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAuditLogsAndTotal", reflect.TypeOf((*MockAuditService)(nil).FindAuditLogsAndTotal), varargs...)
+}
@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/starwars-api/internal/service (interfaces: FeedCheckpointService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/viniosilva/starwars-api/internal/dto"
+)
+
+// MockFeedCheckpointService is a mock of FeedCheckpointService interface.
+type MockFeedCheckpointService struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeedCheckpointServiceMockRecorder
+}
+
+// MockFeedCheckpointServiceMockRecorder is the mock recorder for MockFeedCheckpointService.
+type MockFeedCheckpointServiceMockRecorder struct {
+	mock *MockFeedCheckpointService
+}
+
+// NewMockFeedCheckpointService creates a new mock instance.
+func NewMockFeedCheckpointService(ctrl *gomock.Controller) *MockFeedCheckpointService {
+	mock := &MockFeedCheckpointService{ctrl: ctrl}
+	mock.recorder = &MockFeedCheckpointServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeedCheckpointService) EXPECT() *MockFeedCheckpointServiceMockRecorder {
+	return m.recorder
+}
+
+// DeleteFeedCheckpoint mocks base method.
+func (m *MockFeedCheckpointService) DeleteFeedCheckpoint(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFeedCheckpoint", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFeedCheckpoint indicates an expected call of DeleteFeedCheckpoint.
+func (mr *MockFeedCheckpointServiceMockRecorder) DeleteFeedCheckpoint(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFeedCheckpoint", reflect.TypeOf((*MockFeedCheckpointService)(nil).DeleteFeedCheckpoint), arg0, arg1)
+}
+
+// FindFeedCheckpoint mocks base method.
+func (m *MockFeedCheckpointService) FindFeedCheckpoint(arg0 context.Context, arg1 string) (*dto.FeedCheckpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindFeedCheckpoint", arg0, arg1)
+	ret0, _ := ret[0].(*dto.FeedCheckpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindFeedCheckpoint indicates an expected call of FindFeedCheckpoint.
+func (mr *MockFeedCheckpointServiceMockRecorder) FindFeedCheckpoint(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindFeedCheckpoint", reflect.TypeOf((*MockFeedCheckpointService)(nil).FindFeedCheckpoint), arg0, arg1)
+}
+
+// SaveFeedCheckpoint mocks base method.
+func (m *MockFeedCheckpointService) SaveFeedCheckpoint(arg0 context.Context, arg1 string, arg2 dto.FeedCheckpoint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveFeedCheckpoint", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveFeedCheckpoint indicates an expected call of SaveFeedCheckpoint.
+func (mr *MockFeedCheckpointServiceMockRecorder) SaveFeedCheckpoint(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveFeedCheckpoint", reflect.TypeOf((*MockFeedCheckpointService)(nil).SaveFeedCheckpoint), arg0, arg1, arg2)
+}
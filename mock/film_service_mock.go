@@ -6,10 +6,13 @@ package mock
 
 import (
 	context "context"
+	sql "database/sql"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	dto "github.com/viniosilva/starwars-api/internal/dto"
 	model "github.com/viniosilva/starwars-api/internal/model"
+	service "github.com/viniosilva/starwars-api/internal/service"
 )
 
 // MockFilmService is a mock of FilmService interface.
@@ -36,15 +39,103 @@ func (m *MockFilmService) EXPECT() *MockFilmServiceMockRecorder {
 }
 
 // CreateFilms mocks base method.
-func (m *MockFilmService) CreateFilms(arg0 context.Context, arg1 []*model.Film) error {
+func (m *MockFilmService) CreateFilms(arg0 context.Context, arg1 []*model.Film, arg2 ...service.Option) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateFilms", arg0, arg1)
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateFilms", varargs...)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateFilms indicates an expected call of CreateFilms.
-func (mr *MockFilmServiceMockRecorder) CreateFilms(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockFilmServiceMockRecorder) CreateFilms(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFilms", reflect.TypeOf((*MockFilmService)(nil).CreateFilms), arg0, arg1)
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFilms", reflect.TypeOf((*MockFilmService)(nil).CreateFilms), varargs...)
+}
+
+// CreateFilmsTx mocks base method.
+func (m *MockFilmService) CreateFilmsTx(arg0 context.Context, arg1 *sql.Tx, arg2 []*model.Film, arg3 ...service.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateFilmsTx", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateFilmsTx indicates an expected call of CreateFilmsTx.
+func (mr *MockFilmServiceMockRecorder) CreateFilmsTx(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFilmsTx", reflect.TypeOf((*MockFilmService)(nil).CreateFilmsTx), varargs...)
+}
+
+// FindFilmByID mocks base method.
+func (m *MockFilmService) FindFilmByID(arg0 context.Context, arg1 int) (*model.Film, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindFilmByID", arg0, arg1)
+	ret0, _ := ret[0].(*model.Film)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindFilmByID indicates an expected call of FindFilmByID.
+func (mr *MockFilmServiceMockRecorder) FindFilmByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindFilmByID", reflect.TypeOf((*MockFilmService)(nil).FindFilmByID), arg0, arg1)
+}
+
+// FindFilmsAndTotal mocks base method.
+func (m *MockFilmService) FindFilmsAndTotal(arg0 context.Context, arg1, arg2 int, arg3 ...service.Option) (dto.FindFilmsAndTotalResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FindFilmsAndTotal", varargs...)
+	ret0, _ := ret[0].(dto.FindFilmsAndTotalResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindFilmsAndTotal indicates an expected call of FindFilmsAndTotal.
+func (mr *MockFilmServiceMockRecorder) FindFilmsAndTotal(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindFilmsAndTotal", reflect.TypeOf((*MockFilmService)(nil).FindFilmsAndTotal), varargs...)
+}
+
+// FindFilmsByPlanetIDs mocks base method.
+func (m *MockFilmService) FindFilmsByPlanetIDs(arg0 context.Context, arg1 []int) (map[int][]*model.Film, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindFilmsByPlanetIDs", arg0, arg1)
+	ret0, _ := ret[0].(map[int][]*model.Film)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindFilmsByPlanetIDs indicates an expected call of FindFilmsByPlanetIDs.
+func (mr *MockFilmServiceMockRecorder) FindFilmsByPlanetIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindFilmsByPlanetIDs", reflect.TypeOf((*MockFilmService)(nil).FindFilmsByPlanetIDs), arg0, arg1)
+}
+
+// StreamFilms mocks base method.
+func (m *MockFilmService) StreamFilms(arg0 context.Context, arg1 func(*model.Film) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamFilms", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamFilms indicates an expected call of StreamFilms.
+func (mr *MockFilmServiceMockRecorder) StreamFilms(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamFilms", reflect.TypeOf((*MockFilmService)(nil).StreamFilms), arg0, arg1)
 }
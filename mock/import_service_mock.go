@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/starwars-api/internal/service (interfaces: ImportService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/viniosilva/starwars-api/internal/dto"
+	export "github.com/viniosilva/starwars-api/internal/export"
+	service "github.com/viniosilva/starwars-api/internal/service"
+)
+
+// MockImportService is a mock of ImportService interface.
+type MockImportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockImportServiceMockRecorder
+}
+
+// MockImportServiceMockRecorder is the mock recorder for MockImportService.
+type MockImportServiceMockRecorder struct {
+	mock *MockImportService
+}
+
+// NewMockImportService creates a new mock instance.
+func NewMockImportService(ctrl *gomock.Controller) *MockImportService {
+	mock := &MockImportService{ctrl: ctrl}
+	mock.recorder = &MockImportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImportService) EXPECT() *MockImportServiceMockRecorder {
+	return m.recorder
+}
+
+// ImportFilms mocks base method.
+func (m *MockImportService) ImportFilms(arg0 context.Context, arg1 export.Format, arg2 io.Reader, arg3 bool, arg4 ...service.Option) (dto.ImportResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2, arg3}
+	for _, a := range arg4 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ImportFilms", varargs...)
+	ret0, _ := ret[0].(dto.ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportFilms indicates an expected call of ImportFilms.
+func (mr *MockImportServiceMockRecorder) ImportFilms(arg0, arg1, arg2, arg3 interface{}, arg4 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2, arg3}, arg4...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportFilms", reflect.TypeOf((*MockImportService)(nil).ImportFilms), varargs...)
+}
+
+// ImportPlanets mocks base method.
+func (m *MockImportService) ImportPlanets(arg0 context.Context, arg1 export.Format, arg2 io.Reader, arg3 bool, arg4 ...service.Option) (dto.ImportResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2, arg3}
+	for _, a := range arg4 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ImportPlanets", varargs...)
+	ret0, _ := ret[0].(dto.ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportPlanets indicates an expected call of ImportPlanets.
+func (mr *MockImportServiceMockRecorder) ImportPlanets(arg0, arg1, arg2, arg3 interface{}, arg4 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2, arg3}, arg4...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportPlanets", reflect.TypeOf((*MockImportService)(nil).ImportPlanets), varargs...)
+}
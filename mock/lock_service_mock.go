@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/starwars-api/internal/service (interfaces: LockService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLockService is a mock of LockService interface.
+type MockLockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockLockServiceMockRecorder
+}
+
+// MockLockServiceMockRecorder is the mock recorder for MockLockService.
+type MockLockServiceMockRecorder struct {
+	mock *MockLockService
+}
+
+// NewMockLockService creates a new mock instance.
+func NewMockLockService(ctrl *gomock.Controller) *MockLockService {
+	mock := &MockLockService{ctrl: ctrl}
+	mock.recorder = &MockLockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLockService) EXPECT() *MockLockServiceMockRecorder {
+	return m.recorder
+}
+
+// WithLock mocks base method.
+func (m *MockLockService) WithLock(arg0 context.Context, arg1 string, arg2 func(context.Context) error) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithLock", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WithLock indicates an expected call of WithLock.
+func (mr *MockLockServiceMockRecorder) WithLock(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithLock", reflect.TypeOf((*MockLockService)(nil).WithLock), arg0, arg1, arg2)
+}
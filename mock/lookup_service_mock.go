@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/starwars-api/internal/service (interfaces: LookupService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/viniosilva/starwars-api/internal/dto"
+)
+
+// MockLookupService is a mock of LookupService interface.
+type MockLookupService struct {
+	ctrl     *gomock.Controller
+	recorder *MockLookupServiceMockRecorder
+}
+
+// MockLookupServiceMockRecorder is the mock recorder for MockLookupService.
+type MockLookupServiceMockRecorder struct {
+	mock *MockLookupService
+}
+
+// NewMockLookupService creates a new mock instance.
+func NewMockLookupService(ctrl *gomock.Controller) *MockLookupService {
+	mock := &MockLookupService{ctrl: ctrl}
+	mock.recorder = &MockLookupServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLookupService) EXPECT() *MockLookupServiceMockRecorder {
+	return m.recorder
+}
+
+// FindClimates mocks base method.
+func (m *MockLookupService) FindClimates(arg0 context.Context) ([]dto.LookupDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindClimates", arg0)
+	ret0, _ := ret[0].([]dto.LookupDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindClimates indicates an expected call of FindClimates.
+func (mr *MockLookupServiceMockRecorder) FindClimates(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindClimates", reflect.TypeOf((*MockLookupService)(nil).FindClimates), arg0)
+}
+
+// FindTerrains mocks base method.
+func (m *MockLookupService) FindTerrains(arg0 context.Context) ([]dto.LookupDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTerrains", arg0)
+	ret0, _ := ret[0].([]dto.LookupDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTerrains indicates an expected call of FindTerrains.
+func (mr *MockLookupServiceMockRecorder) FindTerrains(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTerrains", reflect.TypeOf((*MockLookupService)(nil).FindTerrains), arg0)
+}
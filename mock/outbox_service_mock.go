@@ -0,0 +1,98 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/starwars-api/internal/service (interfaces: OutboxService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/viniosilva/starwars-api/internal/model"
+	service "github.com/viniosilva/starwars-api/internal/service"
+)
+
+// MockOutboxService is a mock of OutboxService interface.
+type MockOutboxService struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxServiceMockRecorder
+}
+
+// MockOutboxServiceMockRecorder is the mock recorder for MockOutboxService.
+type MockOutboxServiceMockRecorder struct {
+	mock *MockOutboxService
+}
+
+// NewMockOutboxService creates a new mock instance.
+func NewMockOutboxService(ctrl *gomock.Controller) *MockOutboxService {
+	mock := &MockOutboxService{ctrl: ctrl}
+	mock.recorder = &MockOutboxServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxService) EXPECT() *MockOutboxServiceMockRecorder {
+	return m.recorder
+}
+
+// FindEventsAfter mocks base method.
+func (m *MockOutboxService) FindEventsAfter(arg0 context.Context, arg1 int64, arg2 int) ([]*model.Outbox, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEventsAfter", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.Outbox)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEventsAfter indicates an expected call of FindEventsAfter.
+func (mr *MockOutboxServiceMockRecorder) FindEventsAfter(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEventsAfter", reflect.TypeOf((*MockOutboxService)(nil).FindEventsAfter), arg0, arg1, arg2)
+}
+
+// FindLastEventID mocks base method.
+func (m *MockOutboxService) FindLastEventID(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLastEventID", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLastEventID indicates an expected call of FindLastEventID.
+func (mr *MockOutboxServiceMockRecorder) FindLastEventID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLastEventID", reflect.TypeOf((*MockOutboxService)(nil).FindLastEventID), arg0)
+}
+
+// PurgePublishedEvents mocks base method.
+func (m *MockOutboxService) PurgePublishedEvents(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgePublishedEvents", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgePublishedEvents indicates an expected call of PurgePublishedEvents.
+func (mr *MockOutboxServiceMockRecorder) PurgePublishedEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgePublishedEvents", reflect.TypeOf((*MockOutboxService)(nil).PurgePublishedEvents), arg0, arg1)
+}
+
+// RelayEvents mocks base method.
+func (m *MockOutboxService) RelayEvents(arg0 context.Context, arg1 int, arg2 service.EventPublisher) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RelayEvents", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RelayEvents indicates an expected call of RelayEvents.
+func (mr *MockOutboxServiceMockRecorder) RelayEvents(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RelayEvents", reflect.TypeOf((*MockOutboxService)(nil).RelayEvents), arg0, arg1, arg2)
+}
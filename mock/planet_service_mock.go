@@ -6,6 +6,7 @@ package mock
 
 import (
 	context "context"
+	sql "database/sql"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -37,18 +38,57 @@ func (m *MockPlanetService) EXPECT() *MockPlanetServiceMockRecorder {
 	return m.recorder
 }
 
+// BulkPlanets mocks base method.
+func (m *MockPlanetService) BulkPlanets(arg0 context.Context, arg1 []dto.PlanetBulkOperation, arg2 bool) (dto.BulkPlanetsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkPlanets", arg0, arg1, arg2)
+	ret0, _ := ret[0].(dto.BulkPlanetsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkPlanets indicates an expected call of BulkPlanets.
+func (mr *MockPlanetServiceMockRecorder) BulkPlanets(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkPlanets", reflect.TypeOf((*MockPlanetService)(nil).BulkPlanets), arg0, arg1, arg2)
+}
+
 // CreatePlanets mocks base method.
-func (m *MockPlanetService) CreatePlanets(arg0 context.Context, arg1 []*model.Planet) error {
+func (m *MockPlanetService) CreatePlanets(arg0 context.Context, arg1 []*model.Planet, arg2 ...service.Option) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreatePlanets", arg0, arg1)
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreatePlanets", varargs...)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreatePlanets indicates an expected call of CreatePlanets.
-func (mr *MockPlanetServiceMockRecorder) CreatePlanets(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockPlanetServiceMockRecorder) CreatePlanets(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePlanets", reflect.TypeOf((*MockPlanetService)(nil).CreatePlanets), varargs...)
+}
+
+// CreatePlanetsTx mocks base method.
+func (m *MockPlanetService) CreatePlanetsTx(arg0 context.Context, arg1 *sql.Tx, arg2 []*model.Planet, arg3 ...service.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreatePlanetsTx", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePlanetsTx indicates an expected call of CreatePlanetsTx.
+func (mr *MockPlanetServiceMockRecorder) CreatePlanetsTx(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePlanets", reflect.TypeOf((*MockPlanetService)(nil).CreatePlanets), arg0, arg1)
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePlanetsTx", reflect.TypeOf((*MockPlanetService)(nil).CreatePlanetsTx), varargs...)
 }
 
 // CreateRelationshipFilmsToPlanets mocks base method.
@@ -79,19 +119,53 @@ func (mr *MockPlanetServiceMockRecorder) DeletePlanet(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePlanet", reflect.TypeOf((*MockPlanetService)(nil).DeletePlanet), arg0, arg1)
 }
 
+// DeletePlanetTx mocks base method.
+func (m *MockPlanetService) DeletePlanetTx(arg0 context.Context, arg1 *sql.Tx, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePlanetTx", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePlanetTx indicates an expected call of DeletePlanetTx.
+func (mr *MockPlanetServiceMockRecorder) DeletePlanetTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePlanetTx", reflect.TypeOf((*MockPlanetService)(nil).DeletePlanetTx), arg0, arg1, arg2)
+}
+
 // FindPlanetByID mocks base method.
-func (m *MockPlanetService) FindPlanetByID(arg0 context.Context, arg1 int, arg2 bool) (*model.Planet, error) {
+func (m *MockPlanetService) FindPlanetByID(arg0 context.Context, arg1 int, arg2 bool, arg3 ...service.Option) (*model.Planet, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindPlanetByID", arg0, arg1, arg2)
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FindPlanetByID", varargs...)
 	ret0, _ := ret[0].(*model.Planet)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindPlanetByID indicates an expected call of FindPlanetByID.
-func (mr *MockPlanetServiceMockRecorder) FindPlanetByID(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockPlanetServiceMockRecorder) FindPlanetByID(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPlanetByID", reflect.TypeOf((*MockPlanetService)(nil).FindPlanetByID), varargs...)
+}
+
+// FindPlanetHistory mocks base method.
+func (m *MockPlanetService) FindPlanetHistory(arg0 context.Context, arg1 int) ([]*model.PlanetsHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPlanetHistory", arg0, arg1)
+	ret0, _ := ret[0].([]*model.PlanetsHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPlanetHistory indicates an expected call of FindPlanetHistory.
+func (mr *MockPlanetServiceMockRecorder) FindPlanetHistory(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPlanetByID", reflect.TypeOf((*MockPlanetService)(nil).FindPlanetByID), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPlanetHistory", reflect.TypeOf((*MockPlanetService)(nil).FindPlanetHistory), arg0, arg1)
 }
 
 // FindPlanetsAndTotal mocks base method.
@@ -113,3 +187,134 @@ func (mr *MockPlanetServiceMockRecorder) FindPlanetsAndTotal(arg0, arg1, arg2, a
 	varargs := append([]interface{}{arg0, arg1, arg2, arg3}, arg4...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPlanetsAndTotal", reflect.TypeOf((*MockPlanetService)(nil).FindPlanetsAndTotal), varargs...)
 }
+
+// FindPlanetsByFilmIDs mocks base method.
+func (m *MockPlanetService) FindPlanetsByFilmIDs(arg0 context.Context, arg1 []int) (map[int][]*model.Planet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPlanetsByFilmIDs", arg0, arg1)
+	ret0, _ := ret[0].(map[int][]*model.Planet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPlanetsByFilmIDs indicates an expected call of FindPlanetsByFilmIDs.
+func (mr *MockPlanetServiceMockRecorder) FindPlanetsByFilmIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPlanetsByFilmIDs", reflect.TypeOf((*MockPlanetService)(nil).FindPlanetsByFilmIDs), arg0, arg1)
+}
+
+// FindPlanetsByIDs mocks base method.
+func (m *MockPlanetService) FindPlanetsByIDs(arg0 context.Context, arg1 []int, arg2 bool, arg3 ...service.Option) (dto.FindPlanetsByIDsResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FindPlanetsByIDs", varargs...)
+	ret0, _ := ret[0].(dto.FindPlanetsByIDsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPlanetsByIDs indicates an expected call of FindPlanetsByIDs.
+func (mr *MockPlanetServiceMockRecorder) FindPlanetsByIDs(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPlanetsByIDs", reflect.TypeOf((*MockPlanetService)(nil).FindPlanetsByIDs), varargs...)
+}
+
+// LinkFilmToPlanet mocks base method.
+func (m *MockPlanetService) LinkFilmToPlanet(arg0 context.Context, arg1, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkFilmToPlanet", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkFilmToPlanet indicates an expected call of LinkFilmToPlanet.
+func (mr *MockPlanetServiceMockRecorder) LinkFilmToPlanet(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkFilmToPlanet", reflect.TypeOf((*MockPlanetService)(nil).LinkFilmToPlanet), arg0, arg1, arg2)
+}
+
+// PatchPlanetTx mocks base method.
+func (m *MockPlanetService) PatchPlanetTx(arg0 context.Context, arg1 *sql.Tx, arg2 int, arg3 dto.PlanetPatch) (*model.Planet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchPlanetTx", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.Planet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchPlanetTx indicates an expected call of PatchPlanetTx.
+func (mr *MockPlanetServiceMockRecorder) PatchPlanetTx(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchPlanetTx", reflect.TypeOf((*MockPlanetService)(nil).PatchPlanetTx), arg0, arg1, arg2, arg3)
+}
+
+// RestorePlanetTx mocks base method.
+func (m *MockPlanetService) RestorePlanetTx(arg0 context.Context, arg1 *sql.Tx, arg2 int) (*model.Planet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestorePlanetTx", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Planet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestorePlanetTx indicates an expected call of RestorePlanetTx.
+func (mr *MockPlanetServiceMockRecorder) RestorePlanetTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestorePlanetTx", reflect.TypeOf((*MockPlanetService)(nil).RestorePlanetTx), arg0, arg1, arg2)
+}
+
+// StreamPlanets mocks base method.
+func (m *MockPlanetService) StreamPlanets(arg0 context.Context, arg1 func(*model.Planet) error, arg2 ...service.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "StreamPlanets", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamPlanets indicates an expected call of StreamPlanets.
+func (mr *MockPlanetServiceMockRecorder) StreamPlanets(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamPlanets", reflect.TypeOf((*MockPlanetService)(nil).StreamPlanets), varargs...)
+}
+
+// StreamPlanetsFilms mocks base method.
+func (m *MockPlanetService) StreamPlanetsFilms(arg0 context.Context, arg1 func(int, int) error, arg2 ...service.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "StreamPlanetsFilms", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamPlanetsFilms indicates an expected call of StreamPlanetsFilms.
+func (mr *MockPlanetServiceMockRecorder) StreamPlanetsFilms(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamPlanetsFilms", reflect.TypeOf((*MockPlanetService)(nil).StreamPlanetsFilms), varargs...)
+}
+
+// UnlinkFilmFromPlanet mocks base method.
+func (m *MockPlanetService) UnlinkFilmFromPlanet(arg0 context.Context, arg1, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlinkFilmFromPlanet", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlinkFilmFromPlanet indicates an expected call of UnlinkFilmFromPlanet.
+func (mr *MockPlanetServiceMockRecorder) UnlinkFilmFromPlanet(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlinkFilmFromPlanet", reflect.TypeOf((*MockPlanetService)(nil).UnlinkFilmFromPlanet), arg0, arg1, arg2)
+}
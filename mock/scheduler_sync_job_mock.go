@@ -0,0 +1,63 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/starwars-api/internal/scheduler (interfaces: SyncJob)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/viniosilva/starwars-api/internal/dto"
+)
+
+// MockSyncJob is a mock of SyncJob interface.
+type MockSyncJob struct {
+	ctrl     *gomock.Controller
+	recorder *MockSyncJobMockRecorder
+}
+
+// MockSyncJobMockRecorder is the mock recorder for MockSyncJob.
+type MockSyncJobMockRecorder struct {
+	mock *MockSyncJob
+}
+
+// NewMockSyncJob creates a new mock instance.
+func NewMockSyncJob(ctrl *gomock.Controller) *MockSyncJob {
+	mock := &MockSyncJob{ctrl: ctrl}
+	mock.recorder = &MockSyncJobMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSyncJob) EXPECT() *MockSyncJobMockRecorder {
+	return m.recorder
+}
+
+// Status mocks base method.
+func (m *MockSyncJob) Status() dto.SyncStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status")
+	ret0, _ := ret[0].(dto.SyncStatus)
+	return ret0
+}
+
+// Status indicates an expected call of Status.
+func (mr *MockSyncJobMockRecorder) Status() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockSyncJob)(nil).Status))
+}
+
+// Trigger mocks base method.
+func (m *MockSyncJob) Trigger() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trigger")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Trigger indicates an expected call of Trigger.
+func (mr *MockSyncJobMockRecorder) Trigger() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trigger", reflect.TypeOf((*MockSyncJob)(nil).Trigger))
+}
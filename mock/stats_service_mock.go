@@ -0,0 +1,137 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/starwars-api/internal/service (interfaces: StatsService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/viniosilva/starwars-api/internal/dto"
+	service "github.com/viniosilva/starwars-api/internal/service"
+)
+
+// MockStatsService is a mock of StatsService interface.
+type MockStatsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatsServiceMockRecorder
+}
+
+// MockStatsServiceMockRecorder is the mock recorder for MockStatsService.
+type MockStatsServiceMockRecorder struct {
+	mock *MockStatsService
+}
+
+// NewMockStatsService creates a new mock instance.
+func NewMockStatsService(ctrl *gomock.Controller) *MockStatsService {
+	mock := &MockStatsService{ctrl: ctrl}
+	mock.recorder = &MockStatsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatsService) EXPECT() *MockStatsServiceMockRecorder {
+	return m.recorder
+}
+
+// CountFilmsPerPlanet mocks base method.
+func (m *MockStatsService) CountFilmsPerPlanet(arg0 context.Context, arg1 ...service.Option) ([]dto.StatsDistributionDto, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CountFilmsPerPlanet", varargs...)
+	ret0, _ := ret[0].([]dto.StatsDistributionDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountFilmsPerPlanet indicates an expected call of CountFilmsPerPlanet.
+func (mr *MockStatsServiceMockRecorder) CountFilmsPerPlanet(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountFilmsPerPlanet", reflect.TypeOf((*MockStatsService)(nil).CountFilmsPerPlanet), varargs...)
+}
+
+// CountPlanetsByClimate mocks base method.
+func (m *MockStatsService) CountPlanetsByClimate(arg0 context.Context, arg1 ...service.Option) ([]dto.StatsCountDto, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CountPlanetsByClimate", varargs...)
+	ret0, _ := ret[0].([]dto.StatsCountDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPlanetsByClimate indicates an expected call of CountPlanetsByClimate.
+func (mr *MockStatsServiceMockRecorder) CountPlanetsByClimate(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPlanetsByClimate", reflect.TypeOf((*MockStatsService)(nil).CountPlanetsByClimate), varargs...)
+}
+
+// CountPlanetsByFilm mocks base method.
+func (m *MockStatsService) CountPlanetsByFilm(arg0 context.Context, arg1 ...service.Option) ([]dto.StatsEntityCountDto, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CountPlanetsByFilm", varargs...)
+	ret0, _ := ret[0].([]dto.StatsEntityCountDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPlanetsByFilm indicates an expected call of CountPlanetsByFilm.
+func (mr *MockStatsServiceMockRecorder) CountPlanetsByFilm(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPlanetsByFilm", reflect.TypeOf((*MockStatsService)(nil).CountPlanetsByFilm), varargs...)
+}
+
+// CountPlanetsByTerrain mocks base method.
+func (m *MockStatsService) CountPlanetsByTerrain(arg0 context.Context, arg1 ...service.Option) ([]dto.StatsCountDto, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CountPlanetsByTerrain", varargs...)
+	ret0, _ := ret[0].([]dto.StatsCountDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPlanetsByTerrain indicates an expected call of CountPlanetsByTerrain.
+func (mr *MockStatsServiceMockRecorder) CountPlanetsByTerrain(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPlanetsByTerrain", reflect.TypeOf((*MockStatsService)(nil).CountPlanetsByTerrain), varargs...)
+}
+
+// FindTopPlanets mocks base method.
+func (m *MockStatsService) FindTopPlanets(arg0 context.Context, arg1 int, arg2 ...service.Option) ([]dto.StatsEntityCountDto, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FindTopPlanets", varargs...)
+	ret0, _ := ret[0].([]dto.StatsEntityCountDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTopPlanets indicates an expected call of FindTopPlanets.
+func (mr *MockStatsServiceMockRecorder) FindTopPlanets(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTopPlanets", reflect.TypeOf((*MockStatsService)(nil).FindTopPlanets), varargs...)
+}
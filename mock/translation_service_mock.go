@@ -0,0 +1,120 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/starwars-api/internal/service (interfaces: TranslationService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/viniosilva/starwars-api/internal/model"
+)
+
+// MockTranslationService is a mock of TranslationService interface.
+type MockTranslationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTranslationServiceMockRecorder
+}
+
+// MockTranslationServiceMockRecorder is the mock recorder for MockTranslationService.
+type MockTranslationServiceMockRecorder struct {
+	mock *MockTranslationService
+}
+
+// NewMockTranslationService creates a new mock instance.
+func NewMockTranslationService(ctrl *gomock.Controller) *MockTranslationService {
+	mock := &MockTranslationService{ctrl: ctrl}
+	mock.recorder = &MockTranslationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTranslationService) EXPECT() *MockTranslationServiceMockRecorder {
+	return m.recorder
+}
+
+// DeleteFilmTranslation mocks base method.
+func (m *MockTranslationService) DeleteFilmTranslation(arg0 context.Context, arg1 int, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFilmTranslation", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFilmTranslation indicates an expected call of DeleteFilmTranslation.
+func (mr *MockTranslationServiceMockRecorder) DeleteFilmTranslation(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFilmTranslation", reflect.TypeOf((*MockTranslationService)(nil).DeleteFilmTranslation), arg0, arg1, arg2)
+}
+
+// DeletePlanetTranslation mocks base method.
+func (m *MockTranslationService) DeletePlanetTranslation(arg0 context.Context, arg1 int, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePlanetTranslation", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePlanetTranslation indicates an expected call of DeletePlanetTranslation.
+func (mr *MockTranslationServiceMockRecorder) DeletePlanetTranslation(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePlanetTranslation", reflect.TypeOf((*MockTranslationService)(nil).DeletePlanetTranslation), arg0, arg1, arg2)
+}
+
+// SaveFilmTranslation mocks base method.
+func (m *MockTranslationService) SaveFilmTranslation(arg0 context.Context, arg1 int, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveFilmTranslation", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveFilmTranslation indicates an expected call of SaveFilmTranslation.
+func (mr *MockTranslationServiceMockRecorder) SaveFilmTranslation(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveFilmTranslation", reflect.TypeOf((*MockTranslationService)(nil).SaveFilmTranslation), arg0, arg1, arg2, arg3)
+}
+
+// SavePlanetTranslation mocks base method.
+func (m *MockTranslationService) SavePlanetTranslation(arg0 context.Context, arg1 int, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePlanetTranslation", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SavePlanetTranslation indicates an expected call of SavePlanetTranslation.
+func (mr *MockTranslationServiceMockRecorder) SavePlanetTranslation(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePlanetTranslation", reflect.TypeOf((*MockTranslationService)(nil).SavePlanetTranslation), arg0, arg1, arg2, arg3)
+}
+
+// TranslateFilms mocks base method.
+func (m *MockTranslationService) TranslateFilms(arg0 context.Context, arg1 string, arg2 []*model.Film) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TranslateFilms", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TranslateFilms indicates an expected call of TranslateFilms.
+func (mr *MockTranslationServiceMockRecorder) TranslateFilms(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TranslateFilms", reflect.TypeOf((*MockTranslationService)(nil).TranslateFilms), arg0, arg1, arg2)
+}
+
+// TranslatePlanets mocks base method.
+func (m *MockTranslationService) TranslatePlanets(arg0 context.Context, arg1 string, arg2 []*model.Planet) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TranslatePlanets", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TranslatePlanets indicates an expected call of TranslatePlanets.
+func (mr *MockTranslationServiceMockRecorder) TranslatePlanets(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TranslatePlanets", reflect.TypeOf((*MockTranslationService)(nil).TranslatePlanets), arg0, arg1, arg2)
+}
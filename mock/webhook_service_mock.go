@@ -0,0 +1,193 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/starwars-api/internal/service (interfaces: WebhookService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/viniosilva/starwars-api/internal/dto"
+	model "github.com/viniosilva/starwars-api/internal/model"
+	service "github.com/viniosilva/starwars-api/internal/service"
+)
+
+// MockWebhookService is a mock of WebhookService interface.
+type MockWebhookService struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookServiceMockRecorder
+}
+
+// MockWebhookServiceMockRecorder is the mock recorder for MockWebhookService.
+type MockWebhookServiceMockRecorder struct {
+	mock *MockWebhookService
+}
+
+// NewMockWebhookService creates a new mock instance.
+func NewMockWebhookService(ctrl *gomock.Controller) *MockWebhookService {
+	mock := &MockWebhookService{ctrl: ctrl}
+	mock.recorder = &MockWebhookServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookService) EXPECT() *MockWebhookServiceMockRecorder {
+	return m.recorder
+}
+
+// ClaimDueDeliveries mocks base method.
+func (m *MockWebhookService) ClaimDueDeliveries(arg0 context.Context, arg1 time.Time, arg2 time.Duration, arg3 int) ([]*model.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDueDeliveries", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDueDeliveries indicates an expected call of ClaimDueDeliveries.
+func (mr *MockWebhookServiceMockRecorder) ClaimDueDeliveries(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDueDeliveries", reflect.TypeOf((*MockWebhookService)(nil).ClaimDueDeliveries), arg0, arg1, arg2, arg3)
+}
+
+// CreateWebhook mocks base method.
+func (m *MockWebhookService) CreateWebhook(arg0 context.Context, arg1 *model.Webhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockWebhookServiceMockRecorder) CreateWebhook(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockWebhookService)(nil).CreateWebhook), arg0, arg1)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockWebhookService) DeleteWebhook(arg0 context.Context, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockWebhookServiceMockRecorder) DeleteWebhook(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockWebhookService)(nil).DeleteWebhook), arg0, arg1)
+}
+
+// FindDeliveriesAndTotal mocks base method.
+func (m *MockWebhookService) FindDeliveriesAndTotal(arg0 context.Context, arg1, arg2 int, arg3 ...service.Option) (dto.FindWebhookDeliveriesAndTotalResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FindDeliveriesAndTotal", varargs...)
+	ret0, _ := ret[0].(dto.FindWebhookDeliveriesAndTotalResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeliveriesAndTotal indicates an expected call of FindDeliveriesAndTotal.
+func (mr *MockWebhookServiceMockRecorder) FindDeliveriesAndTotal(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeliveriesAndTotal", reflect.TypeOf((*MockWebhookService)(nil).FindDeliveriesAndTotal), varargs...)
+}
+
+// FindWebhookByID mocks base method.
+func (m *MockWebhookService) FindWebhookByID(arg0 context.Context, arg1 int) (*model.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindWebhookByID", arg0, arg1)
+	ret0, _ := ret[0].(*model.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindWebhookByID indicates an expected call of FindWebhookByID.
+func (mr *MockWebhookServiceMockRecorder) FindWebhookByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindWebhookByID", reflect.TypeOf((*MockWebhookService)(nil).FindWebhookByID), arg0, arg1)
+}
+
+// FindWebhooks mocks base method.
+func (m *MockWebhookService) FindWebhooks(arg0 context.Context) ([]*model.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindWebhooks", arg0)
+	ret0, _ := ret[0].([]*model.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindWebhooks indicates an expected call of FindWebhooks.
+func (mr *MockWebhookServiceMockRecorder) FindWebhooks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindWebhooks", reflect.TypeOf((*MockWebhookService)(nil).FindWebhooks), arg0)
+}
+
+// Publish mocks base method.
+func (m *MockWebhookService) Publish(arg0 context.Context, arg1 ...dto.Event) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Publish", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockWebhookServiceMockRecorder) Publish(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockWebhookService)(nil).Publish), varargs...)
+}
+
+// RetryDelivery mocks base method.
+func (m *MockWebhookService) RetryDelivery(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryDelivery", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RetryDelivery indicates an expected call of RetryDelivery.
+func (mr *MockWebhookServiceMockRecorder) RetryDelivery(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryDelivery", reflect.TypeOf((*MockWebhookService)(nil).RetryDelivery), arg0, arg1)
+}
+
+// UpdateDelivery mocks base method.
+func (m *MockWebhookService) UpdateDelivery(arg0 context.Context, arg1 *model.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDelivery", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDelivery indicates an expected call of UpdateDelivery.
+func (mr *MockWebhookServiceMockRecorder) UpdateDelivery(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDelivery", reflect.TypeOf((*MockWebhookService)(nil).UpdateDelivery), arg0, arg1)
+}
+
+// UpdateWebhook mocks base method.
+func (m *MockWebhookService) UpdateWebhook(arg0 context.Context, arg1 *model.Webhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhook", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWebhook indicates an expected call of UpdateWebhook.
+func (mr *MockWebhookServiceMockRecorder) UpdateWebhook(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhook", reflect.TypeOf((*MockWebhookService)(nil).UpdateWebhook), arg0, arg1)
+}